@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/usememos/memos/store/archive"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the whole workspace into an archive",
+	Long: `Export writes users, settings, memos, resources, tickets and notifications of the workspace
+into a single zip archive. Resources stored in S3 or linked externally keep their references only.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		output, err := cmd.Flags().GetString("output")
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		instanceProfile := newInstanceProfile()
+		storeInstance, err := newStore(ctx, instanceProfile)
+		if err != nil {
+			return err
+		}
+		defer storeInstance.Close()
+
+		file, err := os.Create(output)
+		if err != nil {
+			return errors.Wrap(err, "failed to create archive file")
+		}
+		manifest, err := archive.NewExporter(storeInstance, instanceProfile).Export(ctx, file)
+		if err != nil {
+			file.Close()
+			os.Remove(output)
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return errors.Wrap(err, "failed to close archive file")
+		}
+
+		fmt.Printf("Exported workspace to %s\n", output)
+		printCounts(manifest)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringP("output", "o", "memos-export.zip", "path of the archive to write")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func printCounts(manifest *archive.Manifest) {
+	for _, name := range slices.Sorted(maps.Keys(manifest.Counts)) {
+		fmt.Printf("  %s: %d\n", name, manifest.Counts[name])
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/usememos/memos/store/archive"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <archive>",
+	Short: "Import a workspace archive into an empty instance",
+	Long: `Import restores an archive written by the export command. The target database is migrated
+to the current schema first and must not contain any user.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return errors.Wrap(err, "failed to open archive file")
+		}
+		defer file.Close()
+		stat, err := file.Stat()
+		if err != nil {
+			return errors.Wrap(err, "failed to stat archive file")
+		}
+
+		ctx := context.Background()
+		instanceProfile := newInstanceProfile()
+		storeInstance, err := newStore(ctx, instanceProfile)
+		if err != nil {
+			return err
+		}
+		defer storeInstance.Close()
+
+		manifest, err := archive.NewImporter(storeInstance, instanceProfile).Import(ctx, file, stat.Size())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Imported workspace from %s (version %s)\n", args[0], manifest.Version)
+		printCounts(manifest)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
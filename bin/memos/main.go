@@ -9,6 +9,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/usememos/memos/internal/version"
 	"github.com/usememos/memos/server"
 	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/backup"
 	"github.com/usememos/memos/store/db"
 )
 
@@ -35,33 +37,12 @@ var (
 		Use:   "memos",
 		Short: `An open source, lightweight note-taking service. Easily capture and share your great thoughts.`,
 		Run: func(_ *cobra.Command, _ []string) {
-			instanceProfile := &profile.Profile{
-				Mode:        viper.GetString("mode"),
-				Addr:        viper.GetString("addr"),
-				Port:        viper.GetInt("port"),
-				UNIXSock:    viper.GetString("unix-sock"),
-				Data:        viper.GetString("data"),
-				Driver:      viper.GetString("driver"),
-				DSN:         viper.GetString("dsn"),
-				InstanceURL: viper.GetString("instance-url"),
-				Version:     version.GetCurrentVersion(viper.GetString("mode")),
-			}
-			if err := instanceProfile.Validate(); err != nil {
-				panic(err)
-			}
-
+			instanceProfile := newInstanceProfile()
 			ctx, cancel := context.WithCancel(context.Background())
-			dbDriver, err := db.NewDBDriver(instanceProfile)
+			storeInstance, err := newStore(ctx, instanceProfile)
 			if err != nil {
 				cancel()
-				slog.Error("failed to create db driver", "error", err)
-				return
-			}
-
-			storeInstance := store.New(dbDriver, instanceProfile)
-			if err := storeInstance.Migrate(ctx); err != nil {
-				cancel()
-				slog.Error("failed to migrate", "error", err)
+				slog.Error("failed to open store", "error", err)
 				return
 			}
 
@@ -112,6 +93,10 @@ func init() {
 	rootCmd.PersistentFlags().String("driver", "sqlite", "database driver")
 	rootCmd.PersistentFlags().String("dsn", "", "database source name(aka. DSN)")
 	rootCmd.PersistentFlags().String("instance-url", "", "the url of your memos instance")
+	rootCmd.PersistentFlags().Duration("backup-interval", 0, "interval between sqlite snapshots, e.g. 24h, 0 disables scheduled backups")
+	rootCmd.PersistentFlags().Int("backup-keep", backup.DefaultKeep, "number of sqlite snapshots to keep")
+	rootCmd.PersistentFlags().Bool("backup-upload", false, "upload sqlite snapshots to the S3 storage of the workspace")
+	rootCmd.PersistentFlags().StringSlice("trusted-proxies", nil, "IPs or CIDRs of the reverse proxies whose X-Forwarded-For entries are trusted")
 
 	if err := viper.BindPFlag("mode", rootCmd.PersistentFlags().Lookup("mode")); err != nil {
 		panic(err)
@@ -137,12 +122,71 @@ func init() {
 	if err := viper.BindPFlag("instance-url", rootCmd.PersistentFlags().Lookup("instance-url")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("backup-interval", rootCmd.PersistentFlags().Lookup("backup-interval")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("backup-keep", rootCmd.PersistentFlags().Lookup("backup-keep")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("backup-upload", rootCmd.PersistentFlags().Lookup("backup-upload")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("trusted-proxies", rootCmd.PersistentFlags().Lookup("trusted-proxies")); err != nil {
+		panic(err)
+	}
 
 	viper.SetEnvPrefix("memos")
 	viper.AutomaticEnv()
 	if err := viper.BindEnv("instance-url", "MEMOS_INSTANCE_URL"); err != nil {
 		panic(err)
 	}
+	if err := viper.BindEnv("trusted-proxies", "MEMOS_TRUSTED_PROXIES"); err != nil {
+		panic(err)
+	}
+}
+
+// newInstanceProfile builds the instance profile from flags and environment variables.
+func newInstanceProfile() *profile.Profile {
+	instanceProfile := &profile.Profile{
+		Mode:           viper.GetString("mode"),
+		Addr:           viper.GetString("addr"),
+		Port:           viper.GetInt("port"),
+		UNIXSock:       viper.GetString("unix-sock"),
+		Data:           viper.GetString("data"),
+		Driver:         viper.GetString("driver"),
+		DSN:            viper.GetString("dsn"),
+		InstanceURL:    viper.GetString("instance-url"),
+		Version:        version.GetCurrentVersion(viper.GetString("mode")),
+		BackupInterval: viper.GetDuration("backup-interval"),
+		BackupKeep:     viper.GetInt("backup-keep"),
+		BackupUpload:   viper.GetBool("backup-upload"),
+		TrustedProxies: viper.GetStringSlice("trusted-proxies"),
+	}
+	if err := instanceProfile.Validate(); err != nil {
+		panic(err)
+	}
+	return instanceProfile
+}
+
+// openStore opens the database of the instance without migrating it.
+func openStore(instanceProfile *profile.Profile) (*store.Store, error) {
+	dbDriver, err := db.NewDBDriver(instanceProfile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create db driver")
+	}
+	return store.New(dbDriver, instanceProfile), nil
+}
+
+// newStore opens the database of the instance and migrates it to the current schema.
+func newStore(ctx context.Context, instanceProfile *profile.Profile) (*store.Store, error) {
+	storeInstance, err := openStore(instanceProfile)
+	if err != nil {
+		return nil, err
+	}
+	if err := storeInstance.Migrate(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to migrate")
+	}
+	return storeInstance, nil
 }
 
 func printGreetings(profile *profile.Profile) {
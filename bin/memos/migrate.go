@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate the database to the schema of this binary",
+	Long: `Migrate applies the pending migration scripts, the same way the server does on start.
+With --dry-run the pending statements are printed instead of being applied.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		storeInstance, err := openStore(newInstanceProfile())
+		if err != nil {
+			return err
+		}
+		defer storeInstance.Close()
+
+		migrationStatus, err := storeInstance.GetMigrationStatus(ctx)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			for _, migrationScript := range migrationStatus.Pending {
+				fmt.Printf("-- %s (%s)\n%s\n", migrationScript.Path, migrationScript.SchemaVersion, strings.TrimSpace(migrationScript.Statement))
+			}
+			fmt.Printf("-- %d pending migration(s)\n", len(migrationStatus.Pending))
+			return nil
+		}
+
+		if err := storeInstance.Migrate(ctx); err != nil {
+			return errors.Wrap(err, "failed to migrate")
+		}
+		fmt.Printf("Migrated to schema version %s, %d script(s) applied\n", migrationStatus.TargetSchemaVersion, len(migrationStatus.Pending))
+		return nil
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:          "status",
+	Short:        "Show the schema version of the database and the pending migrations",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		ctx := context.Background()
+		storeInstance, err := openStore(newInstanceProfile())
+		if err != nil {
+			return err
+		}
+		defer storeInstance.Close()
+
+		migrationStatus, err := storeInstance.GetMigrationStatus(ctx)
+		if err != nil {
+			return err
+		}
+		currentSchemaVersion := migrationStatus.CurrentSchemaVersion
+		if currentSchemaVersion == "" {
+			currentSchemaVersion = "none (new database)"
+		}
+		fmt.Printf("Current schema version: %s\n", currentSchemaVersion)
+		fmt.Printf("Target schema version: %s\n", migrationStatus.TargetSchemaVersion)
+		if len(migrationStatus.Pending) == 0 {
+			fmt.Println("The database is up to date.")
+			return nil
+		}
+		fmt.Println("Pending migrations:")
+		for _, migrationScript := range migrationStatus.Pending {
+			down := ""
+			if migrationScript.DownStatement != "" {
+				down = " (reversible)"
+			}
+			fmt.Printf("  %s %s%s\n", migrationScript.SchemaVersion, migrationScript.Path, down)
+		}
+		return nil
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Revert the migrations applied after a schema version",
+	Long: `Down runs the down scripts of the migrations applied after the --to schema version, newest first.
+It refuses to run unless every migration to revert has a down script. Take a backup first.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		targetVersion, err := cmd.Flags().GetString("to")
+		if err != nil {
+			return err
+		}
+		if targetVersion == "" {
+			return errors.New("--to is required")
+		}
+
+		ctx := context.Background()
+		storeInstance, err := openStore(newInstanceProfile())
+		if err != nil {
+			return err
+		}
+		defer storeInstance.Close()
+
+		if err := storeInstance.MigrateDown(ctx, targetVersion); err != nil {
+			return err
+		}
+		fmt.Printf("Reverted to schema version %s\n", targetVersion)
+		return nil
+	},
+}
+
+var migrateVerifyCmd = &cobra.Command{
+	Use:          "verify",
+	Short:        "Report drift between the live schema and LATEST.sql",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		ctx := context.Background()
+		storeInstance, err := openStore(newInstanceProfile())
+		if err != nil {
+			return err
+		}
+		defer storeInstance.Close()
+
+		drifts, err := storeInstance.VerifySchema(ctx)
+		if err != nil {
+			return err
+		}
+		if len(drifts) == 0 {
+			fmt.Println("No schema drift found.")
+			return nil
+		}
+		for _, drift := range drifts {
+			fmt.Printf("  %s\n", drift)
+		}
+		return errors.Errorf("found %d schema drift(s)", len(drifts))
+	},
+}
+
+func init() {
+	migrateCmd.Flags().Bool("dry-run", false, "print the pending statements without applying them")
+	migrateDownCmd.Flags().String("to", "", "schema version to revert to, e.g. 0.25.4")
+
+	migrateCmd.AddCommand(migrateStatusCmd, migrateDownCmd, migrateVerifyCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/usememos/memos/store/backup"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <snapshot>",
+	Short: "Restore the sqlite database from a snapshot",
+	Long: `Restore replaces the sqlite database with a snapshot taken by the backup subsystem.
+The snapshot is either a path or the name of a file in the backup directory. Its schema version
+must not be newer than the one of this binary. Stop the server before restoring.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		instanceProfile := newInstanceProfile()
+		if instanceProfile.Driver != "sqlite" {
+			return errors.Errorf("restore is not supported for driver %s", instanceProfile.Driver)
+		}
+
+		snapshot := args[0]
+		if _, err := os.Stat(snapshot); os.IsNotExist(err) {
+			snapshot = filepath.Join(instanceProfile.Data, backup.DirName, snapshot)
+		}
+
+		storeInstance, err := openStore(instanceProfile)
+		if err != nil {
+			return err
+		}
+		currentSchemaVersion, err := storeInstance.GetCurrentSchemaVersion()
+		if err != nil {
+			return err
+		}
+		if err := storeInstance.Close(); err != nil {
+			return errors.Wrap(err, "failed to close database")
+		}
+
+		previousPath, err := backup.Restore(ctx, instanceProfile, snapshot, currentSchemaVersion)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Restored %s from %s\n", instanceProfile.DSN, snapshot)
+		if previousPath != "" {
+			fmt.Printf("The previous database was moved to %s\n", previousPath)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+}
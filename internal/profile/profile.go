@@ -3,10 +3,12 @@ package profile
 import (
 	"fmt"
 	"log/slog"
+	"net"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -32,12 +34,58 @@ type Profile struct {
 	Version string
 	// InstanceURL is the url of your memos instance.
 	InstanceURL string
+	// BackupInterval is the interval between scheduled sqlite snapshots, 0 disables them.
+	BackupInterval time.Duration
+	// BackupKeep is the number of snapshots kept in the backup directory.
+	BackupKeep int
+	// BackupUpload uploads snapshots to the S3 storage of the workspace.
+	BackupUpload bool
+	// TrustedProxies are the IPs or CIDRs of the reverse proxies in front of the server.
+	// Only the X-Forwarded-For entries added by them are used to find the IP of the client.
+	TrustedProxies []string
 }
 
 func (p *Profile) IsDev() bool {
 	return p.Mode != "prod"
 }
 
+// GetTrustedProxyNets returns the networks of the trusted proxies, including the address the server binds to,
+// which the gRPC gateway connects from.
+func (p *Profile) GetTrustedProxyNets() ([]*net.IPNet, error) {
+	ipNets := []*net.IPNet{}
+	// The bind address may be a host name rather than an IP.
+	if ip := net.ParseIP(p.Addr); ip != nil {
+		ipNets = append(ipNets, getHostIPNet(ip))
+	}
+	for _, proxy := range strings.FieldsFunc(strings.Join(p.TrustedProxies, ","), func(r rune) bool {
+		return r == ',' || r == ' '
+	}) {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, errors.Errorf("invalid trusted proxy %q", proxy)
+			}
+			ipNets = append(ipNets, getHostIPNet(ip))
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trusted proxy %q", proxy)
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
+// getHostIPNet returns the network with the single IP.
+func getHostIPNet(ip net.IP) *net.IPNet {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	bits := 8 * len(ip)
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+}
+
 func checkDataDir(dataDir string) (string, error) {
 	// Convert to absolute path if relative path is supplied.
 	if !filepath.IsAbs(dataDir) {
@@ -83,6 +131,9 @@ func (p *Profile) Validate() error {
 	}
 
 	p.Data = dataDir
+	if _, err := p.GetTrustedProxyNets(); err != nil {
+		return err
+	}
 	if p.Driver == "sqlite" && p.DSN == "" {
 		dbFile := fmt.Sprintf("memos_%s.db", p.Mode)
 		p.DSN = filepath.Join(dataDir, dbFile)
@@ -0,0 +1,138 @@
+// Package exif edits the EXIF metadata of JPEG images.
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+const (
+	markerStartOfImage = 0xD8
+	markerStartOfScan  = 0xDA
+	markerEndOfImage   = 0xD9
+	markerAPP1         = 0xE1
+
+	tagGPSInfo = 0x8825
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// typeSizes are the sizes in bytes of the TIFF field types.
+var typeSizes = map[uint16]uint32{
+	1:  1, // BYTE
+	2:  1, // ASCII
+	3:  2, // SHORT
+	4:  4, // LONG
+	5:  8, // RATIONAL
+	6:  1, // SBYTE
+	7:  1, // UNDEFINED
+	8:  2, // SSHORT
+	9:  4, // SLONG
+	10: 8, // SRATIONAL
+	11: 4, // FLOAT
+	12: 8, // DOUBLE
+}
+
+// StripGPS erases the GPS data from the EXIF metadata of a JPEG image in place, and reports whether
+// there was any. The GPS entries and their values are zeroed and the GPS IFD is left empty, so the
+// length and the structure of the image are unchanged.
+// data may be only the beginning of the image, as long as it contains the EXIF segment, which
+// precedes the image data and fits in 64 KB.
+func StripGPS(data []byte) bool {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != markerStartOfImage {
+		return false
+	}
+	stripped := false
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return stripped
+		}
+		marker := data[i+1]
+		// Skip the fill bytes and the markers without a segment.
+		if marker == 0xFF {
+			i++
+			continue
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			i += 2
+			continue
+		}
+		if marker == markerStartOfScan || marker == markerEndOfImage {
+			return stripped
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return stripped
+		}
+		if segment := data[i+4 : end]; marker == markerAPP1 && bytes.HasPrefix(segment, exifHeader) {
+			if stripTIFFGPS(segment[len(exifHeader):]) {
+				stripped = true
+			}
+		}
+		i = end
+	}
+	return stripped
+}
+
+// stripTIFFGPS erases the GPS IFD of the TIFF structure of the EXIF metadata.
+func stripTIFFGPS(tiff []byte) bool {
+	if len(tiff) < 8 {
+		return false
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return false
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return false
+	}
+
+	gpsOffset, ok := findEntryValue(tiff, order, order.Uint32(tiff[4:]), tagGPSInfo)
+	if !ok || uint64(gpsOffset)+2 > uint64(len(tiff)) {
+		return false
+	}
+	count := uint32(order.Uint16(tiff[gpsOffset:]))
+	entries := gpsOffset + 2
+	if count == 0 || uint64(entries)+uint64(count)*12 > uint64(len(tiff)) {
+		return false
+	}
+	for j := uint32(0); j < count; j++ {
+		entry := tiff[entries+j*12 : entries+j*12+12]
+		size := uint64(typeSizes[order.Uint16(entry[2:])]) * uint64(order.Uint32(entry[4:]))
+		// The values larger than 4 bytes are stored elsewhere, at the offset in the entry.
+		if size > 4 {
+			if offset := uint64(order.Uint32(entry[8:])); offset+size <= uint64(len(tiff)) {
+				clear(tiff[offset : offset+size])
+			}
+		}
+		clear(entry)
+	}
+	// An empty IFD with no next IFD: the count is zero and the zeroed first entry reads as a zero offset.
+	order.PutUint16(tiff[gpsOffset:], 0)
+	return true
+}
+
+// findEntryValue returns the value of the entry with the tag in the IFD at the offset.
+func findEntryValue(tiff []byte, order binary.ByteOrder, offset uint32, tag uint16) (uint32, bool) {
+	if uint64(offset)+2 > uint64(len(tiff)) {
+		return 0, false
+	}
+	count := uint32(order.Uint16(tiff[offset:]))
+	for j := uint32(0); j < count; j++ {
+		start := uint64(offset) + 2 + uint64(j)*12
+		if start+12 > uint64(len(tiff)) {
+			return 0, false
+		}
+		entry := tiff[start : start+12]
+		if order.Uint16(entry) == tag {
+			return order.Uint32(entry[8:]), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,84 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// buildJPEG returns a JPEG image with an EXIF segment holding a GPS latitude and a camera model.
+func buildJPEG(t *testing.T) []byte {
+	order := binary.LittleEndian
+	tiff := []byte{'I', 'I', 42, 0, 8, 0, 0, 0}
+	// IFD0 at offset 8 with the camera model and the GPS IFD pointer.
+	tiff = order.AppendUint16(tiff, 2)
+	tiff = appendEntry(order, tiff, 0x0110, 2, 4, 0) // Model, "cam" inline.
+	copy(tiff[len(tiff)-4:], "cam\x00")
+	tiff = appendEntry(order, tiff, tagGPSInfo, 4, 1, 38)
+	tiff = order.AppendUint32(tiff, 0)
+	// GPS IFD at offset 38 with the latitude reference inline and the latitude at offset 68.
+	tiff = order.AppendUint16(tiff, 2)
+	tiff = appendEntry(order, tiff, 0x0001, 2, 2, 0)
+	copy(tiff[len(tiff)-4:], "N\x00")
+	tiff = appendEntry(order, tiff, 0x0002, 5, 3, 68)
+	tiff = order.AppendUint32(tiff, 0)
+	for _, v := range []uint32{48, 1, 51, 1, 30, 1} {
+		tiff = order.AppendUint32(tiff, v)
+	}
+	segment := append(append([]byte{}, exifHeader...), tiff...)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, jpeg.Encode(buf, image.NewGray(image.Rect(0, 0, 4, 4)), nil))
+	encoded := buf.Bytes()
+	data := []byte{0xFF, markerStartOfImage, 0xFF, markerAPP1}
+	data = binary.BigEndian.AppendUint16(data, uint16(len(segment)+2))
+	data = append(data, segment...)
+	return append(data, encoded[2:]...)
+}
+
+// exifSegment returns the EXIF segment following the start of the image.
+func exifSegment(data []byte) []byte {
+	return data[4 : 4+int(binary.BigEndian.Uint16(data[4:]))]
+}
+
+func appendEntry(order binary.AppendByteOrder, b []byte, tag, typ uint16, count, value uint32) []byte {
+	b = order.AppendUint16(b, tag)
+	b = order.AppendUint16(b, typ)
+	b = order.AppendUint32(b, count)
+	return order.AppendUint32(b, value)
+}
+
+func TestStripGPS(t *testing.T) {
+	data := buildJPEG(t)
+	length := len(data)
+	segment := exifSegment(data)
+	latitude := binary.LittleEndian.AppendUint32(nil, 48)
+	require.True(t, bytes.Contains(segment, []byte("N\x00")))
+
+	require.True(t, StripGPS(data))
+	require.Len(t, data, length)
+	require.False(t, bytes.Contains(segment, []byte("N\x00")))
+	require.False(t, bytes.Contains(segment, latitude))
+	// The other metadata and the image are kept.
+	require.True(t, bytes.Contains(segment, []byte("cam\x00")))
+	_, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	// Nothing is left to strip.
+	require.False(t, StripGPS(data))
+}
+
+func TestStripGPSPrefix(t *testing.T) {
+	data := buildJPEG(t)
+	require.True(t, StripGPS(data[:200]))
+	require.False(t, bytes.Contains(exifSegment(data), []byte("N\x00")))
+}
+
+func TestStripGPSNotJPEG(t *testing.T) {
+	require.False(t, StripGPS([]byte("\x89PNG\r\n\x1a\n")))
+	require.False(t, StripGPS(nil))
+}
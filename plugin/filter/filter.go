@@ -20,6 +20,39 @@ var MemoFilterCELAttributes = []cel.EnvOption{
 	cel.Variable("tag", cel.StringType),
 	cel.Variable("visibility", cel.StringType),
 	cel.Variable("has_task_list", cel.BoolType),
+	cel.Variable("project_id", cel.IntType),
+	// The username of a user mentioned in the memo.
+	cel.Variable("mentions", cel.StringType),
+	// The id of a ticket referenced in the memo.
+	cel.Variable("references_ticket", cel.IntType),
+	// Current timestamp function.
+	cel.Function("now",
+		cel.Overload("now",
+			[]*cel.Type{},
+			cel.IntType,
+			cel.FunctionBinding(func(_ ...ref.Val) ref.Val {
+				return types.Int(time.Now().Unix())
+			}),
+		),
+	),
+}
+
+// TicketFilterCELAttributes are the CEL attributes for ticket.
+var TicketFilterCELAttributes = []cel.EnvOption{
+	cel.Variable("title", cel.StringType),
+	cel.Variable("status", cel.StringType),
+	cel.Variable("priority", cel.StringType),
+	cel.Variable("ticket_type", cel.StringType),
+	cel.Variable("tags", cel.ListType(cel.StringType)),
+	cel.Variable("creator_id", cel.IntType),
+	// The id of the assignee, 0 for unassigned tickets.
+	cel.Variable("assignee_id", cel.IntType),
+	// The id of the project, 0 for workspace-wide tickets.
+	cel.Variable("project_id", cel.IntType),
+	cel.Variable("created_ts", cel.IntType),
+	cel.Variable("updated_ts", cel.IntType),
+	// The values of the custom fields by field key, e.g. `fields.story_points > 3`.
+	cel.Variable("fields", cel.MapType(cel.StringType, cel.DynType)),
 	// Current timestamp function.
 	cel.Function("now",
 		cel.Overload("now",
@@ -45,3 +78,41 @@ func Parse(filter string, opts ...cel.EnvOption) (expr *exprv1.ParsedExpr, err e
 	}
 	return cel.AstToParsedExpr(ast)
 }
+
+// Program is a filter compiled to be evaluated in memory, for the filters without a SQL conversion.
+type Program struct {
+	program cel.Program
+}
+
+// Compile compiles the filter string, which should be a CEL expression that evaluates to a bool.
+func Compile(filter string, opts ...cel.EnvOption) (*Program, error) {
+	e, err := cel.NewEnv(opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CEL environment")
+	}
+	ast, issues := e.Compile(filter)
+	if issues != nil {
+		return nil, errors.Errorf("failed to compile filter: %v", issues)
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, errors.Errorf("filter must evaluate to a bool, got %v", ast.OutputType())
+	}
+	program, err := e.Program(ast)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CEL program")
+	}
+	return &Program{program: program}, nil
+}
+
+// Match returns whether the filter matches the values of its attributes.
+func (p *Program) Match(values map[string]any) (bool, error) {
+	out, _, err := p.program.Eval(values)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to evaluate filter")
+	}
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, errors.Errorf("unexpected filter result %v", out.Value())
+	}
+	return matched, nil
+}
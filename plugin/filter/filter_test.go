@@ -0,0 +1,47 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileTicketFilter(t *testing.T) {
+	ticket := map[string]any{
+		"title":       "Fix login",
+		"status":      "OPEN",
+		"priority":    "HIGH",
+		"ticket_type": "BUG",
+		"tags":        []string{"auth", "web"},
+		"creator_id":  int64(1),
+		"assignee_id": int64(0),
+		"project_id":  int64(2),
+		"created_ts":  int64(1700000000),
+		"updated_ts":  int64(1700000000),
+		"fields":      map[string]any{"story_points": float64(5), "component": "auth", "owner": int64(0)},
+	}
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: `status == "OPEN" && priority == "HIGH"`, want: true},
+		{filter: `status in ["IN_PROGRESS", "CLOSED"]`, want: false},
+		{filter: `"auth" in tags && assignee_id == 0`, want: true},
+		{filter: `title.contains("login") && project_id == 2`, want: true},
+		{filter: `created_ts > now()`, want: false},
+		{filter: `fields.story_points > 3 && fields.component == "auth"`, want: true},
+		{filter: `fields.story_points >= 5.5 || fields.owner != 0`, want: false},
+	}
+	for _, test := range tests {
+		program, err := Compile(test.filter, TicketFilterCELAttributes...)
+		require.NoError(t, err, test.filter)
+		matched, err := program.Match(ticket)
+		require.NoError(t, err, test.filter)
+		require.Equal(t, test.want, matched, test.filter)
+	}
+
+	for _, filter := range []string{`status`, `unknown == 1`, `priority == 1`} {
+		_, err := Compile(filter, TicketFilterCELAttributes...)
+		require.Error(t, err, filter)
+	}
+}
@@ -1,9 +1,9 @@
 package httpgetter
 
 import (
+	"context"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"net/url"
 
@@ -12,37 +12,32 @@ import (
 	"golang.org/x/net/html/atom"
 )
 
-var ErrInternalIP = errors.New("internal IP addresses are not allowed")
-
-var httpClient = &http.Client{
-	CheckRedirect: func(req *http.Request, via []*http.Request) error {
-		if err := validateURL(req.URL.String()); err != nil {
-			return errors.Wrap(err, "redirect to internal IP")
-		}
-		if len(via) >= 10 {
-			return errors.New("too many redirects")
-		}
-		return nil
-	},
-}
+const (
+	// maxHTMLSize is the size of a page read for its metadata, which is in the head of the page.
+	maxHTMLSize = 1 << 20
+	// maxOEmbedSize is the size of an oEmbed response.
+	maxOEmbedSize = 64 << 10
+)
 
 type HTMLMeta struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Image       string `json:"image"`
-}
 
-func GetHTMLMeta(urlStr string) (*HTMLMeta, error) {
-	if err := validateURL(urlStr); err != nil {
-		return nil, err
-	}
+	// oEmbedURL is the URL of the oEmbed endpoint of the page, discovered from its head.
+	oEmbedURL string
+}
 
-	response, err := httpClient.Get(urlStr)
+func GetHTMLMeta(ctx context.Context, urlStr string) (*HTMLMeta, error) {
+	response, err := get(ctx, urlStr)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
 
+	if response.StatusCode >= http.StatusBadRequest {
+		return nil, errors.Errorf("unexpected status %d", response.StatusCode)
+	}
 	mediatype, err := getMediatype(response)
 	if err != nil {
 		return nil, err
@@ -51,9 +46,21 @@ func GetHTMLMeta(urlStr string) (*HTMLMeta, error) {
 		return nil, errors.New("not a HTML page")
 	}
 
-	// TODO: limit the size of the response body
-
-	htmlMeta := extractHTMLMeta(response.Body)
+	htmlMeta := extractHTMLMeta(io.LimitReader(response.Body, maxHTMLSize))
+	if htmlMeta.oEmbedURL != "" {
+		if oEmbedURL, err := response.Request.URL.Parse(htmlMeta.oEmbedURL); err == nil {
+			// The page is previewed without oEmbed when the endpoint fails.
+			if oEmbed, err := getOEmbed(ctx, oEmbedURL.String()); err == nil {
+				oEmbed.apply(htmlMeta)
+			}
+		}
+	}
+	if htmlMeta.Image != "" {
+		// The image may be relative to the page.
+		if imageURL, err := response.Request.URL.Parse(htmlMeta.Image); err == nil {
+			htmlMeta.Image = imageURL.String()
+		}
+	}
 	enrichSiteMeta(response.Request.URL, htmlMeta)
 	return htmlMeta, nil
 }
@@ -96,6 +103,10 @@ func extractHTMLMeta(resp io.Reader) *HTMLMeta {
 				if ok {
 					htmlMeta.Image = ogImage
 				}
+			} else if token.DataAtom == atom.Link {
+				if href, ok := extractOEmbedLink(token); ok {
+					htmlMeta.oEmbedURL = href
+				}
 			}
 		}
 	}
@@ -116,44 +127,6 @@ func extractMetaProperty(token html.Token, prop string) (content string, ok bool
 	return content, ok
 }
 
-func validateURL(urlStr string) error {
-	u, err := url.Parse(urlStr)
-	if err != nil {
-		return errors.New("invalid URL format")
-	}
-
-	if u.Scheme != "http" && u.Scheme != "https" {
-		return errors.New("only http/https protocols are allowed")
-	}
-
-	host := u.Hostname()
-	if host == "" {
-		return errors.New("empty hostname")
-	}
-
-	// check if the hostname is an IP
-	if ip := net.ParseIP(host); ip != nil {
-		if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() {
-			return errors.Wrap(ErrInternalIP, ip.String())
-		}
-		return nil
-	}
-
-	// check if it's a hostname, resolve it and check all returned IPs
-	ips, err := net.LookupIP(host)
-	if err != nil {
-		return errors.Errorf("failed to resolve hostname: %v", err)
-	}
-
-	for _, ip := range ips {
-		if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() {
-			return errors.Wrapf(ErrInternalIP, "host=%s, ip=%s", host, ip.String())
-		}
-	}
-
-	return nil
-}
-
 func enrichSiteMeta(url *url.URL, meta *HTMLMeta) {
 	if url.Hostname() == "www.youtube.com" {
 		if url.Path == "/watch" {
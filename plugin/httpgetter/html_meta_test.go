@@ -1,7 +1,13 @@
 package httpgetter
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -13,7 +19,7 @@ func TestGetHTMLMeta(t *testing.T) {
 		htmlMeta HTMLMeta
 	}{}
 	for _, test := range tests {
-		metadata, err := GetHTMLMeta(test.urlStr)
+		metadata, err := GetHTMLMeta(context.Background(), test.urlStr)
 		require.NoError(t, err)
 		require.Equal(t, test.htmlMeta, *metadata)
 	}
@@ -21,12 +27,87 @@ func TestGetHTMLMeta(t *testing.T) {
 
 func TestGetHTMLMetaForInternal(t *testing.T) {
 	// test for internal IP
-	if _, err := GetHTMLMeta("http://192.168.0.1"); !errors.Is(err, ErrInternalIP) {
+	if _, err := GetHTMLMeta(context.Background(), "http://192.168.0.1"); !errors.Is(err, ErrInternalIP) {
 		t.Errorf("Expected error for internal IP, got %v", err)
 	}
 
 	// test for resolved internal IP
-	if _, err := GetHTMLMeta("http://localhost"); !errors.Is(err, ErrInternalIP) {
+	if _, err := GetHTMLMeta(context.Background(), "http://localhost"); !errors.Is(err, ErrInternalIP) {
 		t.Errorf("Expected error for resolved internal IP, got %v", err)
 	}
 }
+
+// allowLocalServers lets the test fetch from httptest servers, which listen on the loopback address.
+func allowLocalServers(t *testing.T) {
+	original := checkIP
+	checkIP = func(net.IP) error { return nil }
+	t.Cleanup(func() { checkIP = original })
+}
+
+func TestGetHTMLMetaFromServer(t *testing.T) {
+	allowLocalServers(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<html><head>
+<meta property="og:description" content="A description">
+<meta property="og:image" content="/cover.png">
+<link rel="alternate" type="application/json+oembed" href="/oembed">
+</head><body></body></html>`)
+	})
+	mux.HandleFunc("/oembed", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"type":"video","title":"oEmbed title","thumbnail_url":"https://example.com/thumb.png"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	htmlMeta, err := GetHTMLMeta(context.Background(), server.URL+"/page")
+	require.NoError(t, err)
+	require.Equal(t, "oEmbed title", htmlMeta.Title)
+	require.Equal(t, "A description", htmlMeta.Description)
+	require.Equal(t, server.URL+"/cover.png", htmlMeta.Image)
+}
+
+func TestGetHTMLMetaFromLocalServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html><head><title>internal</title></head></html>")
+	}))
+	defer server.Close()
+
+	// The address of the server is checked when it is dialed.
+	_, err := GetHTMLMeta(context.Background(), strings.Replace(server.URL, "127.0.0.1", "localhost", 1))
+	require.ErrorIs(t, err, ErrInternalIP)
+}
+
+func TestGetHTMLMetaCanceled(t *testing.T) {
+	allowLocalServers(t)
+	requested := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		close(requested)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	// Canceling the request of the preview aborts the fetch.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-requested
+		cancel()
+	}()
+	_, err := GetHTMLMeta(ctx, server.URL)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGetImageTooLarge(t *testing.T) {
+	allowLocalServers(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(make([]byte, maxImageSize+1))
+	}))
+	defer server.Close()
+
+	_, err := GetImage(context.Background(), server.URL)
+	require.ErrorIs(t, err, ErrResponseTooLarge)
+}
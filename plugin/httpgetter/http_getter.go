@@ -2,3 +2,125 @@
 // * Get metadata for website;
 // * Get image blob to avoid CORS;
 package httpgetter
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// requestTimeout is the timeout of a request, including reading the response body.
+	requestTimeout = 10 * time.Second
+	// maxRedirects is the number of redirects followed by a request.
+	maxRedirects = 10
+)
+
+var ErrInternalIP = errors.New("internal IP addresses are not allowed")
+
+// ErrResponseTooLarge is returned when a response body is larger than allowed.
+var ErrResponseTooLarge = errors.New("response body is too large")
+
+// checkIP returns an error for the IP addresses that must not be fetched.
+// It is a variable so that tests can fetch from local test servers.
+var checkIP = func(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return errors.Wrap(ErrInternalIP, ip.String())
+	}
+	return nil
+}
+
+// dialer checks the IP address of every connection when it is dialed, after the hostname is resolved,
+// so that a hostname resolving to a public IP address at first and an internal one later is rejected too.
+var dialer = &net.Dialer{
+	Timeout: requestTimeout,
+	Control: func(_, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			return errors.Errorf("invalid IP address %q", host)
+		}
+		return checkIP(ip)
+	},
+}
+
+var httpClient = &http.Client{
+	Timeout: requestTimeout,
+	Transport: &http.Transport{
+		// Requests are never sent through a proxy, which would dial the internal IP addresses instead.
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   requestTimeout,
+		ResponseHeaderTimeout: requestTimeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       90 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if err := validateURL(req.URL.String()); err != nil {
+			return errors.Wrap(err, "invalid redirect")
+		}
+		if len(via) >= maxRedirects {
+			return errors.New("too many redirects")
+		}
+		return nil
+	},
+}
+
+// get sends a GET request to the URL, which must be a public http(s) URL.
+func get(ctx context.Context, urlStr string) (*http.Response, error) {
+	if err := validateURL(urlStr); err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("User-Agent", "Mozilla/5.0 (compatible; memos link preview)")
+	return httpClient.Do(request)
+}
+
+// readBody reads the response body, which must not be larger than maxSize.
+func readBody(response *http.Response, maxSize int64) ([]byte, error) {
+	if response.ContentLength > maxSize {
+		return nil, ErrResponseTooLarge
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxSize {
+		return nil, ErrResponseTooLarge
+	}
+	return body, nil
+}
+
+func validateURL(urlStr string) error {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return errors.New("invalid URL format")
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("only http/https protocols are allowed")
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return errors.New("empty hostname")
+	}
+
+	// The IP addresses of hostnames are checked when they are dialed.
+	if ip := net.ParseIP(host); ip != nil {
+		return checkIP(ip)
+	}
+	return nil
+}
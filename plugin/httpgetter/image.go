@@ -1,29 +1,31 @@
 package httpgetter
 
 import (
-	"errors"
-	"io"
+	"context"
 	"net/http"
-	"net/url"
 	"strings"
+
+	"github.com/pkg/errors"
 )
 
+// maxImageSize is the size of an image allowed to be fetched.
+const maxImageSize = 5 << 20
+
 type Image struct {
 	Blob      []byte
 	Mediatype string
 }
 
-func GetImage(urlStr string) (*Image, error) {
-	if _, err := url.Parse(urlStr); err != nil {
-		return nil, err
-	}
-
-	response, err := http.Get(urlStr)
+func GetImage(ctx context.Context, urlStr string) (*Image, error) {
+	response, err := get(ctx, urlStr)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
 
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %d", response.StatusCode)
+	}
 	mediatype, err := getMediatype(response)
 	if err != nil {
 		return nil, err
@@ -32,7 +34,7 @@ func GetImage(urlStr string) (*Image, error) {
 		return nil, errors.New("wrong image mediatype")
 	}
 
-	bodyBytes, err := io.ReadAll(response.Body)
+	bodyBytes, err := readBody(response, maxImageSize)
 	if err != nil {
 		return nil, err
 	}
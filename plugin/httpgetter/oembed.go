@@ -0,0 +1,77 @@
+package httpgetter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+)
+
+// OEmbed is the response of an oEmbed endpoint, see https://oembed.com.
+type OEmbed struct {
+	Type         string `json:"type"`
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+// getOEmbed gets the oEmbed response of a JSON oEmbed endpoint.
+func getOEmbed(ctx context.Context, urlStr string) (*OEmbed, error) {
+	response, err := get(ctx, urlStr)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %d", response.StatusCode)
+	}
+	body, err := readBody(response, maxOEmbedSize)
+	if err != nil {
+		return nil, err
+	}
+	oEmbed := &OEmbed{}
+	if err := json.Unmarshal(body, oEmbed); err != nil {
+		return nil, errors.Wrap(err, "invalid oEmbed response")
+	}
+	return oEmbed, nil
+}
+
+// apply fills in the metadata missing from the page with the oEmbed response.
+func (o *OEmbed) apply(htmlMeta *HTMLMeta) {
+	if htmlMeta.Title == "" {
+		htmlMeta.Title = o.Title
+	}
+	if htmlMeta.Description == "" && o.AuthorName != "" {
+		htmlMeta.Description = o.AuthorName
+		if o.ProviderName != "" {
+			htmlMeta.Description += " - " + o.ProviderName
+		}
+	}
+	if htmlMeta.Image == "" {
+		htmlMeta.Image = o.ThumbnailURL
+	}
+}
+
+// extractOEmbedLink returns the href of a <link rel="alternate" type="application/json+oembed"> token.
+func extractOEmbedLink(token html.Token) (string, bool) {
+	var rel, typ, href string
+	for _, attr := range token.Attr {
+		switch attr.Key {
+		case "rel":
+			rel = strings.ToLower(attr.Val)
+		case "type":
+			typ = strings.ToLower(attr.Val)
+		case "href":
+			href = attr.Val
+		}
+	}
+	if rel != "alternate" || typ != "application/json+oembed" || href == "" {
+		return "", false
+	}
+	return href, true
+}
@@ -5,4 +5,7 @@ type IdentityProviderUserInfo struct {
 	DisplayName string
 	Email       string
 	AvatarURL   string
+	Groups      []string
+	// Claims are the raw claims the user info was mapped from.
+	Claims map[string]any
 }
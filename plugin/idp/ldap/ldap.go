@@ -0,0 +1,192 @@
+// Package ldap is the plugin for LDAP Identity Provider.
+package ldap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/idp"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// ErrInvalidCredentials is returned when the user is not found or the password does not match.
+var ErrInvalidCredentials = errors.New("invalid ldap credentials")
+
+const (
+	defaultIdentifierAttribute = "uid"
+	timeout                    = 10 * time.Second
+)
+
+// IdentityProvider represents an LDAP Identity Provider.
+type IdentityProvider struct {
+	config *storepb.LDAPConfig
+}
+
+// NewIdentityProvider initializes a new LDAP Identity Provider with the given configuration.
+func NewIdentityProvider(config *storepb.LDAPConfig) (*IdentityProvider, error) {
+	if err := ValidateConfig(config); err != nil {
+		return nil, err
+	}
+	return &IdentityProvider{
+		config: config,
+	}, nil
+}
+
+// ValidateConfig checks the required fields of the given configuration without contacting the server.
+func ValidateConfig(config *storepb.LDAPConfig) error {
+	if config == nil {
+		return errors.New("the ldap config is empty")
+	}
+	for v, field := range map[string]string{
+		config.Url:        "url",
+		config.SearchBase: "searchBase",
+		config.UserFilter: "userFilter",
+	} {
+		if v == "" {
+			return errors.Errorf(`the field "%s" is empty but required`, field)
+		}
+	}
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return errors.Wrap(err, "invalid url")
+	}
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return errors.Errorf("unsupported url scheme %q", u.Scheme)
+	}
+	if config.StartTls && u.Scheme == "ldaps" {
+		return errors.New("startTls cannot be used with ldaps")
+	}
+	if strings.Count(config.UserFilter, "%s") != 1 {
+		return errors.New(`the user filter must contain exactly one "%s"`)
+	}
+	return nil
+}
+
+// Authenticate looks up the user with the service account, binds as the user to check the password
+// and returns the user information mapped from the entry attributes.
+func (p *IdentityProvider) Authenticate(username, password string) (*idp.IdentityProviderUserInfo, error) {
+	// An empty password would be an unauthenticated bind, which most servers accept.
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := p.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if p.config.BindDn != "" {
+		if err := conn.Bind(p.config.BindDn, p.config.BindPassword); err != nil {
+			return nil, errors.Wrap(err, "failed to bind with the service account")
+		}
+	}
+
+	fieldMapping := p.config.FieldMapping
+	if fieldMapping == nil {
+		fieldMapping = &storepb.FieldMapping{}
+	}
+	identifierAttribute := fieldMapping.Identifier
+	if identifierAttribute == "" {
+		identifierAttribute = defaultIdentifierAttribute
+	}
+	attributes := []string{identifierAttribute}
+	for _, attribute := range []string{fieldMapping.DisplayName, fieldMapping.Email, fieldMapping.AvatarUrl, fieldMapping.Groups} {
+		if attribute != "" {
+			attributes = append(attributes, attribute)
+		}
+	}
+	result, err := conn.Search(ldap.NewSearchRequest(
+		p.config.SearchBase,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2,
+		int(timeout.Seconds()),
+		false,
+		fmt.Sprintf(p.config.UserFilter, ldap.EscapeFilter(username)),
+		attributes,
+		nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, errors.Wrap(err, "failed to search user")
+	}
+	if result == nil || len(result.Entries) == 0 {
+		return nil, ErrInvalidCredentials
+	}
+	if len(result.Entries) > 1 {
+		return nil, errors.Errorf("the user filter matches multiple entries for %q", username)
+	}
+	entry := result.Entries[0]
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, errors.Wrap(err, "failed to bind as the user")
+	}
+	return mapEntry(entry, identifierAttribute, fieldMapping)
+}
+
+func (p *IdentityProvider) dial() (*ldap.Conn, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: p.config.InsecureSkipVerify, //nolint:gosec
+	}
+	if u, err := url.Parse(p.config.Url); err == nil {
+		tlsConfig.ServerName = u.Hostname()
+	}
+	conn, err := ldap.DialURL(p.config.Url, ldap.DialWithDialer(&net.Dialer{Timeout: timeout}), ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to ldap server")
+	}
+	conn.SetTimeout(timeout)
+	if p.config.StartTls {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "failed to start tls")
+		}
+	}
+	return conn, nil
+}
+
+func mapEntry(entry *ldap.Entry, identifierAttribute string, fieldMapping *storepb.FieldMapping) (*idp.IdentityProviderUserInfo, error) {
+	claims := map[string]any{}
+	for _, attribute := range entry.Attributes {
+		values := []any{}
+		for _, value := range attribute.Values {
+			values = append(values, value)
+		}
+		claims[attribute.Name] = values
+	}
+	userInfo := &idp.IdentityProviderUserInfo{
+		Identifier: entry.GetEqualFoldAttributeValue(identifierAttribute),
+		Claims:     claims,
+	}
+	if userInfo.Identifier == "" {
+		return nil, errors.Errorf("the attribute %q is not found in the entry or has empty value", identifierAttribute)
+	}
+
+	// Best effort to map optional fields
+	if fieldMapping.DisplayName != "" {
+		userInfo.DisplayName = entry.GetEqualFoldAttributeValue(fieldMapping.DisplayName)
+	}
+	if userInfo.DisplayName == "" {
+		userInfo.DisplayName = userInfo.Identifier
+	}
+	if fieldMapping.Email != "" {
+		userInfo.Email = entry.GetEqualFoldAttributeValue(fieldMapping.Email)
+	}
+	if fieldMapping.AvatarUrl != "" {
+		userInfo.AvatarURL = entry.GetEqualFoldAttributeValue(fieldMapping.AvatarUrl)
+	}
+	if fieldMapping.Groups != "" {
+		userInfo.Groups = entry.GetEqualFoldAttributeValues(fieldMapping.Groups)
+	}
+	return userInfo, nil
+}
@@ -0,0 +1,309 @@
+package ldap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+const (
+	testBindDN       = "cn=reader,dc=example,dc=com"
+	testBindPassword = "reader-secret"
+	testSearchBase   = "ou=people,dc=example,dc=com"
+)
+
+type fakeEntry struct {
+	dn         string
+	password   string
+	attributes map[string][]string
+}
+
+// fakeServer is a minimal in-process LDAP server supporting simple bind, search and StartTLS.
+type fakeServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	entries   []*fakeEntry
+	// startTLS records whether a connection was upgraded.
+	startTLS atomic.Bool
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeServer{
+		listener:  listener,
+		tlsConfig: &tls.Config{Certificates: []tls.Certificate{newTestCertificate(t)}},
+		entries: []*fakeEntry{
+			{
+				dn:       "uid=alice," + testSearchBase,
+				password: "alice-secret",
+				attributes: map[string][]string{
+					"objectClass": {"person"},
+					"uid":         {"alice"},
+					"cn":          {"Alice Liddell"},
+					"mail":        {"alice@example.com"},
+					"memberOf":    {"cn=eng,ou=groups,dc=example,dc=com", "cn=eng-admins,ou=groups,dc=example,dc=com"},
+				},
+			},
+			{
+				dn:       "uid=bob," + testSearchBase,
+				password: "bob-secret",
+				attributes: map[string][]string{
+					"objectClass": {"person"},
+					"uid":         {"bob"},
+				},
+			},
+		},
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *fakeServer) url() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer func() { conn.Close() }()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID := packet.Children[0].Value.(int64)
+		request := packet.Children[1]
+		switch request.Tag {
+		case ber.Tag(0): // BindRequest
+			name := request.Children[1].Data.String()
+			password := request.Children[2].Data.String()
+			code := int64(49) // invalidCredentials
+			if name == testBindDN && password == testBindPassword {
+				code = 0
+			}
+			for _, entry := range s.entries {
+				if entry.dn == name && entry.password == password {
+					code = 0
+				}
+			}
+			writeResult(conn, messageID, 1, code)
+		case ber.Tag(2): // UnbindRequest
+			return
+		case ber.Tag(3): // SearchRequest
+			filter := request.Children[6]
+			for _, entry := range s.entries {
+				if !strings.HasSuffix(entry.dn, request.Children[0].Data.String()) || !matchFilter(filter, entry) {
+					continue
+				}
+				response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, 4, nil, "")
+				response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.dn, ""))
+				attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+				for name, values := range entry.attributes {
+					attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+					attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+					set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+					for _, value := range values {
+						set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+					}
+					attribute.AppendChild(set)
+					attributes.AppendChild(attribute)
+				}
+				response.AppendChild(attributes)
+				writeMessage(conn, messageID, response)
+			}
+			writeResult(conn, messageID, 5, 0)
+		case ber.Tag(23): // ExtendedRequest, only StartTLS is supported
+			writeResult(conn, messageID, 24, 0)
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			s.startTLS.Store(true)
+			conn = tlsConn
+		default:
+			return
+		}
+	}
+}
+
+func matchFilter(filter *ber.Packet, entry *fakeEntry) bool {
+	switch filter.Tag {
+	case 0: // and
+		for _, child := range filter.Children {
+			if !matchFilter(child, entry) {
+				return false
+			}
+		}
+		return true
+	case 1: // or
+		for _, child := range filter.Children {
+			if matchFilter(child, entry) {
+				return true
+			}
+		}
+		return false
+	case 3: // equalityMatch
+		for name, values := range entry.attributes {
+			if strings.EqualFold(name, filter.Children[0].Data.String()) {
+				for _, value := range values {
+					if value == filter.Children[1].Data.String() {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	case 7: // present
+		for name := range entry.attributes {
+			if strings.EqualFold(name, filter.Data.String()) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func writeResult(conn net.Conn, messageID int64, tag ber.Tag, code int64) {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	writeMessage(conn, messageID, response)
+}
+
+func writeMessage(conn net.Conn, messageID int64, response *ber.Packet) {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, ""))
+	packet.AppendChild(response)
+	_, _ = conn.Write(packet.Bytes())
+}
+
+func newTestCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestNewIdentityProvider(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *storepb.LDAPConfig
+		containsErr string
+	}{
+		{
+			name:        "no url",
+			config:      &storepb.LDAPConfig{SearchBase: testSearchBase, UserFilter: "(uid=%s)"},
+			containsErr: `the field "url" is empty but required`,
+		},
+		{
+			name:        "unsupported scheme",
+			config:      &storepb.LDAPConfig{Url: "http://example.com", SearchBase: testSearchBase, UserFilter: "(uid=%s)"},
+			containsErr: "unsupported url scheme",
+		},
+		{
+			name:        "no placeholder in filter",
+			config:      &storepb.LDAPConfig{Url: "ldap://example.com", SearchBase: testSearchBase, UserFilter: "(uid=alice)"},
+			containsErr: "exactly one",
+		},
+		{
+			name:        "starttls with ldaps",
+			config:      &storepb.LDAPConfig{Url: "ldaps://example.com", StartTls: true, SearchBase: testSearchBase, UserFilter: "(uid=%s)"},
+			containsErr: "startTls cannot be used with ldaps",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewIdentityProvider(test.config)
+			require.ErrorContains(t, err, test.containsErr)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	server := newFakeServer(t)
+	provider, err := NewIdentityProvider(&storepb.LDAPConfig{
+		Url:                server.url(),
+		StartTls:           true,
+		InsecureSkipVerify: true,
+		BindDn:             testBindDN,
+		BindPassword:       testBindPassword,
+		SearchBase:         testSearchBase,
+		UserFilter:         "(&(objectClass=person)(uid=%s))",
+		FieldMapping: &storepb.FieldMapping{
+			DisplayName: "cn",
+			Email:       "mail",
+			Groups:      "memberOf",
+		},
+	})
+	require.NoError(t, err)
+
+	userInfo, err := provider.Authenticate("alice", "alice-secret")
+	require.NoError(t, err)
+	require.True(t, server.startTLS.Load())
+	require.Equal(t, "alice", userInfo.Identifier)
+	require.Equal(t, "Alice Liddell", userInfo.DisplayName)
+	require.Equal(t, "alice@example.com", userInfo.Email)
+	require.Equal(t, []string{"cn=eng,ou=groups,dc=example,dc=com", "cn=eng-admins,ou=groups,dc=example,dc=com"}, userInfo.Groups)
+
+	userInfo, err = provider.Authenticate("bob", "bob-secret")
+	require.NoError(t, err)
+	require.Equal(t, "bob", userInfo.DisplayName)
+
+	_, err = provider.Authenticate("alice", "wrong")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = provider.Authenticate("alice", "")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = provider.Authenticate("carol", "carol-secret")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	// The username is escaped so it cannot widen the filter.
+	_, err = provider.Authenticate("*", "alice-secret")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestAuthenticateServiceAccount(t *testing.T) {
+	server := newFakeServer(t)
+	provider, err := NewIdentityProvider(&storepb.LDAPConfig{
+		Url:          server.url(),
+		BindDn:       testBindDN,
+		BindPassword: "wrong",
+		SearchBase:   testSearchBase,
+		UserFilter:   "(uid=%s)",
+	})
+	require.NoError(t, err)
+	_, err = provider.Authenticate("alice", "alice-secret")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrInvalidCredentials)
+}
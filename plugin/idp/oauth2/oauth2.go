@@ -91,7 +91,9 @@ func (p *IdentityProvider) UserInfo(token string) (*idp.IdentityProviderUserInfo
 		return nil, errors.Wrap(err, "failed to unmarshal response body")
 	}
 	slog.Info("user info claims", "claims", claims)
-	userInfo := &idp.IdentityProviderUserInfo{}
+	userInfo := &idp.IdentityProviderUserInfo{
+		Claims: claims,
+	}
 	if v, ok := claims[p.config.FieldMapping.Identifier].(string); ok {
 		userInfo.Identifier = v
 	}
@@ -118,6 +120,20 @@ func (p *IdentityProvider) UserInfo(token string) (*idp.IdentityProviderUserInfo
 			userInfo.AvatarURL = v
 		}
 	}
+	if p.config.FieldMapping.Groups != "" {
+		switch v := claims[p.config.FieldMapping.Groups].(type) {
+		case string:
+			if v != "" {
+				userInfo.Groups = []string{v}
+			}
+		case []any:
+			for _, group := range v {
+				if s, ok := group.(string); ok && s != "" {
+					userInfo.Groups = append(userInfo.Groups, s)
+				}
+			}
+		}
+	}
 	slog.Info("user info", "userInfo", userInfo)
 	return userInfo, nil
 }
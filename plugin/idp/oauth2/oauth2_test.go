@@ -158,6 +158,11 @@ func TestIdentityProvider(t *testing.T) {
 		Identifier:  testSubject,
 		DisplayName: testName,
 		Email:       testEmail,
+		Claims: map[string]any{
+			"sub":   testSubject,
+			"name":  testName,
+			"email": testEmail,
+		},
 	}
 	assert.Equal(t, wantUserInfo, userInfoResult)
 }
@@ -0,0 +1,438 @@
+// Package oidc is the plugin for OpenID Connect Identity Provider.
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/usememos/memos/plugin/idp"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// DiscoveryPath is the well-known path of the OpenID provider configuration.
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+const (
+	// discoveryTTL is how long the discovery document of an issuer is cached.
+	discoveryTTL = time.Hour
+	// keySetTTL is how long the signing keys of an issuer are cached.
+	keySetTTL = time.Hour
+	// keySetRefreshInterval is the minimum time between fetches of the signing keys when an ID token is
+	// signed by an unknown key, as the issuer may have rotated its keys since they were cached.
+	keySetRefreshInterval = time.Minute
+)
+
+// errUnknownSigningKey is returned when an ID token is signed by a key missing from the signing keys.
+var errUnknownSigningKey = errors.New("unknown signing key")
+
+// The discovery documents by issuer and the signing keys by key set URI are cached, as they are needed on every sign in.
+var (
+	cacheMutex     sync.Mutex
+	discoveryCache = map[string]*cachedDiscovery{}
+	keySetCache    = map[string]*cachedKeySet{}
+)
+
+type cachedDiscovery struct {
+	discovery *Discovery
+	fetchedAt time.Time
+}
+
+type cachedKeySet struct {
+	keys      map[string]any
+	fetchedAt time.Time
+}
+
+// Discovery is the subset of the OpenID provider metadata used by memos.
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// IdentityProvider represents an OpenID Connect Identity Provider.
+type IdentityProvider struct {
+	config    *storepb.OIDCConfig
+	discovery *Discovery
+	client    *http.Client
+}
+
+// NewIdentityProvider initializes a new OIDC Identity Provider with the discovery document of the issuer,
+// which is fetched unless it is cached.
+func NewIdentityProvider(ctx context.Context, config *storepb.OIDCConfig) (*IdentityProvider, error) {
+	if err := ValidateConfig(config); err != nil {
+		return nil, err
+	}
+
+	p := &IdentityProvider{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+	issuer := strings.TrimSuffix(config.Issuer, "/")
+	cacheMutex.Lock()
+	cached, ok := discoveryCache[issuer]
+	cacheMutex.Unlock()
+	if ok && time.Since(cached.fetchedAt) < discoveryTTL {
+		p.discovery = cached.discovery
+		return p, nil
+	}
+
+	discovery := &Discovery{}
+	if err := p.getJSON(ctx, issuer+DiscoveryPath, discovery); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch discovery document")
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, errors.Errorf("issuer mismatch, expected %q but got %q", config.Issuer, discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("discovery document is missing required endpoints")
+	}
+	cacheMutex.Lock()
+	discoveryCache[issuer] = &cachedDiscovery{discovery: discovery, fetchedAt: time.Now()}
+	cacheMutex.Unlock()
+	p.discovery = discovery
+	return p, nil
+}
+
+// ValidateConfig checks the required fields of the given configuration without contacting the issuer.
+func ValidateConfig(config *storepb.OIDCConfig) error {
+	if config == nil {
+		return errors.New("the oidc config is empty")
+	}
+	for v, field := range map[string]string{
+		config.Issuer:       "issuer",
+		config.ClientId:     "clientId",
+		config.ClientSecret: "clientSecret",
+	} {
+		if v == "" {
+			return errors.Errorf(`the field "%s" is empty but required`, field)
+		}
+	}
+	return nil
+}
+
+// Discovery returns the discovery document of the issuer.
+func (p *IdentityProvider) Discovery() *Discovery {
+	return p.discovery
+}
+
+func (p *IdentityProvider) oauth2Config(redirectURL string) *oauth2.Config {
+	scopes := []string{"openid"}
+	for _, scope := range p.config.Scopes {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return &oauth2.Config{
+		ClientID:     p.config.ClientId,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  p.discovery.AuthorizationEndpoint,
+			TokenURL: p.discovery.TokenEndpoint,
+		},
+	}
+}
+
+// AuthCodeURL returns the authorization URL with the state, nonce and PKCE challenge of the given verifier.
+func (p *IdentityProvider) AuthCodeURL(redirectURL, state, nonce, verifier string) string {
+	return p.oauth2Config(redirectURL).AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce), oauth2.S256ChallengeOption(verifier))
+}
+
+// Token is the result of the authorization code exchange.
+type Token struct {
+	AccessToken string
+	IDToken     string
+}
+
+// ExchangeToken exchanges the authorization code with the PKCE verifier.
+func (p *IdentityProvider) ExchangeToken(ctx context.Context, redirectURL, code, verifier string) (*Token, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+	token, err := p.oauth2Config(redirectURL).Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exchange token")
+	}
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok || idToken == "" {
+		return nil, errors.New(`missing "id_token" from token response`)
+	}
+	return &Token{
+		AccessToken: token.AccessToken,
+		IDToken:     idToken,
+	}, nil
+}
+
+// VerifyIDToken validates the signature, issuer, audience, expiry and nonce of the ID token and returns its claims.
+func (p *IdentityProvider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (map[string]any, error) {
+	keys, err := p.getKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := p.parseIDToken(rawIDToken, keys)
+	if errors.Is(err, errUnknownSigningKey) {
+		// The issuer may have rotated its keys since they were cached.
+		if keys, err = p.getKeys(ctx, true); err != nil {
+			return nil, err
+		}
+		claims, err = p.parseIDToken(rawIDToken, keys)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid id token")
+	}
+	if v, _ := claims["nonce"].(string); v != nonce {
+		return nil, errors.New("invalid id token nonce")
+	}
+	return claims, nil
+}
+
+func (p *IdentityProvider) parseIDToken(rawIDToken string, keys map[string]any) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" && len(keys) == 1 {
+			for _, key := range keys {
+				return key, nil
+			}
+		}
+		key, ok := keys[kid]
+		if !ok {
+			return nil, errors.Wrapf(errUnknownSigningKey, "kid %q", kid)
+		}
+		return key, nil
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(p.discovery.Issuer),
+		jwt.WithAudience(p.config.ClientId),
+		jwt.WithExpirationRequired(),
+	)
+	return claims, err
+}
+
+// UserInfo returns the user information mapped from the ID token claims,
+// merged with the claims of the userinfo endpoint when it is available.
+func (p *IdentityProvider) UserInfo(ctx context.Context, token *Token, idTokenClaims map[string]any) (*idp.IdentityProviderUserInfo, error) {
+	claims := map[string]any{}
+	if p.discovery.UserInfoEndpoint != "" && token.AccessToken != "" {
+		userInfoClaims := map[string]any{}
+		if err := p.getJSON(ctx, p.discovery.UserInfoEndpoint, &userInfoClaims, "Bearer "+token.AccessToken); err != nil {
+			return nil, errors.Wrap(err, "failed to get user information")
+		}
+		// The sub claim of the userinfo response must match the ID token.
+		if sub, ok := userInfoClaims["sub"]; ok && sub != idTokenClaims["sub"] {
+			return nil, errors.New("userinfo subject does not match the id token")
+		}
+		for k, v := range userInfoClaims {
+			claims[k] = v
+		}
+	}
+	for k, v := range idTokenClaims {
+		claims[k] = v
+	}
+	return MapClaims(claims, p.config.FieldMapping)
+}
+
+// MapClaims maps the claims to user information with the given field mapping.
+// The identifier defaults to the "sub" claim.
+func MapClaims(claims map[string]any, fieldMapping *storepb.FieldMapping) (*idp.IdentityProviderUserInfo, error) {
+	if fieldMapping == nil {
+		fieldMapping = &storepb.FieldMapping{}
+	}
+	identifierClaim := fieldMapping.Identifier
+	if identifierClaim == "" {
+		identifierClaim = "sub"
+	}
+	userInfo := &idp.IdentityProviderUserInfo{
+		Claims: claims,
+	}
+	if v, ok := claims[identifierClaim].(string); ok {
+		userInfo.Identifier = v
+	}
+	if userInfo.Identifier == "" {
+		return nil, errors.Errorf("the field %q is not found in claims or has empty value", identifierClaim)
+	}
+
+	// Best effort to map optional fields
+	if fieldMapping.DisplayName != "" {
+		if v, ok := claims[fieldMapping.DisplayName].(string); ok {
+			userInfo.DisplayName = v
+		}
+	}
+	if userInfo.DisplayName == "" {
+		userInfo.DisplayName = userInfo.Identifier
+	}
+	if fieldMapping.Email != "" {
+		if v, ok := claims[fieldMapping.Email].(string); ok {
+			userInfo.Email = v
+		}
+	}
+	if fieldMapping.AvatarUrl != "" {
+		if v, ok := claims[fieldMapping.AvatarUrl].(string); ok {
+			userInfo.AvatarURL = v
+		}
+	}
+	if fieldMapping.Groups != "" {
+		switch v := claims[fieldMapping.Groups].(type) {
+		case string:
+			if v != "" {
+				userInfo.Groups = []string{v}
+			}
+		case []any:
+			for _, group := range v {
+				if s, ok := group.(string); ok && s != "" {
+					userInfo.Groups = append(userInfo.Groups, s)
+				}
+			}
+		}
+	}
+	return userInfo, nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// getKeys returns the signing keys of the issuer, which are fetched unless they are cached. Refreshing fetches
+// them unless they were fetched less than keySetRefreshInterval ago.
+func (p *IdentityProvider) getKeys(ctx context.Context, refresh bool) (map[string]any, error) {
+	maxAge := keySetTTL
+	if refresh {
+		maxAge = keySetRefreshInterval
+	}
+	cacheMutex.Lock()
+	cached, ok := keySetCache[p.discovery.JWKSURI]
+	cacheMutex.Unlock()
+	if ok && time.Since(cached.fetchedAt) < maxAge {
+		return cached.keys, nil
+	}
+
+	keys, err := p.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cacheMutex.Lock()
+	keySetCache[p.discovery.JWKSURI] = &cachedKeySet{keys: keys, fetchedAt: time.Now()}
+	cacheMutex.Unlock()
+	return keys, nil
+}
+
+func (p *IdentityProvider) fetchKeys(ctx context.Context) (map[string]any, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, p.discovery.JWKSURI, &jwks); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch jwks")
+	}
+	keys := map[string]any{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Skip keys of unsupported types.
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no usable signing keys in jwks")
+	}
+	return keys, nil
+}
+
+func (k *jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, errors.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (p *IdentityProvider) getJSON(ctx context.Context, url string, v any, authorization ...string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to new http request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if len(authorization) > 0 {
+		req.Header.Set("Authorization", authorization[0])
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.Wrap(err, "failed to unmarshal response body")
+	}
+	return nil
+}
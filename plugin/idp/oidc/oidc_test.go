@@ -0,0 +1,262 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+const (
+	testClientID     = "test-client-id"
+	testClientSecret = "test-client-secret"
+	testCode         = "test-code"
+	testRedirectURL  = "https://memos.example.com/auth/callback"
+)
+
+// fakeIssuer is a minimal OpenID provider serving discovery, JWKS, token and userinfo endpoints.
+type fakeIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	// kid is the id of the signing key.
+	kid string
+	// claims are signed into the ID token returned by the token endpoint.
+	claims jwt.MapClaims
+	// challenge is the PKCE challenge the token endpoint expects.
+	challenge string
+	userInfo  map[string]any
+	// discoveryRequests and keySetRequests count the requests to the discovery and JWKS endpoints.
+	discoveryRequests atomic.Int32
+	keySetRequests    atomic.Int32
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	f := &fakeIssuer{key: key, kid: "test-key"}
+	mux := http.NewServeMux()
+	mux.HandleFunc(DiscoveryPath, func(w http.ResponseWriter, _ *http.Request) {
+		f.discoveryRequests.Add(1)
+		writeJSON(w, map[string]any{
+			"issuer":                 f.server.URL,
+			"authorization_endpoint": f.server.URL + "/authorize",
+			"token_endpoint":         f.server.URL + "/token",
+			"userinfo_endpoint":      f.server.URL + "/userinfo",
+			"jwks_uri":               f.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		f.keySetRequests.Add(1)
+		writeJSON(w, map[string]any{
+			"keys": []map[string]any{{
+				"kty": "RSA",
+				"kid": f.kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(f.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(f.key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != testCode || base64.RawURLEncoding.EncodeToString(sum[:]) != f.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]any{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, map[string]any{
+			"access_token": "test-access-token",
+			"token_type":   "Bearer",
+			"id_token":     f.sign(t, f.claims),
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, f.userInfo)
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeIssuer) sign(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = f.kid
+	signed, err := token.SignedString(f.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func (f *fakeIssuer) idTokenClaims(nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":    f.server.URL,
+		"aud":    testClientID,
+		"sub":    "user-1",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"iat":    time.Now().Unix(),
+		"nonce":  nonce,
+		"name":   "Test User",
+		"groups": []string{"admins", "dev"},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestNewIdentityProvider(t *testing.T) {
+	ctx := context.Background()
+	issuer := newFakeIssuer(t)
+
+	_, err := NewIdentityProvider(ctx, &storepb.OIDCConfig{ClientId: testClientID, ClientSecret: testClientSecret})
+	require.ErrorContains(t, err, `the field "issuer" is empty but required`)
+	_, err = NewIdentityProvider(ctx, &storepb.OIDCConfig{Issuer: issuer.server.URL + "/other", ClientId: testClientID, ClientSecret: testClientSecret})
+	require.Error(t, err)
+
+	provider, err := NewIdentityProvider(ctx, &storepb.OIDCConfig{Issuer: issuer.server.URL + "/", ClientId: testClientID, ClientSecret: testClientSecret})
+	require.NoError(t, err)
+	require.Equal(t, issuer.server.URL+"/token", provider.Discovery().TokenEndpoint)
+}
+
+func TestIdentityProvider(t *testing.T) {
+	ctx := context.Background()
+	issuer := newFakeIssuer(t)
+	provider, err := NewIdentityProvider(ctx, &storepb.OIDCConfig{
+		Issuer:       issuer.server.URL,
+		ClientId:     testClientID,
+		ClientSecret: testClientSecret,
+		Scopes:       []string{"profile", "email"},
+		FieldMapping: &storepb.FieldMapping{
+			DisplayName: "name",
+			Email:       "email",
+			Groups:      "groups",
+		},
+	})
+	require.NoError(t, err)
+
+	verifier, nonce := "test-verifier-0123456789-0123456789-0123456789", "test-nonce"
+	authURL, err := url.Parse(provider.AuthCodeURL(testRedirectURL, "test-state", nonce, verifier))
+	require.NoError(t, err)
+	query := authURL.Query()
+	require.Equal(t, "test-state", query.Get("state"))
+	require.Equal(t, nonce, query.Get("nonce"))
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+	require.Equal(t, "openid profile email", query.Get("scope"))
+	issuer.challenge = query.Get("code_challenge")
+	issuer.claims = issuer.idTokenClaims(nonce)
+	issuer.userInfo = map[string]any{"sub": "user-1", "email": "user@example.com"}
+
+	_, err = provider.ExchangeToken(ctx, testRedirectURL, testCode, "wrong-verifier")
+	require.Error(t, err)
+	token, err := provider.ExchangeToken(ctx, testRedirectURL, testCode, verifier)
+	require.NoError(t, err)
+
+	_, err = provider.VerifyIDToken(ctx, token.IDToken, "other-nonce")
+	require.ErrorContains(t, err, "nonce")
+	claims, err := provider.VerifyIDToken(ctx, token.IDToken, nonce)
+	require.NoError(t, err)
+
+	userInfo, err := provider.UserInfo(ctx, token, claims)
+	require.NoError(t, err)
+	require.Equal(t, "user-1", userInfo.Identifier)
+	require.Equal(t, "Test User", userInfo.DisplayName)
+	require.Equal(t, "user@example.com", userInfo.Email)
+	require.Equal(t, []string{"admins", "dev"}, userInfo.Groups)
+}
+
+func TestVerifyIDTokenClaims(t *testing.T) {
+	ctx := context.Background()
+	issuer := newFakeIssuer(t)
+	provider, err := NewIdentityProvider(ctx, &storepb.OIDCConfig{Issuer: issuer.server.URL, ClientId: testClientID, ClientSecret: testClientSecret})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(claims jwt.MapClaims)
+	}{
+		{name: "wrong audience", modify: func(claims jwt.MapClaims) { claims["aud"] = "other-client" }},
+		{name: "wrong issuer", modify: func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" }},
+		{name: "expired", modify: func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{name: "missing expiry", modify: func(claims jwt.MapClaims) { delete(claims, "exp") }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims := issuer.idTokenClaims("nonce")
+			test.modify(claims)
+			_, err := provider.VerifyIDToken(ctx, issuer.sign(t, claims), "nonce")
+			require.Error(t, err)
+		})
+	}
+
+	// A token signed by another key must be rejected.
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodRS256, issuer.idTokenClaims("nonce"))
+	forged.Header["kid"] = "test-key"
+	signed, err := forged.SignedString(otherKey)
+	require.NoError(t, err)
+	_, err = provider.VerifyIDToken(ctx, signed, "nonce")
+	require.Error(t, err)
+}
+
+func TestIdentityProviderCache(t *testing.T) {
+	ctx := context.Background()
+	issuer := newFakeIssuer(t)
+	config := &storepb.OIDCConfig{Issuer: issuer.server.URL, ClientId: testClientID, ClientSecret: testClientSecret}
+
+	// The discovery document and the signing keys are fetched once for all the sign ins.
+	for i := 0; i < 3; i++ {
+		provider, err := NewIdentityProvider(ctx, config)
+		require.NoError(t, err)
+		_, err = provider.VerifyIDToken(ctx, issuer.sign(t, issuer.idTokenClaims("nonce")), "nonce")
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), issuer.discoveryRequests.Load())
+	require.Equal(t, int32(1), issuer.keySetRequests.Load())
+
+	// A token signed by an unknown key refreshes the keys, at most once per refresh interval.
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	issuer.key, issuer.kid = key, "rotated-key"
+	provider, err := NewIdentityProvider(ctx, config)
+	require.NoError(t, err)
+	_, err = provider.VerifyIDToken(ctx, issuer.sign(t, issuer.idTokenClaims("nonce")), "nonce")
+	require.ErrorIs(t, err, errUnknownSigningKey)
+	require.Equal(t, int32(1), issuer.keySetRequests.Load())
+
+	cacheMutex.Lock()
+	keySetCache[provider.Discovery().JWKSURI].fetchedAt = time.Now().Add(-keySetRefreshInterval)
+	cacheMutex.Unlock()
+	_, err = provider.VerifyIDToken(ctx, issuer.sign(t, issuer.idTokenClaims("nonce")), "nonce")
+	require.NoError(t, err)
+	require.Equal(t, int32(2), issuer.keySetRequests.Load())
+}
+
+func TestMapClaims(t *testing.T) {
+	userInfo, err := MapClaims(map[string]any{"sub": "user-1", "role": "admins"}, &storepb.FieldMapping{Groups: "role"})
+	require.NoError(t, err)
+	require.Equal(t, "user-1", userInfo.DisplayName)
+	require.Equal(t, []string{"admins"}, userInfo.Groups)
+
+	_, err = MapClaims(map[string]any{"sub": "user-1"}, &storepb.FieldMapping{Identifier: "preferred_username"})
+	require.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package idp
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// Roles that can be granted by role mappings, from the least to the most privileged.
+var provisioningRoles = []string{"USER", "ADMIN"}
+
+// GetProvisioningConfig returns the provisioning config of the identity provider config, or nil if there is none.
+func GetProvisioningConfig(config *storepb.IdentityProviderConfig) *storepb.ProvisioningConfig {
+	switch v := config.GetConfig().(type) {
+	case *storepb.IdentityProviderConfig_Oauth2Config:
+		return v.Oauth2Config.GetProvisioning()
+	case *storepb.IdentityProviderConfig_OidcConfig:
+		return v.OidcConfig.GetProvisioning()
+	case *storepb.IdentityProviderConfig_LdapConfig:
+		return v.LdapConfig.GetProvisioning()
+	default:
+		return nil
+	}
+}
+
+// ValidateProvisioningConfig checks the role mappings of the provisioning config.
+func ValidateProvisioningConfig(config *storepb.ProvisioningConfig) error {
+	for i, rule := range config.GetRoleMappings() {
+		if rule.Value == "" {
+			return errors.Errorf("role mapping %d has an empty value", i)
+		}
+		if !slices.Contains(provisioningRoles, rule.Role) {
+			return errors.Errorf("role mapping %d has an invalid role %q", i, rule.Role)
+		}
+	}
+	return nil
+}
+
+// MatchRoleMappings returns the most privileged role of the rules matching the user info,
+// and whether any rule matched at all.
+func MatchRoleMappings(rules []*storepb.RoleMappingRule, userInfo *IdentityProviderUserInfo) (string, bool) {
+	matched := -1
+	for _, rule := range rules {
+		if !matchRoleMapping(rule, userInfo) {
+			continue
+		}
+		if index := slices.Index(provisioningRoles, rule.Role); index > matched {
+			matched = index
+		}
+	}
+	if matched < 0 {
+		return "", false
+	}
+	return provisioningRoles[matched], true
+}
+
+func matchRoleMapping(rule *storepb.RoleMappingRule, userInfo *IdentityProviderUserInfo) bool {
+	if rule.Claim == "" {
+		return slices.Contains(userInfo.Groups, rule.Value)
+	}
+	switch v := userInfo.Claims[rule.Claim].(type) {
+	case nil:
+		return false
+	case string:
+		return v == rule.Value
+	case []any:
+		for _, item := range v {
+			if fmt.Sprint(item) == rule.Value {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprint(v) == rule.Value
+	}
+}
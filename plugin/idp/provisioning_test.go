@@ -0,0 +1,67 @@
+package idp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestMatchRoleMappings(t *testing.T) {
+	rules := []*storepb.RoleMappingRule{
+		{Value: "eng", Role: "USER"},
+		{Claim: "groups", Value: "eng-admins", Role: "ADMIN"},
+		{Claim: "department", Value: "ops", Role: "ADMIN"},
+	}
+	tests := []struct {
+		name     string
+		userInfo *IdentityProviderUserInfo
+		role     string
+		matched  bool
+	}{
+		{
+			name:     "mapped groups",
+			userInfo: &IdentityProviderUserInfo{Groups: []string{"eng"}},
+			role:     "USER",
+			matched:  true,
+		},
+		{
+			name:     "most privileged role wins",
+			userInfo: &IdentityProviderUserInfo{Groups: []string{"eng"}, Claims: map[string]any{"groups": []any{"eng", "eng-admins"}}},
+			role:     "ADMIN",
+			matched:  true,
+		},
+		{
+			name:     "string claim",
+			userInfo: &IdentityProviderUserInfo{Claims: map[string]any{"department": "ops"}},
+			role:     "ADMIN",
+			matched:  true,
+		},
+		{
+			name:     "no match",
+			userInfo: &IdentityProviderUserInfo{Groups: []string{"sales"}, Claims: map[string]any{"department": "sales"}},
+			matched:  false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			role, matched := MatchRoleMappings(rules, test.userInfo)
+			require.Equal(t, test.matched, matched)
+			require.Equal(t, test.role, role)
+		})
+	}
+}
+
+func TestValidateProvisioningConfig(t *testing.T) {
+	require.NoError(t, ValidateProvisioningConfig(nil))
+	require.NoError(t, ValidateProvisioningConfig(&storepb.ProvisioningConfig{
+		RoleMappings: []*storepb.RoleMappingRule{{Value: "eng-admins", Role: "ADMIN"}},
+	}))
+	require.Error(t, ValidateProvisioningConfig(&storepb.ProvisioningConfig{
+		RoleMappings: []*storepb.RoleMappingRule{{Value: "eng-admins", Role: "HOST"}},
+	}))
+	require.Error(t, ValidateProvisioningConfig(&storepb.ProvisioningConfig{
+		RoleMappings: []*storepb.RoleMappingRule{{Role: "USER"}},
+	}))
+}
@@ -0,0 +1,267 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/usememos/gomark/ast"
+)
+
+// languagePattern matches the code block languages that are safe to use in a class name.
+var languagePattern = regexp.MustCompile(`^[A-Za-z0-9_+#.-]+$`)
+
+// RenderHTML renders the nodes to HTML. All the text is escaped, links with unsafe schemes are
+// dropped, and raw HTML elements other than line breaks are ignored, so the result is safe to
+// embed in pages, feeds and emails.
+func RenderHTML(nodes []ast.Node, options Options) string {
+	r := &htmlRenderer{options: options}
+	r.renderNodes(nodes)
+	return r.output.String()
+}
+
+type htmlRenderer struct {
+	options Options
+	output  strings.Builder
+}
+
+// renderNodes renders the nodes, skipping the line break that ends a block like the gomark renderers do.
+func (r *htmlRenderer) renderNodes(nodes []ast.Node) {
+	var prevNode ast.Node
+	skipNextLineBreak := false
+	for _, node := range nodes {
+		if node.Type() == ast.LineBreakNode && skipNextLineBreak && prevNode != nil && ast.IsBlockNode(prevNode) {
+			skipNextLineBreak = false
+			continue
+		}
+		r.renderNode(node)
+		prevNode = node
+		skipNextLineBreak = true
+	}
+}
+
+func (r *htmlRenderer) renderNode(node ast.Node) {
+	switch n := node.(type) {
+	case *ast.LineBreak:
+		r.output.WriteString("<br>")
+	case *ast.Paragraph:
+		r.wrap("p", n.Children)
+	case *ast.CodeBlock:
+		r.output.WriteString("<pre><code")
+		if languagePattern.MatchString(n.Language) {
+			fmt.Fprintf(&r.output, ` class="language-%s"`, n.Language)
+		}
+		r.output.WriteString(">")
+		r.text(n.Content)
+		r.output.WriteString("</code></pre>")
+	case *ast.Heading:
+		r.wrap(fmt.Sprintf("h%d", min(max(n.Level, 1), 6)), n.Children)
+	case *ast.HorizontalRule:
+		r.output.WriteString("<hr>")
+	case *ast.Blockquote:
+		r.wrap("blockquote", n.Children)
+	case *ast.List:
+		r.renderList(n)
+	case *ast.UnorderedListItem:
+		r.wrap("li", n.Children)
+	case *ast.OrderedListItem:
+		r.wrap("li", n.Children)
+	case *ast.TaskListItem:
+		r.renderTaskListItem(n)
+	case *ast.MathBlock:
+		r.output.WriteString(`<pre><code class="language-math">`)
+		r.text(n.Content)
+		r.output.WriteString("</code></pre>")
+	case *ast.Table:
+		r.renderTable(n)
+	case *ast.EmbeddedContent:
+		r.renderEmbeddedContent(n)
+	case *ast.Text:
+		r.text(n.Content)
+	case *ast.Bold:
+		r.wrap("strong", n.Children)
+	case *ast.Italic:
+		r.wrap("em", n.Children)
+	case *ast.BoldItalic:
+		r.output.WriteString("<strong><em>")
+		r.text(n.Content)
+		r.output.WriteString("</em></strong>")
+	case *ast.Code:
+		r.wrapText("code", n.Content)
+	case *ast.Image:
+		r.renderImage(n.URL, n.AltText)
+	case *ast.Link:
+		r.renderLink(n.URL, func() { r.renderNodes(n.Content) })
+	case *ast.AutoLink:
+		if _, ok := r.options.autoLinkURL(n); ok {
+			r.renderLink(n.URL, func() { r.text(n.URL) })
+		} else {
+			r.text(autoLinkText(n))
+		}
+	case *ast.Tag:
+		r.output.WriteString(`<span class="tag">`)
+		r.text("#" + n.Content)
+		r.output.WriteString("</span>")
+	case *ast.Strikethrough:
+		r.wrapText("del", n.Content)
+	case *ast.EscapingCharacter:
+		r.text(n.Symbol)
+	case *ast.Math:
+		r.output.WriteString(`<code class="language-math">`)
+		r.text(n.Content)
+		r.output.WriteString("</code>")
+	case *ast.Highlight:
+		r.wrapText("mark", n.Content)
+	case *ast.Subscript:
+		r.wrapText("sub", n.Content)
+	case *ast.Superscript:
+		r.wrapText("sup", n.Content)
+	case *ast.ReferencedContent:
+		reference := r.options.resolve(n.ResourceName)
+		r.renderLink(reference.URL, func() { r.text(reference.Title) })
+	case *ast.Spoiler:
+		r.output.WriteString(`<span class="spoiler">`)
+		r.text(n.Content)
+		r.output.WriteString("</span>")
+	case *ast.HTMLElement:
+		// Only the line breaks are kept from raw HTML.
+		if strings.EqualFold(n.TagName, "br") {
+			r.output.WriteString("<br>")
+		}
+	default:
+	}
+}
+
+func (r *htmlRenderer) text(text string) {
+	r.output.WriteString(html.EscapeString(text))
+}
+
+func (r *htmlRenderer) wrap(tag string, children []ast.Node) {
+	fmt.Fprintf(&r.output, "<%s>", tag)
+	r.renderNodes(children)
+	fmt.Fprintf(&r.output, "</%s>", tag)
+}
+
+func (r *htmlRenderer) wrapText(tag string, text string) {
+	fmt.Fprintf(&r.output, "<%s>", tag)
+	r.text(text)
+	fmt.Fprintf(&r.output, "</%s>", tag)
+}
+
+// renderList renders the items of the list. The line breaks between the items are dropped and the
+// nested lists are kept in the item before them.
+func (r *htmlRenderer) renderList(node *ast.List) {
+	open, tag := "<ul>", "ul"
+	switch node.Kind {
+	case ast.OrderedList:
+		open, tag = "<ol>", "ol"
+	case ast.DescrpitionList:
+		// gomark parses the task lists as description lists.
+		open = `<ul class="task-list">`
+	}
+	r.output.WriteString(open)
+	itemOpen := false
+	for _, child := range node.Children {
+		switch child := child.(type) {
+		case *ast.LineBreak:
+			continue
+		case *ast.List:
+			if !itemOpen {
+				r.output.WriteString("<li>")
+				itemOpen = true
+			}
+			r.renderList(child)
+			continue
+		}
+		if itemOpen {
+			r.output.WriteString("</li>")
+		}
+		r.output.WriteString("<li>")
+		itemOpen = true
+		r.renderListItemContent(child)
+	}
+	if itemOpen {
+		r.output.WriteString("</li>")
+	}
+	fmt.Fprintf(&r.output, "</%s>", tag)
+}
+
+func (r *htmlRenderer) renderListItemContent(node ast.Node) {
+	switch n := node.(type) {
+	case *ast.UnorderedListItem:
+		r.renderNodes(n.Children)
+	case *ast.OrderedListItem:
+		r.renderNodes(n.Children)
+	case *ast.TaskListItem:
+		r.output.WriteString(`<input type="checkbox" disabled`)
+		if n.Complete {
+			r.output.WriteString(" checked")
+		}
+		r.output.WriteString("> ")
+		r.renderNodes(n.Children)
+	default:
+		r.renderNode(n)
+	}
+}
+
+func (r *htmlRenderer) renderTaskListItem(node *ast.TaskListItem) {
+	r.output.WriteString("<li>")
+	r.renderListItemContent(node)
+	r.output.WriteString("</li>")
+}
+
+func (r *htmlRenderer) renderTable(node *ast.Table) {
+	r.output.WriteString("<table><thead><tr>")
+	for _, cell := range node.Header {
+		r.wrap("th", []ast.Node{cell})
+	}
+	r.output.WriteString("</tr></thead><tbody>")
+	for _, row := range node.Rows {
+		r.output.WriteString("<tr>")
+		for _, cell := range row {
+			r.wrap("td", []ast.Node{cell})
+		}
+		r.output.WriteString("</tr>")
+	}
+	r.output.WriteString("</tbody></table>")
+}
+
+func (r *htmlRenderer) renderEmbeddedContent(node *ast.EmbeddedContent) {
+	reference := r.options.resolve(node.ResourceName)
+	switch {
+	case reference.isImage():
+		r.output.WriteString("<figure>")
+		r.renderImage(reference.URL, reference.Title)
+		r.output.WriteString("</figure>")
+	case strings.HasPrefix(node.ResourceName, "memos/"):
+		r.output.WriteString("<blockquote>")
+		r.renderLink(reference.URL, func() { r.text(reference.Title) })
+		r.output.WriteString("</blockquote>")
+	default:
+		r.output.WriteString("<p>")
+		r.renderLink(reference.URL, func() { r.text(reference.Title) })
+		r.output.WriteString("</p>")
+	}
+}
+
+func (r *htmlRenderer) renderImage(rawURL, alt string) {
+	src, ok := r.options.safeURL(rawURL)
+	if !ok {
+		r.text(alt)
+		return
+	}
+	fmt.Fprintf(&r.output, `<img src="%s" alt="%s" loading="lazy">`, html.EscapeString(src), html.EscapeString(alt))
+}
+
+// renderLink renders a link to the URL, or only its content when the URL is unsafe.
+func (r *htmlRenderer) renderLink(rawURL string, renderContent func()) {
+	href, ok := r.options.safeURL(rawURL)
+	if !ok {
+		renderContent()
+		return
+	}
+	fmt.Fprintf(&r.output, `<a href="%s" rel="nofollow noopener noreferrer">`, html.EscapeString(href))
+	renderContent()
+	r.output.WriteString("</a>")
+}
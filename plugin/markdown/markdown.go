@@ -0,0 +1,93 @@
+// Package markdown renders the markdown of memos, parsed by gomark, to HTML and other formats.
+package markdown
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/usememos/gomark"
+	"github.com/usememos/gomark/ast"
+)
+
+// Reference is a resolved resource or memo, which is embedded with ![[name]] or referenced with [[name]].
+type Reference struct {
+	// URL is the URL of the resource content or the memo page.
+	URL string
+	// Title is the filename of a resource or the snippet of a memo.
+	Title string
+	// Type is the MIME type of a resource.
+	Type string
+}
+
+// Options are the options of the renderers.
+type Options struct {
+	// InstanceURL is the URL of the instance, which relative links and references are resolved against.
+	InstanceURL string
+	// Resolve resolves the name of an embedded or referenced resource or memo, e.g. memos/{uid}.
+	// When it is nil or returns nil, the reference links to the name under the instance URL.
+	Resolve func(name string) *Reference
+}
+
+// Parse parses the markdown into nodes.
+func Parse(markdown string) ([]ast.Node, error) {
+	return gomark.Parse(markdown)
+}
+
+func (o Options) resolve(name string) *Reference {
+	if o.Resolve != nil {
+		if reference := o.Resolve(name); reference != nil {
+			return reference
+		}
+	}
+	return &Reference{
+		URL:   o.absoluteURL("/" + name),
+		Title: name,
+	}
+}
+
+// absoluteURL resolves a URL relative to the root of the instance against the instance URL.
+func (o Options) absoluteURL(rawURL string) string {
+	if o.InstanceURL == "" || !strings.HasPrefix(rawURL, "/") || strings.HasPrefix(rawURL, "//") {
+		return rawURL
+	}
+	return strings.TrimSuffix(o.InstanceURL, "/") + rawURL
+}
+
+// safeURL returns the absolute URL of a link, or false when its scheme may run scripts, e.g. javascript:.
+func (o Options) safeURL(rawURL string) (string, bool) {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "":
+		return o.absoluteURL(rawURL), true
+	case "http", "https", "mailto", "tel":
+		return rawURL, true
+	default:
+		return "", false
+	}
+}
+
+// autoLinkURL returns the URL of an auto link, which must be absolute, e.g. <https://usememos.com>.
+func (o Options) autoLinkURL(node *ast.AutoLink) (string, bool) {
+	u, err := url.Parse(node.URL)
+	if err != nil || u.Scheme == "" {
+		return "", false
+	}
+	return o.safeURL(node.URL)
+}
+
+// autoLinkText returns the text of an auto link that is not a link.
+func autoLinkText(node *ast.AutoLink) string {
+	if node.IsRawText {
+		return node.URL
+	}
+	return "<" + node.URL + ">"
+}
+
+// isImage reports whether the reference is an image resource.
+func (r *Reference) isImage() bool {
+	return strings.HasPrefix(r.Type, "image/")
+}
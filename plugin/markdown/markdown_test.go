@@ -0,0 +1,136 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/usememos/gomark/ast"
+)
+
+var testOptions = Options{
+	InstanceURL: "https://memos.example.com/",
+	Resolve: func(name string) *Reference {
+		if name == "resources/photo" {
+			return &Reference{URL: "/file/resources/photo/cat.png", Title: "cat.png", Type: "image/png"}
+		}
+		return nil
+	},
+}
+
+func render(t *testing.T, markdown string, renderer func(nodes []ast.Node, options Options) string) string {
+	nodes, err := Parse(markdown)
+	require.NoError(t, err)
+	return renderer(nodes, testOptions)
+}
+
+func TestRenderHTML(t *testing.T) {
+	tests := []struct {
+		markdown string
+		expected string
+	}{
+		{
+			markdown: "# Hello **world**",
+			expected: "<h1>Hello <strong>world</strong></h1>",
+		},
+		{
+			markdown: "a <script>alert(1)</script> & b",
+			expected: "<p>a &lt;script&gt;alert(1)&lt;/script&gt; &amp; b</p>",
+		},
+		{
+			markdown: "[click](javascript:alert`1`) [docs](/docs)",
+			expected: `<p>click <a href="https://memos.example.com/docs" rel="nofollow noopener noreferrer">docs</a></p>`,
+		},
+		{
+			markdown: "```go\nx < y\n```",
+			expected: `<pre><code class="language-go">x &lt; y</code></pre>`,
+		},
+		{
+			markdown: "- a\n- b\n  - c",
+			expected: "<ul><li>a</li><li>b<ul><li>c</li></ul></li></ul>",
+		},
+		{
+			markdown: "- [ ] todo\n- [x] done",
+			expected: `<ul class="task-list"><li><input type="checkbox" disabled> todo</li><li><input type="checkbox" disabled checked> done</li></ul>`,
+		},
+		{
+			markdown: "![[resources/photo]]",
+			expected: `<figure><img src="https://memos.example.com/file/resources/photo/cat.png" alt="cat.png" loading="lazy"></figure>`,
+		},
+		{
+			markdown: "see [[memos/abc]] #tag",
+			expected: `<p>see <a href="https://memos.example.com/memos/abc" rel="nofollow noopener noreferrer">memos/abc</a> <span class="tag">#tag</span></p>`,
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, render(t, test.markdown, RenderHTML), test.markdown)
+	}
+}
+
+func TestRenderCommonMark(t *testing.T) {
+	tests := []struct {
+		markdown string
+		expected string
+	}{
+		{
+			markdown: "# Title\n\n**bold** and ==marked== with ~~old~~",
+			expected: "# Title\n\n**bold** and <mark>marked</mark> with ~~old~~",
+		},
+		{
+			markdown: "![[resources/photo]]\n[[memos/abc]]",
+			expected: "![cat.png](https://memos.example.com/file/resources/photo/cat.png)\n[memos/abc](https://memos.example.com/memos/abc)",
+		},
+		{
+			markdown: "- [x] done\n1. first",
+			expected: "- [x] done\n1. first",
+		},
+		{
+			markdown: "| a | b |\n| --- | --- |\n| 1 | 2 |",
+			expected: "| a | b |\n| --- | --- |\n| 1 | 2 |",
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, render(t, test.markdown, RenderCommonMark), test.markdown)
+	}
+}
+
+func TestRenderSlack(t *testing.T) {
+	tests := []struct {
+		markdown string
+		expected string
+	}{
+		{
+			markdown: "# Title\n**bold** *italic* ~~old~~ `code`",
+			expected: "*Title*\n*bold* _italic_ ~old~ `code`",
+		},
+		{
+			markdown: "[docs](https://example.com) a < b & c",
+			expected: "<https://example.com|docs> a &lt; b &amp; c",
+		},
+		{
+			markdown: "- one\n- [ ] two\n> quoted",
+			expected: "• one\n☐ two\n> quoted",
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, render(t, test.markdown, RenderSlack), test.markdown)
+	}
+}
+
+func TestRenderPlainText(t *testing.T) {
+	tests := []struct {
+		markdown string
+		expected string
+	}{
+		{
+			markdown: "# Title\n**bold** [docs](https://example.com) #tag",
+			expected: "Title\nbold docs #tag",
+		},
+		{
+			markdown: "![[resources/photo]]\n- [x] done\n* item",
+			expected: "cat.png\n[x] done\n- item",
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, render(t, test.markdown, RenderPlainText), test.markdown)
+	}
+}
@@ -0,0 +1,342 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/usememos/gomark/ast"
+)
+
+type textFormat int
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+const (
+	formatCommonMark textFormat = iota
+	formatSlack
+	formatPlainText
+)
+
+// RenderCommonMark renders the nodes to CommonMark, with the GFM tables, task lists and strikethroughs.
+// The syntax specific to memos is converted: references become links, and highlights, subscripts and
+// superscripts become inline HTML.
+func RenderCommonMark(nodes []ast.Node, options Options) string {
+	return renderText(nodes, options, formatCommonMark)
+}
+
+// RenderSlack renders the nodes to the mrkdwn format of Slack messages.
+// Reference: https://api.slack.com/reference/surfaces/formatting
+func RenderSlack(nodes []ast.Node, options Options) string {
+	return renderText(nodes, options, formatSlack)
+}
+
+// RenderPlainText renders the nodes to plain text, keeping the list markers and the line breaks.
+func RenderPlainText(nodes []ast.Node, options Options) string {
+	return renderText(nodes, options, formatPlainText)
+}
+
+func renderText(nodes []ast.Node, options Options, format textFormat) string {
+	r := &textRenderer{options: options, format: format}
+	r.renderNodes(nodes)
+	return strings.TrimSpace(r.output.String())
+}
+
+type textRenderer struct {
+	options Options
+	format  textFormat
+	output  strings.Builder
+}
+
+func (r *textRenderer) renderNodes(nodes []ast.Node) {
+	for _, node := range nodes {
+		r.renderNode(node)
+	}
+}
+
+// renderToString renders the nodes on their own, e.g. to prefix their lines.
+func (r *textRenderer) renderToString(nodes []ast.Node) string {
+	child := &textRenderer{options: r.options, format: r.format}
+	child.renderNodes(nodes)
+	return child.output.String()
+}
+
+func (r *textRenderer) write(format string, args ...any) {
+	fmt.Fprintf(&r.output, format, args...)
+}
+
+func (r *textRenderer) renderNode(node ast.Node) {
+	switch n := node.(type) {
+	case *ast.LineBreak:
+		r.output.WriteString("\n")
+	case *ast.Paragraph:
+		r.renderNodes(n.Children)
+	case *ast.CodeBlock:
+		r.renderCodeBlock(n.Language, n.Content)
+	case *ast.Heading:
+		switch r.format {
+		case formatCommonMark:
+			r.write("%s %s", strings.Repeat("#", n.Level), r.renderToString(n.Children))
+		case formatSlack:
+			r.write("*%s*", r.renderToString(n.Children))
+		default:
+			r.renderNodes(n.Children)
+		}
+	case *ast.HorizontalRule:
+		if r.format != formatPlainText {
+			r.output.WriteString("---")
+		}
+	case *ast.Blockquote:
+		content := r.renderToString(n.Children)
+		if r.format == formatPlainText {
+			r.output.WriteString(content)
+			break
+		}
+		lines := strings.Split(content, "\n")
+		for i, line := range lines {
+			lines[i] = "> " + line
+		}
+		r.output.WriteString(strings.Join(lines, "\n"))
+	case *ast.List:
+		r.renderNodes(n.Children)
+	case *ast.UnorderedListItem:
+		symbol := n.Symbol
+		if r.format == formatSlack {
+			symbol = "•"
+		} else if r.format == formatPlainText {
+			symbol = "-"
+		}
+		r.write("%s%s %s", strings.Repeat(" ", n.Indent), symbol, r.renderToString(n.Children))
+	case *ast.OrderedListItem:
+		r.write("%s%s. %s", strings.Repeat(" ", n.Indent), n.Number, r.renderToString(n.Children))
+	case *ast.TaskListItem:
+		r.renderTaskListItem(n)
+	case *ast.MathBlock:
+		r.renderCodeBlock("math", n.Content)
+	case *ast.Table:
+		r.renderTable(n)
+	case *ast.EmbeddedContent:
+		reference := r.options.resolve(n.ResourceName)
+		if reference.isImage() {
+			r.renderImage(reference.URL, reference.Title)
+		} else {
+			r.renderLink(reference.URL, r.escape(reference.Title))
+		}
+	case *ast.Text:
+		r.text(n.Content)
+	case *ast.Bold:
+		r.enclose(strings.Repeat(n.Symbol, 2), "*", n.Children)
+	case *ast.Italic:
+		r.enclose(n.Symbol, "_", n.Children)
+	case *ast.BoldItalic:
+		switch r.format {
+		case formatCommonMark:
+			r.write("%s%s%s", strings.Repeat(n.Symbol, 3), n.Content, strings.Repeat(n.Symbol, 3))
+		case formatSlack:
+			r.output.WriteString("*_")
+			r.text(n.Content)
+			r.output.WriteString("_*")
+		default:
+			r.text(n.Content)
+		}
+	case *ast.Code:
+		r.renderCode(n.Content)
+	case *ast.Image:
+		r.renderImage(n.URL, n.AltText)
+	case *ast.Link:
+		r.renderLink(n.URL, r.renderToString(n.Content))
+	case *ast.AutoLink:
+		r.renderAutoLink(n)
+	case *ast.Tag:
+		r.text("#" + n.Content)
+	case *ast.Strikethrough:
+		switch r.format {
+		case formatCommonMark:
+			r.write("~~%s~~", n.Content)
+		case formatSlack:
+			r.output.WriteString("~")
+			r.text(n.Content)
+			r.output.WriteString("~")
+		default:
+			r.text(n.Content)
+		}
+	case *ast.EscapingCharacter:
+		if r.format == formatCommonMark {
+			r.output.WriteString("\\")
+		}
+		r.text(n.Symbol)
+	case *ast.Math:
+		r.renderCode(n.Content)
+	case *ast.Highlight:
+		r.renderInlineHTML("mark", n.Content)
+	case *ast.Subscript:
+		r.renderInlineHTML("sub", n.Content)
+	case *ast.Superscript:
+		r.renderInlineHTML("sup", n.Content)
+	case *ast.ReferencedContent:
+		reference := r.options.resolve(n.ResourceName)
+		r.renderLink(reference.URL, r.escape(reference.Title))
+	case *ast.Spoiler:
+		r.text(n.Content)
+	case *ast.HTMLElement:
+		if strings.EqualFold(n.TagName, "br") {
+			if r.format == formatCommonMark {
+				r.output.WriteString("<br />")
+			} else {
+				r.output.WriteString("\n")
+			}
+		}
+	default:
+	}
+}
+
+// text writes the text, escaped for Slack.
+func (r *textRenderer) text(text string) {
+	r.output.WriteString(r.escape(text))
+}
+
+// escape escapes the text for Slack, where &, < and > are control characters.
+func (r *textRenderer) escape(text string) string {
+	if r.format == formatSlack {
+		return slackEscaper.Replace(text)
+	}
+	return text
+}
+
+// enclose writes the children between the CommonMark or the Slack symbol.
+func (r *textRenderer) enclose(commonMarkSymbol, slackSymbol string, children []ast.Node) {
+	content := r.renderToString(children)
+	switch r.format {
+	case formatCommonMark:
+		r.write("%s%s%s", commonMarkSymbol, content, commonMarkSymbol)
+	case formatSlack:
+		r.write("%s%s%s", slackSymbol, content, slackSymbol)
+	default:
+		r.output.WriteString(content)
+	}
+}
+
+func (r *textRenderer) renderCodeBlock(language, content string) {
+	switch r.format {
+	case formatCommonMark:
+		r.write("```%s\n%s\n```", language, content)
+	case formatSlack:
+		r.write("```\n%s\n```", content)
+	default:
+		r.output.WriteString(content)
+	}
+}
+
+func (r *textRenderer) renderCode(content string) {
+	if r.format == formatPlainText {
+		r.output.WriteString(content)
+		return
+	}
+	// A code span containing backticks is enclosed in double backticks.
+	if strings.Contains(content, "`") {
+		r.write("`` %s ``", content)
+		return
+	}
+	r.write("`%s`", content)
+}
+
+func (r *textRenderer) renderInlineHTML(tag, content string) {
+	if r.format == formatCommonMark {
+		r.write("<%s>%s</%s>", tag, html.EscapeString(content), tag)
+		return
+	}
+	r.text(content)
+}
+
+func (r *textRenderer) renderTaskListItem(node *ast.TaskListItem) {
+	indent := strings.Repeat(" ", node.Indent)
+	content := r.renderToString(node.Children)
+	switch r.format {
+	case formatCommonMark:
+		complete := " "
+		if node.Complete {
+			complete = "x"
+		}
+		r.write("%s%s [%s] %s", indent, node.Symbol, complete, content)
+	case formatSlack:
+		box := "☐"
+		if node.Complete {
+			box = "☑"
+		}
+		r.write("%s%s %s", indent, box, content)
+	default:
+		complete := " "
+		if node.Complete {
+			complete = "x"
+		}
+		r.write("%s[%s] %s", indent, complete, content)
+	}
+}
+
+func (r *textRenderer) renderTable(node *ast.Table) {
+	rows := [][]ast.Node{node.Header}
+	rows = append(rows, node.Rows...)
+	lines := []string{}
+	for i, row := range rows {
+		cells := []string{}
+		for _, cell := range row {
+			cells = append(cells, strings.TrimSpace(r.renderToString([]ast.Node{cell})))
+		}
+		switch r.format {
+		case formatCommonMark:
+			lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+			if i == 0 {
+				lines = append(lines, "| "+strings.Join(node.Delimiter, " | ")+" |")
+			}
+		case formatSlack:
+			lines = append(lines, strings.Join(cells, " | "))
+		default:
+			lines = append(lines, strings.Join(cells, "\t"))
+		}
+	}
+	r.output.WriteString(strings.Join(lines, "\n"))
+}
+
+func (r *textRenderer) renderImage(rawURL, alt string) {
+	src, ok := r.options.safeURL(rawURL)
+	switch {
+	case !ok || r.format == formatPlainText:
+		r.text(alt)
+	case r.format == formatCommonMark:
+		r.write("![%s](%s)", alt, src)
+	default:
+		r.write("<%s|%s>", src, r.escape(alt))
+	}
+}
+
+// renderLink writes a link with the rendered content, or only the content when the URL is unsafe.
+// The content is already escaped.
+func (r *textRenderer) renderLink(rawURL, content string) {
+	href, ok := r.options.safeURL(rawURL)
+	switch {
+	case !ok || r.format == formatPlainText:
+		r.output.WriteString(content)
+	case r.format == formatCommonMark:
+		r.write("[%s](%s)", content, href)
+	default:
+		r.write("<%s|%s>", href, content)
+	}
+}
+
+func (r *textRenderer) renderAutoLink(node *ast.AutoLink) {
+	href, ok := r.options.autoLinkURL(node)
+	switch {
+	case !ok:
+		r.text(autoLinkText(node))
+	case r.format == formatPlainText:
+		r.text(node.URL)
+	case r.format == formatCommonMark:
+		if node.IsRawText {
+			r.output.WriteString(node.URL)
+		} else {
+			r.write("<%s>", href)
+		}
+	default:
+		r.write("<%s>", href)
+	}
+}
@@ -0,0 +1,186 @@
+// Package ratelimit implements in-memory token bucket rate limiting and lockouts with exponential backoff.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often idle entries are dropped.
+const sweepInterval = time.Minute
+
+// Policy is the rate of a token bucket.
+type Policy struct {
+	// Rate is the number of tokens added per second.
+	Rate float64
+	// Burst is the size of the bucket.
+	Burst int
+}
+
+// PerMinute returns a policy allowing requests per minute with the given burst.
+func PerMinute(requests, burst int) Policy {
+	return Policy{
+		Rate:  float64(requests) / time.Minute.Seconds(),
+		Burst: burst,
+	}
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter is a set of token buckets identified by keys.
+type Limiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewLimiter returns an empty limiter.
+func NewLimiter() *Limiter {
+	return &Limiter{
+		buckets: map[string]*bucket{},
+	}
+}
+
+// Allow takes a token from the bucket of the key. When the bucket is empty, it returns false
+// with the time until the next token is available.
+func (l *Limiter) Allow(key string, policy Policy, now time.Time) (bool, time.Duration) {
+	if policy.Rate <= 0 || policy.Burst <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(policy.Burst), last: now}
+		l.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(policy.Burst), b.tokens+elapsed.Seconds()*policy.Rate)
+		b.last = now
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / policy.Rate * float64(time.Second))
+	return false, wait
+}
+
+// sweep drops the buckets that have not been used for a while. Callers hold the lock.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= sweepInterval {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// LockoutPolicy configures when a key is locked out after failures.
+type LockoutPolicy struct {
+	// MaxAttempts is the number of consecutive failures that lock the key.
+	MaxAttempts int
+	// Duration is the first lockout duration. Each following lockout doubles it.
+	Duration time.Duration
+	// MaxDuration caps the lockout duration. Failures older than it are forgotten.
+	MaxDuration time.Duration
+}
+
+type lockoutEntry struct {
+	failures    int
+	lockouts    int
+	lockedUntil time.Time
+	lastFailure time.Time
+}
+
+// Lockout tracks consecutive failures per key and locks keys out with exponential backoff.
+type Lockout struct {
+	mu        sync.Mutex
+	entries   map[string]*lockoutEntry
+	lastSweep time.Time
+}
+
+// NewLockout returns an empty lockout.
+func NewLockout() *Lockout {
+	return &Lockout{
+		entries: map[string]*lockoutEntry{},
+	}
+}
+
+// Check returns how long the key stays locked out, or zero if it is not locked out.
+func (l *Lockout) Check(key string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry, ok := l.entries[key]
+	if !ok || !now.Before(entry.lockedUntil) {
+		return 0
+	}
+	return entry.lockedUntil.Sub(now)
+}
+
+// Fail records a failure of the key. It returns the lockout duration if the failure locked the key out.
+func (l *Lockout) Fail(key string, policy LockoutPolicy, now time.Time) time.Duration {
+	if policy.MaxAttempts <= 0 || policy.Duration <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now, policy.MaxDuration)
+
+	entry, ok := l.entries[key]
+	if !ok {
+		entry = &lockoutEntry{}
+		l.entries[key] = entry
+	}
+	entry.failures++
+	entry.lastFailure = now
+	if entry.failures < policy.MaxAttempts {
+		return 0
+	}
+
+	entry.failures = 0
+	entry.lockouts++
+	duration := policy.Duration
+	for i := 1; i < entry.lockouts && (policy.MaxDuration <= 0 || duration < policy.MaxDuration); i++ {
+		duration *= 2
+	}
+	if policy.MaxDuration > 0 && duration > policy.MaxDuration {
+		duration = policy.MaxDuration
+	}
+	entry.lockedUntil = now.Add(duration)
+	return duration
+}
+
+// Reset forgets the failures of the key.
+func (l *Lockout) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.entries, key)
+}
+
+// sweep drops the entries without recent failures. Callers hold the lock.
+func (l *Lockout) sweep(now time.Time, ttl time.Duration) {
+	if ttl <= 0 || now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, entry := range l.entries {
+		if now.Before(entry.lockedUntil) {
+			continue
+		}
+		if now.Sub(entry.lastFailure) >= ttl {
+			delete(l.entries, key)
+		}
+	}
+}
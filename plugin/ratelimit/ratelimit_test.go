@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	limiter := NewLimiter()
+	policy := PerMinute(60, 2)
+	now := time.Unix(1700000000, 0)
+
+	ok, _ := limiter.Allow("a", policy, now)
+	require.True(t, ok)
+	ok, _ = limiter.Allow("a", policy, now)
+	require.True(t, ok)
+	ok, wait := limiter.Allow("a", policy, now)
+	require.False(t, ok)
+	require.Equal(t, time.Second, wait)
+
+	// Keys have their own buckets.
+	ok, _ = limiter.Allow("b", policy, now)
+	require.True(t, ok)
+
+	// Tokens are refilled at the policy rate.
+	ok, _ = limiter.Allow("a", policy, now.Add(time.Second))
+	require.True(t, ok)
+	ok, _ = limiter.Allow("a", policy, now.Add(time.Second))
+	require.False(t, ok)
+
+	// A zero policy does not limit.
+	ok, _ = limiter.Allow("a", Policy{}, now)
+	require.True(t, ok)
+}
+
+func TestLockout(t *testing.T) {
+	lockout := NewLockout()
+	policy := LockoutPolicy{MaxAttempts: 3, Duration: time.Minute, MaxDuration: 3 * time.Minute}
+	now := time.Unix(1700000000, 0)
+
+	require.Zero(t, lockout.Fail("user", policy, now))
+	require.Zero(t, lockout.Fail("user", policy, now))
+	require.Equal(t, time.Minute, lockout.Fail("user", policy, now))
+	require.Equal(t, time.Minute, lockout.Check("user", now))
+	require.Zero(t, lockout.Check("other", now))
+
+	// Each following lockout doubles the duration up to the maximum.
+	now = now.Add(time.Minute)
+	require.Zero(t, lockout.Check("user", now))
+	lockout.Fail("user", policy, now)
+	lockout.Fail("user", policy, now)
+	require.Equal(t, 2*time.Minute, lockout.Fail("user", policy, now))
+	now = now.Add(2 * time.Minute)
+	lockout.Fail("user", policy, now)
+	lockout.Fail("user", policy, now)
+	require.Equal(t, 3*time.Minute, lockout.Fail("user", policy, now))
+
+	lockout.Reset("user")
+	require.Zero(t, lockout.Check("user", now))
+}
@@ -0,0 +1,46 @@
+// Package database implements the storage keeping contents inline in the database rows of resources.
+package database
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/storage"
+)
+
+// Storage keeps contents in the objects, which are then saved with the resources.
+type Storage struct{}
+
+// New returns the database storage.
+func New() *Storage {
+	return &Storage{}
+}
+
+// Put reads the whole content, since it is saved in a database row.
+func (*Storage) Put(_ context.Context, _ string, _ string, content io.Reader, _ int64) (*storage.Object, error) {
+	blob, err := io.ReadAll(content)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read content")
+	}
+	return &storage.Object{Blob: blob}, nil
+}
+
+func (*Storage) Get(_ context.Context, object *storage.Object) ([]byte, error) {
+	return object.Blob, nil
+}
+
+func (*Storage) Open(_ context.Context, object *storage.Object, offset int64) (io.ReadCloser, error) {
+	reader := bytes.NewReader(object.Blob)
+	if _, err := reader.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(reader), nil
+}
+
+// Delete does nothing, since the content goes away with the resource row.
+func (*Storage) Delete(_ context.Context, _ *storage.Object) error {
+	return nil
+}
@@ -0,0 +1,82 @@
+// Package local implements the storage keeping contents as files on the local file system.
+package local
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/storage"
+)
+
+// Storage stores contents as files under a root directory.
+type Storage struct {
+	root string
+}
+
+// New returns a storage rooted at the directory. Relative keys are resolved against it.
+func New(root string) *Storage {
+	return &Storage{root: root}
+}
+
+// Put writes the content to a temporary file first, so a partial write never has the name of the key.
+func (s *Storage) Put(_ context.Context, key string, _ string, content io.Reader, _ int64) (*storage.Object, error) {
+	osPath := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(osPath), os.ModePerm); err != nil {
+		return nil, errors.Wrap(err, "failed to create directory")
+	}
+	tempFile, err := os.CreateTemp(filepath.Dir(osPath), ".upload-*")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create file")
+	}
+	defer os.Remove(tempFile.Name())
+	if _, err := io.Copy(tempFile, content); err != nil {
+		tempFile.Close()
+		return nil, errors.Wrap(err, "failed to write file")
+	}
+	if err := tempFile.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to write file")
+	}
+	if err := os.Rename(tempFile.Name(), osPath); err != nil {
+		return nil, errors.Wrap(err, "failed to rename file")
+	}
+	return &storage.Object{Key: key}, nil
+}
+
+func (s *Storage) Get(_ context.Context, object *storage.Object) ([]byte, error) {
+	content, err := os.ReadFile(s.path(object.Key))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the file")
+	}
+	return content, nil
+}
+
+func (s *Storage) Open(_ context.Context, object *storage.Object, offset int64) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(object.Key))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open the file")
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, errors.Wrap(err, "failed to seek the file")
+	}
+	return file, nil
+}
+
+func (s *Storage) Delete(_ context.Context, object *storage.Object) error {
+	if err := os.Remove(s.path(object.Key)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to delete local file")
+	}
+	return nil
+}
+
+func (s *Storage) path(key string) string {
+	osPath := filepath.FromSlash(key)
+	if filepath.IsAbs(osPath) {
+		return osPath
+	}
+	return filepath.Join(s.root, osPath)
+}
@@ -0,0 +1,40 @@
+package local
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	s := New(root)
+
+	object, err := s.Put(ctx, "assets/notes.txt", "text/plain", strings.NewReader("notes"), 5)
+	require.NoError(t, err)
+	require.Equal(t, "assets/notes.txt", object.Key)
+	content, err := os.ReadFile(filepath.Join(root, "assets", "notes.txt"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("notes"), content)
+	content, err = s.Get(ctx, object)
+	require.NoError(t, err)
+	require.Equal(t, []byte("notes"), content)
+
+	// Absolute keys are not resolved against the root.
+	absolute := filepath.Join(t.TempDir(), "absolute.txt")
+	object, err = s.Put(ctx, absolute, "text/plain", strings.NewReader("absolute"), 8)
+	require.NoError(t, err)
+	content, err = os.ReadFile(absolute)
+	require.NoError(t, err)
+	require.Equal(t, []byte("absolute"), content)
+
+	require.NoError(t, s.Delete(ctx, object))
+	require.NoError(t, s.Delete(ctx, object))
+	_, err = s.Get(ctx, object)
+	require.Error(t, err)
+}
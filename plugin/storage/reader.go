@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Reader reads the content of an object as a seekable stream. It opens the content at the offset of
+// the first read after a seek, so ranges of remote contents are fetched without reading the rest.
+type Reader struct {
+	ctx     context.Context
+	storage Storage
+	object  *Object
+	size    int64
+
+	offset int64
+	reader io.ReadCloser
+}
+
+// NewReader returns a reader of the content of the object with the size.
+func NewReader(ctx context.Context, storage Storage, object *Object, size int64) *Reader {
+	return &Reader{
+		ctx:     ctx,
+		storage: storage,
+		object:  object,
+		size:    size,
+	}
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.reader == nil {
+		reader, err := r.storage.Open(r.ctx, r.object, r.offset)
+		if err != nil {
+			return 0, err
+		}
+		r.reader = reader
+	}
+	if remaining := r.size - r.offset; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := r.reader.Read(p)
+	r.offset += int64(n)
+	if err == io.EOF && r.offset < r.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	if offset != r.offset && r.reader != nil {
+		r.reader.Close()
+		r.reader = nil
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *Reader) Close() error {
+	if r.reader == nil {
+		return nil
+	}
+	err := r.reader.Close()
+	r.reader = nil
+	return err
+}
@@ -0,0 +1,44 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/storage"
+	"github.com/usememos/memos/plugin/storage/database"
+)
+
+func TestReader(t *testing.T) {
+	ctx := context.Background()
+	object := &storage.Object{Blob: []byte("0123456789")}
+	reader := storage.NewReader(ctx, database.New(), object, 10)
+	defer reader.Close()
+
+	size, err := reader.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), size)
+
+	_, err = reader.Seek(3, io.SeekStart)
+	require.NoError(t, err)
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(reader, buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte("3456"), buf)
+
+	// Reading continues from the current position.
+	rest, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, []byte("789"), rest)
+
+	_, err = reader.Seek(-2, io.SeekEnd)
+	require.NoError(t, err)
+	rest, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, []byte("89"), rest)
+
+	_, err = reader.Seek(-1, io.SeekStart)
+	require.Error(t, err)
+}
@@ -2,6 +2,7 @@ package s3
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/pkg/errors"
 
+	"github.com/usememos/memos/plugin/storage"
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
@@ -62,6 +64,23 @@ func (c *Client) UploadObject(ctx context.Context, key string, fileType string,
 	return *resultKey, nil
 }
 
+// GetObject returns the content of an object in S3.
+func (c *Client) GetObject(ctx context.Context, key string) ([]byte, error) {
+	output, err := c.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: c.Bucket,
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+	defer output.Body.Close()
+	content, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read object")
+	}
+	return content, nil
+}
+
 // PresignGetObject presigns an object in S3.
 func (c *Client) PresignGetObject(ctx context.Context, key string) (string, error) {
 	presignClient := s3.NewPresignClient(c.Client)
@@ -90,3 +109,35 @@ func (c *Client) DeleteObject(ctx context.Context, key string) error {
 	}
 	return nil
 }
+
+// Put uploads the content as an object with the key. Large contents are uploaded in parts.
+func (c *Client) Put(ctx context.Context, key string, contentType string, content io.Reader, _ int64) (*storage.Object, error) {
+	key, err := c.UploadObject(ctx, key, contentType, content)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to upload object")
+	}
+	return &storage.Object{Key: key}, nil
+}
+
+func (c *Client) Get(ctx context.Context, object *storage.Object) ([]byte, error) {
+	return c.GetObject(ctx, object.Key)
+}
+
+func (c *Client) Open(ctx context.Context, object *storage.Object, offset int64) (io.ReadCloser, error) {
+	input := &s3.GetObjectInput{
+		Bucket: c.Bucket,
+		Key:    aws.String(object.Key),
+	}
+	if offset > 0 {
+		input.Range = aws.String(fmt.Sprintf("bytes=%d-", offset))
+	}
+	output, err := c.Client.GetObject(ctx, input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+	return output.Body, nil
+}
+
+func (c *Client) Delete(ctx context.Context, object *storage.Object) error {
+	return c.DeleteObject(ctx, object.Key)
+}
@@ -0,0 +1,91 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// fakeS3 is a stand-in for an S3 server with path style addressing, supporting the object operations used by Client.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.objects[r.URL.Path] = content
+		w.Header().Set("ETag", `"etag"`)
+	case http.MethodGet:
+		content, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code></Error>`))
+			return
+		}
+		var offset int
+		if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &offset); err == nil {
+			w.WriteHeader(http.StatusPartialContent)
+			content = content[offset:]
+		}
+		_, _ = w.Write(content)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client, err := NewClient(ctx, &storepb.StorageS3Config{
+		AccessKeyId:     "access-key",
+		AccessKeySecret: "secret",
+		Endpoint:        server.URL,
+		Region:          "us-east-1",
+		Bucket:          "memos",
+		UsePathStyle:    true,
+	})
+	require.NoError(t, err)
+
+	object, err := client.Put(ctx, "assets/notes.txt", "text/plain", strings.NewReader("notes"), 5)
+	require.NoError(t, err)
+	require.Equal(t, "assets/notes.txt", object.Key)
+	require.Equal(t, []byte("notes"), fake.objects["/memos/assets/notes.txt"])
+	content, err := client.Get(ctx, object)
+	require.NoError(t, err)
+	require.Equal(t, []byte("notes"), content)
+	reader, err := client.Open(ctx, object, 2)
+	require.NoError(t, err)
+	content, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.Equal(t, []byte("tes"), content)
+
+	require.NoError(t, client.Delete(ctx, object))
+	require.Empty(t, fake.objects)
+	_, err = client.Get(ctx, object)
+	require.Error(t, err)
+}
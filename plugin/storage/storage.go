@@ -0,0 +1,28 @@
+// Package storage defines the backends the contents of resources are stored in.
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// Object is the location of a content in a storage.
+type Object struct {
+	// Key is the path of the content in the storage. It is empty for contents kept inline.
+	Key string
+	// Blob is the content itself, for storages that keep it inline with the resource.
+	Blob []byte
+}
+
+// Storage stores contents by keys.
+type Storage interface {
+	// Put stores the content of the size under the key, replacing any content with the same key.
+	// The content is streamed to the storage unless it keeps contents inline.
+	Put(ctx context.Context, key string, contentType string, content io.Reader, size int64) (*Object, error)
+	// Get returns the content of the object.
+	Get(ctx context.Context, object *Object) ([]byte, error)
+	// Open returns a reader of the content of the object, starting at the offset.
+	Open(ctx context.Context, object *Object, offset int64) (io.ReadCloser, error)
+	// Delete deletes the content of the object. Deleting a missing content is not an error.
+	Delete(ctx context.Context, object *Object) error
+}
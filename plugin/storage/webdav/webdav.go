@@ -0,0 +1,147 @@
+// Package webdav implements the storage keeping contents on a WebDAV server.
+package webdav
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/storage"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// Client stores contents under the base URL of a WebDAV collection.
+type Client struct {
+	baseURL  *url.URL
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewClient returns a client of the WebDAV collection of the config.
+func NewClient(config *storepb.StorageWebDAVConfig) (*Client, error) {
+	baseURL, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid webdav url")
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, errors.Errorf("invalid webdav url scheme %q", baseURL.Scheme)
+	}
+	return &Client{
+		baseURL:  baseURL,
+		username: config.Username,
+		password: config.Password,
+		client:   &http.Client{},
+	}, nil
+}
+
+// Put creates the missing parent collections of the key and uploads the content.
+func (c *Client) Put(ctx context.Context, key string, contentType string, content io.Reader, size int64) (*storage.Object, error) {
+	key = strings.TrimPrefix(path.Clean("/"+key), "/")
+	dir := ""
+	for _, segment := range strings.Split(path.Dir(key), "/") {
+		if segment == "." {
+			break
+		}
+		dir = path.Join(dir, segment)
+		response, err := c.do(ctx, "MKCOL", dir+"/", nil, nil, 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create collection")
+		}
+		response.Body.Close()
+		// 405 Method Not Allowed means the collection exists already.
+		if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusMethodNotAllowed {
+			return nil, errors.Errorf("failed to create collection %s: %s", dir, response.Status)
+		}
+	}
+
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	response, err := c.do(ctx, http.MethodPut, key, header, content, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to upload file")
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		return nil, errors.Errorf("failed to upload file %s: %s", key, response.Status)
+	}
+	return &storage.Object{Key: key}, nil
+}
+
+func (c *Client) Get(ctx context.Context, object *storage.Object) ([]byte, error) {
+	response, err := c.do(ctx, http.MethodGet, object.Key, nil, nil, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get file")
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to get file %s: %s", object.Key, response.Status)
+	}
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+	return content, nil
+}
+
+func (c *Client) Open(ctx context.Context, object *storage.Object, offset int64) (io.ReadCloser, error) {
+	header := http.Header{}
+	if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	response, err := c.do(ctx, http.MethodGet, object.Key, header, nil, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get file")
+	}
+	switch response.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// The server ignored the range, so skip to the offset.
+		if _, err := io.CopyN(io.Discard, response.Body, offset); err != nil {
+			response.Body.Close()
+			return nil, errors.Wrap(err, "failed to skip to the offset")
+		}
+	default:
+		response.Body.Close()
+		return nil, errors.Errorf("failed to get file %s: %s", object.Key, response.Status)
+	}
+	return response.Body, nil
+}
+
+func (c *Client) Delete(ctx context.Context, object *storage.Object) error {
+	response, err := c.do(ctx, http.MethodDelete, object.Key, nil, nil, 0)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete file")
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 && response.StatusCode != http.StatusNotFound {
+		return errors.Errorf("failed to delete file %s: %s", object.Key, response.Status)
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, key string, header http.Header, body io.Reader, size int64) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL.JoinPath(key).String(), body)
+	if err != nil {
+		return nil, err
+	}
+	// Send the length rather than chunks, which some servers reject for uploads.
+	if body != nil {
+		request.ContentLength = size
+	}
+	for name, values := range header {
+		request.Header[name] = values
+	}
+	if c.username != "" || c.password != "" {
+		request.SetBasicAuth(c.username, c.password)
+	}
+	return c.client.Do(request)
+}
@@ -0,0 +1,53 @@
+package webdav
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/webdav"
+
+	"github.com/usememos/memos/plugin/storage"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	handler := &webdav.Handler{
+		Prefix:     "/dav",
+		FileSystem: webdav.NewMemFS(),
+		LockSystem: webdav.NewMemLS(),
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client, err := NewClient(&storepb.StorageWebDAVConfig{Url: server.URL + "/dav"})
+	require.NoError(t, err)
+
+	object, err := client.Put(ctx, "assets/2024/notes.txt", "text/plain", strings.NewReader("notes"), 5)
+	require.NoError(t, err)
+	require.Equal(t, "assets/2024/notes.txt", object.Key)
+	// Putting into existing collections overwrites the file.
+	_, err = client.Put(ctx, "assets/2024/notes.txt", "text/plain", strings.NewReader("new notes"), 9)
+	require.NoError(t, err)
+	content, err := client.Get(ctx, object)
+	require.NoError(t, err)
+	require.Equal(t, []byte("new notes"), content)
+	reader, err := client.Open(ctx, object, 4)
+	require.NoError(t, err)
+	content, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.Equal(t, []byte("notes"), content)
+
+	require.NoError(t, client.Delete(ctx, object))
+	_, err = client.Get(ctx, object)
+	require.Error(t, err)
+	require.NoError(t, client.Delete(ctx, &storage.Object{Key: "assets/missing.txt"}))
+
+	_, err = NewClient(&storepb.StorageWebDAVConfig{Url: "file:///tmp"})
+	require.Error(t, err)
+}
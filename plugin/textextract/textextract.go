@@ -0,0 +1,61 @@
+// Package textextract extracts the plain text of documents for searching.
+package textextract
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/pkg/errors"
+)
+
+// Supported reports whether the text of the content type can be extracted.
+func Supported(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+	return strings.HasPrefix(mediaType, "text/") || mediaType == "application/json" || mediaType == "application/pdf"
+}
+
+// Extract returns the plain text of the content with its whitespace collapsed, truncated to maxLength bytes.
+func Extract(contentType string, content []byte, maxLength int) (string, error) {
+	if !Supported(contentType) {
+		return "", errors.Errorf("unsupported content type %q", contentType)
+	}
+
+	var text string
+	if strings.HasPrefix(strings.ToLower(contentType), "application/pdf") {
+		reader, err := pdf.NewReader(bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read pdf")
+		}
+		plainText, err := reader.GetPlainText()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get pdf text")
+		}
+		// Read a bit more than needed, since the whitespace is collapsed afterwards.
+		data, err := io.ReadAll(io.LimitReader(plainText, int64(maxLength)*2))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read pdf text")
+		}
+		text = string(data)
+	} else {
+		if len(content) > maxLength*2 {
+			content = content[:maxLength*2]
+		}
+		text = string(content)
+	}
+	return truncate(strings.Join(strings.Fields(strings.ToValidUTF8(text, " ")), " "), maxLength), nil
+}
+
+// truncate cuts the text to at most maxLength bytes without splitting a character.
+func truncate(text string, maxLength int) string {
+	if len(text) <= maxLength {
+		return text
+	}
+	for maxLength > 0 && !utf8.RuneStart(text[maxLength]) {
+		maxLength--
+	}
+	return text[:maxLength]
+}
@@ -0,0 +1,65 @@
+package textextract
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// buildPDF returns a single page PDF document showing the text.
+func buildPDF(text string) []byte {
+	stream := fmt.Sprintf("BT /F1 12 Tf 72 720 Td (%s) Tj ET", text)
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+	b := &strings.Builder{}
+	b.WriteString("%PDF-1.4\n")
+	offsets := []int{}
+	for i, object := range objects {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := b.Len()
+	fmt.Fprintf(b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return []byte(b.String())
+}
+
+func TestSupported(t *testing.T) {
+	require.True(t, Supported("text/plain; charset=utf-8"))
+	require.True(t, Supported("text/markdown"))
+	require.True(t, Supported("application/pdf"))
+	require.False(t, Supported("image/png"))
+	require.False(t, Supported("application/octet-stream"))
+}
+
+func TestExtractText(t *testing.T) {
+	text, err := Extract("text/plain", []byte("Quarterly\n\n report\tdraft"), 100)
+	require.NoError(t, err)
+	require.Equal(t, "Quarterly report draft", text)
+
+	text, err = Extract("text/plain", []byte("héllo wörld"), 2)
+	require.NoError(t, err)
+	require.Equal(t, "h", text)
+
+	_, err = Extract("image/png", []byte{}, 100)
+	require.Error(t, err)
+}
+
+func TestExtractPDF(t *testing.T) {
+	text, err := Extract("application/pdf", buildPDF("Invoice 42"), 100)
+	require.NoError(t, err)
+	require.Contains(t, text, "Invoice 42")
+
+	_, err = Extract("application/pdf", []byte("not a pdf"), 100)
+	require.Error(t, err)
+}
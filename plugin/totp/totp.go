@@ -0,0 +1,95 @@
+// Package totp implements time-based one-time passwords (RFC 6238) compatible with common authenticator apps.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Period is the time step of a code.
+	Period = 30 * time.Second
+	// Digits is the number of digits of a code.
+	Digits = 6
+	// Skew is the number of steps before and after the current one that are accepted.
+	Skew = 1
+
+	secretSize = 20
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random base32 encoded secret.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "failed to generate secret")
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// ProvisioningURI returns the otpauth URI rendered as a QR code for authenticator apps.
+func ProvisioningURI(issuer, accountName, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(int(Period.Seconds())))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// Step returns the time step of the given time.
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// GenerateCode returns the code of the secret at the given time step.
+func GenerateCode(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil {
+		return "", errors.Wrap(err, "invalid secret")
+	}
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", Digits, value%1000000), nil
+}
+
+// Validate checks the code against the secret around the given time and returns the matched time step.
+// Codes of steps up to lastUsedStep are rejected so that a code cannot be replayed.
+func Validate(secret, code string, t time.Time, lastUsedStep int64) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != Digits {
+		return 0, false
+	}
+	current := Step(t)
+	for step := current - Skew; step <= current+Skew; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+		expected, err := GenerateCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
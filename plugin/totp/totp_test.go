@@ -0,0 +1,63 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateCode(t *testing.T) {
+	// The SHA1 test vectors of RFC 6238, truncated to six digits.
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+	for _, test := range tests {
+		code, err := GenerateCode(secret, Step(time.Unix(test.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, test.code, code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	current := Step(now)
+
+	code, err := GenerateCode(secret, current-1)
+	require.NoError(t, err)
+	step, ok := Validate(secret, code, now, 0)
+	require.True(t, ok)
+	require.Equal(t, current-1, step)
+	// A used code cannot be replayed.
+	_, ok = Validate(secret, code, now, step)
+	require.False(t, ok)
+
+	code, err = GenerateCode(secret, current-2)
+	require.NoError(t, err)
+	_, ok = Validate(secret, code, now, 0)
+	require.False(t, ok)
+	_, ok = Validate(secret, "12345", now, 0)
+	require.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(ProvisioningURI("Memos", "alice", "JBSWY3DPEHPK3PXP"))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/Memos:alice", uri.Path)
+	require.Equal(t, "JBSWY3DPEHPK3PXP", uri.Query().Get("secret"))
+	require.Equal(t, "Memos", uri.Query().Get("issuer"))
+}
@@ -0,0 +1,923 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: api/v1/analytics_service.proto
+
+package apiv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AnalyticsPeriod int32
+
+const (
+	AnalyticsPeriod_ANALYTICS_PERIOD_UNSPECIFIED AnalyticsPeriod = 0
+	AnalyticsPeriod_DAY                          AnalyticsPeriod = 1
+	AnalyticsPeriod_WEEK                         AnalyticsPeriod = 2
+)
+
+// Enum value maps for AnalyticsPeriod.
+var (
+	AnalyticsPeriod_name = map[int32]string{
+		0: "ANALYTICS_PERIOD_UNSPECIFIED",
+		1: "DAY",
+		2: "WEEK",
+	}
+	AnalyticsPeriod_value = map[string]int32{
+		"ANALYTICS_PERIOD_UNSPECIFIED": 0,
+		"DAY":                          1,
+		"WEEK":                         2,
+	}
+)
+
+func (x AnalyticsPeriod) Enum() *AnalyticsPeriod {
+	p := new(AnalyticsPeriod)
+	*p = x
+	return p
+}
+
+func (x AnalyticsPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AnalyticsPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_analytics_service_proto_enumTypes[0].Descriptor()
+}
+
+func (AnalyticsPeriod) Type() protoreflect.EnumType {
+	return &file_api_v1_analytics_service_proto_enumTypes[0]
+}
+
+func (x AnalyticsPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnalyticsPeriod.Descriptor instead.
+func (AnalyticsPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_analytics_service_proto_rawDescGZIP(), []int{0}
+}
+
+type GetTicketMetricsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the project of the tickets, e.g. "projects/1".
+	// The tickets of every project the user can see when empty.
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// The start of the range. Defaults to 30 days before the end.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// The end of the range. Defaults to now.
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// The period the range is split into. Defaults to DAY.
+	Period        AnalyticsPeriod `protobuf:"varint,4,opt,name=period,proto3,enum=memos.api.v1.AnalyticsPeriod" json:"period,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTicketMetricsRequest) Reset() {
+	*x = GetTicketMetricsRequest{}
+	mi := &file_api_v1_analytics_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTicketMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTicketMetricsRequest) ProtoMessage() {}
+
+func (x *GetTicketMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_analytics_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTicketMetricsRequest.ProtoReflect.Descriptor instead.
+func (*GetTicketMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_analytics_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetTicketMetricsRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *GetTicketMetricsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetTicketMetricsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetTicketMetricsRequest) GetPeriod() AnalyticsPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return AnalyticsPeriod_ANALYTICS_PERIOD_UNSPECIFIED
+}
+
+type DurationPercentiles struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of durations.
+	Count         int32                `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	P50           *durationpb.Duration `protobuf:"bytes,2,opt,name=p50,proto3" json:"p50,omitempty"`
+	P85           *durationpb.Duration `protobuf:"bytes,3,opt,name=p85,proto3" json:"p85,omitempty"`
+	P95           *durationpb.Duration `protobuf:"bytes,4,opt,name=p95,proto3" json:"p95,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DurationPercentiles) Reset() {
+	*x = DurationPercentiles{}
+	mi := &file_api_v1_analytics_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DurationPercentiles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DurationPercentiles) ProtoMessage() {}
+
+func (x *DurationPercentiles) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_analytics_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DurationPercentiles.ProtoReflect.Descriptor instead.
+func (*DurationPercentiles) Descriptor() ([]byte, []int) {
+	return file_api_v1_analytics_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DurationPercentiles) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DurationPercentiles) GetP50() *durationpb.Duration {
+	if x != nil {
+		return x.P50
+	}
+	return nil
+}
+
+func (x *DurationPercentiles) GetP85() *durationpb.Duration {
+	if x != nil {
+		return x.P85
+	}
+	return nil
+}
+
+func (x *DurationPercentiles) GetP95() *durationpb.Duration {
+	if x != nil {
+		return x.P95
+	}
+	return nil
+}
+
+type TicketMetrics struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The tickets opened and closed in every period of the range.
+	Periods []*TicketMetrics_Period `protobuf:"bytes,1,rep,name=periods,proto3" json:"periods,omitempty"`
+	// The time from creating to closing the tickets closed in the range.
+	LeadTime *DurationPercentiles `protobuf:"bytes,2,opt,name=lead_time,json=leadTime,proto3" json:"lead_time,omitempty"`
+	// The time from starting to closing the tickets closed in the range.
+	CycleTime *DurationPercentiles `protobuf:"bytes,3,opt,name=cycle_time,json=cycleTime,proto3" json:"cycle_time,omitempty"`
+	// The tickets closed in the range by assignee.
+	Throughput []*TicketMetrics_AssigneeThroughput `protobuf:"bytes,4,rep,name=throughput,proto3" json:"throughput,omitempty"`
+	// The age at the end of the range of the tickets not closed.
+	BacklogAge    []*TicketMetrics_BacklogAgeBucket `protobuf:"bytes,5,rep,name=backlog_age,json=backlogAge,proto3" json:"backlog_age,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketMetrics) Reset() {
+	*x = TicketMetrics{}
+	mi := &file_api_v1_analytics_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketMetrics) ProtoMessage() {}
+
+func (x *TicketMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_analytics_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketMetrics.ProtoReflect.Descriptor instead.
+func (*TicketMetrics) Descriptor() ([]byte, []int) {
+	return file_api_v1_analytics_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TicketMetrics) GetPeriods() []*TicketMetrics_Period {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+func (x *TicketMetrics) GetLeadTime() *DurationPercentiles {
+	if x != nil {
+		return x.LeadTime
+	}
+	return nil
+}
+
+func (x *TicketMetrics) GetCycleTime() *DurationPercentiles {
+	if x != nil {
+		return x.CycleTime
+	}
+	return nil
+}
+
+func (x *TicketMetrics) GetThroughput() []*TicketMetrics_AssigneeThroughput {
+	if x != nil {
+		return x.Throughput
+	}
+	return nil
+}
+
+func (x *TicketMetrics) GetBacklogAge() []*TicketMetrics_BacklogAgeBucket {
+	if x != nil {
+		return x.BacklogAge
+	}
+	return nil
+}
+
+type TicketCumulativeFlow struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	Points        []*TicketCumulativeFlow_Point `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketCumulativeFlow) Reset() {
+	*x = TicketCumulativeFlow{}
+	mi := &file_api_v1_analytics_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketCumulativeFlow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketCumulativeFlow) ProtoMessage() {}
+
+func (x *TicketCumulativeFlow) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_analytics_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketCumulativeFlow.ProtoReflect.Descriptor instead.
+func (*TicketCumulativeFlow) Descriptor() ([]byte, []int) {
+	return file_api_v1_analytics_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TicketCumulativeFlow) GetPoints() []*TicketCumulativeFlow_Point {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type GetMemoActivityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the creator of the memos, e.g. "users/1".
+	// The memos of every user the user can see when empty.
+	Creator string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	// The start of the range. Defaults to 365 days before the end.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// The end of the range. Defaults to now.
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// The offset from UTC of the time zone of the weekdays and hours, e.g. "3600s".
+	UtcOffset     *durationpb.Duration `protobuf:"bytes,4,opt,name=utc_offset,json=utcOffset,proto3" json:"utc_offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemoActivityRequest) Reset() {
+	*x = GetMemoActivityRequest{}
+	mi := &file_api_v1_analytics_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemoActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemoActivityRequest) ProtoMessage() {}
+
+func (x *GetMemoActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_analytics_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemoActivityRequest.ProtoReflect.Descriptor instead.
+func (*GetMemoActivityRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_analytics_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetMemoActivityRequest) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *GetMemoActivityRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetMemoActivityRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetMemoActivityRequest) GetUtcOffset() *durationpb.Duration {
+	if x != nil {
+		return x.UtcOffset
+	}
+	return nil
+}
+
+type MemoActivity struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The hours with memos created in them.
+	Cells []*MemoActivity_Cell `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+	// The number of memos created in the range.
+	Total         int32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoActivity) Reset() {
+	*x = MemoActivity{}
+	mi := &file_api_v1_analytics_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoActivity) ProtoMessage() {}
+
+func (x *MemoActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_analytics_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoActivity.ProtoReflect.Descriptor instead.
+func (*MemoActivity) Descriptor() ([]byte, []int) {
+	return file_api_v1_analytics_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MemoActivity) GetCells() []*MemoActivity_Cell {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *MemoActivity) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type TicketMetrics_Period struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// The number of tickets created in the period.
+	Opened int32 `protobuf:"varint,2,opt,name=opened,proto3" json:"opened,omitempty"`
+	// The number of times tickets were closed in the period.
+	Closed        int32 `protobuf:"varint,3,opt,name=closed,proto3" json:"closed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketMetrics_Period) Reset() {
+	*x = TicketMetrics_Period{}
+	mi := &file_api_v1_analytics_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketMetrics_Period) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketMetrics_Period) ProtoMessage() {}
+
+func (x *TicketMetrics_Period) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_analytics_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketMetrics_Period.ProtoReflect.Descriptor instead.
+func (*TicketMetrics_Period) Descriptor() ([]byte, []int) {
+	return file_api_v1_analytics_service_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *TicketMetrics_Period) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *TicketMetrics_Period) GetOpened() int32 {
+	if x != nil {
+		return x.Opened
+	}
+	return 0
+}
+
+func (x *TicketMetrics_Period) GetClosed() int32 {
+	if x != nil {
+		return x.Closed
+	}
+	return 0
+}
+
+type TicketMetrics_AssigneeThroughput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the assignee, e.g. "users/1". Empty for the unassigned tickets.
+	Assignee string `protobuf:"bytes,1,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	// The number of tickets of the assignee closed in the range.
+	Closed        int32 `protobuf:"varint,2,opt,name=closed,proto3" json:"closed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketMetrics_AssigneeThroughput) Reset() {
+	*x = TicketMetrics_AssigneeThroughput{}
+	mi := &file_api_v1_analytics_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketMetrics_AssigneeThroughput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketMetrics_AssigneeThroughput) ProtoMessage() {}
+
+func (x *TicketMetrics_AssigneeThroughput) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_analytics_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketMetrics_AssigneeThroughput.ProtoReflect.Descriptor instead.
+func (*TicketMetrics_AssigneeThroughput) Descriptor() ([]byte, []int) {
+	return file_api_v1_analytics_service_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *TicketMetrics_AssigneeThroughput) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *TicketMetrics_AssigneeThroughput) GetClosed() int32 {
+	if x != nil {
+		return x.Closed
+	}
+	return 0
+}
+
+type TicketMetrics_BacklogAgeBucket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The minimum age of the tickets in the bucket.
+	MinAge *durationpb.Duration `protobuf:"bytes,1,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
+	// The age the tickets in the bucket are younger than, unset for the last bucket.
+	MaxAge        *durationpb.Duration `protobuf:"bytes,2,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	Count         int32                `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketMetrics_BacklogAgeBucket) Reset() {
+	*x = TicketMetrics_BacklogAgeBucket{}
+	mi := &file_api_v1_analytics_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketMetrics_BacklogAgeBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketMetrics_BacklogAgeBucket) ProtoMessage() {}
+
+func (x *TicketMetrics_BacklogAgeBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_analytics_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketMetrics_BacklogAgeBucket.ProtoReflect.Descriptor instead.
+func (*TicketMetrics_BacklogAgeBucket) Descriptor() ([]byte, []int) {
+	return file_api_v1_analytics_service_proto_rawDescGZIP(), []int{2, 2}
+}
+
+func (x *TicketMetrics_BacklogAgeBucket) GetMinAge() *durationpb.Duration {
+	if x != nil {
+		return x.MinAge
+	}
+	return nil
+}
+
+func (x *TicketMetrics_BacklogAgeBucket) GetMaxAge() *durationpb.Duration {
+	if x != nil {
+		return x.MaxAge
+	}
+	return nil
+}
+
+func (x *TicketMetrics_BacklogAgeBucket) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type TicketCumulativeFlow_Point struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The end of the period.
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// The number of tickets with each status at the end of the period.
+	StatusCounts  map[string]int32 `protobuf:"bytes,2,rep,name=status_counts,json=statusCounts,proto3" json:"status_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketCumulativeFlow_Point) Reset() {
+	*x = TicketCumulativeFlow_Point{}
+	mi := &file_api_v1_analytics_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketCumulativeFlow_Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketCumulativeFlow_Point) ProtoMessage() {}
+
+func (x *TicketCumulativeFlow_Point) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_analytics_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketCumulativeFlow_Point.ProtoReflect.Descriptor instead.
+func (*TicketCumulativeFlow_Point) Descriptor() ([]byte, []int) {
+	return file_api_v1_analytics_service_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *TicketCumulativeFlow_Point) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *TicketCumulativeFlow_Point) GetStatusCounts() map[string]int32 {
+	if x != nil {
+		return x.StatusCounts
+	}
+	return nil
+}
+
+type MemoActivity_Cell struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The day of the week, where Sunday is 0.
+	Weekday int32 `protobuf:"varint,1,opt,name=weekday,proto3" json:"weekday,omitempty"`
+	// The hour of the day, from 0 to 23.
+	Hour          int32 `protobuf:"varint,2,opt,name=hour,proto3" json:"hour,omitempty"`
+	Count         int32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoActivity_Cell) Reset() {
+	*x = MemoActivity_Cell{}
+	mi := &file_api_v1_analytics_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoActivity_Cell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoActivity_Cell) ProtoMessage() {}
+
+func (x *MemoActivity_Cell) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_analytics_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoActivity_Cell.ProtoReflect.Descriptor instead.
+func (*MemoActivity_Cell) Descriptor() ([]byte, []int) {
+	return file_api_v1_analytics_service_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *MemoActivity_Cell) GetWeekday() int32 {
+	if x != nil {
+		return x.Weekday
+	}
+	return 0
+}
+
+func (x *MemoActivity_Cell) GetHour() int32 {
+	if x != nil {
+		return x.Hour
+	}
+	return 0
+}
+
+func (x *MemoActivity_Cell) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_api_v1_analytics_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_analytics_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1eapi/v1/analytics_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdc\x01\n" +
+	"\x17GetTicketMetricsRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x125\n" +
+	"\x06period\x18\x04 \x01(\x0e2\x1d.memos.api.v1.AnalyticsPeriodR\x06period\"\xb2\x01\n" +
+	"\x13DurationPercentiles\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12+\n" +
+	"\x03p50\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03p50\x12+\n" +
+	"\x03p85\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03p85\x12+\n" +
+	"\x03p95\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x03p95\"\xc0\x05\n" +
+	"\rTicketMetrics\x12<\n" +
+	"\aperiods\x18\x01 \x03(\v2\".memos.api.v1.TicketMetrics.PeriodR\aperiods\x12>\n" +
+	"\tlead_time\x18\x02 \x01(\v2!.memos.api.v1.DurationPercentilesR\bleadTime\x12@\n" +
+	"\n" +
+	"cycle_time\x18\x03 \x01(\v2!.memos.api.v1.DurationPercentilesR\tcycleTime\x12N\n" +
+	"\n" +
+	"throughput\x18\x04 \x03(\v2..memos.api.v1.TicketMetrics.AssigneeThroughputR\n" +
+	"throughput\x12M\n" +
+	"\vbacklog_age\x18\x05 \x03(\v2,.memos.api.v1.TicketMetrics.BacklogAgeBucketR\n" +
+	"backlogAge\x1as\n" +
+	"\x06Period\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12\x16\n" +
+	"\x06opened\x18\x02 \x01(\x05R\x06opened\x12\x16\n" +
+	"\x06closed\x18\x03 \x01(\x05R\x06closed\x1aH\n" +
+	"\x12AssigneeThroughput\x12\x1a\n" +
+	"\bassignee\x18\x01 \x01(\tR\bassignee\x12\x16\n" +
+	"\x06closed\x18\x02 \x01(\x05R\x06closed\x1a\x90\x01\n" +
+	"\x10BacklogAgeBucket\x122\n" +
+	"\amin_age\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x06minAge\x122\n" +
+	"\amax_age\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x06maxAge\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"\xbb\x02\n" +
+	"\x14TicketCumulativeFlow\x12@\n" +
+	"\x06points\x18\x01 \x03(\v2(.memos.api.v1.TicketCumulativeFlow.PointR\x06points\x1a\xe0\x01\n" +
+	"\x05Point\x125\n" +
+	"\bend_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12_\n" +
+	"\rstatus_counts\x18\x02 \x03(\v2:.memos.api.v1.TicketCumulativeFlow.Point.StatusCountsEntryR\fstatusCounts\x1a?\n" +
+	"\x11StatusCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xde\x01\n" +
+	"\x16GetMemoActivityRequest\x12\x18\n" +
+	"\acreator\x18\x01 \x01(\tR\acreator\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x128\n" +
+	"\n" +
+	"utc_offset\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\tutcOffset\"\xa7\x01\n" +
+	"\fMemoActivity\x125\n" +
+	"\x05cells\x18\x01 \x03(\v2\x1f.memos.api.v1.MemoActivity.CellR\x05cells\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x1aJ\n" +
+	"\x04Cell\x12\x18\n" +
+	"\aweekday\x18\x01 \x01(\x05R\aweekday\x12\x12\n" +
+	"\x04hour\x18\x02 \x01(\x05R\x04hour\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count*F\n" +
+	"\x0fAnalyticsPeriod\x12 \n" +
+	"\x1cANALYTICS_PERIOD_UNSPECIFIED\x10\x00\x12\a\n" +
+	"\x03DAY\x10\x01\x12\b\n" +
+	"\x04WEEK\x10\x022\xa6\x03\n" +
+	"\x10AnalyticsService\x12y\n" +
+	"\x10GetTicketMetrics\x12%.memos.api.v1.GetTicketMetricsRequest\x1a\x1b.memos.api.v1.TicketMetrics\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/analytics/tickets\x12\x97\x01\n" +
+	"\x17GetTicketCumulativeFlow\x12%.memos.api.v1.GetTicketMetricsRequest\x1a\".memos.api.v1.TicketCumulativeFlow\"1\x82\xd3\xe4\x93\x02+\x12)/api/v1/analytics/tickets/cumulative-flow\x12}\n" +
+	"\x0fGetMemoActivity\x12$.memos.api.v1.GetMemoActivityRequest\x1a\x1a.memos.api.v1.MemoActivity\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v1/analytics/memos/activityB\xad\x01\n" +
+	"\x10com.memos.api.v1B\x15AnalyticsServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
+
+var (
+	file_api_v1_analytics_service_proto_rawDescOnce sync.Once
+	file_api_v1_analytics_service_proto_rawDescData []byte
+)
+
+func file_api_v1_analytics_service_proto_rawDescGZIP() []byte {
+	file_api_v1_analytics_service_proto_rawDescOnce.Do(func() {
+		file_api_v1_analytics_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_analytics_service_proto_rawDesc), len(file_api_v1_analytics_service_proto_rawDesc)))
+	})
+	return file_api_v1_analytics_service_proto_rawDescData
+}
+
+var file_api_v1_analytics_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_v1_analytics_service_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_v1_analytics_service_proto_goTypes = []any{
+	(AnalyticsPeriod)(0),                     // 0: memos.api.v1.AnalyticsPeriod
+	(*GetTicketMetricsRequest)(nil),          // 1: memos.api.v1.GetTicketMetricsRequest
+	(*DurationPercentiles)(nil),              // 2: memos.api.v1.DurationPercentiles
+	(*TicketMetrics)(nil),                    // 3: memos.api.v1.TicketMetrics
+	(*TicketCumulativeFlow)(nil),             // 4: memos.api.v1.TicketCumulativeFlow
+	(*GetMemoActivityRequest)(nil),           // 5: memos.api.v1.GetMemoActivityRequest
+	(*MemoActivity)(nil),                     // 6: memos.api.v1.MemoActivity
+	(*TicketMetrics_Period)(nil),             // 7: memos.api.v1.TicketMetrics.Period
+	(*TicketMetrics_AssigneeThroughput)(nil), // 8: memos.api.v1.TicketMetrics.AssigneeThroughput
+	(*TicketMetrics_BacklogAgeBucket)(nil),   // 9: memos.api.v1.TicketMetrics.BacklogAgeBucket
+	(*TicketCumulativeFlow_Point)(nil),       // 10: memos.api.v1.TicketCumulativeFlow.Point
+	nil,                                      // 11: memos.api.v1.TicketCumulativeFlow.Point.StatusCountsEntry
+	(*MemoActivity_Cell)(nil),                // 12: memos.api.v1.MemoActivity.Cell
+	(*timestamppb.Timestamp)(nil),            // 13: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),              // 14: google.protobuf.Duration
+}
+var file_api_v1_analytics_service_proto_depIdxs = []int32{
+	13, // 0: memos.api.v1.GetTicketMetricsRequest.start_time:type_name -> google.protobuf.Timestamp
+	13, // 1: memos.api.v1.GetTicketMetricsRequest.end_time:type_name -> google.protobuf.Timestamp
+	0,  // 2: memos.api.v1.GetTicketMetricsRequest.period:type_name -> memos.api.v1.AnalyticsPeriod
+	14, // 3: memos.api.v1.DurationPercentiles.p50:type_name -> google.protobuf.Duration
+	14, // 4: memos.api.v1.DurationPercentiles.p85:type_name -> google.protobuf.Duration
+	14, // 5: memos.api.v1.DurationPercentiles.p95:type_name -> google.protobuf.Duration
+	7,  // 6: memos.api.v1.TicketMetrics.periods:type_name -> memos.api.v1.TicketMetrics.Period
+	2,  // 7: memos.api.v1.TicketMetrics.lead_time:type_name -> memos.api.v1.DurationPercentiles
+	2,  // 8: memos.api.v1.TicketMetrics.cycle_time:type_name -> memos.api.v1.DurationPercentiles
+	8,  // 9: memos.api.v1.TicketMetrics.throughput:type_name -> memos.api.v1.TicketMetrics.AssigneeThroughput
+	9,  // 10: memos.api.v1.TicketMetrics.backlog_age:type_name -> memos.api.v1.TicketMetrics.BacklogAgeBucket
+	10, // 11: memos.api.v1.TicketCumulativeFlow.points:type_name -> memos.api.v1.TicketCumulativeFlow.Point
+	13, // 12: memos.api.v1.GetMemoActivityRequest.start_time:type_name -> google.protobuf.Timestamp
+	13, // 13: memos.api.v1.GetMemoActivityRequest.end_time:type_name -> google.protobuf.Timestamp
+	14, // 14: memos.api.v1.GetMemoActivityRequest.utc_offset:type_name -> google.protobuf.Duration
+	12, // 15: memos.api.v1.MemoActivity.cells:type_name -> memos.api.v1.MemoActivity.Cell
+	13, // 16: memos.api.v1.TicketMetrics.Period.start_time:type_name -> google.protobuf.Timestamp
+	14, // 17: memos.api.v1.TicketMetrics.BacklogAgeBucket.min_age:type_name -> google.protobuf.Duration
+	14, // 18: memos.api.v1.TicketMetrics.BacklogAgeBucket.max_age:type_name -> google.protobuf.Duration
+	13, // 19: memos.api.v1.TicketCumulativeFlow.Point.end_time:type_name -> google.protobuf.Timestamp
+	11, // 20: memos.api.v1.TicketCumulativeFlow.Point.status_counts:type_name -> memos.api.v1.TicketCumulativeFlow.Point.StatusCountsEntry
+	1,  // 21: memos.api.v1.AnalyticsService.GetTicketMetrics:input_type -> memos.api.v1.GetTicketMetricsRequest
+	1,  // 22: memos.api.v1.AnalyticsService.GetTicketCumulativeFlow:input_type -> memos.api.v1.GetTicketMetricsRequest
+	5,  // 23: memos.api.v1.AnalyticsService.GetMemoActivity:input_type -> memos.api.v1.GetMemoActivityRequest
+	3,  // 24: memos.api.v1.AnalyticsService.GetTicketMetrics:output_type -> memos.api.v1.TicketMetrics
+	4,  // 25: memos.api.v1.AnalyticsService.GetTicketCumulativeFlow:output_type -> memos.api.v1.TicketCumulativeFlow
+	6,  // 26: memos.api.v1.AnalyticsService.GetMemoActivity:output_type -> memos.api.v1.MemoActivity
+	24, // [24:27] is the sub-list for method output_type
+	21, // [21:24] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_analytics_service_proto_init() }
+func file_api_v1_analytics_service_proto_init() {
+	if File_api_v1_analytics_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_analytics_service_proto_rawDesc), len(file_api_v1_analytics_service_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_analytics_service_proto_goTypes,
+		DependencyIndexes: file_api_v1_analytics_service_proto_depIdxs,
+		EnumInfos:         file_api_v1_analytics_service_proto_enumTypes,
+		MessageInfos:      file_api_v1_analytics_service_proto_msgTypes,
+	}.Build()
+	File_api_v1_analytics_service_proto = out.File
+	file_api_v1_analytics_service_proto_goTypes = nil
+	file_api_v1_analytics_service_proto_depIdxs = nil
+}
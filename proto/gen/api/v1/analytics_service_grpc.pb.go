@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: api/v1/analytics_service.proto
+
+package apiv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalyticsService_GetTicketMetrics_FullMethodName        = "/memos.api.v1.AnalyticsService/GetTicketMetrics"
+	AnalyticsService_GetTicketCumulativeFlow_FullMethodName = "/memos.api.v1.AnalyticsService/GetTicketCumulativeFlow"
+	AnalyticsService_GetMemoActivity_FullMethodName         = "/memos.api.v1.AnalyticsService/GetMemoActivity"
+)
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AnalyticsServiceClient interface {
+	// GetTicketMetrics returns the flow metrics of the tickets in a range of time.
+	GetTicketMetrics(ctx context.Context, in *GetTicketMetricsRequest, opts ...grpc.CallOption) (*TicketMetrics, error)
+	// GetTicketCumulativeFlow returns the number of tickets with each status at the end of every period of a range of time.
+	GetTicketCumulativeFlow(ctx context.Context, in *GetTicketMetricsRequest, opts ...grpc.CallOption) (*TicketCumulativeFlow, error)
+	// GetMemoActivity returns the number of memos created in every hour of the week in a range of time.
+	GetMemoActivity(ctx context.Context, in *GetMemoActivityRequest, opts ...grpc.CallOption) (*MemoActivity, error)
+}
+
+type analyticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) GetTicketMetrics(ctx context.Context, in *GetTicketMetricsRequest, opts ...grpc.CallOption) (*TicketMetrics, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TicketMetrics)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetTicketMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetTicketCumulativeFlow(ctx context.Context, in *GetTicketMetricsRequest, opts ...grpc.CallOption) (*TicketCumulativeFlow, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TicketCumulativeFlow)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetTicketCumulativeFlow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetMemoActivity(ctx context.Context, in *GetMemoActivityRequest, opts ...grpc.CallOption) (*MemoActivity, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoActivity)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetMemoActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+// All implementations must embed UnimplementedAnalyticsServiceServer
+// for forward compatibility.
+type AnalyticsServiceServer interface {
+	// GetTicketMetrics returns the flow metrics of the tickets in a range of time.
+	GetTicketMetrics(context.Context, *GetTicketMetricsRequest) (*TicketMetrics, error)
+	// GetTicketCumulativeFlow returns the number of tickets with each status at the end of every period of a range of time.
+	GetTicketCumulativeFlow(context.Context, *GetTicketMetricsRequest) (*TicketCumulativeFlow, error)
+	// GetMemoActivity returns the number of memos created in every hour of the week in a range of time.
+	GetMemoActivity(context.Context, *GetMemoActivityRequest) (*MemoActivity, error)
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+// UnimplementedAnalyticsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyticsServiceServer struct{}
+
+func (UnimplementedAnalyticsServiceServer) GetTicketMetrics(context.Context, *GetTicketMetricsRequest) (*TicketMetrics, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTicketMetrics not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetTicketCumulativeFlow(context.Context, *GetTicketMetricsRequest) (*TicketCumulativeFlow, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTicketCumulativeFlow not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetMemoActivity(context.Context, *GetMemoActivityRequest) (*MemoActivity, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMemoActivity not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
+func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
+// result in compilation errors.
+type UnsafeAnalyticsServiceServer interface {
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
+	// If the following call panics, it indicates UnimplementedAnalyticsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
+}
+
+func _AnalyticsService_GetTicketMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTicketMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetTicketMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetTicketMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetTicketMetrics(ctx, req.(*GetTicketMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetTicketCumulativeFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTicketMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetTicketCumulativeFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetTicketCumulativeFlow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetTicketCumulativeFlow(ctx, req.(*GetTicketMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetMemoActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMemoActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetMemoActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetMemoActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetMemoActivity(ctx, req.(*GetMemoActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "memos.api.v1.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTicketMetrics",
+			Handler:    _AnalyticsService_GetTicketMetrics_Handler,
+		},
+		{
+			MethodName: "GetTicketCumulativeFlow",
+			Handler:    _AnalyticsService_GetTicketCumulativeFlow_Handler,
+		},
+		{
+			MethodName: "GetMemoActivity",
+			Handler:    _AnalyticsService_GetMemoActivity_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/analytics_service.proto",
+}
@@ -11,6 +11,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -259,7 +260,9 @@ type SSOCredentials struct {
 	// The code to sign in with.
 	Code string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
 	// The redirect URI.
-	RedirectUri   string `protobuf:"bytes,3,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"`
+	RedirectUri string `protobuf:"bytes,3,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"`
+	// The state returned by CreateSSOAuthorization, required for OIDC providers.
+	State         string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -315,6 +318,620 @@ func (x *SSOCredentials) GetRedirectUri() string {
 	return ""
 }
 
+func (x *SSOCredentials) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type CreateSSOAuthorizationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the SSO provider.
+	IdpId int32 `protobuf:"varint,1,opt,name=idp_id,json=idpId,proto3" json:"idp_id,omitempty"`
+	// The redirect URI registered with the provider.
+	RedirectUri   string `protobuf:"bytes,2,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSSOAuthorizationRequest) Reset() {
+	*x = CreateSSOAuthorizationRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSSOAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSSOAuthorizationRequest) ProtoMessage() {}
+
+func (x *CreateSSOAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSSOAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateSSOAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateSSOAuthorizationRequest) GetIdpId() int32 {
+	if x != nil {
+		return x.IdpId
+	}
+	return 0
+}
+
+func (x *CreateSSOAuthorizationRequest) GetRedirectUri() string {
+	if x != nil {
+		return x.RedirectUri
+	}
+	return ""
+}
+
+type SSOAuthorization struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The URL to redirect the user to.
+	AuthorizationUrl string `protobuf:"bytes,1,opt,name=authorization_url,json=authorizationUrl,proto3" json:"authorization_url,omitempty"`
+	// The opaque state bound to this authorization.
+	State         string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SSOAuthorization) Reset() {
+	*x = SSOAuthorization{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SSOAuthorization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SSOAuthorization) ProtoMessage() {}
+
+func (x *SSOAuthorization) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SSOAuthorization.ProtoReflect.Descriptor instead.
+func (*SSOAuthorization) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SSOAuthorization) GetAuthorizationUrl() string {
+	if x != nil {
+		return x.AuthorizationUrl
+	}
+	return ""
+}
+
+func (x *SSOAuthorization) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type SignInWithTwoFactorRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The challenge returned by SignIn.
+	Challenge string `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	// Types that are valid to be assigned to Code:
+	//
+	//	*SignInWithTwoFactorRequest_TotpCode
+	//	*SignInWithTwoFactorRequest_RecoveryCode
+	Code          isSignInWithTwoFactorRequest_Code `protobuf_oneof:"code"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignInWithTwoFactorRequest) Reset() {
+	*x = SignInWithTwoFactorRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignInWithTwoFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignInWithTwoFactorRequest) ProtoMessage() {}
+
+func (x *SignInWithTwoFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignInWithTwoFactorRequest.ProtoReflect.Descriptor instead.
+func (*SignInWithTwoFactorRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SignInWithTwoFactorRequest) GetChallenge() string {
+	if x != nil {
+		return x.Challenge
+	}
+	return ""
+}
+
+func (x *SignInWithTwoFactorRequest) GetCode() isSignInWithTwoFactorRequest_Code {
+	if x != nil {
+		return x.Code
+	}
+	return nil
+}
+
+func (x *SignInWithTwoFactorRequest) GetTotpCode() string {
+	if x != nil {
+		if x, ok := x.Code.(*SignInWithTwoFactorRequest_TotpCode); ok {
+			return x.TotpCode
+		}
+	}
+	return ""
+}
+
+func (x *SignInWithTwoFactorRequest) GetRecoveryCode() string {
+	if x != nil {
+		if x, ok := x.Code.(*SignInWithTwoFactorRequest_RecoveryCode); ok {
+			return x.RecoveryCode
+		}
+	}
+	return ""
+}
+
+type isSignInWithTwoFactorRequest_Code interface {
+	isSignInWithTwoFactorRequest_Code()
+}
+
+type SignInWithTwoFactorRequest_TotpCode struct {
+	// The code of the authenticator app.
+	TotpCode string `protobuf:"bytes,2,opt,name=totp_code,json=totpCode,proto3,oneof"`
+}
+
+type SignInWithTwoFactorRequest_RecoveryCode struct {
+	// One of the recovery codes.
+	RecoveryCode string `protobuf:"bytes,3,opt,name=recovery_code,json=recoveryCode,proto3,oneof"`
+}
+
+func (*SignInWithTwoFactorRequest_TotpCode) isSignInWithTwoFactorRequest_Code() {}
+
+func (*SignInWithTwoFactorRequest_RecoveryCode) isSignInWithTwoFactorRequest_Code() {}
+
+type GetTwoFactorStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTwoFactorStatusRequest) Reset() {
+	*x = GetTwoFactorStatusRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTwoFactorStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTwoFactorStatusRequest) ProtoMessage() {}
+
+func (x *GetTwoFactorStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTwoFactorStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetTwoFactorStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{8}
+}
+
+type TwoFactorStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether two-factor authentication is enabled.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Whether the workspace requires the user to enable two-factor authentication.
+	Required bool `protobuf:"varint,2,opt,name=required,proto3" json:"required,omitempty"`
+	// The number of unused recovery codes.
+	RecoveryCodesRemaining int32 `protobuf:"varint,3,opt,name=recovery_codes_remaining,json=recoveryCodesRemaining,proto3" json:"recovery_codes_remaining,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TwoFactorStatus) Reset() {
+	*x = TwoFactorStatus{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TwoFactorStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TwoFactorStatus) ProtoMessage() {}
+
+func (x *TwoFactorStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TwoFactorStatus.ProtoReflect.Descriptor instead.
+func (*TwoFactorStatus) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TwoFactorStatus) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *TwoFactorStatus) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *TwoFactorStatus) GetRecoveryCodesRemaining() int32 {
+	if x != nil {
+		return x.RecoveryCodesRemaining
+	}
+	return 0
+}
+
+type SetupTwoFactorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetupTwoFactorRequest) Reset() {
+	*x = SetupTwoFactorRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetupTwoFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetupTwoFactorRequest) ProtoMessage() {}
+
+func (x *SetupTwoFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetupTwoFactorRequest.ProtoReflect.Descriptor instead.
+func (*SetupTwoFactorRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{10}
+}
+
+type TwoFactorSetup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The base32 encoded secret.
+	Secret string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	// The otpauth URI to render as a QR code.
+	ProvisioningUri string `protobuf:"bytes,2,opt,name=provisioning_uri,json=provisioningUri,proto3" json:"provisioning_uri,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TwoFactorSetup) Reset() {
+	*x = TwoFactorSetup{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TwoFactorSetup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TwoFactorSetup) ProtoMessage() {}
+
+func (x *TwoFactorSetup) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TwoFactorSetup.ProtoReflect.Descriptor instead.
+func (*TwoFactorSetup) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TwoFactorSetup) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *TwoFactorSetup) GetProvisioningUri() string {
+	if x != nil {
+		return x.ProvisioningUri
+	}
+	return ""
+}
+
+type EnableTwoFactorRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A code generated from the new secret.
+	TotpCode      string `protobuf:"bytes,1,opt,name=totp_code,json=totpCode,proto3" json:"totp_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableTwoFactorRequest) Reset() {
+	*x = EnableTwoFactorRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableTwoFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableTwoFactorRequest) ProtoMessage() {}
+
+func (x *EnableTwoFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableTwoFactorRequest.ProtoReflect.Descriptor instead.
+func (*EnableTwoFactorRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EnableTwoFactorRequest) GetTotpCode() string {
+	if x != nil {
+		return x.TotpCode
+	}
+	return ""
+}
+
+type DisableTwoFactorRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Code:
+	//
+	//	*DisableTwoFactorRequest_TotpCode
+	//	*DisableTwoFactorRequest_RecoveryCode
+	Code          isDisableTwoFactorRequest_Code `protobuf_oneof:"code"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTwoFactorRequest) Reset() {
+	*x = DisableTwoFactorRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTwoFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTwoFactorRequest) ProtoMessage() {}
+
+func (x *DisableTwoFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTwoFactorRequest.ProtoReflect.Descriptor instead.
+func (*DisableTwoFactorRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DisableTwoFactorRequest) GetCode() isDisableTwoFactorRequest_Code {
+	if x != nil {
+		return x.Code
+	}
+	return nil
+}
+
+func (x *DisableTwoFactorRequest) GetTotpCode() string {
+	if x != nil {
+		if x, ok := x.Code.(*DisableTwoFactorRequest_TotpCode); ok {
+			return x.TotpCode
+		}
+	}
+	return ""
+}
+
+func (x *DisableTwoFactorRequest) GetRecoveryCode() string {
+	if x != nil {
+		if x, ok := x.Code.(*DisableTwoFactorRequest_RecoveryCode); ok {
+			return x.RecoveryCode
+		}
+	}
+	return ""
+}
+
+type isDisableTwoFactorRequest_Code interface {
+	isDisableTwoFactorRequest_Code()
+}
+
+type DisableTwoFactorRequest_TotpCode struct {
+	TotpCode string `protobuf:"bytes,1,opt,name=totp_code,json=totpCode,proto3,oneof"`
+}
+
+type DisableTwoFactorRequest_RecoveryCode struct {
+	RecoveryCode string `protobuf:"bytes,2,opt,name=recovery_code,json=recoveryCode,proto3,oneof"`
+}
+
+func (*DisableTwoFactorRequest_TotpCode) isDisableTwoFactorRequest_Code() {}
+
+func (*DisableTwoFactorRequest_RecoveryCode) isDisableTwoFactorRequest_Code() {}
+
+type RegenerateRecoveryCodesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A code of the authenticator app.
+	TotpCode      string `protobuf:"bytes,1,opt,name=totp_code,json=totpCode,proto3" json:"totp_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateRecoveryCodesRequest) Reset() {
+	*x = RegenerateRecoveryCodesRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateRecoveryCodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateRecoveryCodesRequest) ProtoMessage() {}
+
+func (x *RegenerateRecoveryCodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateRecoveryCodesRequest.ProtoReflect.Descriptor instead.
+func (*RegenerateRecoveryCodesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RegenerateRecoveryCodesRequest) GetTotpCode() string {
+	if x != nil {
+		return x.TotpCode
+	}
+	return ""
+}
+
+type RecoveryCodes struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The recovery codes, only returned once.
+	Codes         []string `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoveryCodes) Reset() {
+	*x = RecoveryCodes{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryCodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryCodes) ProtoMessage() {}
+
+func (x *RecoveryCodes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryCodes.ProtoReflect.Descriptor instead.
+func (*RecoveryCodes) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RecoveryCodes) GetCodes() []string {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
 type SignUpRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The username to sign up with.
@@ -327,7 +944,7 @@ type SignUpRequest struct {
 
 func (x *SignUpRequest) Reset() {
 	*x = SignUpRequest{}
-	mi := &file_api_v1_auth_service_proto_msgTypes[5]
+	mi := &file_api_v1_auth_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -339,7 +956,7 @@ func (x *SignUpRequest) String() string {
 func (*SignUpRequest) ProtoMessage() {}
 
 func (x *SignUpRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_auth_service_proto_msgTypes[5]
+	mi := &file_api_v1_auth_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,7 +969,7 @@ func (x *SignUpRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignUpRequest.ProtoReflect.Descriptor instead.
 func (*SignUpRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{5}
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *SignUpRequest) GetUsername() string {
@@ -377,7 +994,7 @@ type SignOutRequest struct {
 
 func (x *SignOutRequest) Reset() {
 	*x = SignOutRequest{}
-	mi := &file_api_v1_auth_service_proto_msgTypes[6]
+	mi := &file_api_v1_auth_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -389,7 +1006,7 @@ func (x *SignOutRequest) String() string {
 func (*SignOutRequest) ProtoMessage() {}
 
 func (x *SignOutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_auth_service_proto_msgTypes[6]
+	mi := &file_api_v1_auth_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -402,14 +1019,384 @@ func (x *SignOutRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignOutRequest.ProtoReflect.Descriptor instead.
 func (*SignOutRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{6}
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{17}
+}
+
+type RefreshSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The refresh token. Browsers leave it empty and send the refresh token cookie instead.
+	RefreshToken  string `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshSessionRequest) Reset() {
+	*x = RefreshSessionRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSessionRequest) ProtoMessage() {}
+
+func (x *RefreshSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSessionRequest.ProtoReflect.Descriptor instead.
+func (*RefreshSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RefreshSessionRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RefreshSessionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The new access token, only returned when the refresh token is given in the request.
+	AccessToken           string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	AccessTokenExpireTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=access_token_expire_time,json=accessTokenExpireTime,proto3" json:"access_token_expire_time,omitempty"`
+	// The new refresh token, only returned when the refresh token is given in the request.
+	// It is empty if the refresh token was already rotated by a concurrent request.
+	RefreshToken  string `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshSessionResponse) Reset() {
+	*x = RefreshSessionResponse{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSessionResponse) ProtoMessage() {}
+
+func (x *RefreshSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSessionResponse.ProtoReflect.Descriptor instead.
+func (*RefreshSessionResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RefreshSessionResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RefreshSessionResponse) GetAccessTokenExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AccessTokenExpireTime
+	}
+	return nil
+}
+
+func (x *RefreshSessionResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type Session struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// A short description of the device, such as "Chrome on macOS".
+	Device       string                 `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
+	UserAgent    string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Ip           string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	CreateTime   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	LastSeenTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_seen_time,json=lastSeenTime,proto3" json:"last_seen_time,omitempty"`
+	ExpireTime   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// Whether this is the session of the current request.
+	Current       bool `protobuf:"varint,8,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *Session) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Session) GetLastSeenTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenTime
+	}
+	return nil
+}
+
+func (x *Session) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{21}
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RevokeSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type RevokeAllSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllSessionsRequest) Reset() {
+	*x = RevokeAllSessionsRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllSessionsRequest) ProtoMessage() {}
+
+func (x *RevokeAllSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{24}
 }
 
 var File_api_v1_auth_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_auth_service_proto_rawDesc = "" +
 	"\n" +
-	"\x19api/v1/auth_service.proto\x12\fmemos.api.v1\x1a\x19api/v1/user_service.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x16\n" +
+	"\x19api/v1/auth_service.proto\x12\fmemos.api.v1\x1a\x19api/v1/user_service.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x16\n" +
 	"\x14GetAuthStatusRequest\"?\n" +
 	"\x15GetAuthStatusResponse\x12&\n" +
 	"\x04user\x18\x01 \x01(\v2\x12.memos.api.v1.UserR\x04user\"\xdd\x01\n" +
@@ -420,18 +1407,85 @@ const file_api_v1_auth_service_proto_rawDesc = "" +
 	"\x06method\"M\n" +
 	"\x13PasswordCredentials\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"^\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"t\n" +
 	"\x0eSSOCredentials\x12\x15\n" +
 	"\x06idp_id\x18\x01 \x01(\x05R\x05idpId\x12\x12\n" +
 	"\x04code\x18\x02 \x01(\tR\x04code\x12!\n" +
-	"\fredirect_uri\x18\x03 \x01(\tR\vredirectUri\"G\n" +
+	"\fredirect_uri\x18\x03 \x01(\tR\vredirectUri\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\"Y\n" +
+	"\x1dCreateSSOAuthorizationRequest\x12\x15\n" +
+	"\x06idp_id\x18\x01 \x01(\x05R\x05idpId\x12!\n" +
+	"\fredirect_uri\x18\x02 \x01(\tR\vredirectUri\"U\n" +
+	"\x10SSOAuthorization\x12+\n" +
+	"\x11authorization_url\x18\x01 \x01(\tR\x10authorizationUrl\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"\x88\x01\n" +
+	"\x1aSignInWithTwoFactorRequest\x12\x1c\n" +
+	"\tchallenge\x18\x01 \x01(\tR\tchallenge\x12\x1d\n" +
+	"\ttotp_code\x18\x02 \x01(\tH\x00R\btotpCode\x12%\n" +
+	"\rrecovery_code\x18\x03 \x01(\tH\x00R\frecoveryCodeB\x06\n" +
+	"\x04code\"\x1b\n" +
+	"\x19GetTwoFactorStatusRequest\"\x81\x01\n" +
+	"\x0fTwoFactorStatus\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1a\n" +
+	"\brequired\x18\x02 \x01(\bR\brequired\x128\n" +
+	"\x18recovery_codes_remaining\x18\x03 \x01(\x05R\x16recoveryCodesRemaining\"\x17\n" +
+	"\x15SetupTwoFactorRequest\"S\n" +
+	"\x0eTwoFactorSetup\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12)\n" +
+	"\x10provisioning_uri\x18\x02 \x01(\tR\x0fprovisioningUri\"5\n" +
+	"\x16EnableTwoFactorRequest\x12\x1b\n" +
+	"\ttotp_code\x18\x01 \x01(\tR\btotpCode\"g\n" +
+	"\x17DisableTwoFactorRequest\x12\x1d\n" +
+	"\ttotp_code\x18\x01 \x01(\tH\x00R\btotpCode\x12%\n" +
+	"\rrecovery_code\x18\x02 \x01(\tH\x00R\frecoveryCodeB\x06\n" +
+	"\x04code\"=\n" +
+	"\x1eRegenerateRecoveryCodesRequest\x12\x1b\n" +
+	"\ttotp_code\x18\x01 \x01(\tR\btotpCode\"%\n" +
+	"\rRecoveryCodes\x12\x14\n" +
+	"\x05codes\x18\x01 \x03(\tR\x05codes\"G\n" +
 	"\rSignUpRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x10\n" +
-	"\x0eSignOutRequest2\x82\x03\n" +
+	"\x0eSignOutRequest\"<\n" +
+	"\x15RefreshSessionRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\xb5\x01\n" +
+	"\x16RefreshSessionResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12S\n" +
+	"\x18access_token_expire_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x15accessTokenExpireTime\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\"\xb6\x02\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06device\x18\x02 \x01(\tR\x06device\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x03 \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02ip\x18\x04 \x01(\tR\x02ip\x12;\n" +
+	"\vcreate_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12@\n" +
+	"\x0elast_seen_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\flastSeenTime\x12;\n" +
+	"\vexpire_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\x12\x18\n" +
+	"\acurrent\x18\b \x01(\bR\acurrent\"\x15\n" +
+	"\x13ListSessionsRequest\"I\n" +
+	"\x14ListSessionsResponse\x121\n" +
+	"\bsessions\x18\x01 \x03(\v2\x15.memos.api.v1.SessionR\bsessions\"5\n" +
+	"\x14RevokeSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\x1a\n" +
+	"\x18RevokeAllSessionsRequest2\x82\x0e\n" +
 	"\vAuthService\x12d\n" +
 	"\rGetAuthStatus\x12\".memos.api.v1.GetAuthStatusRequest\x1a\x12.memos.api.v1.User\"\x1b\x82\xd3\xe4\x93\x02\x15\"\x13/api/v1/auth/status\x12V\n" +
-	"\x06SignIn\x12\x1b.memos.api.v1.SignInRequest\x1a\x12.memos.api.v1.User\"\x1b\x82\xd3\xe4\x93\x02\x15\"\x13/api/v1/auth/signin\x12V\n" +
+	"\x06SignIn\x12\x1b.memos.api.v1.SignInRequest\x1a\x12.memos.api.v1.User\"\x1b\x82\xd3\xe4\x93\x02\x15\"\x13/api/v1/auth/signin\x12\x91\x01\n" +
+	"\x16CreateSSOAuthorization\x12+.memos.api.v1.CreateSSOAuthorizationRequest\x1a\x1e.memos.api.v1.SSOAuthorization\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/auth/sso/authorizations\x12w\n" +
+	"\x13SignInWithTwoFactor\x12(.memos.api.v1.SignInWithTwoFactorRequest\x1a\x12.memos.api.v1.User\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/auth/signin/2fa\x12v\n" +
+	"\x12GetTwoFactorStatus\x12'.memos.api.v1.GetTwoFactorStatusRequest\x1a\x1d.memos.api.v1.TwoFactorStatus\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/auth/2fa\x12v\n" +
+	"\x0eSetupTwoFactor\x12#.memos.api.v1.SetupTwoFactorRequest\x1a\x1c.memos.api.v1.TwoFactorSetup\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/api/v1/auth/2fa:setup\x12x\n" +
+	"\x0fEnableTwoFactor\x12$.memos.api.v1.EnableTwoFactorRequest\x1a\x1b.memos.api.v1.RecoveryCodes\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/auth/2fa:enable\x12v\n" +
+	"\x10DisableTwoFactor\x12%.memos.api.v1.DisableTwoFactorRequest\x1a\x16.google.protobuf.Empty\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/auth/2fa:disable\x12\x99\x01\n" +
+	"\x17RegenerateRecoveryCodes\x12,.memos.api.v1.RegenerateRecoveryCodesRequest\x1a\x1b.memos.api.v1.RecoveryCodes\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/api/v1/auth/2fa:regenerateRecoveryCodes\x12\x85\x01\n" +
+	"\x0eRefreshSession\x12#.memos.api.v1.RefreshSessionRequest\x1a$.memos.api.v1.RefreshSessionResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/auth/sessions:refresh\x12t\n" +
+	"\fListSessions\x12!.memos.api.v1.ListSessionsRequest\x1a\".memos.api.v1.ListSessionsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/auth/sessions\x12w\n" +
+	"\rRevokeSession\x12\".memos.api.v1.RevokeSessionRequest\x1a\x16.google.protobuf.Empty\"*\x82\xd3\xe4\x93\x02$*\"/api/v1/auth/sessions/{session_id}\x12|\n" +
+	"\x11RevokeAllSessions\x12&.memos.api.v1.RevokeAllSessionsRequest\x1a\x16.google.protobuf.Empty\"'\x82\xd3\xe4\x93\x02!\"\x1f/api/v1/auth/sessions:revokeAll\x12V\n" +
 	"\x06SignUp\x12\x1b.memos.api.v1.SignUpRequest\x1a\x12.memos.api.v1.User\"\x1b\x82\xd3\xe4\x93\x02\x15\"\x13/api/v1/auth/signup\x12]\n" +
 	"\aSignOut\x12\x1c.memos.api.v1.SignOutRequest\x1a\x16.google.protobuf.Empty\"\x1c\x82\xd3\xe4\x93\x02\x16\"\x14/api/v1/auth/signoutB\xa8\x01\n" +
 	"\x10com.memos.api.v1B\x10AuthServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
@@ -448,35 +1502,81 @@ func file_api_v1_auth_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_auth_service_proto_rawDescData
 }
 
-var file_api_v1_auth_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_v1_auth_service_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_api_v1_auth_service_proto_goTypes = []any{
-	(*GetAuthStatusRequest)(nil),  // 0: memos.api.v1.GetAuthStatusRequest
-	(*GetAuthStatusResponse)(nil), // 1: memos.api.v1.GetAuthStatusResponse
-	(*SignInRequest)(nil),         // 2: memos.api.v1.SignInRequest
-	(*PasswordCredentials)(nil),   // 3: memos.api.v1.PasswordCredentials
-	(*SSOCredentials)(nil),        // 4: memos.api.v1.SSOCredentials
-	(*SignUpRequest)(nil),         // 5: memos.api.v1.SignUpRequest
-	(*SignOutRequest)(nil),        // 6: memos.api.v1.SignOutRequest
-	(*User)(nil),                  // 7: memos.api.v1.User
-	(*emptypb.Empty)(nil),         // 8: google.protobuf.Empty
+	(*GetAuthStatusRequest)(nil),           // 0: memos.api.v1.GetAuthStatusRequest
+	(*GetAuthStatusResponse)(nil),          // 1: memos.api.v1.GetAuthStatusResponse
+	(*SignInRequest)(nil),                  // 2: memos.api.v1.SignInRequest
+	(*PasswordCredentials)(nil),            // 3: memos.api.v1.PasswordCredentials
+	(*SSOCredentials)(nil),                 // 4: memos.api.v1.SSOCredentials
+	(*CreateSSOAuthorizationRequest)(nil),  // 5: memos.api.v1.CreateSSOAuthorizationRequest
+	(*SSOAuthorization)(nil),               // 6: memos.api.v1.SSOAuthorization
+	(*SignInWithTwoFactorRequest)(nil),     // 7: memos.api.v1.SignInWithTwoFactorRequest
+	(*GetTwoFactorStatusRequest)(nil),      // 8: memos.api.v1.GetTwoFactorStatusRequest
+	(*TwoFactorStatus)(nil),                // 9: memos.api.v1.TwoFactorStatus
+	(*SetupTwoFactorRequest)(nil),          // 10: memos.api.v1.SetupTwoFactorRequest
+	(*TwoFactorSetup)(nil),                 // 11: memos.api.v1.TwoFactorSetup
+	(*EnableTwoFactorRequest)(nil),         // 12: memos.api.v1.EnableTwoFactorRequest
+	(*DisableTwoFactorRequest)(nil),        // 13: memos.api.v1.DisableTwoFactorRequest
+	(*RegenerateRecoveryCodesRequest)(nil), // 14: memos.api.v1.RegenerateRecoveryCodesRequest
+	(*RecoveryCodes)(nil),                  // 15: memos.api.v1.RecoveryCodes
+	(*SignUpRequest)(nil),                  // 16: memos.api.v1.SignUpRequest
+	(*SignOutRequest)(nil),                 // 17: memos.api.v1.SignOutRequest
+	(*RefreshSessionRequest)(nil),          // 18: memos.api.v1.RefreshSessionRequest
+	(*RefreshSessionResponse)(nil),         // 19: memos.api.v1.RefreshSessionResponse
+	(*Session)(nil),                        // 20: memos.api.v1.Session
+	(*ListSessionsRequest)(nil),            // 21: memos.api.v1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),           // 22: memos.api.v1.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),           // 23: memos.api.v1.RevokeSessionRequest
+	(*RevokeAllSessionsRequest)(nil),       // 24: memos.api.v1.RevokeAllSessionsRequest
+	(*User)(nil),                           // 25: memos.api.v1.User
+	(*timestamppb.Timestamp)(nil),          // 26: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                  // 27: google.protobuf.Empty
 }
 var file_api_v1_auth_service_proto_depIdxs = []int32{
-	7, // 0: memos.api.v1.GetAuthStatusResponse.user:type_name -> memos.api.v1.User
-	3, // 1: memos.api.v1.SignInRequest.password_credentials:type_name -> memos.api.v1.PasswordCredentials
-	4, // 2: memos.api.v1.SignInRequest.sso_credentials:type_name -> memos.api.v1.SSOCredentials
-	0, // 3: memos.api.v1.AuthService.GetAuthStatus:input_type -> memos.api.v1.GetAuthStatusRequest
-	2, // 4: memos.api.v1.AuthService.SignIn:input_type -> memos.api.v1.SignInRequest
-	5, // 5: memos.api.v1.AuthService.SignUp:input_type -> memos.api.v1.SignUpRequest
-	6, // 6: memos.api.v1.AuthService.SignOut:input_type -> memos.api.v1.SignOutRequest
-	7, // 7: memos.api.v1.AuthService.GetAuthStatus:output_type -> memos.api.v1.User
-	7, // 8: memos.api.v1.AuthService.SignIn:output_type -> memos.api.v1.User
-	7, // 9: memos.api.v1.AuthService.SignUp:output_type -> memos.api.v1.User
-	8, // 10: memos.api.v1.AuthService.SignOut:output_type -> google.protobuf.Empty
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	25, // 0: memos.api.v1.GetAuthStatusResponse.user:type_name -> memos.api.v1.User
+	3,  // 1: memos.api.v1.SignInRequest.password_credentials:type_name -> memos.api.v1.PasswordCredentials
+	4,  // 2: memos.api.v1.SignInRequest.sso_credentials:type_name -> memos.api.v1.SSOCredentials
+	26, // 3: memos.api.v1.RefreshSessionResponse.access_token_expire_time:type_name -> google.protobuf.Timestamp
+	26, // 4: memos.api.v1.Session.create_time:type_name -> google.protobuf.Timestamp
+	26, // 5: memos.api.v1.Session.last_seen_time:type_name -> google.protobuf.Timestamp
+	26, // 6: memos.api.v1.Session.expire_time:type_name -> google.protobuf.Timestamp
+	20, // 7: memos.api.v1.ListSessionsResponse.sessions:type_name -> memos.api.v1.Session
+	0,  // 8: memos.api.v1.AuthService.GetAuthStatus:input_type -> memos.api.v1.GetAuthStatusRequest
+	2,  // 9: memos.api.v1.AuthService.SignIn:input_type -> memos.api.v1.SignInRequest
+	5,  // 10: memos.api.v1.AuthService.CreateSSOAuthorization:input_type -> memos.api.v1.CreateSSOAuthorizationRequest
+	7,  // 11: memos.api.v1.AuthService.SignInWithTwoFactor:input_type -> memos.api.v1.SignInWithTwoFactorRequest
+	8,  // 12: memos.api.v1.AuthService.GetTwoFactorStatus:input_type -> memos.api.v1.GetTwoFactorStatusRequest
+	10, // 13: memos.api.v1.AuthService.SetupTwoFactor:input_type -> memos.api.v1.SetupTwoFactorRequest
+	12, // 14: memos.api.v1.AuthService.EnableTwoFactor:input_type -> memos.api.v1.EnableTwoFactorRequest
+	13, // 15: memos.api.v1.AuthService.DisableTwoFactor:input_type -> memos.api.v1.DisableTwoFactorRequest
+	14, // 16: memos.api.v1.AuthService.RegenerateRecoveryCodes:input_type -> memos.api.v1.RegenerateRecoveryCodesRequest
+	18, // 17: memos.api.v1.AuthService.RefreshSession:input_type -> memos.api.v1.RefreshSessionRequest
+	21, // 18: memos.api.v1.AuthService.ListSessions:input_type -> memos.api.v1.ListSessionsRequest
+	23, // 19: memos.api.v1.AuthService.RevokeSession:input_type -> memos.api.v1.RevokeSessionRequest
+	24, // 20: memos.api.v1.AuthService.RevokeAllSessions:input_type -> memos.api.v1.RevokeAllSessionsRequest
+	16, // 21: memos.api.v1.AuthService.SignUp:input_type -> memos.api.v1.SignUpRequest
+	17, // 22: memos.api.v1.AuthService.SignOut:input_type -> memos.api.v1.SignOutRequest
+	25, // 23: memos.api.v1.AuthService.GetAuthStatus:output_type -> memos.api.v1.User
+	25, // 24: memos.api.v1.AuthService.SignIn:output_type -> memos.api.v1.User
+	6,  // 25: memos.api.v1.AuthService.CreateSSOAuthorization:output_type -> memos.api.v1.SSOAuthorization
+	25, // 26: memos.api.v1.AuthService.SignInWithTwoFactor:output_type -> memos.api.v1.User
+	9,  // 27: memos.api.v1.AuthService.GetTwoFactorStatus:output_type -> memos.api.v1.TwoFactorStatus
+	11, // 28: memos.api.v1.AuthService.SetupTwoFactor:output_type -> memos.api.v1.TwoFactorSetup
+	15, // 29: memos.api.v1.AuthService.EnableTwoFactor:output_type -> memos.api.v1.RecoveryCodes
+	27, // 30: memos.api.v1.AuthService.DisableTwoFactor:output_type -> google.protobuf.Empty
+	15, // 31: memos.api.v1.AuthService.RegenerateRecoveryCodes:output_type -> memos.api.v1.RecoveryCodes
+	19, // 32: memos.api.v1.AuthService.RefreshSession:output_type -> memos.api.v1.RefreshSessionResponse
+	22, // 33: memos.api.v1.AuthService.ListSessions:output_type -> memos.api.v1.ListSessionsResponse
+	27, // 34: memos.api.v1.AuthService.RevokeSession:output_type -> google.protobuf.Empty
+	27, // 35: memos.api.v1.AuthService.RevokeAllSessions:output_type -> google.protobuf.Empty
+	25, // 36: memos.api.v1.AuthService.SignUp:output_type -> memos.api.v1.User
+	27, // 37: memos.api.v1.AuthService.SignOut:output_type -> google.protobuf.Empty
+	23, // [23:38] is the sub-list for method output_type
+	8,  // [8:23] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_auth_service_proto_init() }
@@ -489,13 +1589,21 @@ func file_api_v1_auth_service_proto_init() {
 		(*SignInRequest_PasswordCredentials)(nil),
 		(*SignInRequest_SsoCredentials)(nil),
 	}
+	file_api_v1_auth_service_proto_msgTypes[7].OneofWrappers = []any{
+		(*SignInWithTwoFactorRequest_TotpCode)(nil),
+		(*SignInWithTwoFactorRequest_RecoveryCode)(nil),
+	}
+	file_api_v1_auth_service_proto_msgTypes[13].OneofWrappers = []any{
+		(*DisableTwoFactorRequest_TotpCode)(nil),
+		(*DisableTwoFactorRequest_RecoveryCode)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_auth_service_proto_rawDesc), len(file_api_v1_auth_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
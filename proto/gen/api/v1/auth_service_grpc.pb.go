@@ -20,10 +20,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_GetAuthStatus_FullMethodName = "/memos.api.v1.AuthService/GetAuthStatus"
-	AuthService_SignIn_FullMethodName        = "/memos.api.v1.AuthService/SignIn"
-	AuthService_SignUp_FullMethodName        = "/memos.api.v1.AuthService/SignUp"
-	AuthService_SignOut_FullMethodName       = "/memos.api.v1.AuthService/SignOut"
+	AuthService_GetAuthStatus_FullMethodName           = "/memos.api.v1.AuthService/GetAuthStatus"
+	AuthService_SignIn_FullMethodName                  = "/memos.api.v1.AuthService/SignIn"
+	AuthService_CreateSSOAuthorization_FullMethodName  = "/memos.api.v1.AuthService/CreateSSOAuthorization"
+	AuthService_SignInWithTwoFactor_FullMethodName     = "/memos.api.v1.AuthService/SignInWithTwoFactor"
+	AuthService_GetTwoFactorStatus_FullMethodName      = "/memos.api.v1.AuthService/GetTwoFactorStatus"
+	AuthService_SetupTwoFactor_FullMethodName          = "/memos.api.v1.AuthService/SetupTwoFactor"
+	AuthService_EnableTwoFactor_FullMethodName         = "/memos.api.v1.AuthService/EnableTwoFactor"
+	AuthService_DisableTwoFactor_FullMethodName        = "/memos.api.v1.AuthService/DisableTwoFactor"
+	AuthService_RegenerateRecoveryCodes_FullMethodName = "/memos.api.v1.AuthService/RegenerateRecoveryCodes"
+	AuthService_RefreshSession_FullMethodName          = "/memos.api.v1.AuthService/RefreshSession"
+	AuthService_ListSessions_FullMethodName            = "/memos.api.v1.AuthService/ListSessions"
+	AuthService_RevokeSession_FullMethodName           = "/memos.api.v1.AuthService/RevokeSession"
+	AuthService_RevokeAllSessions_FullMethodName       = "/memos.api.v1.AuthService/RevokeAllSessions"
+	AuthService_SignUp_FullMethodName                  = "/memos.api.v1.AuthService/SignUp"
+	AuthService_SignOut_FullMethodName                 = "/memos.api.v1.AuthService/SignOut"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -34,6 +45,31 @@ type AuthServiceClient interface {
 	GetAuthStatus(ctx context.Context, in *GetAuthStatusRequest, opts ...grpc.CallOption) (*User, error)
 	// SignIn signs in the user.
 	SignIn(ctx context.Context, in *SignInRequest, opts ...grpc.CallOption) (*User, error)
+	// CreateSSOAuthorization starts a sign in with the given identity provider.
+	// The returned state must be passed back in the SSO credentials.
+	CreateSSOAuthorization(ctx context.Context, in *CreateSSOAuthorizationRequest, opts ...grpc.CallOption) (*SSOAuthorization, error)
+	// SignInWithTwoFactor completes a sign in that requires a second factor.
+	// The challenge is returned by SignIn as the metadata of a TWO_FACTOR_REQUIRED error.
+	SignInWithTwoFactor(ctx context.Context, in *SignInWithTwoFactorRequest, opts ...grpc.CallOption) (*User, error)
+	// GetTwoFactorStatus returns the two-factor authentication status of the current user.
+	GetTwoFactorStatus(ctx context.Context, in *GetTwoFactorStatusRequest, opts ...grpc.CallOption) (*TwoFactorStatus, error)
+	// SetupTwoFactor generates a new TOTP secret for the current user, to be confirmed by EnableTwoFactor.
+	SetupTwoFactor(ctx context.Context, in *SetupTwoFactorRequest, opts ...grpc.CallOption) (*TwoFactorSetup, error)
+	// EnableTwoFactor verifies a code of the pending secret and enables two-factor authentication.
+	EnableTwoFactor(ctx context.Context, in *EnableTwoFactorRequest, opts ...grpc.CallOption) (*RecoveryCodes, error)
+	// DisableTwoFactor disables two-factor authentication of the current user.
+	DisableTwoFactor(ctx context.Context, in *DisableTwoFactorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RegenerateRecoveryCodes replaces the recovery codes of the current user.
+	RegenerateRecoveryCodes(ctx context.Context, in *RegenerateRecoveryCodesRequest, opts ...grpc.CallOption) (*RecoveryCodes, error)
+	// RefreshSession rotates the refresh token of a session and issues a new access token.
+	// The refresh token is read from the request or, if empty, from the refresh token cookie.
+	RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*RefreshSessionResponse, error)
+	// ListSessions returns the active sessions of the current user.
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	// RevokeSession signs out the session of the current user.
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RevokeAllSessions signs out all sessions of the current user, including the current one.
+	RevokeAllSessions(ctx context.Context, in *RevokeAllSessionsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// SignUp signs up the user with the given username and password.
 	SignUp(ctx context.Context, in *SignUpRequest, opts ...grpc.CallOption) (*User, error)
 	// SignOut signs out the user.
@@ -68,6 +104,116 @@ func (c *authServiceClient) SignIn(ctx context.Context, in *SignInRequest, opts
 	return out, nil
 }
 
+func (c *authServiceClient) CreateSSOAuthorization(ctx context.Context, in *CreateSSOAuthorizationRequest, opts ...grpc.CallOption) (*SSOAuthorization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SSOAuthorization)
+	err := c.cc.Invoke(ctx, AuthService_CreateSSOAuthorization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SignInWithTwoFactor(ctx context.Context, in *SignInWithTwoFactorRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, AuthService_SignInWithTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetTwoFactorStatus(ctx context.Context, in *GetTwoFactorStatusRequest, opts ...grpc.CallOption) (*TwoFactorStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TwoFactorStatus)
+	err := c.cc.Invoke(ctx, AuthService_GetTwoFactorStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetupTwoFactor(ctx context.Context, in *SetupTwoFactorRequest, opts ...grpc.CallOption) (*TwoFactorSetup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TwoFactorSetup)
+	err := c.cc.Invoke(ctx, AuthService_SetupTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) EnableTwoFactor(ctx context.Context, in *EnableTwoFactorRequest, opts ...grpc.CallOption) (*RecoveryCodes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoveryCodes)
+	err := c.cc.Invoke(ctx, AuthService_EnableTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DisableTwoFactor(ctx context.Context, in *DisableTwoFactorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_DisableTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RegenerateRecoveryCodes(ctx context.Context, in *RegenerateRecoveryCodesRequest, opts ...grpc.CallOption) (*RecoveryCodes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoveryCodes)
+	err := c.cc.Invoke(ctx, AuthService_RegenerateRecoveryCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*RefreshSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshSessionResponse)
+	err := c.cc.Invoke(ctx, AuthService_RefreshSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_RevokeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeAllSessions(ctx context.Context, in *RevokeAllSessionsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_RevokeAllSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) SignUp(ctx context.Context, in *SignUpRequest, opts ...grpc.CallOption) (*User, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(User)
@@ -96,6 +242,31 @@ type AuthServiceServer interface {
 	GetAuthStatus(context.Context, *GetAuthStatusRequest) (*User, error)
 	// SignIn signs in the user.
 	SignIn(context.Context, *SignInRequest) (*User, error)
+	// CreateSSOAuthorization starts a sign in with the given identity provider.
+	// The returned state must be passed back in the SSO credentials.
+	CreateSSOAuthorization(context.Context, *CreateSSOAuthorizationRequest) (*SSOAuthorization, error)
+	// SignInWithTwoFactor completes a sign in that requires a second factor.
+	// The challenge is returned by SignIn as the metadata of a TWO_FACTOR_REQUIRED error.
+	SignInWithTwoFactor(context.Context, *SignInWithTwoFactorRequest) (*User, error)
+	// GetTwoFactorStatus returns the two-factor authentication status of the current user.
+	GetTwoFactorStatus(context.Context, *GetTwoFactorStatusRequest) (*TwoFactorStatus, error)
+	// SetupTwoFactor generates a new TOTP secret for the current user, to be confirmed by EnableTwoFactor.
+	SetupTwoFactor(context.Context, *SetupTwoFactorRequest) (*TwoFactorSetup, error)
+	// EnableTwoFactor verifies a code of the pending secret and enables two-factor authentication.
+	EnableTwoFactor(context.Context, *EnableTwoFactorRequest) (*RecoveryCodes, error)
+	// DisableTwoFactor disables two-factor authentication of the current user.
+	DisableTwoFactor(context.Context, *DisableTwoFactorRequest) (*emptypb.Empty, error)
+	// RegenerateRecoveryCodes replaces the recovery codes of the current user.
+	RegenerateRecoveryCodes(context.Context, *RegenerateRecoveryCodesRequest) (*RecoveryCodes, error)
+	// RefreshSession rotates the refresh token of a session and issues a new access token.
+	// The refresh token is read from the request or, if empty, from the refresh token cookie.
+	RefreshSession(context.Context, *RefreshSessionRequest) (*RefreshSessionResponse, error)
+	// ListSessions returns the active sessions of the current user.
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	// RevokeSession signs out the session of the current user.
+	RevokeSession(context.Context, *RevokeSessionRequest) (*emptypb.Empty, error)
+	// RevokeAllSessions signs out all sessions of the current user, including the current one.
+	RevokeAllSessions(context.Context, *RevokeAllSessionsRequest) (*emptypb.Empty, error)
 	// SignUp signs up the user with the given username and password.
 	SignUp(context.Context, *SignUpRequest) (*User, error)
 	// SignOut signs out the user.
@@ -116,6 +287,39 @@ func (UnimplementedAuthServiceServer) GetAuthStatus(context.Context, *GetAuthSta
 func (UnimplementedAuthServiceServer) SignIn(context.Context, *SignInRequest) (*User, error) {
 	return nil, status.Error(codes.Unimplemented, "method SignIn not implemented")
 }
+func (UnimplementedAuthServiceServer) CreateSSOAuthorization(context.Context, *CreateSSOAuthorizationRequest) (*SSOAuthorization, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSSOAuthorization not implemented")
+}
+func (UnimplementedAuthServiceServer) SignInWithTwoFactor(context.Context, *SignInWithTwoFactorRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method SignInWithTwoFactor not implemented")
+}
+func (UnimplementedAuthServiceServer) GetTwoFactorStatus(context.Context, *GetTwoFactorStatusRequest) (*TwoFactorStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTwoFactorStatus not implemented")
+}
+func (UnimplementedAuthServiceServer) SetupTwoFactor(context.Context, *SetupTwoFactorRequest) (*TwoFactorSetup, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetupTwoFactor not implemented")
+}
+func (UnimplementedAuthServiceServer) EnableTwoFactor(context.Context, *EnableTwoFactorRequest) (*RecoveryCodes, error) {
+	return nil, status.Error(codes.Unimplemented, "method EnableTwoFactor not implemented")
+}
+func (UnimplementedAuthServiceServer) DisableTwoFactor(context.Context, *DisableTwoFactorRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DisableTwoFactor not implemented")
+}
+func (UnimplementedAuthServiceServer) RegenerateRecoveryCodes(context.Context, *RegenerateRecoveryCodesRequest) (*RecoveryCodes, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegenerateRecoveryCodes not implemented")
+}
+func (UnimplementedAuthServiceServer) RefreshSession(context.Context, *RefreshSessionRequest) (*RefreshSessionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RefreshSession not implemented")
+}
+func (UnimplementedAuthServiceServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeSession(context.Context, *RevokeSessionRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeAllSessions(context.Context, *RevokeAllSessionsRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeAllSessions not implemented")
+}
 func (UnimplementedAuthServiceServer) SignUp(context.Context, *SignUpRequest) (*User, error) {
 	return nil, status.Error(codes.Unimplemented, "method SignUp not implemented")
 }
@@ -179,6 +383,204 @@ func _AuthService_SignIn_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_CreateSSOAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSSOAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreateSSOAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreateSSOAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreateSSOAuthorization(ctx, req.(*CreateSSOAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SignInWithTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignInWithTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SignInWithTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SignInWithTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SignInWithTwoFactor(ctx, req.(*SignInWithTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetTwoFactorStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTwoFactorStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetTwoFactorStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetTwoFactorStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetTwoFactorStatus(ctx, req.(*GetTwoFactorStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SetupTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetupTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SetupTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SetupTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SetupTwoFactor(ctx, req.(*SetupTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_EnableTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).EnableTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_EnableTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).EnableTwoFactor(ctx, req.(*EnableTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DisableTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DisableTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DisableTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DisableTwoFactor(ctx, req.(*DisableTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RegenerateRecoveryCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegenerateRecoveryCodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RegenerateRecoveryCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RegenerateRecoveryCodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RegenerateRecoveryCodes(ctx, req.(*RegenerateRecoveryCodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RefreshSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RefreshSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RefreshSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RefreshSession(ctx, req.(*RefreshSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeAllSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAllSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeAllSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeAllSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeAllSessions(ctx, req.(*RevokeAllSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthService_SignUp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignUpRequest)
 	if err := dec(in); err != nil {
@@ -230,6 +632,50 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SignIn",
 			Handler:    _AuthService_SignIn_Handler,
 		},
+		{
+			MethodName: "CreateSSOAuthorization",
+			Handler:    _AuthService_CreateSSOAuthorization_Handler,
+		},
+		{
+			MethodName: "SignInWithTwoFactor",
+			Handler:    _AuthService_SignInWithTwoFactor_Handler,
+		},
+		{
+			MethodName: "GetTwoFactorStatus",
+			Handler:    _AuthService_GetTwoFactorStatus_Handler,
+		},
+		{
+			MethodName: "SetupTwoFactor",
+			Handler:    _AuthService_SetupTwoFactor_Handler,
+		},
+		{
+			MethodName: "EnableTwoFactor",
+			Handler:    _AuthService_EnableTwoFactor_Handler,
+		},
+		{
+			MethodName: "DisableTwoFactor",
+			Handler:    _AuthService_DisableTwoFactor_Handler,
+		},
+		{
+			MethodName: "RegenerateRecoveryCodes",
+			Handler:    _AuthService_RegenerateRecoveryCodes_Handler,
+		},
+		{
+			MethodName: "RefreshSession",
+			Handler:    _AuthService_RefreshSession_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _AuthService_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _AuthService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "RevokeAllSessions",
+			Handler:    _AuthService_RevokeAllSessions_Handler,
+		},
 		{
 			MethodName: "SignUp",
 			Handler:    _AuthService_SignUp_Handler,
@@ -160,6 +160,65 @@ func (NodeType) EnumDescriptor() ([]byte, []int) {
 	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{0}
 }
 
+type RenderMarkdownRequest_Format int32
+
+const (
+	// FORMAT_UNSPECIFIED renders to HTML.
+	RenderMarkdownRequest_FORMAT_UNSPECIFIED RenderMarkdownRequest_Format = 0
+	// HTML is sanitized HTML.
+	RenderMarkdownRequest_HTML RenderMarkdownRequest_Format = 1
+	// COMMONMARK is CommonMark with the GFM tables, task lists and strikethroughs.
+	RenderMarkdownRequest_COMMONMARK RenderMarkdownRequest_Format = 2
+	// SLACK is the mrkdwn format of Slack messages.
+	RenderMarkdownRequest_SLACK      RenderMarkdownRequest_Format = 3
+	RenderMarkdownRequest_PLAIN_TEXT RenderMarkdownRequest_Format = 4
+)
+
+// Enum value maps for RenderMarkdownRequest_Format.
+var (
+	RenderMarkdownRequest_Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "HTML",
+		2: "COMMONMARK",
+		3: "SLACK",
+		4: "PLAIN_TEXT",
+	}
+	RenderMarkdownRequest_Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"HTML":               1,
+		"COMMONMARK":         2,
+		"SLACK":              3,
+		"PLAIN_TEXT":         4,
+	}
+)
+
+func (x RenderMarkdownRequest_Format) Enum() *RenderMarkdownRequest_Format {
+	p := new(RenderMarkdownRequest_Format)
+	*p = x
+	return p
+}
+
+func (x RenderMarkdownRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RenderMarkdownRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_markdown_service_proto_enumTypes[1].Descriptor()
+}
+
+func (RenderMarkdownRequest_Format) Type() protoreflect.EnumType {
+	return &file_api_v1_markdown_service_proto_enumTypes[1]
+}
+
+func (x RenderMarkdownRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RenderMarkdownRequest_Format.Descriptor instead.
+func (RenderMarkdownRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{6, 0}
+}
+
 type ListNode_Kind int32
 
 const (
@@ -196,11 +255,11 @@ func (x ListNode_Kind) String() string {
 }
 
 func (ListNode_Kind) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_v1_markdown_service_proto_enumTypes[1].Descriptor()
+	return file_api_v1_markdown_service_proto_enumTypes[2].Descriptor()
 }
 
 func (ListNode_Kind) Type() protoreflect.EnumType {
-	return &file_api_v1_markdown_service_proto_enumTypes[1]
+	return &file_api_v1_markdown_service_proto_enumTypes[2]
 }
 
 func (x ListNode_Kind) Number() protoreflect.EnumNumber {
@@ -209,7 +268,7 @@ func (x ListNode_Kind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ListNode_Kind.Descriptor instead.
 func (ListNode_Kind) EnumDescriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{15, 0}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{17, 0}
 }
 
 type ParseMarkdownRequest struct {
@@ -476,6 +535,102 @@ func (x *StringifyMarkdownNodesResponse) GetPlainText() string {
 	return ""
 }
 
+type RenderMarkdownRequest struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Markdown      string                       `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	Format        RenderMarkdownRequest_Format `protobuf:"varint,2,opt,name=format,proto3,enum=memos.api.v1.RenderMarkdownRequest_Format" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderMarkdownRequest) Reset() {
+	*x = RenderMarkdownRequest{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderMarkdownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderMarkdownRequest) ProtoMessage() {}
+
+func (x *RenderMarkdownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderMarkdownRequest.ProtoReflect.Descriptor instead.
+func (*RenderMarkdownRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RenderMarkdownRequest) GetMarkdown() string {
+	if x != nil {
+		return x.Markdown
+	}
+	return ""
+}
+
+func (x *RenderMarkdownRequest) GetFormat() RenderMarkdownRequest_Format {
+	if x != nil {
+		return x.Format
+	}
+	return RenderMarkdownRequest_FORMAT_UNSPECIFIED
+}
+
+type RenderMarkdownResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderMarkdownResponse) Reset() {
+	*x = RenderMarkdownResponse{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderMarkdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderMarkdownResponse) ProtoMessage() {}
+
+func (x *RenderMarkdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderMarkdownResponse.ProtoReflect.Descriptor instead.
+func (*RenderMarkdownResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RenderMarkdownResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
 type GetLinkMetadataRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Link          string                 `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
@@ -485,7 +640,7 @@ type GetLinkMetadataRequest struct {
 
 func (x *GetLinkMetadataRequest) Reset() {
 	*x = GetLinkMetadataRequest{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[6]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -497,7 +652,7 @@ func (x *GetLinkMetadataRequest) String() string {
 func (*GetLinkMetadataRequest) ProtoMessage() {}
 
 func (x *GetLinkMetadataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[6]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,7 +665,7 @@ func (x *GetLinkMetadataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLinkMetadataRequest.ProtoReflect.Descriptor instead.
 func (*GetLinkMetadataRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{6}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetLinkMetadataRequest) GetLink() string {
@@ -531,7 +686,7 @@ type LinkMetadata struct {
 
 func (x *LinkMetadata) Reset() {
 	*x = LinkMetadata{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[7]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -543,7 +698,7 @@ func (x *LinkMetadata) String() string {
 func (*LinkMetadata) ProtoMessage() {}
 
 func (x *LinkMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[7]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -556,7 +711,7 @@ func (x *LinkMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LinkMetadata.ProtoReflect.Descriptor instead.
 func (*LinkMetadata) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{7}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *LinkMetadata) GetTitle() string {
@@ -623,7 +778,7 @@ type Node struct {
 
 func (x *Node) Reset() {
 	*x = Node{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[8]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -635,7 +790,7 @@ func (x *Node) String() string {
 func (*Node) ProtoMessage() {}
 
 func (x *Node) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[8]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,7 +803,7 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Node.ProtoReflect.Descriptor instead.
 func (*Node) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{8}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Node) GetType() NodeType {
@@ -1144,7 +1299,7 @@ type LineBreakNode struct {
 
 func (x *LineBreakNode) Reset() {
 	*x = LineBreakNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[9]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1156,7 +1311,7 @@ func (x *LineBreakNode) String() string {
 func (*LineBreakNode) ProtoMessage() {}
 
 func (x *LineBreakNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[9]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1169,7 +1324,7 @@ func (x *LineBreakNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LineBreakNode.ProtoReflect.Descriptor instead.
 func (*LineBreakNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{9}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{11}
 }
 
 type ParagraphNode struct {
@@ -1181,7 +1336,7 @@ type ParagraphNode struct {
 
 func (x *ParagraphNode) Reset() {
 	*x = ParagraphNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[10]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1193,7 +1348,7 @@ func (x *ParagraphNode) String() string {
 func (*ParagraphNode) ProtoMessage() {}
 
 func (x *ParagraphNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[10]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1206,7 +1361,7 @@ func (x *ParagraphNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ParagraphNode.ProtoReflect.Descriptor instead.
 func (*ParagraphNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{10}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ParagraphNode) GetChildren() []*Node {
@@ -1226,7 +1381,7 @@ type CodeBlockNode struct {
 
 func (x *CodeBlockNode) Reset() {
 	*x = CodeBlockNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[11]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1238,7 +1393,7 @@ func (x *CodeBlockNode) String() string {
 func (*CodeBlockNode) ProtoMessage() {}
 
 func (x *CodeBlockNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[11]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1251,7 +1406,7 @@ func (x *CodeBlockNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CodeBlockNode.ProtoReflect.Descriptor instead.
 func (*CodeBlockNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{11}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CodeBlockNode) GetLanguage() string {
@@ -1278,7 +1433,7 @@ type HeadingNode struct {
 
 func (x *HeadingNode) Reset() {
 	*x = HeadingNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[12]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1290,7 +1445,7 @@ func (x *HeadingNode) String() string {
 func (*HeadingNode) ProtoMessage() {}
 
 func (x *HeadingNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[12]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1303,7 +1458,7 @@ func (x *HeadingNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeadingNode.ProtoReflect.Descriptor instead.
 func (*HeadingNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{12}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *HeadingNode) GetLevel() int32 {
@@ -1329,7 +1484,7 @@ type HorizontalRuleNode struct {
 
 func (x *HorizontalRuleNode) Reset() {
 	*x = HorizontalRuleNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[13]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1341,7 +1496,7 @@ func (x *HorizontalRuleNode) String() string {
 func (*HorizontalRuleNode) ProtoMessage() {}
 
 func (x *HorizontalRuleNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[13]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1354,7 +1509,7 @@ func (x *HorizontalRuleNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HorizontalRuleNode.ProtoReflect.Descriptor instead.
 func (*HorizontalRuleNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{13}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *HorizontalRuleNode) GetSymbol() string {
@@ -1373,7 +1528,7 @@ type BlockquoteNode struct {
 
 func (x *BlockquoteNode) Reset() {
 	*x = BlockquoteNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[14]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1385,7 +1540,7 @@ func (x *BlockquoteNode) String() string {
 func (*BlockquoteNode) ProtoMessage() {}
 
 func (x *BlockquoteNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[14]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1398,7 +1553,7 @@ func (x *BlockquoteNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockquoteNode.ProtoReflect.Descriptor instead.
 func (*BlockquoteNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{14}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *BlockquoteNode) GetChildren() []*Node {
@@ -1419,7 +1574,7 @@ type ListNode struct {
 
 func (x *ListNode) Reset() {
 	*x = ListNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[15]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1431,7 +1586,7 @@ func (x *ListNode) String() string {
 func (*ListNode) ProtoMessage() {}
 
 func (x *ListNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[15]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1444,7 +1599,7 @@ func (x *ListNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNode.ProtoReflect.Descriptor instead.
 func (*ListNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{15}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ListNode) GetKind() ListNode_Kind {
@@ -1479,7 +1634,7 @@ type OrderedListItemNode struct {
 
 func (x *OrderedListItemNode) Reset() {
 	*x = OrderedListItemNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[16]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1491,7 +1646,7 @@ func (x *OrderedListItemNode) String() string {
 func (*OrderedListItemNode) ProtoMessage() {}
 
 func (x *OrderedListItemNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[16]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1504,7 +1659,7 @@ func (x *OrderedListItemNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderedListItemNode.ProtoReflect.Descriptor instead.
 func (*OrderedListItemNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{16}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *OrderedListItemNode) GetNumber() string {
@@ -1539,7 +1694,7 @@ type UnorderedListItemNode struct {
 
 func (x *UnorderedListItemNode) Reset() {
 	*x = UnorderedListItemNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[17]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1551,7 +1706,7 @@ func (x *UnorderedListItemNode) String() string {
 func (*UnorderedListItemNode) ProtoMessage() {}
 
 func (x *UnorderedListItemNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[17]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1564,7 +1719,7 @@ func (x *UnorderedListItemNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnorderedListItemNode.ProtoReflect.Descriptor instead.
 func (*UnorderedListItemNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{17}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *UnorderedListItemNode) GetSymbol() string {
@@ -1600,7 +1755,7 @@ type TaskListItemNode struct {
 
 func (x *TaskListItemNode) Reset() {
 	*x = TaskListItemNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[18]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1612,7 +1767,7 @@ func (x *TaskListItemNode) String() string {
 func (*TaskListItemNode) ProtoMessage() {}
 
 func (x *TaskListItemNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[18]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1625,7 +1780,7 @@ func (x *TaskListItemNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskListItemNode.ProtoReflect.Descriptor instead.
 func (*TaskListItemNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{18}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *TaskListItemNode) GetSymbol() string {
@@ -1665,7 +1820,7 @@ type MathBlockNode struct {
 
 func (x *MathBlockNode) Reset() {
 	*x = MathBlockNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[19]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1677,7 +1832,7 @@ func (x *MathBlockNode) String() string {
 func (*MathBlockNode) ProtoMessage() {}
 
 func (x *MathBlockNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[19]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1690,7 +1845,7 @@ func (x *MathBlockNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MathBlockNode.ProtoReflect.Descriptor instead.
 func (*MathBlockNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{19}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *MathBlockNode) GetContent() string {
@@ -1711,7 +1866,7 @@ type TableNode struct {
 
 func (x *TableNode) Reset() {
 	*x = TableNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[20]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1723,7 +1878,7 @@ func (x *TableNode) String() string {
 func (*TableNode) ProtoMessage() {}
 
 func (x *TableNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[20]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1736,7 +1891,7 @@ func (x *TableNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TableNode.ProtoReflect.Descriptor instead.
 func (*TableNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{20}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *TableNode) GetHeader() []*Node {
@@ -1770,7 +1925,7 @@ type EmbeddedContentNode struct {
 
 func (x *EmbeddedContentNode) Reset() {
 	*x = EmbeddedContentNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[21]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1782,7 +1937,7 @@ func (x *EmbeddedContentNode) String() string {
 func (*EmbeddedContentNode) ProtoMessage() {}
 
 func (x *EmbeddedContentNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[21]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1795,7 +1950,7 @@ func (x *EmbeddedContentNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmbeddedContentNode.ProtoReflect.Descriptor instead.
 func (*EmbeddedContentNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{21}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *EmbeddedContentNode) GetResourceName() string {
@@ -1821,7 +1976,7 @@ type TextNode struct {
 
 func (x *TextNode) Reset() {
 	*x = TextNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[22]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1833,7 +1988,7 @@ func (x *TextNode) String() string {
 func (*TextNode) ProtoMessage() {}
 
 func (x *TextNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[22]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1846,7 +2001,7 @@ func (x *TextNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TextNode.ProtoReflect.Descriptor instead.
 func (*TextNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{22}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *TextNode) GetContent() string {
@@ -1866,7 +2021,7 @@ type BoldNode struct {
 
 func (x *BoldNode) Reset() {
 	*x = BoldNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[23]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1878,7 +2033,7 @@ func (x *BoldNode) String() string {
 func (*BoldNode) ProtoMessage() {}
 
 func (x *BoldNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[23]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1891,7 +2046,7 @@ func (x *BoldNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BoldNode.ProtoReflect.Descriptor instead.
 func (*BoldNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{23}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *BoldNode) GetSymbol() string {
@@ -1918,7 +2073,7 @@ type ItalicNode struct {
 
 func (x *ItalicNode) Reset() {
 	*x = ItalicNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[24]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1930,7 +2085,7 @@ func (x *ItalicNode) String() string {
 func (*ItalicNode) ProtoMessage() {}
 
 func (x *ItalicNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[24]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1943,7 +2098,7 @@ func (x *ItalicNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ItalicNode.ProtoReflect.Descriptor instead.
 func (*ItalicNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{24}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *ItalicNode) GetSymbol() string {
@@ -1970,7 +2125,7 @@ type BoldItalicNode struct {
 
 func (x *BoldItalicNode) Reset() {
 	*x = BoldItalicNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[25]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1982,7 +2137,7 @@ func (x *BoldItalicNode) String() string {
 func (*BoldItalicNode) ProtoMessage() {}
 
 func (x *BoldItalicNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[25]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1995,7 +2150,7 @@ func (x *BoldItalicNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BoldItalicNode.ProtoReflect.Descriptor instead.
 func (*BoldItalicNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{25}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *BoldItalicNode) GetSymbol() string {
@@ -2021,7 +2176,7 @@ type CodeNode struct {
 
 func (x *CodeNode) Reset() {
 	*x = CodeNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[26]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2033,7 +2188,7 @@ func (x *CodeNode) String() string {
 func (*CodeNode) ProtoMessage() {}
 
 func (x *CodeNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[26]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2046,7 +2201,7 @@ func (x *CodeNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CodeNode.ProtoReflect.Descriptor instead.
 func (*CodeNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{26}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *CodeNode) GetContent() string {
@@ -2066,7 +2221,7 @@ type ImageNode struct {
 
 func (x *ImageNode) Reset() {
 	*x = ImageNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[27]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2078,7 +2233,7 @@ func (x *ImageNode) String() string {
 func (*ImageNode) ProtoMessage() {}
 
 func (x *ImageNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[27]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2091,7 +2246,7 @@ func (x *ImageNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ImageNode.ProtoReflect.Descriptor instead.
 func (*ImageNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{27}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ImageNode) GetAltText() string {
@@ -2118,7 +2273,7 @@ type LinkNode struct {
 
 func (x *LinkNode) Reset() {
 	*x = LinkNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[28]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2130,7 +2285,7 @@ func (x *LinkNode) String() string {
 func (*LinkNode) ProtoMessage() {}
 
 func (x *LinkNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[28]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2143,7 +2298,7 @@ func (x *LinkNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LinkNode.ProtoReflect.Descriptor instead.
 func (*LinkNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{28}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *LinkNode) GetContent() []*Node {
@@ -2170,7 +2325,7 @@ type AutoLinkNode struct {
 
 func (x *AutoLinkNode) Reset() {
 	*x = AutoLinkNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[29]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2182,7 +2337,7 @@ func (x *AutoLinkNode) String() string {
 func (*AutoLinkNode) ProtoMessage() {}
 
 func (x *AutoLinkNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[29]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2195,7 +2350,7 @@ func (x *AutoLinkNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AutoLinkNode.ProtoReflect.Descriptor instead.
 func (*AutoLinkNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{29}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *AutoLinkNode) GetUrl() string {
@@ -2221,7 +2376,7 @@ type TagNode struct {
 
 func (x *TagNode) Reset() {
 	*x = TagNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[30]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2233,7 +2388,7 @@ func (x *TagNode) String() string {
 func (*TagNode) ProtoMessage() {}
 
 func (x *TagNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[30]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2246,7 +2401,7 @@ func (x *TagNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TagNode.ProtoReflect.Descriptor instead.
 func (*TagNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{30}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *TagNode) GetContent() string {
@@ -2265,7 +2420,7 @@ type StrikethroughNode struct {
 
 func (x *StrikethroughNode) Reset() {
 	*x = StrikethroughNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[31]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2277,7 +2432,7 @@ func (x *StrikethroughNode) String() string {
 func (*StrikethroughNode) ProtoMessage() {}
 
 func (x *StrikethroughNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[31]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2290,7 +2445,7 @@ func (x *StrikethroughNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StrikethroughNode.ProtoReflect.Descriptor instead.
 func (*StrikethroughNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{31}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *StrikethroughNode) GetContent() string {
@@ -2309,7 +2464,7 @@ type EscapingCharacterNode struct {
 
 func (x *EscapingCharacterNode) Reset() {
 	*x = EscapingCharacterNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[32]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2321,7 +2476,7 @@ func (x *EscapingCharacterNode) String() string {
 func (*EscapingCharacterNode) ProtoMessage() {}
 
 func (x *EscapingCharacterNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[32]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2334,7 +2489,7 @@ func (x *EscapingCharacterNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EscapingCharacterNode.ProtoReflect.Descriptor instead.
 func (*EscapingCharacterNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{32}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *EscapingCharacterNode) GetSymbol() string {
@@ -2353,7 +2508,7 @@ type MathNode struct {
 
 func (x *MathNode) Reset() {
 	*x = MathNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[33]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2365,7 +2520,7 @@ func (x *MathNode) String() string {
 func (*MathNode) ProtoMessage() {}
 
 func (x *MathNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[33]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2378,7 +2533,7 @@ func (x *MathNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MathNode.ProtoReflect.Descriptor instead.
 func (*MathNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{33}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *MathNode) GetContent() string {
@@ -2397,7 +2552,7 @@ type HighlightNode struct {
 
 func (x *HighlightNode) Reset() {
 	*x = HighlightNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[34]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2409,7 +2564,7 @@ func (x *HighlightNode) String() string {
 func (*HighlightNode) ProtoMessage() {}
 
 func (x *HighlightNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[34]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2422,7 +2577,7 @@ func (x *HighlightNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HighlightNode.ProtoReflect.Descriptor instead.
 func (*HighlightNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{34}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *HighlightNode) GetContent() string {
@@ -2441,7 +2596,7 @@ type SubscriptNode struct {
 
 func (x *SubscriptNode) Reset() {
 	*x = SubscriptNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[35]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2453,7 +2608,7 @@ func (x *SubscriptNode) String() string {
 func (*SubscriptNode) ProtoMessage() {}
 
 func (x *SubscriptNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[35]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2466,7 +2621,7 @@ func (x *SubscriptNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscriptNode.ProtoReflect.Descriptor instead.
 func (*SubscriptNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{35}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *SubscriptNode) GetContent() string {
@@ -2485,7 +2640,7 @@ type SuperscriptNode struct {
 
 func (x *SuperscriptNode) Reset() {
 	*x = SuperscriptNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[36]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2497,7 +2652,7 @@ func (x *SuperscriptNode) String() string {
 func (*SuperscriptNode) ProtoMessage() {}
 
 func (x *SuperscriptNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[36]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2510,7 +2665,7 @@ func (x *SuperscriptNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SuperscriptNode.ProtoReflect.Descriptor instead.
 func (*SuperscriptNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{36}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *SuperscriptNode) GetContent() string {
@@ -2530,7 +2685,7 @@ type ReferencedContentNode struct {
 
 func (x *ReferencedContentNode) Reset() {
 	*x = ReferencedContentNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[37]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2542,7 +2697,7 @@ func (x *ReferencedContentNode) String() string {
 func (*ReferencedContentNode) ProtoMessage() {}
 
 func (x *ReferencedContentNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[37]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2555,7 +2710,7 @@ func (x *ReferencedContentNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReferencedContentNode.ProtoReflect.Descriptor instead.
 func (*ReferencedContentNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{37}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *ReferencedContentNode) GetResourceName() string {
@@ -2581,7 +2736,7 @@ type SpoilerNode struct {
 
 func (x *SpoilerNode) Reset() {
 	*x = SpoilerNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[38]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2593,7 +2748,7 @@ func (x *SpoilerNode) String() string {
 func (*SpoilerNode) ProtoMessage() {}
 
 func (x *SpoilerNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[38]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2606,7 +2761,7 @@ func (x *SpoilerNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpoilerNode.ProtoReflect.Descriptor instead.
 func (*SpoilerNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{38}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *SpoilerNode) GetContent() string {
@@ -2626,7 +2781,7 @@ type HTMLElementNode struct {
 
 func (x *HTMLElementNode) Reset() {
 	*x = HTMLElementNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[39]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2638,7 +2793,7 @@ func (x *HTMLElementNode) String() string {
 func (*HTMLElementNode) ProtoMessage() {}
 
 func (x *HTMLElementNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[39]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2651,7 +2806,7 @@ func (x *HTMLElementNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTMLElementNode.ProtoReflect.Descriptor instead.
 func (*HTMLElementNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{39}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *HTMLElementNode) GetTagName() string {
@@ -2677,7 +2832,7 @@ type TableNode_Row struct {
 
 func (x *TableNode_Row) Reset() {
 	*x = TableNode_Row{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[40]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2689,7 +2844,7 @@ func (x *TableNode_Row) String() string {
 func (*TableNode_Row) ProtoMessage() {}
 
 func (x *TableNode_Row) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[40]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2702,7 +2857,7 @@ func (x *TableNode_Row) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TableNode_Row.ProtoReflect.Descriptor instead.
 func (*TableNode_Row) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{20, 0}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{22, 0}
 }
 
 func (x *TableNode_Row) GetCells() []*Node {
@@ -2729,7 +2884,20 @@ const file_api_v1_markdown_service_proto_rawDesc = "" +
 	"\x05nodes\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\x05nodes\"?\n" +
 	"\x1eStringifyMarkdownNodesResponse\x12\x1d\n" +
 	"\n" +
-	"plain_text\x18\x01 \x01(\tR\tplainText\",\n" +
+	"plain_text\x18\x01 \x01(\tR\tplainText\"\xce\x01\n" +
+	"\x15RenderMarkdownRequest\x12\x1a\n" +
+	"\bmarkdown\x18\x01 \x01(\tR\bmarkdown\x12B\n" +
+	"\x06format\x18\x02 \x01(\x0e2*.memos.api.v1.RenderMarkdownRequest.FormatR\x06format\"U\n" +
+	"\x06Format\x12\x16\n" +
+	"\x12FORMAT_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04HTML\x10\x01\x12\x0e\n" +
+	"\n" +
+	"COMMONMARK\x10\x02\x12\t\n" +
+	"\x05SLACK\x10\x03\x12\x0e\n" +
+	"\n" +
+	"PLAIN_TEXT\x10\x04\"2\n" +
+	"\x16RenderMarkdownResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\",\n" +
 	"\x16GetLinkMetadataRequest\x12\x12\n" +
 	"\x04link\x18\x01 \x01(\tR\x04link\"\\\n" +
 	"\fLinkMetadata\x12\x14\n" +
@@ -2907,11 +3075,12 @@ const file_api_v1_markdown_service_proto_rawDesc = "" +
 	"\vSUPERSCRIPT\x10A\x12\x16\n" +
 	"\x12REFERENCED_CONTENT\x10B\x12\v\n" +
 	"\aSPOILER\x10C\x12\x10\n" +
-	"\fHTML_ELEMENT\x10D2\xc7\x04\n" +
+	"\fHTML_ELEMENT\x10D2\xc8\x05\n" +
 	"\x0fMarkdownService\x12{\n" +
 	"\rParseMarkdown\x12\".memos.api.v1.ParseMarkdownRequest\x1a#.memos.api.v1.ParseMarkdownResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/api/v1/markdown:parse\x12\x97\x01\n" +
 	"\x14RestoreMarkdownNodes\x12).memos.api.v1.RestoreMarkdownNodesRequest\x1a*.memos.api.v1.RestoreMarkdownNodesResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/markdown/node:restore\x12\x9f\x01\n" +
-	"\x16StringifyMarkdownNodes\x12+.memos.api.v1.StringifyMarkdownNodesRequest\x1a,.memos.api.v1.StringifyMarkdownNodesResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/markdown/node:stringify\x12{\n" +
+	"\x16StringifyMarkdownNodes\x12+.memos.api.v1.StringifyMarkdownNodesRequest\x1a,.memos.api.v1.StringifyMarkdownNodesResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/markdown/node:stringify\x12\x7f\n" +
+	"\x0eRenderMarkdown\x12#.memos.api.v1.RenderMarkdownRequest\x1a$.memos.api.v1.RenderMarkdownResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/markdown:render\x12{\n" +
 	"\x0fGetLinkMetadata\x12$.memos.api.v1.GetLinkMetadataRequest\x1a\x1a.memos.api.v1.LinkMetadata\"&\x82\xd3\xe4\x93\x02 \x12\x1e/api/v1/markdown/link:metadataB\xac\x01\n" +
 	"\x10com.memos.api.v1B\x14MarkdownServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
 
@@ -2927,118 +3096,124 @@ func file_api_v1_markdown_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_markdown_service_proto_rawDescData
 }
 
-var file_api_v1_markdown_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_v1_markdown_service_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
+var file_api_v1_markdown_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_v1_markdown_service_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_api_v1_markdown_service_proto_goTypes = []any{
 	(NodeType)(0),                          // 0: memos.api.v1.NodeType
-	(ListNode_Kind)(0),                     // 1: memos.api.v1.ListNode.Kind
-	(*ParseMarkdownRequest)(nil),           // 2: memos.api.v1.ParseMarkdownRequest
-	(*ParseMarkdownResponse)(nil),          // 3: memos.api.v1.ParseMarkdownResponse
-	(*RestoreMarkdownNodesRequest)(nil),    // 4: memos.api.v1.RestoreMarkdownNodesRequest
-	(*RestoreMarkdownNodesResponse)(nil),   // 5: memos.api.v1.RestoreMarkdownNodesResponse
-	(*StringifyMarkdownNodesRequest)(nil),  // 6: memos.api.v1.StringifyMarkdownNodesRequest
-	(*StringifyMarkdownNodesResponse)(nil), // 7: memos.api.v1.StringifyMarkdownNodesResponse
-	(*GetLinkMetadataRequest)(nil),         // 8: memos.api.v1.GetLinkMetadataRequest
-	(*LinkMetadata)(nil),                   // 9: memos.api.v1.LinkMetadata
-	(*Node)(nil),                           // 10: memos.api.v1.Node
-	(*LineBreakNode)(nil),                  // 11: memos.api.v1.LineBreakNode
-	(*ParagraphNode)(nil),                  // 12: memos.api.v1.ParagraphNode
-	(*CodeBlockNode)(nil),                  // 13: memos.api.v1.CodeBlockNode
-	(*HeadingNode)(nil),                    // 14: memos.api.v1.HeadingNode
-	(*HorizontalRuleNode)(nil),             // 15: memos.api.v1.HorizontalRuleNode
-	(*BlockquoteNode)(nil),                 // 16: memos.api.v1.BlockquoteNode
-	(*ListNode)(nil),                       // 17: memos.api.v1.ListNode
-	(*OrderedListItemNode)(nil),            // 18: memos.api.v1.OrderedListItemNode
-	(*UnorderedListItemNode)(nil),          // 19: memos.api.v1.UnorderedListItemNode
-	(*TaskListItemNode)(nil),               // 20: memos.api.v1.TaskListItemNode
-	(*MathBlockNode)(nil),                  // 21: memos.api.v1.MathBlockNode
-	(*TableNode)(nil),                      // 22: memos.api.v1.TableNode
-	(*EmbeddedContentNode)(nil),            // 23: memos.api.v1.EmbeddedContentNode
-	(*TextNode)(nil),                       // 24: memos.api.v1.TextNode
-	(*BoldNode)(nil),                       // 25: memos.api.v1.BoldNode
-	(*ItalicNode)(nil),                     // 26: memos.api.v1.ItalicNode
-	(*BoldItalicNode)(nil),                 // 27: memos.api.v1.BoldItalicNode
-	(*CodeNode)(nil),                       // 28: memos.api.v1.CodeNode
-	(*ImageNode)(nil),                      // 29: memos.api.v1.ImageNode
-	(*LinkNode)(nil),                       // 30: memos.api.v1.LinkNode
-	(*AutoLinkNode)(nil),                   // 31: memos.api.v1.AutoLinkNode
-	(*TagNode)(nil),                        // 32: memos.api.v1.TagNode
-	(*StrikethroughNode)(nil),              // 33: memos.api.v1.StrikethroughNode
-	(*EscapingCharacterNode)(nil),          // 34: memos.api.v1.EscapingCharacterNode
-	(*MathNode)(nil),                       // 35: memos.api.v1.MathNode
-	(*HighlightNode)(nil),                  // 36: memos.api.v1.HighlightNode
-	(*SubscriptNode)(nil),                  // 37: memos.api.v1.SubscriptNode
-	(*SuperscriptNode)(nil),                // 38: memos.api.v1.SuperscriptNode
-	(*ReferencedContentNode)(nil),          // 39: memos.api.v1.ReferencedContentNode
-	(*SpoilerNode)(nil),                    // 40: memos.api.v1.SpoilerNode
-	(*HTMLElementNode)(nil),                // 41: memos.api.v1.HTMLElementNode
-	(*TableNode_Row)(nil),                  // 42: memos.api.v1.TableNode.Row
-	nil,                                    // 43: memos.api.v1.HTMLElementNode.AttributesEntry
+	(RenderMarkdownRequest_Format)(0),      // 1: memos.api.v1.RenderMarkdownRequest.Format
+	(ListNode_Kind)(0),                     // 2: memos.api.v1.ListNode.Kind
+	(*ParseMarkdownRequest)(nil),           // 3: memos.api.v1.ParseMarkdownRequest
+	(*ParseMarkdownResponse)(nil),          // 4: memos.api.v1.ParseMarkdownResponse
+	(*RestoreMarkdownNodesRequest)(nil),    // 5: memos.api.v1.RestoreMarkdownNodesRequest
+	(*RestoreMarkdownNodesResponse)(nil),   // 6: memos.api.v1.RestoreMarkdownNodesResponse
+	(*StringifyMarkdownNodesRequest)(nil),  // 7: memos.api.v1.StringifyMarkdownNodesRequest
+	(*StringifyMarkdownNodesResponse)(nil), // 8: memos.api.v1.StringifyMarkdownNodesResponse
+	(*RenderMarkdownRequest)(nil),          // 9: memos.api.v1.RenderMarkdownRequest
+	(*RenderMarkdownResponse)(nil),         // 10: memos.api.v1.RenderMarkdownResponse
+	(*GetLinkMetadataRequest)(nil),         // 11: memos.api.v1.GetLinkMetadataRequest
+	(*LinkMetadata)(nil),                   // 12: memos.api.v1.LinkMetadata
+	(*Node)(nil),                           // 13: memos.api.v1.Node
+	(*LineBreakNode)(nil),                  // 14: memos.api.v1.LineBreakNode
+	(*ParagraphNode)(nil),                  // 15: memos.api.v1.ParagraphNode
+	(*CodeBlockNode)(nil),                  // 16: memos.api.v1.CodeBlockNode
+	(*HeadingNode)(nil),                    // 17: memos.api.v1.HeadingNode
+	(*HorizontalRuleNode)(nil),             // 18: memos.api.v1.HorizontalRuleNode
+	(*BlockquoteNode)(nil),                 // 19: memos.api.v1.BlockquoteNode
+	(*ListNode)(nil),                       // 20: memos.api.v1.ListNode
+	(*OrderedListItemNode)(nil),            // 21: memos.api.v1.OrderedListItemNode
+	(*UnorderedListItemNode)(nil),          // 22: memos.api.v1.UnorderedListItemNode
+	(*TaskListItemNode)(nil),               // 23: memos.api.v1.TaskListItemNode
+	(*MathBlockNode)(nil),                  // 24: memos.api.v1.MathBlockNode
+	(*TableNode)(nil),                      // 25: memos.api.v1.TableNode
+	(*EmbeddedContentNode)(nil),            // 26: memos.api.v1.EmbeddedContentNode
+	(*TextNode)(nil),                       // 27: memos.api.v1.TextNode
+	(*BoldNode)(nil),                       // 28: memos.api.v1.BoldNode
+	(*ItalicNode)(nil),                     // 29: memos.api.v1.ItalicNode
+	(*BoldItalicNode)(nil),                 // 30: memos.api.v1.BoldItalicNode
+	(*CodeNode)(nil),                       // 31: memos.api.v1.CodeNode
+	(*ImageNode)(nil),                      // 32: memos.api.v1.ImageNode
+	(*LinkNode)(nil),                       // 33: memos.api.v1.LinkNode
+	(*AutoLinkNode)(nil),                   // 34: memos.api.v1.AutoLinkNode
+	(*TagNode)(nil),                        // 35: memos.api.v1.TagNode
+	(*StrikethroughNode)(nil),              // 36: memos.api.v1.StrikethroughNode
+	(*EscapingCharacterNode)(nil),          // 37: memos.api.v1.EscapingCharacterNode
+	(*MathNode)(nil),                       // 38: memos.api.v1.MathNode
+	(*HighlightNode)(nil),                  // 39: memos.api.v1.HighlightNode
+	(*SubscriptNode)(nil),                  // 40: memos.api.v1.SubscriptNode
+	(*SuperscriptNode)(nil),                // 41: memos.api.v1.SuperscriptNode
+	(*ReferencedContentNode)(nil),          // 42: memos.api.v1.ReferencedContentNode
+	(*SpoilerNode)(nil),                    // 43: memos.api.v1.SpoilerNode
+	(*HTMLElementNode)(nil),                // 44: memos.api.v1.HTMLElementNode
+	(*TableNode_Row)(nil),                  // 45: memos.api.v1.TableNode.Row
+	nil,                                    // 46: memos.api.v1.HTMLElementNode.AttributesEntry
 }
 var file_api_v1_markdown_service_proto_depIdxs = []int32{
-	10, // 0: memos.api.v1.ParseMarkdownResponse.nodes:type_name -> memos.api.v1.Node
-	10, // 1: memos.api.v1.RestoreMarkdownNodesRequest.nodes:type_name -> memos.api.v1.Node
-	10, // 2: memos.api.v1.StringifyMarkdownNodesRequest.nodes:type_name -> memos.api.v1.Node
-	0,  // 3: memos.api.v1.Node.type:type_name -> memos.api.v1.NodeType
-	11, // 4: memos.api.v1.Node.line_break_node:type_name -> memos.api.v1.LineBreakNode
-	12, // 5: memos.api.v1.Node.paragraph_node:type_name -> memos.api.v1.ParagraphNode
-	13, // 6: memos.api.v1.Node.code_block_node:type_name -> memos.api.v1.CodeBlockNode
-	14, // 7: memos.api.v1.Node.heading_node:type_name -> memos.api.v1.HeadingNode
-	15, // 8: memos.api.v1.Node.horizontal_rule_node:type_name -> memos.api.v1.HorizontalRuleNode
-	16, // 9: memos.api.v1.Node.blockquote_node:type_name -> memos.api.v1.BlockquoteNode
-	17, // 10: memos.api.v1.Node.list_node:type_name -> memos.api.v1.ListNode
-	18, // 11: memos.api.v1.Node.ordered_list_item_node:type_name -> memos.api.v1.OrderedListItemNode
-	19, // 12: memos.api.v1.Node.unordered_list_item_node:type_name -> memos.api.v1.UnorderedListItemNode
-	20, // 13: memos.api.v1.Node.task_list_item_node:type_name -> memos.api.v1.TaskListItemNode
-	21, // 14: memos.api.v1.Node.math_block_node:type_name -> memos.api.v1.MathBlockNode
-	22, // 15: memos.api.v1.Node.table_node:type_name -> memos.api.v1.TableNode
-	23, // 16: memos.api.v1.Node.embedded_content_node:type_name -> memos.api.v1.EmbeddedContentNode
-	24, // 17: memos.api.v1.Node.text_node:type_name -> memos.api.v1.TextNode
-	25, // 18: memos.api.v1.Node.bold_node:type_name -> memos.api.v1.BoldNode
-	26, // 19: memos.api.v1.Node.italic_node:type_name -> memos.api.v1.ItalicNode
-	27, // 20: memos.api.v1.Node.bold_italic_node:type_name -> memos.api.v1.BoldItalicNode
-	28, // 21: memos.api.v1.Node.code_node:type_name -> memos.api.v1.CodeNode
-	29, // 22: memos.api.v1.Node.image_node:type_name -> memos.api.v1.ImageNode
-	30, // 23: memos.api.v1.Node.link_node:type_name -> memos.api.v1.LinkNode
-	31, // 24: memos.api.v1.Node.auto_link_node:type_name -> memos.api.v1.AutoLinkNode
-	32, // 25: memos.api.v1.Node.tag_node:type_name -> memos.api.v1.TagNode
-	33, // 26: memos.api.v1.Node.strikethrough_node:type_name -> memos.api.v1.StrikethroughNode
-	34, // 27: memos.api.v1.Node.escaping_character_node:type_name -> memos.api.v1.EscapingCharacterNode
-	35, // 28: memos.api.v1.Node.math_node:type_name -> memos.api.v1.MathNode
-	36, // 29: memos.api.v1.Node.highlight_node:type_name -> memos.api.v1.HighlightNode
-	37, // 30: memos.api.v1.Node.subscript_node:type_name -> memos.api.v1.SubscriptNode
-	38, // 31: memos.api.v1.Node.superscript_node:type_name -> memos.api.v1.SuperscriptNode
-	39, // 32: memos.api.v1.Node.referenced_content_node:type_name -> memos.api.v1.ReferencedContentNode
-	40, // 33: memos.api.v1.Node.spoiler_node:type_name -> memos.api.v1.SpoilerNode
-	41, // 34: memos.api.v1.Node.html_element_node:type_name -> memos.api.v1.HTMLElementNode
-	10, // 35: memos.api.v1.ParagraphNode.children:type_name -> memos.api.v1.Node
-	10, // 36: memos.api.v1.HeadingNode.children:type_name -> memos.api.v1.Node
-	10, // 37: memos.api.v1.BlockquoteNode.children:type_name -> memos.api.v1.Node
-	1,  // 38: memos.api.v1.ListNode.kind:type_name -> memos.api.v1.ListNode.Kind
-	10, // 39: memos.api.v1.ListNode.children:type_name -> memos.api.v1.Node
-	10, // 40: memos.api.v1.OrderedListItemNode.children:type_name -> memos.api.v1.Node
-	10, // 41: memos.api.v1.UnorderedListItemNode.children:type_name -> memos.api.v1.Node
-	10, // 42: memos.api.v1.TaskListItemNode.children:type_name -> memos.api.v1.Node
-	10, // 43: memos.api.v1.TableNode.header:type_name -> memos.api.v1.Node
-	42, // 44: memos.api.v1.TableNode.rows:type_name -> memos.api.v1.TableNode.Row
-	10, // 45: memos.api.v1.BoldNode.children:type_name -> memos.api.v1.Node
-	10, // 46: memos.api.v1.ItalicNode.children:type_name -> memos.api.v1.Node
-	10, // 47: memos.api.v1.LinkNode.content:type_name -> memos.api.v1.Node
-	43, // 48: memos.api.v1.HTMLElementNode.attributes:type_name -> memos.api.v1.HTMLElementNode.AttributesEntry
-	10, // 49: memos.api.v1.TableNode.Row.cells:type_name -> memos.api.v1.Node
-	2,  // 50: memos.api.v1.MarkdownService.ParseMarkdown:input_type -> memos.api.v1.ParseMarkdownRequest
-	4,  // 51: memos.api.v1.MarkdownService.RestoreMarkdownNodes:input_type -> memos.api.v1.RestoreMarkdownNodesRequest
-	6,  // 52: memos.api.v1.MarkdownService.StringifyMarkdownNodes:input_type -> memos.api.v1.StringifyMarkdownNodesRequest
-	8,  // 53: memos.api.v1.MarkdownService.GetLinkMetadata:input_type -> memos.api.v1.GetLinkMetadataRequest
-	3,  // 54: memos.api.v1.MarkdownService.ParseMarkdown:output_type -> memos.api.v1.ParseMarkdownResponse
-	5,  // 55: memos.api.v1.MarkdownService.RestoreMarkdownNodes:output_type -> memos.api.v1.RestoreMarkdownNodesResponse
-	7,  // 56: memos.api.v1.MarkdownService.StringifyMarkdownNodes:output_type -> memos.api.v1.StringifyMarkdownNodesResponse
-	9,  // 57: memos.api.v1.MarkdownService.GetLinkMetadata:output_type -> memos.api.v1.LinkMetadata
-	54, // [54:58] is the sub-list for method output_type
-	50, // [50:54] is the sub-list for method input_type
-	50, // [50:50] is the sub-list for extension type_name
-	50, // [50:50] is the sub-list for extension extendee
-	0,  // [0:50] is the sub-list for field type_name
+	13, // 0: memos.api.v1.ParseMarkdownResponse.nodes:type_name -> memos.api.v1.Node
+	13, // 1: memos.api.v1.RestoreMarkdownNodesRequest.nodes:type_name -> memos.api.v1.Node
+	13, // 2: memos.api.v1.StringifyMarkdownNodesRequest.nodes:type_name -> memos.api.v1.Node
+	1,  // 3: memos.api.v1.RenderMarkdownRequest.format:type_name -> memos.api.v1.RenderMarkdownRequest.Format
+	0,  // 4: memos.api.v1.Node.type:type_name -> memos.api.v1.NodeType
+	14, // 5: memos.api.v1.Node.line_break_node:type_name -> memos.api.v1.LineBreakNode
+	15, // 6: memos.api.v1.Node.paragraph_node:type_name -> memos.api.v1.ParagraphNode
+	16, // 7: memos.api.v1.Node.code_block_node:type_name -> memos.api.v1.CodeBlockNode
+	17, // 8: memos.api.v1.Node.heading_node:type_name -> memos.api.v1.HeadingNode
+	18, // 9: memos.api.v1.Node.horizontal_rule_node:type_name -> memos.api.v1.HorizontalRuleNode
+	19, // 10: memos.api.v1.Node.blockquote_node:type_name -> memos.api.v1.BlockquoteNode
+	20, // 11: memos.api.v1.Node.list_node:type_name -> memos.api.v1.ListNode
+	21, // 12: memos.api.v1.Node.ordered_list_item_node:type_name -> memos.api.v1.OrderedListItemNode
+	22, // 13: memos.api.v1.Node.unordered_list_item_node:type_name -> memos.api.v1.UnorderedListItemNode
+	23, // 14: memos.api.v1.Node.task_list_item_node:type_name -> memos.api.v1.TaskListItemNode
+	24, // 15: memos.api.v1.Node.math_block_node:type_name -> memos.api.v1.MathBlockNode
+	25, // 16: memos.api.v1.Node.table_node:type_name -> memos.api.v1.TableNode
+	26, // 17: memos.api.v1.Node.embedded_content_node:type_name -> memos.api.v1.EmbeddedContentNode
+	27, // 18: memos.api.v1.Node.text_node:type_name -> memos.api.v1.TextNode
+	28, // 19: memos.api.v1.Node.bold_node:type_name -> memos.api.v1.BoldNode
+	29, // 20: memos.api.v1.Node.italic_node:type_name -> memos.api.v1.ItalicNode
+	30, // 21: memos.api.v1.Node.bold_italic_node:type_name -> memos.api.v1.BoldItalicNode
+	31, // 22: memos.api.v1.Node.code_node:type_name -> memos.api.v1.CodeNode
+	32, // 23: memos.api.v1.Node.image_node:type_name -> memos.api.v1.ImageNode
+	33, // 24: memos.api.v1.Node.link_node:type_name -> memos.api.v1.LinkNode
+	34, // 25: memos.api.v1.Node.auto_link_node:type_name -> memos.api.v1.AutoLinkNode
+	35, // 26: memos.api.v1.Node.tag_node:type_name -> memos.api.v1.TagNode
+	36, // 27: memos.api.v1.Node.strikethrough_node:type_name -> memos.api.v1.StrikethroughNode
+	37, // 28: memos.api.v1.Node.escaping_character_node:type_name -> memos.api.v1.EscapingCharacterNode
+	38, // 29: memos.api.v1.Node.math_node:type_name -> memos.api.v1.MathNode
+	39, // 30: memos.api.v1.Node.highlight_node:type_name -> memos.api.v1.HighlightNode
+	40, // 31: memos.api.v1.Node.subscript_node:type_name -> memos.api.v1.SubscriptNode
+	41, // 32: memos.api.v1.Node.superscript_node:type_name -> memos.api.v1.SuperscriptNode
+	42, // 33: memos.api.v1.Node.referenced_content_node:type_name -> memos.api.v1.ReferencedContentNode
+	43, // 34: memos.api.v1.Node.spoiler_node:type_name -> memos.api.v1.SpoilerNode
+	44, // 35: memos.api.v1.Node.html_element_node:type_name -> memos.api.v1.HTMLElementNode
+	13, // 36: memos.api.v1.ParagraphNode.children:type_name -> memos.api.v1.Node
+	13, // 37: memos.api.v1.HeadingNode.children:type_name -> memos.api.v1.Node
+	13, // 38: memos.api.v1.BlockquoteNode.children:type_name -> memos.api.v1.Node
+	2,  // 39: memos.api.v1.ListNode.kind:type_name -> memos.api.v1.ListNode.Kind
+	13, // 40: memos.api.v1.ListNode.children:type_name -> memos.api.v1.Node
+	13, // 41: memos.api.v1.OrderedListItemNode.children:type_name -> memos.api.v1.Node
+	13, // 42: memos.api.v1.UnorderedListItemNode.children:type_name -> memos.api.v1.Node
+	13, // 43: memos.api.v1.TaskListItemNode.children:type_name -> memos.api.v1.Node
+	13, // 44: memos.api.v1.TableNode.header:type_name -> memos.api.v1.Node
+	45, // 45: memos.api.v1.TableNode.rows:type_name -> memos.api.v1.TableNode.Row
+	13, // 46: memos.api.v1.BoldNode.children:type_name -> memos.api.v1.Node
+	13, // 47: memos.api.v1.ItalicNode.children:type_name -> memos.api.v1.Node
+	13, // 48: memos.api.v1.LinkNode.content:type_name -> memos.api.v1.Node
+	46, // 49: memos.api.v1.HTMLElementNode.attributes:type_name -> memos.api.v1.HTMLElementNode.AttributesEntry
+	13, // 50: memos.api.v1.TableNode.Row.cells:type_name -> memos.api.v1.Node
+	3,  // 51: memos.api.v1.MarkdownService.ParseMarkdown:input_type -> memos.api.v1.ParseMarkdownRequest
+	5,  // 52: memos.api.v1.MarkdownService.RestoreMarkdownNodes:input_type -> memos.api.v1.RestoreMarkdownNodesRequest
+	7,  // 53: memos.api.v1.MarkdownService.StringifyMarkdownNodes:input_type -> memos.api.v1.StringifyMarkdownNodesRequest
+	9,  // 54: memos.api.v1.MarkdownService.RenderMarkdown:input_type -> memos.api.v1.RenderMarkdownRequest
+	11, // 55: memos.api.v1.MarkdownService.GetLinkMetadata:input_type -> memos.api.v1.GetLinkMetadataRequest
+	4,  // 56: memos.api.v1.MarkdownService.ParseMarkdown:output_type -> memos.api.v1.ParseMarkdownResponse
+	6,  // 57: memos.api.v1.MarkdownService.RestoreMarkdownNodes:output_type -> memos.api.v1.RestoreMarkdownNodesResponse
+	8,  // 58: memos.api.v1.MarkdownService.StringifyMarkdownNodes:output_type -> memos.api.v1.StringifyMarkdownNodesResponse
+	10, // 59: memos.api.v1.MarkdownService.RenderMarkdown:output_type -> memos.api.v1.RenderMarkdownResponse
+	12, // 60: memos.api.v1.MarkdownService.GetLinkMetadata:output_type -> memos.api.v1.LinkMetadata
+	56, // [56:61] is the sub-list for method output_type
+	51, // [51:56] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_markdown_service_proto_init() }
@@ -3046,7 +3221,7 @@ func file_api_v1_markdown_service_proto_init() {
 	if File_api_v1_markdown_service_proto != nil {
 		return
 	}
-	file_api_v1_markdown_service_proto_msgTypes[8].OneofWrappers = []any{
+	file_api_v1_markdown_service_proto_msgTypes[10].OneofWrappers = []any{
 		(*Node_LineBreakNode)(nil),
 		(*Node_ParagraphNode)(nil),
 		(*Node_CodeBlockNode)(nil),
@@ -3084,8 +3259,8 @@ func file_api_v1_markdown_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_markdown_service_proto_rawDesc), len(file_api_v1_markdown_service_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   42,
+			NumEnums:      3,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,7 @@ const (
 	MarkdownService_ParseMarkdown_FullMethodName          = "/memos.api.v1.MarkdownService/ParseMarkdown"
 	MarkdownService_RestoreMarkdownNodes_FullMethodName   = "/memos.api.v1.MarkdownService/RestoreMarkdownNodes"
 	MarkdownService_StringifyMarkdownNodes_FullMethodName = "/memos.api.v1.MarkdownService/StringifyMarkdownNodes"
+	MarkdownService_RenderMarkdown_FullMethodName         = "/memos.api.v1.MarkdownService/RenderMarkdown"
 	MarkdownService_GetLinkMetadata_FullMethodName        = "/memos.api.v1.MarkdownService/GetLinkMetadata"
 )
 
@@ -35,6 +36,9 @@ type MarkdownServiceClient interface {
 	RestoreMarkdownNodes(ctx context.Context, in *RestoreMarkdownNodesRequest, opts ...grpc.CallOption) (*RestoreMarkdownNodesResponse, error)
 	// StringifyMarkdownNodes stringify the given nodes to plain text content.
 	StringifyMarkdownNodes(ctx context.Context, in *StringifyMarkdownNodesRequest, opts ...grpc.CallOption) (*StringifyMarkdownNodesResponse, error)
+	// RenderMarkdown renders the given markdown content to sanitized HTML or another format.
+	// The embedded and referenced resources and memos are resolved to absolute URLs.
+	RenderMarkdown(ctx context.Context, in *RenderMarkdownRequest, opts ...grpc.CallOption) (*RenderMarkdownResponse, error)
 	// GetLinkMetadata returns metadata for a given link.
 	GetLinkMetadata(ctx context.Context, in *GetLinkMetadataRequest, opts ...grpc.CallOption) (*LinkMetadata, error)
 }
@@ -77,6 +81,16 @@ func (c *markdownServiceClient) StringifyMarkdownNodes(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *markdownServiceClient) RenderMarkdown(ctx context.Context, in *RenderMarkdownRequest, opts ...grpc.CallOption) (*RenderMarkdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderMarkdownResponse)
+	err := c.cc.Invoke(ctx, MarkdownService_RenderMarkdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *markdownServiceClient) GetLinkMetadata(ctx context.Context, in *GetLinkMetadataRequest, opts ...grpc.CallOption) (*LinkMetadata, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LinkMetadata)
@@ -97,6 +111,9 @@ type MarkdownServiceServer interface {
 	RestoreMarkdownNodes(context.Context, *RestoreMarkdownNodesRequest) (*RestoreMarkdownNodesResponse, error)
 	// StringifyMarkdownNodes stringify the given nodes to plain text content.
 	StringifyMarkdownNodes(context.Context, *StringifyMarkdownNodesRequest) (*StringifyMarkdownNodesResponse, error)
+	// RenderMarkdown renders the given markdown content to sanitized HTML or another format.
+	// The embedded and referenced resources and memos are resolved to absolute URLs.
+	RenderMarkdown(context.Context, *RenderMarkdownRequest) (*RenderMarkdownResponse, error)
 	// GetLinkMetadata returns metadata for a given link.
 	GetLinkMetadata(context.Context, *GetLinkMetadataRequest) (*LinkMetadata, error)
 	mustEmbedUnimplementedMarkdownServiceServer()
@@ -118,6 +135,9 @@ func (UnimplementedMarkdownServiceServer) RestoreMarkdownNodes(context.Context,
 func (UnimplementedMarkdownServiceServer) StringifyMarkdownNodes(context.Context, *StringifyMarkdownNodesRequest) (*StringifyMarkdownNodesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method StringifyMarkdownNodes not implemented")
 }
+func (UnimplementedMarkdownServiceServer) RenderMarkdown(context.Context, *RenderMarkdownRequest) (*RenderMarkdownResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenderMarkdown not implemented")
+}
 func (UnimplementedMarkdownServiceServer) GetLinkMetadata(context.Context, *GetLinkMetadataRequest) (*LinkMetadata, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetLinkMetadata not implemented")
 }
@@ -196,6 +216,24 @@ func _MarkdownService_StringifyMarkdownNodes_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MarkdownService_RenderMarkdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderMarkdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarkdownServiceServer).RenderMarkdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarkdownService_RenderMarkdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarkdownServiceServer).RenderMarkdown(ctx, req.(*RenderMarkdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MarkdownService_GetLinkMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetLinkMetadataRequest)
 	if err := dec(in); err != nil {
@@ -233,6 +271,10 @@ var MarkdownService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StringifyMarkdownNodes",
 			Handler:    _MarkdownService_StringifyMarkdownNodes_Handler,
 		},
+		{
+			MethodName: "RenderMarkdown",
+			Handler:    _MarkdownService_RenderMarkdown_Handler,
+		},
 		{
 			MethodName: "GetLinkMetadata",
 			Handler:    _MarkdownService_GetLinkMetadata_Handler,
@@ -32,6 +32,8 @@ const (
 	Visibility_PRIVATE                Visibility = 1
 	Visibility_PROTECTED              Visibility = 2
 	Visibility_PUBLIC                 Visibility = 3
+	// Only visible to the members of the project of the memo.
+	Visibility_PROJECT Visibility = 4
 )
 
 // Enum value maps for Visibility.
@@ -41,12 +43,14 @@ var (
 		1: "PRIVATE",
 		2: "PROTECTED",
 		3: "PUBLIC",
+		4: "PROJECT",
 	}
 	Visibility_value = map[string]int32{
 		"VISIBILITY_UNSPECIFIED": 0,
 		"PRIVATE":                1,
 		"PROTECTED":              2,
 		"PUBLIC":                 3,
+		"PROJECT":                4,
 	}
 )
 
@@ -153,7 +157,10 @@ type Memo struct {
 	// The snippet of the memo content. Plain text only.
 	Snippet string `protobuf:"bytes,19,opt,name=snippet,proto3" json:"snippet,omitempty"`
 	// The location of the memo.
-	Location      *Location `protobuf:"bytes,20,opt,name=location,proto3,oneof" json:"location,omitempty"`
+	Location *Location `protobuf:"bytes,20,opt,name=location,proto3,oneof" json:"location,omitempty"`
+	// The name of the project of the memo, required for PROJECT visibility.
+	// Format: projects/{id}
+	Project       string `protobuf:"bytes,21,opt,name=project,proto3" json:"project,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -314,6 +321,13 @@ func (x *Memo) GetLocation() *Location {
 	return nil
 }
 
+func (x *Memo) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
 type Location struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Placeholder   string                 `protobuf:"bytes,1,opt,name=placeholder,proto3" json:"placeholder,omitempty"`
@@ -1200,6 +1214,281 @@ func (x *ListMemoRelationsResponse) GetRelations() []*MemoRelation {
 	return nil
 }
 
+type ToggleMemoTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the memo.
+	// Format: memos/{uid}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The index of the task in document order, starting from 0.
+	// Ignored when task_id is set.
+	Index int32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// The stable id of the task.
+	TaskId        string `protobuf:"bytes,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToggleMemoTaskRequest) Reset() {
+	*x = ToggleMemoTaskRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleMemoTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleMemoTaskRequest) ProtoMessage() {}
+
+func (x *ToggleMemoTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleMemoTaskRequest.ProtoReflect.Descriptor instead.
+func (*ToggleMemoTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ToggleMemoTaskRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToggleMemoTaskRequest) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ToggleMemoTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type PromoteMemoTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the memo.
+	// Format: memos/{uid}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The index of the task in document order, starting from 0.
+	// Ignored when task_id is set.
+	Index int32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// The stable id of the task.
+	TaskId        string `protobuf:"bytes,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteMemoTaskRequest) Reset() {
+	*x = PromoteMemoTaskRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteMemoTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteMemoTaskRequest) ProtoMessage() {}
+
+func (x *PromoteMemoTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteMemoTaskRequest.ProtoReflect.Descriptor instead.
+func (*PromoteMemoTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PromoteMemoTaskRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PromoteMemoTaskRequest) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PromoteMemoTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type PromoteMemoTaskResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The memo with the task linked to the ticket.
+	Memo *Memo `protobuf:"bytes,1,opt,name=memo,proto3" json:"memo,omitempty"`
+	// The id of the created ticket.
+	TicketId      int32 `protobuf:"varint,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteMemoTaskResponse) Reset() {
+	*x = PromoteMemoTaskResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteMemoTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteMemoTaskResponse) ProtoMessage() {}
+
+func (x *PromoteMemoTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteMemoTaskResponse.ProtoReflect.Descriptor instead.
+func (*PromoteMemoTaskResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PromoteMemoTaskResponse) GetMemo() *Memo {
+	if x != nil {
+		return x.Memo
+	}
+	return nil
+}
+
+func (x *PromoteMemoTaskResponse) GetTicketId() int32 {
+	if x != nil {
+		return x.TicketId
+	}
+	return 0
+}
+
+type ListBacklinksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the memo or the ticket.
+	// Format: memos/{uid} or tickets/{id}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBacklinksRequest) Reset() {
+	*x = ListBacklinksRequest{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBacklinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBacklinksRequest) ProtoMessage() {}
+
+func (x *ListBacklinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBacklinksRequest.ProtoReflect.Descriptor instead.
+func (*ListBacklinksRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListBacklinksRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListBacklinksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The memos referencing the memo or the ticket.
+	Memos         []*MemoRelation_Memo `protobuf:"bytes,1,rep,name=memos,proto3" json:"memos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBacklinksResponse) Reset() {
+	*x = ListBacklinksResponse{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBacklinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBacklinksResponse) ProtoMessage() {}
+
+func (x *ListBacklinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBacklinksResponse.ProtoReflect.Descriptor instead.
+func (*ListBacklinksResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListBacklinksResponse) GetMemos() []*MemoRelation_Memo {
+	if x != nil {
+		return x.Memos
+	}
+	return nil
+}
+
 type CreateMemoCommentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The name of the memo.
@@ -1212,7 +1501,7 @@ type CreateMemoCommentRequest struct {
 
 func (x *CreateMemoCommentRequest) Reset() {
 	*x = CreateMemoCommentRequest{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[17]
+	mi := &file_api_v1_memo_service_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1224,7 +1513,7 @@ func (x *CreateMemoCommentRequest) String() string {
 func (*CreateMemoCommentRequest) ProtoMessage() {}
 
 func (x *CreateMemoCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[17]
+	mi := &file_api_v1_memo_service_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1237,7 +1526,7 @@ func (x *CreateMemoCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateMemoCommentRequest.ProtoReflect.Descriptor instead.
 func (*CreateMemoCommentRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{17}
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *CreateMemoCommentRequest) GetName() string {
@@ -1264,7 +1553,7 @@ type ListMemoCommentsRequest struct {
 
 func (x *ListMemoCommentsRequest) Reset() {
 	*x = ListMemoCommentsRequest{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[18]
+	mi := &file_api_v1_memo_service_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1276,7 +1565,7 @@ func (x *ListMemoCommentsRequest) String() string {
 func (*ListMemoCommentsRequest) ProtoMessage() {}
 
 func (x *ListMemoCommentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[18]
+	mi := &file_api_v1_memo_service_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1289,7 +1578,7 @@ func (x *ListMemoCommentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMemoCommentsRequest.ProtoReflect.Descriptor instead.
 func (*ListMemoCommentsRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{18}
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ListMemoCommentsRequest) GetName() string {
@@ -1308,7 +1597,7 @@ type ListMemoCommentsResponse struct {
 
 func (x *ListMemoCommentsResponse) Reset() {
 	*x = ListMemoCommentsResponse{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[19]
+	mi := &file_api_v1_memo_service_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1320,7 +1609,7 @@ func (x *ListMemoCommentsResponse) String() string {
 func (*ListMemoCommentsResponse) ProtoMessage() {}
 
 func (x *ListMemoCommentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[19]
+	mi := &file_api_v1_memo_service_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1333,7 +1622,7 @@ func (x *ListMemoCommentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMemoCommentsResponse.ProtoReflect.Descriptor instead.
 func (*ListMemoCommentsResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{19}
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ListMemoCommentsResponse) GetMemos() []*Memo {
@@ -1353,7 +1642,7 @@ type ListMemoReactionsRequest struct {
 
 func (x *ListMemoReactionsRequest) Reset() {
 	*x = ListMemoReactionsRequest{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[20]
+	mi := &file_api_v1_memo_service_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1365,7 +1654,7 @@ func (x *ListMemoReactionsRequest) String() string {
 func (*ListMemoReactionsRequest) ProtoMessage() {}
 
 func (x *ListMemoReactionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[20]
+	mi := &file_api_v1_memo_service_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1378,7 +1667,7 @@ func (x *ListMemoReactionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMemoReactionsRequest.ProtoReflect.Descriptor instead.
 func (*ListMemoReactionsRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{20}
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ListMemoReactionsRequest) GetName() string {
@@ -1397,7 +1686,7 @@ type ListMemoReactionsResponse struct {
 
 func (x *ListMemoReactionsResponse) Reset() {
 	*x = ListMemoReactionsResponse{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[21]
+	mi := &file_api_v1_memo_service_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1409,7 +1698,7 @@ func (x *ListMemoReactionsResponse) String() string {
 func (*ListMemoReactionsResponse) ProtoMessage() {}
 
 func (x *ListMemoReactionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[21]
+	mi := &file_api_v1_memo_service_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1422,7 +1711,7 @@ func (x *ListMemoReactionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMemoReactionsResponse.ProtoReflect.Descriptor instead.
 func (*ListMemoReactionsResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{21}
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *ListMemoReactionsResponse) GetReactions() []*Reaction {
@@ -1443,7 +1732,7 @@ type UpsertMemoReactionRequest struct {
 
 func (x *UpsertMemoReactionRequest) Reset() {
 	*x = UpsertMemoReactionRequest{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[22]
+	mi := &file_api_v1_memo_service_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1455,7 +1744,7 @@ func (x *UpsertMemoReactionRequest) String() string {
 func (*UpsertMemoReactionRequest) ProtoMessage() {}
 
 func (x *UpsertMemoReactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[22]
+	mi := &file_api_v1_memo_service_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1468,7 +1757,7 @@ func (x *UpsertMemoReactionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpsertMemoReactionRequest.ProtoReflect.Descriptor instead.
 func (*UpsertMemoReactionRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{22}
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *UpsertMemoReactionRequest) GetName() string {
@@ -1496,7 +1785,7 @@ type DeleteMemoReactionRequest struct {
 
 func (x *DeleteMemoReactionRequest) Reset() {
 	*x = DeleteMemoReactionRequest{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[23]
+	mi := &file_api_v1_memo_service_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1508,7 +1797,7 @@ func (x *DeleteMemoReactionRequest) String() string {
 func (*DeleteMemoReactionRequest) ProtoMessage() {}
 
 func (x *DeleteMemoReactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[23]
+	mi := &file_api_v1_memo_service_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1521,7 +1810,7 @@ func (x *DeleteMemoReactionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteMemoReactionRequest.ProtoReflect.Descriptor instead.
 func (*DeleteMemoReactionRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{23}
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *DeleteMemoReactionRequest) GetId() int32 {
@@ -1537,13 +1826,19 @@ type Memo_Property struct {
 	HasTaskList        bool                   `protobuf:"varint,2,opt,name=has_task_list,json=hasTaskList,proto3" json:"has_task_list,omitempty"`
 	HasCode            bool                   `protobuf:"varint,3,opt,name=has_code,json=hasCode,proto3" json:"has_code,omitempty"`
 	HasIncompleteTasks bool                   `protobuf:"varint,4,opt,name=has_incomplete_tasks,json=hasIncompleteTasks,proto3" json:"has_incomplete_tasks,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// The number of tasks in the task lists of the memo.
+	TaskCount int32 `protobuf:"varint,5,opt,name=task_count,json=taskCount,proto3" json:"task_count,omitempty"`
+	// The number of completed tasks in the task lists of the memo.
+	CompletedTaskCount int32 `protobuf:"varint,6,opt,name=completed_task_count,json=completedTaskCount,proto3" json:"completed_task_count,omitempty"`
+	// The tasks in the task lists of the memo, in document order.
+	Tasks         []*Memo_Property_Task `protobuf:"bytes,7,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Memo_Property) Reset() {
 	*x = Memo_Property{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[24]
+	mi := &file_api_v1_memo_service_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1555,7 +1850,7 @@ func (x *Memo_Property) String() string {
 func (*Memo_Property) ProtoMessage() {}
 
 func (x *Memo_Property) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[24]
+	mi := &file_api_v1_memo_service_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1599,6 +1894,89 @@ func (x *Memo_Property) GetHasIncompleteTasks() bool {
 	return false
 }
 
+func (x *Memo_Property) GetTaskCount() int32 {
+	if x != nil {
+		return x.TaskCount
+	}
+	return 0
+}
+
+func (x *Memo_Property) GetCompletedTaskCount() int32 {
+	if x != nil {
+		return x.CompletedTaskCount
+	}
+	return 0
+}
+
+func (x *Memo_Property) GetTasks() []*Memo_Property_Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type Memo_Property_Task struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The stable id of the task, derived from its content.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The markdown content of the task.
+	Content       string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Completed     bool   `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Memo_Property_Task) Reset() {
+	*x = Memo_Property_Task{}
+	mi := &file_api_v1_memo_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Memo_Property_Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Memo_Property_Task) ProtoMessage() {}
+
+func (x *Memo_Property_Task) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Memo_Property_Task.ProtoReflect.Descriptor instead.
+func (*Memo_Property_Task) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_service_proto_rawDescGZIP(), []int{0, 0, 0}
+}
+
+func (x *Memo_Property_Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Memo_Property_Task) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Memo_Property_Task) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
 type MemoRelation_Memo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The name of the memo.
@@ -1613,7 +1991,7 @@ type MemoRelation_Memo struct {
 
 func (x *MemoRelation_Memo) Reset() {
 	*x = MemoRelation_Memo{}
-	mi := &file_api_v1_memo_service_proto_msgTypes[25]
+	mi := &file_api_v1_memo_service_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1625,7 +2003,7 @@ func (x *MemoRelation_Memo) String() string {
 func (*MemoRelation_Memo) ProtoMessage() {}
 
 func (x *MemoRelation_Memo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_memo_service_proto_msgTypes[25]
+	mi := &file_api_v1_memo_service_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1666,7 +2044,7 @@ var File_api_v1_memo_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_memo_service_proto_rawDesc = "" +
 	"\n" +
-	"\x19api/v1/memo_service.proto\x12\fmemos.api.v1\x1a\x13api/v1/common.proto\x1a\x1dapi/v1/markdown_service.proto\x1a\x1dapi/v1/reaction_service.proto\x1a\x1dapi/v1/resource_service.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xee\a\n" +
+	"\x19api/v1/memo_service.proto\x12\fmemos.api.v1\x1a\x13api/v1/common.proto\x1a\x1dapi/v1/markdown_service.proto\x1a\x1dapi/v1/reaction_service.proto\x1a\x1dapi/v1/resource_service.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe1\t\n" +
 	"\x04Memo\x12\x1a\n" +
 	"\x04name\x18\x01 \x01(\tB\x06\xe0A\x03\xe0A\bR\x04name\x12)\n" +
 	"\x05state\x18\x03 \x01(\x0e2\x13.memos.api.v1.StateR\x05state\x12\x18\n" +
@@ -1690,12 +2068,21 @@ const file_api_v1_memo_service_proto_rawDesc = "" +
 	"\bproperty\x18\x11 \x01(\v2\x1b.memos.api.v1.Memo.PropertyB\x03\xe0A\x03R\bproperty\x12 \n" +
 	"\x06parent\x18\x12 \x01(\tB\x03\xe0A\x03H\x00R\x06parent\x88\x01\x01\x12\x1d\n" +
 	"\asnippet\x18\x13 \x01(\tB\x03\xe0A\x03R\asnippet\x127\n" +
-	"\blocation\x18\x14 \x01(\v2\x16.memos.api.v1.LocationH\x01R\blocation\x88\x01\x01\x1a\x96\x01\n" +
+	"\blocation\x18\x14 \x01(\v2\x16.memos.api.v1.LocationH\x01R\blocation\x88\x01\x01\x12\x18\n" +
+	"\aproject\x18\x15 \x01(\tR\aproject\x1a\xef\x02\n" +
 	"\bProperty\x12\x19\n" +
 	"\bhas_link\x18\x01 \x01(\bR\ahasLink\x12\"\n" +
 	"\rhas_task_list\x18\x02 \x01(\bR\vhasTaskList\x12\x19\n" +
 	"\bhas_code\x18\x03 \x01(\bR\ahasCode\x120\n" +
-	"\x14has_incomplete_tasks\x18\x04 \x01(\bR\x12hasIncompleteTasksB\t\n" +
+	"\x14has_incomplete_tasks\x18\x04 \x01(\bR\x12hasIncompleteTasks\x12\x1d\n" +
+	"\n" +
+	"task_count\x18\x05 \x01(\x05R\ttaskCount\x120\n" +
+	"\x14completed_task_count\x18\x06 \x01(\x05R\x12completedTaskCount\x126\n" +
+	"\x05tasks\x18\a \x03(\v2 .memos.api.v1.Memo.Property.TaskR\x05tasks\x1aN\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1c\n" +
+	"\tcompleted\x18\x03 \x01(\bR\tcompletedB\t\n" +
 	"\a_parentB\v\n" +
 	"\t_locationJ\x04\b\x02\x10\x03\"f\n" +
 	"\bLocation\x12 \n" +
@@ -1759,7 +2146,22 @@ const file_api_v1_memo_service_proto_rawDesc = "" +
 	"\x18ListMemoRelationsRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\"U\n" +
 	"\x19ListMemoRelationsResponse\x128\n" +
-	"\trelations\x18\x01 \x03(\v2\x1a.memos.api.v1.MemoRelationR\trelations\"\\\n" +
+	"\trelations\x18\x01 \x03(\v2\x1a.memos.api.v1.MemoRelationR\trelations\"Z\n" +
+	"\x15ToggleMemoTaskRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12\x17\n" +
+	"\atask_id\x18\x03 \x01(\tR\x06taskId\"[\n" +
+	"\x16PromoteMemoTaskRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12\x17\n" +
+	"\atask_id\x18\x03 \x01(\tR\x06taskId\"^\n" +
+	"\x17PromoteMemoTaskResponse\x12&\n" +
+	"\x04memo\x18\x01 \x01(\v2\x12.memos.api.v1.MemoR\x04memo\x12\x1b\n" +
+	"\tticket_id\x18\x02 \x01(\x05R\bticketId\"*\n" +
+	"\x14ListBacklinksRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"N\n" +
+	"\x15ListBacklinksResponse\x125\n" +
+	"\x05memos\x18\x01 \x03(\v2\x1f.memos.api.v1.MemoRelation.MemoR\x05memos\"\\\n" +
 	"\x18CreateMemoCommentRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12,\n" +
 	"\acomment\x18\x02 \x01(\v2\x12.memos.api.v1.MemoR\acomment\"-\n" +
@@ -1775,14 +2177,15 @@ const file_api_v1_memo_service_proto_rawDesc = "" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x122\n" +
 	"\breaction\x18\x02 \x01(\v2\x16.memos.api.v1.ReactionR\breaction\"+\n" +
 	"\x19DeleteMemoReactionRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id*P\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id*]\n" +
 	"\n" +
 	"Visibility\x12\x1a\n" +
 	"\x16VISIBILITY_UNSPECIFIED\x10\x00\x12\v\n" +
 	"\aPRIVATE\x10\x01\x12\r\n" +
 	"\tPROTECTED\x10\x02\x12\n" +
 	"\n" +
-	"\x06PUBLIC\x10\x032\xbf\x10\n" +
+	"\x06PUBLIC\x10\x03\x12\v\n" +
+	"\aPROJECT\x10\x042\x88\x14\n" +
 	"\vMemoService\x12^\n" +
 	"\n" +
 	"CreateMemo\x12\x1f.memos.api.v1.CreateMemoRequest\x1a\x12.memos.api.v1.Memo\"\x1b\x82\xd3\xe4\x93\x02\x15:\x04memo\"\r/api/v1/memos\x12\x85\x01\n" +
@@ -1797,7 +2200,10 @@ const file_api_v1_memo_service_proto_rawDesc = "" +
 	"\x10SetMemoResources\x12%.memos.api.v1.SetMemoResourcesRequest\x1a\x16.google.protobuf.Empty\"2\xdaA\x04name\x82\xd3\xe4\x93\x02%:\x01*2 /api/v1/{name=memos/*}/resources\x12\x95\x01\n" +
 	"\x11ListMemoResources\x12&.memos.api.v1.ListMemoResourcesRequest\x1a'.memos.api.v1.ListMemoResourcesResponse\"/\xdaA\x04name\x82\xd3\xe4\x93\x02\"\x12 /api/v1/{name=memos/*}/resources\x12\x85\x01\n" +
 	"\x10SetMemoRelations\x12%.memos.api.v1.SetMemoRelationsRequest\x1a\x16.google.protobuf.Empty\"2\xdaA\x04name\x82\xd3\xe4\x93\x02%:\x01*2 /api/v1/{name=memos/*}/relations\x12\x95\x01\n" +
-	"\x11ListMemoRelations\x12&.memos.api.v1.ListMemoRelationsRequest\x1a'.memos.api.v1.ListMemoRelationsResponse\"/\xdaA\x04name\x82\xd3\xe4\x93\x02\"\x12 /api/v1/{name=memos/*}/relations\x12\x88\x01\n" +
+	"\x11ListMemoRelations\x12&.memos.api.v1.ListMemoRelationsRequest\x1a'.memos.api.v1.ListMemoRelationsResponse\"/\xdaA\x04name\x82\xd3\xe4\x93\x02\"\x12 /api/v1/{name=memos/*}/relations\x12~\n" +
+	"\x0eToggleMemoTask\x12#.memos.api.v1.ToggleMemoTaskRequest\x1a\x12.memos.api.v1.Memo\"3\xdaA\x04name\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/{name=memos/*}:toggleTask\x12\x94\x01\n" +
+	"\x0fPromoteMemoTask\x12$.memos.api.v1.PromoteMemoTaskRequest\x1a%.memos.api.v1.PromoteMemoTaskResponse\"4\xdaA\x04name\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/{name=memos/*}:promoteTask\x12\xaf\x01\n" +
+	"\rListBacklinks\x12\".memos.api.v1.ListBacklinksRequest\x1a#.memos.api.v1.ListBacklinksResponse\"U\xdaA\x04name\x82\xd3\xe4\x93\x02HZ$\x12\"/api/v1/{name=tickets/*}/backlinks\x12 /api/v1/{name=memos/*}/backlinks\x12\x88\x01\n" +
 	"\x11CreateMemoComment\x12&.memos.api.v1.CreateMemoCommentRequest\x1a\x12.memos.api.v1.Memo\"7\xdaA\x04name\x82\xd3\xe4\x93\x02*:\acomment\"\x1f/api/v1/{name=memos/*}/comments\x12\x91\x01\n" +
 	"\x10ListMemoComments\x12%.memos.api.v1.ListMemoCommentsRequest\x1a&.memos.api.v1.ListMemoCommentsResponse\".\xdaA\x04name\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/{name=memos/*}/comments\x12\x95\x01\n" +
 	"\x11ListMemoReactions\x12&.memos.api.v1.ListMemoReactionsRequest\x1a'.memos.api.v1.ListMemoReactionsResponse\"/\xdaA\x04name\x82\xd3\xe4\x93\x02\"\x12 /api/v1/{name=memos/*}/reactions\x12\x89\x01\n" +
@@ -1818,7 +2224,7 @@ func file_api_v1_memo_service_proto_rawDescGZIP() []byte {
 }
 
 var file_api_v1_memo_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_v1_memo_service_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_api_v1_memo_service_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_api_v1_memo_service_proto_goTypes = []any{
 	(Visibility)(0),                   // 0: memos.api.v1.Visibility
 	(MemoRelation_Type)(0),            // 1: memos.api.v1.MemoRelation.Type
@@ -1839,90 +2245,105 @@ var file_api_v1_memo_service_proto_goTypes = []any{
 	(*SetMemoRelationsRequest)(nil),   // 16: memos.api.v1.SetMemoRelationsRequest
 	(*ListMemoRelationsRequest)(nil),  // 17: memos.api.v1.ListMemoRelationsRequest
 	(*ListMemoRelationsResponse)(nil), // 18: memos.api.v1.ListMemoRelationsResponse
-	(*CreateMemoCommentRequest)(nil),  // 19: memos.api.v1.CreateMemoCommentRequest
-	(*ListMemoCommentsRequest)(nil),   // 20: memos.api.v1.ListMemoCommentsRequest
-	(*ListMemoCommentsResponse)(nil),  // 21: memos.api.v1.ListMemoCommentsResponse
-	(*ListMemoReactionsRequest)(nil),  // 22: memos.api.v1.ListMemoReactionsRequest
-	(*ListMemoReactionsResponse)(nil), // 23: memos.api.v1.ListMemoReactionsResponse
-	(*UpsertMemoReactionRequest)(nil), // 24: memos.api.v1.UpsertMemoReactionRequest
-	(*DeleteMemoReactionRequest)(nil), // 25: memos.api.v1.DeleteMemoReactionRequest
-	(*Memo_Property)(nil),             // 26: memos.api.v1.Memo.Property
-	(*MemoRelation_Memo)(nil),         // 27: memos.api.v1.MemoRelation.Memo
-	(State)(0),                        // 28: memos.api.v1.State
-	(*timestamppb.Timestamp)(nil),     // 29: google.protobuf.Timestamp
-	(*Node)(nil),                      // 30: memos.api.v1.Node
-	(*Resource)(nil),                  // 31: memos.api.v1.Resource
-	(*Reaction)(nil),                  // 32: memos.api.v1.Reaction
-	(Direction)(0),                    // 33: memos.api.v1.Direction
-	(*fieldmaskpb.FieldMask)(nil),     // 34: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),             // 35: google.protobuf.Empty
+	(*ToggleMemoTaskRequest)(nil),     // 19: memos.api.v1.ToggleMemoTaskRequest
+	(*PromoteMemoTaskRequest)(nil),    // 20: memos.api.v1.PromoteMemoTaskRequest
+	(*PromoteMemoTaskResponse)(nil),   // 21: memos.api.v1.PromoteMemoTaskResponse
+	(*ListBacklinksRequest)(nil),      // 22: memos.api.v1.ListBacklinksRequest
+	(*ListBacklinksResponse)(nil),     // 23: memos.api.v1.ListBacklinksResponse
+	(*CreateMemoCommentRequest)(nil),  // 24: memos.api.v1.CreateMemoCommentRequest
+	(*ListMemoCommentsRequest)(nil),   // 25: memos.api.v1.ListMemoCommentsRequest
+	(*ListMemoCommentsResponse)(nil),  // 26: memos.api.v1.ListMemoCommentsResponse
+	(*ListMemoReactionsRequest)(nil),  // 27: memos.api.v1.ListMemoReactionsRequest
+	(*ListMemoReactionsResponse)(nil), // 28: memos.api.v1.ListMemoReactionsResponse
+	(*UpsertMemoReactionRequest)(nil), // 29: memos.api.v1.UpsertMemoReactionRequest
+	(*DeleteMemoReactionRequest)(nil), // 30: memos.api.v1.DeleteMemoReactionRequest
+	(*Memo_Property)(nil),             // 31: memos.api.v1.Memo.Property
+	(*Memo_Property_Task)(nil),        // 32: memos.api.v1.Memo.Property.Task
+	(*MemoRelation_Memo)(nil),         // 33: memos.api.v1.MemoRelation.Memo
+	(State)(0),                        // 34: memos.api.v1.State
+	(*timestamppb.Timestamp)(nil),     // 35: google.protobuf.Timestamp
+	(*Node)(nil),                      // 36: memos.api.v1.Node
+	(*Resource)(nil),                  // 37: memos.api.v1.Resource
+	(*Reaction)(nil),                  // 38: memos.api.v1.Reaction
+	(Direction)(0),                    // 39: memos.api.v1.Direction
+	(*fieldmaskpb.FieldMask)(nil),     // 40: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),             // 41: google.protobuf.Empty
 }
 var file_api_v1_memo_service_proto_depIdxs = []int32{
-	28, // 0: memos.api.v1.Memo.state:type_name -> memos.api.v1.State
-	29, // 1: memos.api.v1.Memo.create_time:type_name -> google.protobuf.Timestamp
-	29, // 2: memos.api.v1.Memo.update_time:type_name -> google.protobuf.Timestamp
-	29, // 3: memos.api.v1.Memo.display_time:type_name -> google.protobuf.Timestamp
-	30, // 4: memos.api.v1.Memo.nodes:type_name -> memos.api.v1.Node
+	34, // 0: memos.api.v1.Memo.state:type_name -> memos.api.v1.State
+	35, // 1: memos.api.v1.Memo.create_time:type_name -> google.protobuf.Timestamp
+	35, // 2: memos.api.v1.Memo.update_time:type_name -> google.protobuf.Timestamp
+	35, // 3: memos.api.v1.Memo.display_time:type_name -> google.protobuf.Timestamp
+	36, // 4: memos.api.v1.Memo.nodes:type_name -> memos.api.v1.Node
 	0,  // 5: memos.api.v1.Memo.visibility:type_name -> memos.api.v1.Visibility
-	31, // 6: memos.api.v1.Memo.resources:type_name -> memos.api.v1.Resource
+	37, // 6: memos.api.v1.Memo.resources:type_name -> memos.api.v1.Resource
 	15, // 7: memos.api.v1.Memo.relations:type_name -> memos.api.v1.MemoRelation
-	32, // 8: memos.api.v1.Memo.reactions:type_name -> memos.api.v1.Reaction
-	26, // 9: memos.api.v1.Memo.property:type_name -> memos.api.v1.Memo.Property
+	38, // 8: memos.api.v1.Memo.reactions:type_name -> memos.api.v1.Reaction
+	31, // 9: memos.api.v1.Memo.property:type_name -> memos.api.v1.Memo.Property
 	3,  // 10: memos.api.v1.Memo.location:type_name -> memos.api.v1.Location
 	2,  // 11: memos.api.v1.CreateMemoRequest.memo:type_name -> memos.api.v1.Memo
-	28, // 12: memos.api.v1.ListMemosRequest.state:type_name -> memos.api.v1.State
-	33, // 13: memos.api.v1.ListMemosRequest.direction:type_name -> memos.api.v1.Direction
+	34, // 12: memos.api.v1.ListMemosRequest.state:type_name -> memos.api.v1.State
+	39, // 13: memos.api.v1.ListMemosRequest.direction:type_name -> memos.api.v1.Direction
 	2,  // 14: memos.api.v1.ListMemosResponse.memos:type_name -> memos.api.v1.Memo
 	2,  // 15: memos.api.v1.UpdateMemoRequest.memo:type_name -> memos.api.v1.Memo
-	34, // 16: memos.api.v1.UpdateMemoRequest.update_mask:type_name -> google.protobuf.FieldMask
-	31, // 17: memos.api.v1.SetMemoResourcesRequest.resources:type_name -> memos.api.v1.Resource
-	31, // 18: memos.api.v1.ListMemoResourcesResponse.resources:type_name -> memos.api.v1.Resource
-	27, // 19: memos.api.v1.MemoRelation.memo:type_name -> memos.api.v1.MemoRelation.Memo
-	27, // 20: memos.api.v1.MemoRelation.related_memo:type_name -> memos.api.v1.MemoRelation.Memo
+	40, // 16: memos.api.v1.UpdateMemoRequest.update_mask:type_name -> google.protobuf.FieldMask
+	37, // 17: memos.api.v1.SetMemoResourcesRequest.resources:type_name -> memos.api.v1.Resource
+	37, // 18: memos.api.v1.ListMemoResourcesResponse.resources:type_name -> memos.api.v1.Resource
+	33, // 19: memos.api.v1.MemoRelation.memo:type_name -> memos.api.v1.MemoRelation.Memo
+	33, // 20: memos.api.v1.MemoRelation.related_memo:type_name -> memos.api.v1.MemoRelation.Memo
 	1,  // 21: memos.api.v1.MemoRelation.type:type_name -> memos.api.v1.MemoRelation.Type
 	15, // 22: memos.api.v1.SetMemoRelationsRequest.relations:type_name -> memos.api.v1.MemoRelation
 	15, // 23: memos.api.v1.ListMemoRelationsResponse.relations:type_name -> memos.api.v1.MemoRelation
-	2,  // 24: memos.api.v1.CreateMemoCommentRequest.comment:type_name -> memos.api.v1.Memo
-	2,  // 25: memos.api.v1.ListMemoCommentsResponse.memos:type_name -> memos.api.v1.Memo
-	32, // 26: memos.api.v1.ListMemoReactionsResponse.reactions:type_name -> memos.api.v1.Reaction
-	32, // 27: memos.api.v1.UpsertMemoReactionRequest.reaction:type_name -> memos.api.v1.Reaction
-	4,  // 28: memos.api.v1.MemoService.CreateMemo:input_type -> memos.api.v1.CreateMemoRequest
-	5,  // 29: memos.api.v1.MemoService.ListMemos:input_type -> memos.api.v1.ListMemosRequest
-	7,  // 30: memos.api.v1.MemoService.GetMemo:input_type -> memos.api.v1.GetMemoRequest
-	8,  // 31: memos.api.v1.MemoService.UpdateMemo:input_type -> memos.api.v1.UpdateMemoRequest
-	9,  // 32: memos.api.v1.MemoService.DeleteMemo:input_type -> memos.api.v1.DeleteMemoRequest
-	10, // 33: memos.api.v1.MemoService.RenameMemoTag:input_type -> memos.api.v1.RenameMemoTagRequest
-	11, // 34: memos.api.v1.MemoService.DeleteMemoTag:input_type -> memos.api.v1.DeleteMemoTagRequest
-	12, // 35: memos.api.v1.MemoService.SetMemoResources:input_type -> memos.api.v1.SetMemoResourcesRequest
-	13, // 36: memos.api.v1.MemoService.ListMemoResources:input_type -> memos.api.v1.ListMemoResourcesRequest
-	16, // 37: memos.api.v1.MemoService.SetMemoRelations:input_type -> memos.api.v1.SetMemoRelationsRequest
-	17, // 38: memos.api.v1.MemoService.ListMemoRelations:input_type -> memos.api.v1.ListMemoRelationsRequest
-	19, // 39: memos.api.v1.MemoService.CreateMemoComment:input_type -> memos.api.v1.CreateMemoCommentRequest
-	20, // 40: memos.api.v1.MemoService.ListMemoComments:input_type -> memos.api.v1.ListMemoCommentsRequest
-	22, // 41: memos.api.v1.MemoService.ListMemoReactions:input_type -> memos.api.v1.ListMemoReactionsRequest
-	24, // 42: memos.api.v1.MemoService.UpsertMemoReaction:input_type -> memos.api.v1.UpsertMemoReactionRequest
-	25, // 43: memos.api.v1.MemoService.DeleteMemoReaction:input_type -> memos.api.v1.DeleteMemoReactionRequest
-	2,  // 44: memos.api.v1.MemoService.CreateMemo:output_type -> memos.api.v1.Memo
-	6,  // 45: memos.api.v1.MemoService.ListMemos:output_type -> memos.api.v1.ListMemosResponse
-	2,  // 46: memos.api.v1.MemoService.GetMemo:output_type -> memos.api.v1.Memo
-	2,  // 47: memos.api.v1.MemoService.UpdateMemo:output_type -> memos.api.v1.Memo
-	35, // 48: memos.api.v1.MemoService.DeleteMemo:output_type -> google.protobuf.Empty
-	35, // 49: memos.api.v1.MemoService.RenameMemoTag:output_type -> google.protobuf.Empty
-	35, // 50: memos.api.v1.MemoService.DeleteMemoTag:output_type -> google.protobuf.Empty
-	35, // 51: memos.api.v1.MemoService.SetMemoResources:output_type -> google.protobuf.Empty
-	14, // 52: memos.api.v1.MemoService.ListMemoResources:output_type -> memos.api.v1.ListMemoResourcesResponse
-	35, // 53: memos.api.v1.MemoService.SetMemoRelations:output_type -> google.protobuf.Empty
-	18, // 54: memos.api.v1.MemoService.ListMemoRelations:output_type -> memos.api.v1.ListMemoRelationsResponse
-	2,  // 55: memos.api.v1.MemoService.CreateMemoComment:output_type -> memos.api.v1.Memo
-	21, // 56: memos.api.v1.MemoService.ListMemoComments:output_type -> memos.api.v1.ListMemoCommentsResponse
-	23, // 57: memos.api.v1.MemoService.ListMemoReactions:output_type -> memos.api.v1.ListMemoReactionsResponse
-	32, // 58: memos.api.v1.MemoService.UpsertMemoReaction:output_type -> memos.api.v1.Reaction
-	35, // 59: memos.api.v1.MemoService.DeleteMemoReaction:output_type -> google.protobuf.Empty
-	44, // [44:60] is the sub-list for method output_type
-	28, // [28:44] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	2,  // 24: memos.api.v1.PromoteMemoTaskResponse.memo:type_name -> memos.api.v1.Memo
+	33, // 25: memos.api.v1.ListBacklinksResponse.memos:type_name -> memos.api.v1.MemoRelation.Memo
+	2,  // 26: memos.api.v1.CreateMemoCommentRequest.comment:type_name -> memos.api.v1.Memo
+	2,  // 27: memos.api.v1.ListMemoCommentsResponse.memos:type_name -> memos.api.v1.Memo
+	38, // 28: memos.api.v1.ListMemoReactionsResponse.reactions:type_name -> memos.api.v1.Reaction
+	38, // 29: memos.api.v1.UpsertMemoReactionRequest.reaction:type_name -> memos.api.v1.Reaction
+	32, // 30: memos.api.v1.Memo.Property.tasks:type_name -> memos.api.v1.Memo.Property.Task
+	4,  // 31: memos.api.v1.MemoService.CreateMemo:input_type -> memos.api.v1.CreateMemoRequest
+	5,  // 32: memos.api.v1.MemoService.ListMemos:input_type -> memos.api.v1.ListMemosRequest
+	7,  // 33: memos.api.v1.MemoService.GetMemo:input_type -> memos.api.v1.GetMemoRequest
+	8,  // 34: memos.api.v1.MemoService.UpdateMemo:input_type -> memos.api.v1.UpdateMemoRequest
+	9,  // 35: memos.api.v1.MemoService.DeleteMemo:input_type -> memos.api.v1.DeleteMemoRequest
+	10, // 36: memos.api.v1.MemoService.RenameMemoTag:input_type -> memos.api.v1.RenameMemoTagRequest
+	11, // 37: memos.api.v1.MemoService.DeleteMemoTag:input_type -> memos.api.v1.DeleteMemoTagRequest
+	12, // 38: memos.api.v1.MemoService.SetMemoResources:input_type -> memos.api.v1.SetMemoResourcesRequest
+	13, // 39: memos.api.v1.MemoService.ListMemoResources:input_type -> memos.api.v1.ListMemoResourcesRequest
+	16, // 40: memos.api.v1.MemoService.SetMemoRelations:input_type -> memos.api.v1.SetMemoRelationsRequest
+	17, // 41: memos.api.v1.MemoService.ListMemoRelations:input_type -> memos.api.v1.ListMemoRelationsRequest
+	19, // 42: memos.api.v1.MemoService.ToggleMemoTask:input_type -> memos.api.v1.ToggleMemoTaskRequest
+	20, // 43: memos.api.v1.MemoService.PromoteMemoTask:input_type -> memos.api.v1.PromoteMemoTaskRequest
+	22, // 44: memos.api.v1.MemoService.ListBacklinks:input_type -> memos.api.v1.ListBacklinksRequest
+	24, // 45: memos.api.v1.MemoService.CreateMemoComment:input_type -> memos.api.v1.CreateMemoCommentRequest
+	25, // 46: memos.api.v1.MemoService.ListMemoComments:input_type -> memos.api.v1.ListMemoCommentsRequest
+	27, // 47: memos.api.v1.MemoService.ListMemoReactions:input_type -> memos.api.v1.ListMemoReactionsRequest
+	29, // 48: memos.api.v1.MemoService.UpsertMemoReaction:input_type -> memos.api.v1.UpsertMemoReactionRequest
+	30, // 49: memos.api.v1.MemoService.DeleteMemoReaction:input_type -> memos.api.v1.DeleteMemoReactionRequest
+	2,  // 50: memos.api.v1.MemoService.CreateMemo:output_type -> memos.api.v1.Memo
+	6,  // 51: memos.api.v1.MemoService.ListMemos:output_type -> memos.api.v1.ListMemosResponse
+	2,  // 52: memos.api.v1.MemoService.GetMemo:output_type -> memos.api.v1.Memo
+	2,  // 53: memos.api.v1.MemoService.UpdateMemo:output_type -> memos.api.v1.Memo
+	41, // 54: memos.api.v1.MemoService.DeleteMemo:output_type -> google.protobuf.Empty
+	41, // 55: memos.api.v1.MemoService.RenameMemoTag:output_type -> google.protobuf.Empty
+	41, // 56: memos.api.v1.MemoService.DeleteMemoTag:output_type -> google.protobuf.Empty
+	41, // 57: memos.api.v1.MemoService.SetMemoResources:output_type -> google.protobuf.Empty
+	14, // 58: memos.api.v1.MemoService.ListMemoResources:output_type -> memos.api.v1.ListMemoResourcesResponse
+	41, // 59: memos.api.v1.MemoService.SetMemoRelations:output_type -> google.protobuf.Empty
+	18, // 60: memos.api.v1.MemoService.ListMemoRelations:output_type -> memos.api.v1.ListMemoRelationsResponse
+	2,  // 61: memos.api.v1.MemoService.ToggleMemoTask:output_type -> memos.api.v1.Memo
+	21, // 62: memos.api.v1.MemoService.PromoteMemoTask:output_type -> memos.api.v1.PromoteMemoTaskResponse
+	23, // 63: memos.api.v1.MemoService.ListBacklinks:output_type -> memos.api.v1.ListBacklinksResponse
+	2,  // 64: memos.api.v1.MemoService.CreateMemoComment:output_type -> memos.api.v1.Memo
+	26, // 65: memos.api.v1.MemoService.ListMemoComments:output_type -> memos.api.v1.ListMemoCommentsResponse
+	28, // 66: memos.api.v1.MemoService.ListMemoReactions:output_type -> memos.api.v1.ListMemoReactionsResponse
+	38, // 67: memos.api.v1.MemoService.UpsertMemoReaction:output_type -> memos.api.v1.Reaction
+	41, // 68: memos.api.v1.MemoService.DeleteMemoReaction:output_type -> google.protobuf.Empty
+	50, // [50:69] is the sub-list for method output_type
+	31, // [31:50] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_memo_service_proto_init() }
@@ -1941,7 +2362,7 @@ func file_api_v1_memo_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_memo_service_proto_rawDesc), len(file_api_v1_memo_service_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   26,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
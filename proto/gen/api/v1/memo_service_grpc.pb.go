@@ -31,6 +31,9 @@ const (
 	MemoService_ListMemoResources_FullMethodName  = "/memos.api.v1.MemoService/ListMemoResources"
 	MemoService_SetMemoRelations_FullMethodName   = "/memos.api.v1.MemoService/SetMemoRelations"
 	MemoService_ListMemoRelations_FullMethodName  = "/memos.api.v1.MemoService/ListMemoRelations"
+	MemoService_ToggleMemoTask_FullMethodName     = "/memos.api.v1.MemoService/ToggleMemoTask"
+	MemoService_PromoteMemoTask_FullMethodName    = "/memos.api.v1.MemoService/PromoteMemoTask"
+	MemoService_ListBacklinks_FullMethodName      = "/memos.api.v1.MemoService/ListBacklinks"
 	MemoService_CreateMemoComment_FullMethodName  = "/memos.api.v1.MemoService/CreateMemoComment"
 	MemoService_ListMemoComments_FullMethodName   = "/memos.api.v1.MemoService/ListMemoComments"
 	MemoService_ListMemoReactions_FullMethodName  = "/memos.api.v1.MemoService/ListMemoReactions"
@@ -64,6 +67,13 @@ type MemoServiceClient interface {
 	SetMemoRelations(ctx context.Context, in *SetMemoRelationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// ListMemoRelations lists relations for a memo.
 	ListMemoRelations(ctx context.Context, in *ListMemoRelationsRequest, opts ...grpc.CallOption) (*ListMemoRelationsResponse, error)
+	// ToggleMemoTask toggles a task in the task lists of a memo.
+	ToggleMemoTask(ctx context.Context, in *ToggleMemoTaskRequest, opts ...grpc.CallOption) (*Memo, error)
+	// PromoteMemoTask creates a ticket from a task in the task lists of a memo,
+	// and links the task to the page of the ticket, e.g. [API-42](/tickets/API-42).
+	PromoteMemoTask(ctx context.Context, in *PromoteMemoTaskRequest, opts ...grpc.CallOption) (*PromoteMemoTaskResponse, error)
+	// ListBacklinks lists the memos referencing a memo or a ticket in their content.
+	ListBacklinks(ctx context.Context, in *ListBacklinksRequest, opts ...grpc.CallOption) (*ListBacklinksResponse, error)
 	// CreateMemoComment creates a comment for a memo.
 	CreateMemoComment(ctx context.Context, in *CreateMemoCommentRequest, opts ...grpc.CallOption) (*Memo, error)
 	// ListMemoComments lists comments for a memo.
@@ -194,6 +204,36 @@ func (c *memoServiceClient) ListMemoRelations(ctx context.Context, in *ListMemoR
 	return out, nil
 }
 
+func (c *memoServiceClient) ToggleMemoTask(ctx context.Context, in *ToggleMemoTaskRequest, opts ...grpc.CallOption) (*Memo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Memo)
+	err := c.cc.Invoke(ctx, MemoService_ToggleMemoTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) PromoteMemoTask(ctx context.Context, in *PromoteMemoTaskRequest, opts ...grpc.CallOption) (*PromoteMemoTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoteMemoTaskResponse)
+	err := c.cc.Invoke(ctx, MemoService_PromoteMemoTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) ListBacklinks(ctx context.Context, in *ListBacklinksRequest, opts ...grpc.CallOption) (*ListBacklinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBacklinksResponse)
+	err := c.cc.Invoke(ctx, MemoService_ListBacklinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoServiceClient) CreateMemoComment(ctx context.Context, in *CreateMemoCommentRequest, opts ...grpc.CallOption) (*Memo, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Memo)
@@ -270,6 +310,13 @@ type MemoServiceServer interface {
 	SetMemoRelations(context.Context, *SetMemoRelationsRequest) (*emptypb.Empty, error)
 	// ListMemoRelations lists relations for a memo.
 	ListMemoRelations(context.Context, *ListMemoRelationsRequest) (*ListMemoRelationsResponse, error)
+	// ToggleMemoTask toggles a task in the task lists of a memo.
+	ToggleMemoTask(context.Context, *ToggleMemoTaskRequest) (*Memo, error)
+	// PromoteMemoTask creates a ticket from a task in the task lists of a memo,
+	// and links the task to the page of the ticket, e.g. [API-42](/tickets/API-42).
+	PromoteMemoTask(context.Context, *PromoteMemoTaskRequest) (*PromoteMemoTaskResponse, error)
+	// ListBacklinks lists the memos referencing a memo or a ticket in their content.
+	ListBacklinks(context.Context, *ListBacklinksRequest) (*ListBacklinksResponse, error)
 	// CreateMemoComment creates a comment for a memo.
 	CreateMemoComment(context.Context, *CreateMemoCommentRequest) (*Memo, error)
 	// ListMemoComments lists comments for a memo.
@@ -323,6 +370,15 @@ func (UnimplementedMemoServiceServer) SetMemoRelations(context.Context, *SetMemo
 func (UnimplementedMemoServiceServer) ListMemoRelations(context.Context, *ListMemoRelationsRequest) (*ListMemoRelationsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListMemoRelations not implemented")
 }
+func (UnimplementedMemoServiceServer) ToggleMemoTask(context.Context, *ToggleMemoTaskRequest) (*Memo, error) {
+	return nil, status.Error(codes.Unimplemented, "method ToggleMemoTask not implemented")
+}
+func (UnimplementedMemoServiceServer) PromoteMemoTask(context.Context, *PromoteMemoTaskRequest) (*PromoteMemoTaskResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PromoteMemoTask not implemented")
+}
+func (UnimplementedMemoServiceServer) ListBacklinks(context.Context, *ListBacklinksRequest) (*ListBacklinksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBacklinks not implemented")
+}
 func (UnimplementedMemoServiceServer) CreateMemoComment(context.Context, *CreateMemoCommentRequest) (*Memo, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateMemoComment not implemented")
 }
@@ -557,6 +613,60 @@ func _MemoService_ListMemoRelations_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_ToggleMemoTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ToggleMemoTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ToggleMemoTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ToggleMemoTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ToggleMemoTask(ctx, req.(*ToggleMemoTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_PromoteMemoTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteMemoTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).PromoteMemoTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_PromoteMemoTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).PromoteMemoTask(ctx, req.(*PromoteMemoTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_ListBacklinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBacklinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ListBacklinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ListBacklinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ListBacklinks(ctx, req.(*ListBacklinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoService_CreateMemoComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateMemoCommentRequest)
 	if err := dec(in); err != nil {
@@ -698,6 +808,18 @@ var MemoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListMemoRelations",
 			Handler:    _MemoService_ListMemoRelations_Handler,
 		},
+		{
+			MethodName: "ToggleMemoTask",
+			Handler:    _MemoService_ToggleMemoTask_Handler,
+		},
+		{
+			MethodName: "PromoteMemoTask",
+			Handler:    _MemoService_PromoteMemoTask_Handler,
+		},
+		{
+			MethodName: "ListBacklinks",
+			Handler:    _MemoService_ListBacklinks_Handler,
+		},
 		{
 			MethodName: "CreateMemoComment",
 			Handler:    _MemoService_CreateMemoComment_Handler,
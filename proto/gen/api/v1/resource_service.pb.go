@@ -38,7 +38,15 @@ type Resource struct {
 	Type         string                 `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
 	Size         int64                  `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"`
 	// The related memo. Refer to `Memo.name`.
-	Memo          *string `protobuf:"bytes,9,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	Memo *string `protobuf:"bytes,9,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	// The dimensions of an image resource.
+	Width  int32 `protobuf:"varint,10,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,11,opt,name=height,proto3" json:"height,omitempty"`
+	// The blurhash placeholder of an image resource. Reference: https://blurha.sh
+	Blurhash string `protobuf:"bytes,12,opt,name=blurhash,proto3" json:"blurhash,omitempty"`
+	// The names of the resized variants of an image resource, e.g. small and medium.
+	// A variant is served with the `variant` query parameter of the resource binary.
+	Variants      []string `protobuf:"bytes,13,rep,name=variants,proto3" json:"variants,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -129,6 +137,34 @@ func (x *Resource) GetMemo() string {
 	return ""
 }
 
+func (x *Resource) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Resource) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Resource) GetBlurhash() string {
+	if x != nil {
+		return x.Blurhash
+	}
+	return ""
+}
+
+func (x *Resource) GetVariants() []string {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
 type CreateResourceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Resource      *Resource              `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
@@ -304,8 +340,12 @@ type GetResourceBinaryRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// The filename of the resource. Mainly used for downloading.
 	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	// A flag indicating if the thumbnail version of the resource should be returned
-	Thumbnail     bool `protobuf:"varint,3,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	// A flag indicating if the thumbnail version of the resource should be returned.
+	// It is the small variant of an image.
+	Thumbnail bool `protobuf:"varint,3,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	// The name of the variant of the resource to return, e.g. small or medium.
+	// The resource itself is returned when the variant does not exist.
+	Variant       string `protobuf:"bytes,4,opt,name=variant,proto3" json:"variant,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -361,6 +401,13 @@ func (x *GetResourceBinaryRequest) GetThumbnail() bool {
 	return false
 }
 
+func (x *GetResourceBinaryRequest) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
 type UpdateResourceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Resource      *Resource              `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
@@ -462,7 +509,7 @@ var File_api_v1_resource_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_resource_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1dapi/v1/resource_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/httpbody.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x98\x02\n" +
+	"\x1dapi/v1/resource_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/httpbody.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x92\x03\n" +
 	"\bResource\x12\x1a\n" +
 	"\x04name\x18\x01 \x01(\tB\x06\xe0A\x03\xe0A\bR\x04name\x12@\n" +
 	"\vcreate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
@@ -472,7 +519,12 @@ const file_api_v1_resource_service_proto_rawDesc = "" +
 	"\rexternal_link\x18\x06 \x01(\tR\fexternalLink\x12\x12\n" +
 	"\x04type\x18\a \x01(\tR\x04type\x12\x12\n" +
 	"\x04size\x18\b \x01(\x03R\x04size\x12\x17\n" +
-	"\x04memo\x18\t \x01(\tH\x00R\x04memo\x88\x01\x01B\a\n" +
+	"\x04memo\x18\t \x01(\tH\x00R\x04memo\x88\x01\x01\x12\x19\n" +
+	"\x05width\x18\n" +
+	" \x01(\x05B\x03\xe0A\x03R\x05width\x12\x1b\n" +
+	"\x06height\x18\v \x01(\x05B\x03\xe0A\x03R\x06height\x12\x1f\n" +
+	"\bblurhash\x18\f \x01(\tB\x03\xe0A\x03R\bblurhash\x12\x1f\n" +
+	"\bvariants\x18\r \x03(\tB\x03\xe0A\x03R\bvariantsB\a\n" +
 	"\x05_memoJ\x04\b\x02\x10\x03\"K\n" +
 	"\x15CreateResourceRequest\x122\n" +
 	"\bresource\x18\x01 \x01(\v2\x16.memos.api.v1.ResourceR\bresource\"\x16\n" +
@@ -480,11 +532,12 @@ const file_api_v1_resource_service_proto_rawDesc = "" +
 	"\x15ListResourcesResponse\x124\n" +
 	"\tresources\x18\x01 \x03(\v2\x16.memos.api.v1.ResourceR\tresources\"(\n" +
 	"\x12GetResourceRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"h\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x82\x01\n" +
 	"\x18GetResourceBinaryRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
 	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1c\n" +
-	"\tthumbnail\x18\x03 \x01(\bR\tthumbnail\"\x88\x01\n" +
+	"\tthumbnail\x18\x03 \x01(\bR\tthumbnail\x12\x18\n" +
+	"\avariant\x18\x04 \x01(\tR\avariant\"\x88\x01\n" +
 	"\x15UpdateResourceRequest\x122\n" +
 	"\bresource\x18\x01 \x01(\v2\x16.memos.api.v1.ResourceR\bresource\x12;\n" +
 	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
@@ -0,0 +1,644 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: api/v1/ticket_view_service.proto
+
+package apiv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TicketView_Group int32
+
+const (
+	TicketView_GROUP_UNSPECIFIED TicketView_Group = 0
+	TicketView_STATUS            TicketView_Group = 1
+	TicketView_ASSIGNEE          TicketView_Group = 2
+	TicketView_PRIORITY          TicketView_Group = 3
+)
+
+// Enum value maps for TicketView_Group.
+var (
+	TicketView_Group_name = map[int32]string{
+		0: "GROUP_UNSPECIFIED",
+		1: "STATUS",
+		2: "ASSIGNEE",
+		3: "PRIORITY",
+	}
+	TicketView_Group_value = map[string]int32{
+		"GROUP_UNSPECIFIED": 0,
+		"STATUS":            1,
+		"ASSIGNEE":          2,
+		"PRIORITY":          3,
+	}
+)
+
+func (x TicketView_Group) Enum() *TicketView_Group {
+	p := new(TicketView_Group)
+	*p = x
+	return p
+}
+
+func (x TicketView_Group) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TicketView_Group) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_ticket_view_service_proto_enumTypes[0].Descriptor()
+}
+
+func (TicketView_Group) Type() protoreflect.EnumType {
+	return &file_api_v1_ticket_view_service_proto_enumTypes[0]
+}
+
+func (x TicketView_Group) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TicketView_Group.Descriptor instead.
+func (TicketView_Group) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_ticket_view_service_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type TicketView struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// The CEL filter of the tickets, e.g. `status == "OPEN" && "backend" in tags`.
+	// The attributes are title, status, priority, ticket_type, tags, creator_id, assignee_id,
+	// project_id, created_ts and updated_ts. All tickets are shown when empty.
+	Filter string           `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	Sort   *TicketView_Sort `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	// The field the tickets are grouped into columns by.
+	GroupBy TicketView_Group `protobuf:"varint,5,opt,name=group_by,json=groupBy,proto3,enum=memos.api.v1.TicketView_Group" json:"group_by,omitempty"`
+	// The keys of the columns in the order they are shown: statuses, priorities or assignee
+	// user names, with an empty key for the unassigned tickets.
+	ColumnOrder []string `protobuf:"bytes,6,rep,name=column_order,json=columnOrder,proto3" json:"column_order,omitempty"`
+	// The maximum number of tickets in a column by column key. Zero means no limit.
+	WipLimits map[string]int32 `protobuf:"bytes,7,rep,name=wip_limits,json=wipLimits,proto3" json:"wip_limits,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// The field the tickets are grouped into swimlanes by. There are no swimlanes when unspecified.
+	SwimlaneBy TicketView_Group `protobuf:"varint,8,opt,name=swimlane_by,json=swimlaneBy,proto3,enum=memos.api.v1.TicketView_Group" json:"swimlane_by,omitempty"`
+	// The name of the user who created the view.
+	Creator       string `protobuf:"bytes,9,opt,name=creator,proto3" json:"creator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketView) Reset() {
+	*x = TicketView{}
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketView) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketView) ProtoMessage() {}
+
+func (x *TicketView) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketView.ProtoReflect.Descriptor instead.
+func (*TicketView) Descriptor() ([]byte, []int) {
+	return file_api_v1_ticket_view_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TicketView) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TicketView) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TicketView) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *TicketView) GetSort() *TicketView_Sort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *TicketView) GetGroupBy() TicketView_Group {
+	if x != nil {
+		return x.GroupBy
+	}
+	return TicketView_GROUP_UNSPECIFIED
+}
+
+func (x *TicketView) GetColumnOrder() []string {
+	if x != nil {
+		return x.ColumnOrder
+	}
+	return nil
+}
+
+func (x *TicketView) GetWipLimits() map[string]int32 {
+	if x != nil {
+		return x.WipLimits
+	}
+	return nil
+}
+
+func (x *TicketView) GetSwimlaneBy() TicketView_Group {
+	if x != nil {
+		return x.SwimlaneBy
+	}
+	return TicketView_GROUP_UNSPECIFIED
+}
+
+func (x *TicketView) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+type ListTicketViewsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the user, or "workspace" for the shared views.
+	Parent        string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTicketViewsRequest) Reset() {
+	*x = ListTicketViewsRequest{}
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketViewsRequest) ProtoMessage() {}
+
+func (x *ListTicketViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketViewsRequest.ProtoReflect.Descriptor instead.
+func (*ListTicketViewsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ticket_view_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListTicketViewsRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+type ListTicketViewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Views         []*TicketView          `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTicketViewsResponse) Reset() {
+	*x = ListTicketViewsResponse{}
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketViewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketViewsResponse) ProtoMessage() {}
+
+func (x *ListTicketViewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketViewsResponse.ProtoReflect.Descriptor instead.
+func (*ListTicketViewsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ticket_view_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListTicketViewsResponse) GetViews() []*TicketView {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+type CreateTicketViewRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the user, or "workspace" to share the view.
+	Parent        string      `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	View          *TicketView `protobuf:"bytes,2,opt,name=view,proto3" json:"view,omitempty"`
+	ValidateOnly  bool        `protobuf:"varint,3,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTicketViewRequest) Reset() {
+	*x = CreateTicketViewRequest{}
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTicketViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTicketViewRequest) ProtoMessage() {}
+
+func (x *CreateTicketViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTicketViewRequest.ProtoReflect.Descriptor instead.
+func (*CreateTicketViewRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ticket_view_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateTicketViewRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *CreateTicketViewRequest) GetView() *TicketView {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+func (x *CreateTicketViewRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
+type UpdateTicketViewRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the user, or "workspace" for a shared view.
+	Parent        string                 `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	View          *TicketView            `protobuf:"bytes,2,opt,name=view,proto3" json:"view,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTicketViewRequest) Reset() {
+	*x = UpdateTicketViewRequest{}
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTicketViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTicketViewRequest) ProtoMessage() {}
+
+func (x *UpdateTicketViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTicketViewRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTicketViewRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ticket_view_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateTicketViewRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *UpdateTicketViewRequest) GetView() *TicketView {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+func (x *UpdateTicketViewRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteTicketViewRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the user, or "workspace" for a shared view.
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// The id of the view.
+	Id            string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTicketViewRequest) Reset() {
+	*x = DeleteTicketViewRequest{}
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTicketViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTicketViewRequest) ProtoMessage() {}
+
+func (x *DeleteTicketViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTicketViewRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTicketViewRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ticket_view_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteTicketViewRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *DeleteTicketViewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type TicketView_Sort struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The attribute the tickets are sorted by: title, status, priority, created_ts, updated_ts
+	// or fields.{key} for a custom field.
+	Field         string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Descending    bool   `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketView_Sort) Reset() {
+	*x = TicketView_Sort{}
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketView_Sort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketView_Sort) ProtoMessage() {}
+
+func (x *TicketView_Sort) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ticket_view_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketView_Sort.ProtoReflect.Descriptor instead.
+func (*TicketView_Sort) Descriptor() ([]byte, []int) {
+	return file_api_v1_ticket_view_service_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *TicketView_Sort) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *TicketView_Sort) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+var File_api_v1_ticket_view_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_ticket_view_service_proto_rawDesc = "" +
+	"\n" +
+	" api/v1/ticket_view_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\"\xc7\x04\n" +
+	"\n" +
+	"TicketView\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06filter\x18\x03 \x01(\tR\x06filter\x121\n" +
+	"\x04sort\x18\x04 \x01(\v2\x1d.memos.api.v1.TicketView.SortR\x04sort\x129\n" +
+	"\bgroup_by\x18\x05 \x01(\x0e2\x1e.memos.api.v1.TicketView.GroupR\agroupBy\x12!\n" +
+	"\fcolumn_order\x18\x06 \x03(\tR\vcolumnOrder\x12F\n" +
+	"\n" +
+	"wip_limits\x18\a \x03(\v2'.memos.api.v1.TicketView.WipLimitsEntryR\twipLimits\x12?\n" +
+	"\vswimlane_by\x18\b \x01(\x0e2\x1e.memos.api.v1.TicketView.GroupR\n" +
+	"swimlaneBy\x12\x1d\n" +
+	"\acreator\x18\t \x01(\tB\x03\xe0A\x03R\acreator\x1a<\n" +
+	"\x0eWipLimitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a<\n" +
+	"\x04Sort\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x02 \x01(\bR\n" +
+	"descending\"F\n" +
+	"\x05Group\x12\x15\n" +
+	"\x11GROUP_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06STATUS\x10\x01\x12\f\n" +
+	"\bASSIGNEE\x10\x02\x12\f\n" +
+	"\bPRIORITY\x10\x03\"0\n" +
+	"\x16ListTicketViewsRequest\x12\x16\n" +
+	"\x06parent\x18\x01 \x01(\tR\x06parent\"I\n" +
+	"\x17ListTicketViewsResponse\x12.\n" +
+	"\x05views\x18\x01 \x03(\v2\x18.memos.api.v1.TicketViewR\x05views\"\x84\x01\n" +
+	"\x17CreateTicketViewRequest\x12\x16\n" +
+	"\x06parent\x18\x01 \x01(\tR\x06parent\x12,\n" +
+	"\x04view\x18\x02 \x01(\v2\x18.memos.api.v1.TicketViewR\x04view\x12#\n" +
+	"\rvalidate_only\x18\x03 \x01(\bR\fvalidateOnly\"\x9c\x01\n" +
+	"\x17UpdateTicketViewRequest\x12\x16\n" +
+	"\x06parent\x18\x01 \x01(\tR\x06parent\x12,\n" +
+	"\x04view\x18\x02 \x01(\v2\x18.memos.api.v1.TicketViewR\x04view\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"A\n" +
+	"\x17DeleteTicketViewRequest\x12\x16\n" +
+	"\x06parent\x18\x01 \x01(\tR\x06parent\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id2\xc8\x06\n" +
+	"\x11TicketViewService\x12\xbf\x01\n" +
+	"\x0fListTicketViews\x12$.memos.api.v1.ListTicketViewsRequest\x1a%.memos.api.v1.ListTicketViewsResponse\"_\xdaA\x06parent\x82\xd3\xe4\x93\x02PZ(\x12&/api/v1/{parent=workspace}/ticketViews\x12$/api/v1/{parent=users/*}/ticketViews\x12\xc5\x01\n" +
+	"\x10CreateTicketView\x12%.memos.api.v1.CreateTicketViewRequest\x1a\x18.memos.api.v1.TicketView\"p\xdaA\vparent,view\x82\xd3\xe4\x93\x02\\:\x04viewZ.:\x04view\"&/api/v1/{parent=workspace}/ticketViews\"$/api/v1/{parent=users/*}/ticketViews\x12\xe6\x01\n" +
+	"\x10UpdateTicketView\x12%.memos.api.v1.UpdateTicketViewRequest\x1a\x18.memos.api.v1.TicketView\"\x90\x01\xdaA\x17parent,view,update_mask\x82\xd3\xe4\x93\x02p:\x04viewZ8:\x04view20/api/v1/{parent=workspace}/ticketViews/{view.id}2./api/v1/{parent=users/*}/ticketViews/{view.id}\x12\xbf\x01\n" +
+	"\x10DeleteTicketView\x12%.memos.api.v1.DeleteTicketViewRequest\x1a\x16.google.protobuf.Empty\"l\xdaA\tparent,id\x82\xd3\xe4\x93\x02ZZ-*+/api/v1/{parent=workspace}/ticketViews/{id}*)/api/v1/{parent=users/*}/ticketViews/{id}B\xae\x01\n" +
+	"\x10com.memos.api.v1B\x16TicketViewServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
+
+var (
+	file_api_v1_ticket_view_service_proto_rawDescOnce sync.Once
+	file_api_v1_ticket_view_service_proto_rawDescData []byte
+)
+
+func file_api_v1_ticket_view_service_proto_rawDescGZIP() []byte {
+	file_api_v1_ticket_view_service_proto_rawDescOnce.Do(func() {
+		file_api_v1_ticket_view_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_ticket_view_service_proto_rawDesc), len(file_api_v1_ticket_view_service_proto_rawDesc)))
+	})
+	return file_api_v1_ticket_view_service_proto_rawDescData
+}
+
+var file_api_v1_ticket_view_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_v1_ticket_view_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_v1_ticket_view_service_proto_goTypes = []any{
+	(TicketView_Group)(0),           // 0: memos.api.v1.TicketView.Group
+	(*TicketView)(nil),              // 1: memos.api.v1.TicketView
+	(*ListTicketViewsRequest)(nil),  // 2: memos.api.v1.ListTicketViewsRequest
+	(*ListTicketViewsResponse)(nil), // 3: memos.api.v1.ListTicketViewsResponse
+	(*CreateTicketViewRequest)(nil), // 4: memos.api.v1.CreateTicketViewRequest
+	(*UpdateTicketViewRequest)(nil), // 5: memos.api.v1.UpdateTicketViewRequest
+	(*DeleteTicketViewRequest)(nil), // 6: memos.api.v1.DeleteTicketViewRequest
+	nil,                             // 7: memos.api.v1.TicketView.WipLimitsEntry
+	(*TicketView_Sort)(nil),         // 8: memos.api.v1.TicketView.Sort
+	(*fieldmaskpb.FieldMask)(nil),   // 9: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),           // 10: google.protobuf.Empty
+}
+var file_api_v1_ticket_view_service_proto_depIdxs = []int32{
+	8,  // 0: memos.api.v1.TicketView.sort:type_name -> memos.api.v1.TicketView.Sort
+	0,  // 1: memos.api.v1.TicketView.group_by:type_name -> memos.api.v1.TicketView.Group
+	7,  // 2: memos.api.v1.TicketView.wip_limits:type_name -> memos.api.v1.TicketView.WipLimitsEntry
+	0,  // 3: memos.api.v1.TicketView.swimlane_by:type_name -> memos.api.v1.TicketView.Group
+	1,  // 4: memos.api.v1.ListTicketViewsResponse.views:type_name -> memos.api.v1.TicketView
+	1,  // 5: memos.api.v1.CreateTicketViewRequest.view:type_name -> memos.api.v1.TicketView
+	1,  // 6: memos.api.v1.UpdateTicketViewRequest.view:type_name -> memos.api.v1.TicketView
+	9,  // 7: memos.api.v1.UpdateTicketViewRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 8: memos.api.v1.TicketViewService.ListTicketViews:input_type -> memos.api.v1.ListTicketViewsRequest
+	4,  // 9: memos.api.v1.TicketViewService.CreateTicketView:input_type -> memos.api.v1.CreateTicketViewRequest
+	5,  // 10: memos.api.v1.TicketViewService.UpdateTicketView:input_type -> memos.api.v1.UpdateTicketViewRequest
+	6,  // 11: memos.api.v1.TicketViewService.DeleteTicketView:input_type -> memos.api.v1.DeleteTicketViewRequest
+	3,  // 12: memos.api.v1.TicketViewService.ListTicketViews:output_type -> memos.api.v1.ListTicketViewsResponse
+	1,  // 13: memos.api.v1.TicketViewService.CreateTicketView:output_type -> memos.api.v1.TicketView
+	1,  // 14: memos.api.v1.TicketViewService.UpdateTicketView:output_type -> memos.api.v1.TicketView
+	10, // 15: memos.api.v1.TicketViewService.DeleteTicketView:output_type -> google.protobuf.Empty
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_ticket_view_service_proto_init() }
+func file_api_v1_ticket_view_service_proto_init() {
+	if File_api_v1_ticket_view_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_ticket_view_service_proto_rawDesc), len(file_api_v1_ticket_view_service_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_ticket_view_service_proto_goTypes,
+		DependencyIndexes: file_api_v1_ticket_view_service_proto_depIdxs,
+		EnumInfos:         file_api_v1_ticket_view_service_proto_enumTypes,
+		MessageInfos:      file_api_v1_ticket_view_service_proto_msgTypes,
+	}.Build()
+	File_api_v1_ticket_view_service_proto = out.File
+	file_api_v1_ticket_view_service_proto_goTypes = nil
+	file_api_v1_ticket_view_service_proto_depIdxs = nil
+}
@@ -0,0 +1,244 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: api/v1/ticket_view_service.proto
+
+package apiv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TicketViewService_ListTicketViews_FullMethodName  = "/memos.api.v1.TicketViewService/ListTicketViews"
+	TicketViewService_CreateTicketView_FullMethodName = "/memos.api.v1.TicketViewService/CreateTicketView"
+	TicketViewService_UpdateTicketView_FullMethodName = "/memos.api.v1.TicketViewService/UpdateTicketView"
+	TicketViewService_DeleteTicketView_FullMethodName = "/memos.api.v1.TicketViewService/DeleteTicketView"
+)
+
+// TicketViewServiceClient is the client API for TicketViewService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TicketViewServiceClient interface {
+	// ListTicketViews returns the ticket views of a user, or the ones shared with the workspace.
+	ListTicketViews(ctx context.Context, in *ListTicketViewsRequest, opts ...grpc.CallOption) (*ListTicketViewsResponse, error)
+	// CreateTicketView creates a ticket view for a user, or shares it with the workspace.
+	CreateTicketView(ctx context.Context, in *CreateTicketViewRequest, opts ...grpc.CallOption) (*TicketView, error)
+	// UpdateTicketView updates a ticket view.
+	UpdateTicketView(ctx context.Context, in *UpdateTicketViewRequest, opts ...grpc.CallOption) (*TicketView, error)
+	// DeleteTicketView deletes a ticket view.
+	DeleteTicketView(ctx context.Context, in *DeleteTicketViewRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type ticketViewServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTicketViewServiceClient(cc grpc.ClientConnInterface) TicketViewServiceClient {
+	return &ticketViewServiceClient{cc}
+}
+
+func (c *ticketViewServiceClient) ListTicketViews(ctx context.Context, in *ListTicketViewsRequest, opts ...grpc.CallOption) (*ListTicketViewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTicketViewsResponse)
+	err := c.cc.Invoke(ctx, TicketViewService_ListTicketViews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketViewServiceClient) CreateTicketView(ctx context.Context, in *CreateTicketViewRequest, opts ...grpc.CallOption) (*TicketView, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TicketView)
+	err := c.cc.Invoke(ctx, TicketViewService_CreateTicketView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketViewServiceClient) UpdateTicketView(ctx context.Context, in *UpdateTicketViewRequest, opts ...grpc.CallOption) (*TicketView, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TicketView)
+	err := c.cc.Invoke(ctx, TicketViewService_UpdateTicketView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketViewServiceClient) DeleteTicketView(ctx context.Context, in *DeleteTicketViewRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TicketViewService_DeleteTicketView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TicketViewServiceServer is the server API for TicketViewService service.
+// All implementations must embed UnimplementedTicketViewServiceServer
+// for forward compatibility.
+type TicketViewServiceServer interface {
+	// ListTicketViews returns the ticket views of a user, or the ones shared with the workspace.
+	ListTicketViews(context.Context, *ListTicketViewsRequest) (*ListTicketViewsResponse, error)
+	// CreateTicketView creates a ticket view for a user, or shares it with the workspace.
+	CreateTicketView(context.Context, *CreateTicketViewRequest) (*TicketView, error)
+	// UpdateTicketView updates a ticket view.
+	UpdateTicketView(context.Context, *UpdateTicketViewRequest) (*TicketView, error)
+	// DeleteTicketView deletes a ticket view.
+	DeleteTicketView(context.Context, *DeleteTicketViewRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedTicketViewServiceServer()
+}
+
+// UnimplementedTicketViewServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTicketViewServiceServer struct{}
+
+func (UnimplementedTicketViewServiceServer) ListTicketViews(context.Context, *ListTicketViewsRequest) (*ListTicketViewsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTicketViews not implemented")
+}
+func (UnimplementedTicketViewServiceServer) CreateTicketView(context.Context, *CreateTicketViewRequest) (*TicketView, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTicketView not implemented")
+}
+func (UnimplementedTicketViewServiceServer) UpdateTicketView(context.Context, *UpdateTicketViewRequest) (*TicketView, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateTicketView not implemented")
+}
+func (UnimplementedTicketViewServiceServer) DeleteTicketView(context.Context, *DeleteTicketViewRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteTicketView not implemented")
+}
+func (UnimplementedTicketViewServiceServer) mustEmbedUnimplementedTicketViewServiceServer() {}
+func (UnimplementedTicketViewServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeTicketViewServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TicketViewServiceServer will
+// result in compilation errors.
+type UnsafeTicketViewServiceServer interface {
+	mustEmbedUnimplementedTicketViewServiceServer()
+}
+
+func RegisterTicketViewServiceServer(s grpc.ServiceRegistrar, srv TicketViewServiceServer) {
+	// If the following call panics, it indicates UnimplementedTicketViewServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TicketViewService_ServiceDesc, srv)
+}
+
+func _TicketViewService_ListTicketViews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTicketViewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketViewServiceServer).ListTicketViews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketViewService_ListTicketViews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketViewServiceServer).ListTicketViews(ctx, req.(*ListTicketViewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketViewService_CreateTicketView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTicketViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketViewServiceServer).CreateTicketView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketViewService_CreateTicketView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketViewServiceServer).CreateTicketView(ctx, req.(*CreateTicketViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketViewService_UpdateTicketView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTicketViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketViewServiceServer).UpdateTicketView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketViewService_UpdateTicketView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketViewServiceServer).UpdateTicketView(ctx, req.(*UpdateTicketViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketViewService_DeleteTicketView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTicketViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketViewServiceServer).DeleteTicketView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketViewService_DeleteTicketView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketViewServiceServer).DeleteTicketView(ctx, req.(*DeleteTicketViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TicketViewService_ServiceDesc is the grpc.ServiceDesc for TicketViewService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TicketViewService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "memos.api.v1.TicketViewService",
+	HandlerType: (*TicketViewServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTicketViews",
+			Handler:    _TicketViewService_ListTicketViews_Handler,
+		},
+		{
+			MethodName: "CreateTicketView",
+			Handler:    _TicketViewService_CreateTicketView_Handler,
+		},
+		{
+			MethodName: "UpdateTicketView",
+			Handler:    _TicketViewService_UpdateTicketView_Handler,
+		},
+		{
+			MethodName: "DeleteTicketView",
+			Handler:    _TicketViewService_DeleteTicketView_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/ticket_view_service.proto",
+}
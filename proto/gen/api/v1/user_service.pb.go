@@ -78,6 +78,65 @@ func (User_Role) EnumDescriptor() ([]byte, []int) {
 	return file_api_v1_user_service_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type UserAccessToken_Scope int32
+
+const (
+	UserAccessToken_SCOPE_UNSPECIFIED UserAccessToken_Scope = 0
+	// Read access to memos, resources, tickets and the other user resources.
+	UserAccessToken_READ UserAccessToken_Scope = 1
+	// Write access to memos, resources and their relations.
+	UserAccessToken_MEMOS_WRITE UserAccessToken_Scope = 2
+	// Write access to tickets.
+	UserAccessToken_TICKETS_WRITE UserAccessToken_Scope = 3
+	// Read and write access to webhooks.
+	UserAccessToken_WEBHOOKS UserAccessToken_Scope = 4
+)
+
+// Enum value maps for UserAccessToken_Scope.
+var (
+	UserAccessToken_Scope_name = map[int32]string{
+		0: "SCOPE_UNSPECIFIED",
+		1: "READ",
+		2: "MEMOS_WRITE",
+		3: "TICKETS_WRITE",
+		4: "WEBHOOKS",
+	}
+	UserAccessToken_Scope_value = map[string]int32{
+		"SCOPE_UNSPECIFIED": 0,
+		"READ":              1,
+		"MEMOS_WRITE":       2,
+		"TICKETS_WRITE":     3,
+		"WEBHOOKS":          4,
+	}
+)
+
+func (x UserAccessToken_Scope) Enum() *UserAccessToken_Scope {
+	p := new(UserAccessToken_Scope)
+	*p = x
+	return p
+}
+
+func (x UserAccessToken_Scope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserAccessToken_Scope) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_user_service_proto_enumTypes[1].Descriptor()
+}
+
+func (UserAccessToken_Scope) Type() protoreflect.EnumType {
+	return &file_api_v1_user_service_proto_enumTypes[1]
+}
+
+func (x UserAccessToken_Scope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserAccessToken_Scope.Descriptor instead.
+func (UserAccessToken_Scope) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{16, 0}
+}
+
 type User struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The name of the user.
@@ -955,11 +1014,18 @@ func (x *UpdateUserSettingRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
 }
 
 type UserAccessToken struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The access token, only returned when the token is created.
+	AccessToken string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	IssuedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	ExpiresAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// The identifier of the access token, used to delete it.
+	Id string `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+	// The scopes granted to the access token. Empty means full access.
+	Scopes        []UserAccessToken_Scope `protobuf:"varint,6,rep,packed,name=scopes,proto3,enum=memos.api.v1.UserAccessToken_Scope" json:"scopes,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp  `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	LastUsedIp    string                  `protobuf:"bytes,8,opt,name=last_used_ip,json=lastUsedIp,proto3" json:"last_used_ip,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1022,6 +1088,34 @@ func (x *UserAccessToken) GetExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *UserAccessToken) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserAccessToken) GetScopes() []UserAccessToken_Scope {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *UserAccessToken) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *UserAccessToken) GetLastUsedIp() string {
+	if x != nil {
+		return x.LastUsedIp
+	}
+	return ""
+}
+
 type ListUserAccessTokensRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The name of the user.
@@ -1114,9 +1208,11 @@ func (x *ListUserAccessTokensResponse) GetAccessTokens() []*UserAccessToken {
 type CreateUserAccessTokenRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The name of the user.
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	ExpiresAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	// The scopes granted to the access token. Empty means full access.
+	Scopes        []UserAccessToken_Scope `protobuf:"varint,4,rep,packed,name=scopes,proto3,enum=memos.api.v1.UserAccessToken_Scope" json:"scopes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1172,11 +1268,18 @@ func (x *CreateUserAccessTokenRequest) GetExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *CreateUserAccessTokenRequest) GetScopes() []UserAccessToken_Scope {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
 type DeleteUserAccessTokenRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The name of the user.
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// access_token is the access token to delete.
+	// access_token is the id of the access token to delete, or the access token itself.
 	AccessToken   string `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1375,22 +1478,35 @@ const file_api_v1_user_service_proto_rawDesc = "" +
 	"\x18UpdateUserSettingRequest\x128\n" +
 	"\asetting\x18\x01 \x01(\v2\x19.memos.api.v1.UserSettingB\x03\xe0A\x02R\asetting\x12;\n" +
 	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
-	"updateMask\"\xca\x01\n" +
+	"updateMask\"\xd3\x03\n" +
 	"\x0fUserAccessToken\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x127\n" +
 	"\tissued_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x129\n" +
 	"\n" +
-	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"1\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x0e\n" +
+	"\x02id\x18\x05 \x01(\tR\x02id\x12;\n" +
+	"\x06scopes\x18\x06 \x03(\x0e2#.memos.api.v1.UserAccessToken.ScopeR\x06scopes\x12<\n" +
+	"\flast_used_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x12 \n" +
+	"\flast_used_ip\x18\b \x01(\tR\n" +
+	"lastUsedIp\"Z\n" +
+	"\x05Scope\x12\x15\n" +
+	"\x11SCOPE_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04READ\x10\x01\x12\x0f\n" +
+	"\vMEMOS_WRITE\x10\x02\x12\x11\n" +
+	"\rTICKETS_WRITE\x10\x03\x12\f\n" +
+	"\bWEBHOOKS\x10\x04\"1\n" +
 	"\x1bListUserAccessTokensRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\"b\n" +
 	"\x1cListUserAccessTokensResponse\x12B\n" +
-	"\raccess_tokens\x18\x01 \x03(\v2\x1d.memos.api.v1.UserAccessTokenR\faccessTokens\"\xa3\x01\n" +
+	"\raccess_tokens\x18\x01 \x03(\v2\x1d.memos.api.v1.UserAccessTokenR\faccessTokens\"\xe0\x01\n" +
 	"\x1cCreateUserAccessTokenRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12>\n" +
 	"\n" +
-	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01B\r\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01\x12;\n" +
+	"\x06scopes\x18\x04 \x03(\x0e2#.memos.api.v1.UserAccessToken.ScopeR\x06scopesB\r\n" +
 	"\v_expires_at\"U\n" +
 	"\x1cDeleteUserAccessTokenRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
@@ -1427,92 +1543,96 @@ func file_api_v1_user_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_user_service_proto_rawDescData
 }
 
-var file_api_v1_user_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_v1_user_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
 var file_api_v1_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_api_v1_user_service_proto_goTypes = []any{
 	(User_Role)(0),                       // 0: memos.api.v1.User.Role
-	(*User)(nil),                         // 1: memos.api.v1.User
-	(*ListUsersRequest)(nil),             // 2: memos.api.v1.ListUsersRequest
-	(*ListUsersResponse)(nil),            // 3: memos.api.v1.ListUsersResponse
-	(*GetUserRequest)(nil),               // 4: memos.api.v1.GetUserRequest
-	(*GetUserByUsernameRequest)(nil),     // 5: memos.api.v1.GetUserByUsernameRequest
-	(*GetUserAvatarBinaryRequest)(nil),   // 6: memos.api.v1.GetUserAvatarBinaryRequest
-	(*CreateUserRequest)(nil),            // 7: memos.api.v1.CreateUserRequest
-	(*UpdateUserRequest)(nil),            // 8: memos.api.v1.UpdateUserRequest
-	(*DeleteUserRequest)(nil),            // 9: memos.api.v1.DeleteUserRequest
-	(*UserStats)(nil),                    // 10: memos.api.v1.UserStats
-	(*ListAllUserStatsRequest)(nil),      // 11: memos.api.v1.ListAllUserStatsRequest
-	(*ListAllUserStatsResponse)(nil),     // 12: memos.api.v1.ListAllUserStatsResponse
-	(*GetUserStatsRequest)(nil),          // 13: memos.api.v1.GetUserStatsRequest
-	(*UserSetting)(nil),                  // 14: memos.api.v1.UserSetting
-	(*GetUserSettingRequest)(nil),        // 15: memos.api.v1.GetUserSettingRequest
-	(*UpdateUserSettingRequest)(nil),     // 16: memos.api.v1.UpdateUserSettingRequest
-	(*UserAccessToken)(nil),              // 17: memos.api.v1.UserAccessToken
-	(*ListUserAccessTokensRequest)(nil),  // 18: memos.api.v1.ListUserAccessTokensRequest
-	(*ListUserAccessTokensResponse)(nil), // 19: memos.api.v1.ListUserAccessTokensResponse
-	(*CreateUserAccessTokenRequest)(nil), // 20: memos.api.v1.CreateUserAccessTokenRequest
-	(*DeleteUserAccessTokenRequest)(nil), // 21: memos.api.v1.DeleteUserAccessTokenRequest
-	nil,                                  // 22: memos.api.v1.UserStats.TagCountEntry
-	(*UserStats_MemoTypeStats)(nil),      // 23: memos.api.v1.UserStats.MemoTypeStats
-	(State)(0),                           // 24: memos.api.v1.State
-	(*timestamppb.Timestamp)(nil),        // 25: google.protobuf.Timestamp
-	(*httpbody.HttpBody)(nil),            // 26: google.api.HttpBody
-	(*fieldmaskpb.FieldMask)(nil),        // 27: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),                // 28: google.protobuf.Empty
+	(UserAccessToken_Scope)(0),           // 1: memos.api.v1.UserAccessToken.Scope
+	(*User)(nil),                         // 2: memos.api.v1.User
+	(*ListUsersRequest)(nil),             // 3: memos.api.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),            // 4: memos.api.v1.ListUsersResponse
+	(*GetUserRequest)(nil),               // 5: memos.api.v1.GetUserRequest
+	(*GetUserByUsernameRequest)(nil),     // 6: memos.api.v1.GetUserByUsernameRequest
+	(*GetUserAvatarBinaryRequest)(nil),   // 7: memos.api.v1.GetUserAvatarBinaryRequest
+	(*CreateUserRequest)(nil),            // 8: memos.api.v1.CreateUserRequest
+	(*UpdateUserRequest)(nil),            // 9: memos.api.v1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),            // 10: memos.api.v1.DeleteUserRequest
+	(*UserStats)(nil),                    // 11: memos.api.v1.UserStats
+	(*ListAllUserStatsRequest)(nil),      // 12: memos.api.v1.ListAllUserStatsRequest
+	(*ListAllUserStatsResponse)(nil),     // 13: memos.api.v1.ListAllUserStatsResponse
+	(*GetUserStatsRequest)(nil),          // 14: memos.api.v1.GetUserStatsRequest
+	(*UserSetting)(nil),                  // 15: memos.api.v1.UserSetting
+	(*GetUserSettingRequest)(nil),        // 16: memos.api.v1.GetUserSettingRequest
+	(*UpdateUserSettingRequest)(nil),     // 17: memos.api.v1.UpdateUserSettingRequest
+	(*UserAccessToken)(nil),              // 18: memos.api.v1.UserAccessToken
+	(*ListUserAccessTokensRequest)(nil),  // 19: memos.api.v1.ListUserAccessTokensRequest
+	(*ListUserAccessTokensResponse)(nil), // 20: memos.api.v1.ListUserAccessTokensResponse
+	(*CreateUserAccessTokenRequest)(nil), // 21: memos.api.v1.CreateUserAccessTokenRequest
+	(*DeleteUserAccessTokenRequest)(nil), // 22: memos.api.v1.DeleteUserAccessTokenRequest
+	nil,                                  // 23: memos.api.v1.UserStats.TagCountEntry
+	(*UserStats_MemoTypeStats)(nil),      // 24: memos.api.v1.UserStats.MemoTypeStats
+	(State)(0),                           // 25: memos.api.v1.State
+	(*timestamppb.Timestamp)(nil),        // 26: google.protobuf.Timestamp
+	(*httpbody.HttpBody)(nil),            // 27: google.api.HttpBody
+	(*fieldmaskpb.FieldMask)(nil),        // 28: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),                // 29: google.protobuf.Empty
 }
 var file_api_v1_user_service_proto_depIdxs = []int32{
 	0,  // 0: memos.api.v1.User.role:type_name -> memos.api.v1.User.Role
-	24, // 1: memos.api.v1.User.state:type_name -> memos.api.v1.State
-	25, // 2: memos.api.v1.User.create_time:type_name -> google.protobuf.Timestamp
-	25, // 3: memos.api.v1.User.update_time:type_name -> google.protobuf.Timestamp
-	1,  // 4: memos.api.v1.ListUsersResponse.users:type_name -> memos.api.v1.User
-	26, // 5: memos.api.v1.GetUserAvatarBinaryRequest.http_body:type_name -> google.api.HttpBody
-	1,  // 6: memos.api.v1.CreateUserRequest.user:type_name -> memos.api.v1.User
-	1,  // 7: memos.api.v1.UpdateUserRequest.user:type_name -> memos.api.v1.User
-	27, // 8: memos.api.v1.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
-	25, // 9: memos.api.v1.UserStats.memo_display_timestamps:type_name -> google.protobuf.Timestamp
-	23, // 10: memos.api.v1.UserStats.memo_type_stats:type_name -> memos.api.v1.UserStats.MemoTypeStats
-	22, // 11: memos.api.v1.UserStats.tag_count:type_name -> memos.api.v1.UserStats.TagCountEntry
-	10, // 12: memos.api.v1.ListAllUserStatsResponse.user_stats:type_name -> memos.api.v1.UserStats
-	14, // 13: memos.api.v1.UpdateUserSettingRequest.setting:type_name -> memos.api.v1.UserSetting
-	27, // 14: memos.api.v1.UpdateUserSettingRequest.update_mask:type_name -> google.protobuf.FieldMask
-	25, // 15: memos.api.v1.UserAccessToken.issued_at:type_name -> google.protobuf.Timestamp
-	25, // 16: memos.api.v1.UserAccessToken.expires_at:type_name -> google.protobuf.Timestamp
-	17, // 17: memos.api.v1.ListUserAccessTokensResponse.access_tokens:type_name -> memos.api.v1.UserAccessToken
-	25, // 18: memos.api.v1.CreateUserAccessTokenRequest.expires_at:type_name -> google.protobuf.Timestamp
-	2,  // 19: memos.api.v1.UserService.ListUsers:input_type -> memos.api.v1.ListUsersRequest
-	4,  // 20: memos.api.v1.UserService.GetUser:input_type -> memos.api.v1.GetUserRequest
-	5,  // 21: memos.api.v1.UserService.GetUserByUsername:input_type -> memos.api.v1.GetUserByUsernameRequest
-	6,  // 22: memos.api.v1.UserService.GetUserAvatarBinary:input_type -> memos.api.v1.GetUserAvatarBinaryRequest
-	7,  // 23: memos.api.v1.UserService.CreateUser:input_type -> memos.api.v1.CreateUserRequest
-	8,  // 24: memos.api.v1.UserService.UpdateUser:input_type -> memos.api.v1.UpdateUserRequest
-	9,  // 25: memos.api.v1.UserService.DeleteUser:input_type -> memos.api.v1.DeleteUserRequest
-	11, // 26: memos.api.v1.UserService.ListAllUserStats:input_type -> memos.api.v1.ListAllUserStatsRequest
-	13, // 27: memos.api.v1.UserService.GetUserStats:input_type -> memos.api.v1.GetUserStatsRequest
-	15, // 28: memos.api.v1.UserService.GetUserSetting:input_type -> memos.api.v1.GetUserSettingRequest
-	16, // 29: memos.api.v1.UserService.UpdateUserSetting:input_type -> memos.api.v1.UpdateUserSettingRequest
-	18, // 30: memos.api.v1.UserService.ListUserAccessTokens:input_type -> memos.api.v1.ListUserAccessTokensRequest
-	20, // 31: memos.api.v1.UserService.CreateUserAccessToken:input_type -> memos.api.v1.CreateUserAccessTokenRequest
-	21, // 32: memos.api.v1.UserService.DeleteUserAccessToken:input_type -> memos.api.v1.DeleteUserAccessTokenRequest
-	3,  // 33: memos.api.v1.UserService.ListUsers:output_type -> memos.api.v1.ListUsersResponse
-	1,  // 34: memos.api.v1.UserService.GetUser:output_type -> memos.api.v1.User
-	1,  // 35: memos.api.v1.UserService.GetUserByUsername:output_type -> memos.api.v1.User
-	26, // 36: memos.api.v1.UserService.GetUserAvatarBinary:output_type -> google.api.HttpBody
-	1,  // 37: memos.api.v1.UserService.CreateUser:output_type -> memos.api.v1.User
-	1,  // 38: memos.api.v1.UserService.UpdateUser:output_type -> memos.api.v1.User
-	28, // 39: memos.api.v1.UserService.DeleteUser:output_type -> google.protobuf.Empty
-	12, // 40: memos.api.v1.UserService.ListAllUserStats:output_type -> memos.api.v1.ListAllUserStatsResponse
-	10, // 41: memos.api.v1.UserService.GetUserStats:output_type -> memos.api.v1.UserStats
-	14, // 42: memos.api.v1.UserService.GetUserSetting:output_type -> memos.api.v1.UserSetting
-	14, // 43: memos.api.v1.UserService.UpdateUserSetting:output_type -> memos.api.v1.UserSetting
-	19, // 44: memos.api.v1.UserService.ListUserAccessTokens:output_type -> memos.api.v1.ListUserAccessTokensResponse
-	17, // 45: memos.api.v1.UserService.CreateUserAccessToken:output_type -> memos.api.v1.UserAccessToken
-	28, // 46: memos.api.v1.UserService.DeleteUserAccessToken:output_type -> google.protobuf.Empty
-	33, // [33:47] is the sub-list for method output_type
-	19, // [19:33] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	25, // 1: memos.api.v1.User.state:type_name -> memos.api.v1.State
+	26, // 2: memos.api.v1.User.create_time:type_name -> google.protobuf.Timestamp
+	26, // 3: memos.api.v1.User.update_time:type_name -> google.protobuf.Timestamp
+	2,  // 4: memos.api.v1.ListUsersResponse.users:type_name -> memos.api.v1.User
+	27, // 5: memos.api.v1.GetUserAvatarBinaryRequest.http_body:type_name -> google.api.HttpBody
+	2,  // 6: memos.api.v1.CreateUserRequest.user:type_name -> memos.api.v1.User
+	2,  // 7: memos.api.v1.UpdateUserRequest.user:type_name -> memos.api.v1.User
+	28, // 8: memos.api.v1.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	26, // 9: memos.api.v1.UserStats.memo_display_timestamps:type_name -> google.protobuf.Timestamp
+	24, // 10: memos.api.v1.UserStats.memo_type_stats:type_name -> memos.api.v1.UserStats.MemoTypeStats
+	23, // 11: memos.api.v1.UserStats.tag_count:type_name -> memos.api.v1.UserStats.TagCountEntry
+	11, // 12: memos.api.v1.ListAllUserStatsResponse.user_stats:type_name -> memos.api.v1.UserStats
+	15, // 13: memos.api.v1.UpdateUserSettingRequest.setting:type_name -> memos.api.v1.UserSetting
+	28, // 14: memos.api.v1.UpdateUserSettingRequest.update_mask:type_name -> google.protobuf.FieldMask
+	26, // 15: memos.api.v1.UserAccessToken.issued_at:type_name -> google.protobuf.Timestamp
+	26, // 16: memos.api.v1.UserAccessToken.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 17: memos.api.v1.UserAccessToken.scopes:type_name -> memos.api.v1.UserAccessToken.Scope
+	26, // 18: memos.api.v1.UserAccessToken.last_used_at:type_name -> google.protobuf.Timestamp
+	18, // 19: memos.api.v1.ListUserAccessTokensResponse.access_tokens:type_name -> memos.api.v1.UserAccessToken
+	26, // 20: memos.api.v1.CreateUserAccessTokenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 21: memos.api.v1.CreateUserAccessTokenRequest.scopes:type_name -> memos.api.v1.UserAccessToken.Scope
+	3,  // 22: memos.api.v1.UserService.ListUsers:input_type -> memos.api.v1.ListUsersRequest
+	5,  // 23: memos.api.v1.UserService.GetUser:input_type -> memos.api.v1.GetUserRequest
+	6,  // 24: memos.api.v1.UserService.GetUserByUsername:input_type -> memos.api.v1.GetUserByUsernameRequest
+	7,  // 25: memos.api.v1.UserService.GetUserAvatarBinary:input_type -> memos.api.v1.GetUserAvatarBinaryRequest
+	8,  // 26: memos.api.v1.UserService.CreateUser:input_type -> memos.api.v1.CreateUserRequest
+	9,  // 27: memos.api.v1.UserService.UpdateUser:input_type -> memos.api.v1.UpdateUserRequest
+	10, // 28: memos.api.v1.UserService.DeleteUser:input_type -> memos.api.v1.DeleteUserRequest
+	12, // 29: memos.api.v1.UserService.ListAllUserStats:input_type -> memos.api.v1.ListAllUserStatsRequest
+	14, // 30: memos.api.v1.UserService.GetUserStats:input_type -> memos.api.v1.GetUserStatsRequest
+	16, // 31: memos.api.v1.UserService.GetUserSetting:input_type -> memos.api.v1.GetUserSettingRequest
+	17, // 32: memos.api.v1.UserService.UpdateUserSetting:input_type -> memos.api.v1.UpdateUserSettingRequest
+	19, // 33: memos.api.v1.UserService.ListUserAccessTokens:input_type -> memos.api.v1.ListUserAccessTokensRequest
+	21, // 34: memos.api.v1.UserService.CreateUserAccessToken:input_type -> memos.api.v1.CreateUserAccessTokenRequest
+	22, // 35: memos.api.v1.UserService.DeleteUserAccessToken:input_type -> memos.api.v1.DeleteUserAccessTokenRequest
+	4,  // 36: memos.api.v1.UserService.ListUsers:output_type -> memos.api.v1.ListUsersResponse
+	2,  // 37: memos.api.v1.UserService.GetUser:output_type -> memos.api.v1.User
+	2,  // 38: memos.api.v1.UserService.GetUserByUsername:output_type -> memos.api.v1.User
+	27, // 39: memos.api.v1.UserService.GetUserAvatarBinary:output_type -> google.api.HttpBody
+	2,  // 40: memos.api.v1.UserService.CreateUser:output_type -> memos.api.v1.User
+	2,  // 41: memos.api.v1.UserService.UpdateUser:output_type -> memos.api.v1.User
+	29, // 42: memos.api.v1.UserService.DeleteUser:output_type -> google.protobuf.Empty
+	13, // 43: memos.api.v1.UserService.ListAllUserStats:output_type -> memos.api.v1.ListAllUserStatsResponse
+	11, // 44: memos.api.v1.UserService.GetUserStats:output_type -> memos.api.v1.UserStats
+	15, // 45: memos.api.v1.UserService.GetUserSetting:output_type -> memos.api.v1.UserSetting
+	15, // 46: memos.api.v1.UserService.UpdateUserSetting:output_type -> memos.api.v1.UserSetting
+	20, // 47: memos.api.v1.UserService.ListUserAccessTokens:output_type -> memos.api.v1.ListUserAccessTokensResponse
+	18, // 48: memos.api.v1.UserService.CreateUserAccessToken:output_type -> memos.api.v1.UserAccessToken
+	29, // 49: memos.api.v1.UserService.DeleteUserAccessToken:output_type -> google.protobuf.Empty
+	36, // [36:50] is the sub-list for method output_type
+	22, // [22:36] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_user_service_proto_init() }
@@ -1527,7 +1647,7 @@ func file_api_v1_user_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_user_service_proto_rawDesc), len(file_api_v1_user_service_proto_rawDesc)),
-			NumEnums:      1,
+			NumEnums:      2,
 			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
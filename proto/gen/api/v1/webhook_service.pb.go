@@ -12,6 +12,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -397,9 +398,13 @@ type WebhookRequestPayload struct {
 	ActivityType string                 `protobuf:"bytes,2,opt,name=activity_type,json=activityType,proto3" json:"activity_type,omitempty"`
 	// The name of the creator.
 	// Format: users/{user}
-	Creator       string                 `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
-	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
-	Memo          *Memo                  `protobuf:"bytes,5,opt,name=memo,proto3" json:"memo,omitempty"`
+	Creator    string                 `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	Memo       *Memo                  `protobuf:"bytes,5,opt,name=memo,proto3" json:"memo,omitempty"`
+	// The ticket of the memos.ticket.* activities, in the JSON representation of the tickets API.
+	Ticket *structpb.Struct `protobuf:"bytes,6,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	// The tickets of the memos.tickets.* batch activities, sent as a single event for the batch.
+	Tickets       []*structpb.Struct `protobuf:"bytes,7,rep,name=tickets,proto3" json:"tickets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -469,11 +474,25 @@ func (x *WebhookRequestPayload) GetMemo() *Memo {
 	return nil
 }
 
+func (x *WebhookRequestPayload) GetTicket() *structpb.Struct {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+func (x *WebhookRequestPayload) GetTickets() []*structpb.Struct {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
 var File_api_v1_webhook_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_webhook_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1capi/v1/webhook_service.proto\x12\fmemos.api.v1\x1a\x19api/v1/memo_service.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd3\x01\n" +
+	"\x1capi/v1/webhook_service.proto\x12\fmemos.api.v1\x1a\x19api/v1/memo_service.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd3\x01\n" +
 	"\aWebhook\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
 	"\acreator\x18\x02 \x01(\tR\acreator\x12;\n" +
@@ -497,14 +516,16 @@ const file_api_v1_webhook_service_proto_rawDesc = "" +
 	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
 	"updateMask\"&\n" +
 	"\x14DeleteWebhookRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"\xcd\x01\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\xb1\x02\n" +
 	"\x15WebhookRequestPayload\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\x12#\n" +
 	"\ractivity_type\x18\x02 \x01(\tR\factivityType\x12\x18\n" +
 	"\acreator\x18\x03 \x01(\tR\acreator\x12;\n" +
 	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"createTime\x12&\n" +
-	"\x04memo\x18\x05 \x01(\v2\x12.memos.api.v1.MemoR\x04memo2\xd8\x04\n" +
+	"\x04memo\x18\x05 \x01(\v2\x12.memos.api.v1.MemoR\x04memo\x12/\n" +
+	"\x06ticket\x18\x06 \x01(\v2\x17.google.protobuf.StructR\x06ticket\x121\n" +
+	"\atickets\x18\a \x03(\v2\x17.google.protobuf.StructR\atickets2\xd8\x04\n" +
 	"\x0eWebhookService\x12g\n" +
 	"\rCreateWebhook\x12\".memos.api.v1.CreateWebhookRequest\x1a\x15.memos.api.v1.Webhook\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/api/v1/webhooks\x12h\n" +
 	"\n" +
@@ -539,7 +560,8 @@ var file_api_v1_webhook_service_proto_goTypes = []any{
 	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
 	(*fieldmaskpb.FieldMask)(nil), // 9: google.protobuf.FieldMask
 	(*Memo)(nil),                  // 10: memos.api.v1.Memo
-	(*emptypb.Empty)(nil),         // 11: google.protobuf.Empty
+	(*structpb.Struct)(nil),       // 11: google.protobuf.Struct
+	(*emptypb.Empty)(nil),         // 12: google.protobuf.Empty
 }
 var file_api_v1_webhook_service_proto_depIdxs = []int32{
 	8,  // 0: memos.api.v1.Webhook.create_time:type_name -> google.protobuf.Timestamp
@@ -549,21 +571,23 @@ var file_api_v1_webhook_service_proto_depIdxs = []int32{
 	9,  // 4: memos.api.v1.UpdateWebhookRequest.update_mask:type_name -> google.protobuf.FieldMask
 	8,  // 5: memos.api.v1.WebhookRequestPayload.create_time:type_name -> google.protobuf.Timestamp
 	10, // 6: memos.api.v1.WebhookRequestPayload.memo:type_name -> memos.api.v1.Memo
-	1,  // 7: memos.api.v1.WebhookService.CreateWebhook:input_type -> memos.api.v1.CreateWebhookRequest
-	2,  // 8: memos.api.v1.WebhookService.GetWebhook:input_type -> memos.api.v1.GetWebhookRequest
-	3,  // 9: memos.api.v1.WebhookService.ListWebhooks:input_type -> memos.api.v1.ListWebhooksRequest
-	5,  // 10: memos.api.v1.WebhookService.UpdateWebhook:input_type -> memos.api.v1.UpdateWebhookRequest
-	6,  // 11: memos.api.v1.WebhookService.DeleteWebhook:input_type -> memos.api.v1.DeleteWebhookRequest
-	0,  // 12: memos.api.v1.WebhookService.CreateWebhook:output_type -> memos.api.v1.Webhook
-	0,  // 13: memos.api.v1.WebhookService.GetWebhook:output_type -> memos.api.v1.Webhook
-	4,  // 14: memos.api.v1.WebhookService.ListWebhooks:output_type -> memos.api.v1.ListWebhooksResponse
-	0,  // 15: memos.api.v1.WebhookService.UpdateWebhook:output_type -> memos.api.v1.Webhook
-	11, // 16: memos.api.v1.WebhookService.DeleteWebhook:output_type -> google.protobuf.Empty
-	12, // [12:17] is the sub-list for method output_type
-	7,  // [7:12] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	11, // 7: memos.api.v1.WebhookRequestPayload.ticket:type_name -> google.protobuf.Struct
+	11, // 8: memos.api.v1.WebhookRequestPayload.tickets:type_name -> google.protobuf.Struct
+	1,  // 9: memos.api.v1.WebhookService.CreateWebhook:input_type -> memos.api.v1.CreateWebhookRequest
+	2,  // 10: memos.api.v1.WebhookService.GetWebhook:input_type -> memos.api.v1.GetWebhookRequest
+	3,  // 11: memos.api.v1.WebhookService.ListWebhooks:input_type -> memos.api.v1.ListWebhooksRequest
+	5,  // 12: memos.api.v1.WebhookService.UpdateWebhook:input_type -> memos.api.v1.UpdateWebhookRequest
+	6,  // 13: memos.api.v1.WebhookService.DeleteWebhook:input_type -> memos.api.v1.DeleteWebhookRequest
+	0,  // 14: memos.api.v1.WebhookService.CreateWebhook:output_type -> memos.api.v1.Webhook
+	0,  // 15: memos.api.v1.WebhookService.GetWebhook:output_type -> memos.api.v1.Webhook
+	4,  // 16: memos.api.v1.WebhookService.ListWebhooks:output_type -> memos.api.v1.ListWebhooksResponse
+	0,  // 17: memos.api.v1.WebhookService.UpdateWebhook:output_type -> memos.api.v1.Webhook
+	12, // 18: memos.api.v1.WebhookService.DeleteWebhook:output_type -> google.protobuf.Empty
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_webhook_service_proto_init() }
@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +23,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type StorageMigration_State int32
+
+const (
+	StorageMigration_STATE_UNSPECIFIED StorageMigration_State = 0
+	StorageMigration_RUNNING           StorageMigration_State = 1
+	StorageMigration_COMPLETED         StorageMigration_State = 2
+)
+
+// Enum value maps for StorageMigration_State.
+var (
+	StorageMigration_State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "RUNNING",
+		2: "COMPLETED",
+	}
+	StorageMigration_State_value = map[string]int32{
+		"STATE_UNSPECIFIED": 0,
+		"RUNNING":           1,
+		"COMPLETED":         2,
+	}
+)
+
+func (x StorageMigration_State) Enum() *StorageMigration_State {
+	p := new(StorageMigration_State)
+	*p = x
+	return p
+}
+
+func (x StorageMigration_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StorageMigration_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_workspace_service_proto_enumTypes[0].Descriptor()
+}
+
+func (StorageMigration_State) Type() protoreflect.EnumType {
+	return &file_api_v1_workspace_service_proto_enumTypes[0]
+}
+
+func (x StorageMigration_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StorageMigration_State.Descriptor instead.
+func (StorageMigration_State) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{6, 0}
+}
+
 type WorkspaceProfile struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The name of instance owner.
@@ -131,19 +181,415 @@ func (*GetWorkspaceProfileRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{1}
 }
 
+type WorkspaceBackup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The file name of the snapshot in the backup directory.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The size of the snapshot in bytes.
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceBackup) Reset() {
+	*x = WorkspaceBackup{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceBackup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceBackup) ProtoMessage() {}
+
+func (x *WorkspaceBackup) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceBackup.ProtoReflect.Descriptor instead.
+func (*WorkspaceBackup) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WorkspaceBackup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkspaceBackup) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *WorkspaceBackup) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+type CreateWorkspaceBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceBackupRequest) Reset() {
+	*x = CreateWorkspaceBackupRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceBackupRequest) ProtoMessage() {}
+
+func (x *CreateWorkspaceBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceBackupRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceBackupRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{3}
+}
+
+type ListWorkspaceBackupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspaceBackupsRequest) Reset() {
+	*x = ListWorkspaceBackupsRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspaceBackupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspaceBackupsRequest) ProtoMessage() {}
+
+func (x *ListWorkspaceBackupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspaceBackupsRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkspaceBackupsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{4}
+}
+
+type ListWorkspaceBackupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backups       []*WorkspaceBackup     `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspaceBackupsResponse) Reset() {
+	*x = ListWorkspaceBackupsResponse{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspaceBackupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspaceBackupsResponse) ProtoMessage() {}
+
+func (x *ListWorkspaceBackupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspaceBackupsResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspaceBackupsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListWorkspaceBackupsResponse) GetBackups() []*WorkspaceBackup {
+	if x != nil {
+		return x.Backups
+	}
+	return nil
+}
+
+type StorageMigration struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	State StorageMigration_State `protobuf:"varint,1,opt,name=state,proto3,enum=memos.api.v1.StorageMigration_State" json:"state,omitempty"`
+	// The storage resources are migrated to.
+	TargetStorageType WorkspaceStorageSetting_StorageType `protobuf:"varint,2,opt,name=target_storage_type,json=targetStorageType,proto3,enum=memos.api.v1.WorkspaceStorageSetting_StorageType" json:"target_storage_type,omitempty"`
+	// The number of resources to migrate.
+	Total int32 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// The number of resources processed so far, migrated or failed.
+	Processed int32 `protobuf:"varint,4,opt,name=processed,proto3" json:"processed,omitempty"`
+	// The number of resources moved to the target storage.
+	Migrated int32 `protobuf:"varint,5,opt,name=migrated,proto3" json:"migrated,omitempty"`
+	// The number of resources that could not be moved.
+	Failed int32 `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	// The error of the last failed resource.
+	LastError     string                 `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	FinishTime    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=finish_time,json=finishTime,proto3" json:"finish_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageMigration) Reset() {
+	*x = StorageMigration{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageMigration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageMigration) ProtoMessage() {}
+
+func (x *StorageMigration) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageMigration.ProtoReflect.Descriptor instead.
+func (*StorageMigration) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StorageMigration) GetState() StorageMigration_State {
+	if x != nil {
+		return x.State
+	}
+	return StorageMigration_STATE_UNSPECIFIED
+}
+
+func (x *StorageMigration) GetTargetStorageType() WorkspaceStorageSetting_StorageType {
+	if x != nil {
+		return x.TargetStorageType
+	}
+	return WorkspaceStorageSetting_STORAGE_TYPE_UNSPECIFIED
+}
+
+func (x *StorageMigration) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *StorageMigration) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *StorageMigration) GetMigrated() int32 {
+	if x != nil {
+		return x.Migrated
+	}
+	return 0
+}
+
+func (x *StorageMigration) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *StorageMigration) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *StorageMigration) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *StorageMigration) GetFinishTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishTime
+	}
+	return nil
+}
+
+type StartStorageMigrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartStorageMigrationRequest) Reset() {
+	*x = StartStorageMigrationRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartStorageMigrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartStorageMigrationRequest) ProtoMessage() {}
+
+func (x *StartStorageMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartStorageMigrationRequest.ProtoReflect.Descriptor instead.
+func (*StartStorageMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{7}
+}
+
+type GetStorageMigrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStorageMigrationRequest) Reset() {
+	*x = GetStorageMigrationRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStorageMigrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStorageMigrationRequest) ProtoMessage() {}
+
+func (x *GetStorageMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStorageMigrationRequest.ProtoReflect.Descriptor instead.
+func (*GetStorageMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{8}
+}
+
 var File_api_v1_workspace_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_workspace_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1eapi/v1/workspace_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\"y\n" +
+	"\x1eapi/v1/workspace_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a&api/v1/workspace_setting_service.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"y\n" +
 	"\x10WorkspaceProfile\x12\x14\n" +
 	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12\x12\n" +
 	"\x04mode\x18\x03 \x01(\tR\x04mode\x12!\n" +
 	"\finstance_url\x18\x06 \x01(\tR\vinstanceUrl\"\x1c\n" +
-	"\x1aGetWorkspaceProfileRequest2\x97\x01\n" +
+	"\x1aGetWorkspaceProfileRequest\"v\n" +
+	"\x0fWorkspaceBackup\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12;\n" +
+	"\vcreate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"\x1e\n" +
+	"\x1cCreateWorkspaceBackupRequest\"\x1d\n" +
+	"\x1bListWorkspaceBackupsRequest\"W\n" +
+	"\x1cListWorkspaceBackupsResponse\x127\n" +
+	"\abackups\x18\x01 \x03(\v2\x1d.memos.api.v1.WorkspaceBackupR\abackups\"\xec\x03\n" +
+	"\x10StorageMigration\x12:\n" +
+	"\x05state\x18\x01 \x01(\x0e2$.memos.api.v1.StorageMigration.StateR\x05state\x12a\n" +
+	"\x13target_storage_type\x18\x02 \x01(\x0e21.memos.api.v1.WorkspaceStorageSetting.StorageTypeR\x11targetStorageType\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1c\n" +
+	"\tprocessed\x18\x04 \x01(\x05R\tprocessed\x12\x1a\n" +
+	"\bmigrated\x18\x05 \x01(\x05R\bmigrated\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x05R\x06failed\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\a \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"start_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12;\n" +
+	"\vfinish_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishTime\":\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aRUNNING\x10\x01\x12\r\n" +
+	"\tCOMPLETED\x10\x02\"\x1e\n" +
+	"\x1cStartStorageMigrationRequest\"\x1c\n" +
+	"\x1aGetStorageMigrationRequest2\xd8\x05\n" +
 	"\x10WorkspaceService\x12\x82\x01\n" +
-	"\x13GetWorkspaceProfile\x12(.memos.api.v1.GetWorkspaceProfileRequest\x1a\x1e.memos.api.v1.WorkspaceProfile\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/workspace/profileB\xad\x01\n" +
+	"\x13GetWorkspaceProfile\x12(.memos.api.v1.GetWorkspaceProfileRequest\x1a\x1e.memos.api.v1.WorkspaceProfile\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/workspace/profile\x12\x88\x01\n" +
+	"\x15CreateWorkspaceBackup\x12*.memos.api.v1.CreateWorkspaceBackupRequest\x1a\x1d.memos.api.v1.WorkspaceBackup\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/workspace/backups\x12\x90\x01\n" +
+	"\x14ListWorkspaceBackups\x12).memos.api.v1.ListWorkspaceBackupsRequest\x1a*.memos.api.v1.ListWorkspaceBackupsResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/workspace/backups\x12\x92\x01\n" +
+	"\x15StartStorageMigration\x12*.memos.api.v1.StartStorageMigrationRequest\x1a\x1e.memos.api.v1.StorageMigration\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/workspace/storageMigration\x12\x8b\x01\n" +
+	"\x13GetStorageMigration\x12(.memos.api.v1.GetStorageMigrationRequest\x1a\x1e.memos.api.v1.StorageMigration\"*\x82\xd3\xe4\x93\x02$\x12\"/api/v1/workspace/storageMigrationB\xad\x01\n" +
 	"\x10com.memos.api.v1B\x15WorkspaceServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
 
 var (
@@ -158,19 +604,44 @@ func file_api_v1_workspace_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_workspace_service_proto_rawDescData
 }
 
-var file_api_v1_workspace_service_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_v1_workspace_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_v1_workspace_service_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_api_v1_workspace_service_proto_goTypes = []any{
-	(*WorkspaceProfile)(nil),           // 0: memos.api.v1.WorkspaceProfile
-	(*GetWorkspaceProfileRequest)(nil), // 1: memos.api.v1.GetWorkspaceProfileRequest
+	(StorageMigration_State)(0),              // 0: memos.api.v1.StorageMigration.State
+	(*WorkspaceProfile)(nil),                 // 1: memos.api.v1.WorkspaceProfile
+	(*GetWorkspaceProfileRequest)(nil),       // 2: memos.api.v1.GetWorkspaceProfileRequest
+	(*WorkspaceBackup)(nil),                  // 3: memos.api.v1.WorkspaceBackup
+	(*CreateWorkspaceBackupRequest)(nil),     // 4: memos.api.v1.CreateWorkspaceBackupRequest
+	(*ListWorkspaceBackupsRequest)(nil),      // 5: memos.api.v1.ListWorkspaceBackupsRequest
+	(*ListWorkspaceBackupsResponse)(nil),     // 6: memos.api.v1.ListWorkspaceBackupsResponse
+	(*StorageMigration)(nil),                 // 7: memos.api.v1.StorageMigration
+	(*StartStorageMigrationRequest)(nil),     // 8: memos.api.v1.StartStorageMigrationRequest
+	(*GetStorageMigrationRequest)(nil),       // 9: memos.api.v1.GetStorageMigrationRequest
+	(*timestamppb.Timestamp)(nil),            // 10: google.protobuf.Timestamp
+	(WorkspaceStorageSetting_StorageType)(0), // 11: memos.api.v1.WorkspaceStorageSetting.StorageType
 }
 var file_api_v1_workspace_service_proto_depIdxs = []int32{
-	1, // 0: memos.api.v1.WorkspaceService.GetWorkspaceProfile:input_type -> memos.api.v1.GetWorkspaceProfileRequest
-	0, // 1: memos.api.v1.WorkspaceService.GetWorkspaceProfile:output_type -> memos.api.v1.WorkspaceProfile
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	10, // 0: memos.api.v1.WorkspaceBackup.create_time:type_name -> google.protobuf.Timestamp
+	3,  // 1: memos.api.v1.ListWorkspaceBackupsResponse.backups:type_name -> memos.api.v1.WorkspaceBackup
+	0,  // 2: memos.api.v1.StorageMigration.state:type_name -> memos.api.v1.StorageMigration.State
+	11, // 3: memos.api.v1.StorageMigration.target_storage_type:type_name -> memos.api.v1.WorkspaceStorageSetting.StorageType
+	10, // 4: memos.api.v1.StorageMigration.start_time:type_name -> google.protobuf.Timestamp
+	10, // 5: memos.api.v1.StorageMigration.finish_time:type_name -> google.protobuf.Timestamp
+	2,  // 6: memos.api.v1.WorkspaceService.GetWorkspaceProfile:input_type -> memos.api.v1.GetWorkspaceProfileRequest
+	4,  // 7: memos.api.v1.WorkspaceService.CreateWorkspaceBackup:input_type -> memos.api.v1.CreateWorkspaceBackupRequest
+	5,  // 8: memos.api.v1.WorkspaceService.ListWorkspaceBackups:input_type -> memos.api.v1.ListWorkspaceBackupsRequest
+	8,  // 9: memos.api.v1.WorkspaceService.StartStorageMigration:input_type -> memos.api.v1.StartStorageMigrationRequest
+	9,  // 10: memos.api.v1.WorkspaceService.GetStorageMigration:input_type -> memos.api.v1.GetStorageMigrationRequest
+	1,  // 11: memos.api.v1.WorkspaceService.GetWorkspaceProfile:output_type -> memos.api.v1.WorkspaceProfile
+	3,  // 12: memos.api.v1.WorkspaceService.CreateWorkspaceBackup:output_type -> memos.api.v1.WorkspaceBackup
+	6,  // 13: memos.api.v1.WorkspaceService.ListWorkspaceBackups:output_type -> memos.api.v1.ListWorkspaceBackupsResponse
+	7,  // 14: memos.api.v1.WorkspaceService.StartStorageMigration:output_type -> memos.api.v1.StorageMigration
+	7,  // 15: memos.api.v1.WorkspaceService.GetStorageMigration:output_type -> memos.api.v1.StorageMigration
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_workspace_service_proto_init() }
@@ -178,18 +649,20 @@ func file_api_v1_workspace_service_proto_init() {
 	if File_api_v1_workspace_service_proto != nil {
 		return
 	}
+	file_api_v1_workspace_setting_service_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_workspace_service_proto_rawDesc), len(file_api_v1_workspace_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_v1_workspace_service_proto_goTypes,
 		DependencyIndexes: file_api_v1_workspace_service_proto_depIdxs,
+		EnumInfos:         file_api_v1_workspace_service_proto_enumTypes,
 		MessageInfos:      file_api_v1_workspace_service_proto_msgTypes,
 	}.Build()
 	File_api_v1_workspace_service_proto = out.File
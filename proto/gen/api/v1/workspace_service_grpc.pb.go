@@ -19,7 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	WorkspaceService_GetWorkspaceProfile_FullMethodName = "/memos.api.v1.WorkspaceService/GetWorkspaceProfile"
+	WorkspaceService_GetWorkspaceProfile_FullMethodName   = "/memos.api.v1.WorkspaceService/GetWorkspaceProfile"
+	WorkspaceService_CreateWorkspaceBackup_FullMethodName = "/memos.api.v1.WorkspaceService/CreateWorkspaceBackup"
+	WorkspaceService_ListWorkspaceBackups_FullMethodName  = "/memos.api.v1.WorkspaceService/ListWorkspaceBackups"
+	WorkspaceService_StartStorageMigration_FullMethodName = "/memos.api.v1.WorkspaceService/StartStorageMigration"
+	WorkspaceService_GetStorageMigration_FullMethodName   = "/memos.api.v1.WorkspaceService/GetStorageMigration"
 )
 
 // WorkspaceServiceClient is the client API for WorkspaceService service.
@@ -28,6 +32,15 @@ const (
 type WorkspaceServiceClient interface {
 	// GetWorkspaceProfile returns the workspace profile.
 	GetWorkspaceProfile(ctx context.Context, in *GetWorkspaceProfileRequest, opts ...grpc.CallOption) (*WorkspaceProfile, error)
+	// CreateWorkspaceBackup takes a snapshot of the sqlite database.
+	CreateWorkspaceBackup(ctx context.Context, in *CreateWorkspaceBackupRequest, opts ...grpc.CallOption) (*WorkspaceBackup, error)
+	// ListWorkspaceBackups returns the snapshots in the backup directory, newest first.
+	ListWorkspaceBackups(ctx context.Context, in *ListWorkspaceBackupsRequest, opts ...grpc.CallOption) (*ListWorkspaceBackupsResponse, error)
+	// StartStorageMigration moves the existing resources to the storage of the workspace storage setting.
+	// Starting it again resumes a migration interrupted by a restart or retries the failed resources.
+	StartStorageMigration(ctx context.Context, in *StartStorageMigrationRequest, opts ...grpc.CallOption) (*StorageMigration, error)
+	// GetStorageMigration returns the progress of the last storage migration.
+	GetStorageMigration(ctx context.Context, in *GetStorageMigrationRequest, opts ...grpc.CallOption) (*StorageMigration, error)
 }
 
 type workspaceServiceClient struct {
@@ -48,12 +61,61 @@ func (c *workspaceServiceClient) GetWorkspaceProfile(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *workspaceServiceClient) CreateWorkspaceBackup(ctx context.Context, in *CreateWorkspaceBackupRequest, opts ...grpc.CallOption) (*WorkspaceBackup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkspaceBackup)
+	err := c.cc.Invoke(ctx, WorkspaceService_CreateWorkspaceBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) ListWorkspaceBackups(ctx context.Context, in *ListWorkspaceBackupsRequest, opts ...grpc.CallOption) (*ListWorkspaceBackupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkspaceBackupsResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_ListWorkspaceBackups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) StartStorageMigration(ctx context.Context, in *StartStorageMigrationRequest, opts ...grpc.CallOption) (*StorageMigration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StorageMigration)
+	err := c.cc.Invoke(ctx, WorkspaceService_StartStorageMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) GetStorageMigration(ctx context.Context, in *GetStorageMigrationRequest, opts ...grpc.CallOption) (*StorageMigration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StorageMigration)
+	err := c.cc.Invoke(ctx, WorkspaceService_GetStorageMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkspaceServiceServer is the server API for WorkspaceService service.
 // All implementations must embed UnimplementedWorkspaceServiceServer
 // for forward compatibility.
 type WorkspaceServiceServer interface {
 	// GetWorkspaceProfile returns the workspace profile.
 	GetWorkspaceProfile(context.Context, *GetWorkspaceProfileRequest) (*WorkspaceProfile, error)
+	// CreateWorkspaceBackup takes a snapshot of the sqlite database.
+	CreateWorkspaceBackup(context.Context, *CreateWorkspaceBackupRequest) (*WorkspaceBackup, error)
+	// ListWorkspaceBackups returns the snapshots in the backup directory, newest first.
+	ListWorkspaceBackups(context.Context, *ListWorkspaceBackupsRequest) (*ListWorkspaceBackupsResponse, error)
+	// StartStorageMigration moves the existing resources to the storage of the workspace storage setting.
+	// Starting it again resumes a migration interrupted by a restart or retries the failed resources.
+	StartStorageMigration(context.Context, *StartStorageMigrationRequest) (*StorageMigration, error)
+	// GetStorageMigration returns the progress of the last storage migration.
+	GetStorageMigration(context.Context, *GetStorageMigrationRequest) (*StorageMigration, error)
 	mustEmbedUnimplementedWorkspaceServiceServer()
 }
 
@@ -67,6 +129,18 @@ type UnimplementedWorkspaceServiceServer struct{}
 func (UnimplementedWorkspaceServiceServer) GetWorkspaceProfile(context.Context, *GetWorkspaceProfileRequest) (*WorkspaceProfile, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetWorkspaceProfile not implemented")
 }
+func (UnimplementedWorkspaceServiceServer) CreateWorkspaceBackup(context.Context, *CreateWorkspaceBackupRequest) (*WorkspaceBackup, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateWorkspaceBackup not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) ListWorkspaceBackups(context.Context, *ListWorkspaceBackupsRequest) (*ListWorkspaceBackupsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWorkspaceBackups not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) StartStorageMigration(context.Context, *StartStorageMigrationRequest) (*StorageMigration, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartStorageMigration not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) GetStorageMigration(context.Context, *GetStorageMigrationRequest) (*StorageMigration, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStorageMigration not implemented")
+}
 func (UnimplementedWorkspaceServiceServer) mustEmbedUnimplementedWorkspaceServiceServer() {}
 func (UnimplementedWorkspaceServiceServer) testEmbeddedByValue()                          {}
 
@@ -106,6 +180,78 @@ func _WorkspaceService_GetWorkspaceProfile_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkspaceService_CreateWorkspaceBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkspaceBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).CreateWorkspaceBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_CreateWorkspaceBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).CreateWorkspaceBackup(ctx, req.(*CreateWorkspaceBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_ListWorkspaceBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkspaceBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).ListWorkspaceBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_ListWorkspaceBackups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).ListWorkspaceBackups(ctx, req.(*ListWorkspaceBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_StartStorageMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartStorageMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).StartStorageMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_StartStorageMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).StartStorageMigration(ctx, req.(*StartStorageMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_GetStorageMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStorageMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).GetStorageMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_GetStorageMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).GetStorageMigration(ctx, req.(*GetStorageMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkspaceService_ServiceDesc is the grpc.ServiceDesc for WorkspaceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -117,6 +263,22 @@ var WorkspaceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetWorkspaceProfile",
 			Handler:    _WorkspaceService_GetWorkspaceProfile_Handler,
 		},
+		{
+			MethodName: "CreateWorkspaceBackup",
+			Handler:    _WorkspaceService_CreateWorkspaceBackup_Handler,
+		},
+		{
+			MethodName: "ListWorkspaceBackups",
+			Handler:    _WorkspaceService_ListWorkspaceBackups_Handler,
+		},
+		{
+			MethodName: "StartStorageMigration",
+			Handler:    _WorkspaceService_StartStorageMigration_Handler,
+		},
+		{
+			MethodName: "GetStorageMigration",
+			Handler:    _WorkspaceService_GetStorageMigration_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/workspace_service.proto",
@@ -32,6 +32,8 @@ const (
 	WorkspaceStorageSetting_LOCAL WorkspaceStorageSetting_StorageType = 2
 	// S3 is the S3 storage type.
 	WorkspaceStorageSetting_S3 WorkspaceStorageSetting_StorageType = 3
+	// WEBDAV is the WebDAV storage type.
+	WorkspaceStorageSetting_WEBDAV WorkspaceStorageSetting_StorageType = 4
 )
 
 // Enum value maps for WorkspaceStorageSetting_StorageType.
@@ -41,12 +43,14 @@ var (
 		1: "DATABASE",
 		2: "LOCAL",
 		3: "S3",
+		4: "WEBDAV",
 	}
 	WorkspaceStorageSetting_StorageType_value = map[string]int32{
 		"STORAGE_TYPE_UNSPECIFIED": 0,
 		"DATABASE":                 1,
 		"LOCAL":                    2,
 		"S3":                       3,
+		"WEBDAV":                   4,
 	}
 )
 
@@ -87,6 +91,7 @@ type WorkspaceSetting struct {
 	//	*WorkspaceSetting_GeneralSetting
 	//	*WorkspaceSetting_StorageSetting
 	//	*WorkspaceSetting_MemoRelatedSetting
+	//	*WorkspaceSetting_RateLimitSetting
 	Value         isWorkspaceSetting_Value `protobuf_oneof:"value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -163,6 +168,15 @@ func (x *WorkspaceSetting) GetMemoRelatedSetting() *WorkspaceMemoRelatedSetting
 	return nil
 }
 
+func (x *WorkspaceSetting) GetRateLimitSetting() *WorkspaceRateLimitSetting {
+	if x != nil {
+		if x, ok := x.Value.(*WorkspaceSetting_RateLimitSetting); ok {
+			return x.RateLimitSetting
+		}
+	}
+	return nil
+}
+
 type isWorkspaceSetting_Value interface {
 	isWorkspaceSetting_Value()
 }
@@ -179,12 +193,18 @@ type WorkspaceSetting_MemoRelatedSetting struct {
 	MemoRelatedSetting *WorkspaceMemoRelatedSetting `protobuf:"bytes,4,opt,name=memo_related_setting,json=memoRelatedSetting,proto3,oneof"`
 }
 
+type WorkspaceSetting_RateLimitSetting struct {
+	RateLimitSetting *WorkspaceRateLimitSetting `protobuf:"bytes,5,opt,name=rate_limit_setting,json=rateLimitSetting,proto3,oneof"`
+}
+
 func (*WorkspaceSetting_GeneralSetting) isWorkspaceSetting_Value() {}
 
 func (*WorkspaceSetting_StorageSetting) isWorkspaceSetting_Value() {}
 
 func (*WorkspaceSetting_MemoRelatedSetting) isWorkspaceSetting_Value() {}
 
+func (*WorkspaceSetting_RateLimitSetting) isWorkspaceSetting_Value() {}
+
 type WorkspaceGeneralSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// disallow_user_registration disallows user registration.
@@ -205,8 +225,10 @@ type WorkspaceGeneralSetting struct {
 	DisallowChangeUsername bool `protobuf:"varint,7,opt,name=disallow_change_username,json=disallowChangeUsername,proto3" json:"disallow_change_username,omitempty"`
 	// disallow_change_nickname disallows changing nickname.
 	DisallowChangeNickname bool `protobuf:"varint,8,opt,name=disallow_change_nickname,json=disallowChangeNickname,proto3" json:"disallow_change_nickname,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	// require_two_factor_for_admins requires hosts and admins to enable two-factor authentication.
+	RequireTwoFactorForAdmins bool `protobuf:"varint,9,opt,name=require_two_factor_for_admins,json=requireTwoFactorForAdmins,proto3" json:"require_two_factor_for_admins,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
 }
 
 func (x *WorkspaceGeneralSetting) Reset() {
@@ -295,6 +317,13 @@ func (x *WorkspaceGeneralSetting) GetDisallowChangeNickname() bool {
 	return false
 }
 
+func (x *WorkspaceGeneralSetting) GetRequireTwoFactorForAdmins() bool {
+	if x != nil {
+		return x.RequireTwoFactorForAdmins
+	}
+	return false
+}
+
 type WorkspaceCustomProfile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
@@ -381,9 +410,16 @@ type WorkspaceStorageSetting struct {
 	// The max upload size in megabytes.
 	UploadSizeLimitMb int64 `protobuf:"varint,3,opt,name=upload_size_limit_mb,json=uploadSizeLimitMb,proto3" json:"upload_size_limit_mb,omitempty"`
 	// The S3 config.
-	S3Config      *WorkspaceStorageSetting_S3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	S3Config *WorkspaceStorageSetting_S3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
+	// content_addressed stores new resources by the SHA-256 hash of their content,
+	// so identical files are stored once.
+	ContentAddressed bool `protobuf:"varint,5,opt,name=content_addressed,json=contentAddressed,proto3" json:"content_addressed,omitempty"`
+	// The WebDAV config.
+	WebdavConfig *WorkspaceStorageSetting_WebDAVConfig `protobuf:"bytes,6,opt,name=webdav_config,json=webdavConfig,proto3" json:"webdav_config,omitempty"`
+	// keep_location_metadata keeps the EXIF GPS data of uploaded images, which is stripped by default.
+	KeepLocationMetadata bool `protobuf:"varint,7,opt,name=keep_location_metadata,json=keepLocationMetadata,proto3" json:"keep_location_metadata,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *WorkspaceStorageSetting) Reset() {
@@ -444,6 +480,27 @@ func (x *WorkspaceStorageSetting) GetS3Config() *WorkspaceStorageSetting_S3Confi
 	return nil
 }
 
+func (x *WorkspaceStorageSetting) GetContentAddressed() bool {
+	if x != nil {
+		return x.ContentAddressed
+	}
+	return false
+}
+
+func (x *WorkspaceStorageSetting) GetWebdavConfig() *WorkspaceStorageSetting_WebDAVConfig {
+	if x != nil {
+		return x.WebdavConfig
+	}
+	return nil
+}
+
+func (x *WorkspaceStorageSetting) GetKeepLocationMetadata() bool {
+	if x != nil {
+		return x.KeepLocationMetadata
+	}
+	return false
+}
+
 type WorkspaceMemoRelatedSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// disallow_public_visibility disallows set memo as public visibility.
@@ -465,9 +522,11 @@ type WorkspaceMemoRelatedSetting struct {
 	// enable_blur_nsfw_content enables blurring of content marked as not safe for work (NSFW).
 	EnableBlurNsfwContent bool `protobuf:"varint,12,opt,name=enable_blur_nsfw_content,json=enableBlurNsfwContent,proto3" json:"enable_blur_nsfw_content,omitempty"`
 	// nsfw_tags is the list of tags that mark content as NSFW for blurring.
-	NsfwTags      []string `protobuf:"bytes,13,rep,name=nsfw_tags,json=nsfwTags,proto3" json:"nsfw_tags,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	NsfwTags []string `protobuf:"bytes,13,rep,name=nsfw_tags,json=nsfwTags,proto3" json:"nsfw_tags,omitempty"`
+	// proxy_link_preview_images serves the images of link previews through the server, so that readers do not request them from third parties.
+	ProxyLinkPreviewImages bool `protobuf:"varint,14,opt,name=proxy_link_preview_images,json=proxyLinkPreviewImages,proto3" json:"proxy_link_preview_images,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *WorkspaceMemoRelatedSetting) Reset() {
@@ -570,6 +629,103 @@ func (x *WorkspaceMemoRelatedSetting) GetNsfwTags() []string {
 	return nil
 }
 
+func (x *WorkspaceMemoRelatedSetting) GetProxyLinkPreviewImages() bool {
+	if x != nil {
+		return x.ProxyLinkPreviewImages
+	}
+	return false
+}
+
+type WorkspaceRateLimitSetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// disabled turns off rate limiting and account lockout.
+	Disabled bool `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	// requests_per_minute is the request rate allowed per user, or per IP for anonymous requests.
+	RequestsPerMinute int32 `protobuf:"varint,2,opt,name=requests_per_minute,json=requestsPerMinute,proto3" json:"requests_per_minute,omitempty"`
+	// burst is the number of requests allowed at once.
+	Burst int32 `protobuf:"varint,3,opt,name=burst,proto3" json:"burst,omitempty"`
+	// auth_requests_per_minute is the sign in and sign up rate allowed per IP.
+	AuthRequestsPerMinute int32 `protobuf:"varint,4,opt,name=auth_requests_per_minute,json=authRequestsPerMinute,proto3" json:"auth_requests_per_minute,omitempty"`
+	// max_failed_sign_in_attempts is the number of consecutive wrong passwords that locks an account.
+	MaxFailedSignInAttempts int32 `protobuf:"varint,5,opt,name=max_failed_sign_in_attempts,json=maxFailedSignInAttempts,proto3" json:"max_failed_sign_in_attempts,omitempty"`
+	// lockout_duration_seconds is the first lockout duration, doubled on each following lockout.
+	LockoutDurationSeconds int32 `protobuf:"varint,6,opt,name=lockout_duration_seconds,json=lockoutDurationSeconds,proto3" json:"lockout_duration_seconds,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceRateLimitSetting) Reset() {
+	*x = WorkspaceRateLimitSetting{}
+	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceRateLimitSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceRateLimitSetting) ProtoMessage() {}
+
+func (x *WorkspaceRateLimitSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceRateLimitSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceRateLimitSetting) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_setting_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WorkspaceRateLimitSetting) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *WorkspaceRateLimitSetting) GetRequestsPerMinute() int32 {
+	if x != nil {
+		return x.RequestsPerMinute
+	}
+	return 0
+}
+
+func (x *WorkspaceRateLimitSetting) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+func (x *WorkspaceRateLimitSetting) GetAuthRequestsPerMinute() int32 {
+	if x != nil {
+		return x.AuthRequestsPerMinute
+	}
+	return 0
+}
+
+func (x *WorkspaceRateLimitSetting) GetMaxFailedSignInAttempts() int32 {
+	if x != nil {
+		return x.MaxFailedSignInAttempts
+	}
+	return 0
+}
+
+func (x *WorkspaceRateLimitSetting) GetLockoutDurationSeconds() int32 {
+	if x != nil {
+		return x.LockoutDurationSeconds
+	}
+	return 0
+}
+
 type GetWorkspaceSettingRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The resource name of the workspace setting.
@@ -581,7 +737,7 @@ type GetWorkspaceSettingRequest struct {
 
 func (x *GetWorkspaceSettingRequest) Reset() {
 	*x = GetWorkspaceSettingRequest{}
-	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[5]
+	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -593,7 +749,7 @@ func (x *GetWorkspaceSettingRequest) String() string {
 func (*GetWorkspaceSettingRequest) ProtoMessage() {}
 
 func (x *GetWorkspaceSettingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[5]
+	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -606,7 +762,7 @@ func (x *GetWorkspaceSettingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetWorkspaceSettingRequest.ProtoReflect.Descriptor instead.
 func (*GetWorkspaceSettingRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_workspace_setting_service_proto_rawDescGZIP(), []int{5}
+	return file_api_v1_workspace_setting_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetWorkspaceSettingRequest) GetName() string {
@@ -626,7 +782,7 @@ type SetWorkspaceSettingRequest struct {
 
 func (x *SetWorkspaceSettingRequest) Reset() {
 	*x = SetWorkspaceSettingRequest{}
-	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[6]
+	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -638,7 +794,7 @@ func (x *SetWorkspaceSettingRequest) String() string {
 func (*SetWorkspaceSettingRequest) ProtoMessage() {}
 
 func (x *SetWorkspaceSettingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[6]
+	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -651,7 +807,7 @@ func (x *SetWorkspaceSettingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetWorkspaceSettingRequest.ProtoReflect.Descriptor instead.
 func (*SetWorkspaceSettingRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_workspace_setting_service_proto_rawDescGZIP(), []int{6}
+	return file_api_v1_workspace_setting_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SetWorkspaceSettingRequest) GetSetting() *WorkspaceSetting {
@@ -676,7 +832,7 @@ type WorkspaceStorageSetting_S3Config struct {
 
 func (x *WorkspaceStorageSetting_S3Config) Reset() {
 	*x = WorkspaceStorageSetting_S3Config{}
-	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[7]
+	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -688,7 +844,7 @@ func (x *WorkspaceStorageSetting_S3Config) String() string {
 func (*WorkspaceStorageSetting_S3Config) ProtoMessage() {}
 
 func (x *WorkspaceStorageSetting_S3Config) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[7]
+	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -746,17 +902,79 @@ func (x *WorkspaceStorageSetting_S3Config) GetUsePathStyle() bool {
 	return false
 }
 
+type WorkspaceStorageSetting_WebDAVConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// url is the base URL of the WebDAV collection, e.g. https://dav.example.com/memos.
+	Url           string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Username      string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceStorageSetting_WebDAVConfig) Reset() {
+	*x = WorkspaceStorageSetting_WebDAVConfig{}
+	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceStorageSetting_WebDAVConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceStorageSetting_WebDAVConfig) ProtoMessage() {}
+
+func (x *WorkspaceStorageSetting_WebDAVConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_setting_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceStorageSetting_WebDAVConfig.ProtoReflect.Descriptor instead.
+func (*WorkspaceStorageSetting_WebDAVConfig) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_setting_service_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *WorkspaceStorageSetting_WebDAVConfig) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WorkspaceStorageSetting_WebDAVConfig) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *WorkspaceStorageSetting_WebDAVConfig) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
 var File_api_v1_workspace_setting_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_workspace_setting_service_proto_rawDesc = "" +
 	"\n" +
-	"&api/v1/workspace_setting_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\"\xb2\x02\n" +
+	"&api/v1/workspace_setting_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\"\x8b\x03\n" +
 	"\x10WorkspaceSetting\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12P\n" +
 	"\x0fgeneral_setting\x18\x02 \x01(\v2%.memos.api.v1.WorkspaceGeneralSettingH\x00R\x0egeneralSetting\x12P\n" +
 	"\x0fstorage_setting\x18\x03 \x01(\v2%.memos.api.v1.WorkspaceStorageSettingH\x00R\x0estorageSetting\x12]\n" +
-	"\x14memo_related_setting\x18\x04 \x01(\v2).memos.api.v1.WorkspaceMemoRelatedSettingH\x00R\x12memoRelatedSettingB\a\n" +
-	"\x05value\"\xd9\x03\n" +
+	"\x14memo_related_setting\x18\x04 \x01(\v2).memos.api.v1.WorkspaceMemoRelatedSettingH\x00R\x12memoRelatedSetting\x12W\n" +
+	"\x12rate_limit_setting\x18\x05 \x01(\v2'.memos.api.v1.WorkspaceRateLimitSettingH\x00R\x10rateLimitSettingB\a\n" +
+	"\x05value\"\x9b\x04\n" +
 	"\x17WorkspaceGeneralSetting\x12<\n" +
 	"\x1adisallow_user_registration\x18\x01 \x01(\bR\x18disallowUserRegistration\x124\n" +
 	"\x16disallow_password_auth\x18\x02 \x01(\bR\x14disallowPasswordAuth\x12+\n" +
@@ -765,7 +983,8 @@ const file_api_v1_workspace_setting_service_proto_rawDesc = "" +
 	"\x0ecustom_profile\x18\x05 \x01(\v2$.memos.api.v1.WorkspaceCustomProfileR\rcustomProfile\x121\n" +
 	"\x15week_start_day_offset\x18\x06 \x01(\x05R\x12weekStartDayOffset\x128\n" +
 	"\x18disallow_change_username\x18\a \x01(\bR\x16disallowChangeUsername\x128\n" +
-	"\x18disallow_change_nickname\x18\b \x01(\bR\x16disallowChangeNickname\"\xa3\x01\n" +
+	"\x18disallow_change_nickname\x18\b \x01(\bR\x16disallowChangeNickname\x12@\n" +
+	"\x1drequire_two_factor_for_admins\x18\t \x01(\bR\x19requireTwoFactorForAdmins\"\xa3\x01\n" +
 	"\x16WorkspaceCustomProfile\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x19\n" +
@@ -773,24 +992,33 @@ const file_api_v1_workspace_setting_service_proto_rawDesc = "" +
 	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x1e\n" +
 	"\n" +
 	"appearance\x18\x05 \x01(\tR\n" +
-	"appearance\"\xb7\x04\n" +
+	"appearance\"\xd9\x06\n" +
 	"\x17WorkspaceStorageSetting\x12T\n" +
 	"\fstorage_type\x18\x01 \x01(\x0e21.memos.api.v1.WorkspaceStorageSetting.StorageTypeR\vstorageType\x12+\n" +
 	"\x11filepath_template\x18\x02 \x01(\tR\x10filepathTemplate\x12/\n" +
 	"\x14upload_size_limit_mb\x18\x03 \x01(\x03R\x11uploadSizeLimitMb\x12K\n" +
-	"\ts3_config\x18\x04 \x01(\v2..memos.api.v1.WorkspaceStorageSetting.S3ConfigR\bs3Config\x1a\xcc\x01\n" +
+	"\ts3_config\x18\x04 \x01(\v2..memos.api.v1.WorkspaceStorageSetting.S3ConfigR\bs3Config\x12+\n" +
+	"\x11content_addressed\x18\x05 \x01(\bR\x10contentAddressed\x12W\n" +
+	"\rwebdav_config\x18\x06 \x01(\v22.memos.api.v1.WorkspaceStorageSetting.WebDAVConfigR\fwebdavConfig\x124\n" +
+	"\x16keep_location_metadata\x18\a \x01(\bR\x14keepLocationMetadata\x1a\xcc\x01\n" +
 	"\bS3Config\x12\"\n" +
 	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12*\n" +
 	"\x11access_key_secret\x18\x02 \x01(\tR\x0faccessKeySecret\x12\x1a\n" +
 	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x16\n" +
 	"\x06region\x18\x04 \x01(\tR\x06region\x12\x16\n" +
 	"\x06bucket\x18\x05 \x01(\tR\x06bucket\x12$\n" +
-	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\"L\n" +
+	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\x1aX\n" +
+	"\fWebDAVConfig\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"X\n" +
 	"\vStorageType\x12\x1c\n" +
 	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bDATABASE\x10\x01\x12\t\n" +
 	"\x05LOCAL\x10\x02\x12\x06\n" +
-	"\x02S3\x10\x03\"\x94\x04\n" +
+	"\x02S3\x10\x03\x12\n" +
+	"\n" +
+	"\x06WEBDAV\x10\x04\"\xcf\x04\n" +
 	"\x1bWorkspaceMemoRelatedSetting\x12<\n" +
 	"\x1adisallow_public_visibility\x18\x01 \x01(\bR\x18disallowPublicVisibility\x127\n" +
 	"\x18display_with_update_time\x18\x02 \x01(\bR\x15displayWithUpdateTime\x120\n" +
@@ -802,7 +1030,15 @@ const file_api_v1_workspace_setting_service_proto_rawDesc = "" +
 	" \x03(\tR\treactions\x12<\n" +
 	"\x1adisable_markdown_shortcuts\x18\v \x01(\bR\x18disableMarkdownShortcuts\x127\n" +
 	"\x18enable_blur_nsfw_content\x18\f \x01(\bR\x15enableBlurNsfwContent\x12\x1b\n" +
-	"\tnsfw_tags\x18\r \x03(\tR\bnsfwTagsJ\x04\b\x04\x10\x05J\x04\b\b\x10\t\"5\n" +
+	"\tnsfw_tags\x18\r \x03(\tR\bnsfwTags\x129\n" +
+	"\x19proxy_link_preview_images\x18\x0e \x01(\bR\x16proxyLinkPreviewImagesJ\x04\b\x04\x10\x05J\x04\b\b\x10\t\"\xae\x02\n" +
+	"\x19WorkspaceRateLimitSetting\x12\x1a\n" +
+	"\bdisabled\x18\x01 \x01(\bR\bdisabled\x12.\n" +
+	"\x13requests_per_minute\x18\x02 \x01(\x05R\x11requestsPerMinute\x12\x14\n" +
+	"\x05burst\x18\x03 \x01(\x05R\x05burst\x127\n" +
+	"\x18auth_requests_per_minute\x18\x04 \x01(\x05R\x15authRequestsPerMinute\x12<\n" +
+	"\x1bmax_failed_sign_in_attempts\x18\x05 \x01(\x05R\x17maxFailedSignInAttempts\x128\n" +
+	"\x18lockout_duration_seconds\x18\x06 \x01(\x05R\x16lockoutDurationSeconds\"5\n" +
 	"\x1aGetWorkspaceSettingRequest\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tB\x03\xe0A\x02R\x04name\"V\n" +
 	"\x1aSetWorkspaceSettingRequest\x128\n" +
@@ -825,35 +1061,39 @@ func file_api_v1_workspace_setting_service_proto_rawDescGZIP() []byte {
 }
 
 var file_api_v1_workspace_setting_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_v1_workspace_setting_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_v1_workspace_setting_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_api_v1_workspace_setting_service_proto_goTypes = []any{
-	(WorkspaceStorageSetting_StorageType)(0), // 0: memos.api.v1.WorkspaceStorageSetting.StorageType
-	(*WorkspaceSetting)(nil),                 // 1: memos.api.v1.WorkspaceSetting
-	(*WorkspaceGeneralSetting)(nil),          // 2: memos.api.v1.WorkspaceGeneralSetting
-	(*WorkspaceCustomProfile)(nil),           // 3: memos.api.v1.WorkspaceCustomProfile
-	(*WorkspaceStorageSetting)(nil),          // 4: memos.api.v1.WorkspaceStorageSetting
-	(*WorkspaceMemoRelatedSetting)(nil),      // 5: memos.api.v1.WorkspaceMemoRelatedSetting
-	(*GetWorkspaceSettingRequest)(nil),       // 6: memos.api.v1.GetWorkspaceSettingRequest
-	(*SetWorkspaceSettingRequest)(nil),       // 7: memos.api.v1.SetWorkspaceSettingRequest
-	(*WorkspaceStorageSetting_S3Config)(nil), // 8: memos.api.v1.WorkspaceStorageSetting.S3Config
+	(WorkspaceStorageSetting_StorageType)(0),     // 0: memos.api.v1.WorkspaceStorageSetting.StorageType
+	(*WorkspaceSetting)(nil),                     // 1: memos.api.v1.WorkspaceSetting
+	(*WorkspaceGeneralSetting)(nil),              // 2: memos.api.v1.WorkspaceGeneralSetting
+	(*WorkspaceCustomProfile)(nil),               // 3: memos.api.v1.WorkspaceCustomProfile
+	(*WorkspaceStorageSetting)(nil),              // 4: memos.api.v1.WorkspaceStorageSetting
+	(*WorkspaceMemoRelatedSetting)(nil),          // 5: memos.api.v1.WorkspaceMemoRelatedSetting
+	(*WorkspaceRateLimitSetting)(nil),            // 6: memos.api.v1.WorkspaceRateLimitSetting
+	(*GetWorkspaceSettingRequest)(nil),           // 7: memos.api.v1.GetWorkspaceSettingRequest
+	(*SetWorkspaceSettingRequest)(nil),           // 8: memos.api.v1.SetWorkspaceSettingRequest
+	(*WorkspaceStorageSetting_S3Config)(nil),     // 9: memos.api.v1.WorkspaceStorageSetting.S3Config
+	(*WorkspaceStorageSetting_WebDAVConfig)(nil), // 10: memos.api.v1.WorkspaceStorageSetting.WebDAVConfig
 }
 var file_api_v1_workspace_setting_service_proto_depIdxs = []int32{
-	2, // 0: memos.api.v1.WorkspaceSetting.general_setting:type_name -> memos.api.v1.WorkspaceGeneralSetting
-	4, // 1: memos.api.v1.WorkspaceSetting.storage_setting:type_name -> memos.api.v1.WorkspaceStorageSetting
-	5, // 2: memos.api.v1.WorkspaceSetting.memo_related_setting:type_name -> memos.api.v1.WorkspaceMemoRelatedSetting
-	3, // 3: memos.api.v1.WorkspaceGeneralSetting.custom_profile:type_name -> memos.api.v1.WorkspaceCustomProfile
-	0, // 4: memos.api.v1.WorkspaceStorageSetting.storage_type:type_name -> memos.api.v1.WorkspaceStorageSetting.StorageType
-	8, // 5: memos.api.v1.WorkspaceStorageSetting.s3_config:type_name -> memos.api.v1.WorkspaceStorageSetting.S3Config
-	1, // 6: memos.api.v1.SetWorkspaceSettingRequest.setting:type_name -> memos.api.v1.WorkspaceSetting
-	6, // 7: memos.api.v1.WorkspaceSettingService.GetWorkspaceSetting:input_type -> memos.api.v1.GetWorkspaceSettingRequest
-	7, // 8: memos.api.v1.WorkspaceSettingService.SetWorkspaceSetting:input_type -> memos.api.v1.SetWorkspaceSettingRequest
-	1, // 9: memos.api.v1.WorkspaceSettingService.GetWorkspaceSetting:output_type -> memos.api.v1.WorkspaceSetting
-	1, // 10: memos.api.v1.WorkspaceSettingService.SetWorkspaceSetting:output_type -> memos.api.v1.WorkspaceSetting
-	9, // [9:11] is the sub-list for method output_type
-	7, // [7:9] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	2,  // 0: memos.api.v1.WorkspaceSetting.general_setting:type_name -> memos.api.v1.WorkspaceGeneralSetting
+	4,  // 1: memos.api.v1.WorkspaceSetting.storage_setting:type_name -> memos.api.v1.WorkspaceStorageSetting
+	5,  // 2: memos.api.v1.WorkspaceSetting.memo_related_setting:type_name -> memos.api.v1.WorkspaceMemoRelatedSetting
+	6,  // 3: memos.api.v1.WorkspaceSetting.rate_limit_setting:type_name -> memos.api.v1.WorkspaceRateLimitSetting
+	3,  // 4: memos.api.v1.WorkspaceGeneralSetting.custom_profile:type_name -> memos.api.v1.WorkspaceCustomProfile
+	0,  // 5: memos.api.v1.WorkspaceStorageSetting.storage_type:type_name -> memos.api.v1.WorkspaceStorageSetting.StorageType
+	9,  // 6: memos.api.v1.WorkspaceStorageSetting.s3_config:type_name -> memos.api.v1.WorkspaceStorageSetting.S3Config
+	10, // 7: memos.api.v1.WorkspaceStorageSetting.webdav_config:type_name -> memos.api.v1.WorkspaceStorageSetting.WebDAVConfig
+	1,  // 8: memos.api.v1.SetWorkspaceSettingRequest.setting:type_name -> memos.api.v1.WorkspaceSetting
+	7,  // 9: memos.api.v1.WorkspaceSettingService.GetWorkspaceSetting:input_type -> memos.api.v1.GetWorkspaceSettingRequest
+	8,  // 10: memos.api.v1.WorkspaceSettingService.SetWorkspaceSetting:input_type -> memos.api.v1.SetWorkspaceSettingRequest
+	1,  // 11: memos.api.v1.WorkspaceSettingService.GetWorkspaceSetting:output_type -> memos.api.v1.WorkspaceSetting
+	1,  // 12: memos.api.v1.WorkspaceSettingService.SetWorkspaceSetting:output_type -> memos.api.v1.WorkspaceSetting
+	11, // [11:13] is the sub-list for method output_type
+	9,  // [9:11] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_workspace_setting_service_proto_init() }
@@ -865,6 +1105,7 @@ func file_api_v1_workspace_setting_service_proto_init() {
 		(*WorkspaceSetting_GeneralSetting)(nil),
 		(*WorkspaceSetting_StorageSetting)(nil),
 		(*WorkspaceSetting_MemoRelatedSetting)(nil),
+		(*WorkspaceSetting_RateLimitSetting)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -872,7 +1113,7 @@ func file_api_v1_workspace_setting_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_workspace_setting_service_proto_rawDesc), len(file_api_v1_workspace_setting_service_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   8,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,6 +26,8 @@ type IdentityProvider_Type int32
 const (
 	IdentityProvider_TYPE_UNSPECIFIED IdentityProvider_Type = 0
 	IdentityProvider_OAUTH2           IdentityProvider_Type = 1
+	IdentityProvider_OIDC             IdentityProvider_Type = 2
+	IdentityProvider_LDAP             IdentityProvider_Type = 3
 )
 
 // Enum value maps for IdentityProvider_Type.
@@ -33,10 +35,14 @@ var (
 	IdentityProvider_Type_name = map[int32]string{
 		0: "TYPE_UNSPECIFIED",
 		1: "OAUTH2",
+		2: "OIDC",
+		3: "LDAP",
 	}
 	IdentityProvider_Type_value = map[string]int32{
 		"TYPE_UNSPECIFIED": 0,
 		"OAUTH2":           1,
+		"OIDC":             2,
+		"LDAP":             3,
 	}
 )
 
@@ -148,6 +154,8 @@ type IdentityProviderConfig struct {
 	// Types that are valid to be assigned to Config:
 	//
 	//	*IdentityProviderConfig_Oauth2Config
+	//	*IdentityProviderConfig_OidcConfig
+	//	*IdentityProviderConfig_LdapConfig
 	Config        isIdentityProviderConfig_Config `protobuf_oneof:"config"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -199,6 +207,24 @@ func (x *IdentityProviderConfig) GetOauth2Config() *OAuth2Config {
 	return nil
 }
 
+func (x *IdentityProviderConfig) GetOidcConfig() *OIDCConfig {
+	if x != nil {
+		if x, ok := x.Config.(*IdentityProviderConfig_OidcConfig); ok {
+			return x.OidcConfig
+		}
+	}
+	return nil
+}
+
+func (x *IdentityProviderConfig) GetLdapConfig() *LDAPConfig {
+	if x != nil {
+		if x, ok := x.Config.(*IdentityProviderConfig_LdapConfig); ok {
+			return x.LdapConfig
+		}
+	}
+	return nil
+}
+
 type isIdentityProviderConfig_Config interface {
 	isIdentityProviderConfig_Config()
 }
@@ -207,14 +233,28 @@ type IdentityProviderConfig_Oauth2Config struct {
 	Oauth2Config *OAuth2Config `protobuf:"bytes,1,opt,name=oauth2_config,json=oauth2Config,proto3,oneof"`
 }
 
+type IdentityProviderConfig_OidcConfig struct {
+	OidcConfig *OIDCConfig `protobuf:"bytes,2,opt,name=oidc_config,json=oidcConfig,proto3,oneof"`
+}
+
+type IdentityProviderConfig_LdapConfig struct {
+	LdapConfig *LDAPConfig `protobuf:"bytes,3,opt,name=ldap_config,json=ldapConfig,proto3,oneof"`
+}
+
 func (*IdentityProviderConfig_Oauth2Config) isIdentityProviderConfig_Config() {}
 
+func (*IdentityProviderConfig_OidcConfig) isIdentityProviderConfig_Config() {}
+
+func (*IdentityProviderConfig_LdapConfig) isIdentityProviderConfig_Config() {}
+
 type FieldMapping struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    string                 `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
-	DisplayName   string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	AvatarUrl     string                 `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Identifier  string                 `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	DisplayName string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Email       string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	AvatarUrl   string                 `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	// The claim holding the groups of the user, a list or a single string.
+	Groups        string `protobuf:"bytes,5,opt,name=groups,proto3" json:"groups,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -277,6 +317,13 @@ func (x *FieldMapping) GetAvatarUrl() string {
 	return ""
 }
 
+func (x *FieldMapping) GetGroups() string {
+	if x != nil {
+		return x.Groups
+	}
+	return ""
+}
+
 type OAuth2Config struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
@@ -286,6 +333,7 @@ type OAuth2Config struct {
 	UserInfoUrl   string                 `protobuf:"bytes,5,opt,name=user_info_url,json=userInfoUrl,proto3" json:"user_info_url,omitempty"`
 	Scopes        []string               `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`
 	FieldMapping  *FieldMapping          `protobuf:"bytes,7,opt,name=field_mapping,json=fieldMapping,proto3" json:"field_mapping,omitempty"`
+	Provisioning  *ProvisioningConfig    `protobuf:"bytes,8,opt,name=provisioning,proto3" json:"provisioning,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -369,24 +417,374 @@ func (x *OAuth2Config) GetFieldMapping() *FieldMapping {
 	return nil
 }
 
+func (x *OAuth2Config) GetProvisioning() *ProvisioningConfig {
+	if x != nil {
+		return x.Provisioning
+	}
+	return nil
+}
+
+type OIDCConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The issuer URL, the discovery document is served under /.well-known/openid-configuration.
+	Issuer       string `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	ClientId     string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret string `protobuf:"bytes,3,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	// The scopes to request in addition to "openid".
+	Scopes        []string            `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	FieldMapping  *FieldMapping       `protobuf:"bytes,5,opt,name=field_mapping,json=fieldMapping,proto3" json:"field_mapping,omitempty"`
+	Provisioning  *ProvisioningConfig `protobuf:"bytes,6,opt,name=provisioning,proto3" json:"provisioning,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OIDCConfig) Reset() {
+	*x = OIDCConfig{}
+	mi := &file_store_idp_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OIDCConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OIDCConfig) ProtoMessage() {}
+
+func (x *OIDCConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_store_idp_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OIDCConfig.ProtoReflect.Descriptor instead.
+func (*OIDCConfig) Descriptor() ([]byte, []int) {
+	return file_store_idp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OIDCConfig) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *OIDCConfig) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OIDCConfig) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *OIDCConfig) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *OIDCConfig) GetFieldMapping() *FieldMapping {
+	if x != nil {
+		return x.FieldMapping
+	}
+	return nil
+}
+
+func (x *OIDCConfig) GetProvisioning() *ProvisioningConfig {
+	if x != nil {
+		return x.Provisioning
+	}
+	return nil
+}
+
+type LDAPConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The URL of the server, ldap://host:389 or ldaps://host:636.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Whether to upgrade ldap:// connections with StartTLS.
+	StartTls bool `protobuf:"varint,2,opt,name=start_tls,json=startTls,proto3" json:"start_tls,omitempty"`
+	// Whether to skip the verification of the server certificate.
+	InsecureSkipVerify bool `protobuf:"varint,3,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"`
+	// The DN and password of the account used to search users, anonymous when empty.
+	BindDn       string `protobuf:"bytes,4,opt,name=bind_dn,json=bindDn,proto3" json:"bind_dn,omitempty"`
+	BindPassword string `protobuf:"bytes,5,opt,name=bind_password,json=bindPassword,proto3" json:"bind_password,omitempty"`
+	SearchBase   string `protobuf:"bytes,6,opt,name=search_base,json=searchBase,proto3" json:"search_base,omitempty"`
+	// The filter finding the user, "%s" is replaced with the escaped username, e.g. "(uid=%s)".
+	UserFilter string `protobuf:"bytes,7,opt,name=user_filter,json=userFilter,proto3" json:"user_filter,omitempty"`
+	// The attributes to map, the identifier defaults to "uid".
+	FieldMapping *FieldMapping       `protobuf:"bytes,8,opt,name=field_mapping,json=fieldMapping,proto3" json:"field_mapping,omitempty"`
+	Provisioning *ProvisioningConfig `protobuf:"bytes,9,opt,name=provisioning,proto3" json:"provisioning,omitempty"`
+	// Whether local passwords are still checked before LDAP.
+	// When false LDAP replaces the local password check for everyone except the host.
+	AllowLocalPassword bool `protobuf:"varint,10,opt,name=allow_local_password,json=allowLocalPassword,proto3" json:"allow_local_password,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *LDAPConfig) Reset() {
+	*x = LDAPConfig{}
+	mi := &file_store_idp_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LDAPConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LDAPConfig) ProtoMessage() {}
+
+func (x *LDAPConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_store_idp_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LDAPConfig.ProtoReflect.Descriptor instead.
+func (*LDAPConfig) Descriptor() ([]byte, []int) {
+	return file_store_idp_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LDAPConfig) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *LDAPConfig) GetStartTls() bool {
+	if x != nil {
+		return x.StartTls
+	}
+	return false
+}
+
+func (x *LDAPConfig) GetInsecureSkipVerify() bool {
+	if x != nil {
+		return x.InsecureSkipVerify
+	}
+	return false
+}
+
+func (x *LDAPConfig) GetBindDn() string {
+	if x != nil {
+		return x.BindDn
+	}
+	return ""
+}
+
+func (x *LDAPConfig) GetBindPassword() string {
+	if x != nil {
+		return x.BindPassword
+	}
+	return ""
+}
+
+func (x *LDAPConfig) GetSearchBase() string {
+	if x != nil {
+		return x.SearchBase
+	}
+	return ""
+}
+
+func (x *LDAPConfig) GetUserFilter() string {
+	if x != nil {
+		return x.UserFilter
+	}
+	return ""
+}
+
+func (x *LDAPConfig) GetFieldMapping() *FieldMapping {
+	if x != nil {
+		return x.FieldMapping
+	}
+	return nil
+}
+
+func (x *LDAPConfig) GetProvisioning() *ProvisioningConfig {
+	if x != nil {
+		return x.Provisioning
+	}
+	return nil
+}
+
+func (x *LDAPConfig) GetAllowLocalPassword() bool {
+	if x != nil {
+		return x.AllowLocalPassword
+	}
+	return false
+}
+
+type ProvisioningConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The rules mapping claims to roles, evaluated on every sign in.
+	// The most privileged role of the matching rules is granted, users matching no rule get the USER role.
+	RoleMappings []*RoleMappingRule `protobuf:"bytes,1,rep,name=role_mappings,json=roleMappings,proto3" json:"role_mappings,omitempty"`
+	// Whether the nickname, email and avatar are updated from the claims on every sign in.
+	SyncProfile bool `protobuf:"varint,2,opt,name=sync_profile,json=syncProfile,proto3" json:"sync_profile,omitempty"`
+	// Whether users rejected by the identifier filter, or matching none of the role mappings, are archived.
+	ArchiveUnmatchedUsers bool `protobuf:"varint,3,opt,name=archive_unmatched_users,json=archiveUnmatchedUsers,proto3" json:"archive_unmatched_users,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ProvisioningConfig) Reset() {
+	*x = ProvisioningConfig{}
+	mi := &file_store_idp_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisioningConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningConfig) ProtoMessage() {}
+
+func (x *ProvisioningConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_store_idp_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningConfig.ProtoReflect.Descriptor instead.
+func (*ProvisioningConfig) Descriptor() ([]byte, []int) {
+	return file_store_idp_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProvisioningConfig) GetRoleMappings() []*RoleMappingRule {
+	if x != nil {
+		return x.RoleMappings
+	}
+	return nil
+}
+
+func (x *ProvisioningConfig) GetSyncProfile() bool {
+	if x != nil {
+		return x.SyncProfile
+	}
+	return false
+}
+
+func (x *ProvisioningConfig) GetArchiveUnmatchedUsers() bool {
+	if x != nil {
+		return x.ArchiveUnmatchedUsers
+	}
+	return false
+}
+
+type RoleMappingRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The claim to match, defaults to the groups of the field mapping.
+	Claim string `protobuf:"bytes,1,opt,name=claim,proto3" json:"claim,omitempty"`
+	// The value the claim must equal or, for list claims, contain.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// The role to grant, either ADMIN or USER.
+	Role          string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoleMappingRule) Reset() {
+	*x = RoleMappingRule{}
+	mi := &file_store_idp_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleMappingRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleMappingRule) ProtoMessage() {}
+
+func (x *RoleMappingRule) ProtoReflect() protoreflect.Message {
+	mi := &file_store_idp_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleMappingRule.ProtoReflect.Descriptor instead.
+func (*RoleMappingRule) Descriptor() ([]byte, []int) {
+	return file_store_idp_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RoleMappingRule) GetClaim() string {
+	if x != nil {
+		return x.Claim
+	}
+	return ""
+}
+
+func (x *RoleMappingRule) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *RoleMappingRule) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
 var File_store_idp_proto protoreflect.FileDescriptor
 
 const file_store_idp_proto_rawDesc = "" +
 	"\n" +
-	"\x0fstore/idp.proto\x12\vmemos.store\"\x82\x02\n" +
+	"\x0fstore/idp.proto\x12\vmemos.store\"\x96\x02\n" +
 	"\x10IdentityProvider\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x126\n" +
 	"\x04type\x18\x03 \x01(\x0e2\".memos.store.IdentityProvider.TypeR\x04type\x12+\n" +
 	"\x11identifier_filter\x18\x04 \x01(\tR\x10identifierFilter\x12;\n" +
-	"\x06config\x18\x05 \x01(\v2#.memos.store.IdentityProviderConfigR\x06config\"(\n" +
+	"\x06config\x18\x05 \x01(\v2#.memos.store.IdentityProviderConfigR\x06config\"<\n" +
 	"\x04Type\x12\x14\n" +
 	"\x10TYPE_UNSPECIFIED\x10\x00\x12\n" +
 	"\n" +
-	"\x06OAUTH2\x10\x01\"d\n" +
+	"\x06OAUTH2\x10\x01\x12\b\n" +
+	"\x04OIDC\x10\x02\x12\b\n" +
+	"\x04LDAP\x10\x03\"\xdc\x01\n" +
 	"\x16IdentityProviderConfig\x12@\n" +
-	"\roauth2_config\x18\x01 \x01(\v2\x19.memos.store.OAuth2ConfigH\x00R\foauth2ConfigB\b\n" +
-	"\x06config\"\x86\x01\n" +
+	"\roauth2_config\x18\x01 \x01(\v2\x19.memos.store.OAuth2ConfigH\x00R\foauth2Config\x12:\n" +
+	"\voidc_config\x18\x02 \x01(\v2\x17.memos.store.OIDCConfigH\x00R\n" +
+	"oidcConfig\x12:\n" +
+	"\vldap_config\x18\x03 \x01(\v2\x17.memos.store.LDAPConfigH\x00R\n" +
+	"ldapConfigB\b\n" +
+	"\x06config\"\x9e\x01\n" +
 	"\fFieldMapping\x12\x1e\n" +
 	"\n" +
 	"identifier\x18\x01 \x01(\tR\n" +
@@ -394,7 +792,8 @@ const file_store_idp_proto_rawDesc = "" +
 	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x14\n" +
 	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1d\n" +
 	"\n" +
-	"avatar_url\x18\x04 \x01(\tR\tavatarUrl\"\x84\x02\n" +
+	"avatar_url\x18\x04 \x01(\tR\tavatarUrl\x12\x16\n" +
+	"\x06groups\x18\x05 \x01(\tR\x06groups\"\xc9\x02\n" +
 	"\fOAuth2Config\x12\x1b\n" +
 	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12#\n" +
 	"\rclient_secret\x18\x02 \x01(\tR\fclientSecret\x12\x19\n" +
@@ -402,7 +801,39 @@ const file_store_idp_proto_rawDesc = "" +
 	"\ttoken_url\x18\x04 \x01(\tR\btokenUrl\x12\"\n" +
 	"\ruser_info_url\x18\x05 \x01(\tR\vuserInfoUrl\x12\x16\n" +
 	"\x06scopes\x18\x06 \x03(\tR\x06scopes\x12>\n" +
-	"\rfield_mapping\x18\a \x01(\v2\x19.memos.store.FieldMappingR\ffieldMappingB\x93\x01\n" +
+	"\rfield_mapping\x18\a \x01(\v2\x19.memos.store.FieldMappingR\ffieldMapping\x12C\n" +
+	"\fprovisioning\x18\b \x01(\v2\x1f.memos.store.ProvisioningConfigR\fprovisioning\"\x83\x02\n" +
+	"\n" +
+	"OIDCConfig\x12\x16\n" +
+	"\x06issuer\x18\x01 \x01(\tR\x06issuer\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x03 \x01(\tR\fclientSecret\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x12>\n" +
+	"\rfield_mapping\x18\x05 \x01(\v2\x19.memos.store.FieldMappingR\ffieldMapping\x12C\n" +
+	"\fprovisioning\x18\x06 \x01(\v2\x1f.memos.store.ProvisioningConfigR\fprovisioning\"\xa4\x03\n" +
+	"\n" +
+	"LDAPConfig\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1b\n" +
+	"\tstart_tls\x18\x02 \x01(\bR\bstartTls\x120\n" +
+	"\x14insecure_skip_verify\x18\x03 \x01(\bR\x12insecureSkipVerify\x12\x17\n" +
+	"\abind_dn\x18\x04 \x01(\tR\x06bindDn\x12#\n" +
+	"\rbind_password\x18\x05 \x01(\tR\fbindPassword\x12\x1f\n" +
+	"\vsearch_base\x18\x06 \x01(\tR\n" +
+	"searchBase\x12\x1f\n" +
+	"\vuser_filter\x18\a \x01(\tR\n" +
+	"userFilter\x12>\n" +
+	"\rfield_mapping\x18\b \x01(\v2\x19.memos.store.FieldMappingR\ffieldMapping\x12C\n" +
+	"\fprovisioning\x18\t \x01(\v2\x1f.memos.store.ProvisioningConfigR\fprovisioning\x120\n" +
+	"\x14allow_local_password\x18\n" +
+	" \x01(\bR\x12allowLocalPassword\"\xb2\x01\n" +
+	"\x12ProvisioningConfig\x12A\n" +
+	"\rrole_mappings\x18\x01 \x03(\v2\x1c.memos.store.RoleMappingRuleR\froleMappings\x12!\n" +
+	"\fsync_profile\x18\x02 \x01(\bR\vsyncProfile\x126\n" +
+	"\x17archive_unmatched_users\x18\x03 \x01(\bR\x15archiveUnmatchedUsers\"Q\n" +
+	"\x0fRoleMappingRule\x12\x14\n" +
+	"\x05claim\x18\x01 \x01(\tR\x05claim\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04roleB\x93\x01\n" +
 	"\x0fcom.memos.storeB\bIdpProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
 
 var (
@@ -418,24 +849,36 @@ func file_store_idp_proto_rawDescGZIP() []byte {
 }
 
 var file_store_idp_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_store_idp_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_store_idp_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_store_idp_proto_goTypes = []any{
 	(IdentityProvider_Type)(0),     // 0: memos.store.IdentityProvider.Type
 	(*IdentityProvider)(nil),       // 1: memos.store.IdentityProvider
 	(*IdentityProviderConfig)(nil), // 2: memos.store.IdentityProviderConfig
 	(*FieldMapping)(nil),           // 3: memos.store.FieldMapping
 	(*OAuth2Config)(nil),           // 4: memos.store.OAuth2Config
+	(*OIDCConfig)(nil),             // 5: memos.store.OIDCConfig
+	(*LDAPConfig)(nil),             // 6: memos.store.LDAPConfig
+	(*ProvisioningConfig)(nil),     // 7: memos.store.ProvisioningConfig
+	(*RoleMappingRule)(nil),        // 8: memos.store.RoleMappingRule
 }
 var file_store_idp_proto_depIdxs = []int32{
-	0, // 0: memos.store.IdentityProvider.type:type_name -> memos.store.IdentityProvider.Type
-	2, // 1: memos.store.IdentityProvider.config:type_name -> memos.store.IdentityProviderConfig
-	4, // 2: memos.store.IdentityProviderConfig.oauth2_config:type_name -> memos.store.OAuth2Config
-	3, // 3: memos.store.OAuth2Config.field_mapping:type_name -> memos.store.FieldMapping
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	0,  // 0: memos.store.IdentityProvider.type:type_name -> memos.store.IdentityProvider.Type
+	2,  // 1: memos.store.IdentityProvider.config:type_name -> memos.store.IdentityProviderConfig
+	4,  // 2: memos.store.IdentityProviderConfig.oauth2_config:type_name -> memos.store.OAuth2Config
+	5,  // 3: memos.store.IdentityProviderConfig.oidc_config:type_name -> memos.store.OIDCConfig
+	6,  // 4: memos.store.IdentityProviderConfig.ldap_config:type_name -> memos.store.LDAPConfig
+	3,  // 5: memos.store.OAuth2Config.field_mapping:type_name -> memos.store.FieldMapping
+	7,  // 6: memos.store.OAuth2Config.provisioning:type_name -> memos.store.ProvisioningConfig
+	3,  // 7: memos.store.OIDCConfig.field_mapping:type_name -> memos.store.FieldMapping
+	7,  // 8: memos.store.OIDCConfig.provisioning:type_name -> memos.store.ProvisioningConfig
+	3,  // 9: memos.store.LDAPConfig.field_mapping:type_name -> memos.store.FieldMapping
+	7,  // 10: memos.store.LDAPConfig.provisioning:type_name -> memos.store.ProvisioningConfig
+	8,  // 11: memos.store.ProvisioningConfig.role_mappings:type_name -> memos.store.RoleMappingRule
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_store_idp_proto_init() }
@@ -445,6 +888,8 @@ func file_store_idp_proto_init() {
 	}
 	file_store_idp_proto_msgTypes[1].OneofWrappers = []any{
 		(*IdentityProviderConfig_Oauth2Config)(nil),
+		(*IdentityProviderConfig_OidcConfig)(nil),
+		(*IdentityProviderConfig_LdapConfig)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -452,7 +897,7 @@ func file_store_idp_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_idp_proto_rawDesc), len(file_store_idp_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   4,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -22,12 +22,16 @@ const (
 )
 
 type MemoPayload struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Property      *MemoPayload_Property  `protobuf:"bytes,1,opt,name=property,proto3" json:"property,omitempty"`
-	Location      *MemoPayload_Location  `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
-	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Property *MemoPayload_Property  `protobuf:"bytes,1,opt,name=property,proto3" json:"property,omitempty"`
+	Location *MemoPayload_Location  `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Tags     []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// The ids of the memos with a reference relation created for a reference in the content.
+	// Only these relations are deleted when the reference is removed from the content, the
+	// relations set with SetMemoRelations are kept.
+	ContentRelations []int32 `protobuf:"varint,4,rep,packed,name=content_relations,json=contentRelations,proto3" json:"content_relations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *MemoPayload) Reset() {
@@ -81,6 +85,13 @@ func (x *MemoPayload) GetTags() []string {
 	return nil
 }
 
+func (x *MemoPayload) GetContentRelations() []int32 {
+	if x != nil {
+		return x.ContentRelations
+	}
+	return nil
+}
+
 // The calculated properties from the memo content.
 type MemoPayload_Property struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
@@ -88,8 +99,23 @@ type MemoPayload_Property struct {
 	HasTaskList        bool                   `protobuf:"varint,2,opt,name=has_task_list,json=hasTaskList,proto3" json:"has_task_list,omitempty"`
 	HasCode            bool                   `protobuf:"varint,3,opt,name=has_code,json=hasCode,proto3" json:"has_code,omitempty"`
 	HasIncompleteTasks bool                   `protobuf:"varint,4,opt,name=has_incomplete_tasks,json=hasIncompleteTasks,proto3" json:"has_incomplete_tasks,omitempty"`
-	// The references of the memo. Should be a list of uuid.
-	References    []string `protobuf:"bytes,5,rep,name=references,proto3" json:"references,omitempty"`
+	// The references of the memo, e.g. memos/{uid} and resources/{uid}.
+	// Only the references to existing memos and resources are kept.
+	References []string `protobuf:"bytes,5,rep,name=references,proto3" json:"references,omitempty"`
+	// The ids of the users mentioned with @username.
+	Mentions []int32 `protobuf:"varint,6,rep,packed,name=mentions,proto3" json:"mentions,omitempty"`
+	// The ids of the tickets referenced with #ticket-{id}.
+	TicketReferences []int32 `protobuf:"varint,7,rep,packed,name=ticket_references,json=ticketReferences,proto3" json:"ticket_references,omitempty"`
+	// The outbound http(s) links of the memo.
+	Links []string `protobuf:"bytes,8,rep,name=links,proto3" json:"links,omitempty"`
+	// The plain text of the headings of the memo.
+	Headings []string `protobuf:"bytes,9,rep,name=headings,proto3" json:"headings,omitempty"`
+	// The number of tasks in the task lists of the memo.
+	TaskCount int32 `protobuf:"varint,10,opt,name=task_count,json=taskCount,proto3" json:"task_count,omitempty"`
+	// The number of completed tasks in the task lists of the memo.
+	CompletedTaskCount int32 `protobuf:"varint,11,opt,name=completed_task_count,json=completedTaskCount,proto3" json:"completed_task_count,omitempty"`
+	// The tasks in the task lists of the memo, in document order.
+	Tasks         []*MemoPayload_Task `protobuf:"bytes,12,rep,name=tasks,proto3" json:"tasks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -159,6 +185,117 @@ func (x *MemoPayload_Property) GetReferences() []string {
 	return nil
 }
 
+func (x *MemoPayload_Property) GetMentions() []int32 {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *MemoPayload_Property) GetTicketReferences() []int32 {
+	if x != nil {
+		return x.TicketReferences
+	}
+	return nil
+}
+
+func (x *MemoPayload_Property) GetLinks() []string {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *MemoPayload_Property) GetHeadings() []string {
+	if x != nil {
+		return x.Headings
+	}
+	return nil
+}
+
+func (x *MemoPayload_Property) GetTaskCount() int32 {
+	if x != nil {
+		return x.TaskCount
+	}
+	return 0
+}
+
+func (x *MemoPayload_Property) GetCompletedTaskCount() int32 {
+	if x != nil {
+		return x.CompletedTaskCount
+	}
+	return 0
+}
+
+func (x *MemoPayload_Property) GetTasks() []*MemoPayload_Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type MemoPayload_Task struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The stable id of the task, derived from its content.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The markdown content of the task.
+	Content       string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Completed     bool   `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoPayload_Task) Reset() {
+	*x = MemoPayload_Task{}
+	mi := &file_store_memo_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoPayload_Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoPayload_Task) ProtoMessage() {}
+
+func (x *MemoPayload_Task) ProtoReflect() protoreflect.Message {
+	mi := &file_store_memo_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoPayload_Task.ProtoReflect.Descriptor instead.
+func (*MemoPayload_Task) Descriptor() ([]byte, []int) {
+	return file_store_memo_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *MemoPayload_Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MemoPayload_Task) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *MemoPayload_Task) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
 type MemoPayload_Location struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Placeholder   string                 `protobuf:"bytes,1,opt,name=placeholder,proto3" json:"placeholder,omitempty"`
@@ -170,7 +307,7 @@ type MemoPayload_Location struct {
 
 func (x *MemoPayload_Location) Reset() {
 	*x = MemoPayload_Location{}
-	mi := &file_store_memo_proto_msgTypes[2]
+	mi := &file_store_memo_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -182,7 +319,7 @@ func (x *MemoPayload_Location) String() string {
 func (*MemoPayload_Location) ProtoMessage() {}
 
 func (x *MemoPayload_Location) ProtoReflect() protoreflect.Message {
-	mi := &file_store_memo_proto_msgTypes[2]
+	mi := &file_store_memo_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -195,7 +332,7 @@ func (x *MemoPayload_Location) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemoPayload_Location.ProtoReflect.Descriptor instead.
 func (*MemoPayload_Location) Descriptor() ([]byte, []int) {
-	return file_store_memo_proto_rawDescGZIP(), []int{0, 1}
+	return file_store_memo_proto_rawDescGZIP(), []int{0, 2}
 }
 
 func (x *MemoPayload_Location) GetPlaceholder() string {
@@ -223,11 +360,12 @@ var File_store_memo_proto protoreflect.FileDescriptor
 
 const file_store_memo_proto_rawDesc = "" +
 	"\n" +
-	"\x10store/memo.proto\x12\vmemos.store\"\xc0\x03\n" +
+	"\x10store/memo.proto\x12\vmemos.store\"\xbe\x06\n" +
 	"\vMemoPayload\x12=\n" +
 	"\bproperty\x18\x01 \x01(\v2!.memos.store.MemoPayload.PropertyR\bproperty\x12=\n" +
 	"\blocation\x18\x02 \x01(\v2!.memos.store.MemoPayload.LocationR\blocation\x12\x12\n" +
-	"\x04tags\x18\x03 \x03(\tR\x04tags\x1a\xb6\x01\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12+\n" +
+	"\x11content_relations\x18\x04 \x03(\x05R\x10contentRelations\x1a\xb7\x03\n" +
 	"\bProperty\x12\x19\n" +
 	"\bhas_link\x18\x01 \x01(\bR\ahasLink\x12\"\n" +
 	"\rhas_task_list\x18\x02 \x01(\bR\vhasTaskList\x12\x19\n" +
@@ -235,7 +373,20 @@ const file_store_memo_proto_rawDesc = "" +
 	"\x14has_incomplete_tasks\x18\x04 \x01(\bR\x12hasIncompleteTasks\x12\x1e\n" +
 	"\n" +
 	"references\x18\x05 \x03(\tR\n" +
-	"references\x1af\n" +
+	"references\x12\x1a\n" +
+	"\bmentions\x18\x06 \x03(\x05R\bmentions\x12+\n" +
+	"\x11ticket_references\x18\a \x03(\x05R\x10ticketReferences\x12\x14\n" +
+	"\x05links\x18\b \x03(\tR\x05links\x12\x1a\n" +
+	"\bheadings\x18\t \x03(\tR\bheadings\x12\x1d\n" +
+	"\n" +
+	"task_count\x18\n" +
+	" \x01(\x05R\ttaskCount\x120\n" +
+	"\x14completed_task_count\x18\v \x01(\x05R\x12completedTaskCount\x123\n" +
+	"\x05tasks\x18\f \x03(\v2\x1d.memos.store.MemoPayload.TaskR\x05tasks\x1aN\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1c\n" +
+	"\tcompleted\x18\x03 \x01(\bR\tcompleted\x1af\n" +
 	"\bLocation\x12 \n" +
 	"\vplaceholder\x18\x01 \x01(\tR\vplaceholder\x12\x1a\n" +
 	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
@@ -254,20 +405,22 @@ func file_store_memo_proto_rawDescGZIP() []byte {
 	return file_store_memo_proto_rawDescData
 }
 
-var file_store_memo_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_store_memo_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_store_memo_proto_goTypes = []any{
 	(*MemoPayload)(nil),          // 0: memos.store.MemoPayload
 	(*MemoPayload_Property)(nil), // 1: memos.store.MemoPayload.Property
-	(*MemoPayload_Location)(nil), // 2: memos.store.MemoPayload.Location
+	(*MemoPayload_Task)(nil),     // 2: memos.store.MemoPayload.Task
+	(*MemoPayload_Location)(nil), // 3: memos.store.MemoPayload.Location
 }
 var file_store_memo_proto_depIdxs = []int32{
 	1, // 0: memos.store.MemoPayload.property:type_name -> memos.store.MemoPayload.Property
-	2, // 1: memos.store.MemoPayload.location:type_name -> memos.store.MemoPayload.Location
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 1: memos.store.MemoPayload.location:type_name -> memos.store.MemoPayload.Location
+	2, // 2: memos.store.MemoPayload.Property.tasks:type_name -> memos.store.MemoPayload.Task
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_store_memo_proto_init() }
@@ -281,7 +434,7 @@ func file_store_memo_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_memo_proto_rawDesc), len(file_store_memo_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,322 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: store/project.proto
+
+package store
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ProjectPayload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ticket types allowed in the project. Any type is allowed when empty.
+	// The first type is the default of new tickets.
+	TicketTypes []*ProjectPayload_TicketType `protobuf:"bytes,1,rep,name=ticket_types,json=ticketTypes,proto3" json:"ticket_types,omitempty"`
+	// The workflow of the tickets in the project. The workspace statuses apply when unset.
+	Workflow *ProjectPayload_Workflow `protobuf:"bytes,2,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	// The user new tickets are assigned to when neither the request nor the ticket type
+	// names an assignee. Zero means unassigned.
+	DefaultAssigneeId int32 `protobuf:"varint,3,opt,name=default_assignee_id,json=defaultAssigneeId,proto3" json:"default_assignee_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ProjectPayload) Reset() {
+	*x = ProjectPayload{}
+	mi := &file_store_project_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectPayload) ProtoMessage() {}
+
+func (x *ProjectPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_project_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectPayload.ProtoReflect.Descriptor instead.
+func (*ProjectPayload) Descriptor() ([]byte, []int) {
+	return file_store_project_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProjectPayload) GetTicketTypes() []*ProjectPayload_TicketType {
+	if x != nil {
+		return x.TicketTypes
+	}
+	return nil
+}
+
+func (x *ProjectPayload) GetWorkflow() *ProjectPayload_Workflow {
+	if x != nil {
+		return x.Workflow
+	}
+	return nil
+}
+
+func (x *ProjectPayload) GetDefaultAssigneeId() int32 {
+	if x != nil {
+		return x.DefaultAssigneeId
+	}
+	return 0
+}
+
+type ProjectPayload_TicketType struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The user new tickets of this type are assigned to. Zero falls back to the project default.
+	DefaultAssigneeId int32 `protobuf:"varint,2,opt,name=default_assignee_id,json=defaultAssigneeId,proto3" json:"default_assignee_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ProjectPayload_TicketType) Reset() {
+	*x = ProjectPayload_TicketType{}
+	mi := &file_store_project_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectPayload_TicketType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectPayload_TicketType) ProtoMessage() {}
+
+func (x *ProjectPayload_TicketType) ProtoReflect() protoreflect.Message {
+	mi := &file_store_project_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectPayload_TicketType.ProtoReflect.Descriptor instead.
+func (*ProjectPayload_TicketType) Descriptor() ([]byte, []int) {
+	return file_store_project_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ProjectPayload_TicketType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProjectPayload_TicketType) GetDefaultAssigneeId() int32 {
+	if x != nil {
+		return x.DefaultAssigneeId
+	}
+	return 0
+}
+
+type ProjectPayload_Workflow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The statuses of the workflow. The first status is the initial status of new tickets.
+	Statuses []string `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	// The allowed status changes. Any change between statuses is allowed when empty.
+	Transitions   []*ProjectPayload_Transition `protobuf:"bytes,2,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectPayload_Workflow) Reset() {
+	*x = ProjectPayload_Workflow{}
+	mi := &file_store_project_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectPayload_Workflow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectPayload_Workflow) ProtoMessage() {}
+
+func (x *ProjectPayload_Workflow) ProtoReflect() protoreflect.Message {
+	mi := &file_store_project_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectPayload_Workflow.ProtoReflect.Descriptor instead.
+func (*ProjectPayload_Workflow) Descriptor() ([]byte, []int) {
+	return file_store_project_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *ProjectPayload_Workflow) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ProjectPayload_Workflow) GetTransitions() []*ProjectPayload_Transition {
+	if x != nil {
+		return x.Transitions
+	}
+	return nil
+}
+
+type ProjectPayload_Transition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectPayload_Transition) Reset() {
+	*x = ProjectPayload_Transition{}
+	mi := &file_store_project_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectPayload_Transition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectPayload_Transition) ProtoMessage() {}
+
+func (x *ProjectPayload_Transition) ProtoReflect() protoreflect.Message {
+	mi := &file_store_project_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectPayload_Transition.ProtoReflect.Descriptor instead.
+func (*ProjectPayload_Transition) Descriptor() ([]byte, []int) {
+	return file_store_project_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *ProjectPayload_Transition) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ProjectPayload_Transition) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+var File_store_project_proto protoreflect.FileDescriptor
+
+const file_store_project_proto_rawDesc = "" +
+	"\n" +
+	"\x13store/project.proto\x12\vmemos.store\"\xc3\x03\n" +
+	"\x0eProjectPayload\x12I\n" +
+	"\fticket_types\x18\x01 \x03(\v2&.memos.store.ProjectPayload.TicketTypeR\vticketTypes\x12@\n" +
+	"\bworkflow\x18\x02 \x01(\v2$.memos.store.ProjectPayload.WorkflowR\bworkflow\x12.\n" +
+	"\x13default_assignee_id\x18\x03 \x01(\x05R\x11defaultAssigneeId\x1aP\n" +
+	"\n" +
+	"TicketType\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12.\n" +
+	"\x13default_assignee_id\x18\x02 \x01(\x05R\x11defaultAssigneeId\x1ap\n" +
+	"\bWorkflow\x12\x1a\n" +
+	"\bstatuses\x18\x01 \x03(\tR\bstatuses\x12H\n" +
+	"\vtransitions\x18\x02 \x03(\v2&.memos.store.ProjectPayload.TransitionR\vtransitions\x1a0\n" +
+	"\n" +
+	"Transition\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02toB\x97\x01\n" +
+	"\x0fcom.memos.storeB\fProjectProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
+
+var (
+	file_store_project_proto_rawDescOnce sync.Once
+	file_store_project_proto_rawDescData []byte
+)
+
+func file_store_project_proto_rawDescGZIP() []byte {
+	file_store_project_proto_rawDescOnce.Do(func() {
+		file_store_project_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_store_project_proto_rawDesc), len(file_store_project_proto_rawDesc)))
+	})
+	return file_store_project_proto_rawDescData
+}
+
+var file_store_project_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_store_project_proto_goTypes = []any{
+	(*ProjectPayload)(nil),            // 0: memos.store.ProjectPayload
+	(*ProjectPayload_TicketType)(nil), // 1: memos.store.ProjectPayload.TicketType
+	(*ProjectPayload_Workflow)(nil),   // 2: memos.store.ProjectPayload.Workflow
+	(*ProjectPayload_Transition)(nil), // 3: memos.store.ProjectPayload.Transition
+}
+var file_store_project_proto_depIdxs = []int32{
+	1, // 0: memos.store.ProjectPayload.ticket_types:type_name -> memos.store.ProjectPayload.TicketType
+	2, // 1: memos.store.ProjectPayload.workflow:type_name -> memos.store.ProjectPayload.Workflow
+	3, // 2: memos.store.ProjectPayload.Workflow.transitions:type_name -> memos.store.ProjectPayload.Transition
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_store_project_proto_init() }
+func file_store_project_proto_init() {
+	if File_store_project_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_project_proto_rawDesc), len(file_store_project_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_store_project_proto_goTypes,
+		DependencyIndexes: file_store_project_proto_depIdxs,
+		MessageInfos:      file_store_project_proto_msgTypes,
+	}.Build()
+	File_store_project_proto = out.File
+	file_store_project_proto_goTypes = nil
+	file_store_project_proto_depIdxs = nil
+}
@@ -32,6 +32,8 @@ const (
 	ResourceStorageType_S3 ResourceStorageType = 2
 	// Resource is stored in an external storage. The reference is a URL.
 	ResourceStorageType_EXTERNAL ResourceStorageType = 3
+	// Resource is stored in a WebDAV server. The reference is the path under the base URL.
+	ResourceStorageType_WEBDAV ResourceStorageType = 4
 )
 
 // Enum value maps for ResourceStorageType.
@@ -41,12 +43,14 @@ var (
 		1: "LOCAL",
 		2: "S3",
 		3: "EXTERNAL",
+		4: "WEBDAV",
 	}
 	ResourceStorageType_value = map[string]int32{
 		"RESOURCE_STORAGE_TYPE_UNSPECIFIED": 0,
 		"LOCAL":                             1,
 		"S3":                                2,
 		"EXTERNAL":                          3,
+		"WEBDAV":                            4,
 	}
 )
 
@@ -82,7 +86,22 @@ type ResourcePayload struct {
 	// Types that are valid to be assigned to Payload:
 	//
 	//	*ResourcePayload_S3Object_
-	Payload       isResourcePayload_Payload `protobuf_oneof:"payload"`
+	Payload isResourcePayload_Payload `protobuf_oneof:"payload"`
+	// content_hash is the SHA-256 hash of the content of a content addressed resource.
+	// The content is stored once in the resource blob with the hash.
+	ContentHash string `protobuf:"bytes,2,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	// The following fields are recorded by the resource processing after upload.
+	// width and height are the dimensions of an image.
+	Width  int32 `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	// blurhash is the compact placeholder of an image. Reference: https://blurha.sh
+	Blurhash string `protobuf:"bytes,5,opt,name=blurhash,proto3" json:"blurhash,omitempty"`
+	// variants are the resized copies of an image.
+	Variants []*ResourcePayload_Variant `protobuf:"bytes,6,rep,name=variants,proto3" json:"variants,omitempty"`
+	// extracted_text is the plain text of a PDF or text file, which is searched along with the memo content.
+	ExtractedText string `protobuf:"bytes,7,opt,name=extracted_text,json=extractedText,proto3" json:"extracted_text,omitempty"`
+	// process_time is the time the resource was processed. Resources without it are processed in the background.
+	ProcessTime   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=process_time,json=processTime,proto3" json:"process_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -133,6 +152,55 @@ func (x *ResourcePayload) GetS3Object() *ResourcePayload_S3Object {
 	return nil
 }
 
+func (x *ResourcePayload) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *ResourcePayload) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ResourcePayload) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ResourcePayload) GetBlurhash() string {
+	if x != nil {
+		return x.Blurhash
+	}
+	return ""
+}
+
+func (x *ResourcePayload) GetVariants() []*ResourcePayload_Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *ResourcePayload) GetExtractedText() string {
+	if x != nil {
+		return x.ExtractedText
+	}
+	return ""
+}
+
+func (x *ResourcePayload) GetProcessTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ProcessTime
+	}
+	return nil
+}
+
 type isResourcePayload_Payload interface {
 	isResourcePayload_Payload()
 }
@@ -206,23 +274,116 @@ func (x *ResourcePayload_S3Object) GetLastPresignedTime() *timestamppb.Timestamp
 	return nil
 }
 
+type ResourcePayload_Variant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the name of the variant, e.g. small.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// type is the MIME type of the variant.
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Width         int32  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Size          int64  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourcePayload_Variant) Reset() {
+	*x = ResourcePayload_Variant{}
+	mi := &file_store_resource_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourcePayload_Variant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourcePayload_Variant) ProtoMessage() {}
+
+func (x *ResourcePayload_Variant) ProtoReflect() protoreflect.Message {
+	mi := &file_store_resource_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourcePayload_Variant.ProtoReflect.Descriptor instead.
+func (*ResourcePayload_Variant) Descriptor() ([]byte, []int) {
+	return file_store_resource_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *ResourcePayload_Variant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResourcePayload_Variant) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ResourcePayload_Variant) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ResourcePayload_Variant) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ResourcePayload_Variant) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
 var File_store_resource_proto protoreflect.FileDescriptor
 
 const file_store_resource_proto_rawDesc = "" +
 	"\n" +
-	"\x14store/resource.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1dstore/workspace_setting.proto\"\x88\x02\n" +
+	"\x14store/resource.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1dstore/workspace_setting.proto\"\x92\x05\n" +
 	"\x0fResourcePayload\x12D\n" +
-	"\ts3_object\x18\x01 \x01(\v2%.memos.store.ResourcePayload.S3ObjectH\x00R\bs3Object\x1a\xa3\x01\n" +
+	"\ts3_object\x18\x01 \x01(\v2%.memos.store.ResourcePayload.S3ObjectH\x00R\bs3Object\x12!\n" +
+	"\fcontent_hash\x18\x02 \x01(\tR\vcontentHash\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\x12\x1a\n" +
+	"\bblurhash\x18\x05 \x01(\tR\bblurhash\x12@\n" +
+	"\bvariants\x18\x06 \x03(\v2$.memos.store.ResourcePayload.VariantR\bvariants\x12%\n" +
+	"\x0eextracted_text\x18\a \x01(\tR\rextractedText\x12=\n" +
+	"\fprocess_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vprocessTime\x1a\xa3\x01\n" +
 	"\bS3Object\x129\n" +
 	"\ts3_config\x18\x01 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\tR\x03key\x12J\n" +
-	"\x13last_presigned_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x11lastPresignedTimeB\t\n" +
-	"\apayload*]\n" +
+	"\x13last_presigned_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x11lastPresignedTime\x1as\n" +
+	"\aVariant\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04sizeB\t\n" +
+	"\apayload*i\n" +
 	"\x13ResourceStorageType\x12%\n" +
 	"!RESOURCE_STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05LOCAL\x10\x01\x12\x06\n" +
 	"\x02S3\x10\x02\x12\f\n" +
-	"\bEXTERNAL\x10\x03B\x98\x01\n" +
+	"\bEXTERNAL\x10\x03\x12\n" +
+	"\n" +
+	"\x06WEBDAV\x10\x04B\x98\x01\n" +
 	"\x0fcom.memos.storeB\rResourceProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
 
 var (
@@ -238,23 +399,26 @@ func file_store_resource_proto_rawDescGZIP() []byte {
 }
 
 var file_store_resource_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_store_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_store_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_store_resource_proto_goTypes = []any{
 	(ResourceStorageType)(0),         // 0: memos.store.ResourceStorageType
 	(*ResourcePayload)(nil),          // 1: memos.store.ResourcePayload
 	(*ResourcePayload_S3Object)(nil), // 2: memos.store.ResourcePayload.S3Object
-	(*StorageS3Config)(nil),          // 3: memos.store.StorageS3Config
+	(*ResourcePayload_Variant)(nil),  // 3: memos.store.ResourcePayload.Variant
 	(*timestamppb.Timestamp)(nil),    // 4: google.protobuf.Timestamp
+	(*StorageS3Config)(nil),          // 5: memos.store.StorageS3Config
 }
 var file_store_resource_proto_depIdxs = []int32{
 	2, // 0: memos.store.ResourcePayload.s3_object:type_name -> memos.store.ResourcePayload.S3Object
-	3, // 1: memos.store.ResourcePayload.S3Object.s3_config:type_name -> memos.store.StorageS3Config
-	4, // 2: memos.store.ResourcePayload.S3Object.last_presigned_time:type_name -> google.protobuf.Timestamp
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 1: memos.store.ResourcePayload.variants:type_name -> memos.store.ResourcePayload.Variant
+	4, // 2: memos.store.ResourcePayload.process_time:type_name -> google.protobuf.Timestamp
+	5, // 3: memos.store.ResourcePayload.S3Object.s3_config:type_name -> memos.store.StorageS3Config
+	4, // 4: memos.store.ResourcePayload.S3Object.last_presigned_time:type_name -> google.protobuf.Timestamp
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_store_resource_proto_init() }
@@ -272,7 +436,7 @@ func file_store_resource_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_resource_proto_rawDesc), len(file_store_resource_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
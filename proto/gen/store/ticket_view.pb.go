@@ -0,0 +1,335 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: store/ticket_view.proto
+
+package store
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TicketView_Group int32
+
+const (
+	TicketView_GROUP_UNSPECIFIED TicketView_Group = 0
+	TicketView_STATUS            TicketView_Group = 1
+	TicketView_ASSIGNEE          TicketView_Group = 2
+	TicketView_PRIORITY          TicketView_Group = 3
+)
+
+// Enum value maps for TicketView_Group.
+var (
+	TicketView_Group_name = map[int32]string{
+		0: "GROUP_UNSPECIFIED",
+		1: "STATUS",
+		2: "ASSIGNEE",
+		3: "PRIORITY",
+	}
+	TicketView_Group_value = map[string]int32{
+		"GROUP_UNSPECIFIED": 0,
+		"STATUS":            1,
+		"ASSIGNEE":          2,
+		"PRIORITY":          3,
+	}
+)
+
+func (x TicketView_Group) Enum() *TicketView_Group {
+	p := new(TicketView_Group)
+	*p = x
+	return p
+}
+
+func (x TicketView_Group) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TicketView_Group) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_ticket_view_proto_enumTypes[0].Descriptor()
+}
+
+func (TicketView_Group) Type() protoreflect.EnumType {
+	return &file_store_ticket_view_proto_enumTypes[0]
+}
+
+func (x TicketView_Group) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TicketView_Group.Descriptor instead.
+func (TicketView_Group) EnumDescriptor() ([]byte, []int) {
+	return file_store_ticket_view_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// TicketView is a saved view of the tickets, rendered as a list or a board.
+type TicketView struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// The CEL filter of the tickets in the view. All tickets are shown when empty.
+	Filter string           `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	Sort   *TicketView_Sort `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	// The field the tickets are grouped into columns by.
+	GroupBy TicketView_Group `protobuf:"varint,5,opt,name=group_by,json=groupBy,proto3,enum=memos.store.TicketView_Group" json:"group_by,omitempty"`
+	// The keys of the columns in the order they are shown. The columns not listed follow in their default order.
+	ColumnOrder []string `protobuf:"bytes,6,rep,name=column_order,json=columnOrder,proto3" json:"column_order,omitempty"`
+	// The maximum number of tickets in a column by column key. Zero means no limit.
+	WipLimits map[string]int32 `protobuf:"bytes,7,rep,name=wip_limits,json=wipLimits,proto3" json:"wip_limits,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// The field the tickets are grouped into swimlanes by. There are no swimlanes when unspecified.
+	SwimlaneBy TicketView_Group `protobuf:"varint,8,opt,name=swimlane_by,json=swimlaneBy,proto3,enum=memos.store.TicketView_Group" json:"swimlane_by,omitempty"`
+	// The user who created the view.
+	CreatorId     int32 `protobuf:"varint,9,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketView) Reset() {
+	*x = TicketView{}
+	mi := &file_store_ticket_view_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketView) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketView) ProtoMessage() {}
+
+func (x *TicketView) ProtoReflect() protoreflect.Message {
+	mi := &file_store_ticket_view_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketView.ProtoReflect.Descriptor instead.
+func (*TicketView) Descriptor() ([]byte, []int) {
+	return file_store_ticket_view_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TicketView) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TicketView) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TicketView) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *TicketView) GetSort() *TicketView_Sort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *TicketView) GetGroupBy() TicketView_Group {
+	if x != nil {
+		return x.GroupBy
+	}
+	return TicketView_GROUP_UNSPECIFIED
+}
+
+func (x *TicketView) GetColumnOrder() []string {
+	if x != nil {
+		return x.ColumnOrder
+	}
+	return nil
+}
+
+func (x *TicketView) GetWipLimits() map[string]int32 {
+	if x != nil {
+		return x.WipLimits
+	}
+	return nil
+}
+
+func (x *TicketView) GetSwimlaneBy() TicketView_Group {
+	if x != nil {
+		return x.SwimlaneBy
+	}
+	return TicketView_GROUP_UNSPECIFIED
+}
+
+func (x *TicketView) GetCreatorId() int32 {
+	if x != nil {
+		return x.CreatorId
+	}
+	return 0
+}
+
+type TicketView_Sort struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ticket attribute the tickets are sorted by, one of the attributes of the filter or
+	// fields.{key} for a custom field.
+	Field         string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Descending    bool   `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketView_Sort) Reset() {
+	*x = TicketView_Sort{}
+	mi := &file_store_ticket_view_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketView_Sort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketView_Sort) ProtoMessage() {}
+
+func (x *TicketView_Sort) ProtoReflect() protoreflect.Message {
+	mi := &file_store_ticket_view_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketView_Sort.ProtoReflect.Descriptor instead.
+func (*TicketView_Sort) Descriptor() ([]byte, []int) {
+	return file_store_ticket_view_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *TicketView_Sort) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *TicketView_Sort) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+var File_store_ticket_view_proto protoreflect.FileDescriptor
+
+const file_store_ticket_view_proto_rawDesc = "" +
+	"\n" +
+	"\x17store/ticket_view.proto\x12\vmemos.store\"\xc3\x04\n" +
+	"\n" +
+	"TicketView\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06filter\x18\x03 \x01(\tR\x06filter\x120\n" +
+	"\x04sort\x18\x04 \x01(\v2\x1c.memos.store.TicketView.SortR\x04sort\x128\n" +
+	"\bgroup_by\x18\x05 \x01(\x0e2\x1d.memos.store.TicketView.GroupR\agroupBy\x12!\n" +
+	"\fcolumn_order\x18\x06 \x03(\tR\vcolumnOrder\x12E\n" +
+	"\n" +
+	"wip_limits\x18\a \x03(\v2&.memos.store.TicketView.WipLimitsEntryR\twipLimits\x12>\n" +
+	"\vswimlane_by\x18\b \x01(\x0e2\x1d.memos.store.TicketView.GroupR\n" +
+	"swimlaneBy\x12\x1d\n" +
+	"\n" +
+	"creator_id\x18\t \x01(\x05R\tcreatorId\x1a<\n" +
+	"\x0eWipLimitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a<\n" +
+	"\x04Sort\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x02 \x01(\bR\n" +
+	"descending\"F\n" +
+	"\x05Group\x12\x15\n" +
+	"\x11GROUP_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06STATUS\x10\x01\x12\f\n" +
+	"\bASSIGNEE\x10\x02\x12\f\n" +
+	"\bPRIORITY\x10\x03B\x9a\x01\n" +
+	"\x0fcom.memos.storeB\x0fTicketViewProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
+
+var (
+	file_store_ticket_view_proto_rawDescOnce sync.Once
+	file_store_ticket_view_proto_rawDescData []byte
+)
+
+func file_store_ticket_view_proto_rawDescGZIP() []byte {
+	file_store_ticket_view_proto_rawDescOnce.Do(func() {
+		file_store_ticket_view_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_store_ticket_view_proto_rawDesc), len(file_store_ticket_view_proto_rawDesc)))
+	})
+	return file_store_ticket_view_proto_rawDescData
+}
+
+var file_store_ticket_view_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_store_ticket_view_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_store_ticket_view_proto_goTypes = []any{
+	(TicketView_Group)(0),   // 0: memos.store.TicketView.Group
+	(*TicketView)(nil),      // 1: memos.store.TicketView
+	nil,                     // 2: memos.store.TicketView.WipLimitsEntry
+	(*TicketView_Sort)(nil), // 3: memos.store.TicketView.Sort
+}
+var file_store_ticket_view_proto_depIdxs = []int32{
+	3, // 0: memos.store.TicketView.sort:type_name -> memos.store.TicketView.Sort
+	0, // 1: memos.store.TicketView.group_by:type_name -> memos.store.TicketView.Group
+	2, // 2: memos.store.TicketView.wip_limits:type_name -> memos.store.TicketView.WipLimitsEntry
+	0, // 3: memos.store.TicketView.swimlane_by:type_name -> memos.store.TicketView.Group
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_store_ticket_view_proto_init() }
+func file_store_ticket_view_proto_init() {
+	if File_store_ticket_view_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_ticket_view_proto_rawDesc), len(file_store_ticket_view_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_store_ticket_view_proto_goTypes,
+		DependencyIndexes: file_store_ticket_view_proto_depIdxs,
+		EnumInfos:         file_store_ticket_view_proto_enumTypes,
+		MessageInfos:      file_store_ticket_view_proto_msgTypes,
+	}.Build()
+	File_store_ticket_view_proto = out.File
+	file_store_ticket_view_proto_goTypes = nil
+	file_store_ticket_view_proto_depIdxs = nil
+}
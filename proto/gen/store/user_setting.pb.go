@@ -9,6 +9,7 @@ package store
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -35,6 +36,14 @@ const (
 	UserSettingKey_MEMO_VISIBILITY UserSettingKey = 4
 	// The shortcuts of the user.
 	UserSettingKey_SHORTCUTS UserSettingKey = 5
+	// The TOTP secret of the user.
+	UserSettingKey_TOTP UserSettingKey = 6
+	// The hashed recovery codes of the user.
+	UserSettingKey_RECOVERY_CODES UserSettingKey = 7
+	// The signed in sessions of the user.
+	UserSettingKey_SESSIONS UserSettingKey = 8
+	// The saved ticket views of the user.
+	UserSettingKey_TICKET_VIEWS UserSettingKey = 9
 )
 
 // Enum value maps for UserSettingKey.
@@ -46,6 +55,10 @@ var (
 		3: "APPEARANCE",
 		4: "MEMO_VISIBILITY",
 		5: "SHORTCUTS",
+		6: "TOTP",
+		7: "RECOVERY_CODES",
+		8: "SESSIONS",
+		9: "TICKET_VIEWS",
 	}
 	UserSettingKey_value = map[string]int32{
 		"USER_SETTING_KEY_UNSPECIFIED": 0,
@@ -54,6 +67,10 @@ var (
 		"APPEARANCE":                   3,
 		"MEMO_VISIBILITY":              4,
 		"SHORTCUTS":                    5,
+		"TOTP":                         6,
+		"RECOVERY_CODES":               7,
+		"SESSIONS":                     8,
+		"TICKET_VIEWS":                 9,
 	}
 )
 
@@ -95,6 +112,10 @@ type UserSetting struct {
 	//	*UserSetting_Appearance
 	//	*UserSetting_MemoVisibility
 	//	*UserSetting_Shortcuts
+	//	*UserSetting_Totp
+	//	*UserSetting_RecoveryCodes
+	//	*UserSetting_Sessions
+	//	*UserSetting_TicketViews
 	Value         isUserSetting_Value `protobuf_oneof:"value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -196,6 +217,42 @@ func (x *UserSetting) GetShortcuts() *ShortcutsUserSetting {
 	return nil
 }
 
+func (x *UserSetting) GetTotp() *TOTPUserSetting {
+	if x != nil {
+		if x, ok := x.Value.(*UserSetting_Totp); ok {
+			return x.Totp
+		}
+	}
+	return nil
+}
+
+func (x *UserSetting) GetRecoveryCodes() *RecoveryCodesUserSetting {
+	if x != nil {
+		if x, ok := x.Value.(*UserSetting_RecoveryCodes); ok {
+			return x.RecoveryCodes
+		}
+	}
+	return nil
+}
+
+func (x *UserSetting) GetSessions() *SessionsUserSetting {
+	if x != nil {
+		if x, ok := x.Value.(*UserSetting_Sessions); ok {
+			return x.Sessions
+		}
+	}
+	return nil
+}
+
+func (x *UserSetting) GetTicketViews() *TicketViewsUserSetting {
+	if x != nil {
+		if x, ok := x.Value.(*UserSetting_TicketViews); ok {
+			return x.TicketViews
+		}
+	}
+	return nil
+}
+
 type isUserSetting_Value interface {
 	isUserSetting_Value()
 }
@@ -220,6 +277,22 @@ type UserSetting_Shortcuts struct {
 	Shortcuts *ShortcutsUserSetting `protobuf:"bytes,7,opt,name=shortcuts,proto3,oneof"`
 }
 
+type UserSetting_Totp struct {
+	Totp *TOTPUserSetting `protobuf:"bytes,8,opt,name=totp,proto3,oneof"`
+}
+
+type UserSetting_RecoveryCodes struct {
+	RecoveryCodes *RecoveryCodesUserSetting `protobuf:"bytes,9,opt,name=recovery_codes,json=recoveryCodes,proto3,oneof"`
+}
+
+type UserSetting_Sessions struct {
+	Sessions *SessionsUserSetting `protobuf:"bytes,10,opt,name=sessions,proto3,oneof"`
+}
+
+type UserSetting_TicketViews struct {
+	TicketViews *TicketViewsUserSetting `protobuf:"bytes,11,opt,name=ticket_views,json=ticketViews,proto3,oneof"`
+}
+
 func (*UserSetting_AccessTokens) isUserSetting_Value() {}
 
 func (*UserSetting_Locale) isUserSetting_Value() {}
@@ -230,6 +303,14 @@ func (*UserSetting_MemoVisibility) isUserSetting_Value() {}
 
 func (*UserSetting_Shortcuts) isUserSetting_Value() {}
 
+func (*UserSetting_Totp) isUserSetting_Value() {}
+
+func (*UserSetting_RecoveryCodes) isUserSetting_Value() {}
+
+func (*UserSetting_Sessions) isUserSetting_Value() {}
+
+func (*UserSetting_TicketViews) isUserSetting_Value() {}
+
 type AccessTokensUserSetting struct {
 	state         protoimpl.MessageState                 `protogen:"open.v1"`
 	AccessTokens  []*AccessTokensUserSetting_AccessToken `protobuf:"bytes,1,rep,name=access_tokens,json=accessTokens,proto3" json:"access_tokens,omitempty"`
@@ -318,20 +399,226 @@ func (x *ShortcutsUserSetting) GetShortcuts() []*ShortcutsUserSetting_Shortcut {
 	return nil
 }
 
+type TOTPUserSetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The base32 encoded secret.
+	Secret string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Whether the secret has been verified and two-factor authentication is enabled.
+	Enabled bool `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// The last time step a code was accepted for, codes up to it are rejected.
+	LastUsedStep  int64 `protobuf:"varint,3,opt,name=last_used_step,json=lastUsedStep,proto3" json:"last_used_step,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TOTPUserSetting) Reset() {
+	*x = TOTPUserSetting{}
+	mi := &file_store_user_setting_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TOTPUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TOTPUserSetting) ProtoMessage() {}
+
+func (x *TOTPUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TOTPUserSetting.ProtoReflect.Descriptor instead.
+func (*TOTPUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TOTPUserSetting) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *TOTPUserSetting) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *TOTPUserSetting) GetLastUsedStep() int64 {
+	if x != nil {
+		return x.LastUsedStep
+	}
+	return 0
+}
+
+type RecoveryCodesUserSetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The SHA-256 hashes of the unused recovery codes.
+	CodeHashes    []string `protobuf:"bytes,1,rep,name=code_hashes,json=codeHashes,proto3" json:"code_hashes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoveryCodesUserSetting) Reset() {
+	*x = RecoveryCodesUserSetting{}
+	mi := &file_store_user_setting_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryCodesUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryCodesUserSetting) ProtoMessage() {}
+
+func (x *RecoveryCodesUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryCodesUserSetting.ProtoReflect.Descriptor instead.
+func (*RecoveryCodesUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RecoveryCodesUserSetting) GetCodeHashes() []string {
+	if x != nil {
+		return x.CodeHashes
+	}
+	return nil
+}
+
+type SessionsUserSetting struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Sessions      []*SessionsUserSetting_Session `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionsUserSetting) Reset() {
+	*x = SessionsUserSetting{}
+	mi := &file_store_user_setting_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionsUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionsUserSetting) ProtoMessage() {}
+
+func (x *SessionsUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionsUserSetting.ProtoReflect.Descriptor instead.
+func (*SessionsUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SessionsUserSetting) GetSessions() []*SessionsUserSetting_Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type TicketViewsUserSetting struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Views         []*TicketView          `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketViewsUserSetting) Reset() {
+	*x = TicketViewsUserSetting{}
+	mi := &file_store_user_setting_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketViewsUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketViewsUserSetting) ProtoMessage() {}
+
+func (x *TicketViewsUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketViewsUserSetting.ProtoReflect.Descriptor instead.
+func (*TicketViewsUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TicketViewsUserSetting) GetViews() []*TicketView {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
 type AccessTokensUserSetting_AccessToken struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The access token is a JWT token.
 	// Including expiration time, issuer, etc.
+	// Deprecated: only set for tokens stored before token_hash was introduced.
 	AccessToken string `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
 	// A description for the access token.
-	Description   string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// The hex encoded SHA-256 hash of the access token.
+	TokenHash string `protobuf:"bytes,3,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash,omitempty"`
+	// The scopes granted to the access token. Empty means full access.
+	Scopes     []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// The expire time of the access token. Unset means never expire.
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	LastUsedTime  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used_time,json=lastUsedTime,proto3" json:"last_used_time,omitempty"`
+	LastUsedIp    string                 `protobuf:"bytes,8,opt,name=last_used_ip,json=lastUsedIp,proto3" json:"last_used_ip,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AccessTokensUserSetting_AccessToken) Reset() {
 	*x = AccessTokensUserSetting_AccessToken{}
-	mi := &file_store_user_setting_proto_msgTypes[3]
+	mi := &file_store_user_setting_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -343,7 +630,7 @@ func (x *AccessTokensUserSetting_AccessToken) String() string {
 func (*AccessTokensUserSetting_AccessToken) ProtoMessage() {}
 
 func (x *AccessTokensUserSetting_AccessToken) ProtoReflect() protoreflect.Message {
-	mi := &file_store_user_setting_proto_msgTypes[3]
+	mi := &file_store_user_setting_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,6 +660,48 @@ func (x *AccessTokensUserSetting_AccessToken) GetDescription() string {
 	return ""
 }
 
+func (x *AccessTokensUserSetting_AccessToken) GetTokenHash() string {
+	if x != nil {
+		return x.TokenHash
+	}
+	return ""
+}
+
+func (x *AccessTokensUserSetting_AccessToken) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *AccessTokensUserSetting_AccessToken) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *AccessTokensUserSetting_AccessToken) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *AccessTokensUserSetting_AccessToken) GetLastUsedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedTime
+	}
+	return nil
+}
+
+func (x *AccessTokensUserSetting_AccessToken) GetLastUsedIp() string {
+	if x != nil {
+		return x.LastUsedIp
+	}
+	return ""
+}
+
 type ShortcutsUserSetting_Shortcut struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -384,7 +713,7 @@ type ShortcutsUserSetting_Shortcut struct {
 
 func (x *ShortcutsUserSetting_Shortcut) Reset() {
 	*x = ShortcutsUserSetting_Shortcut{}
-	mi := &file_store_user_setting_proto_msgTypes[4]
+	mi := &file_store_user_setting_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -396,7 +725,7 @@ func (x *ShortcutsUserSetting_Shortcut) String() string {
 func (*ShortcutsUserSetting_Shortcut) ProtoMessage() {}
 
 func (x *ShortcutsUserSetting_Shortcut) ProtoReflect() protoreflect.Message {
-	mi := &file_store_user_setting_proto_msgTypes[4]
+	mi := &file_store_user_setting_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,11 +762,132 @@ func (x *ShortcutsUserSetting_Shortcut) GetFilter() string {
 	return ""
 }
 
+type SessionsUserSetting_Session struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// The hex encoded SHA-256 hash of the current refresh token.
+	RefreshTokenHash string `protobuf:"bytes,2,opt,name=refresh_token_hash,json=refreshTokenHash,proto3" json:"refresh_token_hash,omitempty"`
+	// The hash of the refresh token replaced by the last rotation, used to detect replayed refresh tokens.
+	PreviousRefreshTokenHash string                 `protobuf:"bytes,3,opt,name=previous_refresh_token_hash,json=previousRefreshTokenHash,proto3" json:"previous_refresh_token_hash,omitempty"`
+	CreateTime               *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	LastSeenTime             *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen_time,json=lastSeenTime,proto3" json:"last_seen_time,omitempty"`
+	// The session expires unless it is refreshed before this time.
+	ExpireTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// The time of the last refresh token rotation.
+	RefreshTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=refresh_time,json=refreshTime,proto3" json:"refresh_time,omitempty"`
+	UserAgent   string                 `protobuf:"bytes,8,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Ip          string                 `protobuf:"bytes,9,opt,name=ip,proto3" json:"ip,omitempty"`
+	// Whether the session was created with "never expire", so it outlives the browser session.
+	Persistent    bool `protobuf:"varint,10,opt,name=persistent,proto3" json:"persistent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionsUserSetting_Session) Reset() {
+	*x = SessionsUserSetting_Session{}
+	mi := &file_store_user_setting_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionsUserSetting_Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionsUserSetting_Session) ProtoMessage() {}
+
+func (x *SessionsUserSetting_Session) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionsUserSetting_Session.ProtoReflect.Descriptor instead.
+func (*SessionsUserSetting_Session) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *SessionsUserSetting_Session) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionsUserSetting_Session) GetRefreshTokenHash() string {
+	if x != nil {
+		return x.RefreshTokenHash
+	}
+	return ""
+}
+
+func (x *SessionsUserSetting_Session) GetPreviousRefreshTokenHash() string {
+	if x != nil {
+		return x.PreviousRefreshTokenHash
+	}
+	return ""
+}
+
+func (x *SessionsUserSetting_Session) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *SessionsUserSetting_Session) GetLastSeenTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenTime
+	}
+	return nil
+}
+
+func (x *SessionsUserSetting_Session) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *SessionsUserSetting_Session) GetRefreshTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshTime
+	}
+	return nil
+}
+
+func (x *SessionsUserSetting_Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *SessionsUserSetting_Session) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *SessionsUserSetting_Session) GetPersistent() bool {
+	if x != nil {
+		return x.Persistent
+	}
+	return false
+}
+
 var File_store_user_setting_proto protoreflect.FileDescriptor
 
 const file_store_user_setting_proto_rawDesc = "" +
 	"\n" +
-	"\x18store/user_setting.proto\x12\vmemos.store\"\xd5\x02\n" +
+	"\x18store/user_setting.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17store/ticket_view.proto\"\xe3\x04\n" +
 	"\vUserSetting\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12-\n" +
 	"\x03key\x18\x02 \x01(\x0e2\x1b.memos.store.UserSettingKeyR\x03key\x12K\n" +
@@ -447,19 +897,63 @@ const file_store_user_setting_proto_rawDesc = "" +
 	"appearance\x18\x05 \x01(\tH\x00R\n" +
 	"appearance\x12)\n" +
 	"\x0fmemo_visibility\x18\x06 \x01(\tH\x00R\x0ememoVisibility\x12A\n" +
-	"\tshortcuts\x18\a \x01(\v2!.memos.store.ShortcutsUserSettingH\x00R\tshortcutsB\a\n" +
-	"\x05value\"\xc4\x01\n" +
+	"\tshortcuts\x18\a \x01(\v2!.memos.store.ShortcutsUserSettingH\x00R\tshortcuts\x122\n" +
+	"\x04totp\x18\b \x01(\v2\x1c.memos.store.TOTPUserSettingH\x00R\x04totp\x12N\n" +
+	"\x0erecovery_codes\x18\t \x01(\v2%.memos.store.RecoveryCodesUserSettingH\x00R\rrecoveryCodes\x12>\n" +
+	"\bsessions\x18\n" +
+	" \x01(\v2 .memos.store.SessionsUserSettingH\x00R\bsessions\x12H\n" +
+	"\fticket_views\x18\v \x01(\v2#.memos.store.TicketViewsUserSettingH\x00R\vticketViewsB\a\n" +
+	"\x05value\"\xda\x03\n" +
 	"\x17AccessTokensUserSetting\x12U\n" +
-	"\raccess_tokens\x18\x01 \x03(\v20.memos.store.AccessTokensUserSetting.AccessTokenR\faccessTokens\x1aR\n" +
+	"\raccess_tokens\x18\x01 \x03(\v20.memos.store.AccessTokensUserSetting.AccessTokenR\faccessTokens\x1a\xe7\x02\n" +
 	"\vAccessToken\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\"\xaa\x01\n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"token_hash\x18\x03 \x01(\tR\ttokenHash\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x12;\n" +
+	"\vcreate_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vexpire_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\x12@\n" +
+	"\x0elast_used_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\flastUsedTime\x12 \n" +
+	"\flast_used_ip\x18\b \x01(\tR\n" +
+	"lastUsedIp\"\xaa\x01\n" +
 	"\x14ShortcutsUserSetting\x12H\n" +
 	"\tshortcuts\x18\x01 \x03(\v2*.memos.store.ShortcutsUserSetting.ShortcutR\tshortcuts\x1aH\n" +
 	"\bShortcut\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
-	"\x06filter\x18\x03 \x01(\tR\x06filter*\x85\x01\n" +
+	"\x06filter\x18\x03 \x01(\tR\x06filter\"i\n" +
+	"\x0fTOTPUserSetting\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12$\n" +
+	"\x0elast_used_step\x18\x03 \x01(\x03R\flastUsedStep\";\n" +
+	"\x18RecoveryCodesUserSetting\x12\x1f\n" +
+	"\vcode_hashes\x18\x01 \x03(\tR\n" +
+	"codeHashes\"\xbd\x04\n" +
+	"\x13SessionsUserSetting\x12D\n" +
+	"\bsessions\x18\x01 \x03(\v2(.memos.store.SessionsUserSetting.SessionR\bsessions\x1a\xdf\x03\n" +
+	"\aSession\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12,\n" +
+	"\x12refresh_token_hash\x18\x02 \x01(\tR\x10refreshTokenHash\x12=\n" +
+	"\x1bprevious_refresh_token_hash\x18\x03 \x01(\tR\x18previousRefreshTokenHash\x12;\n" +
+	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12@\n" +
+	"\x0elast_seen_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\flastSeenTime\x12;\n" +
+	"\vexpire_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\x12=\n" +
+	"\frefresh_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vrefreshTime\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\b \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02ip\x18\t \x01(\tR\x02ip\x12\x1e\n" +
+	"\n" +
+	"persistent\x18\n" +
+	" \x01(\bR\n" +
+	"persistent\"G\n" +
+	"\x16TicketViewsUserSetting\x12-\n" +
+	"\x05views\x18\x01 \x03(\v2\x17.memos.store.TicketViewR\x05views*\xc3\x01\n" +
 	"\x0eUserSettingKey\x12 \n" +
 	"\x1cUSER_SETTING_KEY_UNSPECIFIED\x10\x00\x12\x11\n" +
 	"\rACCESS_TOKENS\x10\x01\x12\n" +
@@ -468,7 +962,11 @@ const file_store_user_setting_proto_rawDesc = "" +
 	"\n" +
 	"APPEARANCE\x10\x03\x12\x13\n" +
 	"\x0fMEMO_VISIBILITY\x10\x04\x12\r\n" +
-	"\tSHORTCUTS\x10\x05B\x9b\x01\n" +
+	"\tSHORTCUTS\x10\x05\x12\b\n" +
+	"\x04TOTP\x10\x06\x12\x12\n" +
+	"\x0eRECOVERY_CODES\x10\a\x12\f\n" +
+	"\bSESSIONS\x10\b\x12\x10\n" +
+	"\fTICKET_VIEWS\x10\tB\x9b\x01\n" +
 	"\x0fcom.memos.storeB\x10UserSettingProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
 
 var (
@@ -484,26 +982,46 @@ func file_store_user_setting_proto_rawDescGZIP() []byte {
 }
 
 var file_store_user_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_store_user_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_store_user_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_store_user_setting_proto_goTypes = []any{
 	(UserSettingKey)(0),                         // 0: memos.store.UserSettingKey
 	(*UserSetting)(nil),                         // 1: memos.store.UserSetting
 	(*AccessTokensUserSetting)(nil),             // 2: memos.store.AccessTokensUserSetting
 	(*ShortcutsUserSetting)(nil),                // 3: memos.store.ShortcutsUserSetting
-	(*AccessTokensUserSetting_AccessToken)(nil), // 4: memos.store.AccessTokensUserSetting.AccessToken
-	(*ShortcutsUserSetting_Shortcut)(nil),       // 5: memos.store.ShortcutsUserSetting.Shortcut
+	(*TOTPUserSetting)(nil),                     // 4: memos.store.TOTPUserSetting
+	(*RecoveryCodesUserSetting)(nil),            // 5: memos.store.RecoveryCodesUserSetting
+	(*SessionsUserSetting)(nil),                 // 6: memos.store.SessionsUserSetting
+	(*TicketViewsUserSetting)(nil),              // 7: memos.store.TicketViewsUserSetting
+	(*AccessTokensUserSetting_AccessToken)(nil), // 8: memos.store.AccessTokensUserSetting.AccessToken
+	(*ShortcutsUserSetting_Shortcut)(nil),       // 9: memos.store.ShortcutsUserSetting.Shortcut
+	(*SessionsUserSetting_Session)(nil),         // 10: memos.store.SessionsUserSetting.Session
+	(*TicketView)(nil),                          // 11: memos.store.TicketView
+	(*timestamppb.Timestamp)(nil),               // 12: google.protobuf.Timestamp
 }
 var file_store_user_setting_proto_depIdxs = []int32{
-	0, // 0: memos.store.UserSetting.key:type_name -> memos.store.UserSettingKey
-	2, // 1: memos.store.UserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting
-	3, // 2: memos.store.UserSetting.shortcuts:type_name -> memos.store.ShortcutsUserSetting
-	4, // 3: memos.store.AccessTokensUserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting.AccessToken
-	5, // 4: memos.store.ShortcutsUserSetting.shortcuts:type_name -> memos.store.ShortcutsUserSetting.Shortcut
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	0,  // 0: memos.store.UserSetting.key:type_name -> memos.store.UserSettingKey
+	2,  // 1: memos.store.UserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting
+	3,  // 2: memos.store.UserSetting.shortcuts:type_name -> memos.store.ShortcutsUserSetting
+	4,  // 3: memos.store.UserSetting.totp:type_name -> memos.store.TOTPUserSetting
+	5,  // 4: memos.store.UserSetting.recovery_codes:type_name -> memos.store.RecoveryCodesUserSetting
+	6,  // 5: memos.store.UserSetting.sessions:type_name -> memos.store.SessionsUserSetting
+	7,  // 6: memos.store.UserSetting.ticket_views:type_name -> memos.store.TicketViewsUserSetting
+	8,  // 7: memos.store.AccessTokensUserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting.AccessToken
+	9,  // 8: memos.store.ShortcutsUserSetting.shortcuts:type_name -> memos.store.ShortcutsUserSetting.Shortcut
+	10, // 9: memos.store.SessionsUserSetting.sessions:type_name -> memos.store.SessionsUserSetting.Session
+	11, // 10: memos.store.TicketViewsUserSetting.views:type_name -> memos.store.TicketView
+	12, // 11: memos.store.AccessTokensUserSetting.AccessToken.create_time:type_name -> google.protobuf.Timestamp
+	12, // 12: memos.store.AccessTokensUserSetting.AccessToken.expire_time:type_name -> google.protobuf.Timestamp
+	12, // 13: memos.store.AccessTokensUserSetting.AccessToken.last_used_time:type_name -> google.protobuf.Timestamp
+	12, // 14: memos.store.SessionsUserSetting.Session.create_time:type_name -> google.protobuf.Timestamp
+	12, // 15: memos.store.SessionsUserSetting.Session.last_seen_time:type_name -> google.protobuf.Timestamp
+	12, // 16: memos.store.SessionsUserSetting.Session.expire_time:type_name -> google.protobuf.Timestamp
+	12, // 17: memos.store.SessionsUserSetting.Session.refresh_time:type_name -> google.protobuf.Timestamp
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_store_user_setting_proto_init() }
@@ -511,12 +1029,17 @@ func file_store_user_setting_proto_init() {
 	if File_store_user_setting_proto != nil {
 		return
 	}
+	file_store_ticket_view_proto_init()
 	file_store_user_setting_proto_msgTypes[0].OneofWrappers = []any{
 		(*UserSetting_AccessTokens)(nil),
 		(*UserSetting_Locale)(nil),
 		(*UserSetting_Appearance)(nil),
 		(*UserSetting_MemoVisibility)(nil),
 		(*UserSetting_Shortcuts)(nil),
+		(*UserSetting_Totp)(nil),
+		(*UserSetting_RecoveryCodes)(nil),
+		(*UserSetting_Sessions)(nil),
+		(*UserSetting_TicketViews)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -524,7 +1047,7 @@ func file_store_user_setting_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_user_setting_proto_rawDesc), len(file_store_user_setting_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   5,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -9,6 +9,7 @@ package store
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -33,6 +34,14 @@ const (
 	WorkspaceSettingKey_STORAGE WorkspaceSettingKey = 3
 	// MEMO_RELATED is the key for memo related settings.
 	WorkspaceSettingKey_MEMO_RELATED WorkspaceSettingKey = 4
+	// RATE_LIMIT is the key for rate limit settings.
+	WorkspaceSettingKey_RATE_LIMIT WorkspaceSettingKey = 5
+	// STORAGE_MIGRATION is the key for the state of the resource storage migration.
+	WorkspaceSettingKey_STORAGE_MIGRATION WorkspaceSettingKey = 6
+	// SHARED_TICKET_VIEWS is the key for the ticket views shared with the workspace.
+	WorkspaceSettingKey_SHARED_TICKET_VIEWS WorkspaceSettingKey = 7
+	// TICKET_FIELDS is the key for the custom fields of the tickets.
+	WorkspaceSettingKey_TICKET_FIELDS WorkspaceSettingKey = 8
 )
 
 // Enum value maps for WorkspaceSettingKey.
@@ -43,6 +52,10 @@ var (
 		2: "GENERAL",
 		3: "STORAGE",
 		4: "MEMO_RELATED",
+		5: "RATE_LIMIT",
+		6: "STORAGE_MIGRATION",
+		7: "SHARED_TICKET_VIEWS",
+		8: "TICKET_FIELDS",
 	}
 	WorkspaceSettingKey_value = map[string]int32{
 		"WORKSPACE_SETTING_KEY_UNSPECIFIED": 0,
@@ -50,6 +63,10 @@ var (
 		"GENERAL":                           2,
 		"STORAGE":                           3,
 		"MEMO_RELATED":                      4,
+		"RATE_LIMIT":                        5,
+		"STORAGE_MIGRATION":                 6,
+		"SHARED_TICKET_VIEWS":               7,
+		"TICKET_FIELDS":                     8,
 	}
 )
 
@@ -90,6 +107,8 @@ const (
 	WorkspaceStorageSetting_LOCAL WorkspaceStorageSetting_StorageType = 2
 	// STORAGE_TYPE_S3 is the S3 storage type.
 	WorkspaceStorageSetting_S3 WorkspaceStorageSetting_StorageType = 3
+	// STORAGE_TYPE_WEBDAV is the WebDAV storage type.
+	WorkspaceStorageSetting_WEBDAV WorkspaceStorageSetting_StorageType = 4
 )
 
 // Enum value maps for WorkspaceStorageSetting_StorageType.
@@ -99,12 +118,14 @@ var (
 		1: "DATABASE",
 		2: "LOCAL",
 		3: "S3",
+		4: "WEBDAV",
 	}
 	WorkspaceStorageSetting_StorageType_value = map[string]int32{
 		"STORAGE_TYPE_UNSPECIFIED": 0,
 		"DATABASE":                 1,
 		"LOCAL":                    2,
 		"S3":                       3,
+		"WEBDAV":                   4,
 	}
 )
 
@@ -135,6 +156,117 @@ func (WorkspaceStorageSetting_StorageType) EnumDescriptor() ([]byte, []int) {
 	return file_store_workspace_setting_proto_rawDescGZIP(), []int{4, 0}
 }
 
+type WorkspaceStorageMigrationSetting_State int32
+
+const (
+	WorkspaceStorageMigrationSetting_STATE_UNSPECIFIED WorkspaceStorageMigrationSetting_State = 0
+	// RUNNING is set while resources are being migrated, and resumed on startup.
+	WorkspaceStorageMigrationSetting_RUNNING WorkspaceStorageMigrationSetting_State = 1
+	// COMPLETED is set once every resource has been processed.
+	WorkspaceStorageMigrationSetting_COMPLETED WorkspaceStorageMigrationSetting_State = 2
+)
+
+// Enum value maps for WorkspaceStorageMigrationSetting_State.
+var (
+	WorkspaceStorageMigrationSetting_State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "RUNNING",
+		2: "COMPLETED",
+	}
+	WorkspaceStorageMigrationSetting_State_value = map[string]int32{
+		"STATE_UNSPECIFIED": 0,
+		"RUNNING":           1,
+		"COMPLETED":         2,
+	}
+)
+
+func (x WorkspaceStorageMigrationSetting_State) Enum() *WorkspaceStorageMigrationSetting_State {
+	p := new(WorkspaceStorageMigrationSetting_State)
+	*p = x
+	return p
+}
+
+func (x WorkspaceStorageMigrationSetting_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceStorageMigrationSetting_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_workspace_setting_proto_enumTypes[2].Descriptor()
+}
+
+func (WorkspaceStorageMigrationSetting_State) Type() protoreflect.EnumType {
+	return &file_store_workspace_setting_proto_enumTypes[2]
+}
+
+func (x WorkspaceStorageMigrationSetting_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceStorageMigrationSetting_State.Descriptor instead.
+func (WorkspaceStorageMigrationSetting_State) EnumDescriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{9, 0}
+}
+
+type TicketField_Type int32
+
+const (
+	TicketField_TYPE_UNSPECIFIED TicketField_Type = 0
+	TicketField_TEXT             TicketField_Type = 1
+	TicketField_NUMBER           TicketField_Type = 2
+	TicketField_ENUM             TicketField_Type = 3
+	// The id of a user.
+	TicketField_USER TicketField_Type = 4
+	// A date in the YYYY-MM-DD format.
+	TicketField_DATE TicketField_Type = 5
+)
+
+// Enum value maps for TicketField_Type.
+var (
+	TicketField_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TEXT",
+		2: "NUMBER",
+		3: "ENUM",
+		4: "USER",
+		5: "DATE",
+	}
+	TicketField_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TEXT":             1,
+		"NUMBER":           2,
+		"ENUM":             3,
+		"USER":             4,
+		"DATE":             5,
+	}
+)
+
+func (x TicketField_Type) Enum() *TicketField_Type {
+	p := new(TicketField_Type)
+	*p = x
+	return p
+}
+
+func (x TicketField_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TicketField_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_workspace_setting_proto_enumTypes[3].Descriptor()
+}
+
+func (TicketField_Type) Type() protoreflect.EnumType {
+	return &file_store_workspace_setting_proto_enumTypes[3]
+}
+
+func (x TicketField_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TicketField_Type.Descriptor instead.
+func (TicketField_Type) EnumDescriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{12, 0}
+}
+
 type WorkspaceSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Key   WorkspaceSettingKey    `protobuf:"varint,1,opt,name=key,proto3,enum=memos.store.WorkspaceSettingKey" json:"key,omitempty"`
@@ -144,6 +276,10 @@ type WorkspaceSetting struct {
 	//	*WorkspaceSetting_GeneralSetting
 	//	*WorkspaceSetting_StorageSetting
 	//	*WorkspaceSetting_MemoRelatedSetting
+	//	*WorkspaceSetting_RateLimitSetting
+	//	*WorkspaceSetting_StorageMigrationSetting
+	//	*WorkspaceSetting_SharedTicketViewsSetting
+	//	*WorkspaceSetting_TicketFieldsSetting
 	Value         isWorkspaceSetting_Value `protobuf_oneof:"value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -229,6 +365,42 @@ func (x *WorkspaceSetting) GetMemoRelatedSetting() *WorkspaceMemoRelatedSetting
 	return nil
 }
 
+func (x *WorkspaceSetting) GetRateLimitSetting() *WorkspaceRateLimitSetting {
+	if x != nil {
+		if x, ok := x.Value.(*WorkspaceSetting_RateLimitSetting); ok {
+			return x.RateLimitSetting
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting) GetStorageMigrationSetting() *WorkspaceStorageMigrationSetting {
+	if x != nil {
+		if x, ok := x.Value.(*WorkspaceSetting_StorageMigrationSetting); ok {
+			return x.StorageMigrationSetting
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting) GetSharedTicketViewsSetting() *WorkspaceSharedTicketViewsSetting {
+	if x != nil {
+		if x, ok := x.Value.(*WorkspaceSetting_SharedTicketViewsSetting); ok {
+			return x.SharedTicketViewsSetting
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting) GetTicketFieldsSetting() *WorkspaceTicketFieldsSetting {
+	if x != nil {
+		if x, ok := x.Value.(*WorkspaceSetting_TicketFieldsSetting); ok {
+			return x.TicketFieldsSetting
+		}
+	}
+	return nil
+}
+
 type isWorkspaceSetting_Value interface {
 	isWorkspaceSetting_Value()
 }
@@ -249,6 +421,22 @@ type WorkspaceSetting_MemoRelatedSetting struct {
 	MemoRelatedSetting *WorkspaceMemoRelatedSetting `protobuf:"bytes,5,opt,name=memo_related_setting,json=memoRelatedSetting,proto3,oneof"`
 }
 
+type WorkspaceSetting_RateLimitSetting struct {
+	RateLimitSetting *WorkspaceRateLimitSetting `protobuf:"bytes,6,opt,name=rate_limit_setting,json=rateLimitSetting,proto3,oneof"`
+}
+
+type WorkspaceSetting_StorageMigrationSetting struct {
+	StorageMigrationSetting *WorkspaceStorageMigrationSetting `protobuf:"bytes,7,opt,name=storage_migration_setting,json=storageMigrationSetting,proto3,oneof"`
+}
+
+type WorkspaceSetting_SharedTicketViewsSetting struct {
+	SharedTicketViewsSetting *WorkspaceSharedTicketViewsSetting `protobuf:"bytes,8,opt,name=shared_ticket_views_setting,json=sharedTicketViewsSetting,proto3,oneof"`
+}
+
+type WorkspaceSetting_TicketFieldsSetting struct {
+	TicketFieldsSetting *WorkspaceTicketFieldsSetting `protobuf:"bytes,9,opt,name=ticket_fields_setting,json=ticketFieldsSetting,proto3,oneof"`
+}
+
 func (*WorkspaceSetting_BasicSetting) isWorkspaceSetting_Value() {}
 
 func (*WorkspaceSetting_GeneralSetting) isWorkspaceSetting_Value() {}
@@ -257,6 +445,14 @@ func (*WorkspaceSetting_StorageSetting) isWorkspaceSetting_Value() {}
 
 func (*WorkspaceSetting_MemoRelatedSetting) isWorkspaceSetting_Value() {}
 
+func (*WorkspaceSetting_RateLimitSetting) isWorkspaceSetting_Value() {}
+
+func (*WorkspaceSetting_StorageMigrationSetting) isWorkspaceSetting_Value() {}
+
+func (*WorkspaceSetting_SharedTicketViewsSetting) isWorkspaceSetting_Value() {}
+
+func (*WorkspaceSetting_TicketFieldsSetting) isWorkspaceSetting_Value() {}
+
 type WorkspaceBasicSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The secret key for workspace. Mainly used for session management.
@@ -331,8 +527,10 @@ type WorkspaceGeneralSetting struct {
 	DisallowChangeUsername bool `protobuf:"varint,7,opt,name=disallow_change_username,json=disallowChangeUsername,proto3" json:"disallow_change_username,omitempty"`
 	// disallow_change_nickname disallows changing nickname.
 	DisallowChangeNickname bool `protobuf:"varint,8,opt,name=disallow_change_nickname,json=disallowChangeNickname,proto3" json:"disallow_change_nickname,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	// require_two_factor_for_admins requires hosts and admins to enable two-factor authentication.
+	RequireTwoFactorForAdmins bool `protobuf:"varint,9,opt,name=require_two_factor_for_admins,json=requireTwoFactorForAdmins,proto3" json:"require_two_factor_for_admins,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
 }
 
 func (x *WorkspaceGeneralSetting) Reset() {
@@ -421,6 +619,13 @@ func (x *WorkspaceGeneralSetting) GetDisallowChangeNickname() bool {
 	return false
 }
 
+func (x *WorkspaceGeneralSetting) GetRequireTwoFactorForAdmins() bool {
+	if x != nil {
+		return x.RequireTwoFactorForAdmins
+	}
+	return false
+}
+
 type WorkspaceCustomProfile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
@@ -507,9 +712,16 @@ type WorkspaceStorageSetting struct {
 	// The max upload size in megabytes.
 	UploadSizeLimitMb int64 `protobuf:"varint,3,opt,name=upload_size_limit_mb,json=uploadSizeLimitMb,proto3" json:"upload_size_limit_mb,omitempty"`
 	// The S3 config.
-	S3Config      *StorageS3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	S3Config *StorageS3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
+	// content_addressed stores new resources by the SHA-256 hash of their content,
+	// so identical files are stored once.
+	ContentAddressed bool `protobuf:"varint,5,opt,name=content_addressed,json=contentAddressed,proto3" json:"content_addressed,omitempty"`
+	// The WebDAV config.
+	WebdavConfig *StorageWebDAVConfig `protobuf:"bytes,6,opt,name=webdav_config,json=webdavConfig,proto3" json:"webdav_config,omitempty"`
+	// keep_location_metadata keeps the EXIF GPS data of uploaded images, which is stripped by default.
+	KeepLocationMetadata bool `protobuf:"varint,7,opt,name=keep_location_metadata,json=keepLocationMetadata,proto3" json:"keep_location_metadata,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *WorkspaceStorageSetting) Reset() {
@@ -570,6 +782,27 @@ func (x *WorkspaceStorageSetting) GetS3Config() *StorageS3Config {
 	return nil
 }
 
+func (x *WorkspaceStorageSetting) GetContentAddressed() bool {
+	if x != nil {
+		return x.ContentAddressed
+	}
+	return false
+}
+
+func (x *WorkspaceStorageSetting) GetWebdavConfig() *StorageWebDAVConfig {
+	if x != nil {
+		return x.WebdavConfig
+	}
+	return nil
+}
+
+func (x *WorkspaceStorageSetting) GetKeepLocationMetadata() bool {
+	if x != nil {
+		return x.KeepLocationMetadata
+	}
+	return false
+}
+
 // Reference: https://developers.cloudflare.com/r2/examples/aws/aws-sdk-go/
 type StorageS3Config struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -655,6 +888,67 @@ func (x *StorageS3Config) GetUsePathStyle() bool {
 	return false
 }
 
+type StorageWebDAVConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// url is the base URL of the WebDAV collection, e.g. https://dav.example.com/memos.
+	Url           string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Username      string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageWebDAVConfig) Reset() {
+	*x = StorageWebDAVConfig{}
+	mi := &file_store_workspace_setting_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageWebDAVConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageWebDAVConfig) ProtoMessage() {}
+
+func (x *StorageWebDAVConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_store_workspace_setting_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageWebDAVConfig.ProtoReflect.Descriptor instead.
+func (*StorageWebDAVConfig) Descriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StorageWebDAVConfig) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *StorageWebDAVConfig) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *StorageWebDAVConfig) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
 type WorkspaceMemoRelatedSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// disallow_public_visibility disallows set memo as public visibility.
@@ -676,14 +970,16 @@ type WorkspaceMemoRelatedSetting struct {
 	// enable_blur_nsfw_content enables blurring of content marked as not safe for work (NSFW).
 	EnableBlurNsfwContent bool `protobuf:"varint,12,opt,name=enable_blur_nsfw_content,json=enableBlurNsfwContent,proto3" json:"enable_blur_nsfw_content,omitempty"`
 	// nsfw_tags is the list of tags that mark content as NSFW for blurring.
-	NsfwTags      []string `protobuf:"bytes,13,rep,name=nsfw_tags,json=nsfwTags,proto3" json:"nsfw_tags,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	NsfwTags []string `protobuf:"bytes,13,rep,name=nsfw_tags,json=nsfwTags,proto3" json:"nsfw_tags,omitempty"`
+	// proxy_link_preview_images serves the images of link previews through the server, so that readers do not request them from third parties.
+	ProxyLinkPreviewImages bool `protobuf:"varint,14,opt,name=proxy_link_preview_images,json=proxyLinkPreviewImages,proto3" json:"proxy_link_preview_images,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *WorkspaceMemoRelatedSetting) Reset() {
 	*x = WorkspaceMemoRelatedSetting{}
-	mi := &file_store_workspace_setting_proto_msgTypes[6]
+	mi := &file_store_workspace_setting_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -695,7 +991,7 @@ func (x *WorkspaceMemoRelatedSetting) String() string {
 func (*WorkspaceMemoRelatedSetting) ProtoMessage() {}
 
 func (x *WorkspaceMemoRelatedSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_store_workspace_setting_proto_msgTypes[6]
+	mi := &file_store_workspace_setting_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -708,7 +1004,7 @@ func (x *WorkspaceMemoRelatedSetting) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WorkspaceMemoRelatedSetting.ProtoReflect.Descriptor instead.
 func (*WorkspaceMemoRelatedSetting) Descriptor() ([]byte, []int) {
-	return file_store_workspace_setting_proto_rawDescGZIP(), []int{6}
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *WorkspaceMemoRelatedSetting) GetDisallowPublicVisibility() bool {
@@ -781,22 +1077,424 @@ func (x *WorkspaceMemoRelatedSetting) GetNsfwTags() []string {
 	return nil
 }
 
+func (x *WorkspaceMemoRelatedSetting) GetProxyLinkPreviewImages() bool {
+	if x != nil {
+		return x.ProxyLinkPreviewImages
+	}
+	return false
+}
+
+type WorkspaceRateLimitSetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// disabled turns off rate limiting and account lockout.
+	Disabled bool `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	// requests_per_minute is the request rate allowed per user, or per IP for anonymous requests.
+	RequestsPerMinute int32 `protobuf:"varint,2,opt,name=requests_per_minute,json=requestsPerMinute,proto3" json:"requests_per_minute,omitempty"`
+	// burst is the number of requests allowed at once.
+	Burst int32 `protobuf:"varint,3,opt,name=burst,proto3" json:"burst,omitempty"`
+	// auth_requests_per_minute is the sign in and sign up rate allowed per IP.
+	AuthRequestsPerMinute int32 `protobuf:"varint,4,opt,name=auth_requests_per_minute,json=authRequestsPerMinute,proto3" json:"auth_requests_per_minute,omitempty"`
+	// max_failed_sign_in_attempts is the number of consecutive wrong passwords that locks an account.
+	MaxFailedSignInAttempts int32 `protobuf:"varint,5,opt,name=max_failed_sign_in_attempts,json=maxFailedSignInAttempts,proto3" json:"max_failed_sign_in_attempts,omitempty"`
+	// lockout_duration_seconds is the first lockout duration, doubled on each following lockout.
+	LockoutDurationSeconds int32 `protobuf:"varint,6,opt,name=lockout_duration_seconds,json=lockoutDurationSeconds,proto3" json:"lockout_duration_seconds,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceRateLimitSetting) Reset() {
+	*x = WorkspaceRateLimitSetting{}
+	mi := &file_store_workspace_setting_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceRateLimitSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceRateLimitSetting) ProtoMessage() {}
+
+func (x *WorkspaceRateLimitSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_workspace_setting_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceRateLimitSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceRateLimitSetting) Descriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WorkspaceRateLimitSetting) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *WorkspaceRateLimitSetting) GetRequestsPerMinute() int32 {
+	if x != nil {
+		return x.RequestsPerMinute
+	}
+	return 0
+}
+
+func (x *WorkspaceRateLimitSetting) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+func (x *WorkspaceRateLimitSetting) GetAuthRequestsPerMinute() int32 {
+	if x != nil {
+		return x.AuthRequestsPerMinute
+	}
+	return 0
+}
+
+func (x *WorkspaceRateLimitSetting) GetMaxFailedSignInAttempts() int32 {
+	if x != nil {
+		return x.MaxFailedSignInAttempts
+	}
+	return 0
+}
+
+func (x *WorkspaceRateLimitSetting) GetLockoutDurationSeconds() int32 {
+	if x != nil {
+		return x.LockoutDurationSeconds
+	}
+	return 0
+}
+
+type WorkspaceStorageMigrationSetting struct {
+	state protoimpl.MessageState                 `protogen:"open.v1"`
+	State WorkspaceStorageMigrationSetting_State `protobuf:"varint,1,opt,name=state,proto3,enum=memos.store.WorkspaceStorageMigrationSetting_State" json:"state,omitempty"`
+	// target_storage_type is the storage resources are migrated to.
+	TargetStorageType WorkspaceStorageSetting_StorageType `protobuf:"varint,2,opt,name=target_storage_type,json=targetStorageType,proto3,enum=memos.store.WorkspaceStorageSetting_StorageType" json:"target_storage_type,omitempty"`
+	// total is the number of resources to migrate.
+	Total int32 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// processed is the number of resources processed so far, migrated or failed.
+	Processed int32 `protobuf:"varint,4,opt,name=processed,proto3" json:"processed,omitempty"`
+	// migrated is the number of resources moved to the target storage.
+	Migrated int32 `protobuf:"varint,5,opt,name=migrated,proto3" json:"migrated,omitempty"`
+	// failed is the number of resources that could not be moved.
+	Failed int32 `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	// last_error is the error of the last failed resource.
+	LastError string `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// last_resource_id is the ID of the last processed resource. Resources are processed
+	// in ID order so that an interrupted migration resumes after it.
+	LastResourceId int32                  `protobuf:"varint,8,opt,name=last_resource_id,json=lastResourceId,proto3" json:"last_resource_id,omitempty"`
+	StartTime      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	FinishTime     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=finish_time,json=finishTime,proto3" json:"finish_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WorkspaceStorageMigrationSetting) Reset() {
+	*x = WorkspaceStorageMigrationSetting{}
+	mi := &file_store_workspace_setting_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceStorageMigrationSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceStorageMigrationSetting) ProtoMessage() {}
+
+func (x *WorkspaceStorageMigrationSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_workspace_setting_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceStorageMigrationSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceStorageMigrationSetting) Descriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WorkspaceStorageMigrationSetting) GetState() WorkspaceStorageMigrationSetting_State {
+	if x != nil {
+		return x.State
+	}
+	return WorkspaceStorageMigrationSetting_STATE_UNSPECIFIED
+}
+
+func (x *WorkspaceStorageMigrationSetting) GetTargetStorageType() WorkspaceStorageSetting_StorageType {
+	if x != nil {
+		return x.TargetStorageType
+	}
+	return WorkspaceStorageSetting_STORAGE_TYPE_UNSPECIFIED
+}
+
+func (x *WorkspaceStorageMigrationSetting) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *WorkspaceStorageMigrationSetting) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *WorkspaceStorageMigrationSetting) GetMigrated() int32 {
+	if x != nil {
+		return x.Migrated
+	}
+	return 0
+}
+
+func (x *WorkspaceStorageMigrationSetting) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *WorkspaceStorageMigrationSetting) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WorkspaceStorageMigrationSetting) GetLastResourceId() int32 {
+	if x != nil {
+		return x.LastResourceId
+	}
+	return 0
+}
+
+func (x *WorkspaceStorageMigrationSetting) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *WorkspaceStorageMigrationSetting) GetFinishTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishTime
+	}
+	return nil
+}
+
+type WorkspaceSharedTicketViewsSetting struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Views         []*TicketView          `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceSharedTicketViewsSetting) Reset() {
+	*x = WorkspaceSharedTicketViewsSetting{}
+	mi := &file_store_workspace_setting_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceSharedTicketViewsSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceSharedTicketViewsSetting) ProtoMessage() {}
+
+func (x *WorkspaceSharedTicketViewsSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_workspace_setting_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceSharedTicketViewsSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceSharedTicketViewsSetting) Descriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WorkspaceSharedTicketViewsSetting) GetViews() []*TicketView {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+type WorkspaceTicketFieldsSetting struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fields        []*TicketField         `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceTicketFieldsSetting) Reset() {
+	*x = WorkspaceTicketFieldsSetting{}
+	mi := &file_store_workspace_setting_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceTicketFieldsSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceTicketFieldsSetting) ProtoMessage() {}
+
+func (x *WorkspaceTicketFieldsSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_workspace_setting_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceTicketFieldsSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceTicketFieldsSetting) Descriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WorkspaceTicketFieldsSetting) GetFields() []*TicketField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// TicketField is the definition of a custom field of the tickets.
+type TicketField struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The key of the field in the values of the tickets and in the ticket filter, e.g. "story_points".
+	Key   string           `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Title string           `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Type  TicketField_Type `protobuf:"varint,3,opt,name=type,proto3,enum=memos.store.TicketField_Type" json:"type,omitempty"`
+	// The options of an ENUM field.
+	Options []string `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	// The ticket types the field applies to. It applies to all types when empty.
+	TicketTypes []string `protobuf:"bytes,5,rep,name=ticket_types,json=ticketTypes,proto3" json:"ticket_types,omitempty"`
+	// Whether the tickets the field applies to must have a value.
+	Required      bool `protobuf:"varint,6,opt,name=required,proto3" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketField) Reset() {
+	*x = TicketField{}
+	mi := &file_store_workspace_setting_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketField) ProtoMessage() {}
+
+func (x *TicketField) ProtoReflect() protoreflect.Message {
+	mi := &file_store_workspace_setting_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketField.ProtoReflect.Descriptor instead.
+func (*TicketField) Descriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TicketField) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TicketField) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TicketField) GetType() TicketField_Type {
+	if x != nil {
+		return x.Type
+	}
+	return TicketField_TYPE_UNSPECIFIED
+}
+
+func (x *TicketField) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *TicketField) GetTicketTypes() []string {
+	if x != nil {
+		return x.TicketTypes
+	}
+	return nil
+}
+
+func (x *TicketField) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
 var File_store_workspace_setting_proto protoreflect.FileDescriptor
 
 const file_store_workspace_setting_proto_rawDesc = "" +
 	"\n" +
-	"\x1dstore/workspace_setting.proto\x12\vmemos.store\"\x9a\x03\n" +
+	"\x1dstore/workspace_setting.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17store/ticket_view.proto\"\xb1\x06\n" +
 	"\x10WorkspaceSetting\x122\n" +
 	"\x03key\x18\x01 \x01(\x0e2 .memos.store.WorkspaceSettingKeyR\x03key\x12I\n" +
 	"\rbasic_setting\x18\x02 \x01(\v2\".memos.store.WorkspaceBasicSettingH\x00R\fbasicSetting\x12O\n" +
 	"\x0fgeneral_setting\x18\x03 \x01(\v2$.memos.store.WorkspaceGeneralSettingH\x00R\x0egeneralSetting\x12O\n" +
 	"\x0fstorage_setting\x18\x04 \x01(\v2$.memos.store.WorkspaceStorageSettingH\x00R\x0estorageSetting\x12\\\n" +
-	"\x14memo_related_setting\x18\x05 \x01(\v2(.memos.store.WorkspaceMemoRelatedSettingH\x00R\x12memoRelatedSettingB\a\n" +
+	"\x14memo_related_setting\x18\x05 \x01(\v2(.memos.store.WorkspaceMemoRelatedSettingH\x00R\x12memoRelatedSetting\x12V\n" +
+	"\x12rate_limit_setting\x18\x06 \x01(\v2&.memos.store.WorkspaceRateLimitSettingH\x00R\x10rateLimitSetting\x12k\n" +
+	"\x19storage_migration_setting\x18\a \x01(\v2-.memos.store.WorkspaceStorageMigrationSettingH\x00R\x17storageMigrationSetting\x12o\n" +
+	"\x1bshared_ticket_views_setting\x18\b \x01(\v2..memos.store.WorkspaceSharedTicketViewsSettingH\x00R\x18sharedTicketViewsSetting\x12_\n" +
+	"\x15ticket_fields_setting\x18\t \x01(\v2).memos.store.WorkspaceTicketFieldsSettingH\x00R\x13ticketFieldsSettingB\a\n" +
 	"\x05value\"]\n" +
 	"\x15WorkspaceBasicSetting\x12\x1d\n" +
 	"\n" +
 	"secret_key\x18\x01 \x01(\tR\tsecretKey\x12%\n" +
-	"\x0eschema_version\x18\x02 \x01(\tR\rschemaVersion\"\xd8\x03\n" +
+	"\x0eschema_version\x18\x02 \x01(\tR\rschemaVersion\"\x9a\x04\n" +
 	"\x17WorkspaceGeneralSetting\x12<\n" +
 	"\x1adisallow_user_registration\x18\x01 \x01(\bR\x18disallowUserRegistration\x124\n" +
 	"\x16disallow_password_auth\x18\x02 \x01(\bR\x14disallowPasswordAuth\x12+\n" +
@@ -805,7 +1503,8 @@ const file_store_workspace_setting_proto_rawDesc = "" +
 	"\x0ecustom_profile\x18\x05 \x01(\v2#.memos.store.WorkspaceCustomProfileR\rcustomProfile\x121\n" +
 	"\x15week_start_day_offset\x18\x06 \x01(\x05R\x12weekStartDayOffset\x128\n" +
 	"\x18disallow_change_username\x18\a \x01(\bR\x16disallowChangeUsername\x128\n" +
-	"\x18disallow_change_nickname\x18\b \x01(\bR\x16disallowChangeNickname\"\xa3\x01\n" +
+	"\x18disallow_change_nickname\x18\b \x01(\bR\x16disallowChangeNickname\x12@\n" +
+	"\x1drequire_two_factor_for_admins\x18\t \x01(\bR\x19requireTwoFactorForAdmins\"\xa3\x01\n" +
 	"\x16WorkspaceCustomProfile\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x19\n" +
@@ -813,24 +1512,33 @@ const file_store_workspace_setting_proto_rawDesc = "" +
 	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x1e\n" +
 	"\n" +
 	"appearance\x18\x05 \x01(\tR\n" +
-	"appearance\"\xd5\x02\n" +
+	"appearance\"\x8b\x04\n" +
 	"\x17WorkspaceStorageSetting\x12S\n" +
 	"\fstorage_type\x18\x01 \x01(\x0e20.memos.store.WorkspaceStorageSetting.StorageTypeR\vstorageType\x12+\n" +
 	"\x11filepath_template\x18\x02 \x01(\tR\x10filepathTemplate\x12/\n" +
 	"\x14upload_size_limit_mb\x18\x03 \x01(\x03R\x11uploadSizeLimitMb\x129\n" +
-	"\ts3_config\x18\x04 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\"L\n" +
+	"\ts3_config\x18\x04 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\x12+\n" +
+	"\x11content_addressed\x18\x05 \x01(\bR\x10contentAddressed\x12E\n" +
+	"\rwebdav_config\x18\x06 \x01(\v2 .memos.store.StorageWebDAVConfigR\fwebdavConfig\x124\n" +
+	"\x16keep_location_metadata\x18\a \x01(\bR\x14keepLocationMetadata\"X\n" +
 	"\vStorageType\x12\x1c\n" +
 	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bDATABASE\x10\x01\x12\t\n" +
 	"\x05LOCAL\x10\x02\x12\x06\n" +
-	"\x02S3\x10\x03\"\xd3\x01\n" +
+	"\x02S3\x10\x03\x12\n" +
+	"\n" +
+	"\x06WEBDAV\x10\x04\"\xd3\x01\n" +
 	"\x0fStorageS3Config\x12\"\n" +
 	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12*\n" +
 	"\x11access_key_secret\x18\x02 \x01(\tR\x0faccessKeySecret\x12\x1a\n" +
 	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x16\n" +
 	"\x06region\x18\x04 \x01(\tR\x06region\x12\x16\n" +
 	"\x06bucket\x18\x05 \x01(\tR\x06bucket\x12$\n" +
-	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\"\x94\x04\n" +
+	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\"_\n" +
+	"\x13StorageWebDAVConfig\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"\xcf\x04\n" +
 	"\x1bWorkspaceMemoRelatedSetting\x12<\n" +
 	"\x1adisallow_public_visibility\x18\x01 \x01(\bR\x18disallowPublicVisibility\x127\n" +
 	"\x18display_with_update_time\x18\x02 \x01(\bR\x15displayWithUpdateTime\x120\n" +
@@ -842,13 +1550,64 @@ const file_store_workspace_setting_proto_rawDesc = "" +
 	" \x03(\tR\treactions\x12<\n" +
 	"\x1adisable_markdown_shortcuts\x18\v \x01(\bR\x18disableMarkdownShortcuts\x127\n" +
 	"\x18enable_blur_nsfw_content\x18\f \x01(\bR\x15enableBlurNsfwContent\x12\x1b\n" +
-	"\tnsfw_tags\x18\r \x03(\tR\bnsfwTagsJ\x04\b\x04\x10\x05J\x04\b\b\x10\t*s\n" +
+	"\tnsfw_tags\x18\r \x03(\tR\bnsfwTags\x129\n" +
+	"\x19proxy_link_preview_images\x18\x0e \x01(\bR\x16proxyLinkPreviewImagesJ\x04\b\x04\x10\x05J\x04\b\b\x10\t\"\xae\x02\n" +
+	"\x19WorkspaceRateLimitSetting\x12\x1a\n" +
+	"\bdisabled\x18\x01 \x01(\bR\bdisabled\x12.\n" +
+	"\x13requests_per_minute\x18\x02 \x01(\x05R\x11requestsPerMinute\x12\x14\n" +
+	"\x05burst\x18\x03 \x01(\x05R\x05burst\x127\n" +
+	"\x18auth_requests_per_minute\x18\x04 \x01(\x05R\x15authRequestsPerMinute\x12<\n" +
+	"\x1bmax_failed_sign_in_attempts\x18\x05 \x01(\x05R\x17maxFailedSignInAttempts\x128\n" +
+	"\x18lockout_duration_seconds\x18\x06 \x01(\x05R\x16lockoutDurationSeconds\"\xb4\x04\n" +
+	" WorkspaceStorageMigrationSetting\x12I\n" +
+	"\x05state\x18\x01 \x01(\x0e23.memos.store.WorkspaceStorageMigrationSetting.StateR\x05state\x12`\n" +
+	"\x13target_storage_type\x18\x02 \x01(\x0e20.memos.store.WorkspaceStorageSetting.StorageTypeR\x11targetStorageType\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1c\n" +
+	"\tprocessed\x18\x04 \x01(\x05R\tprocessed\x12\x1a\n" +
+	"\bmigrated\x18\x05 \x01(\x05R\bmigrated\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x05R\x06failed\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\a \x01(\tR\tlastError\x12(\n" +
+	"\x10last_resource_id\x18\b \x01(\x05R\x0elastResourceId\x129\n" +
+	"\n" +
+	"start_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12;\n" +
+	"\vfinish_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishTime\":\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aRUNNING\x10\x01\x12\r\n" +
+	"\tCOMPLETED\x10\x02\"R\n" +
+	"!WorkspaceSharedTicketViewsSetting\x12-\n" +
+	"\x05views\x18\x01 \x03(\v2\x17.memos.store.TicketViewR\x05views\"P\n" +
+	"\x1cWorkspaceTicketFieldsSetting\x120\n" +
+	"\x06fields\x18\x01 \x03(\v2\x18.memos.store.TicketFieldR\x06fields\"\x93\x02\n" +
+	"\vTicketField\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x121\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1d.memos.store.TicketField.TypeR\x04type\x12\x18\n" +
+	"\aoptions\x18\x04 \x03(\tR\aoptions\x12!\n" +
+	"\fticket_types\x18\x05 \x03(\tR\vticketTypes\x12\x1a\n" +
+	"\brequired\x18\x06 \x01(\bR\brequired\"P\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04TEXT\x10\x01\x12\n" +
+	"\n" +
+	"\x06NUMBER\x10\x02\x12\b\n" +
+	"\x04ENUM\x10\x03\x12\b\n" +
+	"\x04USER\x10\x04\x12\b\n" +
+	"\x04DATE\x10\x05*\xc6\x01\n" +
 	"\x13WorkspaceSettingKey\x12%\n" +
 	"!WORKSPACE_SETTING_KEY_UNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05BASIC\x10\x01\x12\v\n" +
 	"\aGENERAL\x10\x02\x12\v\n" +
 	"\aSTORAGE\x10\x03\x12\x10\n" +
-	"\fMEMO_RELATED\x10\x04B\xa0\x01\n" +
+	"\fMEMO_RELATED\x10\x04\x12\x0e\n" +
+	"\n" +
+	"RATE_LIMIT\x10\x05\x12\x15\n" +
+	"\x11STORAGE_MIGRATION\x10\x06\x12\x17\n" +
+	"\x13SHARED_TICKET_VIEWS\x10\a\x12\x11\n" +
+	"\rTICKET_FIELDS\x10\bB\xa0\x01\n" +
 	"\x0fcom.memos.storeB\x15WorkspaceSettingProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
 
 var (
@@ -863,33 +1622,55 @@ func file_store_workspace_setting_proto_rawDescGZIP() []byte {
 	return file_store_workspace_setting_proto_rawDescData
 }
 
-var file_store_workspace_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_store_workspace_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_store_workspace_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_store_workspace_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_store_workspace_setting_proto_goTypes = []any{
-	(WorkspaceSettingKey)(0),                 // 0: memos.store.WorkspaceSettingKey
-	(WorkspaceStorageSetting_StorageType)(0), // 1: memos.store.WorkspaceStorageSetting.StorageType
-	(*WorkspaceSetting)(nil),                 // 2: memos.store.WorkspaceSetting
-	(*WorkspaceBasicSetting)(nil),            // 3: memos.store.WorkspaceBasicSetting
-	(*WorkspaceGeneralSetting)(nil),          // 4: memos.store.WorkspaceGeneralSetting
-	(*WorkspaceCustomProfile)(nil),           // 5: memos.store.WorkspaceCustomProfile
-	(*WorkspaceStorageSetting)(nil),          // 6: memos.store.WorkspaceStorageSetting
-	(*StorageS3Config)(nil),                  // 7: memos.store.StorageS3Config
-	(*WorkspaceMemoRelatedSetting)(nil),      // 8: memos.store.WorkspaceMemoRelatedSetting
+	(WorkspaceSettingKey)(0),                    // 0: memos.store.WorkspaceSettingKey
+	(WorkspaceStorageSetting_StorageType)(0),    // 1: memos.store.WorkspaceStorageSetting.StorageType
+	(WorkspaceStorageMigrationSetting_State)(0), // 2: memos.store.WorkspaceStorageMigrationSetting.State
+	(TicketField_Type)(0),                       // 3: memos.store.TicketField.Type
+	(*WorkspaceSetting)(nil),                    // 4: memos.store.WorkspaceSetting
+	(*WorkspaceBasicSetting)(nil),               // 5: memos.store.WorkspaceBasicSetting
+	(*WorkspaceGeneralSetting)(nil),             // 6: memos.store.WorkspaceGeneralSetting
+	(*WorkspaceCustomProfile)(nil),              // 7: memos.store.WorkspaceCustomProfile
+	(*WorkspaceStorageSetting)(nil),             // 8: memos.store.WorkspaceStorageSetting
+	(*StorageS3Config)(nil),                     // 9: memos.store.StorageS3Config
+	(*StorageWebDAVConfig)(nil),                 // 10: memos.store.StorageWebDAVConfig
+	(*WorkspaceMemoRelatedSetting)(nil),         // 11: memos.store.WorkspaceMemoRelatedSetting
+	(*WorkspaceRateLimitSetting)(nil),           // 12: memos.store.WorkspaceRateLimitSetting
+	(*WorkspaceStorageMigrationSetting)(nil),    // 13: memos.store.WorkspaceStorageMigrationSetting
+	(*WorkspaceSharedTicketViewsSetting)(nil),   // 14: memos.store.WorkspaceSharedTicketViewsSetting
+	(*WorkspaceTicketFieldsSetting)(nil),        // 15: memos.store.WorkspaceTicketFieldsSetting
+	(*TicketField)(nil),                         // 16: memos.store.TicketField
+	(*timestamppb.Timestamp)(nil),               // 17: google.protobuf.Timestamp
+	(*TicketView)(nil),                          // 18: memos.store.TicketView
 }
 var file_store_workspace_setting_proto_depIdxs = []int32{
-	0, // 0: memos.store.WorkspaceSetting.key:type_name -> memos.store.WorkspaceSettingKey
-	3, // 1: memos.store.WorkspaceSetting.basic_setting:type_name -> memos.store.WorkspaceBasicSetting
-	4, // 2: memos.store.WorkspaceSetting.general_setting:type_name -> memos.store.WorkspaceGeneralSetting
-	6, // 3: memos.store.WorkspaceSetting.storage_setting:type_name -> memos.store.WorkspaceStorageSetting
-	8, // 4: memos.store.WorkspaceSetting.memo_related_setting:type_name -> memos.store.WorkspaceMemoRelatedSetting
-	5, // 5: memos.store.WorkspaceGeneralSetting.custom_profile:type_name -> memos.store.WorkspaceCustomProfile
-	1, // 6: memos.store.WorkspaceStorageSetting.storage_type:type_name -> memos.store.WorkspaceStorageSetting.StorageType
-	7, // 7: memos.store.WorkspaceStorageSetting.s3_config:type_name -> memos.store.StorageS3Config
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	0,  // 0: memos.store.WorkspaceSetting.key:type_name -> memos.store.WorkspaceSettingKey
+	5,  // 1: memos.store.WorkspaceSetting.basic_setting:type_name -> memos.store.WorkspaceBasicSetting
+	6,  // 2: memos.store.WorkspaceSetting.general_setting:type_name -> memos.store.WorkspaceGeneralSetting
+	8,  // 3: memos.store.WorkspaceSetting.storage_setting:type_name -> memos.store.WorkspaceStorageSetting
+	11, // 4: memos.store.WorkspaceSetting.memo_related_setting:type_name -> memos.store.WorkspaceMemoRelatedSetting
+	12, // 5: memos.store.WorkspaceSetting.rate_limit_setting:type_name -> memos.store.WorkspaceRateLimitSetting
+	13, // 6: memos.store.WorkspaceSetting.storage_migration_setting:type_name -> memos.store.WorkspaceStorageMigrationSetting
+	14, // 7: memos.store.WorkspaceSetting.shared_ticket_views_setting:type_name -> memos.store.WorkspaceSharedTicketViewsSetting
+	15, // 8: memos.store.WorkspaceSetting.ticket_fields_setting:type_name -> memos.store.WorkspaceTicketFieldsSetting
+	7,  // 9: memos.store.WorkspaceGeneralSetting.custom_profile:type_name -> memos.store.WorkspaceCustomProfile
+	1,  // 10: memos.store.WorkspaceStorageSetting.storage_type:type_name -> memos.store.WorkspaceStorageSetting.StorageType
+	9,  // 11: memos.store.WorkspaceStorageSetting.s3_config:type_name -> memos.store.StorageS3Config
+	10, // 12: memos.store.WorkspaceStorageSetting.webdav_config:type_name -> memos.store.StorageWebDAVConfig
+	2,  // 13: memos.store.WorkspaceStorageMigrationSetting.state:type_name -> memos.store.WorkspaceStorageMigrationSetting.State
+	1,  // 14: memos.store.WorkspaceStorageMigrationSetting.target_storage_type:type_name -> memos.store.WorkspaceStorageSetting.StorageType
+	17, // 15: memos.store.WorkspaceStorageMigrationSetting.start_time:type_name -> google.protobuf.Timestamp
+	17, // 16: memos.store.WorkspaceStorageMigrationSetting.finish_time:type_name -> google.protobuf.Timestamp
+	18, // 17: memos.store.WorkspaceSharedTicketViewsSetting.views:type_name -> memos.store.TicketView
+	16, // 18: memos.store.WorkspaceTicketFieldsSetting.fields:type_name -> memos.store.TicketField
+	3,  // 19: memos.store.TicketField.type:type_name -> memos.store.TicketField.Type
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_store_workspace_setting_proto_init() }
@@ -897,19 +1678,24 @@ func file_store_workspace_setting_proto_init() {
 	if File_store_workspace_setting_proto != nil {
 		return
 	}
+	file_store_ticket_view_proto_init()
 	file_store_workspace_setting_proto_msgTypes[0].OneofWrappers = []any{
 		(*WorkspaceSetting_BasicSetting)(nil),
 		(*WorkspaceSetting_GeneralSetting)(nil),
 		(*WorkspaceSetting_StorageSetting)(nil),
 		(*WorkspaceSetting_MemoRelatedSetting)(nil),
+		(*WorkspaceSetting_RateLimitSetting)(nil),
+		(*WorkspaceSetting_StorageMigrationSetting)(nil),
+		(*WorkspaceSetting_SharedTicketViewsSetting)(nil),
+		(*WorkspaceSetting_TicketFieldsSetting)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_workspace_setting_proto_rawDesc), len(file_store_workspace_setting_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   7,
+			NumEnums:      4,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
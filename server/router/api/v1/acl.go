@@ -2,18 +2,20 @@ package v1
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"strings"
 
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
+	"github.com/usememos/memos/internal/profile"
 	"github.com/usememos/memos/internal/util"
-	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
@@ -25,19 +27,23 @@ const (
 	// user id is extracted from the jwt token subject field.
 	usernameContextKey ContextKey = iota
 	accessTokenContextKey
+	// sessionIDContextKey is the key of the session id, set when the request is authenticated by a session.
+	sessionIDContextKey
 )
 
 // GRPCAuthInterceptor is the auth interceptor for gRPC server.
 type GRPCAuthInterceptor struct {
-	Store  *store.Store
-	secret string
+	Store   *store.Store
+	profile *profile.Profile
+	secret  string
 }
 
 // NewGRPCAuthInterceptor returns a new API auth interceptor.
-func NewGRPCAuthInterceptor(store *store.Store, secret string) *GRPCAuthInterceptor {
+func NewGRPCAuthInterceptor(store *store.Store, profile *profile.Profile, secret string) *GRPCAuthInterceptor {
 	return &GRPCAuthInterceptor{
-		Store:  store,
-		secret: secret,
+		Store:   store,
+		profile: profile,
+		secret:  secret,
 	}
 }
 
@@ -51,82 +57,94 @@ func (in *GRPCAuthInterceptor) AuthenticationInterceptor(ctx context.Context, re
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "failed to get access token: %v", err)
 	}
+	clientIP, userAgent := getClientIPFromMetadata(ctx, md, in.profile), getUserAgentFromMetadata(md)
+
+	// Browsers refresh the session transparently when the access token cookie has expired.
+	if len(md.Get("Authorization")) == 0 && serverInfo.FullMethod != "/memos.api.v1.AuthService/RefreshSession" {
+		if _, err := ParseAccessToken(accessToken, []byte(in.secret)); err != nil {
+			if refreshToken := getCookieFromMetadata(md, RefreshTokenCookieName); refreshToken != "" {
+				if tokens, err := refreshSession(ctx, in.Store, in.secret, refreshToken, clientIP, userAgent); err == nil {
+					if err := setSessionCookies(ctx, tokens); err != nil {
+						return nil, status.Errorf(codes.Internal, "failed to set session cookies: %v", err)
+					}
+					accessToken = tokens.AccessToken
+				}
+			}
+		}
+	}
 
-	username, err := in.authenticate(ctx, accessToken)
+	user, grant, err := in.authenticate(ctx, accessToken, clientIP, userAgent)
 	if err != nil {
 		if isUnauthorizeAllowedMethod(serverInfo.FullMethod) {
 			return handler(ctx, request)
 		}
 		return nil, err
 	}
-	user, err := in.Store.GetUser(ctx, &store.FindUser{
-		Username: &username,
-	})
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get user")
-	}
-	if user == nil {
-		return nil, errors.Errorf("user %q not exists", username)
+	if permission, ok := getMethodPermission(serverInfo.FullMethod); ok {
+		hasPermission, err := in.Store.HasPermission(ctx, user, permission)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check permission: %v", err)
+		}
+		if !hasPermission {
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied: %s is required", permission)
+		}
 	}
-	if user.RowStatus == store.Archived {
-		return nil, errors.Errorf("user %q is archived", username)
+	if !isAccessTokenScopeAllowedMethod(serverInfo.FullMethod, grant.Scopes) {
+		return nil, status.Errorf(codes.PermissionDenied, "access token scope does not allow %s", serverInfo.FullMethod)
 	}
-	if isOnlyForAdminAllowedMethod(serverInfo.FullMethod) && user.Role != store.RoleHost && user.Role != store.RoleAdmin {
-		return nil, errors.Errorf("user %q is not admin", username)
+	if !isTwoFactorEnrollmentAllowedMethod(serverInfo.FullMethod) {
+		enrollmentRequired, err := in.Store.IsTwoFactorEnrollmentRequired(ctx, user)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check two-factor authentication: %v", err)
+		}
+		if enrollmentRequired {
+			return nil, status.Errorf(codes.PermissionDenied, "two-factor authentication must be enabled")
+		}
 	}
 
-	ctx = context.WithValue(ctx, usernameContextKey, username)
+	ctx = context.WithValue(ctx, usernameContextKey, user.Username)
 	ctx = context.WithValue(ctx, accessTokenContextKey, accessToken)
+	if grant.SessionID != "" {
+		ctx = context.WithValue(ctx, sessionIDContextKey, grant.SessionID)
+	}
 	return handler(ctx, request)
 }
 
-func (in *GRPCAuthInterceptor) authenticate(ctx context.Context, accessToken string) (string, error) {
+// authenticate validates the access token and returns its user with what the token grants.
+func (in *GRPCAuthInterceptor) authenticate(ctx context.Context, accessToken, clientIP, userAgent string) (*store.User, *accessGrant, error) {
 	if accessToken == "" {
-		return "", status.Errorf(codes.Unauthenticated, "access token not found")
+		return nil, nil, status.Errorf(codes.Unauthenticated, "access token not found")
 	}
-	claims := &ClaimsMessage{}
-	_, err := jwt.ParseWithClaims(accessToken, claims, func(t *jwt.Token) (any, error) {
-		if t.Method.Alg() != jwt.SigningMethodHS256.Name {
-			return nil, status.Errorf(codes.Unauthenticated, "unexpected access token signing method=%v, expect %v", t.Header["alg"], jwt.SigningMethodHS256)
-		}
-		if kid, ok := t.Header["kid"].(string); ok {
-			if kid == "v1" {
-				return []byte(in.secret), nil
-			}
-		}
-		return nil, status.Errorf(codes.Unauthenticated, "unexpected access token kid=%v", t.Header["kid"])
-	})
+	claims, err := ParseAccessToken(accessToken, []byte(in.secret))
 	if err != nil {
-		return "", status.Errorf(codes.Unauthenticated, "Invalid or expired access token")
+		return nil, nil, status.Errorf(codes.Unauthenticated, "Invalid or expired access token")
 	}
 
-	// We either have a valid access token or we will attempt to generate new access token.
 	userID, err := util.ConvertStringToInt32(claims.Subject)
 	if err != nil {
-		return "", errors.Wrap(err, "malformed ID in the token")
+		return nil, nil, errors.Wrap(err, "malformed ID in the token")
 	}
 	user, err := in.Store.GetUser(ctx, &store.FindUser{
 		ID: &userID,
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "failed to get user")
+		return nil, nil, errors.Wrap(err, "failed to get user")
 	}
 	if user == nil {
-		return "", errors.Errorf("user %q not exists", userID)
+		return nil, nil, errors.Errorf("user %q not exists", userID)
 	}
 	if user.RowStatus == store.Archived {
-		return "", errors.Errorf("user %q is archived", userID)
+		return nil, nil, errors.Errorf("user %q is archived", userID)
 	}
 
-	accessTokens, err := in.Store.GetUserAccessTokens(ctx, user.ID)
+	grant, err := authorizeAccessToken(ctx, in.Store, user, accessToken, claims, clientIP, userAgent)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to get user access tokens")
+		return nil, nil, status.Errorf(codes.Internal, "failed to authorize access token: %v", err)
 	}
-	if !validateAccessToken(accessToken, accessTokens) {
-		return "", status.Errorf(codes.Unauthenticated, "invalid access token")
+	if grant == nil {
+		return nil, nil, status.Errorf(codes.Unauthenticated, "invalid access token")
 	}
-
-	return user.Username, nil
+	return user, grant, nil
 }
 
 func getTokenFromMetadata(md metadata.MD) (string, error) {
@@ -140,23 +158,97 @@ func getTokenFromMetadata(md metadata.MD) (string, error) {
 		return authHeaderParts[1], nil
 	}
 	// Check the cookie header.
-	var accessToken string
+	return getCookieFromMetadata(md, AccessTokenCookieName), nil
+}
+
+// getCookieFromMetadata returns the value of the cookie sent with the request.
+func getCookieFromMetadata(md metadata.MD, name string) string {
+	var value string
 	for _, t := range append(md.Get("grpcgateway-cookie"), md.Get("cookie")...) {
 		header := http.Header{}
 		header.Add("Cookie", t)
 		request := http.Request{Header: header}
-		if v, _ := request.Cookie(AccessTokenCookieName); v != nil {
-			accessToken = v.Value
+		if v, _ := request.Cookie(name); v != nil {
+			value = v.Value
+		}
+	}
+	return value
+}
+
+// getUserAgentFromMetadata returns the user agent of the client, preferring the one forwarded by the gateway.
+func getUserAgentFromMetadata(md metadata.MD) string {
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// getClientIPFromMetadata returns the IP of the client. The X-Forwarded-For entries are read from the right,
+// skipping the ones added by the loopback and the trusted proxies, as the entries on their left can be set by
+// the client. The gRPC gateway appends the address of the HTTP client, and is itself the peer.
+func getClientIPFromMetadata(ctx context.Context, md metadata.MD, profile *profile.Profile) string {
+	hops := []string{}
+	for _, forwardedFor := range md.Get("x-forwarded-for") {
+		for _, hop := range strings.Split(forwardedFor, ",") {
+			hops = append(hops, strings.Trim(strings.TrimSpace(hop), "[]"))
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		// A peer on a unix socket is the gateway or a local reverse proxy, so it is trusted.
+		if p.Addr.Network() != "unix" {
+			host, _, err := net.SplitHostPort(p.Addr.String())
+			if err != nil {
+				host = p.Addr.String()
+			}
+			hops = append(hops, host)
+		} else if len(hops) == 0 {
+			return p.Addr.String()
+		}
+	}
+	if len(hops) == 0 {
+		return ""
+	}
+
+	// The trusted proxies have been validated with the profile.
+	trustedProxyNets, _ := profile.GetTrustedProxyNets()
+	for i := len(hops) - 1; i > 0; i-- {
+		ip := net.ParseIP(hops[i])
+		if ip == nil {
+			// The entries on the left of an invalid one cannot be trusted.
+			return hops[len(hops)-1]
+		}
+		if !isTrustedProxy(ip, trustedProxyNets) {
+			return hops[i]
 		}
 	}
-	return accessToken, nil
+	return hops[0]
 }
 
-func validateAccessToken(accessTokenString string, userAccessTokens []*storepb.AccessTokensUserSetting_AccessToken) bool {
-	for _, userAccessToken := range userAccessTokens {
-		if accessTokenString == userAccessToken.AccessToken {
+// isTrustedProxy reports whether the IP is the loopback or one of the trusted proxies.
+func isTrustedProxy(ip net.IP, trustedProxyNets []*net.IPNet) bool {
+	if ip.IsLoopback() {
+		return true
+	}
+	for _, ipNet := range trustedProxyNets {
+		if ipNet.Contains(ip) {
 			return true
 		}
 	}
 	return false
 }
+
+// NewIPExtractor returns the extractor of the client IP of the Echo requests, which trusts the X-Forwarded-For
+// entries added by the loopback and the trusted proxies only, like getClientIPFromMetadata.
+func NewIPExtractor(profile *profile.Profile) (echo.IPExtractor, error) {
+	trustedProxyNets, err := profile.GetTrustedProxyNets()
+	if err != nil {
+		return nil, err
+	}
+	options := []echo.TrustOption{echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, ipNet := range trustedProxyNets {
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
@@ -1,5 +1,12 @@
 package v1
 
+import (
+	"net/http"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
 var authenticationAllowlistMethods = map[string]bool{
 	"/memos.api.v1.WorkspaceService/GetWorkspaceProfile":          true,
 	"/memos.api.v1.WorkspaceSettingService/GetWorkspaceSetting":   true,
@@ -9,6 +16,9 @@ var authenticationAllowlistMethods = map[string]bool{
 	"/memos.api.v1.AuthService/GetAuthStatus":                     true,
 	"/memos.api.v1.AuthService/SignIn":                            true,
 	"/memos.api.v1.AuthService/SignInWithSSO":                     true,
+	"/memos.api.v1.AuthService/CreateSSOAuthorization":            true,
+	"/memos.api.v1.AuthService/SignInWithTwoFactor":               true,
+	"/memos.api.v1.AuthService/RefreshSession":                    true,
 	"/memos.api.v1.AuthService/SignOut":                           true,
 	"/memos.api.v1.AuthService/SignUp":                            true,
 	"/memos.api.v1.UserService/GetUser":                           true,
@@ -21,6 +31,7 @@ var authenticationAllowlistMethods = map[string]bool{
 	"/memos.api.v1.MemoService/ListMemos":                         true,
 	"/memos.api.v1.MarkdownService/GetLinkMetadata":               true,
 	"/memos.api.v1.ResourceService/GetResourceBinary":             true,
+	"/memos.api.v1.AnalyticsService/GetMemoActivity":              true,
 }
 
 // isUnauthorizeAllowedMethod returns whether the method is exempted from authentication.
@@ -28,12 +39,162 @@ func isUnauthorizeAllowedMethod(fullMethodName string) bool {
 	return authenticationAllowlistMethods[fullMethodName]
 }
 
-var allowedMethodsOnlyForAdmin = map[string]bool{
-	"/memos.api.v1.UserService/CreateUser":                      true,
-	"/memos.api.v1.WorkspaceSettingService/SetWorkspaceSetting": true,
+// authRateLimitedMethods are the methods guessing credentials, limited per IP by the stricter auth policy.
+var authRateLimitedMethods = map[string]bool{
+	"/memos.api.v1.AuthService/SignIn":              true,
+	"/memos.api.v1.AuthService/SignInWithTwoFactor": true,
+	"/memos.api.v1.AuthService/SignUp":              true,
+}
+
+// isAuthRateLimitedMethod returns whether the method is limited by the auth rate limit policy.
+func isAuthRateLimitedMethod(fullMethodName string) bool {
+	return authRateLimitedMethods[fullMethodName]
+}
+
+// methodPermissions are the permissions required to call the methods.
+var methodPermissions = map[string]store.Permission{
+	"/memos.api.v1.UserService/CreateUser":                         store.PermissionUserManage,
+	"/memos.api.v1.WorkspaceSettingService/SetWorkspaceSetting":    store.PermissionWorkspaceSettingManage,
+	"/memos.api.v1.WorkspaceService/CreateWorkspaceBackup":         store.PermissionBackupManage,
+	"/memos.api.v1.WorkspaceService/ListWorkspaceBackups":          store.PermissionBackupManage,
+	"/memos.api.v1.WorkspaceService/StartStorageMigration":         store.PermissionWorkspaceSettingManage,
+	"/memos.api.v1.WorkspaceService/GetStorageMigration":           store.PermissionWorkspaceSettingManage,
+	"/memos.api.v1.WebhookService/CreateWebhook":                   store.PermissionWebhookManage,
+	"/memos.api.v1.WebhookService/UpdateWebhook":                   store.PermissionWebhookManage,
+	"/memos.api.v1.WebhookService/DeleteWebhook":                   store.PermissionWebhookManage,
+	"/memos.api.v1.IdentityProviderService/CreateIdentityProvider": store.PermissionIdentityProviderManage,
+	"/memos.api.v1.IdentityProviderService/UpdateIdentityProvider": store.PermissionIdentityProviderManage,
+	"/memos.api.v1.IdentityProviderService/DeleteIdentityProvider": store.PermissionIdentityProviderManage,
+	"/memos.api.v1.RoleService/ListRoles":                          store.PermissionRoleManage,
+	"/memos.api.v1.RoleService/CreateRole":                         store.PermissionRoleManage,
+	"/memos.api.v1.RoleService/UpdateRole":                         store.PermissionRoleManage,
+	"/memos.api.v1.RoleService/DeleteRole":                         store.PermissionRoleManage,
+	"/memos.api.v1.RoleService/SetUserRoles":                       store.PermissionRoleManage,
+	"/memos.api.v1.AnalyticsService/GetTicketMetrics":              store.PermissionTicketView,
+	"/memos.api.v1.AnalyticsService/GetTicketCumulativeFlow":       store.PermissionTicketView,
+	"/memos.api.v1.TicketViewService/ListTicketViews":              store.PermissionTicketView,
+	"/memos.api.v1.TicketViewService/CreateTicketView":             store.PermissionTicketView,
+	"/memos.api.v1.TicketViewService/UpdateTicketView":             store.PermissionTicketView,
+	"/memos.api.v1.TicketViewService/DeleteTicketView":             store.PermissionTicketView,
+}
+
+// getMethodPermission returns the permission required to call the method, if any.
+func getMethodPermission(fullMethodName string) (store.Permission, bool) {
+	permission, ok := methodPermissions[fullMethodName]
+	return permission, ok
+}
+
+// twoFactorEnrollmentAllowlistMethods are the methods a user who must enable two-factor
+// authentication can still call before enrolling.
+var twoFactorEnrollmentAllowlistMethods = map[string]bool{
+	"/memos.api.v1.AuthService/GetAuthStatus":                   true,
+	"/memos.api.v1.AuthService/SignOut":                         true,
+	"/memos.api.v1.AuthService/GetTwoFactorStatus":              true,
+	"/memos.api.v1.AuthService/SetupTwoFactor":                  true,
+	"/memos.api.v1.AuthService/EnableTwoFactor":                 true,
+	"/memos.api.v1.WorkspaceService/GetWorkspaceProfile":        true,
+	"/memos.api.v1.WorkspaceSettingService/GetWorkspaceSetting": true,
+	"/memos.api.v1.UserService/GetUser":                         true,
+	"/memos.api.v1.UserService/GetUserSetting":                  true,
+}
+
+// isTwoFactorEnrollmentAllowedMethod returns whether the method can be called before enrolling in two-factor authentication.
+func isTwoFactorEnrollmentAllowedMethod(fullMethodName string) bool {
+	return twoFactorEnrollmentAllowlistMethods[fullMethodName]
+}
+
+// methodAccessTokenScopes are the scopes that allow a scoped access token to call the methods.
+// Methods not listed here can only be called with a full access token.
+var methodAccessTokenScopes = map[string]store.AccessTokenScope{
+	"/memos.api.v1.ActivityService/GetActivity":                   store.AccessTokenScopeRead,
+	"/memos.api.v1.AnalyticsService/GetMemoActivity":              store.AccessTokenScopeRead,
+	"/memos.api.v1.AnalyticsService/GetTicketCumulativeFlow":      store.AccessTokenScopeRead,
+	"/memos.api.v1.AnalyticsService/GetTicketMetrics":             store.AccessTokenScopeRead,
+	"/memos.api.v1.AuthService/GetAuthStatus":                     store.AccessTokenScopeRead,
+	"/memos.api.v1.IdentityProviderService/GetIdentityProvider":   store.AccessTokenScopeRead,
+	"/memos.api.v1.IdentityProviderService/ListIdentityProviders": store.AccessTokenScopeRead,
+	"/memos.api.v1.InboxService/ListInboxes":                      store.AccessTokenScopeRead,
+	"/memos.api.v1.MarkdownService/GetLinkMetadata":               store.AccessTokenScopeRead,
+	"/memos.api.v1.MarkdownService/ParseMarkdown":                 store.AccessTokenScopeRead,
+	"/memos.api.v1.MarkdownService/RestoreMarkdownNodes":          store.AccessTokenScopeRead,
+	"/memos.api.v1.MarkdownService/RenderMarkdown":                store.AccessTokenScopeRead,
+	"/memos.api.v1.MarkdownService/StringifyMarkdownNodes":        store.AccessTokenScopeRead,
+	"/memos.api.v1.MemoService/GetMemo":                           store.AccessTokenScopeRead,
+	"/memos.api.v1.MemoService/ListMemoComments":                  store.AccessTokenScopeRead,
+	"/memos.api.v1.MemoService/ListMemoReactions":                 store.AccessTokenScopeRead,
+	"/memos.api.v1.MemoService/ListMemoRelations":                 store.AccessTokenScopeRead,
+	"/memos.api.v1.MemoService/ListBacklinks":                     store.AccessTokenScopeRead,
+	"/memos.api.v1.MemoService/ListMemoResources":                 store.AccessTokenScopeRead,
+	"/memos.api.v1.MemoService/ListMemos":                         store.AccessTokenScopeRead,
+	"/memos.api.v1.ResourceService/GetResource":                   store.AccessTokenScopeRead,
+	"/memos.api.v1.ResourceService/GetResourceBinary":             store.AccessTokenScopeRead,
+	"/memos.api.v1.ResourceService/ListResources":                 store.AccessTokenScopeRead,
+	"/memos.api.v1.RoleService/ListPermissions":                   store.AccessTokenScopeRead,
+	"/memos.api.v1.RoleService/ListUserRoles":                     store.AccessTokenScopeRead,
+	"/memos.api.v1.ShortcutService/ListShortcuts":                 store.AccessTokenScopeRead,
+	"/memos.api.v1.TicketViewService/ListTicketViews":             store.AccessTokenScopeRead,
+	"/memos.api.v1.UserService/GetUser":                           store.AccessTokenScopeRead,
+	"/memos.api.v1.UserService/GetUserAvatarBinary":               store.AccessTokenScopeRead,
+	"/memos.api.v1.UserService/GetUserByUsername":                 store.AccessTokenScopeRead,
+	"/memos.api.v1.UserService/GetUserSetting":                    store.AccessTokenScopeRead,
+	"/memos.api.v1.UserService/GetUserStats":                      store.AccessTokenScopeRead,
+	"/memos.api.v1.UserService/ListAllUserStats":                  store.AccessTokenScopeRead,
+	"/memos.api.v1.UserService/ListUsers":                         store.AccessTokenScopeRead,
+	"/memos.api.v1.WorkspaceService/GetWorkspaceProfile":          store.AccessTokenScopeRead,
+	"/memos.api.v1.WorkspaceSettingService/GetWorkspaceSetting":   store.AccessTokenScopeRead,
+	"/memos.api.v1.WorkspaceSettingService/ListWorkspaceSettings": store.AccessTokenScopeRead,
+
+	"/memos.api.v1.MemoService/CreateMemo":             store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.MemoService/CreateMemoComment":      store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.MemoService/DeleteMemo":             store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.MemoService/DeleteMemoReaction":     store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.MemoService/DeleteMemoTag":          store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.MemoService/PromoteMemoTask":        store.AccessTokenScopeTicketsWrite,
+	"/memos.api.v1.MemoService/RenameMemoTag":          store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.MemoService/SetMemoRelations":       store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.MemoService/SetMemoResources":       store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.MemoService/ToggleMemoTask":         store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.MemoService/UpdateMemo":             store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.MemoService/UpsertMemoReaction":     store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.ResourceService/CreateResource":     store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.ResourceService/DeleteResource":     store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.ResourceService/UpdateResource":     store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.ShortcutService/CreateShortcut":     store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.ShortcutService/DeleteShortcut":     store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.ShortcutService/UpdateShortcut":     store.AccessTokenScopeMemosWrite,
+	"/memos.api.v1.TicketViewService/CreateTicketView": store.AccessTokenScopeTicketsWrite,
+	"/memos.api.v1.TicketViewService/DeleteTicketView": store.AccessTokenScopeTicketsWrite,
+	"/memos.api.v1.TicketViewService/UpdateTicketView": store.AccessTokenScopeTicketsWrite,
+	"/memos.api.v1.WebhookService/CreateWebhook":       store.AccessTokenScopeWebhooks,
+	"/memos.api.v1.WebhookService/DeleteWebhook":       store.AccessTokenScopeWebhooks,
+	"/memos.api.v1.WebhookService/GetWebhook":          store.AccessTokenScopeWebhooks,
+	"/memos.api.v1.WebhookService/ListWebhooks":        store.AccessTokenScopeWebhooks,
+	"/memos.api.v1.WebhookService/UpdateWebhook":       store.AccessTokenScopeWebhooks,
+}
+
+// isAccessTokenScopeAllowedMethod returns whether an access token with the scopes can call the method.
+func isAccessTokenScopeAllowedMethod(fullMethodName string, scopes []string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	scope, ok := methodAccessTokenScopes[fullMethodName]
+	return ok && store.HasAccessTokenScope(scopes, scope)
 }
 
-// isOnlyForAdminAllowedMethod returns true if the method is allowed to be called only by admin.
-func isOnlyForAdminAllowedMethod(methodName string) bool {
-	return allowedMethodsOnlyForAdmin[methodName]
+// isAccessTokenScopeAllowedRoute returns whether an access token with the scopes can call the Echo route.
+// Reads are allowed by the read scope, and ticket writes by the tickets:write scope.
+func isAccessTokenScopeAllowedRoute(method, path string, scopes []string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return store.HasAccessTokenScope(scopes, store.AccessTokenScopeRead)
+	}
+	if path == "/api/v1/tickets" || strings.HasPrefix(path, "/api/v1/tickets/") || strings.HasPrefix(path, "/api/v1/tickets:") {
+		return store.HasAccessTokenScope(scopes, store.AccessTokenScopeTicketsWrite)
+	}
+	if path == "/api/v1/uploads" || strings.HasPrefix(path, "/api/v1/uploads/") {
+		return store.HasAccessTokenScope(scopes, store.AccessTokenScopeMemosWrite)
+	}
+	return false
 }
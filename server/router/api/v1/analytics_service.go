@@ -0,0 +1,242 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// defaultTicketMetricsRange is the range of the ticket metrics when the start is not set.
+	defaultTicketMetricsRange = 30 * 24 * time.Hour
+	// defaultMemoActivityRange is the range of the memo activity when the start is not set.
+	defaultMemoActivityRange = 365 * 24 * time.Hour
+	// maxAnalyticsPeriods is the number of periods a range can be split into.
+	maxAnalyticsPeriods = 400
+)
+
+func (s *APIV1Service) GetTicketMetrics(ctx context.Context, request *v1pb.GetTicketMetricsRequest) (*v1pb.TicketMetrics, error) {
+	find, err := s.buildTicketMetricsFind(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := s.Store.GetTicketMetrics(ctx, find)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get ticket metrics: %v", err)
+	}
+
+	response := &v1pb.TicketMetrics{
+		LeadTime:  convertDurationPercentilesFromStore(metrics.LeadTime),
+		CycleTime: convertDurationPercentilesFromStore(metrics.CycleTime),
+	}
+	// Every period is listed, including the ones without tickets opened or closed.
+	periodCounts := map[int32]*store.TicketPeriodCount{}
+	for _, count := range metrics.Periods {
+		periodCounts[count.Period] = count
+	}
+	for period := 0; period < store.CountPeriods(find.StartTs, find.EndTs, find.PeriodSeconds); period++ {
+		periodMessage := &v1pb.TicketMetrics_Period{
+			StartTime: timestamppb.New(time.Unix(find.StartTs+int64(period)*find.PeriodSeconds, 0)),
+		}
+		if count, ok := periodCounts[int32(period)]; ok {
+			periodMessage.Opened = count.Opened
+			periodMessage.Closed = count.Closed
+		}
+		response.Periods = append(response.Periods, periodMessage)
+	}
+	for _, throughput := range metrics.Throughput {
+		throughputMessage := &v1pb.TicketMetrics_AssigneeThroughput{
+			Closed: throughput.Closed,
+		}
+		if throughput.AssigneeID != nil {
+			throughputMessage.Assignee = fmt.Sprintf("%s%d", UserNamePrefix, *throughput.AssigneeID)
+		}
+		response.Throughput = append(response.Throughput, throughputMessage)
+	}
+	backlogCounts := map[int32]int32{}
+	for _, count := range metrics.BacklogAge {
+		backlogCounts[count.Bucket] = count.Count
+	}
+	for i, minAge := range store.TicketBacklogAgeBuckets {
+		bucket := &v1pb.TicketMetrics_BacklogAgeBucket{
+			MinAge: durationpb.New(time.Duration(minAge) * time.Second),
+			Count:  backlogCounts[int32(i)],
+		}
+		if i+1 < len(store.TicketBacklogAgeBuckets) {
+			bucket.MaxAge = durationpb.New(time.Duration(store.TicketBacklogAgeBuckets[i+1]) * time.Second)
+		}
+		response.BacklogAge = append(response.BacklogAge, bucket)
+	}
+	return response, nil
+}
+
+func (s *APIV1Service) GetTicketCumulativeFlow(ctx context.Context, request *v1pb.GetTicketMetricsRequest) (*v1pb.TicketCumulativeFlow, error) {
+	find, err := s.buildTicketMetricsFind(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.Store.GetTicketCumulativeFlow(ctx, find)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get ticket cumulative flow: %v", err)
+	}
+
+	response := &v1pb.TicketCumulativeFlow{}
+	for _, counts := range list {
+		endTs := min(find.StartTs+int64(counts.Period+1)*find.PeriodSeconds, find.EndTs)
+		point := &v1pb.TicketCumulativeFlow_Point{
+			EndTime:      timestamppb.New(time.Unix(endTs, 0)),
+			StatusCounts: map[string]int32{},
+		}
+		for ticketStatus, count := range counts.Counts {
+			point.StatusCounts[string(ticketStatus)] = count
+		}
+		response.Points = append(response.Points, point)
+	}
+	return response, nil
+}
+
+func (s *APIV1Service) GetMemoActivity(ctx context.Context, request *v1pb.GetMemoActivityRequest) (*v1pb.MemoActivity, error) {
+	startTs, endTs, err := getAnalyticsRange(request.StartTime, request.EndTime, defaultMemoActivityRange)
+	if err != nil {
+		return nil, err
+	}
+	normalStatus := store.Normal
+	memoFind := &store.FindMemo{
+		// Exclude comments by default.
+		ExcludeComments: true,
+		ExcludeContent:  true,
+		RowStatus:       &normalStatus,
+	}
+	if request.Creator != "" {
+		creatorID, err := ExtractUserIDFromName(request.Creator)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid creator name: %v", err)
+		}
+		memoFind.CreatorID = &creatorID
+	}
+
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if currentUser == nil {
+		memoFind.VisibilityList = []store.Visibility{store.Public}
+	} else if memoFind.CreatorID == nil || *memoFind.CreatorID != currentUser.ID {
+		filter, err := s.buildMemoVisibilityFilter(ctx, currentUser, memoFind.CreatorID == nil)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to build memo visibility filter: %v", err)
+		}
+		memoFind.Filter = &filter
+	}
+
+	list, err := s.Store.ListMemoActivity(ctx, &store.FindMemoActivity{
+		MemoFind:  memoFind,
+		StartTs:   startTs,
+		EndTs:     endTs,
+		UTCOffset: int64(request.UtcOffset.AsDuration().Seconds()),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memo activity: %v", err)
+	}
+
+	response := &v1pb.MemoActivity{}
+	for _, count := range list {
+		response.Cells = append(response.Cells, &v1pb.MemoActivity_Cell{
+			Weekday: count.Weekday,
+			Hour:    count.Hour,
+			Count:   count.Count,
+		})
+		response.Total += count.Count
+	}
+	return response, nil
+}
+
+// buildTicketMetricsFind returns the find of the ticket metrics of the request, limited to the tickets the user can see.
+func (s *APIV1Service) buildTicketMetricsFind(ctx context.Context, request *v1pb.GetTicketMetricsRequest) (*store.FindTicketMetrics, error) {
+	startTs, endTs, err := getAnalyticsRange(request.StartTime, request.EndTime, defaultTicketMetricsRange)
+	if err != nil {
+		return nil, err
+	}
+	find := &store.FindTicketMetrics{
+		StartTs:       startTs,
+		EndTs:         endTs,
+		PeriodSeconds: int64((24 * time.Hour).Seconds()),
+	}
+	switch request.Period {
+	case v1pb.AnalyticsPeriod_ANALYTICS_PERIOD_UNSPECIFIED, v1pb.AnalyticsPeriod_DAY:
+	case v1pb.AnalyticsPeriod_WEEK:
+		find.PeriodSeconds = int64((7 * 24 * time.Hour).Seconds())
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid period: %v", request.Period)
+	}
+	if store.CountPeriods(find.StartTs, find.EndTs, find.PeriodSeconds) > maxAnalyticsPeriods {
+		return nil, status.Errorf(codes.InvalidArgument, "the range is split into more than %d periods", maxAnalyticsPeriods)
+	}
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user")
+	}
+	canManage, err := s.Store.HasPermission(ctx, user, store.PermissionProjectManage)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check permission")
+	}
+	if request.Project != "" {
+		projectID, err := ExtractProjectIDFromName(request.Project)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid project name: %v", err)
+		}
+		if !canManage {
+			member, err := s.Store.GetProjectMember(ctx, &store.FindProjectMember{ProjectID: &projectID, UserID: &user.ID})
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get project member: %v", err)
+			}
+			if member == nil {
+				return nil, status.Errorf(codes.PermissionDenied, "permission denied: not a member of the project")
+			}
+		}
+		find.ProjectID = &projectID
+	} else if !canManage {
+		// The tickets of projects the user is not a member of are not counted.
+		projectIDs, err := s.Store.ListUserProjectIDs(ctx, user.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list user projects: %v", err)
+		}
+		find.VisibleProjectIDs = append([]int32{}, projectIDs...)
+	}
+	return find, nil
+}
+
+// getAnalyticsRange returns the range of the request in unix seconds, which ends now and spans defaultRange by default.
+func getAnalyticsRange(startTime, endTime *timestamppb.Timestamp, defaultRange time.Duration) (int64, int64, error) {
+	// Round up to the minute, so that the results of the default range can be cached.
+	end := time.Now().Truncate(time.Minute).Add(time.Minute)
+	if endTime != nil {
+		end = endTime.AsTime()
+	}
+	start := end.Add(-defaultRange)
+	if startTime != nil {
+		start = startTime.AsTime()
+	}
+	if !start.Before(end) {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "the start time must be before the end time")
+	}
+	return start.Unix(), end.Unix(), nil
+}
+
+func convertDurationPercentilesFromStore(percentiles *store.DurationPercentiles) *v1pb.DurationPercentiles {
+	return &v1pb.DurationPercentiles{
+		Count: percentiles.Count,
+		P50:   durationpb.New(time.Duration(percentiles.P50) * time.Second),
+		P85:   durationpb.New(time.Duration(percentiles.P85) * time.Second),
+		P95:   durationpb.New(time.Duration(percentiles.P95) * time.Second),
+	}
+}
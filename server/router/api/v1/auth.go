@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -15,27 +16,62 @@ const (
 	KeyID = "v1"
 	// AccessTokenAudienceName is the audience name of the access token.
 	AccessTokenAudienceName = "user.access-token"
-	AccessTokenDuration     = 7 * 24 * time.Hour
+	// AccessTokenDuration is the lifetime of the access token issued for a session.
+	// The session is kept alive by rotating its refresh token.
+	AccessTokenDuration = 15 * time.Minute
+	// SessionDuration is how long a session lasts without being refreshed.
+	SessionDuration = 7 * 24 * time.Hour
+	// PersistentSessionDuration is how long a "never expire" session lasts without being refreshed.
+	PersistentSessionDuration = 30 * 24 * time.Hour
 
 	// CookieExpDuration expires slightly earlier than the jwt expiration. Client would be logged out if the user
 	// cookie expires, thus the client would always logout first before attempting to make a request with the expired jwt.
 	CookieExpDuration = AccessTokenDuration - 1*time.Minute
 	// AccessTokenCookieName is the cookie name of access token.
 	AccessTokenCookieName = "memos.access-token"
+	// RefreshTokenCookieName is the cookie name of the session refresh token.
+	RefreshTokenCookieName = "memos.refresh-token"
 )
 
 type ClaimsMessage struct {
 	Name string `json:"name"`
+	// SessionID is the session the access token was issued for, empty for personal access tokens.
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // GenerateAccessToken generates an access token.
 func GenerateAccessToken(username string, userID int32, expirationTime time.Time, secret []byte) (string, error) {
-	return generateToken(username, userID, AccessTokenAudienceName, expirationTime, secret)
+	return generateToken(username, userID, "", AccessTokenAudienceName, expirationTime, secret)
+}
+
+// GenerateSessionAccessToken generates an access token bound to the session.
+func GenerateSessionAccessToken(username string, userID int32, sessionID string, expirationTime time.Time, secret []byte) (string, error) {
+	return generateToken(username, userID, sessionID, AccessTokenAudienceName, expirationTime, secret)
+}
+
+// ParseAccessToken verifies the signature and expiration of the access token and returns its claims.
+func ParseAccessToken(accessToken string, secret []byte) (*ClaimsMessage, error) {
+	claims := &ClaimsMessage{}
+	_, err := jwt.ParseWithClaims(accessToken, claims, func(t *jwt.Token) (any, error) {
+		if t.Method.Alg() != jwt.SigningMethodHS256.Name {
+			return nil, errors.Errorf("unexpected access token signing method=%v, expect %v", t.Header["alg"], jwt.SigningMethodHS256)
+		}
+		if kid, ok := t.Header["kid"].(string); ok {
+			if kid == KeyID {
+				return secret, nil
+			}
+		}
+		return nil, errors.Errorf("unexpected access token kid=%v", t.Header["kid"])
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
 }
 
 // generateToken generates a jwt token.
-func generateToken(username string, userID int32, audience string, expirationTime time.Time, secret []byte) (string, error) {
+func generateToken(username string, userID int32, sessionID string, audience string, expirationTime time.Time, secret []byte) (string, error) {
 	registeredClaims := jwt.RegisteredClaims{
 		Issuer:   Issuer,
 		Audience: jwt.ClaimStrings{audience},
@@ -49,6 +85,7 @@ func generateToken(username string, userID int32, audience string, expirationTim
 	// Declare the token with the HS256 algorithm used for signing, and the claims.
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &ClaimsMessage{
 		Name:             username,
+		SessionID:        sessionID,
 		RegisteredClaims: registeredClaims,
 	})
 	token.Header["kid"] = KeyID
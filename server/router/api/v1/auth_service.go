@@ -2,24 +2,19 @@ package v1
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
-	"regexp"
 	"strings"
-	"time"
 
-	"github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/usememos/memos/internal/base"
-	"github.com/usememos/memos/internal/util"
 	"github.com/usememos/memos/plugin/idp"
 	"github.com/usememos/memos/plugin/idp/oauth2"
+	"github.com/usememos/memos/plugin/idp/oidc"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
@@ -35,8 +30,8 @@ func (s *APIV1Service) GetAuthStatus(ctx context.Context, _ *v1pb.GetAuthStatusR
 		return nil, status.Errorf(codes.Unauthenticated, "failed to get current user: %v", err)
 	}
 	if user == nil {
-		// Set the cookie header to expire access token.
-		if err := s.clearAccessTokenCookie(ctx); err != nil {
+		// Set the cookie header to expire the session tokens.
+		if err := clearSessionCookies(ctx); err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to set grpc header: %v", err)
 		}
 		return nil, status.Errorf(codes.Unauthenticated, "user not found")
@@ -46,29 +41,51 @@ func (s *APIV1Service) GetAuthStatus(ctx context.Context, _ *v1pb.GetAuthStatusR
 
 func (s *APIV1Service) SignIn(ctx context.Context, request *v1pb.SignInRequest) (*v1pb.User, error) {
 	var existingUser *store.User
+	// lockoutUsername is the username the failed sign ins are counted by.
+	var lockoutUsername string
 	if passwordCredentials := request.GetPasswordCredentials(); passwordCredentials != nil {
+		// Locked out accounts are rejected before the password is checked.
+		if err := s.checkSignInLockout(ctx, passwordCredentials.Username); err != nil {
+			return nil, err
+		}
 		user, err := s.Store.GetUser(ctx, &store.FindUser{
 			Username: &passwordCredentials.Username,
 		})
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to get user, error: %v", err)
 		}
-		if user == nil {
-			return nil, status.Errorf(codes.InvalidArgument, unmatchedUsernameAndPasswordError)
-		}
-		// Compare the stored hashed password, with the hashed version of the password that was received.
-		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(passwordCredentials.Password)); err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, unmatchedUsernameAndPasswordError)
-		}
-		workspaceGeneralSetting, err := s.Store.GetWorkspaceGeneralSetting(ctx)
+		ldapIdentityProviders, err := s.listLDAPIdentityProviders(ctx)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to get workspace general setting, error: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to list ldap identity providers, error: %v", err)
 		}
-		// Check if the password auth in is allowed.
-		if workspaceGeneralSetting.DisallowPasswordAuth && user.Role == store.RoleUser {
-			return nil, status.Errorf(codes.PermissionDenied, "password signin is not allowed")
+		// Compare the stored hashed password, with the hashed version of the password that was received.
+		if user != nil && isLocalPasswordAllowed(user, ldapIdentityProviders) && bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(passwordCredentials.Password)) == nil {
+			workspaceGeneralSetting, err := s.Store.GetWorkspaceGeneralSetting(ctx)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get workspace general setting, error: %v", err)
+			}
+			// Check if the password auth in is allowed.
+			if workspaceGeneralSetting.DisallowPasswordAuth && user.Role == store.RoleUser {
+				return nil, status.Errorf(codes.PermissionDenied, "password signin is not allowed")
+			}
+		} else {
+			// Fall back to the directory when the local password is missing, wrong or not allowed.
+			user, err = s.signInWithLDAP(ctx, ldapIdentityProviders, passwordCredentials.Username, passwordCredentials.Password)
+			if err != nil {
+				return nil, err
+			}
+			if user == nil {
+				lockoutDuration, err := s.rateLimiter.failSignIn(ctx, passwordCredentials.Username)
+				if err != nil {
+					return nil, status.Errorf(codes.Internal, "failed to record failed sign in, error: %v", err)
+				}
+				if lockoutDuration > 0 {
+					return nil, resourceExhaustedError(ctx, "too many failed sign in attempts", lockoutDuration)
+				}
+				return nil, status.Errorf(codes.InvalidArgument, unmatchedUsernameAndPasswordError)
+			}
 		}
-		existingUser = user
+		existingUser, lockoutUsername = user, passwordCredentials.Username
 	} else if ssoCredentials := request.GetSsoCredentials(); ssoCredentials != nil {
 		identityProvider, err := s.Store.GetIdentityProvider(ctx, &store.FindIdentityProvider{
 			ID: &ssoCredentials.IdpId,
@@ -94,59 +111,37 @@ func (s *APIV1Service) SignIn(ctx context.Context, request *v1pb.SignInRequest)
 			if err != nil {
 				return nil, status.Errorf(codes.Internal, "failed to get user info, error: %v", err)
 			}
-		}
-
-		identifierFilter := identityProvider.IdentifierFilter
-		if identifierFilter != "" {
-			identifierFilterRegex, err := regexp.Compile(identifierFilter)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to compile identifier filter regex, error: %v", err)
-			}
-			if !identifierFilterRegex.MatchString(userInfo.Identifier) {
-				return nil, status.Errorf(codes.PermissionDenied, "identifier %s is not allowed", userInfo.Identifier)
+		} else if identityProvider.Type == storepb.IdentityProvider_OIDC {
+			authorization, ok := s.ssoAuthorizations.take(ctx, ssoCredentials.State)
+			if !ok || authorization.IdentityProviderID != identityProvider.Id || authorization.RedirectURI != ssoCredentials.RedirectUri {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid or expired sso state")
 			}
-		}
-
-		user, err := s.Store.GetUser(ctx, &store.FindUser{
-			Username: &userInfo.Identifier,
-		})
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to get user, error: %v", err)
-		}
-		if user == nil {
-			// Check if the user is allowed to sign up.
-			workspaceGeneralSetting, err := s.Store.GetWorkspaceGeneralSetting(ctx)
+			oidcIdentityProvider, err := oidc.NewIdentityProvider(ctx, identityProvider.Config.GetOidcConfig())
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to get workspace general setting, error: %v", err)
-			}
-			if workspaceGeneralSetting.DisallowUserRegistration {
-				return nil, status.Errorf(codes.PermissionDenied, "user registration is not allowed")
+				return nil, status.Errorf(codes.Internal, "failed to create oidc identity provider, error: %v", err)
 			}
-
-			// Create a new user with the user info from the identity provider.
-			userCreate := &store.User{
-				Username: userInfo.Identifier,
-				// The new signup user should be normal user by default.
-				Role:      store.RoleUser,
-				Nickname:  userInfo.DisplayName,
-				Email:     userInfo.Email,
-				AvatarURL: userInfo.AvatarURL,
-			}
-			password, err := util.RandomString(20)
+			token, err := oidcIdentityProvider.ExchangeToken(ctx, ssoCredentials.RedirectUri, ssoCredentials.Code, authorization.CodeVerifier)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to generate random password, error: %v", err)
+				return nil, status.Errorf(codes.Internal, "failed to exchange token, error: %v", err)
 			}
-			passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+			claims, err := oidcIdentityProvider.VerifyIDToken(ctx, token.IDToken, authorization.Nonce)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to generate password hash, error: %v", err)
+				return nil, status.Errorf(codes.Unauthenticated, "failed to verify id token, error: %v", err)
 			}
-			userCreate.PasswordHash = string(passwordHash)
-			user, err = s.Store.CreateUser(ctx, userCreate)
+			userInfo, err = oidcIdentityProvider.UserInfo(ctx, token, claims)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to create user, error: %v", err)
+				return nil, status.Errorf(codes.Internal, "failed to get user info, error: %v", err)
 			}
 		}
-		existingUser = user
+		if userInfo == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported identity provider type %s", identityProvider.Type)
+		}
+
+		user, err := s.provisionSSOUser(ctx, identityProvider, userInfo)
+		if err != nil {
+			return nil, err
+		}
+		existingUser, lockoutUsername = user, user.Username
 	}
 
 	if existingUser == nil {
@@ -156,36 +151,28 @@ func (s *APIV1Service) SignIn(ctx context.Context, request *v1pb.SignInRequest)
 		return nil, status.Errorf(codes.PermissionDenied, "user has been archived with username %s", existingUser.Username)
 	}
 
-	expireTime := time.Now().Add(AccessTokenDuration)
-	if request.NeverExpire {
-		// Set the expire time to 100 years.
-		expireTime = time.Now().Add(100 * 365 * 24 * time.Hour)
+	if err := s.requireTwoFactor(ctx, existingUser, lockoutUsername, request.NeverExpire); err != nil {
+		return nil, err
 	}
-	if err := s.doSignIn(ctx, existingUser, expireTime); err != nil {
+	if err := s.doSignIn(ctx, existingUser, request.NeverExpire); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to sign in, error: %v", err)
 	}
+	// The failed sign ins are only forgotten once all the factors have passed.
+	s.rateLimiter.resetSignIn(lockoutUsername)
 	return convertUserFromStore(existingUser), nil
 }
 
-func (s *APIV1Service) doSignIn(ctx context.Context, user *store.User, expireTime time.Time) error {
-	accessToken, err := GenerateAccessToken(user.Email, user.ID, expireTime, []byte(s.Secret))
+// doSignIn starts a new session for the user and sets its tokens as cookies.
+// A persistent session outlives the browser session and lasts longer without being refreshed.
+func (s *APIV1Service) doSignIn(ctx context.Context, user *store.User, persistent bool) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	tokens, err := createSession(ctx, s.Store, s.Secret, user, persistent, getClientIPFromMetadata(ctx, md, s.Profile), getUserAgentFromMetadata(md))
 	if err != nil {
-		return status.Errorf(codes.Internal, "failed to generate access token, error: %v", err)
+		return status.Errorf(codes.Internal, "failed to create session, error: %v", err)
 	}
-	if err := s.UpsertAccessTokenToStore(ctx, user, accessToken, "user login"); err != nil {
-		return status.Errorf(codes.Internal, "failed to upsert access token to store, error: %v", err)
+	if err := setSessionCookies(ctx, tokens); err != nil {
+		return status.Errorf(codes.Internal, "failed to set session cookies, error: %v", err)
 	}
-
-	cookie, err := s.buildAccessTokenCookie(ctx, accessToken, expireTime)
-	if err != nil {
-		return status.Errorf(codes.Internal, "failed to build access token cookie, error: %v", err)
-	}
-	if err := grpc.SetHeader(ctx, metadata.New(map[string]string{
-		"Set-Cookie": cookie,
-	})); err != nil {
-		return status.Errorf(codes.Internal, "failed to set grpc header, error: %v", err)
-	}
-
 	return nil
 }
 
@@ -231,76 +218,33 @@ func (s *APIV1Service) SignUp(ctx context.Context, request *v1pb.SignUpRequest)
 		return nil, status.Errorf(codes.Internal, "failed to create user, error: %v", err)
 	}
 
-	if err := s.doSignIn(ctx, user, time.Now().Add(AccessTokenDuration)); err != nil {
+	if err := s.doSignIn(ctx, user, false); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to sign in, error: %v", err)
 	}
 	return convertUserFromStore(user), nil
 }
 
 func (s *APIV1Service) SignOut(ctx context.Context, _ *v1pb.SignOutRequest) (*emptypb.Empty, error) {
-	accessToken, ok := ctx.Value(accessTokenContextKey).(string)
-	// Try to delete the access token from the store.
-	if ok {
-		user, _ := s.GetCurrentUser(ctx)
-		if user != nil {
-			if _, err := s.DeleteUserAccessToken(ctx, &v1pb.DeleteUserAccessTokenRequest{
-				Name:        fmt.Sprintf("%s%d", UserNamePrefix, user.ID),
-				AccessToken: accessToken,
-			}); err != nil {
+	user, _ := s.GetCurrentUser(ctx)
+	if user != nil {
+		if sessionID, ok := ctx.Value(sessionIDContextKey).(string); ok {
+			if err := s.Store.RemoveUserSession(ctx, user.ID, sessionID); err != nil {
+				slog.Error("failed to remove session", "error", err)
+			}
+		} else if accessToken, ok := ctx.Value(accessTokenContextKey).(string); ok {
+			// Try to delete the access token from the store.
+			if err := s.Store.RemoveUserAccessToken(ctx, user.ID, accessToken); err != nil {
 				slog.Error("failed to delete access token", "error", err)
 			}
 		}
 	}
 
-	if err := s.clearAccessTokenCookie(ctx); err != nil {
+	if err := clearSessionCookies(ctx); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to set grpc header, error: %v", err)
 	}
 	return &emptypb.Empty{}, nil
 }
 
-func (s *APIV1Service) clearAccessTokenCookie(ctx context.Context) error {
-	cookie, err := s.buildAccessTokenCookie(ctx, "", time.Time{})
-	if err != nil {
-		return errors.Wrap(err, "failed to build access token cookie")
-	}
-	if err := grpc.SetHeader(ctx, metadata.New(map[string]string{
-		"Set-Cookie": cookie,
-	})); err != nil {
-		return errors.Wrap(err, "failed to set grpc header")
-	}
-	return nil
-}
-
-func (*APIV1Service) buildAccessTokenCookie(ctx context.Context, accessToken string, expireTime time.Time) (string, error) {
-	attrs := []string{
-		fmt.Sprintf("%s=%s", AccessTokenCookieName, accessToken),
-		"Path=/",
-		"HttpOnly",
-	}
-	if expireTime.IsZero() {
-		attrs = append(attrs, "Expires=Thu, 01 Jan 1970 00:00:00 GMT")
-	} else {
-		attrs = append(attrs, "Expires="+expireTime.Format(time.RFC1123))
-	}
-
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return "", errors.New("failed to get metadata from context")
-	}
-	var origin string
-	for _, v := range md.Get("origin") {
-		origin = v
-	}
-	isHTTPS := strings.HasPrefix(origin, "https://")
-	if isHTTPS {
-		attrs = append(attrs, "SameSite=None")
-		attrs = append(attrs, "Secure")
-	} else {
-		attrs = append(attrs, "SameSite=Strict")
-	}
-	return strings.Join(attrs, "; "), nil
-}
-
 func (s *APIV1Service) GetCurrentUser(ctx context.Context) (*store.User, error) {
 	username, ok := ctx.Value(usernameContextKey).(string)
 	if !ok {
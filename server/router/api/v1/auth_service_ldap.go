@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/idp/ldap"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func (s *APIV1Service) listLDAPIdentityProviders(ctx context.Context) ([]*storepb.IdentityProvider, error) {
+	identityProviders, err := s.Store.ListIdentityProviders(ctx, &store.FindIdentityProvider{})
+	if err != nil {
+		return nil, err
+	}
+	ldapIdentityProviders := []*storepb.IdentityProvider{}
+	for _, identityProvider := range identityProviders {
+		if identityProvider.Type == storepb.IdentityProvider_LDAP {
+			ldapIdentityProviders = append(ldapIdentityProviders, identityProvider)
+		}
+	}
+	return ldapIdentityProviders, nil
+}
+
+// isLocalPasswordAllowed reports whether the local password of the user may be checked.
+// Any LDAP provider replacing local passwords disables them for everyone but the host,
+// so the workspace cannot be locked out when the directory is unreachable.
+func isLocalPasswordAllowed(user *store.User, ldapIdentityProviders []*storepb.IdentityProvider) bool {
+	if user.Role == store.RoleHost {
+		return true
+	}
+	for _, identityProvider := range ldapIdentityProviders {
+		if !identityProvider.Config.GetLdapConfig().GetAllowLocalPassword() {
+			return false
+		}
+	}
+	return true
+}
+
+// signInWithLDAP tries the LDAP providers in order and provisions the user of the first one accepting the credentials.
+// It returns nil when no provider accepts them.
+func (s *APIV1Service) signInWithLDAP(ctx context.Context, ldapIdentityProviders []*storepb.IdentityProvider, username, password string) (*store.User, error) {
+	for _, identityProvider := range ldapIdentityProviders {
+		ldapIdentityProvider, err := ldap.NewIdentityProvider(identityProvider.Config.GetLdapConfig())
+		if err != nil {
+			slog.Warn("invalid ldap identity provider", slog.String("name", identityProvider.Name), slog.Any("err", err))
+			continue
+		}
+		userInfo, err := ldapIdentityProvider.Authenticate(username, password)
+		if err != nil {
+			if !errors.Is(err, ldap.ErrInvalidCredentials) {
+				slog.Warn("failed to authenticate with ldap identity provider", slog.String("name", identityProvider.Name), slog.Any("err", err))
+			}
+			continue
+		}
+		return s.provisionSSOUser(ctx, identityProvider, userInfo)
+	}
+	return nil, nil
+}
@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func (s *APIV1Service) RefreshSession(ctx context.Context, request *v1pb.RefreshSessionRequest) (*v1pb.RefreshSessionResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	refreshToken, fromCookie := request.RefreshToken, false
+	if refreshToken == "" {
+		refreshToken, fromCookie = getCookieFromMetadata(md, RefreshTokenCookieName), true
+	}
+	if refreshToken == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "refresh token not found")
+	}
+
+	tokens, err := refreshSession(ctx, s.Store, s.Secret, refreshToken, getClientIPFromMetadata(ctx, md, s.Profile), getUserAgentFromMetadata(md))
+	if err != nil {
+		if fromCookie {
+			if err := clearSessionCookies(ctx); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to set grpc header, error: %v", err)
+			}
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "failed to refresh session: %v", err)
+	}
+	response := &v1pb.RefreshSessionResponse{
+		AccessTokenExpireTime: timestamppb.New(tokens.AccessTokenExpireTime),
+	}
+	if fromCookie {
+		// Keep the tokens out of reach of scripts when they are sent as cookies.
+		if err := setSessionCookies(ctx, tokens); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to set session cookies, error: %v", err)
+		}
+		return response, nil
+	}
+	response.AccessToken = tokens.AccessToken
+	response.RefreshToken = tokens.RefreshToken
+	return response, nil
+}
+
+func (s *APIV1Service) ListSessions(ctx context.Context, _ *v1pb.ListSessionsRequest) (*v1pb.ListSessionsResponse, error) {
+	user, err := s.getCurrentUserOrUnauthenticated(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userSessions, err := s.Store.GetUserSessions(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list sessions: %v", err)
+	}
+	currentSessionID, _ := ctx.Value(sessionIDContextKey).(string)
+	sessions := []*v1pb.Session{}
+	for _, userSession := range userSessions {
+		session := convertSessionFromStore(userSession)
+		session.Current = userSession.SessionId == currentSessionID
+		sessions = append(sessions, session)
+	}
+	// Sort by last seen time in descending order.
+	slices.SortFunc(sessions, func(i, j *v1pb.Session) int {
+		return j.LastSeenTime.AsTime().Compare(i.LastSeenTime.AsTime())
+	})
+	return &v1pb.ListSessionsResponse{Sessions: sessions}, nil
+}
+
+func (s *APIV1Service) RevokeSession(ctx context.Context, request *v1pb.RevokeSessionRequest) (*emptypb.Empty, error) {
+	user, err := s.getCurrentUserOrUnauthenticated(ctx)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.Store.GetUserSession(ctx, user.ID, request.SessionId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get session: %v", err)
+	}
+	if session == nil {
+		return nil, status.Errorf(codes.NotFound, "session not found")
+	}
+	if err := s.Store.RemoveUserSession(ctx, user.ID, request.SessionId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke session: %v", err)
+	}
+	if currentSessionID, _ := ctx.Value(sessionIDContextKey).(string); currentSessionID == request.SessionId {
+		if err := clearSessionCookies(ctx); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to set grpc header, error: %v", err)
+		}
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *APIV1Service) RevokeAllSessions(ctx context.Context, _ *v1pb.RevokeAllSessionsRequest) (*emptypb.Empty, error) {
+	user, err := s.getCurrentUserOrUnauthenticated(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Store.RemoveUserSessions(ctx, user.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke sessions: %v", err)
+	}
+	if _, ok := ctx.Value(sessionIDContextKey).(string); ok {
+		if err := clearSessionCookies(ctx); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to set grpc header, error: %v", err)
+		}
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func convertSessionFromStore(session *storepb.SessionsUserSetting_Session) *v1pb.Session {
+	return &v1pb.Session{
+		Id:           session.SessionId,
+		Device:       describeUserAgent(session.UserAgent),
+		UserAgent:    session.UserAgent,
+		Ip:           session.Ip,
+		CreateTime:   session.CreateTime,
+		LastSeenTime: session.LastSeenTime,
+		ExpireTime:   session.ExpireTime,
+	}
+}
@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/idp"
+	"github.com/usememos/memos/plugin/idp/oidc"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// ssoAuthorizationDuration is how long a started SSO authorization can be completed.
+const ssoAuthorizationDuration = 10 * time.Minute
+
+// ssoAuthorization is a pending SSO sign in keyed by its state.
+type ssoAuthorization struct {
+	IdentityProviderID int32
+	RedirectURI        string
+	Nonce              string
+	CodeVerifier       string
+}
+
+func (s *APIV1Service) CreateSSOAuthorization(ctx context.Context, request *v1pb.CreateSSOAuthorizationRequest) (*v1pb.SSOAuthorization, error) {
+	if request.RedirectUri == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "redirect uri is required")
+	}
+	identityProvider, err := s.Store.GetIdentityProvider(ctx, &store.FindIdentityProvider{
+		ID: &request.IdpId,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get identity provider, error: %v", err)
+	}
+	if identityProvider == nil {
+		return nil, status.Errorf(codes.NotFound, "identity provider not found")
+	}
+	if identityProvider.Type != storepb.IdentityProvider_OIDC {
+		return nil, status.Errorf(codes.FailedPrecondition, "identity provider does not support server side authorization")
+	}
+
+	oidcIdentityProvider, err := oidc.NewIdentityProvider(ctx, identityProvider.Config.GetOidcConfig())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create oidc identity provider, error: %v", err)
+	}
+	state, err := util.RandomString(32)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate state, error: %v", err)
+	}
+	nonce, err := util.RandomString(32)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate nonce, error: %v", err)
+	}
+	authorization := &ssoAuthorization{
+		IdentityProviderID: identityProvider.Id,
+		RedirectURI:        request.RedirectUri,
+		Nonce:              nonce,
+		CodeVerifier:       oauth2.GenerateVerifier(),
+	}
+	s.ssoAuthorizations.add(ctx, state, authorization)
+	return &v1pb.SSOAuthorization{
+		AuthorizationUrl: oidcIdentityProvider.AuthCodeURL(authorization.RedirectURI, state, authorization.Nonce, authorization.CodeVerifier),
+		State:            state,
+	}, nil
+}
+
+// provisionSSOUser applies the identifier filter and role mappings of the identity provider to the user info,
+// then creates the user or syncs the existing one.
+func (s *APIV1Service) provisionSSOUser(ctx context.Context, identityProvider *storepb.IdentityProvider, userInfo *idp.IdentityProviderUserInfo) (*store.User, error) {
+	provisioning := idp.GetProvisioningConfig(identityProvider.Config)
+	identifierFilter := identityProvider.IdentifierFilter
+	if identifierFilter != "" {
+		identifierFilterRegex, err := regexp.Compile(identifierFilter)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to compile identifier filter regex, error: %v", err)
+		}
+		if !identifierFilterRegex.MatchString(userInfo.Identifier) {
+			if err := s.archiveUnmatchedSSOUser(ctx, provisioning, userInfo.Identifier); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to archive user, error: %v", err)
+			}
+			return nil, status.Errorf(codes.PermissionDenied, "identifier %s is not allowed", userInfo.Identifier)
+		}
+	}
+	// The new signup user should be normal user by default.
+	role := store.RoleUser
+	if roleMappings := provisioning.GetRoleMappings(); len(roleMappings) > 0 {
+		matchedRole, ok := idp.MatchRoleMappings(roleMappings, userInfo)
+		if !ok && provisioning.GetArchiveUnmatchedUsers() {
+			if err := s.archiveUnmatchedSSOUser(ctx, provisioning, userInfo.Identifier); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to archive user, error: %v", err)
+			}
+			return nil, status.Errorf(codes.PermissionDenied, "identifier %s does not match any role mapping", userInfo.Identifier)
+		}
+		if ok {
+			role = store.Role(matchedRole)
+		}
+	}
+
+	user, err := s.Store.GetUser(ctx, &store.FindUser{
+		Username: &userInfo.Identifier,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user, error: %v", err)
+	}
+	if user == nil {
+		// Check if the user is allowed to sign up.
+		workspaceGeneralSetting, err := s.Store.GetWorkspaceGeneralSetting(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get workspace general setting, error: %v", err)
+		}
+		if workspaceGeneralSetting.DisallowUserRegistration {
+			return nil, status.Errorf(codes.PermissionDenied, "user registration is not allowed")
+		}
+
+		// Create a new user with the user info from the identity provider.
+		userCreate := &store.User{
+			Username:  userInfo.Identifier,
+			Role:      role,
+			Nickname:  userInfo.DisplayName,
+			Email:     userInfo.Email,
+			AvatarURL: userInfo.AvatarURL,
+		}
+		password, err := util.RandomString(20)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate random password, error: %v", err)
+		}
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate password hash, error: %v", err)
+		}
+		userCreate.PasswordHash = string(passwordHash)
+		user, err = s.Store.CreateUser(ctx, userCreate)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create user, error: %v", err)
+		}
+	} else if user.RowStatus == store.Normal {
+		user, err = s.syncSSOUser(ctx, user, provisioning, role, userInfo)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update user, error: %v", err)
+		}
+	}
+	return user, nil
+}
+
+// syncSSOUser updates the role and, when enabled, the profile of an existing user from the identity provider.
+// The role is only managed when the provider has role mappings, and the host is never demoted.
+func (s *APIV1Service) syncSSOUser(ctx context.Context, user *store.User, provisioning *storepb.ProvisioningConfig, role store.Role, userInfo *idp.IdentityProviderUserInfo) (*store.User, error) {
+	update := &store.UpdateUser{ID: user.ID}
+	changed := false
+	if len(provisioning.GetRoleMappings()) > 0 && user.Role != store.RoleHost && user.Role != role {
+		update.Role, changed = &role, true
+	}
+	if provisioning.GetSyncProfile() {
+		if userInfo.DisplayName != "" && userInfo.DisplayName != user.Nickname {
+			update.Nickname, changed = &userInfo.DisplayName, true
+		}
+		if userInfo.Email != "" && userInfo.Email != user.Email {
+			update.Email, changed = &userInfo.Email, true
+		}
+		if userInfo.AvatarURL != "" && userInfo.AvatarURL != user.AvatarURL {
+			update.AvatarURL, changed = &userInfo.AvatarURL, true
+		}
+	}
+	if !changed {
+		return user, nil
+	}
+	return s.Store.UpdateUser(ctx, update)
+}
+
+// archiveUnmatchedSSOUser archives the existing user with the username when the provider is configured to do so.
+func (s *APIV1Service) archiveUnmatchedSSOUser(ctx context.Context, provisioning *storepb.ProvisioningConfig, username string) error {
+	if !provisioning.GetArchiveUnmatchedUsers() {
+		return nil
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{Username: &username})
+	if err != nil {
+		return err
+	}
+	if user == nil || user.Role == store.RoleHost || user.RowStatus == store.Archived {
+		return nil
+	}
+	rowStatus := store.Archived
+	_, err = s.Store.UpdateUser(ctx, &store.UpdateUser{ID: user.ID, RowStatus: &rowStatus})
+	return err
+}
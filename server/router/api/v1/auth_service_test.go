@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/plugin/totp"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestSignInLockoutWithTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	_, err := s.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_RATE_LIMIT,
+		Value: &storepb.WorkspaceSetting_RateLimitSetting{
+			RateLimitSetting: &storepb.WorkspaceRateLimitSetting{
+				MaxFailedSignInAttempts: 3,
+				LockoutDurationSeconds:  60,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := createTestingUser(ctx, t, s, "alice", store.RoleUser)
+	passwordHashString := string(passwordHash)
+	_, err = s.Store.UpdateUser(ctx, &store.UpdateUser{ID: user.ID, PasswordHash: &passwordHashString})
+	require.NoError(t, err)
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	require.NoError(t, s.Store.UpsertUserTOTP(ctx, user.ID, &storepb.TOTPUserSetting{Secret: secret, Enabled: true}))
+
+	signIn := func(password string) error {
+		_, err := s.SignIn(ctx, &v1pb.SignInRequest{
+			Method: &v1pb.SignInRequest_PasswordCredentials{
+				PasswordCredentials: &v1pb.PasswordCredentials{Username: "alice", Password: password},
+			},
+		})
+		return err
+	}
+	getChallenge := func(err error) string {
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+		for _, detail := range status.Convert(err).Details() {
+			if errorInfo, ok := detail.(*errdetails.ErrorInfo); ok && errorInfo.Reason == twoFactorRequiredReason {
+				return errorInfo.Metadata["challenge"]
+			}
+		}
+		require.Fail(t, "no two-factor challenge", err)
+		return ""
+	}
+	signInWithWrongCode := func(challenge string) error {
+		_, err := s.SignInWithTwoFactor(ctx, &v1pb.SignInWithTwoFactorRequest{
+			Challenge: challenge,
+			Code:      &v1pb.SignInWithTwoFactorRequest_RecoveryCode{RecoveryCode: "wrong-code"},
+		})
+		return err
+	}
+
+	// A correct password does not forget the wrong one while the second factor is pending.
+	require.Equal(t, codes.InvalidArgument, status.Code(signIn("wrong")))
+	challenge := getChallenge(signIn("password"))
+	require.Equal(t, codes.InvalidArgument, status.Code(signInWithWrongCode(challenge)))
+	// Wrong codes count towards the lockout of the username, even across challenges.
+	challenge = getChallenge(signIn("password"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(signInWithWrongCode(challenge)))
+	require.Equal(t, codes.ResourceExhausted, status.Code(signIn("password")))
+}
+
+func TestVerifyTOTPCodeConcurrently(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	user := createTestingUser(ctx, t, s, "alice", store.RoleUser)
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	require.NoError(t, s.Store.UpsertUserTOTP(ctx, user.ID, &storepb.TOTPUserSetting{Secret: secret, Enabled: true}))
+	code, err := totp.GenerateCode(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	// Requests racing with the same code can't both use it.
+	const count = 8
+	results := make(chan bool, count)
+	errs := make(chan error, count)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for range count {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			valid, err := s.verifyTOTPCode(ctx, user.ID, code)
+			errs <- err
+			results <- valid
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+	close(results)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	validCount := 0
+	for valid := range results {
+		if valid {
+			validCount++
+		}
+	}
+	require.Equal(t, 1, validCount)
+}
@@ -0,0 +1,351 @@
+package v1
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/totp"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// twoFactorChallengeDuration is how long a sign in can wait for the second factor.
+	twoFactorChallengeDuration = 5 * time.Minute
+	// maxTwoFactorAttempts is the number of wrong codes allowed for a single challenge.
+	maxTwoFactorAttempts = 5
+	// recoveryCodeCount is the number of recovery codes generated at a time.
+	recoveryCodeCount = 10
+	// recoveryCodeAlphabet leaves out characters that are easily confused with each other.
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+	// twoFactorRequiredReason is the error reason returned by SignIn when a second factor is needed.
+	twoFactorRequiredReason = "TWO_FACTOR_REQUIRED"
+)
+
+// twoFactorChallenge is a sign in that passed the first factor and waits for the second one.
+type twoFactorChallenge struct {
+	UserID int32
+	// LockoutUsername is the username wrong codes are counted by, like wrong passwords.
+	LockoutUsername string
+	NeverExpire     bool
+	ExpiresAt       time.Time
+	Attempts        int
+}
+
+// requireTwoFactor starts a two-factor challenge for the user if they have enabled it.
+// The returned error carries the challenge in an ErrorInfo detail, and is nil if no challenge is needed.
+func (s *APIV1Service) requireTwoFactor(ctx context.Context, user *store.User, lockoutUsername string, neverExpire bool) error {
+	userTOTP, err := s.Store.GetUserTOTP(ctx, user.ID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get user totp, error: %v", err)
+	}
+	if !userTOTP.Enabled {
+		return nil
+	}
+	// Wrong codes lock the account out too, which must not be bypassed by starting a new challenge.
+	if err := s.checkSignInLockout(ctx, lockoutUsername); err != nil {
+		return err
+	}
+
+	key, err := util.RandomString(32)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to generate challenge, error: %v", err)
+	}
+	s.twoFactorChallenges.add(ctx, key, &twoFactorChallenge{
+		UserID:          user.ID,
+		LockoutUsername: lockoutUsername,
+		NeverExpire:     neverExpire,
+		ExpiresAt:       time.Now().Add(twoFactorChallengeDuration),
+	})
+	st, err := status.New(codes.Unauthenticated, "two-factor authentication required").WithDetails(&errdetails.ErrorInfo{
+		Reason:   twoFactorRequiredReason,
+		Domain:   "memos",
+		Metadata: map[string]string{"challenge": key},
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to build challenge, error: %v", err)
+	}
+	return st.Err()
+}
+
+func (s *APIV1Service) SignInWithTwoFactor(ctx context.Context, request *v1pb.SignInWithTwoFactorRequest) (*v1pb.User, error) {
+	challenge, ok := s.twoFactorChallenges.take(ctx, request.Challenge)
+	if !ok || time.Now().After(challenge.ExpiresAt) {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid or expired challenge")
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &challenge.UserID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user, error: %v", err)
+	}
+	if user == nil || user.RowStatus == store.Archived {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid or expired challenge")
+	}
+	if err := s.checkSignInLockout(ctx, challenge.LockoutUsername); err != nil {
+		return nil, err
+	}
+
+	var valid bool
+	switch code := request.Code.(type) {
+	case *v1pb.SignInWithTwoFactorRequest_TotpCode:
+		valid, err = s.verifyTOTPCode(ctx, user.ID, code.TotpCode)
+	case *v1pb.SignInWithTwoFactorRequest_RecoveryCode:
+		valid, err = s.Store.UseUserRecoveryCode(ctx, user.ID, code.RecoveryCode)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "totp code or recovery code is required")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify code, error: %v", err)
+	}
+	if !valid {
+		lockoutDuration, err := s.rateLimiter.failSignIn(ctx, challenge.LockoutUsername)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to record failed sign in, error: %v", err)
+		}
+		if lockoutDuration > 0 {
+			return nil, resourceExhaustedError(ctx, "too many failed sign in attempts", lockoutDuration)
+		}
+		// Put the challenge back so the user can retry until the attempts are used up.
+		challenge.Attempts++
+		if remaining := time.Until(challenge.ExpiresAt); challenge.Attempts < maxTwoFactorAttempts && remaining > 0 {
+			s.twoFactorChallenges.addWithTTL(ctx, request.Challenge, challenge, remaining)
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "invalid two-factor code")
+	}
+
+	if err := s.doSignIn(ctx, user, challenge.NeverExpire); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign in, error: %v", err)
+	}
+	s.rateLimiter.resetSignIn(challenge.LockoutUsername)
+	return convertUserFromStore(user), nil
+}
+
+// checkSignInLockout returns a RESOURCE_EXHAUSTED error if the username is locked out after failed sign ins.
+func (s *APIV1Service) checkSignInLockout(ctx context.Context, username string) error {
+	retryAfter, err := s.rateLimiter.checkSignInLockout(ctx, username)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check sign in lockout, error: %v", err)
+	}
+	if retryAfter > 0 {
+		return resourceExhaustedError(ctx, "too many failed sign in attempts", retryAfter)
+	}
+	return nil
+}
+
+func (s *APIV1Service) GetTwoFactorStatus(ctx context.Context, _ *v1pb.GetTwoFactorStatusRequest) (*v1pb.TwoFactorStatus, error) {
+	user, err := s.getCurrentUserOrUnauthenticated(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userTOTP, err := s.Store.GetUserTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user totp, error: %v", err)
+	}
+	required, err := s.Store.IsTwoFactorRequired(ctx, user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace setting, error: %v", err)
+	}
+	twoFactorStatus := &v1pb.TwoFactorStatus{
+		Enabled:  userTOTP.Enabled,
+		Required: required,
+	}
+	if userTOTP.Enabled {
+		hashes, err := s.Store.GetUserRecoveryCodeHashes(ctx, user.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get recovery codes, error: %v", err)
+		}
+		twoFactorStatus.RecoveryCodesRemaining = int32(len(hashes))
+	}
+	return twoFactorStatus, nil
+}
+
+func (s *APIV1Service) SetupTwoFactor(ctx context.Context, _ *v1pb.SetupTwoFactorRequest) (*v1pb.TwoFactorSetup, error) {
+	user, err := s.getCurrentUserOrUnauthenticated(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userTOTP, err := s.Store.GetUserTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user totp, error: %v", err)
+	}
+	if userTOTP.Enabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate secret, error: %v", err)
+	}
+	// The secret is saved disabled until the user proves they can generate codes with it.
+	if err := s.Store.UpsertUserTOTP(ctx, user.ID, &storepb.TOTPUserSetting{Secret: secret}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save user totp, error: %v", err)
+	}
+	issuer := "Memos"
+	workspaceGeneralSetting, err := s.Store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace general setting, error: %v", err)
+	}
+	if title := workspaceGeneralSetting.GetCustomProfile().GetTitle(); title != "" {
+		issuer = title
+	}
+	return &v1pb.TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningUri: totp.ProvisioningURI(issuer, user.Username, secret),
+	}, nil
+}
+
+func (s *APIV1Service) EnableTwoFactor(ctx context.Context, request *v1pb.EnableTwoFactorRequest) (*v1pb.RecoveryCodes, error) {
+	user, err := s.getCurrentUserOrUnauthenticated(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userTOTP, err := s.Store.GetUserTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user totp, error: %v", err)
+	}
+	if userTOTP.Enabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+	if userTOTP.Secret == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is not set up")
+	}
+	step, ok := totp.Validate(userTOTP.Secret, request.TotpCode, time.Now(), 0)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid two-factor code")
+	}
+	if err := s.Store.UpsertUserTOTP(ctx, user.ID, &storepb.TOTPUserSetting{
+		Secret:       userTOTP.Secret,
+		Enabled:      true,
+		LastUsedStep: step,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save user totp, error: %v", err)
+	}
+	return s.resetRecoveryCodes(ctx, user.ID)
+}
+
+func (s *APIV1Service) DisableTwoFactor(ctx context.Context, request *v1pb.DisableTwoFactorRequest) (*emptypb.Empty, error) {
+	user, err := s.getCurrentUserOrUnauthenticated(ctx)
+	if err != nil {
+		return nil, err
+	}
+	required, err := s.Store.IsTwoFactorRequired(ctx, user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace setting, error: %v", err)
+	}
+	if required {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is required by the workspace")
+	}
+
+	var valid bool
+	switch code := request.Code.(type) {
+	case *v1pb.DisableTwoFactorRequest_TotpCode:
+		valid, err = s.verifyTOTPCode(ctx, user.ID, code.TotpCode)
+	case *v1pb.DisableTwoFactorRequest_RecoveryCode:
+		valid, err = s.Store.UseUserRecoveryCode(ctx, user.ID, code.RecoveryCode)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "totp code or recovery code is required")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify code, error: %v", err)
+	}
+	if !valid {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid two-factor code")
+	}
+
+	if err := s.Store.UpsertUserTOTP(ctx, user.ID, &storepb.TOTPUserSetting{}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save user totp, error: %v", err)
+	}
+	if err := s.Store.SetUserRecoveryCodes(ctx, user.ID, []string{}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save recovery codes, error: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *APIV1Service) RegenerateRecoveryCodes(ctx context.Context, request *v1pb.RegenerateRecoveryCodesRequest) (*v1pb.RecoveryCodes, error) {
+	user, err := s.getCurrentUserOrUnauthenticated(ctx)
+	if err != nil {
+		return nil, err
+	}
+	valid, err := s.verifyTOTPCode(ctx, user.ID, request.TotpCode)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify code, error: %v", err)
+	}
+	if !valid {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid two-factor code")
+	}
+	return s.resetRecoveryCodes(ctx, user.ID)
+}
+
+func (s *APIV1Service) getCurrentUserOrUnauthenticated(ctx context.Context) (*store.User, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user, error: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+	return user, nil
+}
+
+// verifyTOTPCode checks the code against the enabled TOTP secret of the user and
+// records the used time step so the same code cannot be replayed. The check and the
+// record are done under the lock of the setting, so concurrent requests can't both use the code.
+func (s *APIV1Service) verifyTOTPCode(ctx context.Context, userID int32, code string) (bool, error) {
+	valid := false
+	if err := s.Store.UpdateUserTOTP(ctx, userID, func(userTOTP *storepb.TOTPUserSetting) error {
+		if !userTOTP.Enabled {
+			return nil
+		}
+		step, ok := totp.Validate(userTOTP.Secret, code, time.Now(), userTOTP.LastUsedStep)
+		if !ok {
+			return nil
+		}
+		userTOTP.LastUsedStep = step
+		valid = true
+		return nil
+	}); err != nil {
+		return false, err
+	}
+	return valid, nil
+}
+
+// resetRecoveryCodes replaces the recovery codes of the user and returns the new plaintext codes.
+func (s *APIV1Service) resetRecoveryCodes(ctx context.Context, userID int32) (*v1pb.RecoveryCodes, error) {
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate recovery code, error: %v", err)
+		}
+		recoveryCodes = append(recoveryCodes, code)
+	}
+	if err := s.Store.SetUserRecoveryCodes(ctx, userID, recoveryCodes); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save recovery codes, error: %v", err)
+	}
+	return &v1pb.RecoveryCodes{Codes: recoveryCodes}, nil
+}
+
+// generateRecoveryCode returns a random code formatted as "xxxxx-xxxxx".
+func generateRecoveryCode() (string, error) {
+	code := make([]byte, 0, 11)
+	for i := 0; i < 10; i++ {
+		if i == 5 {
+			code = append(code, '-')
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code = append(code, recoveryCodeAlphabet[n.Int64()])
+	}
+	return string(code), nil
+}
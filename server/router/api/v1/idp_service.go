@@ -8,21 +8,20 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/usememos/memos/plugin/idp"
+	"github.com/usememos/memos/plugin/idp/ldap"
+	"github.com/usememos/memos/plugin/idp/oidc"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
 func (s *APIV1Service) CreateIdentityProvider(ctx context.Context, request *v1pb.CreateIdentityProviderRequest) (*v1pb.IdentityProvider, error) {
-	currentUser, err := s.GetCurrentUser(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
-	}
-	if currentUser.Role != store.RoleHost {
-		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	create := convertIdentityProviderToStore(request.IdentityProvider)
+	if err := validateIdentityProviderConfig(create.Type, create.Config); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid identity provider config: %v", err)
 	}
-
-	identityProvider, err := s.Store.CreateIdentityProvider(ctx, convertIdentityProviderToStore(request.IdentityProvider))
+	identityProvider, err := s.Store.CreateIdentityProvider(ctx, create)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create identity provider, error: %+v", err)
 	}
@@ -35,11 +34,19 @@ func (s *APIV1Service) ListIdentityProviders(ctx context.Context, _ *v1pb.ListId
 		return nil, status.Errorf(codes.Internal, "failed to list identity providers, error: %+v", err)
 	}
 
+	canManage, err := s.canManageIdentityProviders(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check permission, error: %+v", err)
+	}
 	response := &v1pb.ListIdentityProvidersResponse{
 		IdentityProviders: []*v1pb.IdentityProvider{},
 	}
 	for _, identityProvider := range identityProviders {
-		response.IdentityProviders = append(response.IdentityProviders, convertIdentityProviderFromStore(identityProvider))
+		converted := convertIdentityProviderFromStore(identityProvider)
+		if !canManage {
+			redactIdentityProviderSecrets(converted)
+		}
+		response.IdentityProviders = append(response.IdentityProviders, converted)
 	}
 	return response, nil
 }
@@ -58,7 +65,15 @@ func (s *APIV1Service) GetIdentityProvider(ctx context.Context, request *v1pb.Ge
 	if identityProvider == nil {
 		return nil, status.Errorf(codes.NotFound, "identity provider not found")
 	}
-	return convertIdentityProviderFromStore(identityProvider), nil
+	canManage, err := s.canManageIdentityProviders(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check permission, error: %+v", err)
+	}
+	converted := convertIdentityProviderFromStore(identityProvider)
+	if !canManage {
+		redactIdentityProviderSecrets(converted)
+	}
+	return converted, nil
 }
 
 func (s *APIV1Service) UpdateIdentityProvider(ctx context.Context, request *v1pb.UpdateIdentityProviderRequest) (*v1pb.IdentityProvider, error) {
@@ -82,6 +97,9 @@ func (s *APIV1Service) UpdateIdentityProvider(ctx context.Context, request *v1pb
 			update.IdentifierFilter = &request.IdentityProvider.IdentifierFilter
 		case "config":
 			update.Config = convertIdentityProviderConfigToStore(request.IdentityProvider.Type, request.IdentityProvider.Config)
+			if err := validateIdentityProviderConfig(update.Type, update.Config); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid identity provider config: %v", err)
+			}
 		}
 	}
 
@@ -126,7 +144,55 @@ func convertIdentityProviderFromStore(identityProvider *storepb.IdentityProvider
 						DisplayName: oauth2Config.FieldMapping.DisplayName,
 						Email:       oauth2Config.FieldMapping.Email,
 						AvatarUrl:   oauth2Config.FieldMapping.AvatarUrl,
+						Groups:      oauth2Config.FieldMapping.Groups,
+					},
+					Provisioning: convertProvisioningConfigFromStore(oauth2Config.Provisioning),
+				},
+			},
+		}
+	} else if identityProvider.Type == storepb.IdentityProvider_OIDC {
+		oidcConfig := identityProvider.Config.GetOidcConfig()
+		fieldMapping := oidcConfig.GetFieldMapping()
+		temp.Config = &v1pb.IdentityProviderConfig{
+			Config: &v1pb.IdentityProviderConfig_OidcConfig{
+				OidcConfig: &v1pb.OIDCConfig{
+					Issuer:       oidcConfig.GetIssuer(),
+					ClientId:     oidcConfig.GetClientId(),
+					ClientSecret: oidcConfig.GetClientSecret(),
+					Scopes:       oidcConfig.GetScopes(),
+					FieldMapping: &v1pb.FieldMapping{
+						Identifier:  fieldMapping.GetIdentifier(),
+						DisplayName: fieldMapping.GetDisplayName(),
+						Email:       fieldMapping.GetEmail(),
+						AvatarUrl:   fieldMapping.GetAvatarUrl(),
+						Groups:      fieldMapping.GetGroups(),
+					},
+					Provisioning: convertProvisioningConfigFromStore(oidcConfig.GetProvisioning()),
+				},
+			},
+		}
+	} else if identityProvider.Type == storepb.IdentityProvider_LDAP {
+		ldapConfig := identityProvider.Config.GetLdapConfig()
+		fieldMapping := ldapConfig.GetFieldMapping()
+		temp.Config = &v1pb.IdentityProviderConfig{
+			Config: &v1pb.IdentityProviderConfig_LdapConfig{
+				LdapConfig: &v1pb.LDAPConfig{
+					Url:                ldapConfig.GetUrl(),
+					StartTls:           ldapConfig.GetStartTls(),
+					InsecureSkipVerify: ldapConfig.GetInsecureSkipVerify(),
+					BindDn:             ldapConfig.GetBindDn(),
+					BindPassword:       ldapConfig.GetBindPassword(),
+					SearchBase:         ldapConfig.GetSearchBase(),
+					UserFilter:         ldapConfig.GetUserFilter(),
+					FieldMapping: &v1pb.FieldMapping{
+						Identifier:  fieldMapping.GetIdentifier(),
+						DisplayName: fieldMapping.GetDisplayName(),
+						Email:       fieldMapping.GetEmail(),
+						AvatarUrl:   fieldMapping.GetAvatarUrl(),
+						Groups:      fieldMapping.GetGroups(),
 					},
+					Provisioning:       convertProvisioningConfigFromStore(ldapConfig.GetProvisioning()),
+					AllowLocalPassword: ldapConfig.GetAllowLocalPassword(),
 				},
 			},
 		}
@@ -134,6 +200,27 @@ func convertIdentityProviderFromStore(identityProvider *storepb.IdentityProvider
 	return temp
 }
 
+// redactIdentityProviderSecrets clears the client secrets and bind passwords of the identity provider.
+func redactIdentityProviderSecrets(identityProvider *v1pb.IdentityProvider) {
+	switch config := identityProvider.GetConfig().GetConfig().(type) {
+	case *v1pb.IdentityProviderConfig_Oauth2Config:
+		config.Oauth2Config.ClientSecret = ""
+	case *v1pb.IdentityProviderConfig_OidcConfig:
+		config.OidcConfig.ClientSecret = ""
+	case *v1pb.IdentityProviderConfig_LdapConfig:
+		config.LdapConfig.BindPassword = ""
+	}
+}
+
+// canManageIdentityProviders reports whether the current user may see the secrets of identity providers.
+func (s *APIV1Service) canManageIdentityProviders(ctx context.Context) (bool, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return false, err
+	}
+	return s.Store.HasPermission(ctx, user, store.PermissionIdentityProviderManage)
+}
+
 func convertIdentityProviderToStore(identityProvider *v1pb.IdentityProvider) *storepb.IdentityProvider {
 	id, _ := ExtractIdentityProviderIDFromName(identityProvider.Name)
 
@@ -164,10 +251,108 @@ func convertIdentityProviderConfigToStore(identityProviderType v1pb.IdentityProv
 						DisplayName: oauth2Config.FieldMapping.DisplayName,
 						Email:       oauth2Config.FieldMapping.Email,
 						AvatarUrl:   oauth2Config.FieldMapping.AvatarUrl,
+						Groups:      oauth2Config.FieldMapping.Groups,
+					},
+					Provisioning: convertProvisioningConfigToStore(oauth2Config.Provisioning),
+				},
+			},
+		}
+	} else if identityProviderType == v1pb.IdentityProvider_OIDC {
+		oidcConfig := config.GetOidcConfig()
+		fieldMapping := oidcConfig.GetFieldMapping()
+		return &storepb.IdentityProviderConfig{
+			Config: &storepb.IdentityProviderConfig_OidcConfig{
+				OidcConfig: &storepb.OIDCConfig{
+					Issuer:       oidcConfig.GetIssuer(),
+					ClientId:     oidcConfig.GetClientId(),
+					ClientSecret: oidcConfig.GetClientSecret(),
+					Scopes:       oidcConfig.GetScopes(),
+					FieldMapping: &storepb.FieldMapping{
+						Identifier:  fieldMapping.GetIdentifier(),
+						DisplayName: fieldMapping.GetDisplayName(),
+						Email:       fieldMapping.GetEmail(),
+						AvatarUrl:   fieldMapping.GetAvatarUrl(),
+						Groups:      fieldMapping.GetGroups(),
 					},
+					Provisioning: convertProvisioningConfigToStore(oidcConfig.GetProvisioning()),
+				},
+			},
+		}
+	} else if identityProviderType == v1pb.IdentityProvider_LDAP {
+		ldapConfig := config.GetLdapConfig()
+		fieldMapping := ldapConfig.GetFieldMapping()
+		return &storepb.IdentityProviderConfig{
+			Config: &storepb.IdentityProviderConfig_LdapConfig{
+				LdapConfig: &storepb.LDAPConfig{
+					Url:                ldapConfig.GetUrl(),
+					StartTls:           ldapConfig.GetStartTls(),
+					InsecureSkipVerify: ldapConfig.GetInsecureSkipVerify(),
+					BindDn:             ldapConfig.GetBindDn(),
+					BindPassword:       ldapConfig.GetBindPassword(),
+					SearchBase:         ldapConfig.GetSearchBase(),
+					UserFilter:         ldapConfig.GetUserFilter(),
+					FieldMapping: &storepb.FieldMapping{
+						Identifier:  fieldMapping.GetIdentifier(),
+						DisplayName: fieldMapping.GetDisplayName(),
+						Email:       fieldMapping.GetEmail(),
+						AvatarUrl:   fieldMapping.GetAvatarUrl(),
+						Groups:      fieldMapping.GetGroups(),
+					},
+					Provisioning:       convertProvisioningConfigToStore(ldapConfig.GetProvisioning()),
+					AllowLocalPassword: ldapConfig.GetAllowLocalPassword(),
 				},
 			},
 		}
 	}
 	return nil
 }
+
+func convertProvisioningConfigFromStore(config *storepb.ProvisioningConfig) *v1pb.ProvisioningConfig {
+	if config == nil {
+		return nil
+	}
+	provisioning := &v1pb.ProvisioningConfig{
+		SyncProfile:           config.SyncProfile,
+		ArchiveUnmatchedUsers: config.ArchiveUnmatchedUsers,
+	}
+	for _, rule := range config.RoleMappings {
+		provisioning.RoleMappings = append(provisioning.RoleMappings, &v1pb.RoleMappingRule{
+			Claim: rule.Claim,
+			Value: rule.Value,
+			Role:  rule.Role,
+		})
+	}
+	return provisioning
+}
+
+func convertProvisioningConfigToStore(config *v1pb.ProvisioningConfig) *storepb.ProvisioningConfig {
+	if config == nil {
+		return nil
+	}
+	provisioning := &storepb.ProvisioningConfig{
+		SyncProfile:           config.SyncProfile,
+		ArchiveUnmatchedUsers: config.ArchiveUnmatchedUsers,
+	}
+	for _, rule := range config.RoleMappings {
+		provisioning.RoleMappings = append(provisioning.RoleMappings, &storepb.RoleMappingRule{
+			Claim: rule.Claim,
+			Value: rule.Value,
+			Role:  rule.Role,
+		})
+	}
+	return provisioning
+}
+
+// validateIdentityProviderConfig checks the parts of the config that can be validated without contacting the provider.
+func validateIdentityProviderConfig(identityProviderType storepb.IdentityProvider_Type, config *storepb.IdentityProviderConfig) error {
+	if identityProviderType == storepb.IdentityProvider_OIDC {
+		if err := oidc.ValidateConfig(config.GetOidcConfig()); err != nil {
+			return err
+		}
+	} else if identityProviderType == storepb.IdentityProvider_LDAP {
+		if err := ldap.ValidateConfig(config.GetLdapConfig()); err != nil {
+			return err
+		}
+	}
+	return idp.ValidateProvisioningConfig(idp.GetProvisioningConfig(config))
+}
@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/httpgetter"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// linkPreviewErrorTTL is how long a link failing to be fetched is not fetched again.
+	linkPreviewErrorTTL = time.Hour
+	// linkPreviewImagePath is the route serving the images of link previews through the server.
+	linkPreviewImagePath = "/file/link-previews/image"
+)
+
+// getLinkPreview returns the cached preview of the link, and fetches it again when the cache is stale.
+// Failures are cached as well, so a broken link is not fetched on every render. A fetched preview is
+// only cached when save is set, otherwise it is returned without UpdatedTs.
+func (s *APIV1Service) getLinkPreview(ctx context.Context, link string, save bool) (*store.LinkPreview, error) {
+	linkPreview, err := s.Store.GetLinkPreview(ctx, &store.FindLinkPreview{URL: &link})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get link preview")
+	}
+	if linkPreview != nil {
+		ttl := store.LinkPreviewTTL
+		if linkPreview.Error != "" {
+			ttl = linkPreviewErrorTTL
+		}
+		if time.Since(time.Unix(linkPreview.UpdatedTs, 0)) < ttl {
+			return linkPreview, nil
+		}
+	}
+
+	linkPreview = &store.LinkPreview{URL: link}
+	if htmlMeta, err := httpgetter.GetHTMLMeta(ctx, link); err != nil {
+		// A fetch aborted by the request is not a failure of the link to cache.
+		if ctx.Err() != nil {
+			return nil, errors.Wrap(ctx.Err(), "failed to get link metadata")
+		}
+		linkPreview.Error = err.Error()
+	} else {
+		linkPreview.Title = htmlMeta.Title
+		linkPreview.Description = htmlMeta.Description
+		linkPreview.Image = htmlMeta.Image
+	}
+	if !save {
+		return linkPreview, nil
+	}
+	linkPreview, err = s.Store.UpsertLinkPreview(ctx, linkPreview)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to upsert link preview")
+	}
+	return linkPreview, nil
+}
+
+// getLinkPreviewImageURL returns the URL of the proxied image of the link preview.
+func getLinkPreviewImageURL(link string) string {
+	return fmt.Sprintf("%s?link=%s", linkPreviewImagePath, url.QueryEscape(link))
+}
+
+// handleGetLinkPreviewImage serves the image of a cached link preview. Only the images of previews
+// are served, so that the route is not an open proxy.
+func (s *APIV1Service) handleGetLinkPreviewImage(c echo.Context) error {
+	ctx := c.Request().Context()
+	memoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace memo related setting").SetInternal(err)
+	}
+	if !memoRelatedSetting.ProxyLinkPreviewImages {
+		return echo.NewHTTPError(http.StatusNotFound, "Link preview images are not proxied")
+	}
+
+	link := c.QueryParam("link")
+	if link == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Link is required")
+	}
+	linkPreview, err := s.Store.GetLinkPreview(ctx, &store.FindLinkPreview{URL: &link})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get link preview").SetInternal(err)
+	}
+	if linkPreview == nil || linkPreview.Image == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "Link preview image not found")
+	}
+
+	image, err := httpgetter.GetImage(ctx, linkPreview.Image)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, "Failed to get link preview image").SetInternal(err)
+	}
+	// SVG images may contain scripts, and are not served from the origin of the workspace.
+	if strings.EqualFold(image.Mediatype, "image/svg+xml") {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "SVG images are not proxied")
+	}
+
+	c.Response().Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(store.LinkPreviewTTL.Seconds())))
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	return c.Blob(http.StatusOK, image.Mediatype, image.Blob)
+}
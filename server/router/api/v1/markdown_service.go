@@ -2,6 +2,7 @@ package v1
 
 import (
 	"context"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/usememos/gomark/ast"
@@ -9,9 +10,12 @@ import (
 	"github.com/usememos/gomark/parser/tokenizer"
 	"github.com/usememos/gomark/renderer"
 	"github.com/usememos/gomark/restore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
-	"github.com/usememos/memos/plugin/httpgetter"
+	"github.com/usememos/memos/plugin/markdown"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
 )
 
 func (*APIV1Service) ParseMarkdown(_ context.Context, request *v1pb.ParseMarkdownRequest) (*v1pb.ParseMarkdownResponse, error) {
@@ -41,16 +45,96 @@ func (*APIV1Service) StringifyMarkdownNodes(_ context.Context, request *v1pb.Str
 	}, nil
 }
 
-func (*APIV1Service) GetLinkMetadata(_ context.Context, request *v1pb.GetLinkMetadataRequest) (*v1pb.LinkMetadata, error) {
-	htmlMeta, err := httpgetter.GetHTMLMeta(request.Link)
+func (s *APIV1Service) RenderMarkdown(ctx context.Context, request *v1pb.RenderMarkdownRequest) (*v1pb.RenderMarkdownResponse, error) {
+	nodes, err := markdown.Parse(request.Markdown)
 	if err != nil {
-		return nil, err
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse markdown: %v", err)
 	}
 
+	options := s.markdownRenderOptions(ctx)
+	var content string
+	switch request.Format {
+	case v1pb.RenderMarkdownRequest_COMMONMARK:
+		content = markdown.RenderCommonMark(nodes, options)
+	case v1pb.RenderMarkdownRequest_SLACK:
+		content = markdown.RenderSlack(nodes, options)
+	case v1pb.RenderMarkdownRequest_PLAIN_TEXT:
+		content = markdown.RenderPlainText(nodes, options)
+	default:
+		content = markdown.RenderHTML(nodes, options)
+	}
+	return &v1pb.RenderMarkdownResponse{
+		Content: content,
+	}, nil
+}
+
+// markdownRenderOptions returns the options to render markdown for the current user.
+// The references are resolved to absolute URLs, and titled only when the user can read them.
+func (s *APIV1Service) markdownRenderOptions(ctx context.Context) markdown.Options {
+	return markdown.Options{
+		InstanceURL: s.Profile.InstanceURL,
+		Resolve: func(name string) *markdown.Reference {
+			switch {
+			case strings.HasPrefix(name, ResourceNamePrefix):
+				resourceUID := strings.TrimPrefix(name, ResourceNamePrefix)
+				resource, err := s.Store.GetResource(ctx, &store.FindResource{UID: &resourceUID})
+				if err != nil || resource == nil || s.checkResourceAccess(ctx, resource) != nil {
+					return nil
+				}
+				return &markdown.Reference{
+					URL:   ResourceURL(resource),
+					Title: resource.Filename,
+					Type:  resource.Type,
+				}
+			case strings.HasPrefix(name, MemoNamePrefix):
+				memo, err := s.GetMemo(ctx, &v1pb.GetMemoRequest{Name: name})
+				if err != nil {
+					return nil
+				}
+				return &markdown.Reference{
+					URL:   "/" + memo.Name,
+					Title: memo.Snippet,
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (s *APIV1Service) GetLinkMetadata(ctx context.Context, request *v1pb.GetLinkMetadataRequest) (*v1pb.LinkMetadata, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	// Anonymous visitors don't add previews to the cache, so that they can't grow it without bound.
+	linkPreview, err := s.getLinkPreview(ctx, request.Link, user != nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get link preview: %v", err)
+	}
+	if linkPreview.Error != "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to fetch link metadata: %s", linkPreview.Error)
+	}
+
+	image := linkPreview.Image
+	if image != "" {
+		memoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get workspace memo related setting")
+		}
+		if memoRelatedSetting.ProxyLinkPreviewImages {
+			// Only the images of cached previews are proxied, so the image of a preview fetched for an
+			// anonymous visitor is left out rather than linked directly.
+			if linkPreview.UpdatedTs != 0 {
+				image = getLinkPreviewImageURL(request.Link)
+			} else {
+				image = ""
+			}
+		}
+	}
 	return &v1pb.LinkMetadata{
-		Title:       htmlMeta.Title,
-		Description: htmlMeta.Description,
-		Image:       htmlMeta.Image,
+		Title:       linkPreview.Title,
+		Description: linkPreview.Description,
+		Image:       image,
 	}, nil
 }
 
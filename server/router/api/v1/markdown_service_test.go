@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestGetLinkMetadataCache(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	// Loopback links are refused by the fetcher, which is a failure cached like any other.
+	link := "http://127.0.0.1/page"
+	request := &v1pb.GetLinkMetadataRequest{Link: link}
+
+	// The previews fetched for anonymous visitors are not cached.
+	_, err := s.GetLinkMetadata(ctx, request)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	linkPreview, err := s.Store.GetLinkPreview(ctx, &store.FindLinkPreview{URL: &link})
+	require.NoError(t, err)
+	require.Nil(t, linkPreview)
+
+	_, err = s.GetLinkMetadata(withTestingUser(ctx, user), request)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	linkPreview, err = s.Store.GetLinkPreview(ctx, &store.FindLinkPreview{URL: &link})
+	require.NoError(t, err)
+	require.NotNil(t, linkPreview)
+	require.NotEmpty(t, linkPreview.Error)
+}
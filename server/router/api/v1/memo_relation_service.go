@@ -3,6 +3,8 @@ package v1
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -10,6 +12,7 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/server/runner/memopayload"
 	"github.com/usememos/memos/store"
 )
 
@@ -31,6 +34,7 @@ func (s *APIV1Service) SetMemoRelations(ctx context.Context, request *v1pb.SetMe
 		return nil, status.Errorf(codes.Internal, "failed to delete memo relation")
 	}
 
+	explicitRelatedMemoIDs := []int32{}
 	for _, relation := range request.Relations {
 		// Ignore reflexive relations.
 		if request.Name == relation.RelatedMemo.Name {
@@ -56,6 +60,21 @@ func (s *APIV1Service) SetMemoRelations(ctx context.Context, request *v1pb.SetMe
 		}); err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to upsert memo relation")
 		}
+		explicitRelatedMemoIDs = append(explicitRelatedMemoIDs, relatedMemo.ID)
+	}
+	// Keep the relations to the memos referenced in the content. The ones set explicitly
+	// are no longer owned by the content, so they stay when the reference is removed.
+	isExplicit := func(relatedMemoID int32) bool {
+		return slices.Contains(explicitRelatedMemoIDs, relatedMemoID)
+	}
+	if slices.ContainsFunc(memo.Payload.GetContentRelations(), isExplicit) {
+		memo.Payload.ContentRelations = slices.DeleteFunc(memo.Payload.ContentRelations, isExplicit)
+		if err := s.Store.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Payload: memo.Payload}); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update memo payload")
+		}
+	}
+	if err := memopayload.SyncMemoRelations(ctx, s.Store, memo); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sync memo relations: %v", err)
 	}
 
 	return &emptypb.Empty{}, nil
@@ -75,11 +94,12 @@ func (s *APIV1Service) ListMemoRelations(ctx context.Context, request *v1pb.List
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get user")
 	}
-	var memoFilter string
-	if currentUser == nil {
-		memoFilter = `visibility == "PUBLIC"`
-	} else {
-		memoFilter = fmt.Sprintf(`creator_id == %d || visibility in ["PUBLIC", "PROTECTED"]`, currentUser.ID)
+	memoFilter := `visibility == "PUBLIC"`
+	if currentUser != nil {
+		memoFilter, err = s.buildMemoVisibilityFilter(ctx, currentUser, true)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to build memo visibility filter: %v", err)
+		}
 	}
 	relationList := []*v1pb.MemoRelation{}
 	tempList, err := s.Store.ListMemoRelations(ctx, &store.FindMemoRelation{
@@ -117,6 +137,135 @@ func (s *APIV1Service) ListMemoRelations(ctx context.Context, request *v1pb.List
 	return response, nil
 }
 
+func (s *APIV1Service) ListBacklinks(ctx context.Context, request *v1pb.ListBacklinksRequest) (*v1pb.ListBacklinksResponse, error) {
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	memoFilter, err := s.buildMemoVisibilityFilter(ctx, currentUser, true)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to build memo visibility filter: %v", err)
+	}
+
+	var memos []*store.Memo
+	if strings.HasPrefix(request.Name, TicketNamePrefix) {
+		memos, err = s.listTicketBacklinks(ctx, currentUser, request.Name, memoFilter)
+	} else {
+		memos, err = s.listMemoBacklinks(ctx, currentUser, request.Name, memoFilter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &v1pb.ListBacklinksResponse{
+		Memos: []*v1pb.MemoRelation_Memo{},
+	}
+	for _, memo := range memos {
+		snippet, err := getMemoContentSnippet(memo.Content)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get memo content snippet: %v", err)
+		}
+		response.Memos = append(response.Memos, &v1pb.MemoRelation_Memo{
+			Name:    fmt.Sprintf("%s%s", MemoNamePrefix, memo.UID),
+			Uid:     memo.UID,
+			Snippet: snippet,
+		})
+	}
+	return response, nil
+}
+
+// listMemoBacklinks lists the memos with a reference relation to the memo, which the user must be able to view.
+func (s *APIV1Service) listMemoBacklinks(ctx context.Context, user *store.User, name string, memoFilter string) ([]*store.Memo, error) {
+	memoUID, err := ExtractMemoUIDFromName(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo")
+	}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+	canView, err := s.canViewMemo(ctx, user, memo)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check memo access: %v", err)
+	}
+	if !canView {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	referenceType := store.MemoRelationReference
+	relations, err := s.Store.ListMemoRelations(ctx, &store.FindMemoRelation{
+		RelatedMemoID: &memo.ID,
+		Type:          &referenceType,
+		MemoFilter:    &memoFilter,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memo relations")
+	}
+	memos := []*store.Memo{}
+	for _, relation := range relations {
+		referencingMemo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &relation.MemoID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get memo")
+		}
+		if referencingMemo != nil && referencingMemo.RowStatus == store.Normal {
+			memos = append(memos, referencingMemo)
+		}
+	}
+	return memos, nil
+}
+
+// listTicketBacklinks lists the memos referencing the ticket, which the user must be able to view.
+func (s *APIV1Service) listTicketBacklinks(ctx context.Context, user *store.User, name string, memoFilter string) ([]*store.Memo, error) {
+	ticketID, err := ExtractTicketIDFromName(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid ticket name: %v", err)
+	}
+	hasPermission, err := s.Store.HasPermission(ctx, user, store.PermissionTicketView)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check permission")
+	}
+	if !hasPermission {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied: %s is required", store.PermissionTicketView)
+	}
+	ticket, err := s.Store.GetTicket(ctx, &store.FindTicket{ID: &ticketID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get ticket")
+	}
+	if ticket == nil {
+		return nil, status.Errorf(codes.NotFound, "ticket not found")
+	}
+	if ticket.ProjectID != nil {
+		canManage, err := s.Store.HasPermission(ctx, user, store.PermissionProjectManage)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check permission")
+		}
+		member, err := s.Store.GetProjectMember(ctx, &store.FindProjectMember{ProjectID: ticket.ProjectID, UserID: &user.ID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get project member")
+		}
+		if !canManage && member == nil {
+			return nil, status.Errorf(codes.PermissionDenied, "not a member of the project")
+		}
+	}
+
+	normalStatus := store.Normal
+	filter := fmt.Sprintf("references_ticket == %d && (%s)", ticket.ID, memoFilter)
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{
+		RowStatus: &normalStatus,
+		Filter:    &filter,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memos")
+	}
+	return memos, nil
+}
+
 func (s *APIV1Service) convertMemoRelationFromStore(ctx context.Context, memoRelation *store.MemoRelation) (*v1pb.MemoRelation, error) {
 	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &memoRelation.MemoID})
 	if err != nil {
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestListBacklinksOfPrivateMemo(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	creator := createTestingUser(ctx, t, s, "creator", store.RoleUser)
+	outsider := createTestingUser(ctx, t, s, "outsider", store.RoleUser)
+	memo := createTestingMemo(ctx, t, s, creator, "private", store.Private, nil)
+	backlink := createTestingMemo(ctx, t, s, creator, "public backlink", store.Public, nil)
+	_, err := s.Store.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: backlink.ID, RelatedMemoID: memo.ID, Type: store.MemoRelationReference})
+	require.NoError(t, err)
+	request := &v1pb.ListBacklinksRequest{Name: MemoNamePrefix + memo.UID}
+
+	response, err := s.ListBacklinks(withTestingUser(ctx, creator), request)
+	require.NoError(t, err)
+	require.Len(t, response.Memos, 1)
+	require.Equal(t, backlink.UID, response.Memos[0].Uid)
+	// The backlink is public, but it tells that the private memo exists.
+	_, err = s.ListBacklinks(withTestingUser(ctx, outsider), request)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestSyncMemoRelationsKeepsExplicitRelations(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	userCtx := withTestingUser(ctx, user)
+	referenced := createTestingMemo(ctx, t, s, user, "referenced", store.Public, nil)
+	explicit := createTestingMemo(ctx, t, s, user, "explicit", store.Public, nil)
+	reference := "[[" + MemoNamePrefix + referenced.UID + "]] [[" + MemoNamePrefix + explicit.UID + "]]"
+
+	memo, err := s.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: reference, Visibility: v1pb.Visibility_PUBLIC}})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{referenced.UID, explicit.UID}, listReferencedMemoUIDs(ctx, t, s, memo.Name))
+	_, err = s.SetMemoRelations(userCtx, &v1pb.SetMemoRelationsRequest{
+		Name: memo.Name,
+		Relations: []*v1pb.MemoRelation{{
+			Memo:        &v1pb.MemoRelation_Memo{Name: memo.Name},
+			RelatedMemo: &v1pb.MemoRelation_Memo{Name: MemoNamePrefix + explicit.UID},
+			Type:        v1pb.MemoRelation_REFERENCE,
+		}},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{referenced.UID, explicit.UID}, listReferencedMemoUIDs(ctx, t, s, memo.Name))
+
+	// Removing the references from the content only deletes the relation created for the content.
+	_, err = s.UpdateMemo(userCtx, &v1pb.UpdateMemoRequest{
+		Memo:       &v1pb.Memo{Name: memo.Name, Content: "no references"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{explicit.UID}, listReferencedMemoUIDs(ctx, t, s, memo.Name))
+}
+
+func listReferencedMemoUIDs(ctx context.Context, t *testing.T, s *APIV1Service, name string) []string {
+	memoUID, err := ExtractMemoUIDFromName(name)
+	require.NoError(t, err)
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	require.NoError(t, err)
+	referenceType := store.MemoRelationReference
+	relations, err := s.Store.ListMemoRelations(ctx, &store.FindMemoRelation{MemoID: &memo.ID, Type: &referenceType})
+	require.NoError(t, err)
+	uids := []string{}
+	for _, relation := range relations {
+		relatedMemo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &relation.RelatedMemoID})
+		require.NoError(t, err)
+		uids = append(uids, relatedMemo.UID)
+	}
+	return uids
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"regexp"
 	"strconv"
+	"strings"
 	"time"
 	"unicode/utf8"
 
@@ -14,13 +14,13 @@ import (
 	"github.com/usememos/gomark/ast"
 	"github.com/usememos/gomark/parser"
 	"github.com/usememos/gomark/parser/tokenizer"
-	"github.com/usememos/gomark/renderer"
 	"github.com/usememos/gomark/restore"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/usememos/memos/plugin/markdown"
 	"github.com/usememos/memos/plugin/webhook"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
@@ -47,6 +47,16 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 	if workspaceMemoRelatedSetting.DisallowPublicVisibility && create.Visibility == store.Public {
 		return nil, status.Errorf(codes.PermissionDenied, "disable public memos system setting is enabled")
 	}
+	if request.Memo.Project != "" {
+		projectID, err := s.getMemoProjectID(ctx, user, request.Memo.Project)
+		if err != nil {
+			return nil, err
+		}
+		create.ProjectID = &projectID
+	}
+	if create.Visibility == store.ProjectMembers && create.ProjectID == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "project is required for project visibility")
+	}
 	contentLengthLimit, err := s.getContentLengthLimit(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get content length limit")
@@ -54,7 +64,7 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 	if len(create.Content) > contentLengthLimit {
 		return nil, status.Errorf(codes.InvalidArgument, "content too long (max %d characters)", contentLengthLimit)
 	}
-	if err := memopayload.RebuildMemoPayload(create); err != nil {
+	if err := memopayload.RebuildMemoPayload(ctx, s.Store, create); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
 	}
 	if request.Memo.Location != nil {
@@ -82,6 +92,8 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to set memo relations")
 		}
+	} else if err := memopayload.SyncMemoRelations(ctx, s.Store, memo); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sync memo relations: %v", err)
 	}
 
 	memoMessage, err := s.convertMemoFromStore(ctx, memo)
@@ -140,17 +152,16 @@ func (s *APIV1Service) ListMemos(ctx context.Context, request *v1pb.ListMemosReq
 	}
 	if currentUser == nil {
 		memoFind.VisibilityList = []store.Visibility{store.Public}
-	} else {
-		if memoFind.CreatorID == nil {
-			internalFilter := fmt.Sprintf(`creator_id == %d || visibility in ["PUBLIC", "PROTECTED"]`, currentUser.ID)
-			if memoFind.Filter != nil {
-				filter := fmt.Sprintf("(%s) && (%s)", *memoFind.Filter, internalFilter)
-				memoFind.Filter = &filter
-			} else {
-				memoFind.Filter = &internalFilter
-			}
-		} else if *memoFind.CreatorID != currentUser.ID {
-			memoFind.VisibilityList = []store.Visibility{store.Public, store.Protected}
+	} else if memoFind.CreatorID == nil || *memoFind.CreatorID != currentUser.ID {
+		internalFilter, err := s.buildMemoVisibilityFilter(ctx, currentUser, memoFind.CreatorID == nil)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to build memo visibility filter: %v", err)
+		}
+		if memoFind.Filter != nil {
+			filter := fmt.Sprintf("(%s) && (%s)", *memoFind.Filter, internalFilter)
+			memoFind.Filter = &filter
+		} else {
+			memoFind.Filter = &internalFilter
 		}
 	}
 
@@ -233,6 +244,15 @@ func (s *APIV1Service) GetMemo(ctx context.Context, request *v1pb.GetMemoRequest
 		if memo.Visibility == store.Private && memo.CreatorID != user.ID {
 			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 		}
+		if memo.Visibility == store.ProjectMembers && memo.CreatorID != user.ID {
+			isMember, err := s.isMemoProjectMember(ctx, user, memo)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get project member: %v", err)
+			}
+			if !isMember {
+				return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+			}
+		}
 	}
 
 	memoMessage, err := s.convertMemoFromStore(ctx, memo)
@@ -281,7 +301,7 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 				return nil, status.Errorf(codes.InvalidArgument, "content too long (max %d characters)", contentLengthLimit)
 			}
 			memo.Content = request.Memo.Content
-			if err := memopayload.RebuildMemoPayload(memo); err != nil {
+			if err := memopayload.RebuildMemoPayload(ctx, s.Store, memo); err != nil {
 				return nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
 			}
 			update.Content = &memo.Content
@@ -296,6 +316,15 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 				return nil, status.Errorf(codes.PermissionDenied, "disable public memos system setting is enabled")
 			}
 			update.Visibility = &visibility
+		} else if path == "project" {
+			projectID := int32(0)
+			if request.Memo.Project != "" {
+				projectID, err = s.getMemoProjectID(ctx, user, request.Memo.Project)
+				if err != nil {
+					return nil, err
+				}
+			}
+			update.ProjectID = &projectID
 		} else if path == "pinned" {
 			update.Pinned = &request.Memo.Pinned
 		} else if path == "state" {
@@ -344,9 +373,26 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 		}
 	}
 
+	// Project visibility needs a project after the update.
+	visibility, projectID := memo.Visibility, memo.ProjectID
+	if update.Visibility != nil {
+		visibility = *update.Visibility
+	}
+	if update.ProjectID != nil {
+		projectID = update.ProjectID
+	}
+	if visibility == store.ProjectMembers && (projectID == nil || *projectID == 0) {
+		return nil, status.Errorf(codes.InvalidArgument, "project is required for project visibility")
+	}
+
 	if err = s.Store.UpdateMemo(ctx, update); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update memo")
 	}
+	if update.Content != nil {
+		if err := memopayload.SyncMemoRelations(ctx, s.Store, memo); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to sync memo relations: %v", err)
+		}
+	}
 
 	memo, err = s.Store.GetMemo(ctx, &store.FindMemo{
 		ID: &memo.ID,
@@ -523,11 +569,12 @@ func (s *APIV1Service) ListMemoComments(ctx context.Context, request *v1pb.ListM
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get user")
 	}
-	var memoFilter string
-	if currentUser == nil {
-		memoFilter = `visibility == "PUBLIC"`
-	} else {
-		memoFilter = fmt.Sprintf(`creator_id == %d || visibility in ["PUBLIC", "PROTECTED"]`, currentUser.ID)
+	memoFilter := `visibility == "PUBLIC"`
+	if currentUser != nil {
+		memoFilter, err = s.buildMemoVisibilityFilter(ctx, currentUser, true)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to build memo visibility filter: %v", err)
+		}
 	}
 	memoRelationComment := store.MemoRelationComment
 	memoRelations, err := s.Store.ListMemoRelations(ctx, &store.FindMemoRelation{
@@ -597,7 +644,7 @@ func (s *APIV1Service) RenameMemoTag(ctx context.Context, request *v1pb.RenameMe
 			}
 		})
 		memo.Content = restore.Restore(nodes)
-		if err := memopayload.RebuildMemoPayload(memo); err != nil {
+		if err := memopayload.RebuildMemoPayload(ctx, s.Store, memo); err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
 		}
 		if err := s.Store.UpdateMemo(ctx, &store.UpdateMemo{
@@ -707,39 +754,8 @@ func (s *APIV1Service) dispatchMemoRelatedWebhook(ctx context.Context, memo *v1p
 }
 
 func (s *APIV1Service) dispatchMemoMentions(ctx context.Context, memo *store.Memo) error {
-	// Analyze mentions in the memo content
-	// Regexp to find all @nickname
-	// Matches @nickname followed by space or end of line
-	// Nickname can contain alphanumeric, underscore, dot, dash.
-	usernameRegexp := regexp.MustCompile(`@([a-zA-Z0-9_.-]+)`)
-	matches := usernameRegexp.FindAllStringSubmatch(memo.Content, -1)
-
-	mentionedUserIDs := make(map[int32]bool)
-	for _, match := range matches {
-		if len(match) < 2 {
-			continue
-		}
-		nickname := match[1]
-		user, err := s.Store.GetUser(ctx, &store.FindUser{
-			Username: &nickname,
-		})
-		if err != nil {
-			// Ignore if user not found or error
-			continue
-		}
-		if user == nil {
-			// Fallback to nickname
-			user, err = s.Store.GetUser(ctx, &store.FindUser{
-				Nickname: &nickname,
-			})
-			if err != nil {
-				continue
-			}
-		}
-		if user != nil {
-			mentionedUserIDs[user.ID] = true
-		}
-	}
+	// The mentions are resolved to user ids when the memo payload is rebuilt.
+	mentionedUserIDs := memo.Payload.GetProperty().GetMentions()
 
 	// For comments, we want the "RelatedMemo" in the notification to point to the Parent Memo (the Ticket).
 	// This ensures clicking the notification takes the user to the Ticket View, not the isolated comment view.
@@ -758,7 +774,7 @@ func (s *APIV1Service) dispatchMemoMentions(ctx context.Context, memo *store.Mem
 		}
 	}
 
-	for userID := range mentionedUserIDs {
+	for _, userID := range mentionedUserIDs {
 		// Don't notify self
 		if userID == memo.CreatorID {
 			continue
@@ -818,6 +834,18 @@ func (s *APIV1Service) dispatchMemoMentions(ctx context.Context, memo *store.Mem
 			}
 		}
 
+		// Only show the content to users who can view the memo, mentions don't grant access.
+		snippet := ""
+		receiver, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+		if err != nil {
+			return errors.Wrap(err, "failed to get mentioned user")
+		}
+		if canView, err := s.canViewMemo(ctx, receiver, memo); err != nil {
+			return errors.Wrap(err, "failed to check memo access")
+		} else if canView {
+			snippet, _ = getMemoContentSnippet(memo.Content)
+		}
+
 		fmt.Printf("SSE: Preparing to notify userID: %d about memo mention\n", userID)
 		GetNotificationHub().NotifyUser(userID, Notification{
 			ID:         activity.ID,
@@ -825,6 +853,7 @@ func (s *APIV1Service) dispatchMemoMentions(ctx context.Context, memo *store.Mem
 			SenderName: senderName,
 			SenderID:   memo.CreatorID,
 			MemoName:   fmt.Sprintf("memos/%s", memo.UID),
+			Snippet:    snippet,
 			Timestamp:  time.Now(),
 		})
 	}
@@ -845,12 +874,12 @@ func convertMemoToWebhookPayload(memo *v1pb.Memo) (*v1pb.WebhookRequestPayload,
 }
 
 func getMemoContentSnippet(content string) (string, error) {
-	nodes, err := parser.Parse(tokenizer.Tokenize(content))
+	nodes, err := markdown.Parse(content)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to parse content")
 	}
 
-	plainText := renderer.NewStringRenderer().Render(nodes)
+	plainText := markdown.RenderPlainText(nodes, markdown.Options{})
 	if len(plainText) > 64 {
 		return substring(plainText, 64) + "...", nil
 	}
@@ -875,3 +904,80 @@ func substring(s string, length int) string {
 
 	return s[:byteIndex]
 }
+
+// buildMemoVisibilityFilter returns the filter of the memos of other users that the user can see,
+// including the memos of the projects the user is a member of. Own memos are included with includeOwn.
+func (s *APIV1Service) buildMemoVisibilityFilter(ctx context.Context, user *store.User, includeOwn bool) (string, error) {
+	filter := `visibility in ["PUBLIC", "PROTECTED"]`
+	if includeOwn {
+		filter = fmt.Sprintf(`creator_id == %d || %s`, user.ID, filter)
+	}
+	projectIDs, err := s.Store.ListUserProjectIDs(ctx, user.ID)
+	if err != nil {
+		return "", err
+	}
+	if len(projectIDs) > 0 {
+		ids := []string{}
+		for _, id := range projectIDs {
+			ids = append(ids, fmt.Sprintf("%d", id))
+		}
+		filter = fmt.Sprintf(`%s || (visibility == "PROJECT" && project_id in [%s])`, filter, strings.Join(ids, ", "))
+	}
+	return filter, nil
+}
+
+// isMemoProjectMember reports whether the user is a member of the project of the memo.
+func (s *APIV1Service) isMemoProjectMember(ctx context.Context, user *store.User, memo *store.Memo) (bool, error) {
+	if memo.ProjectID == nil {
+		return false, nil
+	}
+	member, err := s.Store.GetProjectMember(ctx, &store.FindProjectMember{ProjectID: memo.ProjectID, UserID: &user.ID})
+	if err != nil {
+		return false, err
+	}
+	return member != nil, nil
+}
+
+// canViewMemo reports whether the user, which is nil for anonymous visitors, can view the memo.
+func (s *APIV1Service) canViewMemo(ctx context.Context, user *store.User, memo *store.Memo) (bool, error) {
+	if memo.Visibility == store.Public {
+		return true, nil
+	}
+	if user == nil {
+		return false, nil
+	}
+	if memo.CreatorID == user.ID {
+		return true, nil
+	}
+	switch memo.Visibility {
+	case store.Private:
+		return false, nil
+	case store.ProjectMembers:
+		return s.isMemoProjectMember(ctx, user, memo)
+	default:
+		return true, nil
+	}
+}
+
+// getMemoProjectID returns the ID of the project with the name, which the user must be a member of.
+func (s *APIV1Service) getMemoProjectID(ctx context.Context, user *store.User, name string) (int32, error) {
+	projectID, err := ExtractProjectIDFromName(name)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid project name: %v", err)
+	}
+	project, err := s.Store.GetProject(ctx, &store.FindProject{ID: &projectID})
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get project: %v", err)
+	}
+	if project == nil {
+		return 0, status.Errorf(codes.NotFound, "project not found")
+	}
+	member, err := s.Store.GetProjectMember(ctx, &store.FindProjectMember{ProjectID: &project.ID, UserID: &user.ID})
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get project member: %v", err)
+	}
+	if member == nil {
+		return 0, status.Errorf(codes.PermissionDenied, "not a member of the project")
+	}
+	return project.ID, nil
+}
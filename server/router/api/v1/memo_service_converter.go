@@ -38,6 +38,9 @@ func (s *APIV1Service) convertMemoFromStore(ctx context.Context, memo *store.Mem
 		Visibility:  convertVisibilityFromStore(memo.Visibility),
 		Pinned:      memo.Pinned,
 	}
+	if memo.ProjectID != nil {
+		memoMessage.Project = fmt.Sprintf("%s%d", ProjectNamePrefix, *memo.ProjectID)
+	}
 	if memo.Payload != nil {
 		memoMessage.Tags = memo.Payload.Tags
 		memoMessage.Property = convertMemoPropertyFromStore(memo.Payload.Property)
@@ -92,11 +95,22 @@ func convertMemoPropertyFromStore(property *storepb.MemoPayload_Property) *v1pb.
 	if property == nil {
 		return nil
 	}
+	tasks := []*v1pb.Memo_Property_Task{}
+	for _, task := range property.Tasks {
+		tasks = append(tasks, &v1pb.Memo_Property_Task{
+			Id:        task.Id,
+			Content:   task.Content,
+			Completed: task.Completed,
+		})
+	}
 	return &v1pb.Memo_Property{
 		HasLink:            property.HasLink,
 		HasTaskList:        property.HasTaskList,
 		HasCode:            property.HasCode,
 		HasIncompleteTasks: property.HasIncompleteTasks,
+		TaskCount:          property.TaskCount,
+		CompletedTaskCount: property.CompletedTaskCount,
+		Tasks:              tasks,
 	}
 }
 
@@ -130,6 +144,8 @@ func convertVisibilityFromStore(visibility store.Visibility) v1pb.Visibility {
 		return v1pb.Visibility_PROTECTED
 	case store.Public:
 		return v1pb.Visibility_PUBLIC
+	case store.ProjectMembers:
+		return v1pb.Visibility_PROJECT
 	default:
 		return v1pb.Visibility_VISIBILITY_UNSPECIFIED
 	}
@@ -143,6 +159,8 @@ func convertVisibilityToStore(visibility v1pb.Visibility) store.Visibility {
 		return store.Protected
 	case v1pb.Visibility_PUBLIC:
 		return store.Public
+	case v1pb.Visibility_PROJECT:
+		return store.ProjectMembers
 	default:
 		return store.Private
 	}
@@ -0,0 +1,216 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+	"github.com/usememos/gomark/ast"
+	"github.com/usememos/gomark/parser"
+	"github.com/usememos/gomark/parser/tokenizer"
+	"github.com/usememos/gomark/restore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/plugin/markdown"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/server/runner/memopayload"
+	"github.com/usememos/memos/store"
+)
+
+func (s *APIV1Service) ToggleMemoTask(ctx context.Context, request *v1pb.ToggleMemoTaskRequest) (*v1pb.Memo, error) {
+	memo, err := s.getEditableMemo(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := parser.Parse(tokenizer.Tokenize(memo.Content))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to parse memo: %v", err)
+	}
+	item, _, ok := memopayload.FindTask(nodes, request.Index, request.TaskId)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "task not found")
+	}
+	item.Complete = !item.Complete
+
+	return s.updateMemoContent(ctx, memo, nodes)
+}
+
+func (s *APIV1Service) PromoteMemoTask(ctx context.Context, request *v1pb.PromoteMemoTaskRequest) (*v1pb.PromoteMemoTaskResponse, error) {
+	memo, err := s.getEditableMemo(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user")
+	}
+	hasPermission, err := s.Store.HasPermission(ctx, user, store.PermissionTicketCreate)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check permission")
+	}
+	if !hasPermission {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied: %s is required", store.PermissionTicketCreate)
+	}
+	nodes, err := parser.Parse(tokenizer.Tokenize(memo.Content))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to parse memo: %v", err)
+	}
+	item, task, ok := memopayload.FindTask(nodes, request.Index, request.TaskId)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "task not found")
+	}
+	title := markdown.RenderPlainText(item.Children, markdown.Options{})
+	if title == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "task is empty")
+	}
+
+	// The description of a ticket is a memo, which links back to the memo of the task.
+	// The ticket is checked before anything is created, so that a failure leaves nothing behind.
+	descriptionMemo := &store.Memo{
+		UID:        shortuuid.New(),
+		CreatorID:  user.ID,
+		Content:    fmt.Sprintf("%s\n\n[[%s%s]]", task.Content, MemoNamePrefix, memo.UID),
+		Visibility: memo.Visibility,
+		ProjectID:  memo.ProjectID,
+	}
+	ticket := &store.Ticket{
+		Title:       title,
+		Description: "/m/" + descriptionMemo.UID,
+		Tags:        []string{},
+		CreatorID:   user.ID,
+		CreatedTs:   time.Now().Unix(),
+		UpdatedTs:   time.Now().Unix(),
+		ProjectID:   memo.ProjectID,
+	}
+	var project *store.Project
+	if memo.ProjectID != nil {
+		project, err = s.Store.GetProject(ctx, &store.FindProject{ID: memo.ProjectID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get project: %v", err)
+		}
+		if project == nil || project.RowStatus == store.Archived {
+			return nil, status.Errorf(codes.FailedPrecondition, "the project of the memo is not active")
+		}
+		if err := applyProjectTicketDefaults(project, ticket); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "failed to apply project defaults: %v", err)
+		}
+	}
+	if ticket.Type == "" {
+		ticket.Type = "TASK"
+	}
+	if err := ticket.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid ticket: %v", err)
+	}
+
+	if err := memopayload.RebuildMemoPayload(ctx, s.Store, descriptionMemo); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
+	}
+	descriptionMemo, err = s.Store.CreateMemo(ctx, descriptionMemo)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create description memo: %v", err)
+	}
+	if err := memopayload.SyncMemoRelations(ctx, s.Store, descriptionMemo); err != nil {
+		s.discardPromotedTask(ctx, descriptionMemo, nil)
+		return nil, status.Errorf(codes.Internal, "failed to sync memo relations: %v", err)
+	}
+	ticket, err = s.Store.CreateTicket(ctx, ticket)
+	if err != nil {
+		s.discardPromotedTask(ctx, descriptionMemo, nil)
+		return nil, status.Errorf(codes.Internal, "failed to create ticket: %v", err)
+	}
+
+	// Link the task to the page of the ticket, e.g. "- [ ] Write docs [API-42](/tickets/API-42)",
+	// which makes the memo reference the ticket.
+	key := strconv.Itoa(int(ticket.ID))
+	if project != nil {
+		key = store.FormatTicketKey(project.Key, ticket.Number)
+	}
+	item.Children = append(item.Children, &ast.Text{Content: " "}, &ast.Link{
+		Content: []ast.Node{&ast.Text{Content: key}},
+		URL:     "/tickets/" + key,
+	})
+	memoMessage, err := s.updateMemoContent(ctx, memo, nodes)
+	if err != nil {
+		s.discardPromotedTask(ctx, descriptionMemo, ticket)
+		return nil, err
+	}
+	return &v1pb.PromoteMemoTaskResponse{
+		Memo:     memoMessage,
+		TicketId: ticket.ID,
+	}, nil
+}
+
+// discardPromotedTask removes the description memo and the ticket created for a task that failed to be promoted.
+func (s *APIV1Service) discardPromotedTask(ctx context.Context, descriptionMemo *store.Memo, ticket *store.Ticket) {
+	ctx = context.WithoutCancel(ctx)
+	if ticket != nil {
+		if err := s.Store.DeleteTicket(ctx, &store.DeleteTicket{ID: ticket.ID}); err != nil {
+			slog.Warn("failed to delete the ticket of a task", slog.Int("ticketId", int(ticket.ID)), slog.Any("err", err))
+		}
+	}
+	if err := s.Store.DeleteMemoRelation(ctx, &store.DeleteMemoRelation{MemoID: &descriptionMemo.ID}); err != nil {
+		slog.Warn("failed to delete the relations of a description memo", slog.String("uid", descriptionMemo.UID), slog.Any("err", err))
+	}
+	if err := s.Store.DeleteMemo(ctx, &store.DeleteMemo{ID: descriptionMemo.ID}); err != nil {
+		slog.Warn("failed to delete a description memo", slog.String("uid", descriptionMemo.UID), slog.Any("err", err))
+	}
+}
+
+// getEditableMemo returns the memo with the name, which only the creator or admins can edit.
+func (s *APIV1Service) getEditableMemo(ctx context.Context, name string) (*store.Memo, error) {
+	memoUID, err := ExtractMemoUIDFromName(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo")
+	}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user")
+	}
+	if memo.CreatorID != user.ID && !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	return memo, nil
+}
+
+// updateMemoContent saves the content restored from the nodes, keeping the rest of the markdown as it is.
+func (s *APIV1Service) updateMemoContent(ctx context.Context, memo *store.Memo, nodes []ast.Node) (*v1pb.Memo, error) {
+	memo.Content = restore.Restore(nodes)
+	if err := memopayload.RebuildMemoPayload(ctx, s.Store, memo); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
+	}
+	if err := s.Store.UpdateMemo(ctx, &store.UpdateMemo{
+		ID:      memo.ID,
+		Content: &memo.Content,
+		Payload: memo.Payload,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update memo: %v", err)
+	}
+	if err := memopayload.SyncMemoRelations(ctx, s.Store, memo); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sync memo relations: %v", err)
+	}
+
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get memo")
+	}
+	memoMessage, err := s.convertMemoFromStore(ctx, memo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert memo")
+	}
+	if err := s.DispatchMemoUpdatedWebhook(ctx, memoMessage); err != nil {
+		slog.Warn("Failed to dispatch memo updated webhook", slog.Any("err", err))
+	}
+	return memoMessage, nil
+}
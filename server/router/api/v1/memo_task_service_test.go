@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestToggleMemoTask(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	other := createTestingUser(ctx, t, s, "other", store.RoleUser)
+	userCtx := withTestingUser(ctx, user)
+	memo := createTestingMemo(ctx, t, s, user, "- [ ] one\n- [ ] two", store.Public, nil)
+	name := MemoNamePrefix + memo.UID
+
+	response, err := s.ToggleMemoTask(userCtx, &v1pb.ToggleMemoTaskRequest{Name: name, Index: 1})
+	require.NoError(t, err)
+	require.Equal(t, "- [ ] one\n- [x] two", response.Content)
+	// The id of a task stays the same while other tasks change.
+	taskID := response.Property.Tasks[1].Id
+	response, err = s.ToggleMemoTask(userCtx, &v1pb.ToggleMemoTaskRequest{Name: name, Index: 0})
+	require.NoError(t, err)
+	require.Equal(t, taskID, response.Property.Tasks[1].Id)
+	response, err = s.ToggleMemoTask(userCtx, &v1pb.ToggleMemoTaskRequest{Name: name, TaskId: taskID})
+	require.NoError(t, err)
+	require.Equal(t, "- [x] one\n- [ ] two", response.Content)
+
+	_, err = s.ToggleMemoTask(userCtx, &v1pb.ToggleMemoTaskRequest{Name: name, Index: 2})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.ToggleMemoTask(withTestingUser(ctx, other), &v1pb.ToggleMemoTaskRequest{Name: name, Index: 0})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestPromoteMemoTask(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	userCtx := withTestingUser(ctx, user)
+	project := createTestingProject(ctx, t, s, "API", user)
+	memo := createTestingMemo(ctx, t, s, user, "- [ ] Write docs", store.ProjectMembers, project)
+
+	response, err := s.PromoteMemoTask(userCtx, &v1pb.PromoteMemoTaskRequest{Name: MemoNamePrefix + memo.UID})
+	require.NoError(t, err)
+	ticket, err := s.Store.GetTicket(ctx, &store.FindTicket{ID: &response.TicketId})
+	require.NoError(t, err)
+	require.Equal(t, "Write docs", ticket.Title)
+	require.Equal(t, int32(1), ticket.Number)
+	// The task links to the ticket instead of being tagged with it.
+	require.Equal(t, "- [ ] Write docs [API-1](/tickets/API-1)", response.Memo.Content)
+	require.Empty(t, response.Memo.Tags)
+	memo, err = s.Store.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int32{ticket.ID}, memo.Payload.GetProperty().GetTicketReferences())
+
+	// The description memo links back to the memo of the task.
+	descriptionUID := ticket.Description[len("/m/"):]
+	descriptionMemo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &descriptionUID})
+	require.NoError(t, err)
+	require.Equal(t, store.ProjectMembers, descriptionMemo.Visibility)
+	require.Equal(t, []string{memo.UID}, listReferencedMemoUIDs(ctx, t, s, MemoNamePrefix+descriptionMemo.UID))
+}
+
+func TestPromoteMemoTaskFailure(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	userCtx := withTestingUser(ctx, user)
+	project := createTestingProject(ctx, t, s, "API", user)
+	memo := createTestingMemo(ctx, t, s, user, "- [ ] Write docs", store.ProjectMembers, project)
+	archived := store.Archived
+	_, err := s.Store.UpdateProject(ctx, &store.UpdateProject{ID: project.ID, RowStatus: &archived})
+	require.NoError(t, err)
+
+	_, err = s.PromoteMemoTask(userCtx, &v1pb.PromoteMemoTaskRequest{Name: MemoNamePrefix + memo.UID})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	// Nothing is left behind.
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{})
+	require.NoError(t, err)
+	require.Len(t, memos, 1)
+	tickets, err := s.Store.ListTickets(ctx, &store.FindTicket{})
+	require.NoError(t, err)
+	require.Empty(t, tickets)
+	memo, err = s.Store.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, "- [ ] Write docs", memo.Content)
+}
@@ -132,15 +132,22 @@ func renderNotificationHTML(n Notification) string {
 		message = fmt.Sprintf("<b>%s</b> sent you a notification", html.EscapeString(n.SenderName))
 	}
 
+	// The snippet is the plain text of the memo content, so it is escaped like any other text.
+	var snippet string
+	if n.Snippet != "" {
+		snippet = fmt.Sprintf(`<p class="sse-notification-snippet">%s</p>`, html.EscapeString(n.Snippet))
+	}
+
 	return fmt.Sprintf(`
 		<div id="notification-%d" class="sse-notification-item unread" onclick="window.location='/notifications'">
 			<div class="sse-notification-icon">%s</div>
 			<div class="sse-notification-content">
 				<p class="sse-notification-message">%s</p>
+				%s
 				<span class="sse-notification-time">%s</span>
 			</div>
 		</div>
-	`, n.ID, icon, message, n.Timestamp.Format("3:04 PM"))
+	`, n.ID, icon, message, snippet, n.Timestamp.Format("3:04 PM"))
 }
 
 // renderToastHTML generates HTML for a toast popup notification
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/usememos/memos/store/cache"
+)
+
+// oneTimeStore keeps short-lived values in memory that can be taken only once,
+// such as SSO authorization states and two-factor sign in challenges.
+type oneTimeStore[T any] struct {
+	mutex sync.Mutex
+	cache *cache.Cache
+}
+
+func newOneTimeStore[T any](ttl time.Duration) *oneTimeStore[T] {
+	return &oneTimeStore[T]{
+		cache: cache.New(cache.Config{
+			DefaultTTL:      ttl,
+			CleanupInterval: time.Minute,
+			MaxItems:        10000,
+		}),
+	}
+}
+
+func (s *oneTimeStore[T]) add(ctx context.Context, key string, value T) {
+	s.cache.Set(ctx, key, value)
+}
+
+func (s *oneTimeStore[T]) addWithTTL(ctx context.Context, key string, value T, ttl time.Duration) {
+	s.cache.SetWithTTL(ctx, key, value, ttl)
+}
+
+// take returns the value of the key and removes it.
+func (s *oneTimeStore[T]) take(ctx context.Context, key string) (T, bool) {
+	var zero T
+	if key == "" {
+		return zero, false
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	value, ok := s.cache.Get(ctx, key)
+	if !ok {
+		return zero, false
+	}
+	s.cache.Delete(ctx, key)
+	v, ok := value.(T)
+	return v, ok
+}
@@ -0,0 +1,475 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+type Project struct {
+	ID                int32                `json:"id"`
+	Key               string               `json:"key"`
+	Name              string               `json:"name"`
+	Description       string               `json:"description"`
+	RowStatus         string               `json:"rowStatus"`
+	CreatedTs         int64                `json:"createdTs"`
+	UpdatedTs         int64                `json:"updatedTs"`
+	TicketTypes       []*ProjectTicketType `json:"ticketTypes"`
+	Workflow          *ProjectWorkflow     `json:"workflow"`
+	DefaultAssigneeID *int32               `json:"defaultAssigneeId"`
+}
+
+type ProjectTicketType struct {
+	Name              string `json:"name"`
+	DefaultAssigneeID *int32 `json:"defaultAssigneeId"`
+}
+
+type ProjectWorkflow struct {
+	Statuses    []string                     `json:"statuses"`
+	Transitions []*ProjectWorkflowTransition `json:"transitions"`
+}
+
+type ProjectWorkflowTransition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type ProjectMember struct {
+	UserID    int32  `json:"userId"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	CreatedTs int64  `json:"createdTs"`
+}
+
+type CreateProjectRequest struct {
+	Key               string               `json:"key"`
+	Name              string               `json:"name"`
+	Description       string               `json:"description"`
+	TicketTypes       []*ProjectTicketType `json:"ticketTypes"`
+	Workflow          *ProjectWorkflow     `json:"workflow"`
+	DefaultAssigneeID *int32               `json:"defaultAssigneeId"`
+}
+
+type UpdateProjectRequest struct {
+	Name              *string              `json:"name"`
+	Description       *string              `json:"description"`
+	RowStatus         *string              `json:"rowStatus"`
+	TicketTypes       []*ProjectTicketType `json:"ticketTypes"`
+	Workflow          *ProjectWorkflow     `json:"workflow"`
+	DefaultAssigneeID *int32               `json:"defaultAssigneeId"`
+}
+
+type AddProjectMemberRequest struct {
+	UserID int32  `json:"userId"`
+	Role   string `json:"role"`
+}
+
+func (s *APIV1Service) RegisterProjectRoutes(g *echo.Group) {
+	g.POST("/projects", s.CreateProject)
+	g.GET("/projects", s.ListProjects)
+	g.GET("/projects/:key", s.GetProject)
+	g.PATCH("/projects/:key", s.UpdateProject)
+	g.DELETE("/projects/:key", s.DeleteProject)
+	g.GET("/projects/:key/members", s.ListProjectMembers)
+	g.POST("/projects/:key/members", s.AddProjectMember)
+	g.DELETE("/projects/:key/members/:userId", s.RemoveProjectMember)
+}
+
+func (s *APIV1Service) CreateProject(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	if err := s.checkTicketPermission(ctx, user, store.PermissionProjectManage); err != nil {
+		return err
+	}
+
+	request := &CreateProjectRequest{}
+	if err := c.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body").SetInternal(err)
+	}
+	if !store.ProjectKeyMatcher.MatchString(request.Key) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid project key, expected 2-10 uppercase letters or digits starting with a letter")
+	}
+	if request.Name == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Project name is required")
+	}
+	existing, err := s.Store.GetProject(ctx, &store.FindProject{Key: &request.Key})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get project").SetInternal(err)
+	}
+	if existing != nil {
+		return echo.NewHTTPError(http.StatusConflict, "Project key already exists")
+	}
+
+	payload := &storepb.ProjectPayload{}
+	applyProjectPayload(payload, request.TicketTypes, request.Workflow, request.DefaultAssigneeID)
+	if err := store.ValidateProjectPayload(payload); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	project, err := s.Store.CreateProject(ctx, &store.Project{
+		Key:         request.Key,
+		Name:        request.Name,
+		Description: request.Description,
+		Payload:     payload,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create project").SetInternal(err)
+	}
+	if _, err := s.Store.UpsertProjectMember(ctx, &store.ProjectMember{
+		ProjectID: project.ID,
+		UserID:    user.ID,
+		Role:      store.ProjectMemberRoleOwner,
+	}); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to add project owner").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, convertProjectFromStore(project))
+}
+
+func (s *APIV1Service) ListProjects(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+
+	find := &store.FindProject{}
+	canManage, err := s.Store.HasPermission(ctx, user, store.PermissionProjectManage)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check permission").SetInternal(err)
+	}
+	if !canManage {
+		// Users only see the projects they are a member of.
+		projectIDs, err := s.Store.ListUserProjectIDs(ctx, user.ID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list project members").SetInternal(err)
+		}
+		find.IDList = projectIDs
+	}
+	projects, err := s.Store.ListProjects(ctx, find)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list projects").SetInternal(err)
+	}
+
+	result := make([]*Project, 0, len(projects))
+	for _, project := range projects {
+		result = append(result, convertProjectFromStore(project))
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func (s *APIV1Service) GetProject(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	project, err := s.getProjectByKey(ctx, c.Param("key"))
+	if err != nil {
+		return err
+	}
+	if err := s.checkProjectAccess(ctx, user, project, false); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, convertProjectFromStore(project))
+}
+
+func (s *APIV1Service) UpdateProject(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	project, err := s.getProjectByKey(ctx, c.Param("key"))
+	if err != nil {
+		return err
+	}
+	if err := s.checkProjectAccess(ctx, user, project, true); err != nil {
+		return err
+	}
+
+	request := &UpdateProjectRequest{}
+	if err := c.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body").SetInternal(err)
+	}
+	now := time.Now().Unix()
+	update := &store.UpdateProject{
+		ID:          project.ID,
+		UpdatedTs:   &now,
+		Name:        request.Name,
+		Description: request.Description,
+	}
+	if request.Name != nil && *request.Name == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Project name is required")
+	}
+	if request.RowStatus != nil {
+		rowStatus := store.RowStatus(*request.RowStatus)
+		if rowStatus != store.Normal && rowStatus != store.Archived {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid row status")
+		}
+		update.RowStatus = &rowStatus
+	}
+	if request.TicketTypes != nil || request.Workflow != nil || request.DefaultAssigneeID != nil {
+		payload := project.Payload
+		applyProjectPayload(payload, request.TicketTypes, request.Workflow, request.DefaultAssigneeID)
+		if err := store.ValidateProjectPayload(payload); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		update.Payload = payload
+	}
+
+	project, err = s.Store.UpdateProject(ctx, update)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update project").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, convertProjectFromStore(project))
+}
+
+func (s *APIV1Service) DeleteProject(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	project, err := s.getProjectByKey(ctx, c.Param("key"))
+	if err != nil {
+		return err
+	}
+	if err := s.checkProjectAccess(ctx, user, project, true); err != nil {
+		return err
+	}
+
+	if err := s.Store.DeleteProject(ctx, &store.DeleteProject{ID: project.ID}); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete project").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, true)
+}
+
+func (s *APIV1Service) ListProjectMembers(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	project, err := s.getProjectByKey(ctx, c.Param("key"))
+	if err != nil {
+		return err
+	}
+	if err := s.checkProjectAccess(ctx, user, project, false); err != nil {
+		return err
+	}
+
+	members, err := s.Store.ListProjectMembers(ctx, &store.FindProjectMember{ProjectID: &project.ID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list project members").SetInternal(err)
+	}
+	result := make([]*ProjectMember, 0, len(members))
+	for _, member := range members {
+		memberUser, err := s.Store.GetUser(ctx, &store.FindUser{ID: &member.UserID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user").SetInternal(err)
+		}
+		if memberUser == nil {
+			continue
+		}
+		result = append(result, &ProjectMember{
+			UserID:    member.UserID,
+			Username:  memberUser.Username,
+			Role:      string(member.Role),
+			CreatedTs: member.CreatedTs,
+		})
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func (s *APIV1Service) AddProjectMember(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	project, err := s.getProjectByKey(ctx, c.Param("key"))
+	if err != nil {
+		return err
+	}
+	if err := s.checkProjectAccess(ctx, user, project, true); err != nil {
+		return err
+	}
+
+	request := &AddProjectMemberRequest{}
+	if err := c.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body").SetInternal(err)
+	}
+	role := store.ProjectMemberRole(request.Role)
+	if role == "" {
+		role = store.ProjectMemberRoleMember
+	}
+	if role != store.ProjectMemberRoleOwner && role != store.ProjectMemberRoleMember {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid project member role")
+	}
+	memberUser, err := s.Store.GetUser(ctx, &store.FindUser{ID: &request.UserID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user").SetInternal(err)
+	}
+	if memberUser == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+
+	member, err := s.Store.UpsertProjectMember(ctx, &store.ProjectMember{
+		ProjectID: project.ID,
+		UserID:    memberUser.ID,
+		Role:      role,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to add project member").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, &ProjectMember{
+		UserID:    member.UserID,
+		Username:  memberUser.Username,
+		Role:      string(member.Role),
+		CreatedTs: member.CreatedTs,
+	})
+}
+
+func (s *APIV1Service) RemoveProjectMember(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	project, err := s.getProjectByKey(ctx, c.Param("key"))
+	if err != nil {
+		return err
+	}
+	id, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+	userID := int32(id)
+	// Members may always leave a project themselves.
+	if userID != user.ID {
+		if err := s.checkProjectAccess(ctx, user, project, true); err != nil {
+			return err
+		}
+	}
+
+	if err := s.Store.DeleteProjectMember(ctx, &store.DeleteProjectMember{ProjectID: &project.ID, UserID: &userID}); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to remove project member").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, true)
+}
+
+// getProjectByKey returns the project with the key, or a not found error.
+func (s *APIV1Service) getProjectByKey(ctx context.Context, key string) (*store.Project, error) {
+	project, err := s.Store.GetProject(ctx, &store.FindProject{Key: &key})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get project").SetInternal(err)
+	}
+	if project == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Project not found")
+	}
+	return project, nil
+}
+
+// checkProjectAccess allows members of the project, or only its owners when requireOwner is set.
+// Users with the project.manage permission have access to every project.
+func (s *APIV1Service) checkProjectAccess(ctx context.Context, user *store.User, project *store.Project, requireOwner bool) error {
+	canManage, err := s.Store.HasPermission(ctx, user, store.PermissionProjectManage)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check permission").SetInternal(err)
+	}
+	if canManage {
+		return nil
+	}
+	member, err := s.Store.GetProjectMember(ctx, &store.FindProjectMember{ProjectID: &project.ID, UserID: &user.ID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get project member").SetInternal(err)
+	}
+	if member == nil {
+		return echo.NewHTTPError(http.StatusForbidden, "Permission denied: not a member of the project")
+	}
+	if requireOwner && member.Role != store.ProjectMemberRoleOwner {
+		return echo.NewHTTPError(http.StatusForbidden, "Permission denied: project owner is required")
+	}
+	return nil
+}
+
+// applyProjectPayload overwrites the parts of the payload that are set in the request.
+func applyProjectPayload(payload *storepb.ProjectPayload, ticketTypes []*ProjectTicketType, workflow *ProjectWorkflow, defaultAssigneeID *int32) {
+	if ticketTypes != nil {
+		payload.TicketTypes = []*storepb.ProjectPayload_TicketType{}
+		for _, ticketType := range ticketTypes {
+			payload.TicketTypes = append(payload.TicketTypes, &storepb.ProjectPayload_TicketType{
+				Name:              ticketType.Name,
+				DefaultAssigneeId: valueOrZero(ticketType.DefaultAssigneeID),
+			})
+		}
+	}
+	if workflow != nil {
+		payload.Workflow = &storepb.ProjectPayload_Workflow{Statuses: workflow.Statuses}
+		for _, transition := range workflow.Transitions {
+			payload.Workflow.Transitions = append(payload.Workflow.Transitions, &storepb.ProjectPayload_Transition{
+				From: transition.From,
+				To:   transition.To,
+			})
+		}
+	}
+	if defaultAssigneeID != nil {
+		payload.DefaultAssigneeId = *defaultAssigneeID
+	}
+}
+
+func convertProjectFromStore(project *store.Project) *Project {
+	result := &Project{
+		ID:                project.ID,
+		Key:               project.Key,
+		Name:              project.Name,
+		Description:       project.Description,
+		RowStatus:         string(project.RowStatus),
+		CreatedTs:         project.CreatedTs,
+		UpdatedTs:         project.UpdatedTs,
+		TicketTypes:       []*ProjectTicketType{},
+		DefaultAssigneeID: zeroToNil(project.Payload.GetDefaultAssigneeId()),
+	}
+	for _, ticketType := range project.Payload.GetTicketTypes() {
+		result.TicketTypes = append(result.TicketTypes, &ProjectTicketType{
+			Name:              ticketType.Name,
+			DefaultAssigneeID: zeroToNil(ticketType.DefaultAssigneeId),
+		})
+	}
+	if workflow := project.Payload.GetWorkflow(); workflow != nil {
+		result.Workflow = &ProjectWorkflow{
+			Statuses:    workflow.Statuses,
+			Transitions: []*ProjectWorkflowTransition{},
+		}
+		for _, transition := range workflow.Transitions {
+			result.Workflow.Transitions = append(result.Workflow.Transitions, &ProjectWorkflowTransition{
+				From: transition.From,
+				To:   transition.To,
+			})
+		}
+	}
+	return result
+}
+
+func valueOrZero(v *int32) int32 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func zeroToNil(v int32) *int32 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,160 @@
+package v1
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/plugin/ratelimit"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// maxLockoutDuration caps the exponential backoff of account lockouts.
+const maxLockoutDuration = 24 * time.Hour
+
+// RateLimiter limits the request rates of the API and locks accounts out after repeated failed sign ins.
+type RateLimiter struct {
+	Store   *store.Store
+	Profile *profile.Profile
+	limiter *ratelimit.Limiter
+	lockout *ratelimit.Lockout
+}
+
+// NewRateLimiter returns a new API rate limiter.
+func NewRateLimiter(store *store.Store, profile *profile.Profile) *RateLimiter {
+	return &RateLimiter{
+		Store:   store,
+		Profile: profile,
+		limiter: ratelimit.NewLimiter(),
+		lockout: ratelimit.NewLockout(),
+	}
+}
+
+// RateLimitInterceptor is the unary interceptor limiting the request rates of gRPC API.
+// It runs after authentication so that authenticated requests are limited per user.
+func (r *RateLimiter) RateLimitInterceptor(ctx context.Context, request any, serverInfo *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	setting, err := r.Store.GetWorkspaceRateLimitSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace rate limit setting: %v", err)
+	}
+	if setting.Disabled {
+		return handler(ctx, request)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	clientIP := getClientIPFromMetadata(ctx, md, r.Profile)
+	now := time.Now()
+	if isAuthRateLimitedMethod(serverInfo.FullMethod) {
+		policy := ratelimit.PerMinute(int(setting.AuthRequestsPerMinute), int(setting.AuthRequestsPerMinute))
+		if ok, retryAfter := r.limiter.Allow("auth:"+clientIP, policy, now); !ok {
+			return nil, resourceExhaustedError(ctx, "too many sign in attempts", retryAfter)
+		}
+	}
+	key := "ip:" + clientIP
+	if username, ok := ctx.Value(usernameContextKey).(string); ok {
+		key = "user:" + username
+	}
+	if ok, retryAfter := r.limiter.Allow(key, requestPolicy(setting), now); !ok {
+		return nil, resourceExhaustedError(ctx, "rate limit exceeded", retryAfter)
+	}
+	return handler(ctx, request)
+}
+
+// checkSignInLockout returns how long the username stays locked out after failed sign ins.
+func (r *RateLimiter) checkSignInLockout(ctx context.Context, username string) (time.Duration, error) {
+	setting, err := r.Store.GetWorkspaceRateLimitSetting(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if setting.Disabled {
+		return 0, nil
+	}
+	return r.lockout.Check(username, time.Now()), nil
+}
+
+// failSignIn records a failed sign in of the username and returns the lockout duration if it locked the account.
+func (r *RateLimiter) failSignIn(ctx context.Context, username string) (time.Duration, error) {
+	setting, err := r.Store.GetWorkspaceRateLimitSetting(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if setting.Disabled {
+		return 0, nil
+	}
+	return r.lockout.Fail(username, ratelimit.LockoutPolicy{
+		MaxAttempts: int(setting.MaxFailedSignInAttempts),
+		Duration:    time.Duration(setting.LockoutDurationSeconds) * time.Second,
+		MaxDuration: maxLockoutDuration,
+	}, time.Now()), nil
+}
+
+// resetSignIn forgets the failed sign ins of the username.
+func (r *RateLimiter) resetSignIn(username string) {
+	r.lockout.Reset(username)
+}
+
+// RateLimitMiddleware limits the request rates of the Echo routes. It runs after AuthMiddleware.
+func (s *APIV1Service) RateLimitMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		setting, err := s.Store.GetWorkspaceRateLimitSetting(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace rate limit setting").SetInternal(err)
+		}
+		if setting.Disabled {
+			return next(c)
+		}
+
+		key := "ip:" + c.RealIP()
+		if userID, ok := c.Get(getUserIDContextKey()).(int32); ok {
+			user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user").SetInternal(err)
+			}
+			if user != nil {
+				key = "user:" + user.Username
+			}
+		}
+		if ok, retryAfter := s.rateLimiter.limiter.Allow(key, requestPolicy(setting), time.Now()); !ok {
+			c.Response().Header().Set("Retry-After", strconv.FormatInt(retryAfterSeconds(retryAfter), 10))
+			return echo.NewHTTPError(http.StatusTooManyRequests, "Rate limit exceeded")
+		}
+		return next(c)
+	}
+}
+
+func requestPolicy(setting *storepb.WorkspaceRateLimitSetting) ratelimit.Policy {
+	return ratelimit.PerMinute(int(setting.RequestsPerMinute), int(setting.Burst))
+}
+
+// resourceExhaustedError returns a RESOURCE_EXHAUSTED status telling the client when to retry,
+// both as a Retry-After header and as a RetryInfo detail.
+func resourceExhaustedError(ctx context.Context, message string, retryAfter time.Duration) error {
+	seconds := retryAfterSeconds(retryAfter)
+	// Ignore the error when there is no transport stream to send the header through.
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.FormatInt(seconds, 10)))
+	st, err := status.New(codes.ResourceExhausted, message).WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(time.Duration(seconds) * time.Second),
+	})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, message)
+	}
+	return st.Err()
+}
+
+// retryAfterSeconds rounds the duration up to whole seconds, as the Retry-After header requires.
+func retryAfterSeconds(retryAfter time.Duration) int64 {
+	return max(int64(math.Ceil(retryAfter.Seconds())), 1)
+}
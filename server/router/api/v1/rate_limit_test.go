@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/internal/profile"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestGetClientIPFromMetadata(t *testing.T) {
+	gatewayPeer := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}}
+	tests := []struct {
+		name           string
+		peer           *peer.Peer
+		forwardedFor   string
+		trustedProxies []string
+		want           string
+	}{
+		{
+			name: "direct client",
+			peer: &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 5000}},
+			want: "203.0.113.7",
+		},
+		{
+			name:         "direct client forging the header",
+			peer:         &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 5000}},
+			forwardedFor: "10.0.0.1",
+			want:         "203.0.113.7",
+		},
+		{
+			name:         "gateway client forging the header",
+			peer:         gatewayPeer,
+			forwardedFor: "10.0.0.1, 203.0.113.7",
+			want:         "203.0.113.7",
+		},
+		{
+			name:           "gateway client behind a trusted proxy",
+			peer:           gatewayPeer,
+			forwardedFor:   "10.0.0.1, 203.0.113.7, 192.168.1.2",
+			trustedProxies: []string{"192.168.1.0/24"},
+			want:           "203.0.113.7",
+		},
+		{
+			name:         "gateway client behind an untrusted proxy",
+			peer:         gatewayPeer,
+			forwardedFor: "10.0.0.1, 203.0.113.7, 192.168.1.2",
+			want:         "192.168.1.2",
+		},
+		{
+			name:         "local client",
+			peer:         gatewayPeer,
+			forwardedFor: "127.0.0.1",
+			want:         "127.0.0.1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), test.peer)
+			md := metadata.MD{}
+			if test.forwardedFor != "" {
+				md = metadata.Pairs("x-forwarded-for", test.forwardedFor)
+			}
+			got := getClientIPFromMetadata(ctx, md, &profile.Profile{TrustedProxies: test.trustedProxies})
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestRateLimitForwardedForRotation(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	_, err := s.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_RATE_LIMIT,
+		Value: &storepb.WorkspaceSetting_RateLimitSetting{
+			RateLimitSetting: &storepb.WorkspaceRateLimitSetting{
+				RequestsPerMinute:     1,
+				Burst:                 2,
+				AuthRequestsPerMinute: 2,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("gRPC", func(t *testing.T) {
+		gatewayPeer := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}}
+		serverInfo := &grpc.UnaryServerInfo{FullMethod: "/memos.api.v1.AuthService/SignIn"}
+		handler := func(context.Context, any) (any, error) {
+			return nil, nil
+		}
+		for i := 0; i < 3; i++ {
+			// The gateway appends the address of the client to the header the client sent.
+			md := metadata.Pairs("x-forwarded-for", fmt.Sprintf("10.0.0.%d, 203.0.113.7", i))
+			requestCtx := metadata.NewIncomingContext(peer.NewContext(ctx, gatewayPeer), md)
+			_, err := s.rateLimiter.RateLimitInterceptor(requestCtx, nil, serverInfo, handler)
+			if i < 2 {
+				require.NoError(t, err)
+			} else {
+				require.Equal(t, codes.ResourceExhausted, status.Code(err))
+			}
+		}
+	})
+
+	t.Run("Echo", func(t *testing.T) {
+		e := echo.New()
+		e.IPExtractor, err = NewIPExtractor(s.Profile)
+		require.NoError(t, err)
+		e.GET("/test", func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		}, s.RateLimitMiddleware)
+		for i := 0; i < 3; i++ {
+			request := httptest.NewRequest(http.MethodGet, "/test", nil)
+			request.RemoteAddr = "203.0.113.8:5000"
+			request.Header.Set(echo.HeaderXForwardedFor, fmt.Sprintf("10.0.0.%d", i))
+			recorder := httptest.NewRecorder()
+			e.ServeHTTP(recorder, request)
+			if i < 2 {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			} else {
+				require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+			}
+		}
+	})
+}
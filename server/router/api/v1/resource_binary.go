@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/status"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// handleGetResourceBinary streams the content of a resource from its storage, and serves HTTP range
+// requests without reading the rest of the content. It serves the route of GetResourceBinary.
+func (s *APIV1Service) handleGetResourceBinary(c echo.Context) error {
+	ctx := c.Request().Context()
+	if userID, ok := c.Get(getUserIDContextKey()).(int32); ok {
+		user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user").SetInternal(err)
+		}
+		if user != nil {
+			ctx = context.WithValue(ctx, usernameContextKey, user.Username)
+		}
+	}
+
+	resourceUID := c.Param("uid")
+	resource, err := s.Store.GetResource(ctx, &store.FindResource{
+		GetBlob: true,
+		UID:     &resourceUID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get resource").SetInternal(err)
+	}
+	if resource == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Resource not found")
+	}
+	if err := s.checkResourceAccess(ctx, resource); err != nil {
+		st := status.Convert(err)
+		return echo.NewHTTPError(runtime.HTTPStatusFromCode(st.Code()), st.Message())
+	}
+	if resource.StorageType == storepb.ResourceStorageType_EXTERNAL {
+		return echo.NewHTTPError(http.StatusNotFound, "External resources have no stored content")
+	}
+	modTime := time.Unix(resource.UpdatedTs, 0)
+
+	thumbnail, _ := strconv.ParseBool(c.QueryParam("thumbnail"))
+	if variant := findResourceVariant(resource, c.QueryParam("variant"), thumbnail); variant != nil {
+		file, err := os.Open(s.Store.ResourceVariantPath(resource, variant.Name))
+		if err != nil {
+			// Serve the resource itself when its variant is not available.
+			slog.Warn("failed to open resource variant", slog.String("variant", variant.Name), slog.Any("error", err))
+		} else {
+			defer file.Close()
+			c.Response().Header().Set(echo.HeaderContentType, variant.Type)
+			http.ServeContent(c.Response(), c.Request(), "", modTime, file)
+			return nil
+		}
+	}
+
+	content, err := s.Store.OpenResourceContent(ctx, resource)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to open resource content").SetInternal(err)
+	}
+	defer content.Close()
+	c.Response().Header().Set(echo.HeaderContentType, resourceContentType(resource))
+	http.ServeContent(c.Response(), c.Request(), "", modTime, content)
+	return nil
+}
@@ -17,6 +17,8 @@ const (
 	InboxNamePrefix            = "inboxes/"
 	IdentityProviderNamePrefix = "identityProviders/"
 	ActivityNamePrefix         = "activities/"
+	ProjectNamePrefix          = "projects/"
+	TicketNamePrefix           = "tickets/"
 )
 
 // GetNameParentTokens returns the tokens from a resource name.
@@ -117,3 +119,28 @@ func ExtractActivityIDFromName(name string) (int32, error) {
 	}
 	return id, nil
 }
+
+func ExtractProjectIDFromName(name string) (int32, error) {
+	tokens, err := GetNameParentTokens(name, ProjectNamePrefix)
+	if err != nil {
+		return 0, err
+	}
+	id, err := util.ConvertStringToInt32(tokens[0])
+	if err != nil {
+		return 0, errors.Errorf("invalid project ID %q", tokens[0])
+	}
+	return id, nil
+}
+
+// ExtractTicketIDFromName returns the ticket ID from a resource name.
+func ExtractTicketIDFromName(name string) (int32, error) {
+	tokens, err := GetNameParentTokens(name, TicketNamePrefix)
+	if err != nil {
+		return 0, err
+	}
+	id, err := util.ConvertStringToInt32(tokens[0])
+	if err != nil {
+		return 0, errors.Errorf("invalid ticket ID %q", tokens[0])
+	}
+	return id, nil
+}
@@ -7,13 +7,13 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
-	"github.com/disintegration/imaging"
 	"github.com/lithammer/shortuuid/v4"
 	"github.com/pkg/errors"
 	"google.golang.org/genproto/googleapis/api/httpbody"
@@ -22,9 +22,8 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	"github.com/usememos/memos/internal/profile"
 	"github.com/usememos/memos/internal/util"
-	"github.com/usememos/memos/plugin/storage/s3"
+	"github.com/usememos/memos/plugin/exif"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
@@ -36,15 +35,10 @@ const (
 	// This is unrelated to maximum upload size limit, which is now set through system setting.
 	MaxUploadBufferSizeBytes = 32 << 20
 	MebiByte                 = 1024 * 1024
-	// ThumbnailCacheFolder is the folder name where the thumbnail images are stored.
-	ThumbnailCacheFolder = ".thumbnail_cache"
+	// thumbnailVariant is the variant of images served as their thumbnail.
+	thumbnailVariant = "small"
 )
 
-var SupportedThumbnailMimeTypes = []string{
-	"image/png",
-	"image/jpeg",
-}
-
 func (s *APIV1Service) CreateResource(ctx context.Context, request *v1pb.CreateResourceRequest) (*v1pb.Resource, error) {
 	user, err := s.GetCurrentUser(ctx)
 	if err != nil {
@@ -63,16 +57,15 @@ func (s *APIV1Service) CreateResource(ctx context.Context, request *v1pb.CreateR
 		return nil, status.Errorf(codes.Internal, "failed to get workspace storage setting: %v", err)
 	}
 	size := binary.Size(request.Resource.Content)
-	uploadSizeLimit := int(workspaceStorageSetting.UploadSizeLimitMb) * MebiByte
-	if uploadSizeLimit == 0 {
-		uploadSizeLimit = MaxUploadBufferSizeBytes
-	}
-	if size > uploadSizeLimit {
+	if int64(size) > uploadSizeLimit(workspaceStorageSetting) {
 		return nil, status.Errorf(codes.InvalidArgument, "file size exceeds the limit")
 	}
 	create.Size = int64(size)
 	create.Blob = request.Resource.Content
-	if err := SaveResourceBlob(ctx, s.Profile, s.Store, create); err != nil {
+	if !workspaceStorageSetting.KeepLocationMetadata {
+		exif.StripGPS(create.Blob)
+	}
+	if err := SaveResourceBlob(ctx, s.Store, create); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to save resource blob: %v", err)
 	}
 
@@ -91,6 +84,7 @@ func (s *APIV1Service) CreateResource(ctx context.Context, request *v1pb.CreateR
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create resource: %v", err)
 	}
+	s.resourceProcessor.Enqueue(resource.ID)
 
 	return s.convertResourceFromStore(ctx, resource), nil
 }
@@ -144,47 +138,84 @@ func (s *APIV1Service) GetResourceBinary(ctx context.Context, request *v1pb.GetR
 	if resource == nil {
 		return nil, status.Errorf(codes.NotFound, "resource not found")
 	}
-	// Check the related memo visibility.
+	if err := s.checkResourceAccess(ctx, resource); err != nil {
+		return nil, err
+	}
+
+	if variant := findResourceVariant(resource, request.Variant, request.Thumbnail); variant != nil {
+		blob, err := os.ReadFile(s.Store.ResourceVariantPath(resource, variant.Name))
+		if err != nil {
+			// The resource itself can be used in place of its variant.
+			slog.Warn("failed to read resource variant", slog.String("variant", variant.Name), slog.Any("error", err))
+		} else {
+			return &httpbody.HttpBody{
+				ContentType: variant.Type,
+				Data:        blob,
+			}, nil
+		}
+	}
+
+	blob, err := s.GetResourceBlob(ctx, resource)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get resource blob: %v", err)
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: resourceContentType(resource),
+		Data:        blob,
+	}, nil
+}
+
+// checkResourceAccess checks that the current user can read the resource, which depends on the visibility of its memo.
+func (s *APIV1Service) checkResourceAccess(ctx context.Context, resource *store.Resource) error {
 	if resource.MemoID != nil {
 		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
 			ID: resource.MemoID,
 		})
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to find memo by ID: %v", resource.MemoID)
+			return status.Errorf(codes.Internal, "failed to find memo by ID: %v", resource.MemoID)
 		}
 		if memo != nil && memo.Visibility != store.Public {
 			user, err := s.GetCurrentUser(ctx)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+				return status.Errorf(codes.Internal, "failed to get current user: %v", err)
 			}
 			if user == nil {
-				return nil, status.Errorf(codes.Unauthenticated, "unauthorized access")
+				return status.Errorf(codes.Unauthenticated, "unauthorized access")
 			}
-			if memo.Visibility == store.Private && user.ID != resource.CreatorID {
-				return nil, status.Errorf(codes.Unauthenticated, "unauthorized access")
+			if user.ID != resource.CreatorID {
+				canView, err := s.canViewMemo(ctx, user, memo)
+				if err != nil {
+					return status.Errorf(codes.Internal, "failed to check memo access: %v", err)
+				}
+				if !canView {
+					return status.Errorf(codes.Unauthenticated, "unauthorized access")
+				}
 			}
 		}
 	}
+	return nil
+}
 
-	if request.Thumbnail && util.HasPrefixes(resource.Type, SupportedThumbnailMimeTypes...) {
-		thumbnailBlob, err := s.getOrGenerateThumbnail(resource)
-		if err != nil {
-			// thumbnail failures are logged as warnings and not cosidered critical failures as
-			// a resource image can be used in its place.
-			slog.Warn("failed to get resource thumbnail image", slog.Any("error", err))
-		} else {
-			return &httpbody.HttpBody{
-				ContentType: resource.Type,
-				Data:        thumbnailBlob,
-			}, nil
-		}
+// findResourceVariant returns the variant of the resource to serve, if it exists.
+// The thumbnail of an image is its small variant.
+func findResourceVariant(resource *store.Resource, name string, thumbnail bool) *storepb.ResourcePayload_Variant {
+	if name == "" && thumbnail {
+		name = thumbnailVariant
 	}
-
-	blob, err := s.GetResourceBlob(resource)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get resource blob: %v", err)
+	if name == "" {
+		return nil
+	}
+	for _, variant := range resource.Payload.GetVariants() {
+		if variant.Name == name {
+			return variant
+		}
 	}
+	return nil
+}
 
+// resourceContentType returns the content type the resource is served with.
+func resourceContentType(resource *store.Resource) string {
 	contentType := resource.Type
 	if strings.HasPrefix(contentType, "text/") {
 		contentType += "; charset=utf-8"
@@ -195,11 +226,7 @@ func (s *APIV1Service) GetResourceBinary(ctx context.Context, request *v1pb.GetR
 		strings.EqualFold(contentType, "application/xhtml+xml") {
 		contentType = "application/octet-stream"
 	}
-
-	return &httpbody.HttpBody{
-		ContentType: contentType,
-		Data:        blob,
-	}, nil
+	return contentType
 }
 
 func (s *APIV1Service) UpdateResource(ctx context.Context, request *v1pb.UpdateResourceRequest) (*v1pb.Resource, error) {
@@ -273,6 +300,14 @@ func (s *APIV1Service) convertResourceFromStore(ctx context.Context, resource *s
 	if resource.StorageType == storepb.ResourceStorageType_EXTERNAL || resource.StorageType == storepb.ResourceStorageType_S3 {
 		resourceMessage.ExternalLink = resource.Reference
 	}
+	if payload := resource.Payload; payload != nil {
+		resourceMessage.Width = payload.Width
+		resourceMessage.Height = payload.Height
+		resourceMessage.Blurhash = payload.Blurhash
+		for _, variant := range payload.Variants {
+			resourceMessage.Variants = append(resourceMessage.Variants, variant.Name)
+		}
+	}
 	if resource.MemoID != nil {
 		memo, _ := s.Store.GetMemo(ctx, &store.FindMemo{
 			ID: resource.MemoID,
@@ -286,160 +321,57 @@ func (s *APIV1Service) convertResourceFromStore(ctx context.Context, resource *s
 	return resourceMessage
 }
 
-// SaveResourceBlob save the blob of resource based on the storage config.
-func SaveResourceBlob(ctx context.Context, profile *profile.Profile, stores *store.Store, create *store.Resource) error {
-	workspaceStorageSetting, err := stores.GetWorkspaceStorageSetting(ctx)
-	if err != nil {
-		return errors.Wrap(err, "Failed to find workspace storage setting")
-	}
-
-	if workspaceStorageSetting.StorageType == storepb.WorkspaceStorageSetting_LOCAL {
-		filepathTemplate := "assets/{timestamp}_{filename}"
-		if workspaceStorageSetting.FilepathTemplate != "" {
-			filepathTemplate = workspaceStorageSetting.FilepathTemplate
-		}
-
-		internalPath := filepathTemplate
-		if !strings.Contains(internalPath, "{filename}") {
-			internalPath = filepath.Join(internalPath, "{filename}")
-		}
-		internalPath = replaceFilenameWithPathTemplate(internalPath, create.Filename)
-		internalPath = filepath.ToSlash(internalPath)
-
-		// Ensure the directory exists.
-		osPath := filepath.FromSlash(internalPath)
-		if !filepath.IsAbs(osPath) {
-			osPath = filepath.Join(profile.Data, osPath)
-		}
-		dir := filepath.Dir(osPath)
-		if err = os.MkdirAll(dir, os.ModePerm); err != nil {
-			return errors.Wrap(err, "Failed to create directory")
-		}
-		dst, err := os.Create(osPath)
-		if err != nil {
-			return errors.Wrap(err, "Failed to create file")
-		}
-		defer dst.Close()
-
-		// Write the blob to the file.
-		if err := os.WriteFile(osPath, create.Blob, 0644); err != nil {
-			return errors.Wrap(err, "Failed to write file")
-		}
-		create.Reference = internalPath
-		create.Blob = nil
-		create.StorageType = storepb.ResourceStorageType_LOCAL
-	} else if workspaceStorageSetting.StorageType == storepb.WorkspaceStorageSetting_S3 {
-		s3Config := workspaceStorageSetting.S3Config
-		if s3Config == nil {
-			return errors.Errorf("No actived external storage found")
-		}
-		s3Client, err := s3.NewClient(ctx, s3Config)
-		if err != nil {
-			return errors.Wrap(err, "Failed to create s3 client")
-		}
-
-		filepathTemplate := workspaceStorageSetting.FilepathTemplate
-		if !strings.Contains(filepathTemplate, "{filename}") {
-			filepathTemplate = filepath.Join(filepathTemplate, "{filename}")
-		}
-		filepathTemplate = replaceFilenameWithPathTemplate(filepathTemplate, create.Filename)
-		key, err := s3Client.UploadObject(ctx, filepathTemplate, create.Type, bytes.NewReader(create.Blob))
-		if err != nil {
-			return errors.Wrap(err, "Failed to upload via s3 client")
-		}
-		presignURL, err := s3Client.PresignGetObject(ctx, key)
-		if err != nil {
-			return errors.Wrap(err, "Failed to presign via s3 client")
-		}
-
-		create.Reference = presignURL
-		create.Blob = nil
-		create.StorageType = storepb.ResourceStorageType_S3
-		create.Payload = &storepb.ResourcePayload{
-			Payload: &storepb.ResourcePayload_S3Object_{
-				S3Object: &storepb.ResourcePayload_S3Object{
-					S3Config:          s3Config,
-					Key:               key,
-					LastPresignedTime: timestamppb.New(time.Now()),
-				},
-			},
-		}
+// ResourceURL returns the URL of the content of the resource, relative to the instance URL unless
+// the content is stored externally.
+func ResourceURL(resource *store.Resource) string {
+	if resource.StorageType == storepb.ResourceStorageType_EXTERNAL || resource.StorageType == storepb.ResourceStorageType_S3 {
+		return resource.Reference
 	}
-
-	return nil
+	return fmt.Sprintf("/file/%s%s/%s", ResourceNamePrefix, resource.UID, url.PathEscape(resource.Filename))
 }
 
-func (s *APIV1Service) GetResourceBlob(resource *store.Resource) ([]byte, error) {
-	// For local storage, read the file from the local disk.
-	if resource.StorageType == storepb.ResourceStorageType_LOCAL {
-		resourcePath := filepath.FromSlash(resource.Reference)
-		if !filepath.IsAbs(resourcePath) {
-			resourcePath = filepath.Join(s.Profile.Data, resourcePath)
-		}
-
-		file, err := os.Open(resourcePath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				return nil, errors.Wrap(err, "file not found")
-			}
-			return nil, errors.Wrap(err, "failed to open the file")
-		}
-		defer file.Close()
-		blob, err := io.ReadAll(file)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to read the file")
-		}
-		return blob, nil
+// uploadSizeLimit returns the maximum size of uploaded resources in bytes.
+func uploadSizeLimit(workspaceStorageSetting *storepb.WorkspaceStorageSetting) int64 {
+	if workspaceStorageSetting.UploadSizeLimitMb == 0 {
+		return MaxUploadBufferSizeBytes
 	}
-	// For database storage, return the blob from the database.
-	return resource.Blob, nil
+	return workspaceStorageSetting.UploadSizeLimitMb * MebiByte
 }
 
-const (
-	// thumbnailRatio is the ratio of the thumbnail image.
-	thumbnailRatio = 0.8
-)
+// SaveResourceBlob save the blob of resource based on the storage config.
+func SaveResourceBlob(ctx context.Context, stores *store.Store, create *store.Resource) error {
+	return saveResourceContent(ctx, stores, create, bytes.NewReader(create.Blob))
+}
 
-// getOrGenerateThumbnail returns the thumbnail image of the resource.
-func (s *APIV1Service) getOrGenerateThumbnail(resource *store.Resource) ([]byte, error) {
-	thumbnailCacheFolder := filepath.Join(s.Profile.Data, ThumbnailCacheFolder)
-	if err := os.MkdirAll(thumbnailCacheFolder, os.ModePerm); err != nil {
-		return nil, errors.Wrap(err, "failed to create thumbnail cache folder")
+// saveResourceContent streams the content of the resource to the storage of the workspace.
+// The content is create.Size bytes long.
+func saveResourceContent(ctx context.Context, stores *store.Store, create *store.Resource, content io.ReadSeeker) error {
+	workspaceStorageSetting, err := stores.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "Failed to find workspace storage setting")
 	}
-	filePath := filepath.Join(thumbnailCacheFolder, fmt.Sprintf("%d%s", resource.ID, filepath.Ext(resource.Filename)))
-	if _, err := os.Stat(filePath); err != nil {
-		if !os.IsNotExist(err) {
-			return nil, errors.Wrap(err, "failed to check thumbnail image stat")
-		}
-
-		// If thumbnail image does not exist, generate and save the thumbnail image.
-		blob, err := s.GetResourceBlob(resource)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get resource blob")
-		}
-		img, err := imaging.Decode(bytes.NewReader(blob), imaging.AutoOrientation(true))
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to decode thumbnail image")
-		}
 
-		thumbnailWidth := int(float64(img.Bounds().Dx()) * thumbnailRatio)
-		// Resize the image to the thumbnailWidth.
-		thumbnailImage := imaging.Resize(img, thumbnailWidth, 0, imaging.Lanczos)
-		if err := imaging.Save(thumbnailImage, filePath); err != nil {
-			return nil, errors.Wrap(err, "failed to save thumbnail file")
+	if workspaceStorageSetting.ContentAddressed {
+		if err := stores.PutResourceBlob(ctx, create, content, workspaceStorageSetting); err != nil {
+			return errors.Wrap(err, "Failed to put content addressed blob")
 		}
+		return nil
 	}
 
-	thumbnailFile, err := os.Open(filePath)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to open thumbnail file")
+	filepathTemplate := workspaceStorageSetting.FilepathTemplate
+	if !strings.Contains(filepathTemplate, "{filename}") {
+		filepathTemplate = filepath.Join(filepathTemplate, "{filename}")
 	}
-	defer thumbnailFile.Close()
-	blob, err := io.ReadAll(thumbnailFile)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to read thumbnail file")
+	key := filepath.ToSlash(replaceFilenameWithPathTemplate(filepathTemplate, create.Filename))
+	if err := stores.PutResourceContent(ctx, create, key, content, workspaceStorageSetting); err != nil {
+		return errors.Wrap(err, "Failed to store resource content")
 	}
-	return blob, nil
+	return nil
+}
+
+// GetResourceBlob returns the content of the resource from its storage.
+func (s *APIV1Service) GetResourceBlob(ctx context.Context, resource *store.Resource) ([]byte, error) {
+	return s.Store.ReadResourceContent(ctx, resource)
 }
 
 var fileKeyPattern = regexp.MustCompile(`\{[a-z]{1,9}\}`)
@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestGetResourceBinaryOfProjectMemo(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	creator := createTestingUser(ctx, t, s, "creator", store.RoleUser)
+	member := createTestingUser(ctx, t, s, "member", store.RoleUser)
+	outsider := createTestingUser(ctx, t, s, "outsider", store.RoleUser)
+	project := createTestingProject(ctx, t, s, "API", creator, member)
+	memo := createTestingMemo(ctx, t, s, creator, "attachment", store.ProjectMembers, project)
+	resource, err := s.Store.CreateResource(ctx, &store.Resource{
+		UID:         "attachment",
+		CreatorID:   creator.ID,
+		Filename:    "attachment.txt",
+		Blob:        []byte("secret"),
+		Type:        "text/plain",
+		Size:        6,
+		StorageType: storepb.ResourceStorageType_RESOURCE_STORAGE_TYPE_UNSPECIFIED,
+		MemoID:      &memo.ID,
+	})
+	require.NoError(t, err)
+	request := &v1pb.GetResourceBinaryRequest{Name: ResourceNamePrefix + resource.UID}
+
+	for _, user := range []*store.User{creator, member} {
+		body, err := s.GetResourceBinary(withTestingUser(ctx, user), request)
+		require.NoError(t, err)
+		require.Equal(t, []byte("secret"), body.Data)
+	}
+	_, err = s.GetResourceBinary(withTestingUser(ctx, outsider), request)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = s.GetResourceBinary(ctx, request)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
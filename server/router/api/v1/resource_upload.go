@@ -0,0 +1,460 @@
+package v1
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/base"
+	"github.com/usememos/memos/plugin/exif"
+	"github.com/usememos/memos/store"
+)
+
+// The upload endpoints implement the core and the creation and termination extensions of the tus resumable
+// upload protocol. Reference: https://tus.io/protocols/resumable-upload
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,termination"
+	// UploadFolder is the folder name where the uploads in progress are stored.
+	UploadFolder = ".uploads"
+	// uploadExpiration is how long an unfinished upload can be resumed.
+	uploadExpiration = 24 * time.Hour
+	// resourceNameHeader tells the client the name of the resource created by a finished upload.
+	resourceNameHeader = "Memos-Resource-Name"
+	// exifHeadSize is the size of the beginning of JPEG images read for their EXIF metadata.
+	exifHeadSize = 128 << 10
+)
+
+// upload is an upload in progress. The received content is appended to a file next to its info.
+type upload struct {
+	ID        string `json:"id"`
+	CreatorID int32  `json:"creatorId"`
+	Filename  string `json:"filename"`
+	Type      string `json:"type"`
+	// Memo is the name of the memo the resource is attached to.
+	Memo      string `json:"memo,omitempty"`
+	Length    int64  `json:"length"`
+	CreatedTs int64  `json:"createdTs"`
+	// Resource is the name of the created resource once the upload is finished.
+	Resource string `json:"resource,omitempty"`
+}
+
+// uploadLocks makes sure an upload receives one request at a time.
+type uploadLocks struct {
+	mu     sync.Mutex
+	locked map[string]bool
+}
+
+func (l *uploadLocks) tryLock(id string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.locked[id] {
+		return false
+	}
+	l.locked[id] = true
+	return true
+}
+
+func (l *uploadLocks) unlock(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.locked, id)
+}
+
+// RegisterUploadRoutes registers the resumable upload routes. Uploaded contents are written to the
+// storage of the workspace once complete, and become resources.
+func (s *APIV1Service) RegisterUploadRoutes(g *echo.Group) {
+	g.POST("/uploads", s.handleCreateUpload)
+	g.HEAD("/uploads/:id", s.handleGetUploadOffset)
+	g.PATCH("/uploads/:id", s.handleAppendUpload)
+	g.DELETE("/uploads/:id", s.handleDeleteUpload)
+}
+
+// handleUploadOptions returns the capabilities of the upload endpoints. It does not require authentication.
+func (s *APIV1Service) handleUploadOptions(c echo.Context) error {
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace storage setting").SetInternal(err)
+	}
+	header := c.Response().Header()
+	header.Set("Tus-Resumable", tusVersion)
+	header.Set("Tus-Version", tusVersion)
+	header.Set("Tus-Extension", tusExtensions)
+	header.Set("Tus-Max-Size", strconv.FormatInt(uploadSizeLimit(workspaceStorageSetting), 10))
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *APIV1Service) handleCreateUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+	if err := checkTusResumable(c); err != nil {
+		return err
+	}
+	userID, _ := c.Get(getUserIDContextKey()).(int32)
+
+	length, err := strconv.ParseInt(c.Request().Header.Get("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid Upload-Length header")
+	}
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace storage setting").SetInternal(err)
+	}
+	// Reject too large uploads before receiving any content.
+	if length > uploadSizeLimit(workspaceStorageSetting) {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "File size exceeds the limit")
+	}
+	metadata, err := parseUploadMetadata(c.Request().Header.Get("Upload-Metadata"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid Upload-Metadata header").SetInternal(err)
+	}
+	if metadata["filename"] == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Filename is required in Upload-Metadata")
+	}
+	// Check the memo before receiving any content, it is checked again once the upload is finished.
+	if memo := metadata["memo"]; memo != "" {
+		if _, err := s.getUploadMemo(ctx, memo, userID); err != nil {
+			return err
+		}
+	}
+
+	s.deleteExpiredUploads()
+	u := &upload{
+		ID:        shortuuid.New(),
+		CreatorID: userID,
+		Filename:  metadata["filename"],
+		Type:      metadata["filetype"],
+		Memo:      metadata["memo"],
+		Length:    length,
+		CreatedTs: time.Now().Unix(),
+	}
+	if err := os.MkdirAll(s.uploadFolder(), os.ModePerm); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create upload folder").SetInternal(err)
+	}
+	if err := os.WriteFile(s.uploadContentPath(u.ID), nil, 0600); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create upload").SetInternal(err)
+	}
+	if err := s.saveUpload(u); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create upload").SetInternal(err)
+	}
+	// An empty upload is complete as soon as it is created.
+	if length == 0 {
+		if err := s.finishUpload(c, u); err != nil {
+			return err
+		}
+	}
+
+	header := c.Response().Header()
+	header.Set("Tus-Resumable", tusVersion)
+	header.Set("Location", "/api/v1/uploads/"+u.ID)
+	header.Set("Upload-Offset", "0")
+	return c.NoContent(http.StatusCreated)
+}
+
+func (s *APIV1Service) handleGetUploadOffset(c echo.Context) error {
+	u, err := s.getUpload(c)
+	if err != nil {
+		return err
+	}
+	offset := u.Length
+	if u.Resource == "" {
+		info, err := os.Stat(s.uploadContentPath(u.ID))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get upload offset").SetInternal(err)
+		}
+		offset = info.Size()
+	}
+
+	header := c.Response().Header()
+	header.Set("Tus-Resumable", tusVersion)
+	header.Set("Cache-Control", "no-store")
+	header.Set("Upload-Offset", strconv.FormatInt(offset, 10))
+	header.Set("Upload-Length", strconv.FormatInt(u.Length, 10))
+	if u.Resource != "" {
+		header.Set(resourceNameHeader, u.Resource)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+func (s *APIV1Service) handleAppendUpload(c echo.Context) error {
+	if err := checkTusResumable(c); err != nil {
+		return err
+	}
+	if c.Request().Header.Get("Content-Type") != "application/offset+octet-stream" {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "Content-Type must be application/offset+octet-stream")
+	}
+	u, err := s.getUpload(c)
+	if err != nil {
+		return err
+	}
+	if !s.uploadLocks.tryLock(u.ID) {
+		return echo.NewHTTPError(http.StatusLocked, "Upload is being written by another request")
+	}
+	defer s.uploadLocks.unlock(u.ID)
+	if u.Resource != "" {
+		return echo.NewHTTPError(http.StatusConflict, "Upload is already finished")
+	}
+
+	file, err := os.OpenFile(s.uploadContentPath(u.ID), os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to open upload").SetInternal(err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get upload offset").SetInternal(err)
+	}
+	offset := info.Size()
+	if c.Request().Header.Get("Upload-Offset") != strconv.FormatInt(offset, 10) {
+		return echo.NewHTTPError(http.StatusConflict, "Upload-Offset does not match the upload")
+	}
+
+	// Keep what was received before a disconnection, so the client can resume from it.
+	body := c.Request().Body
+	written, err := io.Copy(file, io.LimitReader(body, u.Length-offset))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to write upload").SetInternal(err)
+	}
+	if n, _ := body.Read(make([]byte, 1)); n > 0 {
+		if err := file.Truncate(offset); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to write upload").SetInternal(err)
+		}
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Content exceeds Upload-Length")
+	}
+	offset += written
+	if offset == u.Length {
+		if err := file.Close(); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to write upload").SetInternal(err)
+		}
+		if err := s.finishUpload(c, u); err != nil {
+			return err
+		}
+	}
+
+	header := c.Response().Header()
+	header.Set("Tus-Resumable", tusVersion)
+	header.Set("Upload-Offset", strconv.FormatInt(offset, 10))
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *APIV1Service) handleDeleteUpload(c echo.Context) error {
+	if err := checkTusResumable(c); err != nil {
+		return err
+	}
+	u, err := s.getUpload(c)
+	if err != nil {
+		return err
+	}
+	if !s.uploadLocks.tryLock(u.ID) {
+		return echo.NewHTTPError(http.StatusLocked, "Upload is being written by another request")
+	}
+	defer s.uploadLocks.unlock(u.ID)
+	s.deleteUpload(u.ID)
+	c.Response().Header().Set("Tus-Resumable", tusVersion)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// finishUpload streams the received content to the storage of the workspace and creates the resource.
+func (s *APIV1Service) finishUpload(c echo.Context, u *upload) error {
+	ctx := c.Request().Context()
+	create := &store.Resource{
+		UID:       shortuuid.New(),
+		CreatorID: u.CreatorID,
+		Filename:  u.Filename,
+		Type:      u.Type,
+		Size:      u.Length,
+	}
+	if u.Memo != "" {
+		memo, err := s.getUploadMemo(ctx, u.Memo, u.CreatorID)
+		if err != nil {
+			return err
+		}
+		create.MemoID = &memo.ID
+	}
+
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace storage setting").SetInternal(err)
+	}
+	if !workspaceStorageSetting.KeepLocationMetadata {
+		if err := stripUploadLocation(s.uploadContentPath(u.ID)); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to strip location metadata").SetInternal(err)
+		}
+	}
+	content, err := os.Open(s.uploadContentPath(u.ID))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to open upload").SetInternal(err)
+	}
+	defer content.Close()
+	if err := saveResourceContent(ctx, s.Store, create, content); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save resource content").SetInternal(err)
+	}
+	resource, err := s.Store.CreateResource(ctx, create)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create resource").SetInternal(err)
+	}
+	s.resourceProcessor.Enqueue(resource.ID)
+
+	// Keep the info of the finished upload until it expires, so a client that missed the response can find the resource.
+	u.Resource = fmt.Sprintf("%s%s", ResourceNamePrefix, resource.UID)
+	if err := s.saveUpload(u); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save upload").SetInternal(err)
+	}
+	if err := os.Remove(s.uploadContentPath(u.ID)); err != nil {
+		slog.Warn("Failed to delete finished upload", slog.String("id", u.ID), slog.Any("err", err))
+	}
+	c.Response().Header().Set(resourceNameHeader, u.Resource)
+	return nil
+}
+
+// getUploadMemo returns the memo with the name, which an upload can only be attached to by its creator.
+func (s *APIV1Service) getUploadMemo(ctx context.Context, name string, userID int32) (*store.Memo, error) {
+	memoUID, err := ExtractMemoUIDFromName(name)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid memo name").SetInternal(err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo").SetInternal(err)
+	}
+	if memo == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Memo not found")
+	}
+	if memo.CreatorID != userID {
+		return nil, echo.NewHTTPError(http.StatusForbidden, "Permission denied")
+	}
+	return memo, nil
+}
+
+// stripUploadLocation erases the GPS data of an uploaded JPEG image in place.
+func stripUploadLocation(path string) error {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return errors.Wrap(err, "failed to open upload")
+	}
+	defer file.Close()
+	head := make([]byte, exifHeadSize)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return errors.Wrap(err, "failed to read upload")
+	}
+	if exif.StripGPS(head[:n]) {
+		if _, err := file.WriteAt(head[:n], 0); err != nil {
+			return errors.Wrap(err, "failed to write upload")
+		}
+	}
+	return nil
+}
+
+// getUpload returns the upload of the request, if it was created by the current user.
+func (s *APIV1Service) getUpload(c echo.Context) (*upload, error) {
+	id := c.Param("id")
+	if !base.UIDMatcher.MatchString(id) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Upload not found")
+	}
+	data, err := os.ReadFile(s.uploadInfoPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, echo.NewHTTPError(http.StatusNotFound, "Upload not found")
+		}
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to read upload").SetInternal(err)
+	}
+	u := &upload{}
+	if err := json.Unmarshal(data, u); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to read upload").SetInternal(err)
+	}
+	userID, _ := c.Get(getUserIDContextKey()).(int32)
+	if u.CreatorID != userID || time.Since(time.Unix(u.CreatedTs, 0)) > uploadExpiration {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Upload not found")
+	}
+	return u, nil
+}
+
+func (s *APIV1Service) saveUpload(u *upload) error {
+	data, err := json.Marshal(u)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal upload")
+	}
+	return os.WriteFile(s.uploadInfoPath(u.ID), data, 0600)
+}
+
+func (s *APIV1Service) deleteUpload(id string) {
+	for _, p := range []string{s.uploadContentPath(id), s.uploadInfoPath(id)} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			slog.Warn("Failed to delete upload", slog.String("id", id), slog.Any("err", err))
+		}
+	}
+}
+
+// deleteExpiredUploads deletes the uploads that can no longer be resumed.
+func (s *APIV1Service) deleteExpiredUploads() {
+	entries, err := os.ReadDir(s.uploadFolder())
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) <= uploadExpiration {
+			continue
+		}
+		if s.uploadLocks.tryLock(id) {
+			s.deleteUpload(id)
+			s.uploadLocks.unlock(id)
+		}
+	}
+}
+
+func (s *APIV1Service) uploadFolder() string {
+	return filepath.Join(s.Profile.Data, UploadFolder)
+}
+
+func (s *APIV1Service) uploadContentPath(id string) string {
+	return filepath.Join(s.uploadFolder(), id)
+}
+
+func (s *APIV1Service) uploadInfoPath(id string) string {
+	return filepath.Join(s.uploadFolder(), id+".json")
+}
+
+func checkTusResumable(c echo.Context) error {
+	if c.Request().Header.Get("Tus-Resumable") != tusVersion {
+		c.Response().Header().Set("Tus-Version", tusVersion)
+		return echo.NewHTTPError(http.StatusPreconditionFailed, "Unsupported Tus-Resumable version")
+	}
+	return nil
+}
+
+// parseUploadMetadata parses the Upload-Metadata header, a comma separated list of keys and base64 encoded values.
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value of %s", key)
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,211 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/server/runner/resourceprocess"
+	"github.com/usememos/memos/store"
+)
+
+// newTestingUploadService returns an API service that keeps its uploads in a temporary data directory.
+func newTestingUploadService(ctx context.Context, t *testing.T) *APIV1Service {
+	s := newTestingService(ctx, t)
+	s.Profile.Data = t.TempDir()
+	s.resourceProcessor = resourceprocess.NewRunner(s.Store, s.Profile)
+	return s
+}
+
+// doUploadRequest serves a request to the upload routes, authenticated as the user.
+func doUploadRequest(t *testing.T, s *APIV1Service, user *store.User, method, target string, header map[string]string, body []byte) *httptest.ResponseRecorder {
+	e := echo.New()
+	g := e.Group("/api/v1", func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(getUserIDContextKey(), user.ID)
+			return next(c)
+		}
+	})
+	s.RegisterUploadRoutes(g)
+
+	request := httptest.NewRequest(method, target, bytes.NewReader(body))
+	request.Header.Set("Tus-Resumable", tusVersion)
+	for key, value := range header {
+		request.Header.Set(key, value)
+	}
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// createTestingUpload creates an upload of the length and returns its location.
+func createTestingUpload(t *testing.T, s *APIV1Service, user *store.User, length int, metadata map[string]string) string {
+	uploadMetadata := "filename " + base64.StdEncoding.EncodeToString([]byte("hello.txt"))
+	for key, value := range metadata {
+		uploadMetadata += "," + key + " " + base64.StdEncoding.EncodeToString([]byte(value))
+	}
+	recorder := doUploadRequest(t, s, user, http.MethodPost, "/api/v1/uploads", map[string]string{
+		"Upload-Length":   strconv.Itoa(length),
+		"Upload-Metadata": uploadMetadata,
+	}, nil)
+	require.Equal(t, http.StatusCreated, recorder.Code, recorder.Body.String())
+	return recorder.Header().Get("Location")
+}
+
+func appendTestingUpload(t *testing.T, s *APIV1Service, user *store.User, location string, offset int, content string) *httptest.ResponseRecorder {
+	return doUploadRequest(t, s, user, http.MethodPatch, location, map[string]string{
+		"Content-Type":  "application/offset+octet-stream",
+		"Upload-Offset": strconv.Itoa(offset),
+	}, []byte(content))
+}
+
+func TestUploadResume(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingUploadService(ctx, t)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	location := createTestingUpload(t, s, user, 11, nil)
+
+	recorder := appendTestingUpload(t, s, user, location, 0, "hello ")
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+	require.Equal(t, "6", recorder.Header().Get("Upload-Offset"))
+	// A request for an offset the upload is not at is refused.
+	recorder = appendTestingUpload(t, s, user, location, 0, "hello ")
+	require.Equal(t, http.StatusConflict, recorder.Code)
+	recorder = doUploadRequest(t, s, user, http.MethodHead, location, nil, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "6", recorder.Header().Get("Upload-Offset"))
+
+	recorder = appendTestingUpload(t, s, user, location, 6, "world")
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+	resourceName := recorder.Header().Get(resourceNameHeader)
+	resourceUID, err := ExtractResourceUIDFromName(resourceName)
+	require.NoError(t, err)
+	resource, err := s.Store.GetResource(ctx, &store.FindResource{UID: &resourceUID, GetBlob: true})
+	require.NoError(t, err)
+	require.Equal(t, user.ID, resource.CreatorID)
+	require.Equal(t, "hello.txt", resource.Filename)
+	require.Equal(t, []byte("hello world"), resource.Blob)
+	// The finished upload tells the resource to a client that missed the response.
+	recorder = doUploadRequest(t, s, user, http.MethodHead, location, nil, nil)
+	require.Equal(t, resourceName, recorder.Header().Get(resourceNameHeader))
+	recorder = appendTestingUpload(t, s, user, location, 11, "!")
+	require.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func TestUploadExceedingLength(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingUploadService(ctx, t)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	location := createTestingUpload(t, s, user, 5, nil)
+
+	recorder := appendTestingUpload(t, s, user, location, 0, "hello world")
+	require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	// Nothing of the refused content is kept.
+	recorder = doUploadRequest(t, s, user, http.MethodHead, location, nil, nil)
+	require.Equal(t, "0", recorder.Header().Get("Upload-Offset"))
+	recorder = appendTestingUpload(t, s, user, location, 0, "hello")
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+	require.NotEmpty(t, recorder.Header().Get(resourceNameHeader))
+}
+
+func TestUploadOfOtherUser(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingUploadService(ctx, t)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	other := createTestingUser(ctx, t, s, "other", store.RoleUser)
+	location := createTestingUpload(t, s, user, 5, nil)
+
+	recorder := doUploadRequest(t, s, other, http.MethodHead, location, nil, nil)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	recorder = appendTestingUpload(t, s, other, location, 0, "hello")
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	recorder = doUploadRequest(t, s, other, http.MethodDelete, location, nil, nil)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	recorder = doUploadRequest(t, s, user, http.MethodHead, location, nil, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestUploadAttachedToMemoOfOtherUser(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingUploadService(ctx, t)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	other := createTestingUser(ctx, t, s, "other", store.RoleUser)
+	memo := createTestingMemo(ctx, t, s, other, "memo of other", store.Public, nil)
+	memoName := MemoNamePrefix + memo.UID
+
+	recorder := doUploadRequest(t, s, user, http.MethodPost, "/api/v1/uploads", map[string]string{
+		"Upload-Length":   "5",
+		"Upload-Metadata": "filename " + base64.StdEncoding.EncodeToString([]byte("hello.txt")) + ",memo " + base64.StdEncoding.EncodeToString([]byte(memoName)),
+	}, nil)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+
+	// The memo is checked again when the upload is finished.
+	location := createTestingUpload(t, s, user, 5, nil)
+	infoPath := s.uploadInfoPath(path.Base(location))
+	u := readTestingUpload(t, infoPath)
+	u.Memo = memoName
+	writeTestingUpload(t, infoPath, u)
+	recorder = appendTestingUpload(t, s, user, location, 0, "hello")
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	resources, err := s.Store.ListResources(ctx, &store.FindResource{MemoID: &memo.ID})
+	require.NoError(t, err)
+	require.Empty(t, resources)
+
+	// The creator of the memo can attach the upload to it.
+	location = createTestingUpload(t, s, other, 5, map[string]string{"memo": memoName})
+	recorder = appendTestingUpload(t, s, other, location, 0, "hello")
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+	resources, err = s.Store.ListResources(ctx, &store.FindResource{MemoID: &memo.ID})
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+}
+
+func TestUploadExpiration(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingUploadService(ctx, t)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	location := createTestingUpload(t, s, user, 5, nil)
+	id := path.Base(location)
+	expiredTime := time.Now().Add(-uploadExpiration - time.Minute)
+	u := readTestingUpload(t, s.uploadInfoPath(id))
+	u.CreatedTs = expiredTime.Unix()
+	writeTestingUpload(t, s.uploadInfoPath(id), u)
+
+	recorder := doUploadRequest(t, s, user, http.MethodHead, location, nil, nil)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	recorder = appendTestingUpload(t, s, user, location, 0, "hello")
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	// The expired upload is deleted when another upload is created.
+	require.NoError(t, os.Chtimes(s.uploadInfoPath(id), expiredTime, expiredTime))
+	createTestingUpload(t, s, user, 5, nil)
+	_, err := os.Stat(s.uploadInfoPath(id))
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(s.uploadContentPath(id))
+	require.True(t, os.IsNotExist(err))
+}
+
+func readTestingUpload(t *testing.T, infoPath string) *upload {
+	data, err := os.ReadFile(infoPath)
+	require.NoError(t, err)
+	u := &upload{}
+	require.NoError(t, json.Unmarshal(data, u))
+	return u
+}
+
+func writeTestingUpload(t *testing.T, infoPath string, u *upload) {
+	data, err := json.Marshal(u)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(infoPath, data, 0600))
+}
@@ -0,0 +1,263 @@
+package v1
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func (*APIV1Service) ListPermissions(_ context.Context, _ *v1pb.ListPermissionsRequest) (*v1pb.ListPermissionsResponse, error) {
+	response := &v1pb.ListPermissionsResponse{
+		Permissions: []*v1pb.Permission{},
+	}
+	for _, permission := range store.ListPermissions() {
+		defaultRoles := []string{}
+		for _, role := range []store.Role{store.RoleHost, store.RoleAdmin, store.RoleUser} {
+			if slices.Contains(store.ListRolePermissions(role), permission) {
+				defaultRoles = append(defaultRoles, role.String())
+			}
+		}
+		response.Permissions = append(response.Permissions, &v1pb.Permission{
+			Name:         permission.String(),
+			Description:  permission.Description(),
+			DefaultRoles: defaultRoles,
+		})
+	}
+	return response, nil
+}
+
+func (s *APIV1Service) ListRoles(ctx context.Context, _ *v1pb.ListRolesRequest) (*v1pb.ListRolesResponse, error) {
+	customRoles, err := s.Store.ListCustomRoles(ctx, &store.FindCustomRole{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list roles: %v", err)
+	}
+	response := &v1pb.ListRolesResponse{
+		Roles: []*v1pb.Role{},
+	}
+	for _, customRole := range customRoles {
+		response.Roles = append(response.Roles, convertRoleFromStore(customRole))
+	}
+	return response, nil
+}
+
+func (s *APIV1Service) CreateRole(ctx context.Context, request *v1pb.CreateRoleRequest) (*v1pb.Role, error) {
+	if request.Role == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "role is required")
+	}
+	name := strings.TrimSpace(request.Role.Name)
+	if name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "role name is required")
+	}
+	permissions, err := convertPermissionsToStore(request.Role.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.Store.GetCustomRole(ctx, &store.FindCustomRole{Name: &name})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get role: %v", err)
+	}
+	if existing != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "role %q already exists", name)
+	}
+
+	customRole, err := s.Store.CreateCustomRole(ctx, &store.CustomRole{
+		Name:        name,
+		Description: request.Role.Description,
+		Permissions: permissions,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create role: %v", err)
+	}
+	return convertRoleFromStore(customRole), nil
+}
+
+func (s *APIV1Service) UpdateRole(ctx context.Context, request *v1pb.UpdateRoleRequest) (*v1pb.Role, error) {
+	if request.Role == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "role is required")
+	}
+	if request.UpdateMask == nil || len(request.UpdateMask.Paths) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "update_mask is required")
+	}
+	customRole, err := s.Store.GetCustomRole(ctx, &store.FindCustomRole{ID: &request.Role.Id})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get role: %v", err)
+	}
+	if customRole == nil {
+		return nil, status.Errorf(codes.NotFound, "role not found")
+	}
+
+	currentTs := time.Now().Unix()
+	update := &store.UpdateCustomRole{
+		ID:        customRole.ID,
+		UpdatedTs: &currentTs,
+	}
+	for _, field := range request.UpdateMask.Paths {
+		switch field {
+		case "name":
+			name := strings.TrimSpace(request.Role.Name)
+			if name == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "role name is required")
+			}
+			update.Name = &name
+		case "description":
+			update.Description = &request.Role.Description
+		case "permissions":
+			permissions, err := convertPermissionsToStore(request.Role.Permissions)
+			if err != nil {
+				return nil, err
+			}
+			update.Permissions = permissions
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid update path: %s", field)
+		}
+	}
+
+	customRole, err = s.Store.UpdateCustomRole(ctx, update)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update role: %v", err)
+	}
+	return convertRoleFromStore(customRole), nil
+}
+
+func (s *APIV1Service) DeleteRole(ctx context.Context, request *v1pb.DeleteRoleRequest) (*emptypb.Empty, error) {
+	customRole, err := s.Store.GetCustomRole(ctx, &store.FindCustomRole{ID: &request.Id})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get role: %v", err)
+	}
+	if customRole == nil {
+		return nil, status.Errorf(codes.NotFound, "role not found")
+	}
+	if err := s.Store.DeleteCustomRole(ctx, &store.DeleteCustomRole{ID: customRole.ID}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete role: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *APIV1Service) ListUserRoles(ctx context.Context, request *v1pb.ListUserRolesRequest) (*v1pb.ListUserRolesResponse, error) {
+	user, err := s.getRoleUser(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	// Only allow role managers or self to list the roles of a user.
+	if currentUser.ID != user.ID {
+		if err := s.checkPermission(ctx, store.PermissionRoleManage); err != nil {
+			return nil, err
+		}
+	}
+	return s.listUserRoles(ctx, user)
+}
+
+func (s *APIV1Service) SetUserRoles(ctx context.Context, request *v1pb.SetUserRolesRequest) (*v1pb.ListUserRolesResponse, error) {
+	user, err := s.getRoleUser(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, roleID := range request.RoleIds {
+		customRole, err := s.Store.GetCustomRole(ctx, &store.FindCustomRole{ID: &roleID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get role: %v", err)
+		}
+		if customRole == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "role %d not found", roleID)
+		}
+	}
+	if err := s.Store.SetUserCustomRoles(ctx, user.ID, request.RoleIds); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set user roles: %v", err)
+	}
+	return s.listUserRoles(ctx, user)
+}
+
+func (s *APIV1Service) getRoleUser(ctx context.Context, name string) (*store.User, error) {
+	userID, err := ExtractUserIDFromName(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	return user, nil
+}
+
+func (s *APIV1Service) listUserRoles(ctx context.Context, user *store.User) (*v1pb.ListUserRolesResponse, error) {
+	customRoles, err := s.Store.ListUserCustomRoles(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list user roles: %v", err)
+	}
+	permissions, err := s.Store.ListUserPermissions(ctx, user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list user permissions: %v", err)
+	}
+	response := &v1pb.ListUserRolesResponse{
+		Roles:       []*v1pb.Role{},
+		Permissions: []string{},
+	}
+	for _, customRole := range customRoles {
+		response.Roles = append(response.Roles, convertRoleFromStore(customRole))
+	}
+	for _, permission := range permissions {
+		response.Permissions = append(response.Permissions, permission.String())
+	}
+	return response, nil
+}
+
+// checkPermission returns a PermissionDenied error unless the current user is granted permission.
+func (s *APIV1Service) checkPermission(ctx context.Context, permission store.Permission) error {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	hasPermission, err := s.Store.HasPermission(ctx, user, permission)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check permission: %v", err)
+	}
+	if !hasPermission {
+		return status.Errorf(codes.PermissionDenied, "permission denied: %s is required", permission)
+	}
+	return nil
+}
+
+func convertRoleFromStore(customRole *store.CustomRole) *v1pb.Role {
+	role := &v1pb.Role{
+		Id:          customRole.ID,
+		Name:        customRole.Name,
+		Description: customRole.Description,
+		Permissions: []string{},
+		CreateTime:  timestamppb.New(time.Unix(customRole.CreatedTs, 0)),
+		UpdateTime:  timestamppb.New(time.Unix(customRole.UpdatedTs, 0)),
+	}
+	for _, permission := range customRole.Permissions {
+		role.Permissions = append(role.Permissions, permission.String())
+	}
+	return role
+}
+
+func convertPermissionsToStore(names []string) ([]store.Permission, error) {
+	permissions := []store.Permission{}
+	for _, name := range names {
+		permission := store.Permission(name)
+		if !permission.IsValid() {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid permission: %s", name)
+		}
+		if !slices.Contains(permissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions, nil
+}
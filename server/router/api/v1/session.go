@@ -0,0 +1,324 @@
+package v1
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/util"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// refreshTokenReuseGracePeriod is how long the previous refresh token of a session is still accepted
+// after a rotation, so concurrent requests refreshing at the same time do not revoke the session.
+const refreshTokenReuseGracePeriod = 30 * time.Second
+
+// sessionTokens are the tokens issued for a session.
+type sessionTokens struct {
+	User                  *store.User
+	Session               *storepb.SessionsUserSetting_Session
+	AccessToken           string
+	AccessTokenExpireTime time.Time
+	// RefreshToken is empty if the refresh token was already rotated by a concurrent request.
+	RefreshToken string
+}
+
+// accessGrant is what a validated access token grants to the request.
+type accessGrant struct {
+	// Scopes are the scopes of a personal access token, empty means full access.
+	Scopes []string
+	// SessionID is the session the access token was issued for, if any.
+	SessionID string
+}
+
+// authorizeAccessToken checks the access token of the user against its session or the stored personal access tokens,
+// and records the usage. It returns nil if the token has been revoked.
+func authorizeAccessToken(ctx context.Context, stores *store.Store, user *store.User, accessToken string, claims *ClaimsMessage, clientIP, userAgent string) (*accessGrant, error) {
+	if claims.SessionID != "" {
+		session, err := stores.GetUserSession(ctx, user.ID, claims.SessionID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get user session")
+		}
+		if session == nil {
+			return nil, nil
+		}
+		if err := stores.TouchUserSession(ctx, user.ID, session.SessionId, clientIP, userAgent); err != nil {
+			return nil, errors.Wrap(err, "failed to record session usage")
+		}
+		return &accessGrant{SessionID: session.SessionId}, nil
+	}
+
+	userAccessToken, err := stores.GetUserAccessToken(ctx, user.ID, accessToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user access token")
+	}
+	if userAccessToken == nil {
+		return nil, nil
+	}
+	if err := stores.TouchUserAccessToken(ctx, user.ID, accessToken, clientIP); err != nil {
+		return nil, errors.Wrap(err, "failed to record access token usage")
+	}
+	return &accessGrant{Scopes: userAccessToken.Scopes}, nil
+}
+
+// createSession starts a new session for the user and issues its tokens.
+func createSession(ctx context.Context, stores *store.Store, secret string, user *store.User, persistent bool, clientIP, userAgent string) (*sessionTokens, error) {
+	sessionID, err := util.RandomString(24)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate session id")
+	}
+	refreshToken, err := generateRefreshToken(user.ID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	session := &storepb.SessionsUserSetting_Session{
+		SessionId:        sessionID,
+		RefreshTokenHash: store.HashAccessToken(refreshToken),
+		CreateTime:       timestamppb.New(now),
+		LastSeenTime:     timestamppb.New(now),
+		ExpireTime:       timestamppb.New(now.Add(sessionDuration(persistent))),
+		RefreshTime:      timestamppb.New(now),
+		UserAgent:        userAgent,
+		Ip:               clientIP,
+		Persistent:       persistent,
+	}
+	if err := stores.UpsertUserSession(ctx, user.ID, session); err != nil {
+		return nil, errors.Wrap(err, "failed to save user session")
+	}
+	return issueSessionTokens(user, session, refreshToken, secret)
+}
+
+// refreshSession rotates the refresh token of a session and issues a new access token.
+// Replaying a refresh token that was rotated out revokes the session, as the token has likely been stolen.
+func refreshSession(ctx context.Context, stores *store.Store, secret, refreshToken, clientIP, userAgent string) (*sessionTokens, error) {
+	userID, sessionID, ok := parseRefreshToken(refreshToken)
+	if !ok {
+		return nil, errors.New("malformed refresh token")
+	}
+
+	user, err := stores.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+	if user == nil || user.RowStatus == store.Archived {
+		return nil, errors.New("user not found")
+	}
+
+	// The refresh token is checked and rotated in a single update of the sessions, so concurrent
+	// refreshes and revocations cannot overwrite each other.
+	var session *storepb.SessionsUserSetting_Session
+	newRefreshToken, revoked := "", false
+	hash := []byte(store.HashAccessToken(refreshToken))
+	if err := stores.UpdateUserSessions(ctx, userID, func(sessions []*storepb.SessionsUserSetting_Session) ([]*storepb.SessionsUserSetting_Session, error) {
+		index := slices.IndexFunc(sessions, func(session *storepb.SessionsUserSetting_Session) bool {
+			return session.SessionId == sessionID
+		})
+		if index < 0 {
+			return nil, errors.New("session not found")
+		}
+		session = sessions[index]
+
+		now := time.Now()
+		switch {
+		case subtle.ConstantTimeCompare(hash, []byte(session.RefreshTokenHash)) == 1:
+			token, err := generateRefreshToken(userID, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			newRefreshToken = token
+			session.PreviousRefreshTokenHash = session.RefreshTokenHash
+			session.RefreshTokenHash = store.HashAccessToken(newRefreshToken)
+			session.RefreshTime = timestamppb.New(now)
+			session.LastSeenTime = timestamppb.New(now)
+			session.ExpireTime = timestamppb.New(now.Add(sessionDuration(session.Persistent)))
+			session.Ip = clientIP
+			if userAgent != "" {
+				session.UserAgent = userAgent
+			}
+			return sessions, nil
+		case session.PreviousRefreshTokenHash != "" && subtle.ConstantTimeCompare(hash, []byte(session.PreviousRefreshTokenHash)) == 1:
+			if session.RefreshTime != nil && now.Sub(session.RefreshTime.AsTime()) < refreshTokenReuseGracePeriod {
+				// Leave the sessions unchanged and issue an access token without a refresh token.
+				return sessions, nil
+			}
+			revoked = true
+			return slices.Delete(sessions, index, index+1), nil
+		default:
+			return nil, errors.New("invalid refresh token")
+		}
+	}); err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, errors.New("refresh token has been reused, the session is revoked")
+	}
+	return issueSessionTokens(user, session, newRefreshToken, secret)
+}
+
+func issueSessionTokens(user *store.User, session *storepb.SessionsUserSetting_Session, refreshToken, secret string) (*sessionTokens, error) {
+	expireTime := time.Now().Add(AccessTokenDuration)
+	accessToken, err := GenerateSessionAccessToken(user.Username, user.ID, session.SessionId, expireTime, []byte(secret))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate access token")
+	}
+	return &sessionTokens{
+		User:                  user,
+		Session:               session,
+		AccessToken:           accessToken,
+		AccessTokenExpireTime: expireTime,
+		RefreshToken:          refreshToken,
+	}, nil
+}
+
+func sessionDuration(persistent bool) time.Duration {
+	if persistent {
+		return PersistentSessionDuration
+	}
+	return SessionDuration
+}
+
+// generateRefreshToken returns a random refresh token in the format "{userID}.{sessionID}.{secret}".
+func generateRefreshToken(userID int32, sessionID string) (string, error) {
+	secret, err := util.RandomString(43)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate refresh token")
+	}
+	return fmt.Sprintf("%d.%s.%s", userID, sessionID, secret), nil
+}
+
+func parseRefreshToken(refreshToken string) (int32, string, bool) {
+	parts := strings.Split(refreshToken, ".")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return 0, "", false
+	}
+	userID, err := util.ConvertStringToInt32(parts[0])
+	if err != nil {
+		return 0, "", false
+	}
+	return userID, parts[1], true
+}
+
+// setSessionCookies sets the access token and refresh token cookies of the session in the gRPC response.
+func setSessionCookies(ctx context.Context, tokens *sessionTokens) error {
+	isHTTPS := isHTTPSRequest(ctx)
+	md := metadata.Pairs("Set-Cookie", formatCookie(AccessTokenCookieName, tokens.AccessToken, tokens.AccessTokenExpireTime, isHTTPS))
+	if tokens.RefreshToken != "" {
+		md.Append("Set-Cookie", formatCookie(RefreshTokenCookieName, tokens.RefreshToken, refreshTokenCookieExpireTime(tokens.Session), isHTTPS))
+	}
+	if err := grpc.SetHeader(ctx, md); err != nil {
+		return errors.Wrap(err, "failed to set grpc header")
+	}
+	return nil
+}
+
+// clearSessionCookies expires the access token and refresh token cookies in the gRPC response.
+func clearSessionCookies(ctx context.Context) error {
+	isHTTPS := isHTTPSRequest(ctx)
+	if err := grpc.SetHeader(ctx, metadata.Pairs(
+		"Set-Cookie", formatCookie(AccessTokenCookieName, "", time.Unix(0, 0), isHTTPS),
+		"Set-Cookie", formatCookie(RefreshTokenCookieName, "", time.Unix(0, 0), isHTTPS),
+	)); err != nil {
+		return errors.Wrap(err, "failed to set grpc header")
+	}
+	return nil
+}
+
+// setEchoSessionCookies sets the access token and refresh token cookies of the session in the Echo response.
+func setEchoSessionCookies(c echo.Context, tokens *sessionTokens) {
+	isHTTPS := strings.HasPrefix(c.Request().Header.Get("Origin"), "https://")
+	c.Response().Header().Add("Set-Cookie", formatCookie(AccessTokenCookieName, tokens.AccessToken, tokens.AccessTokenExpireTime, isHTTPS))
+	if tokens.RefreshToken != "" {
+		c.Response().Header().Add("Set-Cookie", formatCookie(RefreshTokenCookieName, tokens.RefreshToken, refreshTokenCookieExpireTime(tokens.Session), isHTTPS))
+	}
+}
+
+// refreshTokenCookieExpireTime returns the expire time of the refresh token cookie,
+// which is a browser session cookie unless the session is persistent.
+func refreshTokenCookieExpireTime(session *storepb.SessionsUserSetting_Session) time.Time {
+	if !session.Persistent {
+		return time.Time{}
+	}
+	return session.ExpireTime.AsTime()
+}
+
+// formatCookie formats a Set-Cookie header value. A zero expire time makes a browser session cookie.
+func formatCookie(name, value string, expireTime time.Time, isHTTPS bool) string {
+	attrs := []string{
+		fmt.Sprintf("%s=%s", name, value),
+		"Path=/",
+		"HttpOnly",
+	}
+	if !expireTime.IsZero() {
+		attrs = append(attrs, "Expires="+expireTime.UTC().Format(http.TimeFormat))
+	}
+	if isHTTPS {
+		attrs = append(attrs, "SameSite=None")
+		attrs = append(attrs, "Secure")
+	} else {
+		attrs = append(attrs, "SameSite=Strict")
+	}
+	return strings.Join(attrs, "; ")
+}
+
+func isHTTPSRequest(ctx context.Context) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var origin string
+	for _, v := range md.Get("origin") {
+		origin = v
+	}
+	return strings.HasPrefix(origin, "https://")
+}
+
+// describeUserAgent returns a short description of the device, such as "Chrome on macOS".
+func describeUserAgent(userAgent string) string {
+	browser := ""
+	for _, candidate := range []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+	} {
+		if strings.Contains(userAgent, candidate.token) {
+			browser = candidate.name
+			break
+		}
+	}
+	platform := ""
+	for _, candidate := range []struct{ token, name string }{
+		{"Android", "Android"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Mac OS X", "macOS"},
+		{"Windows", "Windows"},
+		{"Linux", "Linux"},
+	} {
+		if strings.Contains(userAgent, candidate.token) {
+			platform = candidate.name
+			break
+		}
+	}
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	default:
+		return "Unknown device"
+	}
+}
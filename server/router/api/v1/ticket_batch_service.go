@@ -0,0 +1,260 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/plugin/webhook"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+// maxBatchTicketCount is the maximum number of tickets of a batch operation.
+const maxBatchTicketCount = 500
+
+// BatchTicketsRequest selects the tickets of a batch operation, either by ID or with a ticket filter.
+type BatchTicketsRequest struct {
+	IDs    []int32 `json:"ids"`
+	Filter string  `json:"filter"`
+}
+
+type BatchUpdateTicketsRequest struct {
+	BatchTicketsRequest
+	// Update is applied to each of the tickets, like the request of UpdateTicket.
+	Update UpdateTicketRequest `json:"update"`
+}
+
+// BatchTicketResult is the result of a batch operation for one of the tickets.
+type BatchTicketResult struct {
+	ID     int32   `json:"id"`
+	Ticket *Ticket `json:"ticket,omitempty"`
+	// Error is why the operation is not allowed for the ticket, in which case none of the tickets were changed.
+	Error string `json:"error,omitempty"`
+}
+
+type BatchTicketsResponse struct {
+	Results []*BatchTicketResult `json:"results"`
+}
+
+// BatchUpdateTickets applies an update to the selected tickets in a single transaction. When the update
+// is not allowed for one of the tickets, none of them are updated and the response is a 400 whose
+// results tell which tickets failed.
+func (s *APIV1Service) BatchUpdateTickets(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+
+	request := &BatchUpdateTicketsRequest{}
+	if err := c.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body").SetInternal(err)
+	}
+	ids, tickets, err := s.listBatchTickets(ctx, user, &request.BatchTicketsRequest)
+	if err != nil {
+		return err
+	}
+
+	response := &BatchTicketsResponse{Results: []*BatchTicketResult{}}
+	updates, projects, failed := []*store.UpdateTicket{}, map[int32]*store.Project{}, false
+	for _, id := range ids {
+		result := &BatchTicketResult{ID: id}
+		response.Results = append(response.Results, result)
+		ticket, ok := tickets[id]
+		if !ok {
+			result.Error, failed = "Ticket not found", true
+			continue
+		}
+		update, project, err := s.prepareTicketUpdate(ctx, user, ticket, &request.Update)
+		if err != nil {
+			if result.Error, err = getBatchTicketError(err); err != nil {
+				return err
+			}
+			failed = true
+			continue
+		}
+		updates = append(updates, update)
+		if project != nil {
+			projects[project.ID] = project
+		}
+	}
+	if failed {
+		return c.JSON(http.StatusBadRequest, response)
+	}
+
+	list, err := s.Store.BatchUpdateTickets(ctx, updates)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update tickets").SetInternal(err)
+	}
+	results := make([]*Ticket, 0, len(list))
+	for i, ticket := range list {
+		var project *store.Project
+		if ticket.ProjectID != nil {
+			project = projects[*ticket.ProjectID]
+		}
+		if response.Results[i].Ticket, err = s.convertTicketFromStore(ctx, ticket, project); err != nil {
+			return err
+		}
+		results = append(results, response.Results[i].Ticket)
+	}
+	if err := s.dispatchTicketBatchWebhook(ctx, results, "memos.tickets.updated"); err != nil {
+		slog.Warn("Failed to dispatch tickets updated webhook", slog.Any("err", err))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// BatchDeleteTickets deletes the selected tickets in a single transaction. When one of the tickets cannot
+// be deleted, none of them are deleted and the response is a 400 whose results tell which tickets failed.
+func (s *APIV1Service) BatchDeleteTickets(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+
+	request := &BatchTicketsRequest{}
+	if err := c.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body").SetInternal(err)
+	}
+	ids, tickets, err := s.listBatchTickets(ctx, user, request)
+	if err != nil {
+		return err
+	}
+
+	response := &BatchTicketsResponse{Results: []*BatchTicketResult{}}
+	deleted, failed := []*Ticket{}, false
+	for _, id := range ids {
+		result := &BatchTicketResult{ID: id}
+		response.Results = append(response.Results, result)
+		ticket, ok := tickets[id]
+		if !ok {
+			result.Error, failed = "Ticket not found", true
+			continue
+		}
+		project, err := s.checkTicketDelete(ctx, user, ticket)
+		if err != nil {
+			if result.Error, err = getBatchTicketError(err); err != nil {
+				return err
+			}
+			failed = true
+			continue
+		}
+		// The tickets are sent to the webhooks as they were before being deleted.
+		ticketResult, err := s.convertTicketFromStore(ctx, ticket, project)
+		if err != nil {
+			return err
+		}
+		deleted = append(deleted, ticketResult)
+	}
+	if failed {
+		return c.JSON(http.StatusBadRequest, response)
+	}
+
+	if err := s.Store.BatchDeleteTickets(ctx, ids); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete tickets").SetInternal(err)
+	}
+	if err := s.dispatchTicketBatchWebhook(ctx, deleted, "memos.tickets.deleted"); err != nil {
+		slog.Warn("Failed to dispatch tickets deleted webhook", slog.Any("err", err))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// listBatchTickets returns the IDs of the tickets selected by the request, and the ones of them that exist.
+// The tickets selected with a filter are the ones ListTickets returns for it.
+func (s *APIV1Service) listBatchTickets(ctx context.Context, user *store.User, request *BatchTicketsRequest) ([]int32, map[int32]*store.Ticket, error) {
+	if err := s.checkTicketPermission(ctx, user, store.PermissionTicketView); err != nil {
+		return nil, nil, err
+	}
+	if (len(request.IDs) == 0) == (request.Filter == "") {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "Either ids or filter is required")
+	}
+
+	var list []*store.Ticket
+	ids := []int32{}
+	if request.Filter != "" {
+		var err error
+		if list, _, err = s.listTickets(ctx, user, &store.FindTicket{}, request.Filter, ""); err != nil {
+			return nil, nil, err
+		}
+		for _, ticket := range list {
+			ids = append(ids, ticket.ID)
+		}
+	} else {
+		for _, id := range request.IDs {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) > maxBatchTicketCount {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("A batch can have at most %d tickets, got %d", maxBatchTicketCount, len(ids)))
+	}
+	if list == nil {
+		var err error
+		if list, err = s.Store.ListTickets(ctx, &store.FindTicket{IDList: ids}); err != nil {
+			return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list tickets").SetInternal(err)
+		}
+	}
+
+	tickets := map[int32]*store.Ticket{}
+	for _, ticket := range list {
+		tickets[ticket.ID] = ticket
+	}
+	return ids, tickets, nil
+}
+
+// getBatchTicketError returns the message of a client error for the results of a batch operation, or
+// the error itself when it is a server error that fails the whole operation.
+func getBatchTicketError(err error) (string, error) {
+	var httpErr *echo.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code >= http.StatusInternalServerError {
+		return "", err
+	}
+	return fmt.Sprint(httpErr.Message), nil
+}
+
+// dispatchTicketBatchWebhook dispatches a single event per webhook for the tickets of a batch operation,
+// to the webhooks of the creators of the tickets.
+func (s *APIV1Service) dispatchTicketBatchWebhook(ctx context.Context, tickets []*Ticket, activityType string) error {
+	ticketsByCreator := map[int32][]*Ticket{}
+	for _, ticket := range tickets {
+		ticketsByCreator[ticket.CreatorID] = append(ticketsByCreator[ticket.CreatorID], ticket)
+	}
+	for creatorID, creatorTickets := range ticketsByCreator {
+		webhooks, err := s.Store.ListWebhooks(ctx, &store.FindWebhook{
+			CreatorID: &creatorID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(webhooks) == 0 {
+			continue
+		}
+		ticketStructs := make([]*structpb.Struct, 0, len(creatorTickets))
+		for _, ticket := range creatorTickets {
+			ticketStruct, err := convertTicketToStruct(ticket)
+			if err != nil {
+				return errors.Wrap(err, "failed to convert ticket to webhook payload")
+			}
+			ticketStructs = append(ticketStructs, ticketStruct)
+		}
+		for _, hook := range webhooks {
+			webhook.PostAsync(&v1pb.WebhookRequestPayload{
+				Url:          hook.URL,
+				ActivityType: activityType,
+				Creator:      fmt.Sprintf("%s%d", UserNamePrefix, creatorID),
+				CreateTime:   timestamppb.Now(),
+				Tickets:      ticketStructs,
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+// doTicketRequest serves a request to the ticket routes, authenticated as the user.
+func doTicketRequest(t *testing.T, s *APIV1Service, user *store.User, method, path string, body any) *httptest.ResponseRecorder {
+	e := echo.New()
+	g := e.Group("/api/v1", func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(getUserIDContextKey(), user.ID)
+			return next(c)
+		}
+	})
+	s.RegisterTicketRoutes(g)
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	request := httptest.NewRequest(method, "/api/v1"+path, bytes.NewReader(data))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// createTestingTicket creates an open ticket of the user.
+func createTestingTicket(ctx context.Context, t *testing.T, s *APIV1Service, user *store.User, title string) *store.Ticket {
+	ticket, err := s.Store.CreateTicket(ctx, &store.Ticket{
+		Title:     title,
+		Status:    store.TicketStatusOpen,
+		Priority:  store.TicketPriorityMedium,
+		CreatorID: user.ID,
+	})
+	require.NoError(t, err)
+	return ticket
+}
+
+func decodeBatchTicketsResponse(t *testing.T, recorder *httptest.ResponseRecorder) *BatchTicketsResponse {
+	response := &BatchTicketsResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), response))
+	return response
+}
+
+func TestBatchUpdateTicketsPermissionDenied(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	host := createTestingUser(ctx, t, s, "host", store.RoleHost)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	hostTicket := createTestingTicket(ctx, t, s, host, "host ticket")
+	userTicket := createTestingTicket(ctx, t, s, user, "user ticket")
+
+	// The user may only edit their own ticket, so none of the tickets are updated.
+	title := "renamed"
+	recorder := doTicketRequest(t, s, user, http.MethodPost, "/tickets:batchUpdate", &BatchUpdateTicketsRequest{
+		BatchTicketsRequest: BatchTicketsRequest{IDs: []int32{userTicket.ID, hostTicket.ID}},
+		Update:              UpdateTicketRequest{Title: &title},
+	})
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	response := decodeBatchTicketsResponse(t, recorder)
+	require.Len(t, response.Results, 2)
+	require.Equal(t, userTicket.ID, response.Results[0].ID)
+	require.Empty(t, response.Results[0].Error)
+	require.Equal(t, hostTicket.ID, response.Results[1].ID)
+	require.Contains(t, response.Results[1].Error, "Permission denied")
+	ticket, err := s.Store.GetTicket(ctx, &store.FindTicket{ID: &userTicket.ID})
+	require.NoError(t, err)
+	require.Equal(t, "user ticket", ticket.Title)
+
+	recorder = doTicketRequest(t, s, user, http.MethodPost, "/tickets:batchDelete", &BatchTicketsRequest{
+		IDs: []int32{userTicket.ID, hostTicket.ID},
+	})
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	response = decodeBatchTicketsResponse(t, recorder)
+	require.Empty(t, response.Results[0].Error)
+	require.Contains(t, response.Results[1].Error, "Permission denied")
+	tickets, err := s.Store.ListTickets(ctx, &store.FindTicket{})
+	require.NoError(t, err)
+	require.Len(t, tickets, 2)
+}
+
+func TestBatchTicketsLimit(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	host := createTestingUser(ctx, t, s, "host", store.RoleHost)
+
+	ids := []int32{}
+	for i := int32(1); i <= maxBatchTicketCount+1; i++ {
+		ids = append(ids, i)
+	}
+	recorder := doTicketRequest(t, s, host, http.MethodPost, "/tickets:batchDelete", &BatchTicketsRequest{IDs: ids})
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "at most 500 tickets")
+
+	// Duplicated IDs are counted once.
+	ids = append(ids[:maxBatchTicketCount], ids[:maxBatchTicketCount]...)
+	recorder = doTicketRequest(t, s, host, http.MethodPost, "/tickets:batchDelete", &BatchTicketsRequest{IDs: ids})
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.NotContains(t, recorder.Body.String(), "at most 500 tickets")
+	require.Len(t, decodeBatchTicketsResponse(t, recorder).Results, maxBatchTicketCount)
+}
+
+func TestBatchTicketsFilterMatchingNothing(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	host := createTestingUser(ctx, t, s, "host", store.RoleHost)
+	createTestingTicket(ctx, t, s, host, "ticket")
+
+	status := string(store.TicketStatusClosed)
+	recorder := doTicketRequest(t, s, host, http.MethodPost, "/tickets:batchUpdate", &BatchUpdateTicketsRequest{
+		BatchTicketsRequest: BatchTicketsRequest{Filter: `title == "missing"`},
+		Update:              UpdateTicketRequest{Status: &status},
+	})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Empty(t, decodeBatchTicketsResponse(t, recorder).Results)
+
+	recorder = doTicketRequest(t, s, host, http.MethodPost, "/tickets:batchDelete", &BatchTicketsRequest{Filter: `title == "missing"`})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Empty(t, decodeBatchTicketsResponse(t, recorder).Results)
+	tickets, err := s.Store.ListTickets(ctx, &store.FindTicket{})
+	require.NoError(t, err)
+	require.Len(t, tickets, 1)
+	require.Equal(t, store.TicketStatusOpen, tickets[0].Status)
+}
+
+func TestBatchTicketsWebhook(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	host := createTestingUser(ctx, t, s, "host", store.RoleHost)
+
+	var mutex sync.Mutex
+	payloads := []*v1pb.WebhookRequestPayload{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		payload := &v1pb.WebhookRequestPayload{}
+		require.NoError(t, protojson.Unmarshal(body, payload))
+		mutex.Lock()
+		payloads = append(payloads, payload)
+		mutex.Unlock()
+		w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		_, _ = w.Write([]byte(`{"code":0}`))
+	}))
+	defer server.Close()
+	_, err := s.Store.CreateWebhook(ctx, &store.Webhook{CreatorID: host.ID, Name: "test", URL: server.URL})
+	require.NoError(t, err)
+	for _, title := range []string{"first", "second", "third"} {
+		createTestingTicket(ctx, t, s, host, title)
+	}
+	getPayloads := func() []*v1pb.WebhookRequestPayload {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return slices.Clone(payloads)
+	}
+
+	status := string(store.TicketStatusClosed)
+	recorder := doTicketRequest(t, s, host, http.MethodPost, "/tickets:batchUpdate", &BatchUpdateTicketsRequest{
+		BatchTicketsRequest: BatchTicketsRequest{Filter: `status == "OPEN"`},
+		Update:              UpdateTicketRequest{Status: &status},
+	})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Len(t, decodeBatchTicketsResponse(t, recorder).Results, 3)
+	require.Eventually(t, func() bool { return len(getPayloads()) > 0 }, 5*time.Second, 10*time.Millisecond)
+	// Wait for any other webhook event, which would be sent right away.
+	time.Sleep(100 * time.Millisecond)
+	received := getPayloads()
+	require.Len(t, received, 1)
+	require.Equal(t, "memos.tickets.updated", received[0].ActivityType)
+	require.Len(t, received[0].Tickets, 3)
+	for _, ticket := range received[0].Tickets {
+		require.Equal(t, status, ticket.Fields["status"].GetStringValue())
+	}
+
+	recorder = doTicketRequest(t, s, host, http.MethodPost, "/tickets:batchDelete", &BatchTicketsRequest{Filter: `status == "CLOSED"`})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Eventually(t, func() bool { return len(getPayloads()) > 1 }, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	received = getPayloads()
+	require.Len(t, received, 2)
+	require.Equal(t, "memos.tickets.deleted", received[1].ActivityType)
+	require.Len(t, received[1].Tickets, 3)
+}
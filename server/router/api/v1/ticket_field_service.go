@@ -0,0 +1,259 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// TicketField is the definition of a custom field of the tickets.
+type TicketField struct {
+	// Key is the key of the field in the ticket fields and in the ticket filter, e.g. "story_points".
+	Key   string `json:"key"`
+	Title string `json:"title"`
+	// Type is one of TEXT, NUMBER, ENUM, USER and DATE.
+	Type    string   `json:"type"`
+	Options []string `json:"options"`
+	// TicketTypes are the ticket types the field applies to, all types when empty.
+	TicketTypes []string `json:"ticketTypes"`
+	Required    bool     `json:"required"`
+}
+
+type UpdateTicketFieldRequest struct {
+	Title       *string  `json:"title"`
+	Options     []string `json:"options"`
+	TicketTypes []string `json:"ticketTypes"`
+	Required    *bool    `json:"required"`
+}
+
+func (s *APIV1Service) ListTicketFields(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	if err := s.checkTicketPermission(ctx, user, store.PermissionTicketView); err != nil {
+		return err
+	}
+
+	definitions, err := s.listTicketFieldDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	result := make([]*TicketField, 0, len(definitions))
+	for _, field := range definitions {
+		result = append(result, convertTicketFieldFromStore(field))
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func (s *APIV1Service) CreateTicketField(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	if err := s.checkTicketPermission(ctx, user, store.PermissionWorkspaceSettingManage); err != nil {
+		return err
+	}
+
+	request := &TicketField{}
+	if err := c.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body").SetInternal(err)
+	}
+	field := &storepb.TicketField{
+		Key:         request.Key,
+		Title:       request.Title,
+		Type:        storepb.TicketField_Type(storepb.TicketField_Type_value[request.Type]),
+		Options:     request.Options,
+		TicketTypes: request.TicketTypes,
+		Required:    request.Required,
+	}
+	if err := store.ValidateTicketField(field); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid ticket field: %v", err))
+	}
+
+	definitions, err := s.listTicketFieldDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(definitions, func(definition *storepb.TicketField) bool {
+		return definition.Key == field.Key
+	}) {
+		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("Ticket field %s already exists", field.Key))
+	}
+	if err := s.saveTicketFieldDefinitions(ctx, append(definitions, field)); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, convertTicketFieldFromStore(field))
+}
+
+// UpdateTicketField updates the definition of a custom field. The key and the type of a field cannot be
+// changed, as the values of the tickets depend on them.
+func (s *APIV1Service) UpdateTicketField(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	if err := s.checkTicketPermission(ctx, user, store.PermissionWorkspaceSettingManage); err != nil {
+		return err
+	}
+
+	request := &UpdateTicketFieldRequest{}
+	if err := c.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body").SetInternal(err)
+	}
+	definitions, err := s.listTicketFieldDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	index := slices.IndexFunc(definitions, func(field *storepb.TicketField) bool {
+		return field.Key == c.Param("key")
+	})
+	if index < 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Ticket field not found")
+	}
+
+	field := definitions[index]
+	if request.Title != nil {
+		field.Title = *request.Title
+	}
+	if request.Options != nil {
+		field.Options = request.Options
+	}
+	if request.TicketTypes != nil {
+		field.TicketTypes = request.TicketTypes
+	}
+	if request.Required != nil {
+		field.Required = *request.Required
+	}
+	if err := store.ValidateTicketField(field); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid ticket field: %v", err))
+	}
+	if err := s.saveTicketFieldDefinitions(ctx, definitions); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, convertTicketFieldFromStore(field))
+}
+
+// DeleteTicketField deletes the definition of a custom field. The values of the tickets are dropped
+// when the tickets are next updated.
+func (s *APIV1Service) DeleteTicketField(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	if err := s.checkTicketPermission(ctx, user, store.PermissionWorkspaceSettingManage); err != nil {
+		return err
+	}
+
+	definitions, err := s.listTicketFieldDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	newDefinitions := slices.DeleteFunc(definitions, func(field *storepb.TicketField) bool {
+		return field.Key == c.Param("key")
+	})
+	if err := s.saveTicketFieldDefinitions(ctx, newDefinitions); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, true)
+}
+
+func (s *APIV1Service) listTicketFieldDefinitions(ctx context.Context) ([]*storepb.TicketField, error) {
+	setting, err := s.Store.GetWorkspaceTicketFieldsSetting(ctx)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get ticket fields").SetInternal(err)
+	}
+	return setting.Fields, nil
+}
+
+func (s *APIV1Service) saveTicketFieldDefinitions(ctx context.Context, definitions []*storepb.TicketField) error {
+	if _, err := s.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_TICKET_FIELDS,
+		Value: &storepb.WorkspaceSetting_TicketFieldsSetting{
+			TicketFieldsSetting: &storepb.WorkspaceTicketFieldsSetting{Fields: definitions},
+		},
+	}); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save ticket fields").SetInternal(err)
+	}
+	return nil
+}
+
+// normalizeTicketFields validates the values of the custom fields of a ticket of the type, including that
+// the users of the USER fields exist.
+func (s *APIV1Service) normalizeTicketFields(ctx context.Context, definitions []*storepb.TicketField, ticketType string, fields map[string]any) (map[string]any, error) {
+	normalized, err := store.NormalizeTicketFields(definitions, ticketType, fields)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid fields: %v", err))
+	}
+	for _, field := range definitions {
+		userID, ok := normalized[field.Key].(int32)
+		if !ok || field.Type != storepb.TicketField_USER {
+			continue
+		}
+		user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user").SetInternal(err)
+		}
+		if user == nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid fields: user %d of field %q not found", userID, field.Key))
+		}
+	}
+	return normalized, nil
+}
+
+// getTicketFieldFilterValues returns the values of the custom fields of the ticket in the ticket filter.
+// Every defined field has a value, the zero value of its type when the ticket has none, so that filters
+// such as `fields.story_points > 3` apply to all tickets.
+func getTicketFieldFilterValues(definitions []*storepb.TicketField, fields map[string]any) map[string]any {
+	values := map[string]any{}
+	for _, field := range definitions {
+		value := fields[field.Key]
+		switch field.Type {
+		case storepb.TicketField_NUMBER:
+			number, _ := value.(float64)
+			values[field.Key] = number
+		case storepb.TicketField_USER:
+			// The user ids read from the database are float64, and the normalized ones int32.
+			switch userID := value.(type) {
+			case float64:
+				values[field.Key] = int64(userID)
+			case int32:
+				values[field.Key] = int64(userID)
+			default:
+				values[field.Key] = int64(0)
+			}
+		default:
+			text, _ := value.(string)
+			values[field.Key] = text
+		}
+	}
+	return values
+}
+
+func convertTicketFieldFromStore(field *storepb.TicketField) *TicketField {
+	ticketField := &TicketField{
+		Key:         field.Key,
+		Title:       field.Title,
+		Type:        field.Type.String(),
+		Options:     field.Options,
+		TicketTypes: field.TicketTypes,
+		Required:    field.Required,
+	}
+	if ticketField.Options == nil {
+		ticketField.Options = []string{}
+	}
+	if ticketField.TicketTypes == nil {
+		ticketField.TicketTypes = []string{}
+	}
+	return ticketField
+}
@@ -1,12 +1,27 @@
 package v1
 
 import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/plugin/filter"
+	"github.com/usememos/memos/plugin/webhook"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
@@ -22,16 +37,26 @@ type Ticket struct {
 	UpdatedTs   int64    `json:"updatedTs"`
 	Type        string   `json:"type"`
 	Tags        []string `json:"tags"`
+	ProjectID   *int32   `json:"projectId"`
+	// Key is the key of the ticket in its project, e.g. "API-42".
+	Key string `json:"key,omitempty"`
+	// TaskCount and CompletedTaskCount are the task progress of the description memo.
+	TaskCount          int32 `json:"taskCount"`
+	CompletedTaskCount int32 `json:"completedTaskCount"`
+	// Fields are the values of the custom fields by field key.
+	Fields map[string]any `json:"fields"`
 }
 
 type CreateTicketRequest struct {
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Status      string   `json:"status"`
-	Priority    string   `json:"priority"`
-	Type        string   `json:"type"`
-	Tags        []string `json:"tags"`
-	AssigneeID  *int32   `json:"assigneeId"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Status      string         `json:"status"`
+	Priority    string         `json:"priority"`
+	Type        string         `json:"type"`
+	Tags        []string       `json:"tags"`
+	AssigneeID  *int32         `json:"assigneeId"`
+	ProjectID   *int32         `json:"projectId"`
+	Fields      map[string]any `json:"fields"`
 }
 
 type UpdateTicketRequest struct {
@@ -42,12 +67,21 @@ type UpdateTicketRequest struct {
 	Type        *string  `json:"type"`
 	Tags        []string `json:"tags"`
 	AssigneeID  *int32   `json:"assigneeId"`
+	// Fields are merged into the values of the custom fields, a null value removes the value of a field.
+	Fields map[string]any `json:"fields"`
 }
 
 func (s *APIV1Service) RegisterTicketRoutes(g *echo.Group) {
 	g.POST("/tickets", s.CreateTicket)
 	g.GET("/tickets", s.ListTickets)
 	g.GET("/tickets/assignees", s.ListTicketAssignees)
+	g.POST("/tickets\\:batchUpdate", s.BatchUpdateTickets)
+	g.POST("/tickets\\:batchDelete", s.BatchDeleteTickets)
+	g.GET("/tickets/export", s.ExportTickets)
+	g.GET("/tickets/fields", s.ListTicketFields)
+	g.POST("/tickets/fields", s.CreateTicketField)
+	g.PATCH("/tickets/fields/:key", s.UpdateTicketField)
+	g.DELETE("/tickets/fields/:key", s.DeleteTicketField)
 	g.GET("/tickets/:id", s.GetTicket)
 	g.PATCH("/tickets/:id", s.UpdateTicket)
 	g.DELETE("/tickets/:id", s.DeleteTicket)
@@ -56,10 +90,14 @@ func (s *APIV1Service) RegisterTicketRoutes(g *echo.Group) {
 func (s *APIV1Service) CreateTicket(c echo.Context) error {
 	ctx := c.Request().Context()
 	slog.Info("CreateTicket handler", "context_keys", c.ParamNames())
-	userID, ok := c.Get(getUserIDContextKey()).(int32)
-	slog.Info("CreateTicket userID", "userID", userID, "ok", ok)
-	if !ok {
-		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in context")
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	userID := user.ID
+	slog.Info("CreateTicket userID", "userID", userID)
+	if err := s.checkTicketPermission(ctx, user, store.PermissionTicketCreate); err != nil {
+		return err
 	}
 
 	request := &CreateTicketRequest{}
@@ -68,6 +106,11 @@ func (s *APIV1Service) CreateTicket(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body").SetInternal(err)
 	}
 	slog.Info("CreateTicket request", "title", request.Title, "status", request.Status, "priority", request.Priority)
+	if request.AssigneeID != nil && *request.AssigneeID != userID {
+		if err := s.checkTicketPermission(ctx, user, store.PermissionTicketAssign); err != nil {
+			return err
+		}
+	}
 
 	ticket := &store.Ticket{
 		Title:       request.Title,
@@ -80,14 +123,35 @@ func (s *APIV1Service) CreateTicket(c echo.Context) error {
 		AssigneeID:  request.AssigneeID,
 		CreatedTs:   time.Now().Unix(),
 		UpdatedTs:   time.Now().Unix(),
+		ProjectID:   request.ProjectID,
 	}
 
+	var project *store.Project
+	if request.ProjectID != nil {
+		project, err = s.getTicketProject(ctx, user, *request.ProjectID)
+		if err != nil {
+			return err
+		}
+		if project.RowStatus == store.Archived {
+			return echo.NewHTTPError(http.StatusBadRequest, "Project is archived")
+		}
+		if err := applyProjectTicketDefaults(project, ticket); err != nil {
+			return err
+		}
+	}
 	if ticket.Type == "" {
 		ticket.Type = "TASK"
 	}
 	if ticket.Tags == nil {
 		ticket.Tags = []string{}
 	}
+	definitions, err := s.listTicketFieldDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	if ticket.Fields, err = s.normalizeTicketFields(ctx, definitions, ticket.Type, request.Fields); err != nil {
+		return err
+	}
 
 	if err := ticket.Validate(); err != nil {
 		slog.Error("CreateTicket validate error", "error", err)
@@ -95,7 +159,7 @@ func (s *APIV1Service) CreateTicket(c echo.Context) error {
 	}
 	slog.Info("CreateTicket validated")
 
-	ticket, err := s.Store.CreateTicket(ctx, ticket)
+	ticket, err = s.Store.CreateTicket(ctx, ticket)
 	if err != nil {
 		slog.Error("CreateTicket store error", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create ticket").SetInternal(err)
@@ -103,12 +167,48 @@ func (s *APIV1Service) CreateTicket(c echo.Context) error {
 
 	slog.Info("CreateTicket success", "id", ticket.ID)
 
-	return c.JSON(http.StatusOK, convertTicketFromStore(ticket))
+	result, err := s.convertTicketFromStore(ctx, ticket, project)
+	if err != nil {
+		return err
+	}
+	if err := s.dispatchTicketWebhook(ctx, result, "memos.ticket.created"); err != nil {
+		slog.Warn("Failed to dispatch ticket created webhook", slog.Any("err", err))
+	}
+	return c.JSON(http.StatusOK, result)
 }
 
 func (s *APIV1Service) ListTickets(c echo.Context) error {
 	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	if err := s.checkTicketPermission(ctx, user, store.PermissionTicketView); err != nil {
+		return err
+	}
+
+	list, projects, err := s.listTicketsByQuery(c, user)
+	if err != nil {
+		return err
+	}
+	result := make([]*Ticket, 0, len(list))
+	for _, t := range list {
+		var project *store.Project
+		if t.ProjectID != nil {
+			project = projects[*t.ProjectID]
+		}
+		ticket, err := s.convertTicketFromStore(ctx, t, project)
+		if err != nil {
+			return err
+		}
+		result = append(result, ticket)
+	}
 
+	return c.JSON(http.StatusOK, result)
+}
+
+// listTicketsByQuery returns the tickets of listTickets for the query parameters of the request.
+func (s *APIV1Service) listTicketsByQuery(c echo.Context, user *store.User) ([]*store.Ticket, map[int32]*store.Project, error) {
 	find := &store.FindTicket{}
 	if typeStr := c.QueryParam("type"); typeStr != "" {
 		find.Type = &typeStr
@@ -116,23 +216,165 @@ func (s *APIV1Service) ListTickets(c echo.Context) error {
 	if creatorIDStr := c.QueryParam("creatorId"); creatorIDStr != "" {
 		creatorID, err := strconv.Atoi(creatorIDStr)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Invalid creatorId")
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid creatorId")
 		}
 		id := int32(creatorID)
 		find.CreatorID = &id
 	}
+	if projectIDStr := c.QueryParam("projectId"); projectIDStr != "" {
+		projectID, err := strconv.Atoi(projectIDStr)
+		if err != nil {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid projectId")
+		}
+		id := int32(projectID)
+		find.ProjectID = &id
+	}
+	return s.listTickets(c.Request().Context(), user, find, c.QueryParam("filter"), c.QueryParam("orderBy"))
+}
+
+// listTickets returns the tickets visible to the user that match find and the ticket filter, sorted by
+// orderBy when it is not empty, along with their projects by ID.
+func (s *APIV1Service) listTickets(ctx context.Context, user *store.User, find *store.FindTicket, filterStr, orderBy string) ([]*store.Ticket, map[int32]*store.Project, error) {
+	var ticketFilter *filter.Program
+	if filterStr != "" {
+		var err error
+		if ticketFilter, err = filter.Compile(filterStr, filter.TicketFilterCELAttributes...); err != nil {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid filter: %v", err))
+		}
+	}
+	definitions, err := s.listTicketFieldDefinitions(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	list, err := s.Store.ListTickets(ctx, find)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list tickets").SetInternal(err)
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list tickets").SetInternal(err)
+	}
+	projects, err := s.listTicketProjects(ctx, user)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	result := make([]*Ticket, 0, len(list))
+	tickets := make([]*store.Ticket, 0, len(list))
 	for _, t := range list {
-		result = append(result, convertTicketFromStore(t))
+		// Tickets of projects the user is not a member of are hidden.
+		if t.ProjectID != nil && projects[*t.ProjectID] == nil {
+			continue
+		}
+		if ticketFilter != nil {
+			matched, err := ticketFilter.Match(getTicketFilterValues(t, definitions))
+			if err != nil {
+				return nil, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid filter: %v", err))
+			}
+			if !matched {
+				continue
+			}
+		}
+		tickets = append(tickets, t)
 	}
+	if orderBy != "" {
+		if err := sortTickets(tickets, orderBy, definitions); err != nil {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid orderBy: %v", err))
+		}
+	}
+	return tickets, projects, nil
+}
 
-	return c.JSON(http.StatusOK, result)
+// ExportTickets responds with the tickets of ListTickets as a CSV file, with a column per custom field.
+func (s *APIV1Service) ExportTickets(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	if err := s.checkTicketPermission(ctx, user, store.PermissionTicketView); err != nil {
+		return err
+	}
+
+	list, projects, err := s.listTicketsByQuery(c, user)
+	if err != nil {
+		return err
+	}
+	definitions, err := s.listTicketFieldDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	usernames := map[int32]string{}
+	getUsername := func(userID int32) (string, error) {
+		if username, ok := usernames[userID]; ok {
+			return username, nil
+		}
+		user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+		if err != nil {
+			return "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user").SetInternal(err)
+		}
+		if user != nil {
+			usernames[userID] = user.Username
+		}
+		return usernames[userID], nil
+	}
+
+	header := []string{"ID", "Key", "Title", "Status", "Priority", "Type", "Tags", "Creator", "Assignee", "Created", "Updated"}
+	for _, field := range definitions {
+		header = append(header, field.Title)
+	}
+	records := [][]string{header}
+	for _, ticket := range list {
+		key := ""
+		if ticket.ProjectID != nil {
+			key = store.FormatTicketKey(projects[*ticket.ProjectID].Key, ticket.Number)
+		}
+		creator, err := getUsername(ticket.CreatorID)
+		if err != nil {
+			return err
+		}
+		assignee := ""
+		if ticket.AssigneeID != nil {
+			if assignee, err = getUsername(*ticket.AssigneeID); err != nil {
+				return err
+			}
+		}
+		record := []string{
+			strconv.Itoa(int(ticket.ID)),
+			key,
+			ticket.Title,
+			string(ticket.Status),
+			string(ticket.Priority),
+			ticket.Type,
+			strings.Join(ticket.Tags, ","),
+			creator,
+			assignee,
+			time.Unix(ticket.CreatedTs, 0).UTC().Format(time.RFC3339),
+			time.Unix(ticket.UpdatedTs, 0).UTC().Format(time.RFC3339),
+		}
+		for _, field := range definitions {
+			value, ok := ticket.Fields[field.Key]
+			if !ok {
+				record = append(record, "")
+				continue
+			}
+			if userID, ok := value.(float64); ok && field.Type == storepb.TicketField_USER {
+				username, err := getUsername(int32(userID))
+				if err != nil {
+					return err
+				}
+				record = append(record, username)
+				continue
+			}
+			record = append(record, fmt.Sprint(value))
+		}
+		records = append(records, record)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="tickets.csv"`)
+	c.Response().WriteHeader(http.StatusOK)
+	writer := csv.NewWriter(c.Response())
+	if err := writer.WriteAll(records); err != nil {
+		return errors.Wrap(err, "failed to write tickets")
+	}
+	return nil
 }
 
 // AssigneeUser is a simplified user structure for the assignee dropdown
@@ -144,16 +386,23 @@ type AssigneeUser struct {
 func (s *APIV1Service) ListTicketAssignees(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	// Verify user is logged in
-	_, ok := c.Get(getUserIDContextKey()).(int32)
-	if !ok {
-		return echo.NewHTTPError(http.StatusUnauthorized, "Missing user in context")
+	currentUser, err := s.getTicketUser(c)
+	if err != nil {
+		return err
 	}
 
-	// List all users for assignee dropdown
-	users, err := s.Store.ListUsers(ctx, &store.FindUser{})
+	// Users who cannot assign tickets to others may only assign themselves.
+	users := []*store.User{currentUser}
+	canAssign, err := s.Store.HasPermission(ctx, currentUser, store.PermissionTicketAssign)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list users").SetInternal(err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check permission").SetInternal(err)
+	}
+	if canAssign {
+		normalStatus := store.Normal
+		users, err = s.Store.ListUsers(ctx, &store.FindUser{RowStatus: &normalStatus})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list users").SetInternal(err)
+		}
 	}
 
 	result := make([]*AssigneeUser, 0, len(users))
@@ -175,19 +424,74 @@ func (s *APIV1Service) UpdateTicket(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ticket ID")
 	}
 
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	ticketID := int32(id)
+	ticket, err := s.Store.GetTicket(ctx, &store.FindTicket{ID: &ticketID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get ticket").SetInternal(err)
+	}
+	if ticket == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Ticket not found")
+	}
 	request := &UpdateTicketRequest{}
 	if err := c.Bind(request); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body").SetInternal(err)
 	}
+	update, project, err := s.prepareTicketUpdate(ctx, user, ticket, request)
+	if err != nil {
+		return err
+	}
+
+	ticket, err = s.Store.UpdateTicket(ctx, update)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update ticket").SetInternal(err)
+	}
+
+	result, err := s.convertTicketFromStore(ctx, ticket, project)
+	if err != nil {
+		return err
+	}
+	if err := s.dispatchTicketWebhook(ctx, result, "memos.ticket.updated"); err != nil {
+		slog.Warn("Failed to dispatch ticket updated webhook", slog.Any("err", err))
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// prepareTicketUpdate checks that the user can apply the request to the ticket, and returns the update
+// along with the project of the ticket.
+func (s *APIV1Service) prepareTicketUpdate(ctx context.Context, user *store.User, ticket *store.Ticket, request *UpdateTicketRequest) (*store.UpdateTicket, *store.Project, error) {
+	isOwn := ticket.CreatorID == user.ID || (ticket.AssigneeID != nil && *ticket.AssigneeID == user.ID)
+	if err := s.checkTicketOwnPermission(ctx, user, isOwn, store.PermissionTicketEditAny, store.PermissionTicketEditOwn); err != nil {
+		return nil, nil, err
+	}
+	var project *store.Project
+	if ticket.ProjectID != nil {
+		var err error
+		if project, err = s.getTicketProject(ctx, user, *ticket.ProjectID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if request.AssigneeID != nil && *request.AssigneeID != user.ID && (ticket.AssigneeID == nil || *ticket.AssigneeID != *request.AssigneeID) {
+		if err := s.checkTicketPermission(ctx, user, store.PermissionTicketAssign); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	update := &store.UpdateTicket{
-		ID:          int32(id),
+		ID:          ticket.ID,
 		Title:       request.Title,
 		Description: request.Description,
 		AssigneeID:  request.AssigneeID,
 	}
 	if request.Status != nil {
 		status := store.TicketStatus(*request.Status)
+		if project != nil && !project.IsTicketTransitionAllowed(string(ticket.Status), string(status)) {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Transition from %s to %s is not allowed by the project workflow", ticket.Status, status))
+		}
 		update.Status = &status
 	}
 	if request.Priority != nil {
@@ -195,20 +499,40 @@ func (s *APIV1Service) UpdateTicket(c echo.Context) error {
 		update.Priority = &priority
 	}
 	if request.Type != nil {
+		if project != nil && !project.IsTicketTypeAllowed(*request.Type) {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Ticket type %s is not allowed in the project", *request.Type))
+		}
 		update.Type = request.Type
 	}
 	if request.Tags != nil {
 		update.Tags = request.Tags
 	}
+	if request.Fields != nil || request.Type != nil {
+		ticketType := ticket.Type
+		if request.Type != nil {
+			ticketType = *request.Type
+		}
+		definitions, err := s.listTicketFieldDefinitions(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		// The values of the fields that were deleted or do not apply to the new type are dropped.
+		fields := map[string]any{}
+		for _, field := range definitions {
+			if value, ok := ticket.Fields[field.Key]; ok && store.TicketFieldAppliesTo(field, ticketType) {
+				fields[field.Key] = value
+			}
+		}
+		for key, value := range request.Fields {
+			fields[key] = value
+		}
+		if update.Fields, err = s.normalizeTicketFields(ctx, definitions, ticketType, fields); err != nil {
+			return nil, nil, err
+		}
+	}
 	now := time.Now().Unix()
 	update.UpdatedTs = &now
-
-	ticket, err := s.Store.UpdateTicket(ctx, update)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update ticket").SetInternal(err)
-	}
-
-	return c.JSON(http.StatusOK, convertTicketFromStore(ticket))
+	return update, project, nil
 }
 
 func (s *APIV1Service) DeleteTicket(c echo.Context) error {
@@ -219,15 +543,42 @@ func (s *APIV1Service) DeleteTicket(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ticket ID")
 	}
 
-	if err := s.Store.DeleteTicket(ctx, &store.DeleteTicket{ID: int32(id)}); err != nil {
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	ticketID := int32(id)
+	ticket, err := s.Store.GetTicket(ctx, &store.FindTicket{ID: &ticketID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get ticket").SetInternal(err)
+	}
+	if ticket == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Ticket not found")
+	}
+	if _, err := s.checkTicketDelete(ctx, user, ticket); err != nil {
+		return err
+	}
+
+	if err := s.Store.DeleteTicket(ctx, &store.DeleteTicket{ID: ticket.ID}); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete ticket").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, true)
 }
 
-func convertTicketFromStore(ticket *store.Ticket) *Ticket {
-	return &Ticket{
+// checkTicketDelete checks that the user can delete the ticket, and returns the project of the ticket.
+func (s *APIV1Service) checkTicketDelete(ctx context.Context, user *store.User, ticket *store.Ticket) (*store.Project, error) {
+	if err := s.checkTicketOwnPermission(ctx, user, ticket.CreatorID == user.ID, store.PermissionTicketDelete, store.PermissionTicketDeleteOwn); err != nil {
+		return nil, err
+	}
+	if ticket.ProjectID == nil {
+		return nil, nil
+	}
+	return s.getTicketProject(ctx, user, *ticket.ProjectID)
+}
+
+func (s *APIV1Service) convertTicketFromStore(ctx context.Context, ticket *store.Ticket, project *store.Project) (*Ticket, error) {
+	result := &Ticket{
 		ID:          ticket.ID,
 		Title:       ticket.Title,
 		Description: ticket.Description,
@@ -239,12 +590,42 @@ func convertTicketFromStore(ticket *store.Ticket) *Ticket {
 		UpdatedTs:   ticket.UpdatedTs,
 		Type:        ticket.Type,
 		Tags:        ticket.Tags,
+		ProjectID:   ticket.ProjectID,
+		Fields:      ticket.Fields,
+	}
+	if result.Fields == nil {
+		result.Fields = map[string]any{}
+	}
+	if project != nil {
+		result.Key = store.FormatTicketKey(project.Key, ticket.Number)
 	}
+	if memoUID, ok := strings.CutPrefix(ticket.Description, "/m/"); ok {
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID, ExcludeContent: true})
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get description memo").SetInternal(err)
+		}
+		if memo != nil {
+			result.TaskCount = memo.Payload.GetProperty().GetTaskCount()
+			result.CompletedTaskCount = memo.Payload.GetProperty().GetCompletedTaskCount()
+		}
+	}
+	return result, nil
 }
 
 func (s *APIV1Service) GetTicket(c echo.Context) error {
 	ctx := c.Request().Context()
+	user, err := s.getTicketUser(c)
+	if err != nil {
+		return err
+	}
+	if err := s.checkTicketPermission(ctx, user, store.PermissionTicketView); err != nil {
+		return err
+	}
+
 	idStr := c.Param("id")
+	if projectKey, number, ok := store.ParseTicketKey(idStr); ok {
+		return s.getTicketByKey(c, user, projectKey, number)
+	}
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ticket ID")
@@ -288,8 +669,302 @@ func (s *APIV1Service) GetTicket(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Ticket not found")
 	}
 
+	var project *store.Project
+	if list[0].ProjectID != nil {
+		if project, err = s.getTicketProject(ctx, user, *list[0].ProjectID); err != nil {
+			return err
+		}
+	}
+
 	slog.Info("GetTicket success", "id", list[0].ID)
-	return c.JSON(http.StatusOK, convertTicketFromStore(list[0]))
+	result, err := s.convertTicketFromStore(ctx, list[0], project)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// getTicketByKey responds with the ticket with a project key such as "API-42".
+func (s *APIV1Service) getTicketByKey(c echo.Context, user *store.User, projectKey string, number int32) error {
+	ctx := c.Request().Context()
+	project, err := s.getProjectByKey(ctx, projectKey)
+	if err != nil {
+		return err
+	}
+	if err := s.checkProjectAccess(ctx, user, project, false); err != nil {
+		return err
+	}
+	ticket, err := s.Store.GetTicket(ctx, &store.FindTicket{ProjectID: &project.ID, Number: &number})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get ticket").SetInternal(err)
+	}
+	if ticket == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Ticket not found")
+	}
+	result, err := s.convertTicketFromStore(ctx, ticket, project)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// getTicketProject returns the project of a ticket, checking that the user has access to it.
+func (s *APIV1Service) getTicketProject(ctx context.Context, user *store.User, projectID int32) (*store.Project, error) {
+	project, err := s.Store.GetProject(ctx, &store.FindProject{ID: &projectID})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get project").SetInternal(err)
+	}
+	if project == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Project not found")
+	}
+	if err := s.checkProjectAccess(ctx, user, project, false); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// listTicketProjects returns the projects whose tickets the user can see, by ID.
+func (s *APIV1Service) listTicketProjects(ctx context.Context, user *store.User) (map[int32]*store.Project, error) {
+	find := &store.FindProject{}
+	canManage, err := s.Store.HasPermission(ctx, user, store.PermissionProjectManage)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to check permission").SetInternal(err)
+	}
+	if !canManage {
+		projectIDs, err := s.Store.ListUserProjectIDs(ctx, user.ID)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list project members").SetInternal(err)
+		}
+		find.IDList = projectIDs
+	}
+	projects, err := s.Store.ListProjects(ctx, find)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to list projects").SetInternal(err)
+	}
+	projectMap := map[int32]*store.Project{}
+	for _, project := range projects {
+		projectMap[project.ID] = project
+	}
+	return projectMap, nil
+}
+
+// applyProjectTicketDefaults fills in the type, status and assignee of a new ticket from its project
+// and checks them against the ticket types and workflow of the project.
+func applyProjectTicketDefaults(project *store.Project, ticket *store.Ticket) error {
+	if ticket.Type == "" {
+		ticket.Type = project.DefaultTicketType()
+	}
+	if ticket.Type != "" && !project.IsTicketTypeAllowed(ticket.Type) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Ticket type %s is not allowed in the project", ticket.Type))
+	}
+	if ticket.Status == "" {
+		ticket.Status = store.TicketStatus(project.InitialTicketStatus())
+	}
+	if ticket.Status != "" && !project.IsTicketStatusAllowed(string(ticket.Status)) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Ticket status %s is not allowed by the project workflow", ticket.Status))
+	}
+	if ticket.AssigneeID == nil {
+		ticket.AssigneeID = project.DefaultAssigneeID(ticket.Type)
+	}
+	return nil
+}
+
+// getTicketUser returns the user set on the context by AuthMiddleware.
+func (s *APIV1Service) getTicketUser(c echo.Context) (*store.User, error) {
+	userID, ok := c.Get(getUserIDContextKey()).(int32)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Missing user in context")
+	}
+	user, err := s.Store.GetUser(c.Request().Context(), &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user").SetInternal(err)
+	}
+	if user == nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "User not found")
+	}
+	return user, nil
+}
+
+func (s *APIV1Service) checkTicketPermission(ctx context.Context, user *store.User, permission store.Permission) error {
+	hasPermission, err := s.Store.HasPermission(ctx, user, permission)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check permission").SetInternal(err)
+	}
+	if !hasPermission {
+		return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("Permission denied: %s is required", permission))
+	}
+	return nil
+}
+
+// checkTicketOwnPermission checks anyPermission, falling back to ownPermission when the ticket is the user's own.
+func (s *APIV1Service) checkTicketOwnPermission(ctx context.Context, user *store.User, isOwn bool, anyPermission, ownPermission store.Permission) error {
+	hasPermission, err := s.Store.HasPermission(ctx, user, anyPermission)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check permission").SetInternal(err)
+	}
+	if hasPermission {
+		return nil
+	}
+	if !isOwn {
+		return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("Permission denied: %s is required", anyPermission))
+	}
+	return s.checkTicketPermission(ctx, user, ownPermission)
+}
+
+// ticketSortFields are the ticket attributes the tickets can be sorted by, besides the custom fields.
+var ticketSortFields = []string{"title", "status", "priority", "created_ts", "updated_ts"}
+
+// ticketPriorityOrder is the sort order of the ticket priorities.
+var ticketPriorityOrder = []store.TicketPriority{store.TicketPriorityLow, store.TicketPriorityMedium, store.TicketPriorityHigh}
+
+// isTicketSortField returns whether the tickets can be sorted by the field, one of ticketSortFields or
+// "fields.<key>" for a custom field.
+func isTicketSortField(field string, definitions []*storepb.TicketField) bool {
+	if key, ok := strings.CutPrefix(field, "fields."); ok {
+		return slices.ContainsFunc(definitions, func(definition *storepb.TicketField) bool {
+			return definition.Key == key
+		})
+	}
+	return slices.Contains(ticketSortFields, field)
+}
+
+// sortTickets sorts the tickets by orderBy, such as "priority desc" or "fields.story_points". The tickets
+// without a value of the custom field come last in both directions.
+func sortTickets(tickets []*store.Ticket, orderBy string, definitions []*storepb.TicketField) error {
+	parts := strings.Fields(orderBy)
+	if len(parts) == 0 || len(parts) > 2 {
+		return errors.Errorf("expected a field and an optional direction, got %q", orderBy)
+	}
+	field, descending := parts[0], false
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "asc":
+		case "desc":
+			descending = true
+		default:
+			return errors.Errorf("invalid direction %q", parts[1])
+		}
+	}
+	if !isTicketSortField(field, definitions) {
+		return errors.Errorf("unsupported field %q", field)
+	}
+
+	slices.SortStableFunc(tickets, func(a, b *store.Ticket) int {
+		var result int
+		if key, ok := strings.CutPrefix(field, "fields."); ok {
+			valueA, okA := a.Fields[key]
+			valueB, okB := b.Fields[key]
+			switch {
+			case !okA && !okB:
+				return 0
+			case !okA:
+				return 1
+			case !okB:
+				return -1
+			}
+			numberA, isNumberA := valueA.(float64)
+			numberB, isNumberB := valueB.(float64)
+			if isNumberA && isNumberB {
+				result = cmp.Compare(numberA, numberB)
+			} else {
+				result = cmp.Compare(fmt.Sprint(valueA), fmt.Sprint(valueB))
+			}
+		} else {
+			switch field {
+			case "title":
+				result = cmp.Compare(a.Title, b.Title)
+			case "status":
+				result = cmp.Compare(a.Status, b.Status)
+			case "priority":
+				result = cmp.Compare(slices.Index(ticketPriorityOrder, a.Priority), slices.Index(ticketPriorityOrder, b.Priority))
+			case "created_ts":
+				result = cmp.Compare(a.CreatedTs, b.CreatedTs)
+			case "updated_ts":
+				result = cmp.Compare(a.UpdatedTs, b.UpdatedTs)
+			}
+		}
+		if descending {
+			return -result
+		}
+		return result
+	})
+	return nil
+}
+
+// dispatchTicketWebhook dispatches the webhooks of the creator of the ticket.
+func (s *APIV1Service) dispatchTicketWebhook(ctx context.Context, ticket *Ticket, activityType string) error {
+	webhooks, err := s.Store.ListWebhooks(ctx, &store.FindWebhook{
+		CreatorID: &ticket.CreatorID,
+	})
+	if err != nil {
+		return err
+	}
+	for _, hook := range webhooks {
+		payload, err := convertTicketToWebhookPayload(ticket)
+		if err != nil {
+			return errors.Wrap(err, "failed to convert ticket to webhook payload")
+		}
+		payload.ActivityType = activityType
+		payload.Url = hook.URL
+		webhook.PostAsync(payload)
+	}
+	return nil
+}
+
+func convertTicketToWebhookPayload(ticket *Ticket) (*v1pb.WebhookRequestPayload, error) {
+	ticketStruct, err := convertTicketToStruct(ticket)
+	if err != nil {
+		return nil, err
+	}
+	return &v1pb.WebhookRequestPayload{
+		Creator:    fmt.Sprintf("%s%d", UserNamePrefix, ticket.CreatorID),
+		CreateTime: timestamppb.New(time.Now()),
+		Ticket:     ticketStruct,
+	}, nil
+}
+
+// convertTicketToStruct converts the ticket to a struct with the JSON representation of the tickets API.
+func convertTicketToStruct(ticket *Ticket) (*structpb.Struct, error) {
+	data, err := json.Marshal(ticket)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal ticket")
+	}
+	values := map[string]any{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal ticket")
+	}
+	ticketStruct, err := structpb.NewStruct(values)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert ticket")
+	}
+	return ticketStruct, nil
+}
+
+// getTicketFilterValues returns the values of the attributes of the ticket filter for the ticket.
+func getTicketFilterValues(ticket *store.Ticket, definitions []*storepb.TicketField) map[string]any {
+	values := map[string]any{
+		"title":       ticket.Title,
+		"status":      string(ticket.Status),
+		"priority":    string(ticket.Priority),
+		"ticket_type": ticket.Type,
+		"tags":        ticket.Tags,
+		"creator_id":  int64(ticket.CreatorID),
+		"assignee_id": int64(0),
+		"project_id":  int64(0),
+		"created_ts":  ticket.CreatedTs,
+		"updated_ts":  ticket.UpdatedTs,
+		"fields":      getTicketFieldFilterValues(definitions, ticket.Fields),
+	}
+	if ticket.Tags == nil {
+		values["tags"] = []string{}
+	}
+	if ticket.AssigneeID != nil {
+		values["assignee_id"] = int64(*ticket.AssigneeID)
+	}
+	if ticket.ProjectID != nil {
+		values["project_id"] = int64(*ticket.ProjectID)
+	}
+	return values
 }
 
 // Helper to match the key used in common/auth.go checks
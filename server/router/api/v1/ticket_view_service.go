@@ -0,0 +1,310 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/filter"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// WorkspaceTicketViewsParent is the parent of the ticket views shared with the workspace.
+const WorkspaceTicketViewsParent = "workspace"
+
+func (s *APIV1Service) ListTicketViews(ctx context.Context, request *v1pb.ListTicketViewsRequest) (*v1pb.ListTicketViewsResponse, error) {
+	userID, err := s.getTicketViewsOwner(ctx, request.Parent, false)
+	if err != nil {
+		return nil, err
+	}
+	views, err := s.listTicketViews(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list ticket views: %v", err)
+	}
+
+	response := &v1pb.ListTicketViewsResponse{
+		Views: []*v1pb.TicketView{},
+	}
+	for _, view := range views {
+		response.Views = append(response.Views, convertTicketViewFromStore(view))
+	}
+	return response, nil
+}
+
+func (s *APIV1Service) CreateTicketView(ctx context.Context, request *v1pb.CreateTicketViewRequest) (*v1pb.TicketView, error) {
+	userID, err := s.getTicketViewsOwner(ctx, request.Parent, true)
+	if err != nil {
+		return nil, err
+	}
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+
+	newView := convertTicketViewToStore(request.View)
+	newView.Id = util.GenUUID()
+	newView.CreatorId = currentUser.ID
+	if err := s.validateTicketView(ctx, newView); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid ticket view: %v", err)
+	}
+	if request.ValidateOnly {
+		return convertTicketViewFromStore(newView), nil
+	}
+
+	if err := s.updateTicketViews(ctx, userID, func(views []*storepb.TicketView) ([]*storepb.TicketView, error) {
+		return append(views, newView), nil
+	}); err != nil {
+		return nil, err
+	}
+	return convertTicketViewFromStore(newView), nil
+}
+
+func (s *APIV1Service) UpdateTicketView(ctx context.Context, request *v1pb.UpdateTicketViewRequest) (*v1pb.TicketView, error) {
+	userID, err := s.getTicketViewsOwner(ctx, request.Parent, true)
+	if err != nil {
+		return nil, err
+	}
+	if request.UpdateMask == nil || len(request.UpdateMask.Paths) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "update mask is required")
+	}
+
+	var view *storepb.TicketView
+	if err := s.updateTicketViews(ctx, userID, func(views []*storepb.TicketView) ([]*storepb.TicketView, error) {
+		index := slices.IndexFunc(views, func(view *storepb.TicketView) bool {
+			return view.Id == request.View.GetId()
+		})
+		if index < 0 {
+			return nil, status.Errorf(codes.NotFound, "ticket view not found")
+		}
+
+		update := convertTicketViewToStore(request.View)
+		view = views[index]
+		for _, field := range request.UpdateMask.Paths {
+			switch field {
+			case "title":
+				view.Title = update.Title
+			case "filter":
+				view.Filter = update.Filter
+			case "sort":
+				view.Sort = update.Sort
+			case "group_by":
+				view.GroupBy = update.GroupBy
+			case "column_order":
+				view.ColumnOrder = update.ColumnOrder
+			case "wip_limits":
+				view.WipLimits = update.WipLimits
+			case "swimlane_by":
+				view.SwimlaneBy = update.SwimlaneBy
+			default:
+				return nil, status.Errorf(codes.InvalidArgument, "unsupported update mask path: %s", field)
+			}
+		}
+		if err := s.validateTicketView(ctx, view); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid ticket view: %v", err)
+		}
+		return views, nil
+	}); err != nil {
+		return nil, err
+	}
+	return convertTicketViewFromStore(view), nil
+}
+
+func (s *APIV1Service) DeleteTicketView(ctx context.Context, request *v1pb.DeleteTicketViewRequest) (*emptypb.Empty, error) {
+	userID, err := s.getTicketViewsOwner(ctx, request.Parent, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.updateTicketViews(ctx, userID, func(views []*storepb.TicketView) ([]*storepb.TicketView, error) {
+		return slices.DeleteFunc(views, func(view *storepb.TicketView) bool {
+			return view.Id == request.Id
+		}), nil
+	}); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// getTicketViewsOwner returns the id of the user the ticket views of the parent belong to, or 0 for the
+// views shared with the workspace. Everyone can read the shared views, but only the users who can manage
+// the workspace settings can change them.
+func (s *APIV1Service) getTicketViewsOwner(ctx context.Context, parent string, write bool) (int32, error) {
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return 0, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	if parent == WorkspaceTicketViewsParent {
+		if write {
+			canManage, err := s.Store.HasPermission(ctx, currentUser, store.PermissionWorkspaceSettingManage)
+			if err != nil {
+				return 0, status.Errorf(codes.Internal, "failed to check permission: %v", err)
+			}
+			if !canManage {
+				return 0, status.Errorf(codes.PermissionDenied, "permission denied")
+			}
+		}
+		return 0, nil
+	}
+	userID, err := ExtractUserIDFromName(parent)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	if currentUser.ID != userID {
+		return 0, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	return userID, nil
+}
+
+// listTicketViews returns the ticket views of the user, or the ones shared with the workspace when userID is 0.
+func (s *APIV1Service) listTicketViews(ctx context.Context, userID int32) ([]*storepb.TicketView, error) {
+	if userID == 0 {
+		setting, err := s.Store.GetWorkspaceSharedTicketViewsSetting(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return setting.Views, nil
+	}
+	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_TICKET_VIEWS,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userSetting == nil {
+		return []*storepb.TicketView{}, nil
+	}
+	// The user setting is cached, so the views are copied before the callers edit them.
+	views := []*storepb.TicketView{}
+	for _, view := range userSetting.GetTicketViews().GetViews() {
+		views = append(views, proto.Clone(view).(*storepb.TicketView))
+	}
+	return views, nil
+}
+
+// updateTicketViews calls update with copies of the ticket views of the user, or the ones shared with the
+// workspace when userID is 0, and saves the views it returns. The updates are serialized, so concurrent
+// changes of the views are not lost. The status errors of update are returned as is.
+func (s *APIV1Service) updateTicketViews(ctx context.Context, userID int32, update func([]*storepb.TicketView) ([]*storepb.TicketView, error)) error {
+	var err error
+	if userID == 0 {
+		err = s.Store.UpdateWorkspaceSharedTicketViews(ctx, update)
+	} else {
+		err = s.Store.UpdateUserTicketViews(ctx, userID, update)
+	}
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Errorf(codes.Internal, "failed to save ticket views: %v", err)
+}
+
+func (s *APIV1Service) validateTicketView(ctx context.Context, view *storepb.TicketView) error {
+	if view.Title == "" {
+		return errors.New("title is required")
+	}
+	if view.Filter != "" {
+		if err := s.validateTicketFilter(ctx, view.Filter); err != nil {
+			return errors.Wrap(err, "invalid filter")
+		}
+	}
+	if view.Sort != nil {
+		setting, err := s.Store.GetWorkspaceTicketFieldsSetting(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get ticket fields")
+		}
+		if !isTicketSortField(view.Sort.Field, setting.Fields) {
+			return errors.Errorf("unsupported sort field %q", view.Sort.Field)
+		}
+	}
+	for _, group := range []storepb.TicketView_Group{view.GroupBy, view.SwimlaneBy} {
+		if _, ok := storepb.TicketView_Group_name[int32(group)]; !ok {
+			return errors.Errorf("invalid group %v", group)
+		}
+	}
+	if view.SwimlaneBy != storepb.TicketView_GROUP_UNSPECIFIED && view.SwimlaneBy == view.GroupBy {
+		return errors.New("the swimlanes must be grouped by another field than the columns")
+	}
+	if view.GroupBy == storepb.TicketView_GROUP_UNSPECIFIED && (len(view.ColumnOrder) > 0 || len(view.WipLimits) > 0) {
+		return errors.New("column order and WIP limits require the tickets to be grouped")
+	}
+	for i, key := range view.ColumnOrder {
+		if slices.Contains(view.ColumnOrder[:i], key) {
+			return errors.Errorf("duplicate column %q", key)
+		}
+	}
+	for key, limit := range view.WipLimits {
+		if limit < 0 {
+			return errors.Errorf("negative WIP limit of column %q", key)
+		}
+	}
+	return nil
+}
+
+// validateTicketFilter validates the filter of the tickets. Unlike the memo filter, the ticket filter is
+// evaluated in memory, so it only needs to compile.
+func (*APIV1Service) validateTicketFilter(_ context.Context, filterStr string) error {
+	if filterStr == "" {
+		return errors.New("filter cannot be empty")
+	}
+	if _, err := filter.Compile(filterStr, filter.TicketFilterCELAttributes...); err != nil {
+		return errors.Wrap(err, "failed to compile filter")
+	}
+	return nil
+}
+
+func convertTicketViewFromStore(view *storepb.TicketView) *v1pb.TicketView {
+	ticketView := &v1pb.TicketView{
+		Id:          view.Id,
+		Title:       view.Title,
+		Filter:      view.Filter,
+		GroupBy:     v1pb.TicketView_Group(view.GroupBy),
+		ColumnOrder: view.ColumnOrder,
+		WipLimits:   view.WipLimits,
+		SwimlaneBy:  v1pb.TicketView_Group(view.SwimlaneBy),
+	}
+	if view.Sort != nil {
+		ticketView.Sort = &v1pb.TicketView_Sort{
+			Field:      view.Sort.Field,
+			Descending: view.Sort.Descending,
+		}
+	}
+	if view.CreatorId != 0 {
+		ticketView.Creator = fmt.Sprintf("%s%d", UserNamePrefix, view.CreatorId)
+	}
+	return ticketView
+}
+
+func convertTicketViewToStore(view *v1pb.TicketView) *storepb.TicketView {
+	ticketView := &storepb.TicketView{
+		Id:          view.GetId(),
+		Title:       view.GetTitle(),
+		Filter:      view.GetFilter(),
+		GroupBy:     storepb.TicketView_Group(view.GetGroupBy()),
+		ColumnOrder: view.GetColumnOrder(),
+		WipLimits:   view.GetWipLimits(),
+		SwimlaneBy:  storepb.TicketView_Group(view.GetSwimlaneBy()),
+	}
+	if view.GetSort() != nil {
+		ticketView.Sort = &storepb.TicketView_Sort{
+			Field:      view.GetSort().GetField(),
+			Descending: view.GetSort().GetDescending(),
+		}
+	}
+	return ticketView
+}
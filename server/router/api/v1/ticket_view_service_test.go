@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestTicketViews(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	other := createTestingUser(ctx, t, s, "other", store.RoleUser)
+	userCtx := withTestingUser(ctx, user)
+	parent := fmt.Sprintf("%s%d", UserNamePrefix, user.ID)
+
+	view, err := s.CreateTicketView(userCtx, &v1pb.CreateTicketViewRequest{
+		Parent: parent,
+		View:   &v1pb.TicketView{Title: "Open", Filter: `status == "OPEN"`},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, view.Id)
+	require.Equal(t, parent, view.Creator)
+
+	// An invalid filter is rejected.
+	_, err = s.CreateTicketView(userCtx, &v1pb.CreateTicketViewRequest{
+		Parent: parent,
+		View:   &v1pb.TicketView{Title: "Broken", Filter: `status ==`},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	updated, err := s.UpdateTicketView(userCtx, &v1pb.UpdateTicketViewRequest{
+		Parent:     parent,
+		View:       &v1pb.TicketView{Id: view.Id, Title: "Open tickets", Filter: "ignored"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Open tickets", updated.Title)
+	require.Equal(t, view.Filter, updated.Filter)
+	_, err = s.UpdateTicketView(userCtx, &v1pb.UpdateTicketViewRequest{
+		Parent:     parent,
+		View:       &v1pb.TicketView{Id: "missing", Title: "Missing"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// The views of a user are private to them.
+	_, err = s.ListTicketViews(withTestingUser(ctx, other), &v1pb.ListTicketViewsRequest{Parent: parent})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	response, err := s.ListTicketViews(userCtx, &v1pb.ListTicketViewsRequest{Parent: parent})
+	require.NoError(t, err)
+	require.Len(t, response.Views, 1)
+	require.Equal(t, "Open tickets", response.Views[0].Title)
+
+	_, err = s.DeleteTicketView(userCtx, &v1pb.DeleteTicketViewRequest{Parent: parent, Id: view.Id})
+	require.NoError(t, err)
+	response, err = s.ListTicketViews(userCtx, &v1pb.ListTicketViewsRequest{Parent: parent})
+	require.NoError(t, err)
+	require.Empty(t, response.Views)
+}
+
+func TestSharedTicketViews(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	host := createTestingUser(ctx, t, s, "host", store.RoleHost)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+
+	// Only the users managing the workspace settings can change the shared views.
+	_, err := s.CreateTicketView(withTestingUser(ctx, user), &v1pb.CreateTicketViewRequest{
+		Parent: WorkspaceTicketViewsParent,
+		View:   &v1pb.TicketView{Title: "Shared"},
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = s.CreateTicketView(withTestingUser(ctx, host), &v1pb.CreateTicketViewRequest{
+		Parent: WorkspaceTicketViewsParent,
+		View:   &v1pb.TicketView{Title: "Shared"},
+	})
+	require.NoError(t, err)
+
+	response, err := s.ListTicketViews(withTestingUser(ctx, user), &v1pb.ListTicketViewsRequest{Parent: WorkspaceTicketViewsParent})
+	require.NoError(t, err)
+	require.Len(t, response.Views, 1)
+	require.Equal(t, fmt.Sprintf("%s%d", UserNamePrefix, host.ID), response.Views[0].Creator)
+}
+
+func TestTicketViewsConcurrentCreate(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	host := createTestingUser(ctx, t, s, "host", store.RoleHost)
+	hostCtx := withTestingUser(ctx, host)
+
+	// Concurrent creations don't overwrite each other.
+	const count = 10
+	for _, parent := range []string{fmt.Sprintf("%s%d", UserNamePrefix, host.ID), WorkspaceTicketViewsParent} {
+		var wg sync.WaitGroup
+		errs := make(chan error, count)
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, err := s.CreateTicketView(hostCtx, &v1pb.CreateTicketViewRequest{
+					Parent: parent,
+					View:   &v1pb.TicketView{Title: fmt.Sprintf("View %d", i)},
+				})
+				errs <- err
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		response, err := s.ListTicketViews(hostCtx, &v1pb.ListTicketViewsRequest{Parent: parent})
+		require.NoError(t, err)
+		require.Len(t, response.Views, count)
+	}
+}
@@ -115,15 +115,16 @@ func (s *APIV1Service) GetUserAvatarBinary(ctx context.Context, request *v1pb.Ge
 }
 
 func (s *APIV1Service) CreateUser(ctx context.Context, request *v1pb.CreateUserRequest) (*v1pb.User, error) {
+	if !base.UIDMatcher.MatchString(strings.ToLower(request.User.Username)) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid username: %s", request.User.Username)
+	}
 	currentUser, err := s.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
 	}
-	if currentUser.Role != store.RoleHost {
-		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
-	}
-	if !base.UIDMatcher.MatchString(strings.ToLower(request.User.Username)) {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid username: %s", request.User.Username)
+	role := convertUserRoleToStore(request.User.Role)
+	if err := checkRoleGrantable(currentUser, role); err != nil {
+		return nil, err
 	}
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(request.User.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -132,7 +133,7 @@ func (s *APIV1Service) CreateUser(ctx context.Context, request *v1pb.CreateUserR
 
 	user, err := s.Store.CreateUser(ctx, &store.User{
 		Username:     request.User.Username,
-		Role:         convertUserRoleToStore(request.User.Role),
+		Role:         role,
 		Email:        request.User.Email,
 		Nickname:     request.User.Nickname,
 		PasswordHash: string(passwordHash),
@@ -157,9 +158,11 @@ func (s *APIV1Service) UpdateUser(ctx context.Context, request *v1pb.UpdateUserR
 		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
 	}
 	// Check permission.
-	// Only allow admin or self to update user.
-	if currentUser.ID != userID && currentUser.Role != store.RoleAdmin && currentUser.Role != store.RoleHost {
-		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	// Only allow user managers or self to update user.
+	if currentUser.ID != userID {
+		if err := s.checkPermission(ctx, store.PermissionUserManage); err != nil {
+			return nil, err
+		}
 	}
 
 	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
@@ -169,6 +172,12 @@ func (s *APIV1Service) UpdateUser(ctx context.Context, request *v1pb.UpdateUserR
 	if user == nil {
 		return nil, status.Errorf(codes.NotFound, "user not found")
 	}
+	// User managers cannot update users above them.
+	if currentUser.ID != userID {
+		if err := checkRoleGrantable(currentUser, user.Role); err != nil {
+			return nil, err
+		}
+	}
 
 	currentTs := time.Now().Unix()
 	update := &store.UpdateUser{
@@ -200,11 +209,15 @@ func (s *APIV1Service) UpdateUser(ctx context.Context, request *v1pb.UpdateUserR
 		} else if field == "description" {
 			update.Description = &request.User.Description
 		} else if field == "role" {
-			// Only allow admin to update role.
-			if currentUser.Role != store.RoleAdmin && currentUser.Role != store.RoleHost {
-				return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+			// Only allow user managers to update role.
+			if err := s.checkPermission(ctx, store.PermissionUserManage); err != nil {
+				return nil, err
 			}
+			// Users cannot grant a role above their own.
 			role := convertUserRoleToStore(request.User.Role)
+			if err := checkRoleGrantable(currentUser, role); err != nil {
+				return nil, err
+			}
 			update.Role = &role
 		} else if field == "password" {
 			passwordHash, err := bcrypt.GenerateFromPassword([]byte(request.User.Password), bcrypt.DefaultCost)
@@ -238,8 +251,10 @@ func (s *APIV1Service) DeleteUser(ctx context.Context, request *v1pb.DeleteUserR
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
 	}
-	if currentUser.ID != userID && currentUser.Role != store.RoleAdmin && currentUser.Role != store.RoleHost {
-		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	if currentUser.ID != userID {
+		if err := s.checkPermission(ctx, store.PermissionUserManage); err != nil {
+			return nil, err
+		}
 	}
 
 	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
@@ -249,6 +264,12 @@ func (s *APIV1Service) DeleteUser(ctx context.Context, request *v1pb.DeleteUserR
 	if user == nil {
 		return nil, status.Errorf(codes.NotFound, "user not found")
 	}
+	// User managers cannot delete users above them.
+	if currentUser.ID != userID {
+		if err := checkRoleGrantable(currentUser, user.Role); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.Store.DeleteUser(ctx, &store.DeleteUser{
 		ID: user.ID,
@@ -365,32 +386,33 @@ func (s *APIV1Service) ListUserAccessTokens(ctx context.Context, request *v1pb.L
 
 	accessTokens := []*v1pb.UserAccessToken{}
 	for _, userAccessToken := range userAccessTokens {
-		claims := &ClaimsMessage{}
-		_, err := jwt.ParseWithClaims(userAccessToken.AccessToken, claims, func(t *jwt.Token) (any, error) {
-			if t.Method.Alg() != jwt.SigningMethodHS256.Name {
-				return nil, errors.Errorf("unexpected access token signing method=%v, expect %v", t.Header["alg"], jwt.SigningMethodHS256)
-			}
-			if kid, ok := t.Header["kid"].(string); ok {
-				if kid == "v1" {
-					return []byte(s.Secret), nil
-				}
-			}
-			return nil, errors.Errorf("unexpected access token kid=%v", t.Header["kid"])
-		})
-		if err != nil {
-			// If the access token is invalid or expired, just ignore it.
+		if store.IsAccessTokenExpired(userAccessToken, time.Now()) {
 			continue
 		}
-
-		userAccessToken := &v1pb.UserAccessToken{
-			AccessToken: userAccessToken.AccessToken,
-			Description: userAccessToken.Description,
-			IssuedAt:    timestamppb.New(claims.IssuedAt.Time),
-		}
-		if claims.ExpiresAt != nil {
-			userAccessToken.ExpiresAt = timestamppb.New(claims.ExpiresAt.Time)
+		accessToken := convertUserAccessTokenFromStore(userAccessToken)
+		if userAccessToken.AccessToken != "" {
+			// Tokens stored before hashing was introduced keep their metadata in the JWT claims.
+			claims := &ClaimsMessage{}
+			if _, err := jwt.ParseWithClaims(userAccessToken.AccessToken, claims, func(t *jwt.Token) (any, error) {
+				if t.Method.Alg() != jwt.SigningMethodHS256.Name {
+					return nil, errors.Errorf("unexpected access token signing method=%v, expect %v", t.Header["alg"], jwt.SigningMethodHS256)
+				}
+				if kid, ok := t.Header["kid"].(string); ok {
+					if kid == "v1" {
+						return []byte(s.Secret), nil
+					}
+				}
+				return nil, errors.Errorf("unexpected access token kid=%v", t.Header["kid"])
+			}); err != nil {
+				// If the access token is invalid or expired, just ignore it.
+				continue
+			}
+			accessToken.IssuedAt = timestamppb.New(claims.IssuedAt.Time)
+			if claims.ExpiresAt != nil {
+				accessToken.ExpiresAt = timestamppb.New(claims.ExpiresAt.Time)
+			}
 		}
-		accessTokens = append(accessTokens, userAccessToken)
+		accessTokens = append(accessTokens, accessToken)
 	}
 
 	// Sort by issued time in descending order.
@@ -422,43 +444,30 @@ func (s *APIV1Service) CreateUserAccessToken(ctx context.Context, request *v1pb.
 	expiresAt := time.Time{}
 	if request.ExpiresAt != nil {
 		expiresAt = request.ExpiresAt.AsTime()
+		if !expiresAt.After(time.Now()) {
+			return nil, status.Errorf(codes.InvalidArgument, "expires_at must be in the future")
+		}
 	}
-
-	accessToken, err := GenerateAccessToken(currentUser.Username, currentUser.ID, expiresAt, []byte(s.Secret))
+	scopes, err := convertAccessTokenScopesToStore(request.Scopes)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate access token: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid scopes: %v", err)
 	}
 
-	claims := &ClaimsMessage{}
-	_, err = jwt.ParseWithClaims(accessToken, claims, func(t *jwt.Token) (any, error) {
-		if t.Method.Alg() != jwt.SigningMethodHS256.Name {
-			return nil, errors.Errorf("unexpected access token signing method=%v, expect %v", t.Header["alg"], jwt.SigningMethodHS256)
-		}
-		if kid, ok := t.Header["kid"].(string); ok {
-			if kid == "v1" {
-				return []byte(s.Secret), nil
-			}
-		}
-		return nil, errors.Errorf("unexpected access token kid=%v", t.Header["kid"])
-	})
+	accessToken, err := GenerateAccessToken(currentUser.Username, currentUser.ID, expiresAt, []byte(s.Secret))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to parse access token: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to generate access token: %v", err)
 	}
 
 	// Upsert the access token to user setting store.
-	if err := s.UpsertAccessTokenToStore(ctx, currentUser, accessToken, request.Description); err != nil {
+	userAccessToken, err := s.UpsertAccessTokenToStore(ctx, currentUser, accessToken, request.Description, scopes, expiresAt)
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to upsert access token to store: %v", err)
 	}
 
-	userAccessToken := &v1pb.UserAccessToken{
-		AccessToken: accessToken,
-		Description: request.Description,
-		IssuedAt:    timestamppb.New(claims.IssuedAt.Time),
-	}
-	if claims.ExpiresAt != nil {
-		userAccessToken.ExpiresAt = timestamppb.New(claims.ExpiresAt.Time)
-	}
-	return userAccessToken, nil
+	accessTokenpb := convertUserAccessTokenFromStore(userAccessToken)
+	// The access token is only returned once, the store keeps its hash.
+	accessTokenpb.AccessToken = accessToken
+	return accessTokenpb, nil
 }
 
 func (s *APIV1Service) DeleteUserAccessToken(ctx context.Context, request *v1pb.DeleteUserAccessTokenRequest) (*emptypb.Empty, error) {
@@ -477,54 +486,71 @@ func (s *APIV1Service) DeleteUserAccessToken(ctx context.Context, request *v1pb.
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
-	userAccessTokens, err := s.Store.GetUserAccessTokens(ctx, currentUser.ID)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list access tokens: %v", err)
-	}
-	updatedUserAccessTokens := []*storepb.AccessTokensUserSetting_AccessToken{}
-	for _, userAccessToken := range userAccessTokens {
-		if userAccessToken.AccessToken == request.AccessToken {
-			continue
-		}
-		updatedUserAccessTokens = append(updatedUserAccessTokens, userAccessToken)
-	}
-	if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
-		UserId: currentUser.ID,
-		Key:    storepb.UserSettingKey_ACCESS_TOKENS,
-		Value: &storepb.UserSetting_AccessTokens{
-			AccessTokens: &storepb.AccessTokensUserSetting{
-				AccessTokens: updatedUserAccessTokens,
-			},
-		},
-	}); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+	if err := s.Store.RemoveUserAccessToken(ctx, currentUser.ID, request.AccessToken); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to remove access token: %v", err)
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
-func (s *APIV1Service) UpsertAccessTokenToStore(ctx context.Context, user *store.User, accessToken, description string) error {
-	userAccessTokens, err := s.Store.GetUserAccessTokens(ctx, user.ID)
-	if err != nil {
-		return errors.Wrap(err, "failed to get user access tokens")
-	}
-	userAccessToken := storepb.AccessTokensUserSetting_AccessToken{
-		AccessToken: accessToken,
+// UpsertAccessTokenToStore saves the hash of the access token with its metadata to the user setting store.
+func (s *APIV1Service) UpsertAccessTokenToStore(ctx context.Context, user *store.User, accessToken, description string, scopes []string, expireTime time.Time) (*storepb.AccessTokensUserSetting_AccessToken, error) {
+	userAccessToken := &storepb.AccessTokensUserSetting_AccessToken{
+		TokenHash:   store.HashAccessToken(accessToken),
 		Description: description,
+		Scopes:      scopes,
+		CreateTime:  timestamppb.Now(),
 	}
-	userAccessTokens = append(userAccessTokens, &userAccessToken)
-	if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
-		UserId: user.ID,
-		Key:    storepb.UserSettingKey_ACCESS_TOKENS,
-		Value: &storepb.UserSetting_AccessTokens{
-			AccessTokens: &storepb.AccessTokensUserSetting{
-				AccessTokens: userAccessTokens,
-			},
-		},
-	}); err != nil {
-		return errors.Wrap(err, "failed to upsert user setting")
+	if !expireTime.IsZero() {
+		userAccessToken.ExpireTime = timestamppb.New(expireTime)
 	}
-	return nil
+	if err := s.Store.AddUserAccessToken(ctx, user.ID, userAccessToken); err != nil {
+		return nil, errors.Wrap(err, "failed to add user access token")
+	}
+	return userAccessToken, nil
+}
+
+func convertUserAccessTokenFromStore(userAccessToken *storepb.AccessTokensUserSetting_AccessToken) *v1pb.UserAccessToken {
+	accessToken := &v1pb.UserAccessToken{
+		Id:          store.AccessTokenID(userAccessToken),
+		Description: userAccessToken.Description,
+		IssuedAt:    userAccessToken.CreateTime,
+		ExpiresAt:   userAccessToken.ExpireTime,
+		LastUsedAt:  userAccessToken.LastUsedTime,
+		LastUsedIp:  userAccessToken.LastUsedIp,
+	}
+	for _, scope := range userAccessToken.Scopes {
+		if value, ok := accessTokenScopeFromStore[store.AccessTokenScope(scope)]; ok {
+			accessToken.Scopes = append(accessToken.Scopes, value)
+		}
+	}
+	return accessToken
+}
+
+var accessTokenScopeFromStore = map[store.AccessTokenScope]v1pb.UserAccessToken_Scope{
+	store.AccessTokenScopeRead:         v1pb.UserAccessToken_READ,
+	store.AccessTokenScopeMemosWrite:   v1pb.UserAccessToken_MEMOS_WRITE,
+	store.AccessTokenScopeTicketsWrite: v1pb.UserAccessToken_TICKETS_WRITE,
+	store.AccessTokenScopeWebhooks:     v1pb.UserAccessToken_WEBHOOKS,
+}
+
+func convertAccessTokenScopesToStore(scopes []v1pb.UserAccessToken_Scope) ([]string, error) {
+	result := []string{}
+	for _, scope := range scopes {
+		var value store.AccessTokenScope
+		for storeScope, scopepb := range accessTokenScopeFromStore {
+			if scopepb == scope {
+				value = storeScope
+			}
+		}
+		if value == "" {
+			return nil, errors.Errorf("unsupported scope %s", scope)
+		}
+		if !slices.Contains(result, string(value)) {
+			result = append(result, string(value))
+		}
+	}
+	return result, nil
 }
 
 func convertUserFromStore(user *store.User) *v1pb.User {
@@ -566,6 +592,15 @@ func convertUserRoleFromStore(role store.Role) v1pb.User_Role {
 	}
 }
 
+// checkRoleGrantable checks that the role is not above the role of the current user, so that only hosts
+// can make other users hosts.
+func checkRoleGrantable(currentUser *store.User, role store.Role) error {
+	if currentUser == nil || role.Rank() > currentUser.Role.Rank() {
+		return status.Errorf(codes.PermissionDenied, "permission denied: cannot grant the %s role", role)
+	}
+	return nil
+}
+
 func convertUserRoleToStore(role v1pb.User_Role) store.Role {
 	switch role {
 	case v1pb.User_HOST:
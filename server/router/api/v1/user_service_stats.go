@@ -34,17 +34,16 @@ func (s *APIV1Service) ListAllUserStats(ctx context.Context, _ *v1pb.ListAllUser
 	}
 	if currentUser == nil {
 		memoFind.VisibilityList = []store.Visibility{store.Public}
-	} else {
-		if memoFind.CreatorID == nil {
-			internalFilter := fmt.Sprintf(`creator_id == %d || visibility in ["PUBLIC", "PROTECTED"]`, currentUser.ID)
-			if memoFind.Filter != nil {
-				filter := fmt.Sprintf("(%s) && (%s)", *memoFind.Filter, internalFilter)
-				memoFind.Filter = &filter
-			} else {
-				memoFind.Filter = &internalFilter
-			}
-		} else if *memoFind.CreatorID != currentUser.ID {
-			memoFind.VisibilityList = []store.Visibility{store.Public, store.Protected}
+	} else if memoFind.CreatorID == nil || *memoFind.CreatorID != currentUser.ID {
+		internalFilter, err := s.buildMemoVisibilityFilter(ctx, currentUser, memoFind.CreatorID == nil)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to build memo visibility filter: %v", err)
+		}
+		if memoFind.Filter != nil {
+			filter := fmt.Sprintf("(%s) && (%s)", *memoFind.Filter, internalFilter)
+			memoFind.Filter = &filter
+		} else {
+			memoFind.Filter = &internalFilter
 		}
 	}
 	memos, err := s.Store.ListMemos(ctx, memoFind)
@@ -125,7 +124,7 @@ func (s *APIV1Service) GetUserStats(ctx context.Context, request *v1pb.GetUserSt
 	if currentUser != nil {
 		visibilities = append(visibilities, store.Protected)
 		if currentUser.ID == user.ID {
-			visibilities = append(visibilities, store.Private)
+			visibilities = append(visibilities, store.Private, store.ProjectMembers)
 		}
 	}
 	memoFind.VisibilityList = visibilities
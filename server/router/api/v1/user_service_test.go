@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestUserRoleEscalation(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	host := createTestingUser(ctx, t, s, "host", store.RoleHost)
+	admin := createTestingUser(ctx, t, s, "admin", store.RoleAdmin)
+	user := createTestingUser(ctx, t, s, "user", store.RoleUser)
+	adminCtx := withTestingUser(ctx, admin)
+
+	// An admin cannot create a host.
+	_, err := s.CreateUser(adminCtx, &v1pb.CreateUserRequest{
+		User: &v1pb.User{Username: "newhost", Role: v1pb.User_HOST, Password: "password"},
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// An admin cannot promote a user, nor themselves, to host.
+	for _, target := range []*store.User{user, admin} {
+		_, err = s.UpdateUser(adminCtx, &v1pb.UpdateUserRequest{
+			User:       &v1pb.User{Name: fmt.Sprintf("%s%d", UserNamePrefix, target.ID), Role: v1pb.User_HOST},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"role"}},
+		})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	}
+
+	// An admin cannot demote a host.
+	_, err = s.UpdateUser(adminCtx, &v1pb.UpdateUserRequest{
+		User:       &v1pb.User{Name: fmt.Sprintf("%s%d", UserNamePrefix, host.ID), Role: v1pb.User_USER},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"role"}},
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// An admin can still grant the roles up to their own.
+	created, err := s.CreateUser(adminCtx, &v1pb.CreateUserRequest{
+		User: &v1pb.User{Username: "newadmin", Role: v1pb.User_ADMIN, Password: "password"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, v1pb.User_ADMIN, created.Role)
+	updated, err := s.UpdateUser(adminCtx, &v1pb.UpdateUserRequest{
+		User:       &v1pb.User{Name: fmt.Sprintf("%s%d", UserNamePrefix, user.ID), Role: v1pb.User_ADMIN},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"role"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, v1pb.User_ADMIN, updated.Role)
+
+	// A host can grant the host role.
+	created, err = s.CreateUser(withTestingUser(ctx, host), &v1pb.CreateUserRequest{
+		User: &v1pb.User{Username: "newhost", Role: v1pb.User_HOST, Password: "password"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, v1pb.User_HOST, created.Role)
+}
+
+func TestUserManageAboveOwnRole(t *testing.T) {
+	ctx := context.Background()
+	s := newTestingService(ctx, t)
+	host := createTestingUser(ctx, t, s, "host", store.RoleHost)
+	admin := createTestingUser(ctx, t, s, "admin", store.RoleAdmin)
+	manager := createTestingUser(ctx, t, s, "manager", store.RoleUser)
+	role, err := s.Store.CreateCustomRole(ctx, &store.CustomRole{Name: "manager", Permissions: []store.Permission{store.PermissionUserManage}})
+	require.NoError(t, err)
+	require.NoError(t, s.Store.SetUserCustomRoles(ctx, manager.ID, []int32{role.ID}))
+	hostName := fmt.Sprintf("%s%d", UserNamePrefix, host.ID)
+
+	// Neither an admin nor a user manager can update or delete a host.
+	for _, currentUser := range []*store.User{admin, manager} {
+		for _, path := range []string{"password", "username", "email", "state"} {
+			_, err = s.UpdateUser(withTestingUser(ctx, currentUser), &v1pb.UpdateUserRequest{
+				User:       &v1pb.User{Name: hostName, Username: "renamed", Email: "renamed@example.com", Password: "password", State: v1pb.State_ARCHIVED},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{path}},
+			})
+			require.Equal(t, codes.PermissionDenied, status.Code(err))
+		}
+		_, err = s.DeleteUser(withTestingUser(ctx, currentUser), &v1pb.DeleteUserRequest{Name: hostName})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &host.ID})
+	require.NoError(t, err)
+	require.Equal(t, "host", user.Username)
+
+	// A user manager can delete a user, together with their role assignments.
+	_, err = s.DeleteUser(withTestingUser(ctx, admin), &v1pb.DeleteUserRequest{Name: fmt.Sprintf("%s%d", UserNamePrefix, manager.ID)})
+	require.NoError(t, err)
+	roles, err := s.Store.ListUserCustomRoles(ctx, manager.ID)
+	require.NoError(t, err)
+	require.Empty(t, roles)
+}
@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"github.com/labstack/echo/v4"
@@ -20,6 +19,7 @@ import (
 	"github.com/usememos/memos/internal/profile"
 	"github.com/usememos/memos/internal/util"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/server/runner/resourceprocess"
 	"github.com/usememos/memos/store"
 )
 
@@ -38,21 +38,40 @@ type APIV1Service struct {
 	v1pb.UnimplementedWebhookServiceServer
 	v1pb.UnimplementedMarkdownServiceServer
 	v1pb.UnimplementedIdentityProviderServiceServer
+	v1pb.UnimplementedRoleServiceServer
+	v1pb.UnimplementedAnalyticsServiceServer
+	v1pb.UnimplementedTicketViewServiceServer
 
 	Secret  string
 	Profile *profile.Profile
 	Store   *store.Store
 
 	grpcServer *grpc.Server
+	// ssoAuthorizations are the pending SSO sign ins started by CreateSSOAuthorization.
+	ssoAuthorizations *oneTimeStore[*ssoAuthorization]
+	// twoFactorChallenges are the sign ins waiting for a second factor.
+	twoFactorChallenges *oneTimeStore[*twoFactorChallenge]
+	// rateLimiter is shared with the rate limit interceptor of the gRPC server.
+	rateLimiter *RateLimiter
+	// uploadLocks are the resumable uploads receiving content.
+	uploadLocks *uploadLocks
+	// resourceProcessor processes the created resources in the background.
+	resourceProcessor *resourceprocess.Runner
 }
 
-func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store, grpcServer *grpc.Server) *APIV1Service {
+func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store, grpcServer *grpc.Server, rateLimiter *RateLimiter, resourceProcessor *resourceprocess.Runner) *APIV1Service {
 	grpc.EnableTracing = true
 	apiv1Service := &APIV1Service{
 		Secret:     secret,
 		Profile:    profile,
 		Store:      store,
 		grpcServer: grpcServer,
+
+		ssoAuthorizations:   newOneTimeStore[*ssoAuthorization](ssoAuthorizationDuration),
+		twoFactorChallenges: newOneTimeStore[*twoFactorChallenge](twoFactorChallengeDuration),
+		rateLimiter:         rateLimiter,
+		uploadLocks:         &uploadLocks{locked: map[string]bool{}},
+		resourceProcessor:   resourceProcessor,
 	}
 	grpc_health_v1.RegisterHealthServer(grpcServer, apiv1Service)
 	v1pb.RegisterWorkspaceServiceServer(grpcServer, apiv1Service)
@@ -67,6 +86,9 @@ func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store
 	v1pb.RegisterWebhookServiceServer(grpcServer, apiv1Service)
 	v1pb.RegisterMarkdownServiceServer(grpcServer, apiv1Service)
 	v1pb.RegisterIdentityProviderServiceServer(grpcServer, apiv1Service)
+	v1pb.RegisterRoleServiceServer(grpcServer, apiv1Service)
+	v1pb.RegisterAnalyticsServiceServer(grpcServer, apiv1Service)
+	v1pb.RegisterTicketViewServiceServer(grpcServer, apiv1Service)
 	reflection.Register(grpcServer)
 	return apiv1Service
 }
@@ -88,7 +110,7 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 		return err
 	}
 
-	gwMux := runtime.NewServeMux()
+	gwMux := runtime.NewServeMux(runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher))
 	if err := v1pb.RegisterWorkspaceServiceHandler(ctx, gwMux, conn); err != nil {
 		return err
 	}
@@ -125,15 +147,32 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 	if err := v1pb.RegisterIdentityProviderServiceHandler(ctx, gwMux, conn); err != nil {
 		return err
 	}
+	if err := v1pb.RegisterRoleServiceHandler(ctx, gwMux, conn); err != nil {
+		return err
+	}
+	if err := v1pb.RegisterAnalyticsServiceHandler(ctx, gwMux, conn); err != nil {
+		return err
+	}
+	if err := v1pb.RegisterTicketViewServiceHandler(ctx, gwMux, conn); err != nil {
+		return err
+	}
 	gwGroup := echoServer.Group("")
 	gwGroup.Use(middleware.CORS())
 
 	// Register ticket routes directly to Echo group with Auth middleware
 	// Register these BEFORE the gRPC-gateway Any wildcard to ensure they take precedence
 	ticketGroup := echoServer.Group("/api/v1")
-	ticketGroup.Use(s.AuthMiddleware)
+	ticketGroup.Use(s.AuthMiddleware, s.RateLimitMiddleware)
 	s.RegisterTicketRoutes(ticketGroup)
 	s.RegisterNotificationRoutes(ticketGroup)
+	s.RegisterProjectRoutes(ticketGroup)
+	s.RegisterUploadRoutes(ticketGroup)
+	echoServer.OPTIONS("/api/v1/uploads", s.handleUploadOptions)
+
+	// Stream resource binaries with range support. It takes precedence over the GetResourceBinary gateway route.
+	echoServer.GET("/file/resources/:uid/:filename", s.handleGetResourceBinary, middleware.CORS(), s.OptionalAuthMiddleware, s.RateLimitMiddleware)
+	echoServer.HEAD("/file/resources/:uid/:filename", s.handleGetResourceBinary, middleware.CORS(), s.OptionalAuthMiddleware, s.RateLimitMiddleware)
+	echoServer.GET(linkPreviewImagePath, s.handleGetLinkPreviewImage, middleware.CORS(), s.RateLimitMiddleware)
 
 	handler := echo.WrapHandler(gwMux)
 	gwGroup.Any("/api/v1/*", handler)
@@ -158,13 +197,37 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 		}
 		s.NotificationStreamHandler(c.Response().Writer, c.Request(), userID)
 		return nil
-	}, s.AuthMiddleware)
+	}, s.AuthMiddleware, s.RateLimitMiddleware)
 
 	return nil
 }
 
+// outgoingHeaderMatcher forwards the Retry-After header as is, and the other metadata with the default prefix.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == "retry-after" {
+		return "Retry-After", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
 func (s *APIV1Service) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return s.authMiddleware(next, false)
+}
+
+// OptionalAuthMiddleware authenticates the request like AuthMiddleware, but lets the requests without
+// valid credentials through anonymously.
+func (s *APIV1Service) OptionalAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return s.authMiddleware(next, true)
+}
+
+func (s *APIV1Service) authMiddleware(next echo.HandlerFunc, optional bool) echo.HandlerFunc {
 	return func(c echo.Context) error {
+		unauthorized := func(message string) error {
+			if optional {
+				return next(c)
+			}
+			return echo.NewHTTPError(http.StatusUnauthorized, message)
+		}
 		ctx := c.Request().Context()
 		accessToken := ""
 
@@ -181,32 +244,30 @@ func (s *APIV1Service) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			if err == nil {
 				accessToken = cookie.Value
 			}
+			// Browsers refresh the session transparently when the access token cookie has expired.
+			if _, err := ParseAccessToken(accessToken, []byte(s.Secret)); err != nil {
+				if cookie, err := c.Cookie(RefreshTokenCookieName); err == nil && cookie.Value != "" {
+					if tokens, err := refreshSession(ctx, s.Store, s.Secret, cookie.Value, c.RealIP(), c.Request().UserAgent()); err == nil {
+						setEchoSessionCookies(c, tokens)
+						accessToken = tokens.AccessToken
+					}
+				}
+			}
 		}
 
 		if accessToken == "" {
-			return echo.NewHTTPError(http.StatusUnauthorized, "Missing access token")
+			return unauthorized("Missing access token")
 		}
 
 		// Validate token
-		claims := &ClaimsMessage{}
-		_, err := jwt.ParseWithClaims(accessToken, claims, func(t *jwt.Token) (any, error) {
-			if t.Method.Alg() != jwt.SigningMethodHS256.Name {
-				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-			}
-			if kid, ok := t.Header["kid"].(string); ok {
-				if kid == KeyID {
-					return []byte(s.Secret), nil
-				}
-			}
-			return nil, fmt.Errorf("unexpected kid: %v", t.Header["kid"])
-		})
+		claims, err := ParseAccessToken(accessToken, []byte(s.Secret))
 		if err != nil {
-			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
+			return unauthorized("Invalid or expired token")
 		}
 
 		userID, err := util.ConvertStringToInt32(claims.Subject)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token subject")
+			return unauthorized("Invalid token subject")
 		}
 
 		// Get user to ensure exists and active
@@ -215,26 +276,29 @@ func (s *APIV1Service) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user").SetInternal(err)
 		}
 		if user == nil {
-			return echo.NewHTTPError(http.StatusUnauthorized, "User not found")
+			return unauthorized("User not found")
 		}
 		if user.RowStatus == store.Archived {
-			return echo.NewHTTPError(http.StatusUnauthorized, "User is archived")
+			return unauthorized("User is archived")
 		}
 
-		// Validate token against DB tokens
-		accessTokens, err := s.Store.GetUserAccessTokens(ctx, user.ID)
+		// Validate token against the session or the stored access tokens
+		grant, err := authorizeAccessToken(ctx, s.Store, user, accessToken, claims, c.RealIP(), c.Request().UserAgent())
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user access tokens").SetInternal(err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to authorize access token").SetInternal(err)
 		}
-		isValid := false
-		for _, t := range accessTokens {
-			if t.AccessToken == accessToken {
-				isValid = true
-				break
-			}
+		if grant == nil {
+			return unauthorized("Token revoked or invalid")
+		}
+		if !isAccessTokenScopeAllowedRoute(c.Request().Method, c.Path(), grant.Scopes) {
+			return echo.NewHTTPError(http.StatusForbidden, "Access token scope does not allow this request")
+		}
+		enrollmentRequired, err := s.Store.IsTwoFactorEnrollmentRequired(ctx, user)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check two-factor authentication").SetInternal(err)
 		}
-		if !isValid {
-			return echo.NewHTTPError(http.StatusUnauthorized, "Token revoked or invalid")
+		if enrollmentRequired {
+			return echo.NewHTTPError(http.StatusForbidden, "Two-factor authentication must be enabled")
 		}
 
 		c.Set(getUserIDContextKey(), userID)
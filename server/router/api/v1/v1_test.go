@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+// newTestingService returns an API service backed by a testing store.
+func newTestingService(ctx context.Context, t *testing.T) *APIV1Service {
+	stores := teststore.NewTestingStore(ctx, t)
+	t.Cleanup(func() {
+		stores.Close()
+	})
+	testingProfile := &profile.Profile{Mode: "dev"}
+	return NewAPIV1Service("test-secret", testingProfile, stores, grpc.NewServer(), NewRateLimiter(stores, testingProfile), nil)
+}
+
+// createTestingUser creates a user with the role.
+func createTestingUser(ctx context.Context, t *testing.T, s *APIV1Service, username string, role store.Role) *store.User {
+	user, err := s.Store.CreateUser(ctx, &store.User{
+		Username:     username,
+		Role:         role,
+		Email:        username + "@example.com",
+		Nickname:     username,
+		PasswordHash: "hash",
+	})
+	require.NoError(t, err)
+	return user
+}
+
+// withTestingUser returns the context of a gRPC request authenticated as the user.
+func withTestingUser(ctx context.Context, user *store.User) context.Context {
+	return context.WithValue(ctx, usernameContextKey, user.Username)
+}
+
+// createTestingProject creates a project with the users as its members.
+func createTestingProject(ctx context.Context, t *testing.T, s *APIV1Service, key string, members ...*store.User) *store.Project {
+	project, err := s.Store.CreateProject(ctx, &store.Project{Key: key, Name: key})
+	require.NoError(t, err)
+	for _, member := range members {
+		_, err := s.Store.UpsertProjectMember(ctx, &store.ProjectMember{ProjectID: project.ID, UserID: member.ID, Role: store.ProjectMemberRoleMember})
+		require.NoError(t, err)
+	}
+	return project
+}
+
+// createTestingMemo creates a memo of the user, in the project when it is not nil.
+func createTestingMemo(ctx context.Context, t *testing.T, s *APIV1Service, user *store.User, content string, visibility store.Visibility, project *store.Project) *store.Memo {
+	create := &store.Memo{
+		UID:        shortuuid.New(),
+		CreatorID:  user.ID,
+		Content:    content,
+		Visibility: visibility,
+	}
+	if project != nil {
+		create.ProjectID = &project.ID
+	}
+	memo, err := s.Store.CreateMemo(ctx, create)
+	require.NoError(t, err)
+	return memo
+}
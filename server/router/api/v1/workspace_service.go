@@ -2,13 +2,18 @@ package v1
 
 import (
 	"context"
+	"log/slog"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/backup"
 )
 
 func (s *APIV1Service) GetWorkspaceProfile(ctx context.Context, _ *v1pb.GetWorkspaceProfileRequest) (*v1pb.WorkspaceProfile, error) {
@@ -27,6 +32,75 @@ func (s *APIV1Service) GetWorkspaceProfile(ctx context.Context, _ *v1pb.GetWorks
 	return workspaceProfile, nil
 }
 
+func (s *APIV1Service) CreateWorkspaceBackup(ctx context.Context, _ *v1pb.CreateWorkspaceBackupRequest) (*v1pb.WorkspaceBackup, error) {
+	if s.Profile.Driver != "sqlite" {
+		return nil, status.Errorf(codes.Unimplemented, "backups are not supported for driver %s", s.Profile.Driver)
+	}
+	workspaceBackup, err := backup.NewManager(s.Store, s.Profile).Create(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create backup: %v", err)
+	}
+	return convertWorkspaceBackupFromStore(workspaceBackup), nil
+}
+
+func (s *APIV1Service) ListWorkspaceBackups(ctx context.Context, _ *v1pb.ListWorkspaceBackupsRequest) (*v1pb.ListWorkspaceBackupsResponse, error) {
+	backups, err := backup.NewManager(s.Store, s.Profile).List()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list backups: %v", err)
+	}
+	response := &v1pb.ListWorkspaceBackupsResponse{
+		Backups: []*v1pb.WorkspaceBackup{},
+	}
+	for _, workspaceBackup := range backups {
+		response.Backups = append(response.Backups, convertWorkspaceBackupFromStore(workspaceBackup))
+	}
+	return response, nil
+}
+
+func convertWorkspaceBackupFromStore(workspaceBackup *backup.Backup) *v1pb.WorkspaceBackup {
+	return &v1pb.WorkspaceBackup{
+		Name:       workspaceBackup.Name,
+		Size:       workspaceBackup.Size,
+		CreateTime: timestamppb.New(time.Unix(workspaceBackup.CreatedTs, 0)),
+	}
+}
+
+func (s *APIV1Service) StartStorageMigration(ctx context.Context, _ *v1pb.StartStorageMigrationRequest) (*v1pb.StorageMigration, error) {
+	migration, err := s.Store.StartStorageMigration(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to start storage migration: %v", err)
+	}
+	// The migration outlives the request, and saves its progress for GetStorageMigration.
+	go func() {
+		if err := s.Store.RunStorageMigration(context.WithoutCancel(ctx)); err != nil {
+			slog.Error("Failed to run storage migration", slog.Any("err", err))
+		}
+	}()
+	return convertStorageMigrationFromStore(migration), nil
+}
+
+func (s *APIV1Service) GetStorageMigration(ctx context.Context, _ *v1pb.GetStorageMigrationRequest) (*v1pb.StorageMigration, error) {
+	migration, err := s.Store.GetWorkspaceStorageMigrationSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get storage migration: %v", err)
+	}
+	return convertStorageMigrationFromStore(migration), nil
+}
+
+func convertStorageMigrationFromStore(migration *storepb.WorkspaceStorageMigrationSetting) *v1pb.StorageMigration {
+	return &v1pb.StorageMigration{
+		State:             v1pb.StorageMigration_State(migration.State),
+		TargetStorageType: v1pb.WorkspaceStorageSetting_StorageType(migration.TargetStorageType),
+		Total:             migration.Total,
+		Processed:         migration.Processed,
+		Migrated:          migration.Migrated,
+		Failed:            migration.Failed,
+		LastError:         migration.LastError,
+		StartTime:         migration.StartTime,
+		FinishTime:        migration.FinishTime,
+	}
+}
+
 var ownerCache *v1pb.User
 
 func (s *APIV1Service) GetInstanceOwner(ctx context.Context) (*v1pb.User, error) {
@@ -29,6 +29,8 @@ func (s *APIV1Service) GetWorkspaceSetting(ctx context.Context, request *v1pb.Ge
 		_, err = s.Store.GetWorkspaceMemoRelatedSetting(ctx)
 	case storepb.WorkspaceSettingKey_STORAGE:
 		_, err = s.Store.GetWorkspaceStorageSetting(ctx)
+	case storepb.WorkspaceSettingKey_RATE_LIMIT:
+		_, err = s.Store.GetWorkspaceRateLimitSetting(ctx)
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unsupported workspace setting key: %v", workspaceSettingKey)
 	}
@@ -46,14 +48,10 @@ func (s *APIV1Service) GetWorkspaceSetting(ctx context.Context, request *v1pb.Ge
 		return nil, status.Errorf(codes.NotFound, "workspace setting not found")
 	}
 
-	// For storage setting, only host can get it.
-	if workspaceSetting.Key == storepb.WorkspaceSettingKey_STORAGE {
-		user, err := s.GetCurrentUser(ctx)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
-		}
-		if user == nil || user.Role != store.RoleHost {
-			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	// For storage and rate limit settings, only workspace setting managers can get them.
+	if workspaceSetting.Key == storepb.WorkspaceSettingKey_STORAGE || workspaceSetting.Key == storepb.WorkspaceSettingKey_RATE_LIMIT {
+		if err := s.checkPermission(ctx, store.PermissionWorkspaceSettingManage); err != nil {
+			return nil, err
 		}
 	}
 
@@ -61,14 +59,6 @@ func (s *APIV1Service) GetWorkspaceSetting(ctx context.Context, request *v1pb.Ge
 }
 
 func (s *APIV1Service) SetWorkspaceSetting(ctx context.Context, request *v1pb.SetWorkspaceSettingRequest) (*v1pb.WorkspaceSetting, error) {
-	user, err := s.GetCurrentUser(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
-	}
-	if user.Role != store.RoleHost {
-		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
-	}
-
 	updateSetting := convertWorkspaceSettingToStore(request.Setting)
 	workspaceSetting, err := s.Store.UpsertWorkspaceSetting(ctx, updateSetting)
 	if err != nil {
@@ -95,6 +85,10 @@ func convertWorkspaceSettingFromStore(setting *storepb.WorkspaceSetting) *v1pb.W
 		workspaceSetting.Value = &v1pb.WorkspaceSetting_MemoRelatedSetting{
 			MemoRelatedSetting: convertWorkspaceMemoRelatedSettingFromStore(setting.GetMemoRelatedSetting()),
 		}
+	case *storepb.WorkspaceSetting_RateLimitSetting:
+		workspaceSetting.Value = &v1pb.WorkspaceSetting_RateLimitSetting{
+			RateLimitSetting: convertWorkspaceRateLimitSettingFromStore(setting.GetRateLimitSetting()),
+		}
 	}
 	return workspaceSetting
 }
@@ -120,6 +114,10 @@ func convertWorkspaceSettingToStore(setting *v1pb.WorkspaceSetting) *storepb.Wor
 		workspaceSetting.Value = &storepb.WorkspaceSetting_MemoRelatedSetting{
 			MemoRelatedSetting: convertWorkspaceMemoRelatedSettingToStore(setting.GetMemoRelatedSetting()),
 		}
+	case storepb.WorkspaceSettingKey_RATE_LIMIT:
+		workspaceSetting.Value = &storepb.WorkspaceSetting_RateLimitSetting{
+			RateLimitSetting: convertWorkspaceRateLimitSettingToStore(setting.GetRateLimitSetting()),
+		}
 	}
 	return workspaceSetting
 }
@@ -129,13 +127,14 @@ func convertWorkspaceGeneralSettingFromStore(setting *storepb.WorkspaceGeneralSe
 		return nil
 	}
 	generalSetting := &v1pb.WorkspaceGeneralSetting{
-		DisallowUserRegistration: setting.DisallowUserRegistration,
-		DisallowPasswordAuth:     setting.DisallowPasswordAuth,
-		AdditionalScript:         setting.AdditionalScript,
-		AdditionalStyle:          setting.AdditionalStyle,
-		WeekStartDayOffset:       setting.WeekStartDayOffset,
-		DisallowChangeUsername:   setting.DisallowChangeUsername,
-		DisallowChangeNickname:   setting.DisallowChangeNickname,
+		DisallowUserRegistration:  setting.DisallowUserRegistration,
+		DisallowPasswordAuth:      setting.DisallowPasswordAuth,
+		AdditionalScript:          setting.AdditionalScript,
+		AdditionalStyle:           setting.AdditionalStyle,
+		WeekStartDayOffset:        setting.WeekStartDayOffset,
+		DisallowChangeUsername:    setting.DisallowChangeUsername,
+		DisallowChangeNickname:    setting.DisallowChangeNickname,
+		RequireTwoFactorForAdmins: setting.RequireTwoFactorForAdmins,
 	}
 	if setting.CustomProfile != nil {
 		generalSetting.CustomProfile = &v1pb.WorkspaceCustomProfile{
@@ -154,13 +153,14 @@ func convertWorkspaceGeneralSettingToStore(setting *v1pb.WorkspaceGeneralSetting
 		return nil
 	}
 	generalSetting := &storepb.WorkspaceGeneralSetting{
-		DisallowUserRegistration: setting.DisallowUserRegistration,
-		DisallowPasswordAuth:     setting.DisallowPasswordAuth,
-		AdditionalScript:         setting.AdditionalScript,
-		AdditionalStyle:          setting.AdditionalStyle,
-		WeekStartDayOffset:       setting.WeekStartDayOffset,
-		DisallowChangeUsername:   setting.DisallowChangeUsername,
-		DisallowChangeNickname:   setting.DisallowChangeNickname,
+		DisallowUserRegistration:  setting.DisallowUserRegistration,
+		DisallowPasswordAuth:      setting.DisallowPasswordAuth,
+		AdditionalScript:          setting.AdditionalScript,
+		AdditionalStyle:           setting.AdditionalStyle,
+		WeekStartDayOffset:        setting.WeekStartDayOffset,
+		DisallowChangeUsername:    setting.DisallowChangeUsername,
+		DisallowChangeNickname:    setting.DisallowChangeNickname,
+		RequireTwoFactorForAdmins: setting.RequireTwoFactorForAdmins,
 	}
 	if setting.CustomProfile != nil {
 		generalSetting.CustomProfile = &storepb.WorkspaceCustomProfile{
@@ -179,9 +179,11 @@ func convertWorkspaceStorageSettingFromStore(settingpb *storepb.WorkspaceStorage
 		return nil
 	}
 	setting := &v1pb.WorkspaceStorageSetting{
-		StorageType:       v1pb.WorkspaceStorageSetting_StorageType(settingpb.StorageType),
-		FilepathTemplate:  settingpb.FilepathTemplate,
-		UploadSizeLimitMb: settingpb.UploadSizeLimitMb,
+		StorageType:          v1pb.WorkspaceStorageSetting_StorageType(settingpb.StorageType),
+		FilepathTemplate:     settingpb.FilepathTemplate,
+		UploadSizeLimitMb:    settingpb.UploadSizeLimitMb,
+		ContentAddressed:     settingpb.ContentAddressed,
+		KeepLocationMetadata: settingpb.KeepLocationMetadata,
 	}
 	if settingpb.S3Config != nil {
 		setting.S3Config = &v1pb.WorkspaceStorageSetting_S3Config{
@@ -193,6 +195,13 @@ func convertWorkspaceStorageSettingFromStore(settingpb *storepb.WorkspaceStorage
 			UsePathStyle:    settingpb.S3Config.UsePathStyle,
 		}
 	}
+	if settingpb.WebdavConfig != nil {
+		setting.WebdavConfig = &v1pb.WorkspaceStorageSetting_WebDAVConfig{
+			Url:      settingpb.WebdavConfig.Url,
+			Username: settingpb.WebdavConfig.Username,
+			Password: settingpb.WebdavConfig.Password,
+		}
+	}
 	return setting
 }
 
@@ -201,9 +210,11 @@ func convertWorkspaceStorageSettingToStore(setting *v1pb.WorkspaceStorageSetting
 		return nil
 	}
 	settingpb := &storepb.WorkspaceStorageSetting{
-		StorageType:       storepb.WorkspaceStorageSetting_StorageType(setting.StorageType),
-		FilepathTemplate:  setting.FilepathTemplate,
-		UploadSizeLimitMb: setting.UploadSizeLimitMb,
+		StorageType:          storepb.WorkspaceStorageSetting_StorageType(setting.StorageType),
+		FilepathTemplate:     setting.FilepathTemplate,
+		UploadSizeLimitMb:    setting.UploadSizeLimitMb,
+		ContentAddressed:     setting.ContentAddressed,
+		KeepLocationMetadata: setting.KeepLocationMetadata,
 	}
 	if setting.S3Config != nil {
 		settingpb.S3Config = &storepb.StorageS3Config{
@@ -215,6 +226,13 @@ func convertWorkspaceStorageSettingToStore(setting *v1pb.WorkspaceStorageSetting
 			UsePathStyle:    setting.S3Config.UsePathStyle,
 		}
 	}
+	if setting.WebdavConfig != nil {
+		settingpb.WebdavConfig = &storepb.StorageWebDAVConfig{
+			Url:      setting.WebdavConfig.Url,
+			Username: setting.WebdavConfig.Username,
+			Password: setting.WebdavConfig.Password,
+		}
+	}
 	return settingpb
 }
 
@@ -233,6 +251,7 @@ func convertWorkspaceMemoRelatedSettingFromStore(setting *storepb.WorkspaceMemoR
 		DisableMarkdownShortcuts: setting.DisableMarkdownShortcuts,
 		EnableBlurNsfwContent:    setting.EnableBlurNsfwContent,
 		NsfwTags:                 setting.NsfwTags,
+		ProxyLinkPreviewImages:   setting.ProxyLinkPreviewImages,
 	}
 }
 
@@ -251,5 +270,34 @@ func convertWorkspaceMemoRelatedSettingToStore(setting *v1pb.WorkspaceMemoRelate
 		DisableMarkdownShortcuts: setting.DisableMarkdownShortcuts,
 		EnableBlurNsfwContent:    setting.EnableBlurNsfwContent,
 		NsfwTags:                 setting.NsfwTags,
+		ProxyLinkPreviewImages:   setting.ProxyLinkPreviewImages,
+	}
+}
+
+func convertWorkspaceRateLimitSettingFromStore(setting *storepb.WorkspaceRateLimitSetting) *v1pb.WorkspaceRateLimitSetting {
+	if setting == nil {
+		return nil
+	}
+	return &v1pb.WorkspaceRateLimitSetting{
+		Disabled:                setting.Disabled,
+		RequestsPerMinute:       setting.RequestsPerMinute,
+		Burst:                   setting.Burst,
+		AuthRequestsPerMinute:   setting.AuthRequestsPerMinute,
+		MaxFailedSignInAttempts: setting.MaxFailedSignInAttempts,
+		LockoutDurationSeconds:  setting.LockoutDurationSeconds,
+	}
+}
+
+func convertWorkspaceRateLimitSettingToStore(setting *v1pb.WorkspaceRateLimitSetting) *storepb.WorkspaceRateLimitSetting {
+	if setting == nil {
+		return nil
+	}
+	return &storepb.WorkspaceRateLimitSetting{
+		Disabled:                setting.Disabled,
+		RequestsPerMinute:       setting.RequestsPerMinute,
+		Burst:                   setting.Burst,
+		AuthRequestsPerMinute:   setting.AuthRequestsPerMinute,
+		MaxFailedSignInAttempts: setting.MaxFailedSignInAttempts,
+		LockoutDurationSeconds:  setting.LockoutDurationSeconds,
 	}
 }
@@ -2,18 +2,17 @@ package rss
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/feeds"
 	"github.com/labstack/echo/v4"
-	"github.com/usememos/gomark"
-	"github.com/usememos/gomark/renderer"
 
 	"github.com/usememos/memos/internal/profile"
-	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/plugin/markdown"
+	apiv1 "github.com/usememos/memos/server/router/api/v1"
 	"github.com/usememos/memos/store"
 )
 
@@ -113,7 +112,7 @@ func (s *RSSService) generateRSSFromMemoList(ctx context.Context, memoList []*st
 	feed.Items = make([]*feeds.Item, itemCountLimit)
 	for i := 0; i < itemCountLimit; i++ {
 		memo := memoList[i]
-		description, err := getRSSItemDescription(memo.Content)
+		description, err := s.getRSSItemDescription(ctx, memo.Content, baseURL)
 		if err != nil {
 			return "", err
 		}
@@ -133,10 +132,9 @@ func (s *RSSService) generateRSSFromMemoList(ctx context.Context, memoList []*st
 		if len(resources) > 0 {
 			resource := resources[0]
 			enclosure := feeds.Enclosure{}
-			if resource.StorageType == storepb.ResourceStorageType_EXTERNAL || resource.StorageType == storepb.ResourceStorageType_S3 {
-				enclosure.Url = resource.Reference
-			} else {
-				enclosure.Url = fmt.Sprintf("%s/file/resources/%d/%s", baseURL, resource.ID, resource.Filename)
+			enclosure.Url = apiv1.ResourceURL(resource)
+			if strings.HasPrefix(enclosure.Url, "/") {
+				enclosure.Url = baseURL + enclosure.Url
 			}
 			enclosure.Length = strconv.Itoa(int(resource.Size))
 			enclosure.Type = resource.Type
@@ -151,13 +149,38 @@ func (s *RSSService) generateRSSFromMemoList(ctx context.Context, memoList []*st
 	return rss, nil
 }
 
-func getRSSItemDescription(content string) (string, error) {
-	nodes, err := gomark.Parse(content)
+func (s *RSSService) getRSSItemDescription(ctx context.Context, content, baseURL string) (string, error) {
+	nodes, err := markdown.Parse(content)
 	if err != nil {
 		return "", err
 	}
-	result := renderer.NewHTMLRenderer().Render(nodes)
-	return result, nil
+	options := markdown.Options{
+		InstanceURL: baseURL,
+		Resolve: func(name string) *markdown.Reference {
+			if !strings.HasPrefix(name, "resources/") {
+				return nil
+			}
+			resourceUID := strings.TrimPrefix(name, "resources/")
+			resource, err := s.Store.GetResource(ctx, &store.FindResource{UID: &resourceUID})
+			if err != nil || resource == nil {
+				return nil
+			}
+			// Only the resources of public memos are embedded in the feed.
+			if resource.MemoID == nil {
+				return nil
+			}
+			memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: resource.MemoID})
+			if err != nil || memo == nil || memo.Visibility != store.Public {
+				return nil
+			}
+			return &markdown.Reference{
+				URL:   apiv1.ResourceURL(resource),
+				Title: resource.Filename,
+				Type:  resource.Type,
+			}
+		},
+	}
+	return markdown.RenderHTML(nodes, options), nil
 }
 
 func getRSSHeading(ctx context.Context, stores *store.Store) (RSSHeading, error) {
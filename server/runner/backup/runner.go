@@ -0,0 +1,46 @@
+package backup
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/backup"
+)
+
+type Runner struct {
+	Manager  *backup.Manager
+	Interval time.Duration
+}
+
+func NewRunner(store *store.Store, profile *profile.Profile) *Runner {
+	return &Runner{
+		Manager:  backup.NewManager(store, profile),
+		Interval: profile.BackupInterval,
+	}
+}
+
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.RunOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Runner) RunOnce(ctx context.Context) {
+	backup, err := r.Manager.Create(ctx)
+	if err != nil {
+		slog.Error("failed to create backup", "error", err)
+		return
+	}
+	slog.Info("backup created", "name", backup.Name, "size", backup.Size)
+}
@@ -2,14 +2,23 @@ package memopayload
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"log/slog"
+	"net/url"
+	"regexp"
 	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/usememos/gomark/ast"
 	"github.com/usememos/gomark/parser"
 	"github.com/usememos/gomark/parser/tokenizer"
+	"github.com/usememos/gomark/restore"
 
+	"github.com/usememos/memos/plugin/markdown"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
@@ -50,7 +59,7 @@ func (r *Runner) RunOnce(ctx context.Context) {
 		// Process batch
 		batchSuccessCount := 0
 		for _, memo := range memos {
-			if err := RebuildMemoPayload(memo); err != nil {
+			if err := RebuildMemoPayload(ctx, r.Store, memo); err != nil {
 				slog.Error("failed to rebuild memo payload", "err", err, "memoID", memo.ID)
 				continue
 			}
@@ -61,6 +70,10 @@ func (r *Runner) RunOnce(ctx context.Context) {
 				slog.Error("failed to update memo", "err", err, "memoID", memo.ID)
 				continue
 			}
+			if err := SyncMemoRelations(ctx, r.Store, memo); err != nil {
+				slog.Error("failed to sync memo relations", "err", err, "memoID", memo.ID)
+				continue
+			}
 			batchSuccessCount++
 		}
 
@@ -72,7 +85,22 @@ func (r *Runner) RunOnce(ctx context.Context) {
 	}
 }
 
-func RebuildMemoPayload(memo *store.Memo) error {
+var (
+	// mentionRegexp matches the @username mentions in text, where the username can contain alphanumeric, underscore, dot and dash.
+	mentionRegexp = regexp.MustCompile(`@([a-zA-Z0-9_.-]+)`)
+	// ticketTagRegexp matches the #ticket-{id} tags referencing a ticket.
+	ticketTagRegexp = regexp.MustCompile(`^ticket-(\d+)$`)
+	// memoPathRegexp matches the paths of the memo pages, e.g. /m/{uid} and /memos/{uid}.
+	memoPathRegexp = regexp.MustCompile(`^/(?:m|memos)/([^/]+)$`)
+	// ticketPathRegexp matches the paths of the ticket pages, e.g. /tickets/{id} and /tickets/{key}.
+	ticketPathRegexp = regexp.MustCompile(`^/tickets/([^/]+)$`)
+)
+
+// RebuildMemoPayload rebuilds the calculated properties of the memo from its content.
+// The mentions, references and ticket references are resolved against the store, and the ones
+// that do not exist are dropped. Besides [[memos/{uid}]] references, links to the page of a memo
+// or a ticket are references too.
+func RebuildMemoPayload(ctx context.Context, stores *store.Store, memo *store.Memo) error {
 	nodes, err := parser.Parse(tokenizer.Tokenize(memo.Content))
 	if err != nil {
 		return errors.Wrap(err, "failed to parse content")
@@ -82,6 +110,9 @@ func RebuildMemoPayload(memo *store.Memo) error {
 		memo.Payload = &storepb.MemoPayload{}
 	}
 	tags := []string{}
+	usernames := []string{}
+	references := []string{}
+	ticketKeys := []string{}
 	property := &storepb.MemoPayload_Property{}
 	TraverseASTNodes(nodes, func(node ast.Node) {
 		switch n := node.(type) {
@@ -90,25 +121,273 @@ func RebuildMemoPayload(memo *store.Memo) error {
 			if !slices.Contains(tags, tag) {
 				tags = append(tags, tag)
 			}
-		case *ast.Link, *ast.AutoLink:
+			if matches := ticketTagRegexp.FindStringSubmatch(tag); matches != nil {
+				ticketKeys = appendUnique(ticketKeys, matches[1])
+			} else if _, _, ok := store.ParseTicketKey(tag); ok {
+				ticketKeys = appendUnique(ticketKeys, tag)
+			}
+		case *ast.Text:
+			for _, matches := range mentionRegexp.FindAllStringSubmatch(n.Content, -1) {
+				if !slices.Contains(usernames, matches[1]) {
+					usernames = append(usernames, matches[1])
+				}
+			}
+		case *ast.Link:
 			property.HasLink = true
-		case *ast.TaskListItem:
-			property.HasTaskList = true
-			if !n.Complete {
-				property.HasIncompleteTasks = true
+			appendLink(property, n.URL)
+			references, ticketKeys = appendLinkReference(references, ticketKeys, n.URL)
+		case *ast.AutoLink:
+			property.HasLink = true
+			appendLink(property, n.URL)
+			references, ticketKeys = appendLinkReference(references, ticketKeys, n.URL)
+		case *ast.Heading:
+			if heading := markdown.RenderPlainText(n.Children, markdown.Options{}); heading != "" {
+				property.Headings = append(property.Headings, heading)
 			}
 		case *ast.Code, *ast.CodeBlock:
 			property.HasCode = true
 		case *ast.EmbeddedContent:
-			// TODO: validate references.
-			property.References = append(property.References, n.ResourceName)
+			references = appendUnique(references, n.ResourceName)
+		case *ast.ReferencedContent:
+			references = appendUnique(references, n.ResourceName)
 		}
 	})
+
+	_, property.Tasks = ListTasks(nodes)
+	for _, task := range property.Tasks {
+		property.HasTaskList = true
+		property.TaskCount++
+		if task.Completed {
+			property.CompletedTaskCount++
+		} else {
+			property.HasIncompleteTasks = true
+		}
+	}
+
+	for _, username := range usernames {
+		user, err := findMentionedUser(ctx, stores, username)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find mentioned user %s", username)
+		}
+		if user != nil && !slices.Contains(property.Mentions, user.ID) {
+			property.Mentions = append(property.Mentions, user.ID)
+		}
+	}
+	for _, reference := range references {
+		exists, err := referenceExists(ctx, stores, reference)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find reference %s", reference)
+		}
+		if exists {
+			property.References = append(property.References, reference)
+		}
+	}
+	for _, key := range ticketKeys {
+		ticket, err := findReferencedTicket(ctx, stores, key)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find ticket %s", key)
+		}
+		if ticket != nil && !slices.Contains(property.TicketReferences, ticket.ID) {
+			property.TicketReferences = append(property.TicketReferences, ticket.ID)
+		}
+	}
 	memo.Payload.Tags = tags
 	memo.Payload.Property = property
 	return nil
 }
 
+// ListTasks returns the task list items of the nodes in document order, along with their tasks.
+// The id of a task is derived from its content, so it is stable while other parts of the content change.
+// Tasks with the same content are told apart by the order in which they occur.
+func ListTasks(nodes []ast.Node) ([]*ast.TaskListItem, []*storepb.MemoPayload_Task) {
+	items := []*ast.TaskListItem{}
+	tasks := []*storepb.MemoPayload_Task{}
+	occurrences := map[string]int{}
+	TraverseASTNodes(nodes, func(node ast.Node) {
+		item, ok := node.(*ast.TaskListItem)
+		if !ok {
+			return
+		}
+		content := restore.Restore(item.Children)
+		sum := sha256.Sum256([]byte(content))
+		id := hex.EncodeToString(sum[:4])
+		occurrences[id]++
+		if occurrence := occurrences[id]; occurrence > 1 {
+			id = fmt.Sprintf("%s-%d", id, occurrence)
+		}
+		items = append(items, item)
+		tasks = append(tasks, &storepb.MemoPayload_Task{
+			Id:        id,
+			Content:   content,
+			Completed: item.Complete,
+		})
+	})
+	return items, tasks
+}
+
+// FindTask returns the task list item with the id, or at the index when the id is empty.
+func FindTask(nodes []ast.Node, index int32, id string) (*ast.TaskListItem, *storepb.MemoPayload_Task, bool) {
+	items, tasks := ListTasks(nodes)
+	for i, task := range tasks {
+		if (id != "" && task.Id == id) || (id == "" && int32(i) == index) {
+			return items[i], task, true
+		}
+	}
+	return nil, nil, false
+}
+
+// SyncMemoRelations reconciles the reference relations of the memo with the memos referenced in its content.
+// The relations it creates are recorded in the content relations of the payload, and only those are deleted
+// once the reference is removed from the content. Relations that already exist, e.g. set with SetMemoRelations,
+// are left alone.
+func SyncMemoRelations(ctx context.Context, stores *store.Store, memo *store.Memo) error {
+	if memo.Payload == nil {
+		memo.Payload = &storepb.MemoPayload{}
+	}
+	referenceType := store.MemoRelationReference
+	previousContentRelations := memo.Payload.ContentRelations
+	contentRelations := []int32{}
+	for _, reference := range memo.Payload.GetProperty().GetReferences() {
+		relatedMemo, err := findReferencedMemo(ctx, stores, reference)
+		if err != nil {
+			return err
+		}
+		if relatedMemo == nil || relatedMemo.ID == memo.ID || slices.Contains(contentRelations, relatedMemo.ID) {
+			continue
+		}
+		if !slices.Contains(previousContentRelations, relatedMemo.ID) {
+			relations, err := stores.ListMemoRelations(ctx, &store.FindMemoRelation{
+				MemoID:        &memo.ID,
+				RelatedMemoID: &relatedMemo.ID,
+				Type:          &referenceType,
+			})
+			if err != nil {
+				return errors.Wrap(err, "failed to list memo relations")
+			}
+			if len(relations) > 0 {
+				continue
+			}
+		}
+		if _, err := stores.UpsertMemoRelation(ctx, &store.MemoRelation{
+			MemoID:        memo.ID,
+			RelatedMemoID: relatedMemo.ID,
+			Type:          referenceType,
+		}); err != nil {
+			return errors.Wrap(err, "failed to upsert memo relation")
+		}
+		contentRelations = append(contentRelations, relatedMemo.ID)
+	}
+	for _, relatedMemoID := range previousContentRelations {
+		if slices.Contains(contentRelations, relatedMemoID) {
+			continue
+		}
+		if err := stores.DeleteMemoRelation(ctx, &store.DeleteMemoRelation{
+			MemoID:        &memo.ID,
+			RelatedMemoID: &relatedMemoID,
+			Type:          &referenceType,
+		}); err != nil {
+			return errors.Wrap(err, "failed to delete memo relation")
+		}
+	}
+
+	if slices.Equal(previousContentRelations, contentRelations) {
+		return nil
+	}
+	memo.Payload.ContentRelations = contentRelations
+	if err := stores.UpdateMemo(ctx, &store.UpdateMemo{
+		ID:      memo.ID,
+		Payload: memo.Payload,
+	}); err != nil {
+		return errors.Wrap(err, "failed to update memo payload")
+	}
+	return nil
+}
+
+func findReferencedMemo(ctx context.Context, stores *store.Store, reference string) (*store.Memo, error) {
+	if !strings.HasPrefix(reference, "memos/") {
+		return nil, nil
+	}
+	uid := strings.TrimPrefix(reference, "memos/")
+	memo, err := stores.GetMemo(ctx, &store.FindMemo{UID: &uid})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find memo %s", reference)
+	}
+	return memo, nil
+}
+
+// appendLink appends the outbound http(s) link to the property.
+func appendLink(property *storepb.MemoPayload_Property, rawURL string) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return
+	}
+	if !slices.Contains(property.Links, rawURL) {
+		property.Links = append(property.Links, rawURL)
+	}
+}
+
+// appendLinkReference appends the memo or the ticket whose page the link points to.
+func appendLinkReference(references, ticketKeys []string, rawURL string) ([]string, []string) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return references, ticketKeys
+	}
+	if matches := memoPathRegexp.FindStringSubmatch(u.Path); matches != nil {
+		references = appendUnique(references, "memos/"+matches[1])
+	} else if matches := ticketPathRegexp.FindStringSubmatch(u.Path); matches != nil {
+		ticketKeys = appendUnique(ticketKeys, matches[1])
+	}
+	return references, ticketKeys
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+// findMentionedUser finds the user mentioned by username, falling back to the nickname.
+func findMentionedUser(ctx context.Context, stores *store.Store, username string) (*store.User, error) {
+	user, err := stores.GetUser(ctx, &store.FindUser{Username: &username})
+	if err != nil || user != nil {
+		return user, err
+	}
+	return stores.GetUser(ctx, &store.FindUser{Nickname: &username})
+}
+
+// referenceExists reports whether the referenced memo or resource exists.
+func referenceExists(ctx context.Context, stores *store.Store, reference string) (bool, error) {
+	switch {
+	case strings.HasPrefix(reference, "memos/"):
+		uid := strings.TrimPrefix(reference, "memos/")
+		memo, err := stores.GetMemo(ctx, &store.FindMemo{UID: &uid})
+		return memo != nil, err
+	case strings.HasPrefix(reference, "resources/"):
+		uid := strings.TrimPrefix(reference, "resources/")
+		resource, err := stores.GetResource(ctx, &store.FindResource{UID: &uid})
+		return resource != nil, err
+	default:
+		return false, nil
+	}
+}
+
+// findReferencedTicket finds the ticket by its id, e.g. 42, or its key in a project, e.g. API-42.
+func findReferencedTicket(ctx context.Context, stores *store.Store, key string) (*store.Ticket, error) {
+	if projectKey, number, ok := store.ParseTicketKey(key); ok {
+		project, err := stores.GetProject(ctx, &store.FindProject{Key: &projectKey})
+		if err != nil || project == nil {
+			return nil, err
+		}
+		return stores.GetTicket(ctx, &store.FindTicket{ProjectID: &project.ID, Number: &number})
+	}
+	if id, err := strconv.ParseInt(key, 10, 32); err == nil {
+		ticketID := int32(id)
+		return stores.GetTicket(ctx, &store.FindTicket{ID: &ticketID})
+	}
+	return nil, nil
+}
+
 func TraverseASTNodes(nodes []ast.Node, fn func(ast.Node)) {
 	for _, node := range nodes {
 		fn(node)
@@ -0,0 +1,54 @@
+package resourcegc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/usememos/memos/store"
+)
+
+type Runner struct {
+	Store *store.Store
+}
+
+func NewRunner(store *store.Store) *Runner {
+	return &Runner{
+		Store: store,
+	}
+}
+
+const (
+	// Schedule runner every 24 hours.
+	runnerInterval = time.Hour * 24
+	// gracePeriod keeps the blobs without references that were used recently,
+	// since their resources may still be being created.
+	gracePeriod = time.Hour
+)
+
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(runnerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.RunOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Runner) RunOnce(ctx context.Context) {
+	deleted, err := r.Store.CollectResourceBlobGarbage(ctx, gracePeriod)
+	if err != nil {
+		slog.Error("Failed to collect orphaned resource blobs", "error", err)
+	} else if deleted > 0 {
+		slog.Info("Deleted orphaned resource blobs", "count", deleted)
+	}
+	// Link previews are cached by URL, so the cache grows with every link ever rendered unless pruned.
+	if err := r.Store.PruneLinkPreviews(ctx); err != nil {
+		slog.Error("Failed to prune expired link previews", "error", err)
+	}
+}
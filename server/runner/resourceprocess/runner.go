@@ -0,0 +1,277 @@
+package resourceprocess
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/bbrks/go-blurhash"
+	"github.com/disintegration/imaging"
+	"github.com/pkg/errors"
+	// Register the WebP decoder for the variants of WebP images.
+	_ "golang.org/x/image/webp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/plugin/textextract"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// Runner processes the resources after upload: it records the dimensions and the blurhash of images,
+// generates their resized variants, and extracts the text of documents for searching.
+type Runner struct {
+	Store   *store.Store
+	Profile *profile.Profile
+
+	queue chan int32
+}
+
+func NewRunner(store *store.Store, profile *profile.Profile) *Runner {
+	return &Runner{
+		Store:   store,
+		Profile: profile,
+		queue:   make(chan int32, queueSize),
+	}
+}
+
+const (
+	queueSize = 256
+	// listBatchSize is the number of resources checked at a time for processing.
+	listBatchSize = 100
+	// maxContentSize is the size limit of the contents read into memory for processing.
+	maxContentSize = 64 << 20
+	// maxImagePixels is the limit of the pixels of the decoded images.
+	maxImagePixels = 50_000_000
+	// maxExtractedTextLength is the limit of the text extracted from a resource in bytes.
+	maxExtractedTextLength = 64 << 10
+	// legacyThumbnailCacheFolder is where the thumbnails were cached before the variants.
+	legacyThumbnailCacheFolder = ".thumbnail_cache"
+	// VariantType is the MIME type of the variants.
+	VariantType = "image/webp"
+)
+
+// Variant is a resized copy of images.
+type Variant struct {
+	Name string
+	// MaxSize is the maximum width and height of the variant.
+	MaxSize int
+}
+
+// Variants are generated for the images larger than their size.
+var Variants = []Variant{
+	{Name: "small", MaxSize: 320},
+	{Name: "medium", MaxSize: 1280},
+}
+
+var imageTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"image/bmp",
+	"image/tiff",
+}
+
+// Enqueue schedules the processing of a resource. It does not block: when the queue is full, the
+// resource is processed the next time the runner starts.
+func (r *Runner) Enqueue(resourceID int32) {
+	select {
+	case r.queue <- resourceID:
+	default:
+		slog.Warn("Resource processing queue is full", slog.Int("id", int(resourceID)))
+	}
+}
+
+func (r *Runner) Run(ctx context.Context) {
+	for {
+		select {
+		case resourceID := <-r.queue:
+			resource, err := r.Store.GetResource(ctx, &store.FindResource{ID: &resourceID})
+			if err != nil {
+				slog.Error("Failed to get resource to process", "id", resourceID, "error", err)
+				continue
+			}
+			if resource == nil {
+				continue
+			}
+			if err := r.Process(ctx, resource); err != nil {
+				slog.Error("Failed to process resource", "id", resourceID, "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// RunOnce processes the resources that have not been processed, e.g. the ones created before
+// the processing existed or left in the queue by the last shutdown.
+func (r *Runner) RunOnce(ctx context.Context) {
+	// The thumbnails are replaced by the variants.
+	if err := os.RemoveAll(filepath.Join(r.Profile.Data, legacyThumbnailCacheFolder)); err != nil {
+		slog.Warn("Failed to delete thumbnail cache", "error", err)
+	}
+
+	limit, offset := listBatchSize, 0
+	for {
+		resources, err := r.Store.ListResources(ctx, &store.FindResource{
+			Limit:  &limit,
+			Offset: &offset,
+		})
+		if err != nil {
+			slog.Error("Failed to list resources to process", "error", err)
+			return
+		}
+		for _, resource := range resources {
+			if resource.Payload.GetProcessTime() != nil {
+				continue
+			}
+			if err := r.Process(ctx, resource); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				slog.Error("Failed to process resource", "id", resource.ID, "error", err)
+			}
+		}
+		if len(resources) < limit {
+			return
+		}
+		offset += limit
+	}
+}
+
+// Process processes the resource and records the results in its payload. Contents that cannot
+// be decoded are recorded as processed, while the failures to read them are returned, so the
+// resource is processed again later.
+func (r *Runner) Process(ctx context.Context, resource *store.Resource) error {
+	result := &storepb.ResourcePayload{}
+	isImage := slices.Contains(imageTypes, mediaType(resource.Type))
+	if resource.StorageType != storepb.ResourceStorageType_EXTERNAL && resource.Size <= maxContentSize && (isImage || textextract.Supported(resource.Type)) {
+		content, err := r.readContent(ctx, resource)
+		if err != nil {
+			return err
+		}
+		if isImage {
+			if err := r.processImage(resource, content, result); err != nil {
+				slog.Warn("Failed to process image", "id", resource.ID, "error", err)
+			}
+		} else {
+			text, err := textextract.Extract(resource.Type, content, maxExtractedTextLength)
+			if err != nil {
+				slog.Warn("Failed to extract text", "id", resource.ID, "error", err)
+			}
+			result.ExtractedText = text
+		}
+	}
+
+	// Merge into the latest payload, which may have changed during the processing.
+	latest, err := r.Store.GetResource(ctx, &store.FindResource{ID: &resource.ID})
+	if err != nil {
+		return errors.Wrap(err, "failed to get resource")
+	}
+	if latest == nil {
+		// The resource was deleted during the processing.
+		return nil
+	}
+	payload := latest.Payload
+	if payload == nil {
+		payload = &storepb.ResourcePayload{}
+	}
+	payload.Width = result.Width
+	payload.Height = result.Height
+	payload.Blurhash = result.Blurhash
+	payload.Variants = result.Variants
+	payload.ExtractedText = result.ExtractedText
+	payload.ProcessTime = timestamppb.Now()
+	if err := r.Store.UpdateResource(ctx, &store.UpdateResource{
+		ID:      resource.ID,
+		Payload: payload,
+	}); err != nil {
+		return errors.Wrap(err, "failed to update resource payload")
+	}
+	return nil
+}
+
+func (r *Runner) readContent(ctx context.Context, resource *store.Resource) ([]byte, error) {
+	// The content of the resources stored in the database is their blob.
+	resource, err := r.Store.GetResource(ctx, &store.FindResource{ID: &resource.ID, GetBlob: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get resource")
+	}
+	if resource == nil {
+		return nil, errors.New("resource not found")
+	}
+	content, err := r.Store.OpenResourceContent(ctx, resource)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open resource content")
+	}
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read resource content")
+	}
+	return data, nil
+}
+
+// processImage records the dimensions and the blurhash of the image, and writes its variants.
+func (r *Runner) processImage(resource *store.Resource, content []byte, result *storepb.ResourcePayload) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return errors.Wrap(err, "failed to decode image config")
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return errors.Errorf("image of %dx%d is too large", config.Width, config.Height)
+	}
+	img, err := imaging.Decode(bytes.NewReader(content), imaging.AutoOrientation(true))
+	if err != nil {
+		return errors.Wrap(err, "failed to decode image")
+	}
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	result.Width, result.Height = int32(width), int32(height)
+
+	// The blurhash only keeps the rough colors, so it is computed from a tiny copy.
+	hash, err := blurhash.Encode(4, 3, imaging.Fit(img, 32, 32, imaging.Box))
+	if err != nil {
+		return errors.Wrap(err, "failed to encode blurhash")
+	}
+	result.Blurhash = hash
+
+	for _, variant := range Variants {
+		if width <= variant.MaxSize && height <= variant.MaxSize {
+			continue
+		}
+		resized := imaging.Fit(img, variant.MaxSize, variant.MaxSize, imaging.Lanczos)
+		buf := &bytes.Buffer{}
+		if err := nativewebp.Encode(buf, resized, nil); err != nil {
+			return errors.Wrapf(err, "failed to encode variant %s", variant.Name)
+		}
+		path := r.Store.ResourceVariantPath(resource, variant.Name)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return errors.Wrap(err, "failed to create variant folder")
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			return errors.Wrapf(err, "failed to write variant %s", variant.Name)
+		}
+		result.Variants = append(result.Variants, &storepb.ResourcePayload_Variant{
+			Name:   variant.Name,
+			Type:   VariantType,
+			Width:  int32(resized.Bounds().Dx()),
+			Height: int32(resized.Bounds().Dy()),
+			Size:   int64(buf.Len()),
+		})
+	}
+	return nil
+}
+
+// mediaType returns the media type of the content type without its parameters.
+func mediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.TrimSpace(strings.ToLower(mediaType))
+}
@@ -111,14 +111,14 @@ func (r *Runner) CheckAndPresign(ctx context.Context) {
 
 			s3ObjectPayload.S3Config = s3Config
 			s3ObjectPayload.LastPresignedTime = timestamppb.New(time.Now())
+			// Update the payload in place to keep the content hash of content addressed resources.
+			resource.Payload.Payload = &storepb.ResourcePayload_S3Object_{
+				S3Object: s3ObjectPayload,
+			}
 			if err := r.Store.UpdateResource(ctx, &store.UpdateResource{
 				ID:        resource.ID,
 				Reference: &presignURL,
-				Payload: &storepb.ResourcePayload{
-					Payload: &storepb.ResourcePayload_S3Object_{
-						S3Object: s3ObjectPayload,
-					},
-				},
+				Payload:   resource.Payload,
 			}); err != nil {
 				slog.Error("Failed to update resource", "error", err, "resourceID", resource.ID)
 				continue
@@ -24,7 +24,10 @@ import (
 	apiv1 "github.com/usememos/memos/server/router/api/v1"
 	"github.com/usememos/memos/server/router/frontend"
 	"github.com/usememos/memos/server/router/rss"
+	"github.com/usememos/memos/server/runner/backup"
 	"github.com/usememos/memos/server/runner/memopayload"
+	"github.com/usememos/memos/server/runner/resourcegc"
+	"github.com/usememos/memos/server/runner/resourceprocess"
 	"github.com/usememos/memos/server/runner/s3presign"
 	"github.com/usememos/memos/store"
 )
@@ -38,6 +41,7 @@ type Server struct {
 	grpcServer        *grpc.Server
 	profiler          *profiler.Profiler
 	runnerCancelFuncs []context.CancelFunc
+	resourceProcessor *resourceprocess.Runner
 }
 
 func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store) (*Server, error) {
@@ -51,6 +55,11 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 	echoServer.HideBanner = true
 	echoServer.HidePort = true
 	echoServer.Use(middleware.Recover())
+	ipExtractor, err := apiv1.NewIPExtractor(profile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create ip extractor")
+	}
+	echoServer.IPExtractor = ipExtractor
 	s.echoServer = echoServer
 
 	// Initialize profiler
@@ -82,17 +91,20 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 	// Create and register RSS routes.
 	rss.NewRSSService(s.Profile, s.Store).RegisterRoutes(rootGroup)
 
+	rateLimiter := apiv1.NewRateLimiter(store, profile)
 	grpcServer := grpc.NewServer(
 		// Override the maximum receiving message size to math.MaxInt32 for uploading large resources.
 		grpc.MaxRecvMsgSize(math.MaxInt32),
 		grpc.ChainUnaryInterceptor(
 			apiv1.NewLoggerInterceptor().LoggerInterceptor,
 			grpcrecovery.UnaryServerInterceptor(),
-			apiv1.NewGRPCAuthInterceptor(store, secret).AuthenticationInterceptor,
+			apiv1.NewGRPCAuthInterceptor(store, profile, secret).AuthenticationInterceptor,
+			rateLimiter.RateLimitInterceptor,
 		))
 	s.grpcServer = grpcServer
 
-	apiV1Service := apiv1.NewAPIV1Service(s.Secret, profile, store, grpcServer)
+	s.resourceProcessor = resourceprocess.NewRunner(store, profile)
+	apiV1Service := apiv1.NewAPIV1Service(s.Secret, profile, store, grpcServer, rateLimiter, s.resourceProcessor)
 	// Register gRPC gateway as api v1.
 	if err := apiV1Service.RegisterGateway(ctx, echoServer); err != nil {
 		return nil, errors.Wrap(err, "failed to register gRPC gateway")
@@ -205,6 +217,45 @@ func (s *Server) StartBackgroundRunners(ctx context.Context) {
 		slog.Info("s3presign runner stopped")
 	}()
 
+	// Start the garbage collection of orphaned resource blobs.
+	resourcegcContext, resourcegcCancel := context.WithCancel(ctx)
+	s.runnerCancelFuncs = append(s.runnerCancelFuncs, resourcegcCancel)
+	resourcegcRunner := resourcegc.NewRunner(s.Store)
+	go func() {
+		resourcegcRunner.RunOnce(resourcegcContext)
+		resourcegcRunner.Run(resourcegcContext)
+		slog.Info("resourcegc runner stopped")
+	}()
+
+	// Process the uploaded resources, after the ones left unprocessed.
+	resourceprocessContext, resourceprocessCancel := context.WithCancel(ctx)
+	s.runnerCancelFuncs = append(s.runnerCancelFuncs, resourceprocessCancel)
+	go func() {
+		s.resourceProcessor.RunOnce(resourceprocessContext)
+		s.resourceProcessor.Run(resourceprocessContext)
+		slog.Info("resourceprocess runner stopped")
+	}()
+
+	// Resume the storage migration interrupted by the last shutdown, if any.
+	storageMigrationContext, storageMigrationCancel := context.WithCancel(ctx)
+	s.runnerCancelFuncs = append(s.runnerCancelFuncs, storageMigrationCancel)
+	go func() {
+		if err := s.Store.RunStorageMigration(storageMigrationContext); err != nil && !errors.Is(err, context.Canceled) {
+			slog.Error("Failed to resume storage migration", "error", err)
+		}
+	}()
+
+	// Start scheduled sqlite snapshots if enabled.
+	if s.Profile.Driver == "sqlite" && s.Profile.BackupInterval > 0 {
+		backupContext, backupCancel := context.WithCancel(ctx)
+		s.runnerCancelFuncs = append(s.runnerCancelFuncs, backupCancel)
+		backupRunner := backup.NewRunner(s.Store, s.Profile)
+		go func() {
+			backupRunner.Run(backupContext)
+			slog.Info("backup runner stopped")
+		}()
+	}
+
 	// Log the number of goroutines running
 	slog.Info("background runners started", "goroutines", runtime.NumGoroutine())
 }
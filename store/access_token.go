@@ -0,0 +1,171 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// AccessTokenScope is a scope granted to a personal access token.
+type AccessTokenScope string
+
+const (
+	// AccessTokenScopeRead grants read access to memos, resources, tickets and the other user resources.
+	AccessTokenScopeRead AccessTokenScope = "read"
+	// AccessTokenScopeMemosWrite grants write access to memos, resources and their relations.
+	AccessTokenScopeMemosWrite AccessTokenScope = "memos:write"
+	// AccessTokenScopeTicketsWrite grants write access to tickets.
+	AccessTokenScopeTicketsWrite AccessTokenScope = "tickets:write"
+	// AccessTokenScopeWebhooks grants read and write access to webhooks.
+	AccessTokenScopeWebhooks AccessTokenScope = "webhooks"
+)
+
+// accessTokenUsageInterval is how often the last used time of an access token is written.
+const accessTokenUsageInterval = time.Minute
+
+// HashAccessToken returns the hex encoded SHA-256 hash of the access token.
+func HashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// AccessTokenHash returns the hash of the stored access token, hashing tokens stored in plaintext on the fly.
+func AccessTokenHash(accessToken *storepb.AccessTokensUserSetting_AccessToken) string {
+	if accessToken.TokenHash != "" {
+		return accessToken.TokenHash
+	}
+	return HashAccessToken(accessToken.AccessToken)
+}
+
+// AccessTokenID returns the public identifier of the stored access token.
+func AccessTokenID(accessToken *storepb.AccessTokensUserSetting_AccessToken) string {
+	return AccessTokenHash(accessToken)[:16]
+}
+
+// IsAccessTokenExpired reports whether the stored access token has expired.
+func IsAccessTokenExpired(accessToken *storepb.AccessTokensUserSetting_AccessToken, now time.Time) bool {
+	return accessToken.ExpireTime != nil && !now.Before(accessToken.ExpireTime.AsTime())
+}
+
+// HasAccessTokenScope reports whether the scopes grant the scope. Tokens without scopes have full access.
+func HasAccessTokenScope(scopes []string, scope AccessTokenScope) bool {
+	return len(scopes) == 0 || slices.Contains(scopes, string(scope))
+}
+
+// GetUserAccessToken returns the unexpired stored access token of the user matching the token, or nil if there is none.
+func (s *Store) GetUserAccessToken(ctx context.Context, userID int32, token string) (*storepb.AccessTokensUserSetting_AccessToken, error) {
+	accessTokens, err := s.GetUserAccessTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	index := findAccessToken(accessTokens, token)
+	if index < 0 || IsAccessTokenExpired(accessTokens[index], time.Now()) {
+		return nil, nil
+	}
+	return accessTokens[index], nil
+}
+
+// AddUserAccessToken saves a new access token of the user. Expired tokens are dropped at the same time,
+// so the setting does not grow forever.
+func (s *Store) AddUserAccessToken(ctx context.Context, userID int32, accessToken *storepb.AccessTokensUserSetting_AccessToken) error {
+	return s.updateUserAccessTokens(ctx, userID, func(accessTokens []*storepb.AccessTokensUserSetting_AccessToken) []*storepb.AccessTokensUserSetting_AccessToken {
+		now := time.Now()
+		accessTokens = slices.DeleteFunc(accessTokens, func(t *storepb.AccessTokensUserSetting_AccessToken) bool {
+			return IsAccessTokenExpired(t, now)
+		})
+		return append(accessTokens, accessToken)
+	})
+}
+
+// TouchUserAccessToken records the last use of the access token of the user. Writes are throttled,
+// and a token still stored in plaintext is replaced by its hash.
+func (s *Store) TouchUserAccessToken(ctx context.Context, userID int32, token, ip string) error {
+	// Check the throttle without the lock first, as access tokens are touched by every request.
+	accessTokens, err := s.GetUserAccessTokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+	index := findAccessToken(accessTokens, token)
+	if index < 0 || !isAccessTokenTouchDue(accessTokens[index], ip, time.Now()) {
+		return nil
+	}
+	return s.updateUserAccessTokens(ctx, userID, func(accessTokens []*storepb.AccessTokensUserSetting_AccessToken) []*storepb.AccessTokensUserSetting_AccessToken {
+		// The token may have been removed or touched since it was read.
+		index := findAccessToken(accessTokens, token)
+		now := time.Now()
+		if index < 0 || !isAccessTokenTouchDue(accessTokens[index], ip, now) {
+			return accessTokens
+		}
+		accessToken := accessTokens[index]
+		accessToken.TokenHash = AccessTokenHash(accessToken)
+		accessToken.AccessToken = ""
+		accessToken.LastUsedTime = timestamppb.New(now)
+		accessToken.LastUsedIp = ip
+		return accessTokens
+	})
+}
+
+// hashUserAccessTokens replaces the access tokens stored in plaintext by their hashes.
+func (s *Store) hashUserAccessTokens(ctx context.Context) error {
+	userSettings, err := s.ListUserSettings(ctx, &FindUserSetting{Key: storepb.UserSettingKey_ACCESS_TOKENS})
+	if err != nil {
+		return errors.Wrap(err, "failed to list access tokens")
+	}
+	for _, userSetting := range userSettings {
+		if !slices.ContainsFunc(userSetting.GetAccessTokens().GetAccessTokens(), isPlaintextAccessToken) {
+			continue
+		}
+		if err := s.updateUserAccessTokens(ctx, userSetting.UserId, func(accessTokens []*storepb.AccessTokensUserSetting_AccessToken) []*storepb.AccessTokensUserSetting_AccessToken {
+			for _, accessToken := range accessTokens {
+				if isPlaintextAccessToken(accessToken) {
+					accessToken.TokenHash = AccessTokenHash(accessToken)
+					accessToken.AccessToken = ""
+				}
+			}
+			return accessTokens
+		}); err != nil {
+			return errors.Wrapf(err, "failed to hash access tokens of user %d", userSetting.UserId)
+		}
+	}
+	return nil
+}
+
+// updateUserAccessTokens calls update with copies of the access tokens of the user and saves the tokens it returns.
+// The updates of the access tokens of a user are serialized, so update sees the changes of the previous updates.
+func (s *Store) updateUserAccessTokens(ctx context.Context, userID int32, update func([]*storepb.AccessTokensUserSetting_AccessToken) []*storepb.AccessTokensUserSetting_AccessToken) error {
+	return s.updateUserSetting(ctx, userID, storepb.UserSettingKey_ACCESS_TOKENS, func(userSetting *storepb.UserSetting) error {
+		accessTokens := update(userSetting.GetAccessTokens().GetAccessTokens())
+		userSetting.Value = &storepb.UserSetting_AccessTokens{
+			AccessTokens: &storepb.AccessTokensUserSetting{
+				AccessTokens: accessTokens,
+			},
+		}
+		return nil
+	})
+}
+
+// isAccessTokenTouchDue reports whether the last use of the access token needs to be written.
+func isAccessTokenTouchDue(accessToken *storepb.AccessTokensUserSetting_AccessToken, ip string, now time.Time) bool {
+	return accessToken.TokenHash == "" || accessToken.LastUsedIp != ip || accessToken.LastUsedTime == nil ||
+		now.Sub(accessToken.LastUsedTime.AsTime()) >= accessTokenUsageInterval
+}
+
+// isPlaintextAccessToken reports whether the access token is stored in plaintext.
+func isPlaintextAccessToken(accessToken *storepb.AccessTokensUserSetting_AccessToken) bool {
+	return accessToken.AccessToken != ""
+}
+
+func findAccessToken(accessTokens []*storepb.AccessTokensUserSetting_AccessToken, token string) int {
+	hash := []byte(HashAccessToken(token))
+	return slices.IndexFunc(accessTokens, func(accessToken *storepb.AccessTokensUserSetting_AccessToken) bool {
+		return subtle.ConstantTimeCompare([]byte(AccessTokenHash(accessToken)), hash) == 1
+	})
+}
@@ -0,0 +1,193 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// analyticsCacheTTL is how long the results of analytics are cached.
+const analyticsCacheTTL = 5 * time.Minute
+
+// TicketBacklogAgeBuckets are the lower bounds in seconds of the buckets of the backlog age histogram.
+var TicketBacklogAgeBuckets = []int64{
+	0,
+	int64((24 * time.Hour).Seconds()),
+	int64((7 * 24 * time.Hour).Seconds()),
+	int64((30 * 24 * time.Hour).Seconds()),
+	int64((90 * 24 * time.Hour).Seconds()),
+}
+
+type FindTicketMetrics struct {
+	ProjectID *int32
+	// VisibleProjectIDs limits the tickets in projects to the tickets in these projects when it is not nil.
+	// The tickets without a project are counted either way.
+	VisibleProjectIDs []int32
+	// StartTs and EndTs bound the range of the metrics, which is split into periods of PeriodSeconds.
+	StartTs       int64
+	EndTs         int64
+	PeriodSeconds int64
+}
+
+// TicketPeriodCount is the number of tickets opened and closed in a period.
+type TicketPeriodCount struct {
+	// Period is the index of the period in the range.
+	Period int32
+	Opened int32
+	Closed int32
+}
+
+// DurationPercentiles are the nearest-rank percentiles of durations in seconds.
+type DurationPercentiles struct {
+	Count int32
+	P50   int64
+	P85   int64
+	P95   int64
+}
+
+// TicketAssigneeThroughput is the number of tickets of an assignee closed in the range.
+type TicketAssigneeThroughput struct {
+	// AssigneeID is nil for the unassigned tickets.
+	AssigneeID *int32
+	Closed     int32
+}
+
+// TicketBacklogAgeCount is the number of tickets not closed in a bucket of TicketBacklogAgeBuckets.
+type TicketBacklogAgeCount struct {
+	Bucket int32
+	Count  int32
+}
+
+type TicketMetrics struct {
+	Periods []*TicketPeriodCount
+	// LeadTime is the time from creating to closing the tickets closed in the range.
+	LeadTime *DurationPercentiles
+	// CycleTime is the time from starting to closing the tickets closed in the range.
+	CycleTime  *DurationPercentiles
+	Throughput []*TicketAssigneeThroughput
+	// BacklogAge is the age at the end of the range of the tickets not closed.
+	BacklogAge []*TicketBacklogAgeCount
+}
+
+// TicketStatusDelta is the change of the number of tickets with a status in a period.
+type TicketStatusDelta struct {
+	// Period is the index of the period in the range, -1 for the changes before the range.
+	Period int32
+	Status TicketStatus
+	Delta  int32
+}
+
+// TicketStatusCounts is the number of tickets with each status at the end of a period.
+type TicketStatusCounts struct {
+	Period int32
+	Counts map[TicketStatus]int32
+}
+
+type FindMemoActivity struct {
+	// MemoFind finds the memos counted.
+	MemoFind *FindMemo
+	StartTs  int64
+	EndTs    int64
+	// UTCOffset is the offset in seconds of the time zone the weekdays and hours are in.
+	UTCOffset int64
+}
+
+// MemoActivityCount is the number of memos created in an hour of a weekday, where Sunday is 0.
+type MemoActivityCount struct {
+	Weekday int32
+	Hour    int32
+	Count   int32
+}
+
+func (s *Store) GetTicketMetrics(ctx context.Context, find *FindTicketMetrics) (*TicketMetrics, error) {
+	key, err := analyticsCacheKey("ticket_metrics", find)
+	if err != nil {
+		return nil, err
+	}
+	if cached, ok := s.analyticsCache.Get(ctx, key); ok {
+		if metrics, ok := cached.(*TicketMetrics); ok {
+			return metrics, nil
+		}
+	}
+	metrics, err := s.driver.GetTicketMetrics(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	s.analyticsCache.SetWithTTL(ctx, key, metrics, analyticsCacheTTL)
+	return metrics, nil
+}
+
+// GetTicketCumulativeFlow returns the number of tickets with each status at the end of every period of the range.
+func (s *Store) GetTicketCumulativeFlow(ctx context.Context, find *FindTicketMetrics) ([]*TicketStatusCounts, error) {
+	key, err := analyticsCacheKey("ticket_cumulative_flow", find)
+	if err != nil {
+		return nil, err
+	}
+	if cached, ok := s.analyticsCache.Get(ctx, key); ok {
+		if list, ok := cached.([]*TicketStatusCounts); ok {
+			return list, nil
+		}
+	}
+	deltas, err := s.driver.ListTicketStatusDeltas(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	// The deltas are sorted by period, and the ones before the range make up the initial counts.
+	periodCount := CountPeriods(find.StartTs, find.EndTs, find.PeriodSeconds)
+	counts := map[TicketStatus]int32{}
+	list := make([]*TicketStatusCounts, 0, periodCount)
+	index := 0
+	for period := int32(-1); period < int32(periodCount); period++ {
+		for ; index < len(deltas) && deltas[index].Period <= period; index++ {
+			counts[deltas[index].Status] += deltas[index].Delta
+		}
+		if period < 0 {
+			continue
+		}
+		periodCounts := &TicketStatusCounts{Period: period, Counts: map[TicketStatus]int32{}}
+		for status, count := range counts {
+			periodCounts.Counts[status] = count
+		}
+		list = append(list, periodCounts)
+	}
+	s.analyticsCache.SetWithTTL(ctx, key, list, analyticsCacheTTL)
+	return list, nil
+}
+
+func (s *Store) ListMemoActivity(ctx context.Context, find *FindMemoActivity) ([]*MemoActivityCount, error) {
+	key, err := analyticsCacheKey("memo_activity", find)
+	if err != nil {
+		return nil, err
+	}
+	if cached, ok := s.analyticsCache.Get(ctx, key); ok {
+		if list, ok := cached.([]*MemoActivityCount); ok {
+			return list, nil
+		}
+	}
+	list, err := s.driver.ListMemoActivity(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	s.analyticsCache.SetWithTTL(ctx, key, list, analyticsCacheTTL)
+	return list, nil
+}
+
+// CountPeriods returns the number of periods of the range, where the last one may be partial.
+func CountPeriods(startTs, endTs, periodSeconds int64) int {
+	if endTs <= startTs || periodSeconds <= 0 {
+		return 0
+	}
+	return int((endTs - startTs + periodSeconds - 1) / periodSeconds)
+}
+
+// analyticsCacheKey returns the key of the result of the analytics with the find in the cache.
+func analyticsCacheKey(kind string, find any) (string, error) {
+	bytes, err := json.Marshal(find)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal analytics find")
+	}
+	return kind + ":" + string(bytes), nil
+}
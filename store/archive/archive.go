@@ -0,0 +1,201 @@
+// Package archive reads and writes workspace archives.
+//
+// A workspace archive is a zip file holding a manifest, one JSON lines file per
+// table and the blobs of resources stored in the database or on the local file
+// system. IDs in the archive are the IDs of the source instance; the importer
+// remaps them to the IDs assigned by the target database.
+package archive
+
+import (
+	"encoding/json"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// FormatVersion is the version of the archive layout.
+	// Bump it whenever a record changes in a way older importers can't read.
+	FormatVersion = 1
+
+	manifestFileName          = "manifest.json"
+	workspaceSettingsFileName = "workspace_settings.jsonl"
+	usersFileName             = "users.jsonl"
+	userSettingsFileName      = "user_settings.jsonl"
+	identityProvidersFileName = "identity_providers.jsonl"
+	webhooksFileName          = "webhooks.jsonl"
+	customRolesFileName       = "custom_roles.jsonl"
+	projectsFileName          = "projects.jsonl"
+	memosFileName             = "memos.jsonl"
+	memoRelationsFileName     = "memo_relations.jsonl"
+	reactionsFileName         = "reactions.jsonl"
+	resourcesFileName         = "resources.jsonl"
+	ticketsFileName           = "tickets.jsonl"
+	notificationsFileName     = "notifications.jsonl"
+	// resourceBlobDir is the directory holding resource blobs, keyed by resource uid.
+	resourceBlobDir = "resources/"
+)
+
+// Manifest describes the content of an archive.
+type Manifest struct {
+	FormatVersion int    `json:"formatVersion"`
+	Version       string `json:"version"`
+	SchemaVersion string `json:"schemaVersion"`
+	Driver        string `json:"driver"`
+	CreatedTs     int64  `json:"createdTs"`
+	// Counts is the number of records written per file.
+	Counts map[string]int `json:"counts"`
+}
+
+type workspaceSettingRecord struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+type userRecord struct {
+	ID           int32  `json:"id"`
+	RowStatus    string `json:"rowStatus"`
+	CreatedTs    int64  `json:"createdTs"`
+	UpdatedTs    int64  `json:"updatedTs"`
+	Username     string `json:"username"`
+	Role         string `json:"role"`
+	Email        string `json:"email"`
+	Nickname     string `json:"nickname"`
+	PasswordHash string `json:"passwordHash"`
+	AvatarURL    string `json:"avatarUrl"`
+	Description  string `json:"description"`
+}
+
+type userSettingRecord struct {
+	UserID int32  `json:"userId"`
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+}
+
+type identityProviderRecord struct {
+	ID               int32  `json:"id"`
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	IdentifierFilter string `json:"identifierFilter"`
+	Config           string `json:"config"`
+}
+
+type webhookRecord struct {
+	ID        int32  `json:"id"`
+	CreatedTs int64  `json:"createdTs"`
+	UpdatedTs int64  `json:"updatedTs"`
+	CreatorID int32  `json:"creatorId"`
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+}
+
+type customRoleRecord struct {
+	ID          int32    `json:"id"`
+	CreatedTs   int64    `json:"createdTs"`
+	UpdatedTs   int64    `json:"updatedTs"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+	// UserIDs are the users the role is assigned to.
+	UserIDs []int32 `json:"userIds"`
+}
+
+type projectRecord struct {
+	ID          int32                  `json:"id"`
+	RowStatus   string                 `json:"rowStatus"`
+	CreatedTs   int64                  `json:"createdTs"`
+	UpdatedTs   int64                  `json:"updatedTs"`
+	Key         string                 `json:"key"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Payload     json.RawMessage        `json:"payload,omitempty"`
+	Members     []*projectMemberRecord `json:"members"`
+}
+
+type projectMemberRecord struct {
+	UserID int32  `json:"userId"`
+	Role   string `json:"role"`
+}
+
+type memoRecord struct {
+	ID         int32           `json:"id"`
+	UID        string          `json:"uid"`
+	RowStatus  string          `json:"rowStatus"`
+	CreatorID  int32           `json:"creatorId"`
+	CreatedTs  int64           `json:"createdTs"`
+	UpdatedTs  int64           `json:"updatedTs"`
+	Content    string          `json:"content"`
+	Visibility string          `json:"visibility"`
+	Pinned     bool            `json:"pinned"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	ProjectID  *int32          `json:"projectId,omitempty"`
+}
+
+type memoRelationRecord struct {
+	MemoID        int32  `json:"memoId"`
+	RelatedMemoID int32  `json:"relatedMemoId"`
+	Type          string `json:"type"`
+}
+
+type reactionRecord struct {
+	ID           int32  `json:"id"`
+	CreatedTs    int64  `json:"createdTs"`
+	CreatorID    int32  `json:"creatorId"`
+	ContentID    string `json:"contentId"`
+	ReactionType string `json:"reactionType"`
+}
+
+type resourceRecord struct {
+	ID          int32           `json:"id"`
+	UID         string          `json:"uid"`
+	CreatorID   int32           `json:"creatorId"`
+	CreatedTs   int64           `json:"createdTs"`
+	UpdatedTs   int64           `json:"updatedTs"`
+	Filename    string          `json:"filename"`
+	Type        string          `json:"type"`
+	Size        int64           `json:"size"`
+	StorageType string          `json:"storageType"`
+	Reference   string          `json:"reference"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	MemoID      *int32          `json:"memoId,omitempty"`
+	// HasBlob reports whether the archive holds the content of the resource.
+	HasBlob bool `json:"hasBlob"`
+}
+
+type ticketRecord struct {
+	ID          int32    `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Priority    string   `json:"priority"`
+	CreatorID   int32    `json:"creatorId"`
+	AssigneeID  *int32   `json:"assigneeId,omitempty"`
+	CreatedTs   int64    `json:"createdTs"`
+	UpdatedTs   int64    `json:"updatedTs"`
+	Type        string   `json:"type"`
+	Tags        []string `json:"tags"`
+	ProjectID   *int32   `json:"projectId,omitempty"`
+	// Number is the number of the ticket in its project, which is part of its key.
+	Number int32 `json:"number,omitempty"`
+	// Fields are the values of the custom fields by field key.
+	Fields map[string]any `json:"fields,omitempty"`
+}
+
+type notificationRecord struct {
+	ID          int32  `json:"id"`
+	InitiatorID int32  `json:"initiatorId"`
+	ReceiverID  int32  `json:"receiverId"`
+	TicketURL   string `json:"ticketUrl"`
+	CreatedTs   int64  `json:"createdTs"`
+	IsRead      bool   `json:"isRead"`
+}
+
+// hasResourceBlob reports whether the content of the resource can be carried in an archive.
+// Content addressed resources are always carried, since the importer has to store their blob again.
+// Other S3 and external resources only keep a reference, their objects stay where they are.
+func hasResourceBlob(resource *store.Resource) bool {
+	return resource.Payload.GetContentHash() != "" ||
+		resource.StorageType == storepb.ResourceStorageType_RESOURCE_STORAGE_TYPE_UNSPECIFIED ||
+		resource.StorageType == storepb.ResourceStorageType_LOCAL
+}
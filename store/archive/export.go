@@ -0,0 +1,472 @@
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/usememos/memos/internal/profile"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// Exporter writes the whole workspace into an archive.
+type Exporter struct {
+	Store   *store.Store
+	Profile *profile.Profile
+}
+
+func NewExporter(store *store.Store, profile *profile.Profile) *Exporter {
+	return &Exporter{
+		Store:   store,
+		Profile: profile,
+	}
+}
+
+// Export writes the archive to w and returns its manifest.
+func (e *Exporter) Export(ctx context.Context, w io.Writer) (*Manifest, error) {
+	workspaceBasicSetting, err := e.Store.GetWorkspaceBasicSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace basic setting")
+	}
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		Version:       e.Profile.Version,
+		SchemaVersion: workspaceBasicSetting.SchemaVersion,
+		Driver:        e.Profile.Driver,
+		CreatedTs:     time.Now().Unix(),
+		Counts:        map[string]int{},
+	}
+
+	zw := zip.NewWriter(w)
+	steps := []func(context.Context, *zip.Writer, *Manifest) error{
+		e.exportWorkspaceSettings,
+		e.exportUsers,
+		e.exportUserSettings,
+		e.exportIdentityProviders,
+		e.exportWebhooks,
+		e.exportCustomRoles,
+		e.exportProjects,
+		e.exportMemos,
+		e.exportMemoRelations,
+		e.exportReactions,
+		e.exportResources,
+		e.exportTickets,
+		e.exportNotifications,
+	}
+	for _, step := range steps {
+		if err := step(ctx, zw, manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	manifestWriter, err := zw.Create(manifestFileName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create manifest")
+	}
+	encoder := json.NewEncoder(manifestWriter)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to write manifest")
+	}
+	if err := zw.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close archive")
+	}
+	return manifest, nil
+}
+
+func (e *Exporter) exportWorkspaceSettings(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	// Read the raw settings so that keys unknown to this version are carried over too.
+	list, err := e.Store.GetDriver().ListWorkspaceSettings(ctx, &store.FindWorkspaceSetting{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list workspace settings")
+	}
+	records := make([]*workspaceSettingRecord, 0, len(list))
+	for _, workspaceSetting := range list {
+		records = append(records, &workspaceSettingRecord{
+			Name:        workspaceSetting.Name,
+			Value:       workspaceSetting.Value,
+			Description: workspaceSetting.Description,
+		})
+	}
+	return writeRecords(zw, manifest, workspaceSettingsFileName, records)
+}
+
+func (e *Exporter) exportUsers(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	users, err := e.Store.ListUsers(ctx, &store.FindUser{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list users")
+	}
+	records := make([]*userRecord, 0, len(users))
+	for _, user := range users {
+		records = append(records, &userRecord{
+			ID:           user.ID,
+			RowStatus:    user.RowStatus.String(),
+			CreatedTs:    user.CreatedTs,
+			UpdatedTs:    user.UpdatedTs,
+			Username:     user.Username,
+			Role:         user.Role.String(),
+			Email:        user.Email,
+			Nickname:     user.Nickname,
+			PasswordHash: user.PasswordHash,
+			AvatarURL:    user.AvatarURL,
+			Description:  user.Description,
+		})
+	}
+	return writeRecords(zw, manifest, usersFileName, records)
+}
+
+func (e *Exporter) exportUserSettings(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	list, err := e.Store.GetDriver().ListUserSettings(ctx, &store.FindUserSetting{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list user settings")
+	}
+	records := make([]*userSettingRecord, 0, len(list))
+	for _, userSetting := range list {
+		records = append(records, &userSettingRecord{
+			UserID: userSetting.UserID,
+			Key:    userSetting.Key.String(),
+			Value:  userSetting.Value,
+		})
+	}
+	return writeRecords(zw, manifest, userSettingsFileName, records)
+}
+
+func (e *Exporter) exportIdentityProviders(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	list, err := e.Store.GetDriver().ListIdentityProviders(ctx, &store.FindIdentityProvider{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list identity providers")
+	}
+	records := make([]*identityProviderRecord, 0, len(list))
+	for _, identityProvider := range list {
+		records = append(records, &identityProviderRecord{
+			ID:               identityProvider.ID,
+			Name:             identityProvider.Name,
+			Type:             identityProvider.Type.String(),
+			IdentifierFilter: identityProvider.IdentifierFilter,
+			Config:           identityProvider.Config,
+		})
+	}
+	return writeRecords(zw, manifest, identityProvidersFileName, records)
+}
+
+func (e *Exporter) exportWebhooks(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	webhooks, err := e.Store.ListWebhooks(ctx, &store.FindWebhook{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list webhooks")
+	}
+	records := make([]*webhookRecord, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		records = append(records, &webhookRecord{
+			ID:        webhook.ID,
+			CreatedTs: webhook.CreatedTs,
+			UpdatedTs: webhook.UpdatedTs,
+			CreatorID: webhook.CreatorID,
+			Name:      webhook.Name,
+			URL:       webhook.URL,
+		})
+	}
+	return writeRecords(zw, manifest, webhooksFileName, records)
+}
+
+func (e *Exporter) exportCustomRoles(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	customRoles, err := e.Store.ListCustomRoles(ctx, &store.FindCustomRole{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list custom roles")
+	}
+	userCustomRoles, err := e.Store.GetDriver().ListUserCustomRoles(ctx, &store.FindUserCustomRole{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list custom role assignments")
+	}
+	records := make([]*customRoleRecord, 0, len(customRoles))
+	for _, customRole := range customRoles {
+		record := &customRoleRecord{
+			ID:          customRole.ID,
+			CreatedTs:   customRole.CreatedTs,
+			UpdatedTs:   customRole.UpdatedTs,
+			Name:        customRole.Name,
+			Description: customRole.Description,
+			Permissions: []string{},
+			UserIDs:     []int32{},
+		}
+		for _, permission := range customRole.Permissions {
+			record.Permissions = append(record.Permissions, permission.String())
+		}
+		for _, userCustomRole := range userCustomRoles {
+			if userCustomRole.RoleID == customRole.ID {
+				record.UserIDs = append(record.UserIDs, userCustomRole.UserID)
+			}
+		}
+		records = append(records, record)
+	}
+	return writeRecords(zw, manifest, customRolesFileName, records)
+}
+
+func (e *Exporter) exportProjects(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	projects, err := e.Store.ListProjects(ctx, &store.FindProject{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list projects")
+	}
+	records := make([]*projectRecord, 0, len(projects))
+	for _, project := range projects {
+		record := &projectRecord{
+			ID:          project.ID,
+			RowStatus:   project.RowStatus.String(),
+			CreatedTs:   project.CreatedTs,
+			UpdatedTs:   project.UpdatedTs,
+			Key:         project.Key,
+			Name:        project.Name,
+			Description: project.Description,
+			Members:     []*projectMemberRecord{},
+		}
+		payload, err := protojson.Marshal(project.Payload)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal payload of project %s", project.Key)
+		}
+		record.Payload = payload
+		members, err := e.Store.ListProjectMembers(ctx, &store.FindProjectMember{ProjectID: &project.ID})
+		if err != nil {
+			return errors.Wrapf(err, "failed to list members of project %s", project.Key)
+		}
+		for _, member := range members {
+			record.Members = append(record.Members, &projectMemberRecord{
+				UserID: member.UserID,
+				Role:   string(member.Role),
+			})
+		}
+		records = append(records, record)
+	}
+	return writeRecords(zw, manifest, projectsFileName, records)
+}
+
+func (e *Exporter) exportMemos(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	memos, err := e.Store.ListMemos(ctx, &store.FindMemo{
+		OrderByTimeAsc: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list memos")
+	}
+	records := make([]*memoRecord, 0, len(memos))
+	for _, memo := range memos {
+		record := &memoRecord{
+			ID:         memo.ID,
+			UID:        memo.UID,
+			RowStatus:  memo.RowStatus.String(),
+			CreatorID:  memo.CreatorID,
+			CreatedTs:  memo.CreatedTs,
+			UpdatedTs:  memo.UpdatedTs,
+			Content:    memo.Content,
+			Visibility: memo.Visibility.String(),
+			Pinned:     memo.Pinned,
+			ProjectID:  memo.ProjectID,
+		}
+		if memo.Payload != nil {
+			payload, err := protojson.Marshal(memo.Payload)
+			if err != nil {
+				return errors.Wrapf(err, "failed to marshal payload of memo %d", memo.ID)
+			}
+			record.Payload = payload
+		}
+		records = append(records, record)
+	}
+	return writeRecords(zw, manifest, memosFileName, records)
+}
+
+func (e *Exporter) exportMemoRelations(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	memoRelations, err := e.Store.ListMemoRelations(ctx, &store.FindMemoRelation{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list memo relations")
+	}
+	records := make([]*memoRelationRecord, 0, len(memoRelations))
+	for _, memoRelation := range memoRelations {
+		records = append(records, &memoRelationRecord{
+			MemoID:        memoRelation.MemoID,
+			RelatedMemoID: memoRelation.RelatedMemoID,
+			Type:          string(memoRelation.Type),
+		})
+	}
+	return writeRecords(zw, manifest, memoRelationsFileName, records)
+}
+
+func (e *Exporter) exportReactions(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	reactions, err := e.Store.ListReactions(ctx, &store.FindReaction{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list reactions")
+	}
+	records := make([]*reactionRecord, 0, len(reactions))
+	for _, reaction := range reactions {
+		records = append(records, &reactionRecord{
+			ID:           reaction.ID,
+			CreatedTs:    reaction.CreatedTs,
+			CreatorID:    reaction.CreatorID,
+			ContentID:    reaction.ContentID,
+			ReactionType: reaction.ReactionType,
+		})
+	}
+	return writeRecords(zw, manifest, reactionsFileName, records)
+}
+
+func (e *Exporter) exportResources(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	limit := math.MaxInt32
+	resources, err := e.Store.ListResources(ctx, &store.FindResource{
+		Limit: &limit,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list resources")
+	}
+	records := make([]*resourceRecord, 0, len(resources))
+	for _, resource := range resources {
+		record := &resourceRecord{
+			ID:          resource.ID,
+			UID:         resource.UID,
+			CreatorID:   resource.CreatorID,
+			CreatedTs:   resource.CreatedTs,
+			UpdatedTs:   resource.UpdatedTs,
+			Filename:    resource.Filename,
+			Type:        resource.Type,
+			Size:        resource.Size,
+			StorageType: resource.StorageType.String(),
+			Reference:   resource.Reference,
+			MemoID:      resource.MemoID,
+		}
+		if resource.Payload != nil {
+			payload, err := protojson.Marshal(resource.Payload)
+			if err != nil {
+				return errors.Wrapf(err, "failed to marshal payload of resource %d", resource.ID)
+			}
+			record.Payload = payload
+		}
+		if hasResourceBlob(resource) {
+			blob, err := e.readResourceBlob(ctx, resource)
+			if err != nil {
+				// A missing file shouldn't abort the whole export, keep the metadata and move on.
+				slog.Warn("failed to read resource blob", slog.String("uid", resource.UID), slog.Any("error", err))
+			} else {
+				blobWriter, err := zw.Create(resourceBlobDir + resource.UID)
+				if err != nil {
+					return errors.Wrapf(err, "failed to create blob of resource %s", resource.UID)
+				}
+				if _, err := blobWriter.Write(blob); err != nil {
+					return errors.Wrapf(err, "failed to write blob of resource %s", resource.UID)
+				}
+				record.HasBlob = true
+			}
+		}
+		records = append(records, record)
+	}
+	return writeRecords(zw, manifest, resourcesFileName, records)
+}
+
+func (e *Exporter) exportTickets(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	tickets, err := e.Store.ListTickets(ctx, &store.FindTicket{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list tickets")
+	}
+	records := make([]*ticketRecord, 0, len(tickets))
+	// Tickets are listed newest first, write them in creation order.
+	for i := len(tickets) - 1; i >= 0; i-- {
+		ticket := tickets[i]
+		records = append(records, &ticketRecord{
+			ID:          ticket.ID,
+			Title:       ticket.Title,
+			Description: ticket.Description,
+			Status:      string(ticket.Status),
+			Priority:    string(ticket.Priority),
+			CreatorID:   ticket.CreatorID,
+			AssigneeID:  ticket.AssigneeID,
+			CreatedTs:   ticket.CreatedTs,
+			UpdatedTs:   ticket.UpdatedTs,
+			Type:        ticket.Type,
+			Tags:        ticket.Tags,
+			ProjectID:   ticket.ProjectID,
+			Number:      ticket.Number,
+			Fields:      ticket.Fields,
+		})
+	}
+	return writeRecords(zw, manifest, ticketsFileName, records)
+}
+
+func (e *Exporter) exportNotifications(ctx context.Context, zw *zip.Writer, manifest *Manifest) error {
+	notifications, err := e.Store.ListNotifications(ctx, &store.FindNotification{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list notifications")
+	}
+	records := make([]*notificationRecord, 0, len(notifications))
+	for i := len(notifications) - 1; i >= 0; i-- {
+		notification := notifications[i]
+		records = append(records, &notificationRecord{
+			ID:          notification.ID,
+			InitiatorID: notification.InitiatorID,
+			ReceiverID:  notification.ReceiverID,
+			TicketURL:   notification.TicketURL,
+			CreatedTs:   notification.CreatedTs,
+			IsRead:      notification.IsRead,
+		})
+	}
+	return writeRecords(zw, manifest, notificationsFileName, records)
+}
+
+// readResourceBlob returns the content of the resource. The content of content addressed resources
+// is read from their shared blob.
+func (e *Exporter) readResourceBlob(ctx context.Context, resource *store.Resource) ([]byte, error) {
+	if hash := resource.Payload.GetContentHash(); hash != "" {
+		return e.Store.ReadResourceBlob(ctx, hash)
+	}
+	if resource.StorageType == storepb.ResourceStorageType_LOCAL {
+		p, err := localResourcePath(e.Profile, resource.Reference)
+		if err != nil {
+			return nil, err
+		}
+		return os.ReadFile(p)
+	}
+	resource, err := e.Store.GetResource(ctx, &store.FindResource{
+		ID:      &resource.ID,
+		GetBlob: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, errors.New("resource not found")
+	}
+	return resource.Blob, nil
+}
+
+// localResourcePath resolves the reference of a local resource against the data directory.
+// References resolving outside of the data directory are rejected, so that an archive can't
+// be used to read or write arbitrary files.
+func localResourcePath(profile *profile.Profile, reference string) (string, error) {
+	p := filepath.FromSlash(reference)
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(profile.Data, p)
+	}
+	rel, err := filepath.Rel(profile.Data, p)
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", errors.Errorf("reference %q is outside of the data directory", reference)
+	}
+	return p, nil
+}
+
+func writeRecords[T any](zw *zip.Writer, manifest *Manifest, name string, records []T) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", name)
+	}
+	encoder := json.NewEncoder(w)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return errors.Wrapf(err, "failed to write %s", name)
+		}
+	}
+	manifest.Counts[name] = len(records)
+	return nil
+}
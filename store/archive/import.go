@@ -0,0 +1,790 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/usememos/memos/internal/profile"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+var protojsonUnmarshaler = protojson.UnmarshalOptions{
+	AllowPartial:   true,
+	DiscardUnknown: true,
+}
+
+// Importer restores an archive into an empty workspace.
+type Importer struct {
+	Store   *store.Store
+	Profile *profile.Profile
+
+	files map[string]*zip.File
+	// ID mappings from the source instance to the target database.
+	userIDs    map[int32]int32
+	memoIDs    map[int32]int32
+	ticketIDs  map[int32]int32
+	projectIDs map[int32]int32
+	// memoPayloads are the payloads of the imported memos with content relations, by memo id.
+	// The content relations hold source memo ids until the memo relations are imported.
+	memoPayloads map[int32]*storepb.MemoPayload
+	// rollbacks remove the records created so far when the import fails.
+	rollbacks []func(context.Context) error
+}
+
+func NewImporter(store *store.Store, profile *profile.Profile) *Importer {
+	return &Importer{
+		Store:   store,
+		Profile: profile,
+	}
+}
+
+// Import reads the archive from r and writes its records into the store.
+// The target workspace must not have any user yet. If the import fails, the records
+// created so far are removed again so that it can be retried.
+func (i *Importer) Import(ctx context.Context, r io.ReaderAt, size int64) (*Manifest, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open archive")
+	}
+	i.files = map[string]*zip.File{}
+	for _, file := range zr.File {
+		i.files[file.Name] = file
+	}
+	i.userIDs = map[int32]int32{}
+	i.memoIDs = map[int32]int32{}
+	i.ticketIDs = map[int32]int32{}
+	i.projectIDs = map[int32]int32{}
+	i.memoPayloads = map[int32]*storepb.MemoPayload{}
+	i.rollbacks = nil
+
+	manifest, err := i.readManifest()
+	if err != nil {
+		return nil, err
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > FormatVersion {
+		return nil, errors.Errorf("unsupported archive format version %d", manifest.FormatVersion)
+	}
+
+	users, err := i.Store.ListUsers(ctx, &store.FindUser{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list users")
+	}
+	if len(users) > 0 {
+		return nil, errors.New("the target workspace is not empty")
+	}
+
+	steps := []func(context.Context) error{
+		i.importWorkspaceSettings,
+		i.importUsers,
+		i.importUserSettings,
+		i.importIdentityProviders,
+		i.importWebhooks,
+		i.importCustomRoles,
+		i.importSharedTicketViews,
+		i.importProjects,
+		i.importMemos,
+		i.importMemoRelations,
+		i.importReactions,
+		i.importResources,
+		i.importTickets,
+		i.importNotifications,
+	}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			i.rollback(ctx)
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// onRollback registers fn to remove a record created by the import if the import fails.
+func (i *Importer) onRollback(fn func(context.Context) error) {
+	i.rollbacks = append(i.rollbacks, fn)
+}
+
+// rollback removes the records created so far, newest first. Workspace settings are left as they are,
+// importing the archive again overwrites them.
+func (i *Importer) rollback(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+	for j := len(i.rollbacks) - 1; j >= 0; j-- {
+		if err := i.rollbacks[j](ctx); err != nil {
+			slog.Warn("failed to roll back the import", slog.Any("error", err))
+		}
+	}
+	i.rollbacks = nil
+}
+
+func (i *Importer) readManifest() (*Manifest, error) {
+	file, ok := i.files[manifestFileName]
+	if !ok {
+		return nil, errors.New("manifest not found, not a workspace archive")
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open manifest")
+	}
+	defer rc.Close()
+	manifest := &Manifest{}
+	if err := json.NewDecoder(rc).Decode(manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to read manifest")
+	}
+	return manifest, nil
+}
+
+func (i *Importer) importWorkspaceSettings(ctx context.Context) error {
+	return readRecords(i.files, workspaceSettingsFileName, func(record *workspaceSettingRecord) error {
+		var value proto.Message
+		switch record.Name {
+		case storepb.WorkspaceSettingKey_BASIC.String():
+			// Keep the schema version of the target database, only the secret key is carried over
+			// so that access tokens issued by the source instance stay valid.
+			archived := &storepb.WorkspaceBasicSetting{}
+			if err := protojsonUnmarshaler.Unmarshal([]byte(record.Value), archived); err != nil {
+				return errors.Wrap(err, "failed to unmarshal basic setting")
+			}
+			workspaceBasicSetting, err := i.Store.GetWorkspaceBasicSetting(ctx)
+			if err != nil {
+				return errors.Wrap(err, "failed to get workspace basic setting")
+			}
+			workspaceBasicSetting.SecretKey = archived.SecretKey
+			_, err = i.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+				Key:   storepb.WorkspaceSettingKey_BASIC,
+				Value: &storepb.WorkspaceSetting_BasicSetting{BasicSetting: workspaceBasicSetting},
+			})
+			return err
+		case storepb.WorkspaceSettingKey_GENERAL.String():
+			value = &storepb.WorkspaceGeneralSetting{}
+		case storepb.WorkspaceSettingKey_STORAGE.String():
+			value = &storepb.WorkspaceStorageSetting{}
+		case storepb.WorkspaceSettingKey_MEMO_RELATED.String():
+			value = &storepb.WorkspaceMemoRelatedSetting{}
+		case storepb.WorkspaceSettingKey_TICKET_FIELDS.String():
+			value = &storepb.WorkspaceTicketFieldsSetting{}
+		case storepb.WorkspaceSettingKey_SHARED_TICKET_VIEWS.String():
+			// The views refer to their creators, they are imported once the users are.
+			return nil
+		default:
+			_, err := i.Store.GetDriver().UpsertWorkspaceSetting(ctx, &store.WorkspaceSetting{
+				Name:        record.Name,
+				Value:       record.Value,
+				Description: record.Description,
+			})
+			return err
+		}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(record.Value), value); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal workspace setting %s", record.Name)
+		}
+		workspaceSetting := &storepb.WorkspaceSetting{
+			Key: storepb.WorkspaceSettingKey(storepb.WorkspaceSettingKey_value[record.Name]),
+		}
+		switch v := value.(type) {
+		case *storepb.WorkspaceGeneralSetting:
+			workspaceSetting.Value = &storepb.WorkspaceSetting_GeneralSetting{GeneralSetting: v}
+		case *storepb.WorkspaceStorageSetting:
+			workspaceSetting.Value = &storepb.WorkspaceSetting_StorageSetting{StorageSetting: v}
+		case *storepb.WorkspaceMemoRelatedSetting:
+			workspaceSetting.Value = &storepb.WorkspaceSetting_MemoRelatedSetting{MemoRelatedSetting: v}
+		case *storepb.WorkspaceTicketFieldsSetting:
+			workspaceSetting.Value = &storepb.WorkspaceSetting_TicketFieldsSetting{TicketFieldsSetting: v}
+		}
+		if _, err := i.Store.UpsertWorkspaceSetting(ctx, workspaceSetting); err != nil {
+			return errors.Wrapf(err, "failed to upsert workspace setting %s", record.Name)
+		}
+		return nil
+	})
+}
+
+func (i *Importer) importUsers(ctx context.Context) error {
+	return readRecords(i.files, usersFileName, func(record *userRecord) error {
+		user, err := i.Store.CreateUser(ctx, &store.User{
+			Username:     record.Username,
+			Role:         store.Role(record.Role),
+			Email:        record.Email,
+			Nickname:     record.Nickname,
+			PasswordHash: record.PasswordHash,
+			AvatarURL:    record.AvatarURL,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create user %s", record.Username)
+		}
+		i.onRollback(func(ctx context.Context) error {
+			if err := i.Store.DeleteUserSettings(ctx, &store.DeleteUserSetting{UserID: user.ID}); err != nil {
+				return errors.Wrapf(err, "failed to delete settings of user %s", record.Username)
+			}
+			return i.Store.DeleteUser(ctx, &store.DeleteUser{ID: user.ID})
+		})
+		rowStatus := store.RowStatus(record.RowStatus)
+		if _, err := i.Store.UpdateUser(ctx, &store.UpdateUser{
+			ID:          user.ID,
+			CreatedTs:   &record.CreatedTs,
+			UpdatedTs:   &record.UpdatedTs,
+			RowStatus:   &rowStatus,
+			Description: &record.Description,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to update user %s", record.Username)
+		}
+		i.userIDs[record.ID] = user.ID
+		return nil
+	})
+}
+
+func (i *Importer) importUserSettings(ctx context.Context) error {
+	return readRecords(i.files, userSettingsFileName, func(record *userSettingRecord) error {
+		userID, ok := i.userIDs[record.UserID]
+		if !ok {
+			slog.Warn("skip user setting of unknown user", slog.Int("userId", int(record.UserID)), slog.String("key", record.Key))
+			return nil
+		}
+		key, ok := storepb.UserSettingKey_value[record.Key]
+		if !ok {
+			slog.Warn("skip unknown user setting", slog.String("key", record.Key))
+			return nil
+		}
+		if storepb.UserSettingKey(key) == storepb.UserSettingKey_TICKET_VIEWS {
+			ticketViews := &storepb.TicketViewsUserSetting{}
+			if err := protojsonUnmarshaler.Unmarshal([]byte(record.Value), ticketViews); err != nil {
+				return errors.Wrap(err, "failed to unmarshal ticket views")
+			}
+			i.mapTicketViews(ticketViews.Views)
+			_, err := i.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: userID,
+				Key:    storepb.UserSettingKey_TICKET_VIEWS,
+				Value:  &storepb.UserSetting_TicketViews{TicketViews: ticketViews},
+			})
+			return err
+		}
+		_, err := i.Store.GetDriver().UpsertUserSetting(ctx, &store.UserSetting{
+			UserID: userID,
+			Key:    storepb.UserSettingKey(key),
+			Value:  record.Value,
+		})
+		return err
+	})
+}
+
+func (i *Importer) importIdentityProviders(ctx context.Context) error {
+	return readRecords(i.files, identityProvidersFileName, func(record *identityProviderRecord) error {
+		identityProvider, err := i.Store.GetDriver().CreateIdentityProvider(ctx, &store.IdentityProvider{
+			Name:             record.Name,
+			Type:             storepb.IdentityProvider_Type(storepb.IdentityProvider_Type_value[record.Type]),
+			IdentifierFilter: record.IdentifierFilter,
+			Config:           record.Config,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create identity provider %s", record.Name)
+		}
+		i.onRollback(func(ctx context.Context) error {
+			return i.Store.DeleteIdentityProvider(ctx, &store.DeleteIdentityProvider{ID: identityProvider.ID})
+		})
+		return nil
+	})
+}
+
+func (i *Importer) importWebhooks(ctx context.Context) error {
+	return readRecords(i.files, webhooksFileName, func(record *webhookRecord) error {
+		creatorID, ok := i.userIDs[record.CreatorID]
+		if !ok {
+			slog.Warn("skip webhook of unknown user", slog.String("name", record.Name))
+			return nil
+		}
+		webhook, err := i.Store.CreateWebhook(ctx, &store.Webhook{
+			CreatorID: creatorID,
+			Name:      record.Name,
+			URL:       record.URL,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create webhook %s", record.Name)
+		}
+		i.onRollback(func(ctx context.Context) error {
+			return i.Store.DeleteWebhook(ctx, &store.DeleteWebhook{ID: webhook.ID})
+		})
+		return nil
+	})
+}
+
+func (i *Importer) importCustomRoles(ctx context.Context) error {
+	return readRecords(i.files, customRolesFileName, func(record *customRoleRecord) error {
+		permissions := []store.Permission{}
+		for _, name := range record.Permissions {
+			permission := store.Permission(name)
+			if !permission.IsValid() {
+				slog.Warn("skip unknown permission", slog.String("role", record.Name), slog.String("permission", name))
+				continue
+			}
+			permissions = append(permissions, permission)
+		}
+		customRole, err := i.Store.CreateCustomRole(ctx, &store.CustomRole{
+			Name:        record.Name,
+			Description: record.Description,
+			Permissions: permissions,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create custom role %s", record.Name)
+		}
+		i.onRollback(func(ctx context.Context) error {
+			return i.Store.DeleteCustomRole(ctx, &store.DeleteCustomRole{ID: customRole.ID})
+		})
+		for _, sourceUserID := range record.UserIDs {
+			userID, ok := i.userIDs[sourceUserID]
+			if !ok {
+				continue
+			}
+			if _, err := i.Store.GetDriver().CreateUserCustomRole(ctx, &store.UserCustomRole{UserID: userID, RoleID: customRole.ID}); err != nil {
+				return errors.Wrapf(err, "failed to assign custom role %s", record.Name)
+			}
+		}
+		return nil
+	})
+}
+
+func (i *Importer) importSharedTicketViews(ctx context.Context) error {
+	return readRecords(i.files, workspaceSettingsFileName, func(record *workspaceSettingRecord) error {
+		if record.Name != storepb.WorkspaceSettingKey_SHARED_TICKET_VIEWS.String() {
+			return nil
+		}
+		sharedTicketViews := &storepb.WorkspaceSharedTicketViewsSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(record.Value), sharedTicketViews); err != nil {
+			return errors.Wrap(err, "failed to unmarshal shared ticket views")
+		}
+		i.mapTicketViews(sharedTicketViews.Views)
+		_, err := i.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+			Key:   storepb.WorkspaceSettingKey_SHARED_TICKET_VIEWS,
+			Value: &storepb.WorkspaceSetting_SharedTicketViewsSetting{SharedTicketViewsSetting: sharedTicketViews},
+		})
+		return errors.Wrap(err, "failed to upsert shared ticket views")
+	})
+}
+
+func (i *Importer) importProjects(ctx context.Context) error {
+	return readRecords(i.files, projectsFileName, func(record *projectRecord) error {
+		payload := &storepb.ProjectPayload{}
+		if len(record.Payload) > 0 {
+			if err := protojsonUnmarshaler.Unmarshal(record.Payload, payload); err != nil {
+				return errors.Wrapf(err, "failed to unmarshal payload of project %s", record.Key)
+			}
+		}
+		// Default assignees refer to users of the source instance.
+		payload.DefaultAssigneeId = i.userIDs[payload.DefaultAssigneeId]
+		for _, ticketType := range payload.TicketTypes {
+			ticketType.DefaultAssigneeId = i.userIDs[ticketType.DefaultAssigneeId]
+		}
+		project, err := i.Store.CreateProject(ctx, &store.Project{
+			Key:         record.Key,
+			Name:        record.Name,
+			Description: record.Description,
+			Payload:     payload,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create project %s", record.Key)
+		}
+		i.onRollback(func(ctx context.Context) error {
+			return i.Store.DeleteProject(ctx, &store.DeleteProject{ID: project.ID})
+		})
+		rowStatus := store.RowStatus(record.RowStatus)
+		if _, err := i.Store.UpdateProject(ctx, &store.UpdateProject{
+			ID:        project.ID,
+			UpdatedTs: &record.UpdatedTs,
+			RowStatus: &rowStatus,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to update project %s", record.Key)
+		}
+		for _, member := range record.Members {
+			userID, ok := i.userIDs[member.UserID]
+			if !ok {
+				continue
+			}
+			if _, err := i.Store.UpsertProjectMember(ctx, &store.ProjectMember{
+				ProjectID: project.ID,
+				UserID:    userID,
+				Role:      store.ProjectMemberRole(member.Role),
+			}); err != nil {
+				return errors.Wrapf(err, "failed to add member to project %s", record.Key)
+			}
+		}
+		i.projectIDs[record.ID] = project.ID
+		return nil
+	})
+}
+
+func (i *Importer) importMemos(ctx context.Context) error {
+	return readRecords(i.files, memosFileName, func(record *memoRecord) error {
+		creatorID, ok := i.userIDs[record.CreatorID]
+		if !ok {
+			slog.Warn("skip memo of unknown user", slog.String("uid", record.UID))
+			return nil
+		}
+		payload := &storepb.MemoPayload{}
+		if len(record.Payload) > 0 {
+			if err := protojsonUnmarshaler.Unmarshal(record.Payload, payload); err != nil {
+				return errors.Wrapf(err, "failed to unmarshal payload of memo %s", record.UID)
+			}
+		}
+		memo, err := i.Store.CreateMemo(ctx, &store.Memo{
+			UID:        record.UID,
+			CreatorID:  creatorID,
+			Content:    record.Content,
+			Visibility: store.Visibility(record.Visibility),
+			Payload:    payload,
+			ProjectID:  i.mapProjectID(record.ProjectID),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create memo %s", record.UID)
+		}
+		i.onRollback(func(ctx context.Context) error {
+			return i.Store.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID})
+		})
+		rowStatus := store.RowStatus(record.RowStatus)
+		if err := i.Store.UpdateMemo(ctx, &store.UpdateMemo{
+			ID:        memo.ID,
+			CreatedTs: &record.CreatedTs,
+			UpdatedTs: &record.UpdatedTs,
+			RowStatus: &rowStatus,
+			Pinned:    &record.Pinned,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to update memo %s", record.UID)
+		}
+		i.memoIDs[record.ID] = memo.ID
+		if len(payload.ContentRelations) > 0 {
+			i.memoPayloads[memo.ID] = payload
+		}
+		return nil
+	})
+}
+
+func (i *Importer) importMemoRelations(ctx context.Context) error {
+	if err := readRecords(i.files, memoRelationsFileName, func(record *memoRelationRecord) error {
+		memoID, ok := i.memoIDs[record.MemoID]
+		relatedMemoID, relatedOK := i.memoIDs[record.RelatedMemoID]
+		if !ok || !relatedOK {
+			slog.Warn("skip relation of unknown memo", slog.Int("memoId", int(record.MemoID)), slog.Int("relatedMemoId", int(record.RelatedMemoID)))
+			return nil
+		}
+		memoRelation, err := i.Store.UpsertMemoRelation(ctx, &store.MemoRelation{
+			MemoID:        memoID,
+			RelatedMemoID: relatedMemoID,
+			Type:          store.MemoRelationType(record.Type),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to upsert memo relation")
+		}
+		i.onRollback(func(ctx context.Context) error {
+			return i.Store.DeleteMemoRelation(ctx, &store.DeleteMemoRelation{
+				MemoID:        &memoRelation.MemoID,
+				RelatedMemoID: &memoRelation.RelatedMemoID,
+				Type:          &memoRelation.Type,
+			})
+		})
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Remap the content relations, which tell the relations created for the content apart.
+	for memoID, payload := range i.memoPayloads {
+		contentRelations := []int32{}
+		for _, relatedMemoID := range payload.ContentRelations {
+			if relatedMemoID, ok := i.memoIDs[relatedMemoID]; ok {
+				contentRelations = append(contentRelations, relatedMemoID)
+			}
+		}
+		payload.ContentRelations = contentRelations
+		if err := i.Store.UpdateMemo(ctx, &store.UpdateMemo{ID: memoID, Payload: payload}); err != nil {
+			return errors.Wrap(err, "failed to update memo payload")
+		}
+	}
+	return nil
+}
+
+func (i *Importer) importReactions(ctx context.Context) error {
+	return readRecords(i.files, reactionsFileName, func(record *reactionRecord) error {
+		creatorID, ok := i.userIDs[record.CreatorID]
+		if !ok {
+			return nil
+		}
+		// Reactions point at memos by name, which is built from the uid and doesn't change.
+		reaction, err := i.Store.UpsertReaction(ctx, &store.Reaction{
+			CreatorID:    creatorID,
+			ContentID:    record.ContentID,
+			ReactionType: record.ReactionType,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to upsert reaction")
+		}
+		i.onRollback(func(ctx context.Context) error {
+			return i.Store.DeleteReaction(ctx, &store.DeleteReaction{ID: reaction.ID})
+		})
+		return nil
+	})
+}
+
+func (i *Importer) importResources(ctx context.Context) error {
+	workspaceStorageSetting, err := i.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	return readRecords(i.files, resourcesFileName, func(record *resourceRecord) error {
+		creatorID, ok := i.userIDs[record.CreatorID]
+		if !ok {
+			slog.Warn("skip resource of unknown user", slog.String("uid", record.UID))
+			return nil
+		}
+		create := &store.Resource{
+			UID:         record.UID,
+			CreatorID:   creatorID,
+			Filename:    record.Filename,
+			Type:        record.Type,
+			Size:        record.Size,
+			StorageType: storepb.ResourceStorageType(storepb.ResourceStorageType_value[record.StorageType]),
+			Reference:   record.Reference,
+		}
+		if len(record.Payload) > 0 {
+			create.Payload = &storepb.ResourcePayload{}
+			if err := protojsonUnmarshaler.Unmarshal(record.Payload, create.Payload); err != nil {
+				return errors.Wrapf(err, "failed to unmarshal payload of resource %s", record.UID)
+			}
+		}
+		if record.MemoID != nil {
+			if memoID, ok := i.memoIDs[*record.MemoID]; ok {
+				create.MemoID = &memoID
+			}
+		}
+		if record.HasBlob {
+			blob, err := i.readBlob(record.UID)
+			if err != nil {
+				return err
+			}
+			if hash := create.Payload.GetContentHash(); hash != "" {
+				if store.HashResourceContent(blob) != hash {
+					return errors.Errorf("blob of resource %s does not match its hash", record.UID)
+				}
+				// Store the content again, so that the blob shared by the resources exists in the target.
+				if err := i.Store.PutResourceBlob(ctx, create, bytes.NewReader(blob), workspaceStorageSetting); err != nil {
+					return errors.Wrapf(err, "failed to put blob of resource %s", record.UID)
+				}
+			} else if create.StorageType == storepb.ResourceStorageType_LOCAL {
+				p, err := localResourcePath(i.Profile, record.Reference)
+				if err != nil {
+					return errors.Wrapf(err, "invalid reference of resource %s", record.UID)
+				}
+				if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+					return errors.Wrap(err, "failed to create directory")
+				}
+				if err := os.WriteFile(p, blob, 0644); err != nil {
+					return errors.Wrapf(err, "failed to write file of resource %s", record.UID)
+				}
+				i.onRollback(func(context.Context) error {
+					if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+						return err
+					}
+					return nil
+				})
+			} else {
+				create.Blob = blob
+			}
+		}
+		resource, err := i.Store.CreateResource(ctx, create)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create resource %s", record.UID)
+		}
+		i.onRollback(func(ctx context.Context) error {
+			return i.Store.DeleteResource(ctx, &store.DeleteResource{ID: resource.ID})
+		})
+		return i.Store.UpdateResource(ctx, &store.UpdateResource{
+			ID:        resource.ID,
+			CreatedTs: &record.CreatedTs,
+			UpdatedTs: &record.UpdatedTs,
+		})
+	})
+}
+
+func (i *Importer) importTickets(ctx context.Context) error {
+	// The definitions of the custom fields are imported with the workspace settings.
+	workspaceTicketFieldsSetting, err := i.Store.GetWorkspaceTicketFieldsSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace ticket fields setting")
+	}
+	return readRecords(i.files, ticketsFileName, func(record *ticketRecord) error {
+		creatorID, ok := i.userIDs[record.CreatorID]
+		if !ok {
+			slog.Warn("skip ticket of unknown user", slog.Int("id", int(record.ID)))
+			return nil
+		}
+		create := &store.Ticket{
+			Title:       record.Title,
+			Description: record.Description,
+			Status:      store.TicketStatus(record.Status),
+			Priority:    store.TicketPriority(record.Priority),
+			CreatorID:   creatorID,
+			CreatedTs:   record.CreatedTs,
+			UpdatedTs:   record.UpdatedTs,
+			Type:        record.Type,
+			Tags:        record.Tags,
+			ProjectID:   i.mapProjectID(record.ProjectID),
+		}
+		// Keep the number, so that the keys of the tickets, e.g. API-42, still refer to them.
+		if create.ProjectID != nil {
+			create.Number = record.Number
+		}
+		if create.Tags == nil {
+			create.Tags = []string{}
+		}
+		if len(record.Fields) > 0 {
+			create.Fields = i.mapTicketFields(workspaceTicketFieldsSetting.Fields, record.Fields)
+		}
+		if record.AssigneeID != nil {
+			if assigneeID, ok := i.userIDs[*record.AssigneeID]; ok {
+				create.AssigneeID = &assigneeID
+			}
+		}
+		ticket, err := i.Store.CreateTicket(ctx, create)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create ticket %d", record.ID)
+		}
+		i.onRollback(func(ctx context.Context) error {
+			return i.Store.DeleteTicket(ctx, &store.DeleteTicket{ID: ticket.ID})
+		})
+		i.ticketIDs[record.ID] = ticket.ID
+		return nil
+	})
+}
+
+func (i *Importer) importNotifications(ctx context.Context) error {
+	return readRecords(i.files, notificationsFileName, func(record *notificationRecord) error {
+		initiatorID, ok := i.userIDs[record.InitiatorID]
+		receiverID, receiverOK := i.userIDs[record.ReceiverID]
+		if !ok || !receiverOK {
+			return nil
+		}
+		notification, err := i.Store.CreateNotification(ctx, &store.Notification{
+			InitiatorID: initiatorID,
+			ReceiverID:  receiverID,
+			TicketURL:   i.remapTicketURL(record.TicketURL),
+			CreatedTs:   record.CreatedTs,
+			IsRead:      record.IsRead,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to create notification")
+		}
+		i.onRollback(func(ctx context.Context) error {
+			return i.Store.DeleteNotification(ctx, &store.DeleteNotification{ID: notification.ID})
+		})
+		return nil
+	})
+}
+
+// remapTicketURL rewrites `/tickets/{id}` links to the imported ticket ID.
+// Memo links are built from the memo uid and are kept as is.
+func (i *Importer) remapTicketURL(ticketURL string) string {
+	const ticketURLPrefix = "/tickets/"
+	if !strings.HasPrefix(ticketURL, ticketURLPrefix) {
+		return ticketURL
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(ticketURL, ticketURLPrefix))
+	if err != nil {
+		return ticketURL
+	}
+	ticketID, ok := i.ticketIDs[int32(id)]
+	if !ok {
+		return ticketURL
+	}
+	return ticketURLPrefix + strconv.Itoa(int(ticketID))
+}
+
+func (i *Importer) readBlob(uid string) ([]byte, error) {
+	file, ok := i.files[resourceBlobDir+uid]
+	if !ok {
+		return nil, errors.Errorf("blob of resource %s not found", uid)
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open blob of resource %s", uid)
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// readRecords decodes the JSON lines file name and calls fn for each record.
+// A file missing from the archive is treated as empty.
+func readRecords[T any](files map[string]*zip.File, name string, fn func(*T) error) error {
+	file, ok := files[name]
+	if !ok {
+		return nil
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", name)
+	}
+	defer rc.Close()
+
+	decoder := json.NewDecoder(rc)
+	for {
+		record := new(T)
+		if err := decoder.Decode(record); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to read %s", name)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+// mapProjectID returns the ID of the imported project with the source ID, or nil if there is none.
+func (i *Importer) mapProjectID(sourceID *int32) *int32 {
+	if sourceID == nil {
+		return nil
+	}
+	projectID, ok := i.projectIDs[*sourceID]
+	if !ok {
+		return nil
+	}
+	return &projectID
+}
+
+// mapTicketFields returns the values of the custom fields with the users of USER fields mapped to
+// the imported users. Values of users that were not imported are dropped.
+func (i *Importer) mapTicketFields(definitions []*storepb.TicketField, fields map[string]any) map[string]any {
+	mapped := map[string]any{}
+	for key, value := range fields {
+		index := slices.IndexFunc(definitions, func(field *storepb.TicketField) bool {
+			return field.Key == key
+		})
+		if index >= 0 && definitions[index].Type == storepb.TicketField_USER {
+			sourceID, ok := value.(float64)
+			if !ok {
+				continue
+			}
+			userID, ok := i.userIDs[int32(sourceID)]
+			if !ok {
+				continue
+			}
+			value = userID
+		}
+		mapped[key] = value
+	}
+	return mapped
+}
+
+// mapTicketViews maps the creators of the views to the imported users.
+func (i *Importer) mapTicketViews(views []*storepb.TicketView) {
+	for _, view := range views {
+		view.CreatorId = i.userIDs[view.CreatorId]
+	}
+}
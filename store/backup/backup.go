@@ -0,0 +1,237 @@
+// Package backup takes online snapshots of sqlite databases.
+//
+// Snapshots are written with `VACUUM INTO`, which produces a consistent, compacted copy of
+// the database while the server keeps serving requests. They are kept in the `backups`
+// directory under the data directory and optionally uploaded to the S3 storage configured
+// for the workspace.
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	// Import the SQLite driver to read snapshots.
+	_ "modernc.org/sqlite"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/plugin/storage/s3"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// DirName is the directory under the data directory holding the snapshots.
+	DirName = "backups"
+	// DefaultKeep is the number of snapshots kept by default.
+	DefaultKeep = 7
+
+	filePrefix = "memos_"
+	fileSuffix = ".db"
+	// timeLayout has nanoseconds, so that snapshots taken within the same second get distinct names.
+	// Names without them, written by older versions, are parsed as well.
+	timeLayout = "20060102T150405.000000000Z"
+	// legacyTimeLayout is the layout of names written by older versions.
+	legacyTimeLayout = "20060102T150405Z"
+)
+
+// Backup is a snapshot file in the backup directory.
+type Backup struct {
+	Name      string
+	Size      int64
+	CreatedTs int64
+}
+
+type Manager struct {
+	Store   *store.Store
+	Profile *profile.Profile
+	// Keep is the number of snapshots kept in the backup directory, older ones are removed.
+	Keep int
+	// Upload uploads every new snapshot to the S3 storage of the workspace.
+	Upload bool
+}
+
+func NewManager(store *store.Store, profile *profile.Profile) *Manager {
+	manager := &Manager{
+		Store:   store,
+		Profile: profile,
+		Keep:    DefaultKeep,
+		Upload:  profile.BackupUpload,
+	}
+	if profile.BackupKeep > 0 {
+		manager.Keep = profile.BackupKeep
+	}
+	return manager
+}
+
+// Dir returns the backup directory.
+func (m *Manager) Dir() string {
+	return filepath.Join(m.Profile.Data, DirName)
+}
+
+// Create takes a snapshot of the database and rotates old snapshots.
+func (m *Manager) Create(ctx context.Context) (*Backup, error) {
+	if m.Profile.Driver != "sqlite" {
+		return nil, errors.Errorf("backups are not supported for driver %s", m.Profile.Driver)
+	}
+	if err := os.MkdirAll(m.Dir(), os.ModePerm); err != nil {
+		return nil, errors.Wrap(err, "failed to create backup directory")
+	}
+
+	createdTime := time.Now().UTC()
+	name := filePrefix + createdTime.Format(timeLayout) + fileSuffix
+	path := filepath.Join(m.Dir(), name)
+	if _, err := m.Store.GetDriver().GetDB().ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return nil, errors.Wrap(err, "failed to write snapshot")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to stat snapshot")
+	}
+	backup := &Backup{
+		Name:      name,
+		Size:      info.Size(),
+		CreatedTs: createdTime.Unix(),
+	}
+
+	if m.Upload {
+		if err := m.upload(ctx, backup); err != nil {
+			// Keep the local snapshot even if the upload fails.
+			slog.Error("failed to upload snapshot", slog.String("name", backup.Name), slog.String("error", err.Error()))
+		}
+	}
+	if err := m.rotate(); err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
+
+// List returns the snapshots in the backup directory, newest first.
+func (m *Manager) List() ([]*Backup, error) {
+	entries, err := os.ReadDir(m.Dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Backup{}, nil
+		}
+		return nil, errors.Wrap(err, "failed to read backup directory")
+	}
+
+	backups := []*Backup{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		createdTime, ok := parseName(entry.Name())
+		if !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to stat snapshot")
+		}
+		backups = append(backups, &Backup{
+			Name:      entry.Name(),
+			Size:      info.Size(),
+			CreatedTs: createdTime.Unix(),
+		})
+	}
+	slices.SortFunc(backups, func(a, b *Backup) int {
+		return strings.Compare(b.Name, a.Name)
+	})
+	return backups, nil
+}
+
+func (m *Manager) rotate() error {
+	if m.Keep <= 0 {
+		return nil
+	}
+	backups, err := m.List()
+	if err != nil {
+		return err
+	}
+	for _, backup := range backups[min(m.Keep, len(backups)):] {
+		if err := os.Remove(filepath.Join(m.Dir(), backup.Name)); err != nil {
+			return errors.Wrapf(err, "failed to remove snapshot %s", backup.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Manager) upload(ctx context.Context, backup *Backup) error {
+	workspaceStorageSetting, err := m.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	s3Config := workspaceStorageSetting.GetS3Config()
+	if s3Config == nil {
+		return errors.New("no S3 storage configured")
+	}
+	s3Client, err := s3.NewClient(ctx, s3Config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create S3 client")
+	}
+	file, err := os.Open(filepath.Join(m.Dir(), backup.Name))
+	if err != nil {
+		return errors.Wrap(err, "failed to open snapshot")
+	}
+	defer file.Close()
+	if _, err := s3Client.UploadObject(ctx, DirName+"/"+backup.Name, "application/vnd.sqlite3", file); err != nil {
+		return errors.Wrap(err, "failed to upload snapshot")
+	}
+	return nil
+}
+
+func parseName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileSuffix) {
+		return time.Time{}, false
+	}
+	value := strings.TrimSuffix(strings.TrimPrefix(name, filePrefix), fileSuffix)
+	for _, layout := range []string{timeLayout, legacyTimeLayout} {
+		if createdTime, err := time.Parse(layout, value); err == nil {
+			return createdTime, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ReadSchemaVersion checks the integrity of the snapshot at path and returns its schema version.
+func ReadSchemaVersion(ctx context.Context, path string) (string, error) {
+	if _, err := os.Stat(path); err != nil {
+		return "", errors.Wrap(err, "failed to access snapshot")
+	}
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?mode=ro", path))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open snapshot")
+	}
+	defer db.Close()
+
+	var result string
+	if err := db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&result); err != nil {
+		return "", errors.Wrap(err, "failed to check snapshot integrity")
+	}
+	if result != "ok" {
+		return "", errors.Errorf("snapshot is corrupted: %s", result)
+	}
+
+	var value string
+	if err := db.QueryRowContext(ctx, "SELECT value FROM system_setting WHERE name = ?", storepb.WorkspaceSettingKey_BASIC.String()).Scan(&value); err != nil {
+		return "", errors.Wrap(err, "failed to read workspace basic setting")
+	}
+	workspaceBasicSetting := &storepb.WorkspaceBasicSetting{}
+	if err := protojson.Unmarshal([]byte(value), workspaceBasicSetting); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal workspace basic setting")
+	}
+	if workspaceBasicSetting.SchemaVersion == "" {
+		return "", errors.New("snapshot has no schema version")
+	}
+	return workspaceBasicSetting.SchemaVersion, nil
+}
@@ -0,0 +1,94 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/internal/version"
+)
+
+// Restore replaces the sqlite database of the profile with the snapshot at path.
+// The snapshot must not be newer than currentSchemaVersion; older snapshots are migrated
+// on the next start. The replaced database and its write-ahead log are kept next to it with a
+// `.before-restore-*` suffix, and moved back if the snapshot can't be copied.
+// The server must not be running while restoring.
+func Restore(ctx context.Context, profile *profile.Profile, path string, currentSchemaVersion string) (string, error) {
+	if profile.Driver != "sqlite" {
+		return "", errors.Errorf("restore is not supported for driver %s", profile.Driver)
+	}
+	schemaVersion, err := ReadSchemaVersion(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	if version.IsVersionGreaterThan(schemaVersion, currentSchemaVersion) {
+		return "", errors.Errorf("snapshot schema version %s is newer than the current schema version %s", schemaVersion, currentSchemaVersion)
+	}
+
+	previousPath := ""
+	if _, err := os.Stat(profile.DSN); err == nil {
+		previousPath = fmt.Sprintf("%s.before-restore-%s", profile.DSN, time.Now().UTC().Format(timeLayout))
+		// The write-ahead log holds writes that may not be checkpointed into the database yet,
+		// so it moves along with the database to keep the replaced copy complete.
+		if err := moveDatabase(profile.DSN, previousPath); err != nil {
+			return "", errors.Wrap(err, "failed to move the current database")
+		}
+	} else {
+		// Without a database, a leftover write-ahead log doesn't belong to anything.
+		for _, suffix := range walSuffixes {
+			if err := os.Remove(profile.DSN + suffix); err != nil && !os.IsNotExist(err) {
+				return "", errors.Wrapf(err, "failed to remove %s", profile.DSN+suffix)
+			}
+		}
+	}
+	if err := copyFile(path, profile.DSN); err != nil {
+		if previousPath != "" {
+			if err := os.Remove(profile.DSN); err != nil && !os.IsNotExist(err) {
+				return "", errors.Wrapf(err, "failed to remove the partially restored database, the replaced database is kept at %s", previousPath)
+			}
+			if err := moveDatabase(previousPath, profile.DSN); err != nil {
+				return "", errors.Wrapf(err, "failed to move the replaced database back from %s", previousPath)
+			}
+		}
+		return "", err
+	}
+	return previousPath, nil
+}
+
+// walSuffixes are the suffixes of the write-ahead log files of a sqlite database.
+var walSuffixes = []string{"-wal", "-shm"}
+
+// moveDatabase renames the sqlite database at src to dst together with its write-ahead log files.
+func moveDatabase(src, dst string) error {
+	if err := os.Rename(src, dst); err != nil {
+		return err
+	}
+	for _, suffix := range walSuffixes {
+		if err := os.Rename(src+suffix, dst+suffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return errors.Wrap(err, "failed to open snapshot")
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to create database file")
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return errors.Wrap(err, "failed to copy snapshot")
+	}
+	return out.Close()
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// CustomRole is a named bundle of permissions that can be assigned to users
+// in addition to their built-in role.
+type CustomRole struct {
+	ID        int32
+	CreatedTs int64
+	UpdatedTs int64
+
+	Name        string
+	Description string
+	Permissions []Permission
+}
+
+type FindCustomRole struct {
+	ID   *int32
+	Name *string
+	// IDList filters the roles by ID when not nil.
+	IDList []int32
+}
+
+type UpdateCustomRole struct {
+	ID          int32
+	UpdatedTs   *int64
+	Name        *string
+	Description *string
+	Permissions []Permission
+}
+
+type DeleteCustomRole struct {
+	ID int32
+}
+
+// UserCustomRole assigns a custom role to a user.
+type UserCustomRole struct {
+	UserID int32
+	RoleID int32
+}
+
+type FindUserCustomRole struct {
+	UserID *int32
+	RoleID *int32
+}
+
+type DeleteUserCustomRole struct {
+	UserID *int32
+	RoleID *int32
+}
+
+func (s *Store) CreateCustomRole(ctx context.Context, create *CustomRole) (*CustomRole, error) {
+	if err := validatePermissions(create.Permissions); err != nil {
+		return nil, err
+	}
+	return s.driver.CreateCustomRole(ctx, create)
+}
+
+func (s *Store) ListCustomRoles(ctx context.Context, find *FindCustomRole) ([]*CustomRole, error) {
+	if find.IDList != nil && len(find.IDList) == 0 {
+		return []*CustomRole{}, nil
+	}
+	return s.driver.ListCustomRoles(ctx, find)
+}
+
+func (s *Store) GetCustomRole(ctx context.Context, find *FindCustomRole) (*CustomRole, error) {
+	list, err := s.ListCustomRoles(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) UpdateCustomRole(ctx context.Context, update *UpdateCustomRole) (*CustomRole, error) {
+	if update.Permissions != nil {
+		if err := validatePermissions(update.Permissions); err != nil {
+			return nil, err
+		}
+	}
+	return s.driver.UpdateCustomRole(ctx, update)
+}
+
+// DeleteCustomRole deletes the role together with its assignments.
+func (s *Store) DeleteCustomRole(ctx context.Context, delete *DeleteCustomRole) error {
+	if err := s.driver.DeleteUserCustomRole(ctx, &DeleteUserCustomRole{RoleID: &delete.ID}); err != nil {
+		return errors.Wrap(err, "failed to delete role assignments")
+	}
+	return s.driver.DeleteCustomRole(ctx, delete)
+}
+
+// ListUserCustomRoles returns the custom roles assigned to the user.
+func (s *Store) ListUserCustomRoles(ctx context.Context, userID int32) ([]*CustomRole, error) {
+	userCustomRoles, err := s.driver.ListUserCustomRoles(ctx, &FindUserCustomRole{UserID: &userID})
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := []int32{}
+	for _, userCustomRole := range userCustomRoles {
+		roleIDs = append(roleIDs, userCustomRole.RoleID)
+	}
+	return s.ListCustomRoles(ctx, &FindCustomRole{IDList: roleIDs})
+}
+
+// SetUserCustomRoles replaces the custom roles assigned to the user with roleIDs.
+func (s *Store) SetUserCustomRoles(ctx context.Context, userID int32, roleIDs []int32) error {
+	userCustomRoles, err := s.driver.ListUserCustomRoles(ctx, &FindUserCustomRole{UserID: &userID})
+	if err != nil {
+		return err
+	}
+	existing := []int32{}
+	for _, userCustomRole := range userCustomRoles {
+		existing = append(existing, userCustomRole.RoleID)
+		if !slices.Contains(roleIDs, userCustomRole.RoleID) {
+			if err := s.driver.DeleteUserCustomRole(ctx, &DeleteUserCustomRole{UserID: &userID, RoleID: &userCustomRole.RoleID}); err != nil {
+				return errors.Wrapf(err, "failed to unassign role %d", userCustomRole.RoleID)
+			}
+		}
+	}
+	for _, roleID := range roleIDs {
+		if slices.Contains(existing, roleID) {
+			continue
+		}
+		if _, err := s.driver.CreateUserCustomRole(ctx, &UserCustomRole{UserID: userID, RoleID: roleID}); err != nil {
+			return errors.Wrapf(err, "failed to assign role %d", roleID)
+		}
+		existing = append(existing, roleID)
+	}
+	return nil
+}
+
+func validatePermissions(permissions []Permission) error {
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return errors.Errorf("invalid permission %q", permission)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+// closedTicketsQuery selects the tickets closed in the range with the time they were last closed.
+const closedTicketsQuery = `
+	SELECT t.id AS id, t.created_ts AS created_ts, t.assignee_id AS assignee_id, MAX(h.created_ts) AS closed_ts
+	FROM tickets t
+	JOIN ticket_status_history h ON h.ticket_id = t.id AND h.to_status = 'CLOSED'
+	WHERE t.status = 'CLOSED' AND %s
+	GROUP BY t.id, t.created_ts, t.assignee_id
+	HAVING MAX(h.created_ts) >= ? AND MAX(h.created_ts) < ?`
+
+func (d *DB) GetTicketMetrics(ctx context.Context, find *store.FindTicketMetrics) (*store.TicketMetrics, error) {
+	metrics := &store.TicketMetrics{}
+	var err error
+	if metrics.Periods, err = d.listTicketPeriodCounts(ctx, find); err != nil {
+		return nil, err
+	}
+	closedTickets, closedTicketsArgs := ticketMetricsClosedTickets(find)
+	metrics.LeadTime, err = d.getDurationPercentiles(ctx, closedTickets, "SELECT closed_ts - created_ts AS duration FROM closed", closedTicketsArgs)
+	if err != nil {
+		return nil, err
+	}
+	metrics.CycleTime, err = d.getDurationPercentiles(ctx, closedTickets, `
+		SELECT closed.closed_ts - started.started_ts AS duration
+		FROM closed
+		JOIN (SELECT ticket_id, MIN(created_ts) AS started_ts FROM ticket_status_history WHERE to_status = 'IN_PROGRESS' GROUP BY ticket_id) started ON started.ticket_id = closed.id
+		WHERE started.started_ts <= closed.closed_ts`, closedTicketsArgs)
+	if err != nil {
+		return nil, err
+	}
+	if metrics.Throughput, err = d.listTicketThroughput(ctx, closedTickets, closedTicketsArgs); err != nil {
+		return nil, err
+	}
+	if metrics.BacklogAge, err = d.listTicketBacklogAge(ctx, find); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+func (d *DB) listTicketPeriodCounts(ctx context.Context, find *store.FindTicketMetrics) ([]*store.TicketPeriodCount, error) {
+	condition, conditionArgs := ticketMetricsCondition(find)
+	query := fmt.Sprintf(`
+		SELECT period, SUM(opened), SUM(closed) FROM (
+			SELECT (t.created_ts - ?) DIV ? AS period, 1 AS opened, 0 AS closed
+			FROM tickets t
+			WHERE t.created_ts >= ? AND t.created_ts < ? AND %s
+			UNION ALL
+			SELECT (h.created_ts - ?) DIV ? AS period, 0 AS opened, 1 AS closed
+			FROM ticket_status_history h
+			JOIN tickets t ON t.id = h.ticket_id
+			WHERE h.to_status = 'CLOSED' AND h.created_ts >= ? AND h.created_ts < ? AND %s
+		) events
+		GROUP BY period
+		ORDER BY period`, condition, condition)
+	args := []any{find.StartTs, find.PeriodSeconds, find.StartTs, find.EndTs}
+	args = append(args, conditionArgs...)
+	args = append(args, find.StartTs, find.PeriodSeconds, find.StartTs, find.EndTs)
+	args = append(args, conditionArgs...)
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.TicketPeriodCount, 0)
+	for rows.Next() {
+		count := &store.TicketPeriodCount{}
+		if err := rows.Scan(&count.Period, &count.Opened, &count.Closed); err != nil {
+			return nil, err
+		}
+		list = append(list, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// getDurationPercentiles returns the nearest-rank percentiles of the durations selected by durationsQuery
+// from the closed tickets.
+func (d *DB) getDurationPercentiles(ctx context.Context, closedTickets string, durationsQuery string, args []any) (*store.DurationPercentiles, error) {
+	query := fmt.Sprintf(`
+		WITH closed AS (%s),
+		durations AS (%s),
+		ranked AS (SELECT duration, ROW_NUMBER() OVER (ORDER BY duration) AS rn, COUNT(*) OVER () AS n FROM durations)
+		SELECT
+			COUNT(*),
+			COALESCE(MIN(CASE WHEN rn >= 0.50 * n THEN duration END), 0),
+			COALESCE(MIN(CASE WHEN rn >= 0.85 * n THEN duration END), 0),
+			COALESCE(MIN(CASE WHEN rn >= 0.95 * n THEN duration END), 0)
+		FROM ranked`, closedTickets, durationsQuery)
+	percentiles := &store.DurationPercentiles{}
+	if err := d.db.QueryRowContext(ctx, query, args...).Scan(&percentiles.Count, &percentiles.P50, &percentiles.P85, &percentiles.P95); err != nil {
+		return nil, err
+	}
+	return percentiles, nil
+}
+
+func (d *DB) listTicketThroughput(ctx context.Context, closedTickets string, args []any) ([]*store.TicketAssigneeThroughput, error) {
+	query := fmt.Sprintf(`
+		WITH closed AS (%s)
+		SELECT assignee_id, COUNT(*) FROM closed
+		GROUP BY assignee_id
+		ORDER BY COUNT(*) DESC, assignee_id ASC`, closedTickets)
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.TicketAssigneeThroughput, 0)
+	for rows.Next() {
+		throughput := &store.TicketAssigneeThroughput{}
+		var assigneeID sql.NullInt32
+		if err := rows.Scan(&assigneeID, &throughput.Closed); err != nil {
+			return nil, err
+		}
+		if assigneeID.Valid {
+			throughput.AssigneeID = &assigneeID.Int32
+		}
+		list = append(list, throughput)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (d *DB) listTicketBacklogAge(ctx context.Context, find *store.FindTicketMetrics) ([]*store.TicketBacklogAgeCount, error) {
+	condition, conditionArgs := ticketMetricsCondition(find)
+	query := fmt.Sprintf(`
+		SELECT %s AS bucket, COUNT(*) FROM (
+			SELECT ? - t.created_ts AS age
+			FROM tickets t
+			WHERE t.status != 'CLOSED' AND t.created_ts < ? AND %s
+		) backlog
+		GROUP BY bucket
+		ORDER BY bucket`, backlogAgeBucketExpr(), condition)
+	args := append([]any{find.EndTs, find.EndTs}, conditionArgs...)
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.TicketBacklogAgeCount, 0)
+	for rows.Next() {
+		count := &store.TicketBacklogAgeCount{}
+		if err := rows.Scan(&count.Bucket, &count.Count); err != nil {
+			return nil, err
+		}
+		list = append(list, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (d *DB) ListTicketStatusDeltas(ctx context.Context, find *store.FindTicketMetrics) ([]*store.TicketStatusDelta, error) {
+	condition, conditionArgs := ticketMetricsCondition(find)
+	query := fmt.Sprintf(`
+		SELECT period, status, SUM(delta) FROM (
+			SELECT CASE WHEN h.created_ts < ? THEN -1 ELSE (h.created_ts - ?) DIV ? END AS period, h.to_status AS status, 1 AS delta
+			FROM ticket_status_history h
+			JOIN tickets t ON t.id = h.ticket_id
+			WHERE h.created_ts < ? AND %s
+			UNION ALL
+			SELECT CASE WHEN h.created_ts < ? THEN -1 ELSE (h.created_ts - ?) DIV ? END AS period, h.from_status AS status, -1 AS delta
+			FROM ticket_status_history h
+			JOIN tickets t ON t.id = h.ticket_id
+			WHERE h.from_status != '' AND h.created_ts < ? AND %s
+		) deltas
+		GROUP BY period, status
+		ORDER BY period, status`, condition, condition)
+	args := []any{find.StartTs, find.StartTs, find.PeriodSeconds, find.EndTs}
+	args = append(args, conditionArgs...)
+	args = append(args, find.StartTs, find.StartTs, find.PeriodSeconds, find.EndTs)
+	args = append(args, conditionArgs...)
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.TicketStatusDelta, 0)
+	for rows.Next() {
+		delta := &store.TicketStatusDelta{}
+		if err := rows.Scan(&delta.Period, &delta.Status, &delta.Delta); err != nil {
+			return nil, err
+		}
+		list = append(list, delta)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (d *DB) ListMemoActivity(ctx context.Context, find *store.FindMemoActivity) ([]*store.MemoActivityCount, error) {
+	memoQuery, args, err := d.buildMemoListQuery(find.MemoFind)
+	if err != nil {
+		return nil, err
+	}
+	// 1970-01-01 was a Thursday, so the weekday of a day since the epoch is offset by 4 from Sunday.
+	query := `
+		SELECT ((m.created_ts + ?) DIV 86400 + 4) % 7 AS weekday, ((m.created_ts + ?) % 86400) DIV 3600 AS hour, COUNT(*)
+		FROM (` + memoQuery + `) m
+		WHERE m.created_ts >= ? AND m.created_ts < ?
+		GROUP BY weekday, hour
+		ORDER BY weekday, hour`
+	args = append([]any{find.UTCOffset, find.UTCOffset}, args...)
+	args = append(args, find.StartTs, find.EndTs)
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.MemoActivityCount, 0)
+	for rows.Next() {
+		count := &store.MemoActivityCount{}
+		if err := rows.Scan(&count.Weekday, &count.Hour, &count.Count); err != nil {
+			return nil, err
+		}
+		list = append(list, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ticketMetricsCondition returns the condition on the tickets `t` the metrics are computed for.
+func ticketMetricsCondition(find *store.FindTicketMetrics) (string, []any) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ProjectID != nil {
+		where, args = append(where, "t.project_id = ?"), append(args, *find.ProjectID)
+	}
+	if find.VisibleProjectIDs != nil {
+		placeholders := []string{}
+		for _, id := range find.VisibleProjectIDs {
+			placeholders, args = append(placeholders, "?"), append(args, id)
+		}
+		if len(placeholders) == 0 {
+			where = append(where, "t.project_id IS NULL")
+		} else {
+			where = append(where, fmt.Sprintf("(t.project_id IS NULL OR t.project_id IN (%s))", strings.Join(placeholders, ", ")))
+		}
+	}
+	return strings.Join(where, " AND "), args
+}
+
+// ticketMetricsClosedTickets returns the query of the tickets closed in the range and its arguments.
+func ticketMetricsClosedTickets(find *store.FindTicketMetrics) (string, []any) {
+	condition, args := ticketMetricsCondition(find)
+	return fmt.Sprintf(closedTicketsQuery, condition), append(args, find.StartTs, find.EndTs)
+}
+
+// backlogAgeBucketExpr returns the expression of the bucket of the backlog age histogram the `age` falls in.
+func backlogAgeBucketExpr() string {
+	cases := []string{}
+	for i := len(store.TicketBacklogAgeBuckets) - 1; i > 0; i-- {
+		cases = append(cases, fmt.Sprintf("WHEN age >= %d THEN %d", store.TicketBacklogAgeBuckets[i], i))
+	}
+	return "CASE " + strings.Join(cases, " ") + " ELSE 0 END"
+}
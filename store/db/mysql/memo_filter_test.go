@@ -95,6 +95,16 @@ func TestConvertExprToSQL(t *testing.T) {
 			want:   "UNIX_TIMESTAMP(`memo`.`created_ts`) > ?",
 			args:   []any{time.Now().Unix() - 60*60*24},
 		},
+		{
+			filter: `mentions == "alice"`,
+			want:   "JSON_CONTAINS(JSON_EXTRACT(`memo`.`payload`, '$.property.mentions'), CAST((SELECT `user`.`id` FROM `user` WHERE `user`.`username` = ?) AS JSON))",
+			args:   []any{"alice"},
+		},
+		{
+			filter: `references_ticket == 42`,
+			want:   "JSON_CONTAINS(JSON_EXTRACT(`memo`.`payload`, '$.property.ticketReferences'), CAST(? AS JSON))",
+			args:   []any{int64(42)},
+		},
 	}
 
 	for _, tt := range tests {
@@ -97,3 +97,8 @@ func (d *DB) UpdateNotification(ctx context.Context, update *store.UpdateNotific
 	}
 	return list[0], nil
 }
+
+func (d *DB) DeleteNotification(ctx context.Context, delete *store.DeleteNotification) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `notifications` WHERE `id` = ?", delete.ID)
+	return err
+}
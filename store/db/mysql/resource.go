@@ -156,6 +156,9 @@ func (d *DB) UpdateResource(ctx context.Context, update *store.UpdateResource) e
 	if v := update.UID; v != nil {
 		set, args = append(set, "`uid` = ?"), append(args, *v)
 	}
+	if v := update.CreatedTs; v != nil {
+		set, args = append(set, "`created_ts` = FROM_UNIXTIME(?)"), append(args, *v)
+	}
 	if v := update.UpdatedTs; v != nil {
 		set, args = append(set, "`updated_ts` = FROM_UNIXTIME(?)"), append(args, *v)
 	}
@@ -165,6 +168,14 @@ func (d *DB) UpdateResource(ctx context.Context, update *store.UpdateResource) e
 	if v := update.MemoID; v != nil {
 		set, args = append(set, "`memo_id` = ?"), append(args, *v)
 	}
+	if v := update.StorageType; v != nil {
+		storageType := ""
+		if *v != storepb.ResourceStorageType_RESOURCE_STORAGE_TYPE_UNSPECIFIED {
+			storageType = v.String()
+		}
+		set, args = append(set, "`storage_type` = ?"), append(args, storageType)
+		set, args = append(set, "`blob` = ?"), append(args, update.Blob)
+	}
 	if v := update.Reference; v != nil {
 		set, args = append(set, "`reference` = ?"), append(args, *v)
 	}
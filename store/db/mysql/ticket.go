@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -9,6 +10,10 @@ import (
 )
 
 func (d *DB) CreateTicket(ctx context.Context, create *store.Ticket) (*store.Ticket, error) {
+	fields, err := marshalTicketFields(create.Fields)
+	if err != nil {
+		return nil, err
+	}
 	stmt := `
 		INSERT INTO tickets (
 			title,
@@ -18,9 +23,15 @@ func (d *DB) CreateTicket(ctx context.Context, create *store.Ticket) (*store.Tic
 			creator_id,
 			assignee_id,
 			created_ts,
-			updated_ts
+			updated_ts,
+			project_id,
+			number,
+			type,
+			fields
 		)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, CASE WHEN ? > 0 THEN ? ELSE COALESCE(MAX(number), 0) + 1 END, ?, ?
+		FROM tickets
+		WHERE project_id = ?
 	`
 	result, err := d.db.ExecContext(
 		ctx,
@@ -33,6 +44,12 @@ func (d *DB) CreateTicket(ctx context.Context, create *store.Ticket) (*store.Tic
 		create.AssigneeID,
 		create.CreatedTs,
 		create.UpdatedTs,
+		create.ProjectID,
+		create.Number,
+		create.Number,
+		create.Type,
+		fields,
+		create.ProjectID,
 	)
 	if err != nil {
 		return nil, err
@@ -43,7 +60,14 @@ func (d *DB) CreateTicket(ctx context.Context, create *store.Ticket) (*store.Tic
 		return nil, err
 	}
 	create.ID = int32(id)
+	if create.ProjectID == nil {
+		return create, nil
+	}
 
+	// The number is only meaningful for tickets in a project.
+	if err := d.db.QueryRowContext(ctx, "SELECT number FROM tickets WHERE id = ?", create.ID).Scan(&create.Number); err != nil {
+		return nil, err
+	}
 	return create, nil
 }
 
@@ -53,14 +77,34 @@ func (d *DB) ListTickets(ctx context.Context, find *store.FindTicket) ([]*store.
 		where = append(where, "id = ?")
 		args = append(args, *find.ID)
 	}
+	if v := find.IDList; len(v) != 0 {
+		placeholder := []string{}
+		for _, id := range v {
+			placeholder = append(placeholder, "?")
+			args = append(args, id)
+		}
+		where = append(where, fmt.Sprintf("id IN (%s)", strings.Join(placeholder, ",")))
+	}
 	if find.CreatorID != nil {
 		where = append(where, "creator_id = ?")
 		args = append(args, *find.CreatorID)
 	}
+	if find.Type != nil {
+		where = append(where, "type = ?")
+		args = append(args, *find.Type)
+	}
 	if find.Description != nil {
 		where = append(where, "description = ?")
 		args = append(args, *find.Description)
 	}
+	if find.ProjectID != nil {
+		where = append(where, "project_id = ?")
+		args = append(args, *find.ProjectID)
+	}
+	if find.Number != nil {
+		where = append(where, "number = ?")
+		args = append(args, *find.Number)
+	}
 
 	query := fmt.Sprintf(`
 		SELECT
@@ -72,7 +116,11 @@ func (d *DB) ListTickets(ctx context.Context, find *store.FindTicket) ([]*store.
 			creator_id,
 			assignee_id,
 			created_ts,
-			updated_ts
+			updated_ts,
+			project_id,
+			CASE WHEN project_id IS NULL THEN 0 ELSE number END,
+			type,
+			fields
 		FROM tickets
 		WHERE %s
 		ORDER BY created_ts DESC
@@ -87,6 +135,7 @@ func (d *DB) ListTickets(ctx context.Context, find *store.FindTicket) ([]*store.
 	list := make([]*store.Ticket, 0)
 	for rows.Next() {
 		var ticket store.Ticket
+		var fields string
 		if err := rows.Scan(
 			&ticket.ID,
 			&ticket.Title,
@@ -97,9 +146,14 @@ func (d *DB) ListTickets(ctx context.Context, find *store.FindTicket) ([]*store.
 			&ticket.AssigneeID,
 			&ticket.CreatedTs,
 			&ticket.UpdatedTs,
+			&ticket.ProjectID,
+			&ticket.Number,
+			&ticket.Type,
+			&fields,
 		); err != nil {
 			return nil, err
 		}
+		ticket.Fields = unmarshalTicketFields(fields)
 		list = append(list, &ticket)
 	}
 
@@ -122,7 +176,27 @@ func (d *DB) GetTicket(ctx context.Context, find *store.FindTicket) (*store.Tick
 }
 
 func (d *DB) UpdateTicket(ctx context.Context, update *store.UpdateTicket) (*store.Ticket, error) {
-	set, args := []string{}, []interface{}{}
+	set, args, err := ticketUpdateSet(update)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, update.ID)
+	stmt := fmt.Sprintf(`
+		UPDATE tickets
+		SET %s
+		WHERE id = ?
+	`, strings.Join(set, ", "))
+
+	if _, err := d.db.ExecContext(ctx, stmt, args...); err != nil {
+		return nil, err
+	}
+
+	return d.GetTicket(ctx, &store.FindTicket{ID: &update.ID})
+}
+
+// ticketUpdateSet returns the assignments of the columns changed by the update and their arguments.
+func ticketUpdateSet(update *store.UpdateTicket) ([]string, []any, error) {
+	set, args := []string{}, []any{}
 	if update.Title != nil {
 		set = append(set, "title = ?")
 		args = append(args, *update.Title)
@@ -147,22 +221,25 @@ func (d *DB) UpdateTicket(ctx context.Context, update *store.UpdateTicket) (*sto
 		set = append(set, "updated_ts = ?")
 		args = append(args, *update.UpdatedTs)
 	}
-
-	args = append(args, update.ID)
-	stmt := fmt.Sprintf(`
-		UPDATE tickets
-		SET %s
-		WHERE id = ?
-	`, strings.Join(set, ", "))
-
-	if _, err := d.db.ExecContext(ctx, stmt, args...); err != nil {
-		return nil, err
+	if update.Type != nil {
+		set = append(set, "type = ?")
+		args = append(args, *update.Type)
 	}
-
-	return d.GetTicket(ctx, &store.FindTicket{ID: &update.ID})
+	if update.Fields != nil {
+		fields, err := marshalTicketFields(update.Fields)
+		if err != nil {
+			return nil, nil, err
+		}
+		set = append(set, "fields = ?")
+		args = append(args, fields)
+	}
+	return set, args, nil
 }
 
 func (d *DB) DeleteTicket(ctx context.Context, delete *store.DeleteTicket) error {
+	if _, err := d.db.ExecContext(ctx, "DELETE FROM `ticket_status_history` WHERE `ticket_id` = ?", delete.ID); err != nil {
+		return err
+	}
 	stmt := `DELETE FROM tickets WHERE id = ?`
 	result, err := d.db.ExecContext(ctx, stmt, delete.ID)
 	if err != nil {
@@ -173,3 +250,118 @@ func (d *DB) DeleteTicket(ctx context.Context, delete *store.DeleteTicket) error
 	}
 	return nil
 }
+
+// UpdateTickets applies the updates and creates the status transitions in a single transaction.
+func (d *DB) UpdateTickets(ctx context.Context, updates []*store.UpdateTicket, transitions []*store.TicketStatusTransition) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, update := range updates {
+		set, args, err := ticketUpdateSet(update)
+		if err != nil {
+			return err
+		}
+		args = append(args, update.ID)
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`UPDATE tickets SET %s WHERE id = ?`, strings.Join(set, ", ")), args...); err != nil {
+			return err
+		}
+	}
+	for _, transition := range transitions {
+		stmt := "INSERT INTO `ticket_status_history` (`ticket_id`, `from_status`, `to_status`, `created_ts`) VALUES (?, ?, ?, ?)"
+		if _, err := tx.ExecContext(ctx, stmt, transition.TicketID, transition.FromStatus, transition.ToStatus, transition.CreatedTs); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteTickets deletes the tickets and their status history in a single transaction.
+func (d *DB) DeleteTickets(ctx context.Context, ids []int32) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	placeholder, args := []string{}, []any{}
+	for _, id := range ids {
+		placeholder = append(placeholder, "?")
+		args = append(args, id)
+	}
+	holders := strings.Join(placeholder, ",")
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM `ticket_status_history` WHERE `ticket_id` IN ("+holders+")", args...); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM `tickets` WHERE `id` IN ("+holders+")", args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (d *DB) CreateTicketStatusTransition(ctx context.Context, create *store.TicketStatusTransition) (*store.TicketStatusTransition, error) {
+	stmt := "INSERT INTO `ticket_status_history` (`ticket_id`, `from_status`, `to_status`, `created_ts`) VALUES (?, ?, ?, ?)"
+	result, err := d.db.ExecContext(ctx, stmt, create.TicketID, create.FromStatus, create.ToStatus, create.CreatedTs)
+	if err != nil {
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	create.ID = int32(id)
+	return create, nil
+}
+
+func (d *DB) ListTicketStatusTransitions(ctx context.Context, find *store.FindTicketStatusTransition) ([]*store.TicketStatusTransition, error) {
+	where, args := []string{"1=1"}, []any{}
+	if find.TicketID != nil {
+		where, args = append(where, "`ticket_id` = ?"), append(args, *find.TicketID)
+	}
+
+	query := "SELECT `id`, `ticket_id`, `from_status`, `to_status`, `created_ts` FROM `ticket_status_history` WHERE " + strings.Join(where, " AND ") + " ORDER BY `created_ts` ASC, `id` ASC"
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.TicketStatusTransition, 0)
+	for rows.Next() {
+		transition := &store.TicketStatusTransition{}
+		if err := rows.Scan(&transition.ID, &transition.TicketID, &transition.FromStatus, &transition.ToStatus, &transition.CreatedTs); err != nil {
+			return nil, err
+		}
+		list = append(list, transition)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func marshalTicketFields(fields map[string]any) (string, error) {
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	bytes, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+func unmarshalTicketFields(value string) map[string]any {
+	fields := map[string]any{}
+	if err := json.Unmarshal([]byte(value), &fields); err != nil || fields == nil {
+		return map[string]any{}
+	}
+	return fields
+}
@@ -0,0 +1,277 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+// ticketMetricsArgs collects the arguments of a query of the ticket metrics, and returns their placeholders.
+type ticketMetricsArgs struct {
+	args []any
+}
+
+func (a *ticketMetricsArgs) add(value any) string {
+	a.args = append(a.args, value)
+	return placeholder(len(a.args))
+}
+
+// condition returns the condition on the tickets `t` the metrics are computed for.
+func (a *ticketMetricsArgs) condition(find *store.FindTicketMetrics) string {
+	where := []string{"1 = 1"}
+	if find.ProjectID != nil {
+		where = append(where, "t.project_id = "+a.add(*find.ProjectID))
+	}
+	if find.VisibleProjectIDs != nil {
+		placeholders := []string{}
+		for _, id := range find.VisibleProjectIDs {
+			placeholders = append(placeholders, a.add(id))
+		}
+		if len(placeholders) == 0 {
+			where = append(where, "t.project_id IS NULL")
+		} else {
+			where = append(where, fmt.Sprintf("(t.project_id IS NULL OR t.project_id IN (%s))", strings.Join(placeholders, ", ")))
+		}
+	}
+	return strings.Join(where, " AND ")
+}
+
+// closedTickets returns the query of the tickets closed in the range with the time they were last closed.
+func (a *ticketMetricsArgs) closedTickets(find *store.FindTicketMetrics) string {
+	return `
+		SELECT t.id AS id, t.created_ts AS created_ts, t.assignee_id AS assignee_id, MAX(h.created_ts) AS closed_ts
+		FROM tickets t
+		JOIN ticket_status_history h ON h.ticket_id = t.id AND h.to_status = 'CLOSED'
+		WHERE t.status = 'CLOSED' AND ` + a.condition(find) + `
+		GROUP BY t.id, t.created_ts, t.assignee_id
+		HAVING MAX(h.created_ts) >= ` + a.add(find.StartTs) + ` AND MAX(h.created_ts) < ` + a.add(find.EndTs)
+}
+
+func (d *DB) GetTicketMetrics(ctx context.Context, find *store.FindTicketMetrics) (*store.TicketMetrics, error) {
+	metrics := &store.TicketMetrics{}
+	var err error
+	if metrics.Periods, err = d.listTicketPeriodCounts(ctx, find); err != nil {
+		return nil, err
+	}
+	metrics.LeadTime, err = d.getDurationPercentiles(ctx, find, "SELECT closed_ts - created_ts AS duration FROM closed")
+	if err != nil {
+		return nil, err
+	}
+	metrics.CycleTime, err = d.getDurationPercentiles(ctx, find, `
+		SELECT closed.closed_ts - started.started_ts AS duration
+		FROM closed
+		JOIN (SELECT ticket_id, MIN(created_ts) AS started_ts FROM ticket_status_history WHERE to_status = 'IN_PROGRESS' GROUP BY ticket_id) started ON started.ticket_id = closed.id
+		WHERE started.started_ts <= closed.closed_ts`)
+	if err != nil {
+		return nil, err
+	}
+	if metrics.Throughput, err = d.listTicketThroughput(ctx, find); err != nil {
+		return nil, err
+	}
+	if metrics.BacklogAge, err = d.listTicketBacklogAge(ctx, find); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+func (d *DB) listTicketPeriodCounts(ctx context.Context, find *store.FindTicketMetrics) ([]*store.TicketPeriodCount, error) {
+	a := &ticketMetricsArgs{}
+	query := `
+		SELECT period, SUM(opened), SUM(closed) FROM (
+			SELECT (t.created_ts - ` + a.add(find.StartTs) + `) / ` + a.add(find.PeriodSeconds) + ` AS period, 1 AS opened, 0 AS closed
+			FROM tickets t
+			WHERE t.created_ts >= ` + a.add(find.StartTs) + ` AND t.created_ts < ` + a.add(find.EndTs) + ` AND ` + a.condition(find) + `
+			UNION ALL
+			SELECT (h.created_ts - ` + a.add(find.StartTs) + `) / ` + a.add(find.PeriodSeconds) + ` AS period, 0 AS opened, 1 AS closed
+			FROM ticket_status_history h
+			JOIN tickets t ON t.id = h.ticket_id
+			WHERE h.to_status = 'CLOSED' AND h.created_ts >= ` + a.add(find.StartTs) + ` AND h.created_ts < ` + a.add(find.EndTs) + ` AND ` + a.condition(find) + `
+		) events
+		GROUP BY period
+		ORDER BY period`
+	rows, err := d.db.QueryContext(ctx, query, a.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.TicketPeriodCount, 0)
+	for rows.Next() {
+		count := &store.TicketPeriodCount{}
+		if err := rows.Scan(&count.Period, &count.Opened, &count.Closed); err != nil {
+			return nil, err
+		}
+		list = append(list, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// getDurationPercentiles returns the nearest-rank percentiles of the durations selected by durationsQuery
+// from the closed tickets.
+func (d *DB) getDurationPercentiles(ctx context.Context, find *store.FindTicketMetrics, durationsQuery string) (*store.DurationPercentiles, error) {
+	a := &ticketMetricsArgs{}
+	query := `
+		WITH closed AS (` + a.closedTickets(find) + `),
+		durations AS (` + durationsQuery + `)
+		SELECT
+			COUNT(*),
+			COALESCE(PERCENTILE_DISC(0.50) WITHIN GROUP (ORDER BY duration), 0),
+			COALESCE(PERCENTILE_DISC(0.85) WITHIN GROUP (ORDER BY duration), 0),
+			COALESCE(PERCENTILE_DISC(0.95) WITHIN GROUP (ORDER BY duration), 0)
+		FROM durations`
+	percentiles := &store.DurationPercentiles{}
+	if err := d.db.QueryRowContext(ctx, query, a.args...).Scan(&percentiles.Count, &percentiles.P50, &percentiles.P85, &percentiles.P95); err != nil {
+		return nil, err
+	}
+	return percentiles, nil
+}
+
+func (d *DB) listTicketThroughput(ctx context.Context, find *store.FindTicketMetrics) ([]*store.TicketAssigneeThroughput, error) {
+	a := &ticketMetricsArgs{}
+	query := `
+		WITH closed AS (` + a.closedTickets(find) + `)
+		SELECT assignee_id, COUNT(*) FROM closed
+		GROUP BY assignee_id
+		ORDER BY COUNT(*) DESC, assignee_id ASC`
+	rows, err := d.db.QueryContext(ctx, query, a.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.TicketAssigneeThroughput, 0)
+	for rows.Next() {
+		throughput := &store.TicketAssigneeThroughput{}
+		var assigneeID sql.NullInt32
+		if err := rows.Scan(&assigneeID, &throughput.Closed); err != nil {
+			return nil, err
+		}
+		if assigneeID.Valid {
+			throughput.AssigneeID = &assigneeID.Int32
+		}
+		list = append(list, throughput)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (d *DB) listTicketBacklogAge(ctx context.Context, find *store.FindTicketMetrics) ([]*store.TicketBacklogAgeCount, error) {
+	a := &ticketMetricsArgs{}
+	query := `
+		SELECT ` + backlogAgeBucketExpr() + ` AS bucket, COUNT(*) FROM (
+			SELECT ` + a.add(find.EndTs) + ` - t.created_ts AS age
+			FROM tickets t
+			WHERE t.status != 'CLOSED' AND t.created_ts < ` + a.add(find.EndTs) + ` AND ` + a.condition(find) + `
+		) backlog
+		GROUP BY bucket
+		ORDER BY bucket`
+	rows, err := d.db.QueryContext(ctx, query, a.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.TicketBacklogAgeCount, 0)
+	for rows.Next() {
+		count := &store.TicketBacklogAgeCount{}
+		if err := rows.Scan(&count.Bucket, &count.Count); err != nil {
+			return nil, err
+		}
+		list = append(list, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (d *DB) ListTicketStatusDeltas(ctx context.Context, find *store.FindTicketMetrics) ([]*store.TicketStatusDelta, error) {
+	a := &ticketMetricsArgs{}
+	period := func() string {
+		return `CASE WHEN h.created_ts < ` + a.add(find.StartTs) + ` THEN -1 ELSE (h.created_ts - ` + a.add(find.StartTs) + `) / ` + a.add(find.PeriodSeconds) + ` END`
+	}
+	query := `
+		SELECT period, status, SUM(delta) FROM (
+			SELECT ` + period() + ` AS period, h.to_status AS status, 1 AS delta
+			FROM ticket_status_history h
+			JOIN tickets t ON t.id = h.ticket_id
+			WHERE h.created_ts < ` + a.add(find.EndTs) + ` AND ` + a.condition(find) + `
+			UNION ALL
+			SELECT ` + period() + ` AS period, h.from_status AS status, -1 AS delta
+			FROM ticket_status_history h
+			JOIN tickets t ON t.id = h.ticket_id
+			WHERE h.from_status != '' AND h.created_ts < ` + a.add(find.EndTs) + ` AND ` + a.condition(find) + `
+		) deltas
+		GROUP BY period, status
+		ORDER BY period, status`
+	rows, err := d.db.QueryContext(ctx, query, a.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.TicketStatusDelta, 0)
+	for rows.Next() {
+		delta := &store.TicketStatusDelta{}
+		if err := rows.Scan(&delta.Period, &delta.Status, &delta.Delta); err != nil {
+			return nil, err
+		}
+		list = append(list, delta)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (d *DB) ListMemoActivity(ctx context.Context, find *store.FindMemoActivity) ([]*store.MemoActivityCount, error) {
+	memoQuery, args, err := d.buildMemoListQuery(find.MemoFind)
+	if err != nil {
+		return nil, err
+	}
+	// The placeholders of the memo query come first.
+	a := &ticketMetricsArgs{args: args}
+	utcOffset := a.add(find.UTCOffset)
+	// 1970-01-01 was a Thursday, so the weekday of a day since the epoch is offset by 4 from Sunday.
+	query := `
+		SELECT ((m.created_ts + ` + utcOffset + `) / 86400 + 4) % 7 AS weekday, ((m.created_ts + ` + utcOffset + `) % 86400) / 3600 AS hour, COUNT(*)
+		FROM (` + memoQuery + `) m
+		WHERE m.created_ts >= ` + a.add(find.StartTs) + ` AND m.created_ts < ` + a.add(find.EndTs) + `
+		GROUP BY weekday, hour
+		ORDER BY weekday, hour`
+	rows, err := d.db.QueryContext(ctx, query, a.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.MemoActivityCount, 0)
+	for rows.Next() {
+		count := &store.MemoActivityCount{}
+		if err := rows.Scan(&count.Weekday, &count.Hour, &count.Count); err != nil {
+			return nil, err
+		}
+		list = append(list, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// backlogAgeBucketExpr returns the expression of the bucket of the backlog age histogram the `age` falls in.
+func backlogAgeBucketExpr() string {
+	cases := []string{}
+	for i := len(store.TicketBacklogAgeBuckets) - 1; i > 0; i-- {
+		cases = append(cases, fmt.Sprintf("WHEN age >= %d THEN %d", store.TicketBacklogAgeBuckets[i], i))
+	}
+	return "CASE " + strings.Join(cases, " ") + " ELSE 0 END"
+}
@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateCustomRole(ctx context.Context, create *store.CustomRole) (*store.CustomRole, error) {
+	permissions, err := json.Marshal(create.Permissions)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal permissions")
+	}
+	fields := []string{"name", "description", "permissions"}
+	args := []any{create.Name, create.Description, string(permissions)}
+	stmt := "INSERT INTO custom_role (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts, updated_ts"
+	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+		&create.ID,
+		&create.CreatedTs,
+		&create.UpdatedTs,
+	); err != nil {
+		return nil, err
+	}
+	customRole := create
+	return customRole, nil
+}
+
+func (d *DB) ListCustomRoles(ctx context.Context, find *store.FindCustomRole) ([]*store.CustomRole, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "id = "+placeholder(len(args)+1)), append(args, *find.ID)
+	}
+	if find.Name != nil {
+		where, args = append(where, "name = "+placeholder(len(args)+1)), append(args, *find.Name)
+	}
+	if v := find.IDList; len(v) != 0 {
+		holders := []string{}
+		for _, id := range v {
+			holders = append(holders, placeholder(len(args)+1))
+			args = append(args, id)
+		}
+		where = append(where, fmt.Sprintf("id IN (%s)", strings.Join(holders, ", ")))
+	}
+
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT
+			id,
+			created_ts,
+			updated_ts,
+			name,
+			description,
+			permissions
+		FROM custom_role
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY id ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.CustomRole{}
+	for rows.Next() {
+		customRole := &store.CustomRole{}
+		var permissions string
+		if err := rows.Scan(
+			&customRole.ID,
+			&customRole.CreatedTs,
+			&customRole.UpdatedTs,
+			&customRole.Name,
+			&customRole.Description,
+			&permissions,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(permissions), &customRole.Permissions); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal permissions")
+		}
+		list = append(list, customRole)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateCustomRole(ctx context.Context, update *store.UpdateCustomRole) (*store.CustomRole, error) {
+	set, args := []string{}, []any{}
+	if update.UpdatedTs != nil {
+		set, args = append(set, "updated_ts = "+placeholder(len(args)+1)), append(args, *update.UpdatedTs)
+	}
+	if update.Name != nil {
+		set, args = append(set, "name = "+placeholder(len(args)+1)), append(args, *update.Name)
+	}
+	if update.Description != nil {
+		set, args = append(set, "description = "+placeholder(len(args)+1)), append(args, *update.Description)
+	}
+	if update.Permissions != nil {
+		permissions, err := json.Marshal(update.Permissions)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal permissions")
+		}
+		set, args = append(set, "permissions = "+placeholder(len(args)+1)), append(args, string(permissions))
+	}
+	if len(set) == 0 {
+		return nil, errors.New("no fields to update")
+	}
+
+	stmt := "UPDATE custom_role SET " + strings.Join(set, ", ") + " WHERE id = " + placeholder(len(args)+1) + " RETURNING id, created_ts, updated_ts, name, description, permissions"
+	args = append(args, update.ID)
+	customRole := &store.CustomRole{}
+	var permissions string
+	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+		&customRole.ID,
+		&customRole.CreatedTs,
+		&customRole.UpdatedTs,
+		&customRole.Name,
+		&customRole.Description,
+		&permissions,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(permissions), &customRole.Permissions); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal permissions")
+	}
+	return customRole, nil
+}
+
+func (d *DB) DeleteCustomRole(ctx context.Context, delete *store.DeleteCustomRole) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM custom_role WHERE id = $1", delete.ID)
+	return err
+}
+
+func (d *DB) CreateUserCustomRole(ctx context.Context, create *store.UserCustomRole) (*store.UserCustomRole, error) {
+	if _, err := d.db.ExecContext(ctx, "INSERT INTO user_custom_role (user_id, role_id) VALUES ($1, $2)", create.UserID, create.RoleID); err != nil {
+		return nil, err
+	}
+	return create, nil
+}
+
+func (d *DB) ListUserCustomRoles(ctx context.Context, find *store.FindUserCustomRole) ([]*store.UserCustomRole, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.UserID != nil {
+		where, args = append(where, "user_id = "+placeholder(len(args)+1)), append(args, *find.UserID)
+	}
+	if find.RoleID != nil {
+		where, args = append(where, "role_id = "+placeholder(len(args)+1)), append(args, *find.RoleID)
+	}
+
+	rows, err := d.db.QueryContext(ctx, "SELECT user_id, role_id FROM user_custom_role WHERE "+strings.Join(where, " AND ")+" ORDER BY user_id ASC, role_id ASC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.UserCustomRole{}
+	for rows.Next() {
+		userCustomRole := &store.UserCustomRole{}
+		if err := rows.Scan(&userCustomRole.UserID, &userCustomRole.RoleID); err != nil {
+			return nil, err
+		}
+		list = append(list, userCustomRole)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteUserCustomRole(ctx context.Context, delete *store.DeleteUserCustomRole) error {
+	where, args := []string{"1 = 1"}, []any{}
+	if delete.UserID != nil {
+		where, args = append(where, "user_id = "+placeholder(len(args)+1)), append(args, *delete.UserID)
+	}
+	if delete.RoleID != nil {
+		where, args = append(where, "role_id = "+placeholder(len(args)+1)), append(args, *delete.RoleID)
+	}
+	_, err := d.db.ExecContext(ctx, "DELETE FROM user_custom_role WHERE "+strings.Join(where, " AND "), args...)
+	return err
+}
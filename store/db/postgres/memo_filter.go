@@ -58,7 +58,7 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 			if err != nil {
 				return err
 			}
-			if !slices.Contains([]string{"creator_id", "created_ts", "updated_ts", "visibility", "content", "has_task_list"}, identifier) {
+			if !slices.Contains([]string{"creator_id", "created_ts", "updated_ts", "visibility", "content", "has_task_list", "project_id", "mentions", "references_ticket"}, identifier) {
 				return errors.Errorf("invalid identifier for %s", v.CallExpr.Function)
 			}
 			value, err := filter.GetExprValue(v.CallExpr.Args[1])
@@ -116,7 +116,7 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 					return err
 				}
 				ctx.Args = append(ctx.Args, valueStr)
-			} else if identifier == "creator_id" {
+			} else if identifier == "creator_id" || identifier == "project_id" {
 				if operator != "=" && operator != "!=" {
 					return errors.Errorf("invalid operator for %s", v.CallExpr.Function)
 				}
@@ -126,6 +126,9 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 				}
 
 				factor := "memo.creator_id"
+				if identifier == "project_id" {
+					factor = "memo.project_id"
+				}
 				if _, err := ctx.Buffer.WriteString(fmt.Sprintf("%s %s %s", factor, operator, placeholder(len(ctx.Args)+ctx.ArgsOffset+1))); err != nil {
 					return err
 				}
@@ -144,6 +147,30 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 					return err
 				}
 				ctx.Args = append(ctx.Args, valueBool)
+			} else if identifier == "mentions" {
+				if operator != "=" {
+					return errors.Errorf("invalid operator for %s", v.CallExpr.Function)
+				}
+				valueStr, ok := value.(string)
+				if !ok {
+					return errors.New("invalid string value for mentions")
+				}
+				if _, err := ctx.Buffer.WriteString(fmt.Sprintf(`memo.payload->'property'->'mentions' @> JSONB_BUILD_ARRAY((SELECT "user".id FROM "user" WHERE "user".username = %s))`, placeholder(len(ctx.Args)+ctx.ArgsOffset+1))); err != nil {
+					return err
+				}
+				ctx.Args = append(ctx.Args, valueStr)
+			} else if identifier == "references_ticket" {
+				if operator != "=" {
+					return errors.Errorf("invalid operator for %s", v.CallExpr.Function)
+				}
+				valueInt, ok := value.(int64)
+				if !ok {
+					return errors.New("invalid int value for references_ticket")
+				}
+				if _, err := ctx.Buffer.WriteString(fmt.Sprintf("memo.payload->'property'->'ticketReferences' @> JSONB_BUILD_ARRAY(%s::INTEGER)", placeholder(len(ctx.Args)+ctx.ArgsOffset+1))); err != nil {
+					return err
+				}
+				ctx.Args = append(ctx.Args, valueInt)
 			}
 		case "@in":
 			if len(v.CallExpr.Args) != 2 {
@@ -153,7 +180,7 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 			if err != nil {
 				return err
 			}
-			if !slices.Contains([]string{"tag", "visibility"}, identifier) {
+			if !slices.Contains([]string{"tag", "visibility", "project_id"}, identifier) {
 				return errors.Errorf("invalid identifier for %s", v.CallExpr.Function)
 			}
 
@@ -190,6 +217,15 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 					return err
 				}
 				ctx.Args = append(ctx.Args, values...)
+			} else if identifier == "project_id" {
+				placeholders := []string{}
+				for i := range values {
+					placeholders = append(placeholders, placeholder(len(ctx.Args)+ctx.ArgsOffset+i+1))
+				}
+				if _, err := ctx.Buffer.WriteString(fmt.Sprintf("memo.project_id IN (%s)", strings.Join(placeholders, ","))); err != nil {
+					return err
+				}
+				ctx.Args = append(ctx.Args, values...)
 			}
 		case "contains":
 			if len(v.CallExpr.Args) != 1 {
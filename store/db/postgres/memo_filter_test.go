@@ -95,6 +95,16 @@ func TestRestoreExprToSQL(t *testing.T) {
 			want:   "EXTRACT(EPOCH FROM memo.created_ts) > $1",
 			args:   []any{time.Now().Unix() - 60*60*24},
 		},
+		{
+			filter: `mentions == "alice"`,
+			want:   `memo.payload->'property'->'mentions' @> JSONB_BUILD_ARRAY((SELECT "user".id FROM "user" WHERE "user".username = $1))`,
+			args:   []any{"alice"},
+		},
+		{
+			filter: `references_ticket == 42`,
+			want:   "memo.payload->'property'->'ticketReferences' @> JSONB_BUILD_ARRAY($1::INTEGER)",
+			args:   []any{int64(42)},
+		},
 	}
 
 	for _, tt := range tests {
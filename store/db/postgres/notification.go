@@ -90,3 +90,8 @@ func (d *DB) UpdateNotification(ctx context.Context, update *store.UpdateNotific
 	}
 	return notification, nil
 }
+
+func (d *DB) DeleteNotification(ctx context.Context, delete *store.DeleteNotification) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM notifications WHERE id = $1", delete.ID)
+	return err
+}
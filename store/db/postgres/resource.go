@@ -141,6 +141,9 @@ func (d *DB) UpdateResource(ctx context.Context, update *store.UpdateResource) e
 	if v := update.UID; v != nil {
 		set, args = append(set, "uid = "+placeholder(len(args)+1)), append(args, *v)
 	}
+	if v := update.CreatedTs; v != nil {
+		set, args = append(set, "created_ts = "+placeholder(len(args)+1)), append(args, *v)
+	}
 	if v := update.UpdatedTs; v != nil {
 		set, args = append(set, "updated_ts = "+placeholder(len(args)+1)), append(args, *v)
 	}
@@ -150,6 +153,14 @@ func (d *DB) UpdateResource(ctx context.Context, update *store.UpdateResource) e
 	if v := update.MemoID; v != nil {
 		set, args = append(set, "memo_id = "+placeholder(len(args)+1)), append(args, *v)
 	}
+	if v := update.StorageType; v != nil {
+		storageType := ""
+		if *v != storepb.ResourceStorageType_RESOURCE_STORAGE_TYPE_UNSPECIFIED {
+			storageType = v.String()
+		}
+		set, args = append(set, "storage_type = "+placeholder(len(args)+1)), append(args, storageType)
+		set, args = append(set, "blob = "+placeholder(len(args)+1)), append(args, update.Blob)
+	}
 	if v := update.Reference; v != nil {
 		set, args = append(set, "reference = "+placeholder(len(args)+1)), append(args, *v)
 	}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -9,6 +10,10 @@ import (
 )
 
 func (d *DB) CreateTicket(ctx context.Context, create *store.Ticket) (*store.Ticket, error) {
+	fields, err := marshalTicketFields(create.Fields)
+	if err != nil {
+		return nil, err
+	}
 	stmt := `
 		INSERT INTO tickets (
 			title,
@@ -18,10 +23,14 @@ func (d *DB) CreateTicket(ctx context.Context, create *store.Ticket) (*store.Tic
 			creator_id,
 			assignee_id,
 			created_ts,
-			updated_ts
+			updated_ts,
+			project_id,
+			number,
+			type,
+			fields
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, CASE WHEN $9::INTEGER IS NULL THEN 0 WHEN $12::INTEGER > 0 THEN $12 ELSE (SELECT COALESCE(MAX(number), 0) + 1 FROM tickets WHERE project_id = $9) END, $10, $11)
+		RETURNING id, number
 	`
 	if err := d.db.QueryRowContext(
 		ctx,
@@ -34,7 +43,11 @@ func (d *DB) CreateTicket(ctx context.Context, create *store.Ticket) (*store.Tic
 		create.AssigneeID,
 		create.CreatedTs,
 		create.UpdatedTs,
-	).Scan(&create.ID); err != nil {
+		create.ProjectID,
+		create.Type,
+		fields,
+		create.Number,
+	).Scan(&create.ID, &create.Number); err != nil {
 		return nil, err
 	}
 
@@ -56,16 +69,39 @@ func (d *DB) ListTickets(ctx context.Context, find *store.FindTicket) ([]*store.
 		args = append(args, *find.ID)
 		argCounter++
 	}
+	if v := find.IDList; len(v) != 0 {
+		holders := []string{}
+		for _, id := range v {
+			holders = append(holders, fmt.Sprintf("$%d", argCounter))
+			args = append(args, id)
+			argCounter++
+		}
+		where = append(where, fmt.Sprintf("id IN (%s)", strings.Join(holders, ", ")))
+	}
 	if find.CreatorID != nil {
 		where = append(where, fmt.Sprintf("creator_id = $%d", argCounter))
 		args = append(args, *find.CreatorID)
 		argCounter++
 	}
+	if find.Type != nil {
+		where = append(where, fmt.Sprintf("type = $%d", argCounter))
+		args = append(args, *find.Type)
+		argCounter++
+	}
 	if find.Description != nil {
 		where = append(where, fmt.Sprintf("description = $%d", argCounter))
 		args = append(args, *find.Description)
 		argCounter++
 	}
+	if find.ProjectID != nil {
+		where = append(where, fmt.Sprintf("project_id = $%d", argCounter))
+		args = append(args, *find.ProjectID)
+		argCounter++
+	}
+	if find.Number != nil {
+		where = append(where, fmt.Sprintf("number = $%d", argCounter))
+		args = append(args, *find.Number)
+	}
 
 	query := fmt.Sprintf(`
 		SELECT
@@ -77,7 +113,11 @@ func (d *DB) ListTickets(ctx context.Context, find *store.FindTicket) ([]*store.
 			creator_id,
 			assignee_id,
 			created_ts,
-			updated_ts
+			updated_ts,
+			project_id,
+			number,
+			type,
+			fields
 		FROM tickets
 		WHERE %s
 		ORDER BY created_ts DESC
@@ -92,6 +132,7 @@ func (d *DB) ListTickets(ctx context.Context, find *store.FindTicket) ([]*store.
 	list := make([]*store.Ticket, 0)
 	for rows.Next() {
 		var ticket store.Ticket
+		var fields string
 		if err := rows.Scan(
 			&ticket.ID,
 			&ticket.Title,
@@ -102,9 +143,14 @@ func (d *DB) ListTickets(ctx context.Context, find *store.FindTicket) ([]*store.
 			&ticket.AssigneeID,
 			&ticket.CreatedTs,
 			&ticket.UpdatedTs,
+			&ticket.ProjectID,
+			&ticket.Number,
+			&ticket.Type,
+			&fields,
 		); err != nil {
 			return nil, err
 		}
+		ticket.Fields = unmarshalTicketFields(fields)
 		list = append(list, &ticket)
 	}
 
@@ -127,49 +173,20 @@ func (d *DB) GetTicket(ctx context.Context, find *store.FindTicket) (*store.Tick
 }
 
 func (d *DB) UpdateTicket(ctx context.Context, update *store.UpdateTicket) (*store.Ticket, error) {
-	set, args := []string{}, []interface{}{}
-	argCounter := 1
-
-	if update.Title != nil {
-		set = append(set, fmt.Sprintf("title = $%d", argCounter))
-		args = append(args, *update.Title)
-		argCounter++
-	}
-	if update.Description != nil {
-		set = append(set, fmt.Sprintf("description = $%d", argCounter))
-		args = append(args, *update.Description)
-		argCounter++
-	}
-	if update.Status != nil {
-		set = append(set, fmt.Sprintf("status = $%d", argCounter))
-		args = append(args, *update.Status)
-		argCounter++
-	}
-	if update.Priority != nil {
-		set = append(set, fmt.Sprintf("priority = $%d", argCounter))
-		args = append(args, *update.Priority)
-		argCounter++
-	}
-	if update.AssigneeID != nil {
-		set = append(set, fmt.Sprintf("assignee_id = $%d", argCounter))
-		args = append(args, *update.AssigneeID)
-		argCounter++
-	}
-	if update.UpdatedTs != nil {
-		set = append(set, fmt.Sprintf("updated_ts = $%d", argCounter))
-		args = append(args, *update.UpdatedTs)
-		argCounter++
+	set, args, err := ticketUpdateSet(update)
+	if err != nil {
+		return nil, err
 	}
-
 	args = append(args, update.ID)
 	stmt := fmt.Sprintf(`
 		UPDATE tickets
 		SET %s
-		WHERE id = $%d
-		RETURNING id, title, description, status, priority, creator_id, assignee_id, created_ts, updated_ts
-	`, strings.Join(set, ", "), argCounter)
+		WHERE id = %s
+		RETURNING id, title, description, status, priority, creator_id, assignee_id, created_ts, updated_ts, project_id, number, type, fields
+	`, strings.Join(set, ", "), placeholder(len(args)))
 
 	var ticket store.Ticket
+	var fields string
 	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
 		&ticket.ID,
 		&ticket.Title,
@@ -180,14 +197,64 @@ func (d *DB) UpdateTicket(ctx context.Context, update *store.UpdateTicket) (*sto
 		&ticket.AssigneeID,
 		&ticket.CreatedTs,
 		&ticket.UpdatedTs,
+		&ticket.ProjectID,
+		&ticket.Number,
+		&ticket.Type,
+		&fields,
 	); err != nil {
 		return nil, err
 	}
+	ticket.Fields = unmarshalTicketFields(fields)
 
 	return &ticket, nil
 }
 
+// ticketUpdateSet returns the assignments of the columns changed by the update and their arguments.
+func ticketUpdateSet(update *store.UpdateTicket) ([]string, []any, error) {
+	set, args := []string{}, []any{}
+	if update.Title != nil {
+		set = append(set, "title = "+placeholder(len(args)+1))
+		args = append(args, *update.Title)
+	}
+	if update.Description != nil {
+		set = append(set, "description = "+placeholder(len(args)+1))
+		args = append(args, *update.Description)
+	}
+	if update.Status != nil {
+		set = append(set, "status = "+placeholder(len(args)+1))
+		args = append(args, *update.Status)
+	}
+	if update.Priority != nil {
+		set = append(set, "priority = "+placeholder(len(args)+1))
+		args = append(args, *update.Priority)
+	}
+	if update.AssigneeID != nil {
+		set = append(set, "assignee_id = "+placeholder(len(args)+1))
+		args = append(args, *update.AssigneeID)
+	}
+	if update.UpdatedTs != nil {
+		set = append(set, "updated_ts = "+placeholder(len(args)+1))
+		args = append(args, *update.UpdatedTs)
+	}
+	if update.Type != nil {
+		set = append(set, "type = "+placeholder(len(args)+1))
+		args = append(args, *update.Type)
+	}
+	if update.Fields != nil {
+		fields, err := marshalTicketFields(update.Fields)
+		if err != nil {
+			return nil, nil, err
+		}
+		set = append(set, "fields = "+placeholder(len(args)+1))
+		args = append(args, fields)
+	}
+	return set, args, nil
+}
+
 func (d *DB) DeleteTicket(ctx context.Context, delete *store.DeleteTicket) error {
+	if _, err := d.db.ExecContext(ctx, `DELETE FROM ticket_status_history WHERE ticket_id = $1`, delete.ID); err != nil {
+		return err
+	}
 	stmt := `DELETE FROM tickets WHERE id = $1`
 	result, err := d.db.ExecContext(ctx, stmt, delete.ID)
 	if err != nil {
@@ -198,3 +265,115 @@ func (d *DB) DeleteTicket(ctx context.Context, delete *store.DeleteTicket) error
 	}
 	return nil
 }
+
+// UpdateTickets applies the updates and creates the status transitions in a single transaction.
+func (d *DB) UpdateTickets(ctx context.Context, updates []*store.UpdateTicket, transitions []*store.TicketStatusTransition) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, update := range updates {
+		set, args, err := ticketUpdateSet(update)
+		if err != nil {
+			return err
+		}
+		args = append(args, update.ID)
+		stmt := fmt.Sprintf(`UPDATE tickets SET %s WHERE id = %s`, strings.Join(set, ", "), placeholder(len(args)))
+		if _, err := tx.ExecContext(ctx, stmt, args...); err != nil {
+			return err
+		}
+	}
+	for _, transition := range transitions {
+		stmt := `INSERT INTO ticket_status_history (ticket_id, from_status, to_status, created_ts) VALUES ($1, $2, $3, $4)`
+		if _, err := tx.ExecContext(ctx, stmt, transition.TicketID, transition.FromStatus, transition.ToStatus, transition.CreatedTs); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteTickets deletes the tickets and their status history in a single transaction.
+func (d *DB) DeleteTickets(ctx context.Context, ids []int32) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	args := []any{}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM ticket_status_history WHERE ticket_id IN (`+placeholders(len(args))+`)`, args...); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tickets WHERE id IN (`+placeholders(len(args))+`)`, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (d *DB) CreateTicketStatusTransition(ctx context.Context, create *store.TicketStatusTransition) (*store.TicketStatusTransition, error) {
+	stmt := `
+		INSERT INTO ticket_status_history (ticket_id, from_status, to_status, created_ts)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+	if err := d.db.QueryRowContext(ctx, stmt, create.TicketID, create.FromStatus, create.ToStatus, create.CreatedTs).Scan(&create.ID); err != nil {
+		return nil, err
+	}
+	return create, nil
+}
+
+func (d *DB) ListTicketStatusTransitions(ctx context.Context, find *store.FindTicketStatusTransition) ([]*store.TicketStatusTransition, error) {
+	where, args := []string{"1=1"}, []any{}
+	if find.TicketID != nil {
+		where, args = append(where, "ticket_id = "+placeholder(len(args)+1)), append(args, *find.TicketID)
+	}
+
+	query := `SELECT id, ticket_id, from_status, to_status, created_ts FROM ticket_status_history WHERE ` + strings.Join(where, " AND ") + ` ORDER BY created_ts ASC, id ASC`
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.TicketStatusTransition, 0)
+	for rows.Next() {
+		transition := &store.TicketStatusTransition{}
+		if err := rows.Scan(&transition.ID, &transition.TicketID, &transition.FromStatus, &transition.ToStatus, &transition.CreatedTs); err != nil {
+			return nil, err
+		}
+		list = append(list, transition)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func marshalTicketFields(fields map[string]any) (string, error) {
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	bytes, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+func unmarshalTicketFields(value string) map[string]any {
+	fields := map[string]any{}
+	if err := json.Unmarshal([]byte(value), &fields); err != nil || fields == nil {
+		return map[string]any{}
+	}
+	return fields
+}
@@ -27,6 +27,9 @@ func (d *DB) CreateUser(ctx context.Context, create *store.User) (*store.User, e
 
 func (d *DB) UpdateUser(ctx context.Context, update *store.UpdateUser) (*store.User, error) {
 	set, args := []string{}, []any{}
+	if v := update.CreatedTs; v != nil {
+		set, args = append(set, "created_ts = "+placeholder(len(args)+1)), append(args, *v)
+	}
 	if v := update.UpdatedTs; v != nil {
 		set, args = append(set, "updated_ts = "+placeholder(len(args)+1)), append(args, *v)
 	}
@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertLinkPreview(ctx context.Context, upsert *store.LinkPreview) (*store.LinkPreview, error) {
+	stmt := "INSERT INTO `link_preview` (`hash`, `url`, `title`, `description`, `image`, `error`, `updated_ts`) VALUES (?, ?, ?, ?, ?, ?, ?) " +
+		"ON CONFLICT(`hash`) DO UPDATE SET `title` = excluded.`title`, `description` = excluded.`description`, `image` = excluded.`image`, `error` = excluded.`error`, `updated_ts` = excluded.`updated_ts`"
+	if _, err := d.db.ExecContext(ctx, stmt, upsert.Hash, upsert.URL, upsert.Title, upsert.Description, upsert.Image, upsert.Error, upsert.UpdatedTs); err != nil {
+		return nil, err
+	}
+
+	list, err := d.ListLinkPreviews(ctx, &store.FindLinkPreview{URL: &upsert.URL})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("link preview of %s not found", upsert.URL)
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListLinkPreviews(ctx context.Context, find *store.FindLinkPreview) ([]*store.LinkPreview, error) {
+	where, args := []string{"1 = 1"}, []any{}
+
+	if v := find.URL; v != nil {
+		where, args = append(where, "`hash` = ?"), append(args, store.HashLinkPreviewURL(*v))
+	}
+	if v := find.UpdatedTsBefore; v != nil {
+		where, args = append(where, "`updated_ts` < ?"), append(args, *v)
+	}
+
+	query := "SELECT `hash`, `url`, `title`, `description`, `image`, `error`, `updated_ts` FROM `link_preview` WHERE " + strings.Join(where, " AND ") + " ORDER BY `updated_ts` DESC"
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.LinkPreview, 0)
+	for rows.Next() {
+		linkPreview := &store.LinkPreview{}
+		if err := rows.Scan(
+			&linkPreview.Hash,
+			&linkPreview.URL,
+			&linkPreview.Title,
+			&linkPreview.Description,
+			&linkPreview.Image,
+			&linkPreview.Error,
+			&linkPreview.UpdatedTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, linkPreview)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteLinkPreviews(ctx context.Context, delete *store.DeleteLinkPreview) error {
+	where, args := []string{"1 = 1"}, []any{}
+
+	if v := delete.UpdatedTsBefore; v != nil {
+		where, args = append(where, "`updated_ts` < ?"), append(args, *v)
+	}
+
+	stmt := "DELETE FROM `link_preview` WHERE " + strings.Join(where, " AND ")
+	if _, err := d.db.ExecContext(ctx, stmt, args...); err != nil {
+		return err
+	}
+	return nil
+}
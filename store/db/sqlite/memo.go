@@ -25,6 +25,9 @@ func (d *DB) CreateMemo(ctx context.Context, create *store.Memo) (*store.Memo, e
 		payload = string(payloadBytes)
 	}
 	args := []any{create.UID, create.CreatorID, create.Content, create.Visibility, payload}
+	if create.ProjectID != nil {
+		fields, placeholder, args = append(fields, "`project_id`"), append(placeholder, "?"), append(args, *create.ProjectID)
+	}
 
 	stmt := "INSERT INTO `memo` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ") RETURNING `id`, `created_ts`, `updated_ts`, `row_status`"
 	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
@@ -40,6 +43,56 @@ func (d *DB) CreateMemo(ctx context.Context, create *store.Memo) (*store.Memo, e
 }
 
 func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo, error) {
+	query, args, err := d.buildMemoListQuery(find)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.Memo, 0)
+	for rows.Next() {
+		var memo store.Memo
+		var payloadBytes []byte
+		dests := []any{
+			&memo.ID,
+			&memo.UID,
+			&memo.CreatorID,
+			&memo.CreatedTs,
+			&memo.UpdatedTs,
+			&memo.RowStatus,
+			&memo.Visibility,
+			&memo.Pinned,
+			&payloadBytes,
+			&memo.ProjectID,
+			&memo.ParentID,
+		}
+		if !find.ExcludeContent {
+			dests = append(dests, &memo.Content)
+		}
+		if err := rows.Scan(dests...); err != nil {
+			return nil, err
+		}
+		payload := &storepb.MemoPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal payload")
+		}
+		memo.Payload = payload
+		list = append(list, &memo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// buildMemoListQuery returns the query listing the memos found by find, which is also used as a subquery to aggregate them.
+func (d *DB) buildMemoListQuery(find *store.FindMemo) (string, []any, error) {
 	where, args := []string{"1 = 1"}, []any{}
 
 	if v := find.ID; v != nil {
@@ -54,6 +107,9 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 	if v := find.RowStatus; v != nil {
 		where, args = append(where, "`memo`.`row_status` = ?"), append(args, *v)
 	}
+	if v := find.ProjectID; v != nil {
+		where, args = append(where, "`memo`.`project_id` = ?"), append(args, *v)
+	}
 	if v := find.CreatedTsBefore; v != nil {
 		where, args = append(where, "`memo`.`created_ts` < ?"), append(args, *v)
 	}
@@ -68,7 +124,8 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 	}
 	if v := find.ContentSearch; len(v) != 0 {
 		for _, s := range v {
-			where, args = append(where, "`memo`.`content` LIKE ?"), append(args, fmt.Sprintf("%%%s%%", s))
+			// The text extracted from the attached resources is searched too.
+			where, args = append(where, "(`memo`.`content` LIKE ? OR EXISTS (SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id` AND JSON_EXTRACT(`resource`.`payload`, '$.extractedText') LIKE ?))"), append(args, fmt.Sprintf("%%%s%%", s), fmt.Sprintf("%%%s%%", s))
 		}
 	}
 	if v := find.VisibilityList; len(v) != 0 {
@@ -109,12 +166,12 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 		// The filter string should be a CEL expression.
 		parsedExpr, err := filter.Parse(*v, filter.MemoFilterCELAttributes...)
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
 		convertCtx := filter.NewConvertContext()
 		// ConvertExprToSQL converts the parsed expression to a SQL condition string.
 		if err := d.ConvertExprToSQL(convertCtx, parsedExpr.GetExpr()); err != nil {
-			return nil, err
+			return "", nil, err
 		}
 		condition := convertCtx.Buffer.String()
 		if condition != "" {
@@ -149,6 +206,7 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 		"`memo`.`visibility` AS `visibility`",
 		"`memo`.`pinned` AS `pinned`",
 		"`memo`.`payload` AS `payload`",
+		"`memo`.`project_id` AS `project_id`",
 		"`memo_relation`.`related_memo_id` AS `parent_id`",
 	}
 	if !find.ExcludeContent {
@@ -165,48 +223,7 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 			query = fmt.Sprintf("%s OFFSET %d", query, *find.Offset)
 		}
 	}
-
-	rows, err := d.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	list := make([]*store.Memo, 0)
-	for rows.Next() {
-		var memo store.Memo
-		var payloadBytes []byte
-		dests := []any{
-			&memo.ID,
-			&memo.UID,
-			&memo.CreatorID,
-			&memo.CreatedTs,
-			&memo.UpdatedTs,
-			&memo.RowStatus,
-			&memo.Visibility,
-			&memo.Pinned,
-			&payloadBytes,
-			&memo.ParentID,
-		}
-		if !find.ExcludeContent {
-			dests = append(dests, &memo.Content)
-		}
-		if err := rows.Scan(dests...); err != nil {
-			return nil, err
-		}
-		payload := &storepb.MemoPayload{}
-		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
-			return nil, errors.Wrap(err, "failed to unmarshal payload")
-		}
-		memo.Payload = payload
-		list = append(list, &memo)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return list, nil
+	return query, args, nil
 }
 
 func (d *DB) UpdateMemo(ctx context.Context, update *store.UpdateMemo) error {
@@ -232,6 +249,13 @@ func (d *DB) UpdateMemo(ctx context.Context, update *store.UpdateMemo) error {
 	if v := update.Pinned; v != nil {
 		set, args = append(set, "`pinned` = ?"), append(args, *v)
 	}
+	if v := update.ProjectID; v != nil {
+		if *v == 0 {
+			set = append(set, "`project_id` = NULL")
+		} else {
+			set, args = append(set, "`project_id` = ?"), append(args, *v)
+		}
+	}
 	if v := update.Payload; v != nil {
 		payloadBytes, err := protojson.Marshal(v)
 		if err != nil {
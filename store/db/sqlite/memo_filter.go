@@ -58,7 +58,7 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 			if err != nil {
 				return err
 			}
-			if !slices.Contains([]string{"creator_id", "created_ts", "updated_ts", "visibility", "content", "has_task_list"}, identifier) {
+			if !slices.Contains([]string{"creator_id", "created_ts", "updated_ts", "visibility", "content", "has_task_list", "project_id", "mentions", "references_ticket"}, identifier) {
 				return errors.Errorf("invalid identifier for %s", v.CallExpr.Function)
 			}
 			value, err := filter.GetExprValue(v.CallExpr.Args[1])
@@ -116,7 +116,7 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 					return err
 				}
 				ctx.Args = append(ctx.Args, valueStr)
-			} else if identifier == "creator_id" {
+			} else if identifier == "creator_id" || identifier == "project_id" {
 				if operator != "=" && operator != "!=" {
 					return errors.Errorf("invalid operator for %s", v.CallExpr.Function)
 				}
@@ -128,6 +128,8 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 				var factor string
 				if identifier == "creator_id" {
 					factor = "`memo`.`creator_id`"
+				} else if identifier == "project_id" {
+					factor = "`memo`.`project_id`"
 				}
 				if _, err := ctx.Buffer.WriteString(fmt.Sprintf("%s %s ?", factor, operator)); err != nil {
 					return err
@@ -149,6 +151,30 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 				if _, err := ctx.Buffer.WriteString(fmt.Sprintf("JSON_EXTRACT(`memo`.`payload`, '$.property.hasTaskList') %s %d", operator, compareValue)); err != nil {
 					return err
 				}
+			} else if identifier == "mentions" {
+				if operator != "=" {
+					return errors.Errorf("invalid operator for %s", v.CallExpr.Function)
+				}
+				valueStr, ok := value.(string)
+				if !ok {
+					return errors.New("invalid string value for mentions")
+				}
+				if _, err := ctx.Buffer.WriteString("EXISTS (SELECT 1 FROM JSON_EACH(`memo`.`payload`, '$.property.mentions') WHERE JSON_EACH.`value` = (SELECT `user`.`id` FROM `user` WHERE `user`.`username` = ?))"); err != nil {
+					return err
+				}
+				ctx.Args = append(ctx.Args, valueStr)
+			} else if identifier == "references_ticket" {
+				if operator != "=" {
+					return errors.Errorf("invalid operator for %s", v.CallExpr.Function)
+				}
+				valueInt, ok := value.(int64)
+				if !ok {
+					return errors.New("invalid int value for references_ticket")
+				}
+				if _, err := ctx.Buffer.WriteString("EXISTS (SELECT 1 FROM JSON_EACH(`memo`.`payload`, '$.property.ticketReferences') WHERE JSON_EACH.`value` = ?)"); err != nil {
+					return err
+				}
+				ctx.Args = append(ctx.Args, valueInt)
 			}
 		case "@in":
 			if len(v.CallExpr.Args) != 2 {
@@ -158,7 +184,7 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 			if err != nil {
 				return err
 			}
-			if !slices.Contains([]string{"tag", "visibility"}, identifier) {
+			if !slices.Contains([]string{"tag", "visibility", "project_id"}, identifier) {
 				return errors.Errorf("invalid identifier for %s", v.CallExpr.Function)
 			}
 
@@ -195,6 +221,15 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 					return err
 				}
 				ctx.Args = append(ctx.Args, values...)
+			} else if identifier == "project_id" {
+				placeholder := []string{}
+				for range values {
+					placeholder = append(placeholder, "?")
+				}
+				if _, err := ctx.Buffer.WriteString(fmt.Sprintf("`memo`.`project_id` IN (%s)", strings.Join(placeholder, ","))); err != nil {
+					return err
+				}
+				ctx.Args = append(ctx.Args, values...)
 			}
 		case "contains":
 			if len(v.CallExpr.Args) != 1 {
@@ -110,6 +110,16 @@ func TestConvertExprToSQL(t *testing.T) {
 			want:   "`memo`.`created_ts` > ?",
 			args:   []any{time.Now().Unix() - 60*60*24},
 		},
+		{
+			filter: `mentions == "alice"`,
+			want:   "EXISTS (SELECT 1 FROM JSON_EACH(`memo`.`payload`, '$.property.mentions') WHERE JSON_EACH.`value` = (SELECT `user`.`id` FROM `user` WHERE `user`.`username` = ?))",
+			args:   []any{"alice"},
+		},
+		{
+			filter: `references_ticket == 42`,
+			want:   "EXISTS (SELECT 1 FROM JSON_EACH(`memo`.`payload`, '$.property.ticketReferences') WHERE JSON_EACH.`value` = ?)",
+			args:   []any{int64(42)},
+		},
 	}
 
 	for _, tt := range tests {
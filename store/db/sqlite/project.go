@@ -0,0 +1,185 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateProject(ctx context.Context, create *store.Project) (*store.Project, error) {
+	payload, err := protojson.Marshal(create.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal payload")
+	}
+	fields := []string{"`key`", "`name`", "`description`", "`payload`"}
+	placeholder := []string{"?", "?", "?", "?"}
+	args := []any{create.Key, create.Name, create.Description, string(payload)}
+	stmt := "INSERT INTO `project` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ") RETURNING `id`, `created_ts`, `updated_ts`, `row_status`"
+	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+		&create.ID,
+		&create.CreatedTs,
+		&create.UpdatedTs,
+		&create.RowStatus,
+	); err != nil {
+		return nil, err
+	}
+	project := create
+	return project, nil
+}
+
+func (d *DB) ListProjects(ctx context.Context, find *store.FindProject) ([]*store.Project, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "`id` = ?"), append(args, *find.ID)
+	}
+	if find.Key != nil {
+		where, args = append(where, "`key` = ?"), append(args, *find.Key)
+	}
+	if find.RowStatus != nil {
+		where, args = append(where, "`row_status` = ?"), append(args, *find.RowStatus)
+	}
+	if v := find.IDList; len(v) != 0 {
+		placeholder := []string{}
+		for _, id := range v {
+			placeholder = append(placeholder, "?")
+			args = append(args, id)
+		}
+		where = append(where, fmt.Sprintf("`id` IN (%s)", strings.Join(placeholder, ",")))
+	}
+
+	rows, err := d.db.QueryContext(ctx, "SELECT `id`, `created_ts`, `updated_ts`, `row_status`, `key`, `name`, `description`, `payload` FROM `project` WHERE "+strings.Join(where, " AND ")+" ORDER BY `key` ASC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.Project{}
+	for rows.Next() {
+		project := &store.Project{}
+		var payload []byte
+		if err := rows.Scan(
+			&project.ID,
+			&project.CreatedTs,
+			&project.UpdatedTs,
+			&project.RowStatus,
+			&project.Key,
+			&project.Name,
+			&project.Description,
+			&payload,
+		); err != nil {
+			return nil, err
+		}
+		project.Payload = &storepb.ProjectPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payload, project.Payload); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal payload")
+		}
+		list = append(list, project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateProject(ctx context.Context, update *store.UpdateProject) (*store.Project, error) {
+	set, args := []string{}, []any{}
+	if v := update.UpdatedTs; v != nil {
+		set, args = append(set, "`updated_ts` = ?"), append(args, *v)
+	}
+	if v := update.RowStatus; v != nil {
+		set, args = append(set, "`row_status` = ?"), append(args, *v)
+	}
+	if v := update.Name; v != nil {
+		set, args = append(set, "`name` = ?"), append(args, *v)
+	}
+	if v := update.Description; v != nil {
+		set, args = append(set, "`description` = ?"), append(args, *v)
+	}
+	if v := update.Payload; v != nil {
+		payload, err := protojson.Marshal(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal payload")
+		}
+		set, args = append(set, "`payload` = ?"), append(args, string(payload))
+	}
+	if len(set) == 0 {
+		return nil, errors.New("no fields to update")
+	}
+	args = append(args, update.ID)
+
+	if _, err := d.db.ExecContext(ctx, "UPDATE `project` SET "+strings.Join(set, ", ")+" WHERE `id` = ?", args...); err != nil {
+		return nil, err
+	}
+	list, err := d.ListProjects(ctx, &store.FindProject{ID: &update.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("project %d not found", update.ID)
+	}
+	return list[0], nil
+}
+
+func (d *DB) DeleteProject(ctx context.Context, delete *store.DeleteProject) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `project` WHERE `id` = ?", delete.ID)
+	return err
+}
+
+func (d *DB) UpsertProjectMember(ctx context.Context, upsert *store.ProjectMember) (*store.ProjectMember, error) {
+	stmt := "INSERT INTO `project_member` (`project_id`, `user_id`, `role`) VALUES (?, ?, ?) ON CONFLICT(`project_id`, `user_id`) DO UPDATE SET `role` = EXCLUDED.`role` RETURNING `created_ts`"
+	if err := d.db.QueryRowContext(ctx, stmt, upsert.ProjectID, upsert.UserID, upsert.Role).Scan(&upsert.CreatedTs); err != nil {
+		return nil, err
+	}
+	return upsert, nil
+}
+
+func (d *DB) ListProjectMembers(ctx context.Context, find *store.FindProjectMember) ([]*store.ProjectMember, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ProjectID != nil {
+		where, args = append(where, "`project_id` = ?"), append(args, *find.ProjectID)
+	}
+	if find.UserID != nil {
+		where, args = append(where, "`user_id` = ?"), append(args, *find.UserID)
+	}
+
+	rows, err := d.db.QueryContext(ctx, "SELECT `project_id`, `user_id`, `role`, `created_ts` FROM `project_member` WHERE "+strings.Join(where, " AND ")+" ORDER BY `project_id` ASC, `created_ts` ASC, `user_id` ASC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.ProjectMember{}
+	for rows.Next() {
+		member := &store.ProjectMember{}
+		if err := rows.Scan(&member.ProjectID, &member.UserID, &member.Role, &member.CreatedTs); err != nil {
+			return nil, err
+		}
+		list = append(list, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteProjectMember(ctx context.Context, delete *store.DeleteProjectMember) error {
+	where, args := []string{"1 = 1"}, []any{}
+	if delete.ProjectID != nil {
+		where, args = append(where, "`project_id` = ?"), append(args, *delete.ProjectID)
+	}
+	if delete.UserID != nil {
+		where, args = append(where, "`user_id` = ?"), append(args, *delete.UserID)
+	}
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `project_member` WHERE "+strings.Join(where, " AND "), args...)
+	return err
+}
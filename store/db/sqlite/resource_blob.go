@@ -0,0 +1,156 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertResourceBlob(ctx context.Context, upsert *store.ResourceBlob) (*store.ResourceBlob, error) {
+	storageType := ""
+	if upsert.StorageType != storepb.ResourceStorageType_RESOURCE_STORAGE_TYPE_UNSPECIFIED {
+		storageType = upsert.StorageType.String()
+	}
+	payloadString := "{}"
+	if upsert.Payload != nil {
+		bytes, err := protojson.Marshal(upsert.Payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal resource blob payload")
+		}
+		payloadString = string(bytes)
+	}
+
+	// An existing blob keeps its content and gets one more reference.
+	stmt := "INSERT INTO `resource_blob` (`hash`, `size`, `storage_type`, `reference`, `blob`, `ref_count`, `payload`) VALUES (?, ?, ?, ?, ?, 1, ?) " +
+		"ON CONFLICT(`hash`) DO UPDATE SET `ref_count` = `ref_count` + 1, `updated_ts` = strftime('%s', 'now')"
+	if _, err := d.db.ExecContext(ctx, stmt, upsert.Hash, upsert.Size, storageType, upsert.Reference, upsert.Blob, payloadString); err != nil {
+		return nil, err
+	}
+
+	list, err := d.ListResourceBlobs(ctx, &store.FindResourceBlob{Hash: &upsert.Hash})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("resource blob %s not found", upsert.Hash)
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListResourceBlobs(ctx context.Context, find *store.FindResourceBlob) ([]*store.ResourceBlob, error) {
+	where, args := []string{"1 = 1"}, []any{}
+
+	if v := find.Hash; v != nil {
+		where, args = append(where, "`hash` = ?"), append(args, *v)
+	}
+	if find.Orphaned {
+		where = append(where, "`ref_count` <= 0")
+	}
+	if v := find.UpdatedTsBefore; v != nil {
+		where, args = append(where, "`updated_ts` < ?"), append(args, *v)
+	}
+
+	fields := []string{"`hash`", "`created_ts`", "`updated_ts`", "`size`", "`storage_type`", "`reference`", "`ref_count`", "`payload`"}
+	if find.GetBlob {
+		fields = append(fields, "`blob`")
+	}
+
+	query := "SELECT " + strings.Join(fields, ", ") + " FROM `resource_blob` WHERE " + strings.Join(where, " AND ") + " ORDER BY `created_ts` ASC"
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.ResourceBlob, 0)
+	for rows.Next() {
+		blob := &store.ResourceBlob{}
+		var storageType string
+		var payloadBytes []byte
+		dests := []any{
+			&blob.Hash,
+			&blob.CreatedTs,
+			&blob.UpdatedTs,
+			&blob.Size,
+			&storageType,
+			&blob.Reference,
+			&blob.RefCount,
+			&payloadBytes,
+		}
+		if find.GetBlob {
+			dests = append(dests, &blob.Blob)
+		}
+		if err := rows.Scan(dests...); err != nil {
+			return nil, err
+		}
+
+		blob.StorageType = storepb.ResourceStorageType(storepb.ResourceStorageType_value[storageType])
+		payload := &storepb.ResourcePayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+			return nil, err
+		}
+		blob.Payload = payload
+		list = append(list, blob)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateResourceBlob(ctx context.Context, update *store.UpdateResourceBlob) error {
+	set, args := []string{}, []any{}
+
+	if v := update.UpdatedTs; v != nil {
+		set, args = append(set, "`updated_ts` = ?"), append(args, *v)
+	}
+	if v := update.RefCount; v != nil {
+		set, args = append(set, "`ref_count` = ?"), append(args, *v)
+	}
+	if v := update.RefCountDelta; v != 0 {
+		set, args = append(set, "`ref_count` = `ref_count` + ?"), append(args, v)
+	}
+	if v := update.StorageType; v != nil {
+		storageType := ""
+		if *v != storepb.ResourceStorageType_RESOURCE_STORAGE_TYPE_UNSPECIFIED {
+			storageType = v.String()
+		}
+		set, args = append(set, "`storage_type` = ?"), append(args, storageType)
+		set, args = append(set, "`blob` = ?"), append(args, update.Blob)
+	}
+	if v := update.Reference; v != nil {
+		set, args = append(set, "`reference` = ?"), append(args, *v)
+	}
+	if v := update.Payload; v != nil {
+		bytes, err := protojson.Marshal(v)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal resource blob payload")
+		}
+		set, args = append(set, "`payload` = ?"), append(args, string(bytes))
+	}
+	if len(set) == 0 {
+		return nil
+	}
+
+	args = append(args, update.Hash)
+	stmt := "UPDATE `resource_blob` SET " + strings.Join(set, ", ") + " WHERE `hash` = ?"
+	if _, err := d.db.ExecContext(ctx, stmt, args...); err != nil {
+		return errors.Wrap(err, "failed to update resource blob")
+	}
+	return nil
+}
+
+func (d *DB) DeleteResourceBlob(ctx context.Context, delete *store.DeleteResourceBlob) error {
+	stmt := "DELETE FROM `resource_blob` WHERE `hash` = ?"
+	if _, err := d.db.ExecContext(ctx, stmt, delete.Hash); err != nil {
+		return err
+	}
+	return nil
+}
@@ -66,3 +66,8 @@ func (d *DB) ListUserSettings(ctx context.Context, find *store.FindUserSetting)
 
 	return userSettingList, nil
 }
+
+func (d *DB) DeleteUserSettings(ctx context.Context, delete *store.DeleteUserSetting) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM user_setting WHERE user_id = ?", delete.UserID)
+	return err
+}
@@ -29,6 +29,17 @@ type Driver interface {
 	UpdateResource(ctx context.Context, update *UpdateResource) error
 	DeleteResource(ctx context.Context, delete *DeleteResource) error
 
+	// ResourceBlob model related methods.
+	UpsertResourceBlob(ctx context.Context, upsert *ResourceBlob) (*ResourceBlob, error)
+	ListResourceBlobs(ctx context.Context, find *FindResourceBlob) ([]*ResourceBlob, error)
+	UpdateResourceBlob(ctx context.Context, update *UpdateResourceBlob) error
+	DeleteResourceBlob(ctx context.Context, delete *DeleteResourceBlob) error
+
+	// LinkPreview model related methods.
+	UpsertLinkPreview(ctx context.Context, upsert *LinkPreview) (*LinkPreview, error)
+	ListLinkPreviews(ctx context.Context, find *FindLinkPreview) ([]*LinkPreview, error)
+	DeleteLinkPreviews(ctx context.Context, delete *DeleteLinkPreview) error
+
 	// Memo model related methods.
 	CreateMemo(ctx context.Context, create *Memo) (*Memo, error)
 	ListMemos(ctx context.Context, find *FindMemo) ([]*Memo, error)
@@ -54,6 +65,7 @@ type Driver interface {
 	// UserSetting model related methods.
 	UpsertUserSetting(ctx context.Context, upsert *UserSetting) (*UserSetting, error)
 	ListUserSettings(ctx context.Context, find *FindUserSetting) ([]*UserSetting, error)
+	DeleteUserSettings(ctx context.Context, delete *DeleteUserSetting) error
 
 	// IdentityProvider model related methods.
 	CreateIdentityProvider(ctx context.Context, create *IdentityProvider) (*IdentityProvider, error)
@@ -84,11 +96,43 @@ type Driver interface {
 	GetTicket(ctx context.Context, find *FindTicket) (*Ticket, error)
 	UpdateTicket(ctx context.Context, update *UpdateTicket) (*Ticket, error)
 	DeleteTicket(ctx context.Context, delete *DeleteTicket) error
+	UpdateTickets(ctx context.Context, updates []*UpdateTicket, transitions []*TicketStatusTransition) error
+	DeleteTickets(ctx context.Context, ids []int32) error
+	CreateTicketStatusTransition(ctx context.Context, create *TicketStatusTransition) (*TicketStatusTransition, error)
+	ListTicketStatusTransitions(ctx context.Context, find *FindTicketStatusTransition) ([]*TicketStatusTransition, error)
+
+	// Analytics related methods.
+	GetTicketMetrics(ctx context.Context, find *FindTicketMetrics) (*TicketMetrics, error)
+	ListTicketStatusDeltas(ctx context.Context, find *FindTicketMetrics) ([]*TicketStatusDelta, error)
+	ListMemoActivity(ctx context.Context, find *FindMemoActivity) ([]*MemoActivityCount, error)
 
 	// Notification model related methods.
 	CreateNotification(ctx context.Context, create *Notification) (*Notification, error)
 	ListNotifications(ctx context.Context, find *FindNotification) ([]*Notification, error)
 	UpdateNotification(ctx context.Context, update *UpdateNotification) (*Notification, error)
+	DeleteNotification(ctx context.Context, delete *DeleteNotification) error
+
+	// Project model related methods.
+	CreateProject(ctx context.Context, create *Project) (*Project, error)
+	ListProjects(ctx context.Context, find *FindProject) ([]*Project, error)
+	UpdateProject(ctx context.Context, update *UpdateProject) (*Project, error)
+	DeleteProject(ctx context.Context, delete *DeleteProject) error
+
+	// ProjectMember model related methods.
+	UpsertProjectMember(ctx context.Context, upsert *ProjectMember) (*ProjectMember, error)
+	ListProjectMembers(ctx context.Context, find *FindProjectMember) ([]*ProjectMember, error)
+	DeleteProjectMember(ctx context.Context, delete *DeleteProjectMember) error
+
+	// CustomRole model related methods.
+	CreateCustomRole(ctx context.Context, create *CustomRole) (*CustomRole, error)
+	ListCustomRoles(ctx context.Context, find *FindCustomRole) ([]*CustomRole, error)
+	UpdateCustomRole(ctx context.Context, update *UpdateCustomRole) (*CustomRole, error)
+	DeleteCustomRole(ctx context.Context, delete *DeleteCustomRole) error
+
+	// UserCustomRole model related methods.
+	CreateUserCustomRole(ctx context.Context, create *UserCustomRole) (*UserCustomRole, error)
+	ListUserCustomRoles(ctx context.Context, find *FindUserCustomRole) ([]*UserCustomRole, error)
+	DeleteUserCustomRole(ctx context.Context, delete *DeleteUserCustomRole) error
 
 	// Shortcut related methods.
 	ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) error
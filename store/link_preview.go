@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// LinkPreviewTTL is how long the preview of a link is cached, after which it is fetched again or pruned.
+const LinkPreviewTTL = 24 * time.Hour
+
+// LinkPreview is the cached metadata of a link previewed in memos.
+type LinkPreview struct {
+	// Hash is the hex encoded SHA-256 hash of the URL.
+	Hash string
+
+	// Standard fields
+	UpdatedTs int64
+
+	// Domain specific fields
+	URL         string
+	Title       string
+	Description string
+	Image       string
+	// Error is the reason the metadata could not be fetched, cached so that failing links are not fetched repeatedly.
+	Error string
+}
+
+type FindLinkPreview struct {
+	URL *string
+	// UpdatedTsBefore finds the previews cached before the time.
+	UpdatedTsBefore *int64
+}
+
+type DeleteLinkPreview struct {
+	// UpdatedTsBefore deletes the previews cached before the time.
+	UpdatedTsBefore *int64
+}
+
+// HashLinkPreviewURL returns the hex encoded SHA-256 hash of the URL, which keys its cached preview.
+func HashLinkPreviewURL(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+// UpsertLinkPreview caches the preview of its URL, replacing the previously cached one.
+func (s *Store) UpsertLinkPreview(ctx context.Context, upsert *LinkPreview) (*LinkPreview, error) {
+	upsert.Hash = HashLinkPreviewURL(upsert.URL)
+	if upsert.UpdatedTs == 0 {
+		upsert.UpdatedTs = time.Now().Unix()
+	}
+	return s.driver.UpsertLinkPreview(ctx, upsert)
+}
+
+func (s *Store) ListLinkPreviews(ctx context.Context, find *FindLinkPreview) ([]*LinkPreview, error) {
+	return s.driver.ListLinkPreviews(ctx, find)
+}
+
+func (s *Store) GetLinkPreview(ctx context.Context, find *FindLinkPreview) (*LinkPreview, error) {
+	list, err := s.ListLinkPreviews(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// PruneLinkPreviews deletes the previews cached for longer than LinkPreviewTTL, which would be fetched again anyway.
+func (s *Store) PruneLinkPreviews(ctx context.Context) error {
+	before := time.Now().Add(-LinkPreviewTTL).Unix()
+	return s.driver.DeleteLinkPreviews(ctx, &DeleteLinkPreview{UpdatedTsBefore: &before})
+}
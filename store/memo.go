@@ -19,6 +19,8 @@ const (
 	Protected Visibility = "PROTECTED"
 	// Private is the PRIVATE visibility.
 	Private Visibility = "PRIVATE"
+	// ProjectMembers is the PROJECT visibility, limited to the members of the project of the memo.
+	ProjectMembers Visibility = "PROJECT"
 )
 
 func (v Visibility) String() string {
@@ -29,6 +31,8 @@ func (v Visibility) String() string {
 		return "PROTECTED"
 	case Private:
 		return "PRIVATE"
+	case ProjectMembers:
+		return "PROJECT"
 	}
 	return "PRIVATE"
 }
@@ -50,6 +54,8 @@ type Memo struct {
 	Visibility Visibility
 	Pinned     bool
 	Payload    *storepb.MemoPayload
+	// ProjectID is the project of the memo, nil for workspace-wide memos.
+	ProjectID *int32
 
 	// Composed fields
 	ParentID *int32
@@ -71,6 +77,7 @@ type FindMemo struct {
 	ContentSearch   []string
 	VisibilityList  []Visibility
 	Pinned          *bool
+	ProjectID       *int32
 	PayloadFind     *FindMemoPayload
 	ExcludeContent  bool
 	ExcludeComments bool
@@ -105,6 +112,8 @@ type UpdateMemo struct {
 	Visibility *Visibility
 	Pinned     *bool
 	Payload    *storepb.MemoPayload
+	// ProjectID moves the memo to a project, zero removes it from its project.
+	ProjectID *int32
 }
 
 type DeleteMemo struct {
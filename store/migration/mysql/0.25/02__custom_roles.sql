@@ -0,0 +1,14 @@
+CREATE TABLE `custom_role` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `name` VARCHAR(256) NOT NULL UNIQUE,
+  `description` TEXT NOT NULL,
+  `permissions` TEXT NOT NULL
+);
+
+CREATE TABLE `user_custom_role` (
+  `user_id` INT NOT NULL,
+  `role_id` INT NOT NULL,
+  UNIQUE(`user_id`,`role_id`)
+);
@@ -0,0 +1,25 @@
+CREATE TABLE `project` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `row_status` VARCHAR(256) NOT NULL DEFAULT 'NORMAL',
+  `key` VARCHAR(32) NOT NULL UNIQUE,
+  `name` VARCHAR(256) NOT NULL,
+  `description` TEXT NOT NULL,
+  `payload` JSON NOT NULL
+);
+
+CREATE TABLE `project_member` (
+  `project_id` INT NOT NULL,
+  `user_id` INT NOT NULL,
+  `role` VARCHAR(256) NOT NULL DEFAULT 'MEMBER',
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE(`project_id`,`user_id`)
+);
+
+ALTER TABLE `tickets` ADD COLUMN `project_id` INT;
+ALTER TABLE `tickets` ADD COLUMN `number` INT NOT NULL DEFAULT 0;
+ALTER TABLE `tickets` ADD UNIQUE INDEX `idx_tickets_project_number` (`project_id`, `number`);
+
+ALTER TABLE `memo` ADD COLUMN `project_id` INT;
+ALTER TABLE `memo` ADD INDEX `idx_memo_project_id` (`project_id`);
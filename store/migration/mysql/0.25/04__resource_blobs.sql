@@ -0,0 +1,11 @@
+CREATE TABLE `resource_blob` (
+  `hash` VARCHAR(64) NOT NULL PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `size` BIGINT NOT NULL DEFAULT '0',
+  `storage_type` VARCHAR(256) NOT NULL DEFAULT '',
+  `reference` TEXT NOT NULL DEFAULT (''),
+  `blob` LONGBLOB,
+  `ref_count` INT NOT NULL DEFAULT '0',
+  `payload` TEXT NOT NULL
+);
@@ -0,0 +1,9 @@
+CREATE TABLE `link_preview` (
+  `hash` VARCHAR(64) NOT NULL PRIMARY KEY,
+  `url` TEXT NOT NULL,
+  `title` TEXT NOT NULL,
+  `description` TEXT NOT NULL,
+  `image` TEXT NOT NULL,
+  `error` TEXT NOT NULL,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,13 @@
+CREATE TABLE `ticket_status_history` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `ticket_id` INT NOT NULL,
+  `from_status` VARCHAR(255) NOT NULL DEFAULT '',
+  `to_status` VARCHAR(255) NOT NULL,
+  `created_ts` BIGINT NOT NULL,
+  INDEX `idx_ticket_status_history_ticket_id` (`ticket_id`)
+);
+
+INSERT INTO `ticket_status_history` (`ticket_id`, `from_status`, `to_status`, `created_ts`)
+SELECT `id`, '', 'OPEN', `created_ts` FROM `tickets`;
+INSERT INTO `ticket_status_history` (`ticket_id`, `from_status`, `to_status`, `created_ts`)
+SELECT `id`, 'OPEN', `status`, `updated_ts` FROM `tickets` WHERE `status` != 'OPEN';
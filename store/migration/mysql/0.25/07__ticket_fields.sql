@@ -0,0 +1,7 @@
+-- tickets: the type of the ticket, which custom fields are scoped by.
+ALTER TABLE `tickets` ADD COLUMN `type` VARCHAR(255) NOT NULL DEFAULT 'TASK';
+
+-- tickets: the values of the custom fields by field key.
+ALTER TABLE `tickets` ADD COLUMN `fields` JSON;
+UPDATE `tickets` SET `fields` = '{}';
+ALTER TABLE `tickets` MODIFY `fields` JSON NOT NULL;
@@ -45,7 +45,9 @@ CREATE TABLE `memo` (
   `content` TEXT NOT NULL,
   `visibility` VARCHAR(256) NOT NULL DEFAULT 'PRIVATE',
   `pinned` BOOLEAN NOT NULL DEFAULT FALSE,
-  `payload` JSON NOT NULL
+  `payload` JSON NOT NULL,
+  `project_id` INT,
+  INDEX `idx_memo_project_id` (`project_id`)
 );
 
 -- memo_organizer
@@ -81,6 +83,30 @@ CREATE TABLE `resource` (
   `payload` TEXT NOT NULL
 );
 
+-- resource_blob
+CREATE TABLE `resource_blob` (
+  `hash` VARCHAR(64) NOT NULL PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `size` BIGINT NOT NULL DEFAULT '0',
+  `storage_type` VARCHAR(256) NOT NULL DEFAULT '',
+  `reference` TEXT NOT NULL DEFAULT (''),
+  `blob` LONGBLOB,
+  `ref_count` INT NOT NULL DEFAULT '0',
+  `payload` TEXT NOT NULL
+);
+
+-- link_preview
+CREATE TABLE `link_preview` (
+  `hash` VARCHAR(64) NOT NULL PRIMARY KEY,
+  `url` TEXT NOT NULL,
+  `title` TEXT NOT NULL,
+  `description` TEXT NOT NULL,
+  `image` TEXT NOT NULL,
+  `error` TEXT NOT NULL,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
 -- activity
 CREATE TABLE `activity` (
   `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
@@ -130,3 +156,81 @@ CREATE TABLE `reaction` (
   `reaction_type` VARCHAR(256) NOT NULL,
   UNIQUE(`creator_id`,`content_id`,`reaction_type`)  
 );
+
+-- tickets
+CREATE TABLE `tickets` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `title` TEXT NOT NULL,
+  `description` TEXT NOT NULL,
+  `status` VARCHAR(255) NOT NULL DEFAULT 'OPEN',
+  `priority` VARCHAR(255) NOT NULL DEFAULT 'MEDIUM',
+  `creator_id` INT NOT NULL,
+  `assignee_id` INT,
+  `created_ts` BIGINT NOT NULL,
+  `updated_ts` BIGINT NOT NULL,
+  `project_id` INT,
+  `number` INT NOT NULL DEFAULT 0,
+  `type` VARCHAR(255) NOT NULL DEFAULT 'TASK',
+  `fields` JSON NOT NULL,
+  INDEX `idx_tickets_creator_id` (`creator_id`),
+  INDEX `idx_tickets_status` (`status`),
+  UNIQUE INDEX `idx_tickets_project_number` (`project_id`, `number`)
+);
+
+-- ticket_status_history
+CREATE TABLE `ticket_status_history` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `ticket_id` INT NOT NULL,
+  `from_status` VARCHAR(255) NOT NULL DEFAULT '',
+  `to_status` VARCHAR(255) NOT NULL,
+  `created_ts` BIGINT NOT NULL,
+  INDEX `idx_ticket_status_history_ticket_id` (`ticket_id`)
+);
+
+-- notifications
+CREATE TABLE `notifications` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `initiator_id` INT NOT NULL,
+  `receiver_id` INT NOT NULL,
+  `ticket_url` TEXT NOT NULL,
+  `created_ts` BIGINT NOT NULL,
+  `is_read` BOOLEAN NOT NULL DEFAULT 0
+);
+
+-- custom_role
+CREATE TABLE `custom_role` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `name` VARCHAR(256) NOT NULL UNIQUE,
+  `description` TEXT NOT NULL,
+  `permissions` TEXT NOT NULL
+);
+
+-- user_custom_role
+CREATE TABLE `user_custom_role` (
+  `user_id` INT NOT NULL,
+  `role_id` INT NOT NULL,
+  UNIQUE(`user_id`,`role_id`)
+);
+
+-- project
+CREATE TABLE `project` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `row_status` VARCHAR(256) NOT NULL DEFAULT 'NORMAL',
+  `key` VARCHAR(32) NOT NULL UNIQUE,
+  `name` VARCHAR(256) NOT NULL,
+  `description` TEXT NOT NULL,
+  `payload` JSON NOT NULL
+);
+
+-- project_member
+CREATE TABLE `project_member` (
+  `project_id` INT NOT NULL,
+  `user_id` INT NOT NULL,
+  `role` VARCHAR(256) NOT NULL DEFAULT 'MEMBER',
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE(`project_id`,`user_id`)
+);
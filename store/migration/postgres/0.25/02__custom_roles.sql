@@ -0,0 +1,14 @@
+CREATE TABLE custom_role (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  name TEXT NOT NULL UNIQUE,
+  description TEXT NOT NULL DEFAULT '',
+  permissions TEXT NOT NULL DEFAULT '[]'
+);
+
+CREATE TABLE user_custom_role (
+  user_id INTEGER NOT NULL,
+  role_id INTEGER NOT NULL,
+  UNIQUE(user_id, role_id)
+);
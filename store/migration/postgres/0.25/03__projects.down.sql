@@ -0,0 +1,10 @@
+UPDATE memo SET visibility = 'PRIVATE' WHERE visibility = 'PROJECT';
+DROP INDEX idx_memo_project_id;
+ALTER TABLE memo DROP COLUMN project_id;
+
+DROP INDEX idx_tickets_project_number;
+ALTER TABLE tickets DROP COLUMN number;
+ALTER TABLE tickets DROP COLUMN project_id;
+
+DROP TABLE project_member;
+DROP TABLE project;
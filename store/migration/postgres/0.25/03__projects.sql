@@ -0,0 +1,25 @@
+CREATE TABLE project (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  row_status TEXT NOT NULL DEFAULT 'NORMAL',
+  key TEXT NOT NULL UNIQUE,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE project_member (
+  project_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  role TEXT NOT NULL DEFAULT 'MEMBER',
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  UNIQUE(project_id, user_id)
+);
+
+ALTER TABLE tickets ADD COLUMN project_id INTEGER;
+ALTER TABLE tickets ADD COLUMN number INTEGER NOT NULL DEFAULT 0;
+CREATE UNIQUE INDEX idx_tickets_project_number ON tickets (project_id, number) WHERE project_id IS NOT NULL;
+
+ALTER TABLE memo ADD COLUMN project_id INTEGER;
+CREATE INDEX idx_memo_project_id ON memo (project_id);
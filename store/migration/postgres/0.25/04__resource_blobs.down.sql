@@ -0,0 +1 @@
+DROP TABLE resource_blob;
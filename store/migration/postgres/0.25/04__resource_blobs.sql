@@ -0,0 +1,11 @@
+CREATE TABLE resource_blob (
+  hash TEXT NOT NULL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  size BIGINT NOT NULL DEFAULT 0,
+  storage_type TEXT NOT NULL DEFAULT '',
+  reference TEXT NOT NULL DEFAULT '',
+  blob BYTEA,
+  ref_count INTEGER NOT NULL DEFAULT 0,
+  payload TEXT NOT NULL DEFAULT '{}'
+);
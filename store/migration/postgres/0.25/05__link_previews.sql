@@ -0,0 +1,9 @@
+CREATE TABLE link_preview (
+  hash TEXT NOT NULL PRIMARY KEY,
+  url TEXT NOT NULL,
+  title TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  image TEXT NOT NULL DEFAULT '',
+  error TEXT NOT NULL DEFAULT '',
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())
+);
@@ -0,0 +1,14 @@
+CREATE TABLE ticket_status_history (
+  id SERIAL PRIMARY KEY,
+  ticket_id INTEGER NOT NULL,
+  from_status TEXT NOT NULL DEFAULT '',
+  to_status TEXT NOT NULL,
+  created_ts BIGINT NOT NULL
+);
+
+CREATE INDEX idx_ticket_status_history_ticket_id ON ticket_status_history (ticket_id);
+
+INSERT INTO ticket_status_history (ticket_id, from_status, to_status, created_ts)
+SELECT id, '', 'OPEN', created_ts FROM tickets;
+INSERT INTO ticket_status_history (ticket_id, from_status, to_status, created_ts)
+SELECT id, 'OPEN', status, updated_ts FROM tickets WHERE status != 'OPEN';
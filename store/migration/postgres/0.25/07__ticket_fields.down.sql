@@ -0,0 +1,2 @@
+ALTER TABLE tickets DROP COLUMN fields;
+ALTER TABLE tickets DROP COLUMN type;
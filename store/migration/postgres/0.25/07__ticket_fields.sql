@@ -0,0 +1,5 @@
+-- tickets: the type of the ticket, which custom fields are scoped by.
+ALTER TABLE tickets ADD COLUMN type TEXT NOT NULL DEFAULT 'TASK';
+
+-- tickets: the values of the custom fields by field key.
+ALTER TABLE tickets ADD COLUMN fields JSONB NOT NULL DEFAULT '{}';
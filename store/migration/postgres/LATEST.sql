@@ -45,9 +45,12 @@ CREATE TABLE memo (
   content TEXT NOT NULL,
   visibility TEXT NOT NULL DEFAULT 'PRIVATE',
   pinned BOOLEAN NOT NULL DEFAULT FALSE,
-  payload JSONB NOT NULL DEFAULT '{}'
+  payload JSONB NOT NULL DEFAULT '{}',
+  project_id INTEGER
 );
 
+CREATE INDEX idx_memo_project_id ON memo (project_id);
+
 -- memo_organizer
 CREATE TABLE memo_organizer (
   memo_id INTEGER NOT NULL,
@@ -81,6 +84,30 @@ CREATE TABLE resource (
   payload TEXT NOT NULL DEFAULT '{}'
 );
 
+-- resource_blob
+CREATE TABLE resource_blob (
+  hash TEXT NOT NULL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  size BIGINT NOT NULL DEFAULT 0,
+  storage_type TEXT NOT NULL DEFAULT '',
+  reference TEXT NOT NULL DEFAULT '',
+  blob BYTEA,
+  ref_count INTEGER NOT NULL DEFAULT 0,
+  payload TEXT NOT NULL DEFAULT '{}'
+);
+
+-- link_preview
+CREATE TABLE link_preview (
+  hash TEXT NOT NULL PRIMARY KEY,
+  url TEXT NOT NULL,
+  title TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  image TEXT NOT NULL DEFAULT '',
+  error TEXT NOT NULL DEFAULT '',
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())
+);
+
 -- activity
 CREATE TABLE activity (
   id SERIAL PRIMARY KEY,
@@ -130,3 +157,83 @@ CREATE TABLE reaction (
   reaction_type TEXT NOT NULL,
   UNIQUE(creator_id, content_id, reaction_type)
 );
+
+-- tickets
+CREATE TABLE tickets (
+  id SERIAL PRIMARY KEY,
+  title TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL DEFAULT 'OPEN',
+  priority TEXT NOT NULL DEFAULT 'MEDIUM',
+  creator_id INTEGER NOT NULL,
+  assignee_id INTEGER,
+  created_ts BIGINT NOT NULL,
+  updated_ts BIGINT NOT NULL,
+  project_id INTEGER,
+  number INTEGER NOT NULL DEFAULT 0,
+  type TEXT NOT NULL DEFAULT 'TASK',
+  fields JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_tickets_creator_id ON tickets (creator_id);
+CREATE INDEX idx_tickets_status ON tickets (status);
+CREATE UNIQUE INDEX idx_tickets_project_number ON tickets (project_id, number) WHERE project_id IS NOT NULL;
+
+-- ticket_status_history
+CREATE TABLE ticket_status_history (
+  id SERIAL PRIMARY KEY,
+  ticket_id INTEGER NOT NULL,
+  from_status TEXT NOT NULL DEFAULT '',
+  to_status TEXT NOT NULL,
+  created_ts BIGINT NOT NULL
+);
+
+CREATE INDEX idx_ticket_status_history_ticket_id ON ticket_status_history (ticket_id);
+
+-- notifications
+CREATE TABLE notifications (
+  id SERIAL PRIMARY KEY,
+  initiator_id INTEGER NOT NULL,
+  receiver_id INTEGER NOT NULL,
+  ticket_url TEXT NOT NULL,
+  created_ts BIGINT NOT NULL,
+  is_read BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- custom_role
+CREATE TABLE custom_role (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  name TEXT NOT NULL UNIQUE,
+  description TEXT NOT NULL DEFAULT '',
+  permissions TEXT NOT NULL DEFAULT '[]'
+);
+
+-- user_custom_role
+CREATE TABLE user_custom_role (
+  user_id INTEGER NOT NULL,
+  role_id INTEGER NOT NULL,
+  UNIQUE(user_id, role_id)
+);
+
+-- project
+CREATE TABLE project (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  row_status TEXT NOT NULL DEFAULT 'NORMAL',
+  key TEXT NOT NULL UNIQUE,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  payload JSONB NOT NULL DEFAULT '{}'
+);
+
+-- project_member
+CREATE TABLE project_member (
+  project_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  role TEXT NOT NULL DEFAULT 'MEMBER',
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  UNIQUE(project_id, user_id)
+);
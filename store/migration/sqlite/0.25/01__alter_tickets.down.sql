@@ -0,0 +1,2 @@
+ALTER TABLE tickets DROP COLUMN tags;
+ALTER TABLE tickets DROP COLUMN type;
@@ -0,0 +1,41 @@
+-- Revert the beads integration: drop agent_workflows and the beads-specific ticket columns.
+-- SQLite can't drop columns referenced by foreign keys or indexes, so tickets is recreated.
+
+DROP TABLE agent_workflows;
+
+CREATE TEMPORARY TABLE tickets_backup AS SELECT * FROM tickets;
+DROP TABLE tickets;
+
+CREATE TABLE tickets (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  title TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL DEFAULT 'OPEN',
+  priority TEXT NOT NULL DEFAULT 'MEDIUM',
+  creator_id INTEGER NOT NULL,
+  assignee_id INTEGER,
+  created_ts BIGINT NOT NULL,
+  updated_ts BIGINT NOT NULL,
+  type TEXT NOT NULL DEFAULT 'TASK',
+  tags TEXT NOT NULL DEFAULT '[]',
+  FOREIGN KEY (creator_id) REFERENCES user(id) ON DELETE CASCADE,
+  FOREIGN KEY (assignee_id) REFERENCES user(id) ON DELETE SET NULL
+);
+
+INSERT INTO tickets (
+  id, title, description, status, priority,
+  creator_id, assignee_id, created_ts, updated_ts,
+  type, tags
+)
+SELECT
+  id, title, description, status, priority,
+  creator_id, assignee_id, created_ts, updated_ts,
+  type, tags
+FROM tickets_backup
+ORDER BY id ASC;
+
+DROP TABLE tickets_backup;
+
+CREATE INDEX idx_tickets_creator_id ON tickets (creator_id);
+CREATE INDEX idx_tickets_status ON tickets (status);
+CREATE INDEX idx_tickets_assignee_id ON tickets (assignee_id);
@@ -0,0 +1,2 @@
+DROP TABLE user_custom_role;
+DROP TABLE custom_role;
@@ -0,0 +1,16 @@
+-- custom_role
+CREATE TABLE custom_role (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  name TEXT NOT NULL UNIQUE,
+  description TEXT NOT NULL DEFAULT '',
+  permissions TEXT NOT NULL DEFAULT '[]'
+);
+
+-- user_custom_role
+CREATE TABLE user_custom_role (
+  user_id INTEGER NOT NULL,
+  role_id INTEGER NOT NULL,
+  UNIQUE(user_id, role_id)
+);
@@ -0,0 +1,32 @@
+-- Project memos fall back to private memos of their creators.
+CREATE TEMPORARY TABLE memo_backup AS SELECT * FROM memo;
+DROP TABLE memo;
+
+CREATE TABLE memo (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  uid TEXT NOT NULL UNIQUE,
+  creator_id INTEGER NOT NULL,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  row_status TEXT NOT NULL CHECK (row_status IN ('NORMAL', 'ARCHIVED')) DEFAULT 'NORMAL',
+  content TEXT NOT NULL DEFAULT '',
+  visibility TEXT NOT NULL CHECK (visibility IN ('PUBLIC', 'PROTECTED', 'PRIVATE')) DEFAULT 'PRIVATE',
+  pinned INTEGER NOT NULL CHECK (pinned IN (0, 1)) DEFAULT 0,
+  payload TEXT NOT NULL DEFAULT '{}'
+);
+
+INSERT INTO memo (id, uid, creator_id, created_ts, updated_ts, row_status, content, visibility, pinned, payload)
+SELECT id, uid, creator_id, created_ts, updated_ts, row_status, content, CASE WHEN visibility = 'PROJECT' THEN 'PRIVATE' ELSE visibility END, pinned, payload
+FROM memo_backup
+ORDER BY id ASC;
+
+DROP TABLE memo_backup;
+
+CREATE INDEX idx_memo_creator_id ON memo (creator_id);
+
+DROP INDEX idx_tickets_project_number;
+ALTER TABLE tickets DROP COLUMN number;
+ALTER TABLE tickets DROP COLUMN project_id;
+
+DROP TABLE project_member;
+DROP TABLE project;
@@ -0,0 +1,54 @@
+-- project
+CREATE TABLE project (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  row_status TEXT NOT NULL CHECK (row_status IN ('NORMAL', 'ARCHIVED')) DEFAULT 'NORMAL',
+  key TEXT NOT NULL UNIQUE,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  payload TEXT NOT NULL DEFAULT '{}'
+);
+
+-- project_member
+CREATE TABLE project_member (
+  project_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  role TEXT NOT NULL CHECK (role IN ('OWNER', 'MEMBER')) DEFAULT 'MEMBER',
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  UNIQUE(project_id, user_id)
+);
+
+-- tickets: per-project keys such as API-42.
+ALTER TABLE tickets ADD COLUMN project_id INTEGER;
+ALTER TABLE tickets ADD COLUMN number INTEGER NOT NULL DEFAULT 0;
+
+CREATE UNIQUE INDEX idx_tickets_project_number ON tickets (project_id, number) WHERE project_id IS NOT NULL;
+
+-- memo: recreate the table to allow the PROJECT visibility.
+CREATE TEMPORARY TABLE memo_backup AS SELECT * FROM memo;
+DROP TABLE memo;
+
+CREATE TABLE memo (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  uid TEXT NOT NULL UNIQUE,
+  creator_id INTEGER NOT NULL,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  row_status TEXT NOT NULL CHECK (row_status IN ('NORMAL', 'ARCHIVED')) DEFAULT 'NORMAL',
+  content TEXT NOT NULL DEFAULT '',
+  visibility TEXT NOT NULL CHECK (visibility IN ('PUBLIC', 'PROTECTED', 'PRIVATE', 'PROJECT')) DEFAULT 'PRIVATE',
+  pinned INTEGER NOT NULL CHECK (pinned IN (0, 1)) DEFAULT 0,
+  payload TEXT NOT NULL DEFAULT '{}',
+  project_id INTEGER
+);
+
+INSERT INTO memo (id, uid, creator_id, created_ts, updated_ts, row_status, content, visibility, pinned, payload)
+SELECT id, uid, creator_id, created_ts, updated_ts, row_status, content, visibility, pinned, payload
+FROM memo_backup
+ORDER BY id ASC;
+
+DROP TABLE memo_backup;
+
+CREATE INDEX idx_memo_creator_id ON memo (creator_id);
+CREATE INDEX idx_memo_project_id ON memo (project_id);
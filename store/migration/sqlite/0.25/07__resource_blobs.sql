@@ -0,0 +1,12 @@
+-- resource_blob: content addressed resource contents shared by resources.
+CREATE TABLE resource_blob (
+  hash TEXT NOT NULL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  size INTEGER NOT NULL DEFAULT 0,
+  storage_type TEXT NOT NULL DEFAULT '',
+  reference TEXT NOT NULL DEFAULT '',
+  blob BLOB DEFAULT NULL,
+  ref_count INTEGER NOT NULL DEFAULT 0,
+  payload TEXT NOT NULL DEFAULT '{}'
+);
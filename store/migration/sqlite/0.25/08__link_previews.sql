@@ -0,0 +1,10 @@
+-- link_preview: cached metadata of the links previewed in memos.
+CREATE TABLE link_preview (
+  hash TEXT NOT NULL PRIMARY KEY,
+  url TEXT NOT NULL,
+  title TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  image TEXT NOT NULL DEFAULT '',
+  error TEXT NOT NULL DEFAULT '',
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now'))
+);
@@ -0,0 +1,16 @@
+-- ticket_status_history: the status transitions of tickets, used by the ticket metrics.
+CREATE TABLE ticket_status_history (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  ticket_id INTEGER NOT NULL,
+  from_status TEXT NOT NULL DEFAULT '',
+  to_status TEXT NOT NULL,
+  created_ts BIGINT NOT NULL
+);
+
+CREATE INDEX idx_ticket_status_history_ticket_id ON ticket_status_history (ticket_id);
+
+-- Existing tickets were opened when created, and moved to their current status when last updated.
+INSERT INTO ticket_status_history (ticket_id, from_status, to_status, created_ts)
+SELECT id, '', 'OPEN', created_ts FROM tickets;
+INSERT INTO ticket_status_history (ticket_id, from_status, to_status, created_ts)
+SELECT id, 'OPEN', status, updated_ts FROM tickets WHERE status != 'OPEN';
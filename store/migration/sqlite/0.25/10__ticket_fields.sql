@@ -0,0 +1,2 @@
+-- tickets: the values of the custom fields by field key.
+ALTER TABLE tickets ADD COLUMN fields TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,3 @@
+-- Restore the index as 04__tickets_add_foreign_keys.sql created it.
+DROP INDEX IF EXISTS idx_tickets_beads_id;
+CREATE UNIQUE INDEX idx_tickets_beads_id ON tickets(beads_id) WHERE beads_id IS NOT NULL;
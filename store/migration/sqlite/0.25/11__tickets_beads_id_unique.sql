@@ -0,0 +1,4 @@
+-- tickets: beads ids are unique. 03__beads_integration.sql declares a UNIQUE column, which
+-- SQLite can't add to an existing table, so the uniqueness is enforced by the index instead.
+DROP INDEX IF EXISTS idx_tickets_beads_id;
+CREATE UNIQUE INDEX idx_tickets_beads_id ON tickets(beads_id) WHERE beads_id IS NOT NULL;
@@ -46,12 +46,14 @@ CREATE TABLE memo (
   updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
   row_status TEXT NOT NULL CHECK (row_status IN ('NORMAL', 'ARCHIVED')) DEFAULT 'NORMAL',
   content TEXT NOT NULL DEFAULT '',
-  visibility TEXT NOT NULL CHECK (visibility IN ('PUBLIC', 'PROTECTED', 'PRIVATE')) DEFAULT 'PRIVATE',
+  visibility TEXT NOT NULL CHECK (visibility IN ('PUBLIC', 'PROTECTED', 'PRIVATE', 'PROJECT')) DEFAULT 'PRIVATE',
   pinned INTEGER NOT NULL CHECK (pinned IN (0, 1)) DEFAULT 0,
-  payload TEXT NOT NULL DEFAULT '{}'
+  payload TEXT NOT NULL DEFAULT '{}',
+  project_id INTEGER
 );
 
 CREATE INDEX idx_memo_creator_id ON memo (creator_id);
+CREATE INDEX idx_memo_project_id ON memo (project_id);
 
 -- memo_organizer
 CREATE TABLE memo_organizer (
@@ -90,6 +92,30 @@ CREATE INDEX idx_resource_creator_id ON resource (creator_id);
 
 CREATE INDEX idx_resource_memo_id ON resource (memo_id);
 
+-- resource_blob
+CREATE TABLE resource_blob (
+  hash TEXT NOT NULL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  size INTEGER NOT NULL DEFAULT 0,
+  storage_type TEXT NOT NULL DEFAULT '',
+  reference TEXT NOT NULL DEFAULT '',
+  blob BLOB DEFAULT NULL,
+  ref_count INTEGER NOT NULL DEFAULT 0,
+  payload TEXT NOT NULL DEFAULT '{}'
+);
+
+-- link_preview
+CREATE TABLE link_preview (
+  hash TEXT NOT NULL PRIMARY KEY,
+  url TEXT NOT NULL,
+  title TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  image TEXT NOT NULL DEFAULT '',
+  error TEXT NOT NULL DEFAULT '',
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+
 -- activity
 CREATE TABLE activity (
   id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -149,13 +175,107 @@ CREATE TABLE tickets (
   description TEXT NOT NULL DEFAULT '',
   status TEXT NOT NULL DEFAULT 'OPEN',
   priority TEXT NOT NULL DEFAULT 'MEDIUM',
+  type TEXT NOT NULL DEFAULT 'TASK',
+  tags TEXT NOT NULL DEFAULT '[]',
   creator_id INTEGER NOT NULL,
   assignee_id INTEGER,
   created_ts BIGINT NOT NULL,
   updated_ts BIGINT NOT NULL,
-  type TEXT NOT NULL DEFAULT 'TASK',
-  tags TEXT NOT NULL DEFAULT '[]'
+  beads_id TEXT,
+  parent_id INTEGER,
+  labels TEXT DEFAULT '[]',
+  dependencies TEXT DEFAULT '[]',
+  discovery_context TEXT,
+  closed_reason TEXT,
+  issue_type TEXT,
+  project_id INTEGER,
+  number INTEGER NOT NULL DEFAULT 0,
+  fields TEXT NOT NULL DEFAULT '{}',
+  FOREIGN KEY (creator_id) REFERENCES user(id) ON DELETE CASCADE,
+  FOREIGN KEY (assignee_id) REFERENCES user(id) ON DELETE SET NULL,
+  FOREIGN KEY (parent_id) REFERENCES tickets(id) ON DELETE CASCADE
 );
 
 CREATE INDEX idx_tickets_creator_id ON tickets (creator_id);
 CREATE INDEX idx_tickets_status ON tickets (status);
+CREATE INDEX idx_tickets_assignee_id ON tickets (assignee_id);
+CREATE UNIQUE INDEX idx_tickets_beads_id ON tickets(beads_id) WHERE beads_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_tickets_project_number ON tickets (project_id, number) WHERE project_id IS NOT NULL;
+
+-- ticket_status_history
+CREATE TABLE ticket_status_history (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  ticket_id INTEGER NOT NULL,
+  from_status TEXT NOT NULL DEFAULT '',
+  to_status TEXT NOT NULL,
+  created_ts BIGINT NOT NULL
+);
+
+CREATE INDEX idx_ticket_status_history_ticket_id ON ticket_status_history (ticket_id);
+
+-- notifications
+CREATE TABLE notifications (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  initiator_id INTEGER NOT NULL,
+  receiver_id INTEGER NOT NULL,
+  ticket_url TEXT NOT NULL,
+  created_ts BIGINT NOT NULL,
+  is_read BOOLEAN NOT NULL DEFAULT 0
+);
+
+-- agent_workflows
+CREATE TABLE agent_workflows (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  ticket_id INTEGER NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+  session_id TEXT NOT NULL,
+  agent_name TEXT NOT NULL DEFAULT 'antigravity',
+  task_name TEXT,
+  task_mode TEXT CHECK(task_mode IN ('PLANNING', 'EXECUTION', 'VERIFICATION')),
+  task_status TEXT,
+  task_summary TEXT,
+  predicted_size INTEGER,
+  created_ts INTEGER NOT NULL,
+  metadata TEXT DEFAULT '{}'
+);
+
+CREATE INDEX idx_workflows_ticket ON agent_workflows(ticket_id);
+CREATE INDEX idx_workflows_session ON agent_workflows(session_id);
+CREATE INDEX idx_workflows_created ON agent_workflows(created_ts);
+
+-- custom_role
+CREATE TABLE custom_role (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  name TEXT NOT NULL UNIQUE,
+  description TEXT NOT NULL DEFAULT '',
+  permissions TEXT NOT NULL DEFAULT '[]'
+);
+
+-- user_custom_role
+CREATE TABLE user_custom_role (
+  user_id INTEGER NOT NULL,
+  role_id INTEGER NOT NULL,
+  UNIQUE(user_id, role_id)
+);
+
+-- project
+CREATE TABLE project (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  row_status TEXT NOT NULL CHECK (row_status IN ('NORMAL', 'ARCHIVED')) DEFAULT 'NORMAL',
+  key TEXT NOT NULL UNIQUE,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  payload TEXT NOT NULL DEFAULT '{}'
+);
+
+-- project_member
+CREATE TABLE project_member (
+  project_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  role TEXT NOT NULL CHECK (role IN ('OWNER', 'MEMBER')) DEFAULT 'MEMBER',
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  UNIQUE(project_id, user_id)
+);
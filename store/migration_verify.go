@@ -0,0 +1,171 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SchemaDrift is a table or column that differs between the database and LATEST.sql.
+type SchemaDrift struct {
+	Table string
+	// Column is empty when the whole table drifts.
+	Column string
+	// Missing reports whether the object is declared in LATEST.sql but absent from the database.
+	// Otherwise it only exists in the database.
+	Missing bool
+}
+
+func (d *SchemaDrift) String() string {
+	object := fmt.Sprintf("table %s", d.Table)
+	if d.Column != "" {
+		object = fmt.Sprintf("column %s.%s", d.Table, d.Column)
+	}
+	if d.Missing {
+		return object + " is missing from the database"
+	}
+	return object + " is not declared in " + LatestSchemaFileName
+}
+
+// VerifySchema compares the tables and columns of the database with LATEST.sql of the driver.
+func (s *Store) VerifySchema(ctx context.Context) ([]*SchemaDrift, error) {
+	bytes, err := migrationFS.ReadFile(s.getMigrationBasePath() + LatestSchemaFileName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read latest schema file")
+	}
+	expected := parseSchemaColumns(string(bytes))
+	actual, err := s.listSchemaColumns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := []*SchemaDrift{}
+	for _, table := range sortedKeys(expected) {
+		columns, ok := actual[table]
+		if !ok {
+			drifts = append(drifts, &SchemaDrift{Table: table, Missing: true})
+			continue
+		}
+		for _, column := range expected[table] {
+			if !slices.Contains(columns, column) {
+				drifts = append(drifts, &SchemaDrift{Table: table, Column: column, Missing: true})
+			}
+		}
+		for _, column := range columns {
+			if !slices.Contains(expected[table], column) {
+				drifts = append(drifts, &SchemaDrift{Table: table, Column: column})
+			}
+		}
+	}
+	for _, table := range sortedKeys(actual) {
+		if _, ok := expected[table]; !ok {
+			drifts = append(drifts, &SchemaDrift{Table: table})
+		}
+	}
+	return drifts, nil
+}
+
+// listSchemaColumns introspects the live schema and returns the columns per table.
+func (s *Store) listSchemaColumns(ctx context.Context) (map[string][]string, error) {
+	var query string
+	switch s.profile.Driver {
+	case "sqlite":
+		query = "SELECT m.name, p.name FROM sqlite_master m JOIN pragma_table_info(m.name) p WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' ORDER BY m.name, p.cid"
+	case "mysql":
+		query = "SELECT TABLE_NAME, COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() ORDER BY TABLE_NAME, ORDINAL_POSITION"
+	case "postgres":
+		query = "SELECT table_name, column_name FROM information_schema.columns WHERE table_schema = current_schema() ORDER BY table_name, ordinal_position"
+	default:
+		return nil, errors.Errorf("unsupported driver %s", s.profile.Driver)
+	}
+
+	rows, err := s.driver.GetDB().QueryContext(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query schema")
+	}
+	defer rows.Close()
+
+	columns := map[string][]string{}
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return nil, errors.Wrap(err, "failed to scan schema")
+		}
+		table, column = strings.ToLower(table), strings.ToLower(column)
+		columns[table] = append(columns[table], column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+var (
+	createTableRegexp = regexp.MustCompile("(?is)CREATE\\s+TABLE\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?[`\"]?(\\w+)[`\"]?\\s*\\(")
+	sqlCommentRegexp  = regexp.MustCompile(`--[^\n]*`)
+	columnNameRegexp  = regexp.MustCompile("^\\s*([`\"]?)(\\w+)")
+)
+
+// parseSchemaColumns returns the columns per table declared by the CREATE TABLE statements of schema.
+// It only understands the subset of SQL used by the LATEST.sql files.
+func parseSchemaColumns(schema string) map[string][]string {
+	schema = sqlCommentRegexp.ReplaceAllString(schema, "")
+	columns := map[string][]string{}
+	for _, match := range createTableRegexp.FindAllStringSubmatchIndex(schema, -1) {
+		table := strings.ToLower(schema[match[2]:match[3]])
+		columns[table] = []string{}
+		for _, definition := range splitTableDefinitions(schema[match[1]:]) {
+			nameMatch := columnNameRegexp.FindStringSubmatch(definition)
+			if nameMatch == nil {
+				continue
+			}
+			// Quoted names are always columns, e.g. `key` in mysql.
+			if nameMatch[1] == "" {
+				switch strings.ToUpper(nameMatch[2]) {
+				case "PRIMARY", "FOREIGN", "UNIQUE", "CONSTRAINT", "INDEX", "CHECK":
+					continue
+				}
+			}
+			columns[table] = append(columns[table], strings.ToLower(nameMatch[2]))
+		}
+	}
+	return columns
+}
+
+// splitTableDefinitions splits the body of a CREATE TABLE statement starting after its opening
+// parenthesis into top-level definitions, stopping at the closing parenthesis.
+func splitTableDefinitions(body string) []string {
+	definitions := []string{}
+	depth, start := 0, 0
+	inQuote := false
+	for i, r := range body {
+		switch {
+		case r == '\'':
+			inQuote = !inQuote
+		case inQuote:
+		case r == '(':
+			depth++
+		case r == ')' && depth == 0:
+			return append(definitions, body[start:i])
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			definitions = append(definitions, body[start:i])
+			start = i + 1
+		}
+	}
+	return definitions
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -8,6 +8,7 @@ import (
 	"io/fs"
 	"log/slog"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -31,8 +32,31 @@ const (
 	// LatestSchemaFileName is the name of the latest schema file.
 	// This file is used to apply the latest schema when no migration history is found.
 	LatestSchemaFileName = "LATEST.sql"
+	// DownMigrateFileSuffix is the suffix of the optional script reverting a migration file.
+	// For example, "1__create_table.down.sql" reverts "1__create_table.sql".
+	DownMigrateFileSuffix = ".down.sql"
 )
 
+// MigrationScript is a migration file of the current driver.
+type MigrationScript struct {
+	// Path is the path of the file in the embedded migration directory.
+	Path string
+	// SchemaVersion is the schema version after the file is applied.
+	SchemaVersion string
+	Statement     string
+	// DownStatement reverts the migration, empty if the file has no down script.
+	DownStatement string
+}
+
+// MigrationStatus compares the schema version of the database with the current binary.
+type MigrationStatus struct {
+	// CurrentSchemaVersion is the latest version in the migration history, empty for a new database.
+	CurrentSchemaVersion string
+	TargetSchemaVersion  string
+	// Pending is the list of scripts Migrate would apply, in order.
+	Pending []*MigrationScript
+}
+
 // Migrate applies the latest schema to the database.
 func (s *Store) Migrate(ctx context.Context) error {
 	if err := s.preMigrate(ctx); err != nil {
@@ -56,23 +80,17 @@ func (s *Store) Migrate(ctx context.Context) error {
 			return errors.Errorf("no migration history found")
 		}
 
-		migrationHistoryVersions := []string{}
-		for _, migrationHistory := range migrationHistoryList {
-			migrationHistoryVersions = append(migrationHistoryVersions, migrationHistory.Version)
-		}
-		sort.Sort(version.SortVersion(migrationHistoryVersions))
-		latestMigrationHistoryVersion := migrationHistoryVersions[len(migrationHistoryVersions)-1]
+		latestMigrationHistoryVersion := getLatestMigrationHistoryVersion(migrationHistoryList)
 		schemaVersion, err := s.GetCurrentSchemaVersion()
 		if err != nil {
 			return errors.Wrap(err, "failed to get current schema version")
 		}
 
 		if version.IsVersionGreaterThan(schemaVersion, latestMigrationHistoryVersion) {
-			filePaths, err := fs.Glob(migrationFS, fmt.Sprintf("%s*/*.sql", s.getMigrationBasePath()))
+			migrationScripts, err := s.listMigrationScripts(latestMigrationHistoryVersion, schemaVersion)
 			if err != nil {
-				return errors.Wrap(err, "failed to read migration files")
+				return err
 			}
-			sort.Strings(filePaths)
 
 			// Start a transaction to apply the latest schema.
 			tx, err := s.driver.GetDB().Begin()
@@ -82,20 +100,9 @@ func (s *Store) Migrate(ctx context.Context) error {
 			defer tx.Rollback()
 
 			slog.Info("start migration", slog.String("currentSchemaVersion", latestMigrationHistoryVersion), slog.String("targetSchemaVersion", schemaVersion))
-			for _, filePath := range filePaths {
-				fileSchemaVersion, err := s.getSchemaVersionOfMigrateScript(filePath)
-				if err != nil {
-					return errors.Wrap(err, "failed to get schema version of migrate script")
-				}
-				if version.IsVersionGreaterThan(fileSchemaVersion, latestMigrationHistoryVersion) && version.IsVersionGreaterOrEqualThan(schemaVersion, fileSchemaVersion) {
-					bytes, err := migrationFS.ReadFile(filePath)
-					if err != nil {
-						return errors.Wrapf(err, "failed to read minor version migration file: %s", filePath)
-					}
-					stmt := string(bytes)
-					if err := s.execute(ctx, tx, stmt); err != nil {
-						return errors.Wrapf(err, "migrate error: %s", stmt)
-					}
+			for _, migrationScript := range migrationScripts {
+				if err := s.execute(ctx, tx, migrationScript.Statement); err != nil {
+					return errors.Wrapf(err, "migrate error: %s", migrationScript.Statement)
 				}
 			}
 
@@ -121,6 +128,112 @@ func (s *Store) Migrate(ctx context.Context) error {
 			return errors.Wrap(err, "failed to seed")
 		}
 	}
+	// Older versions stored access tokens in plaintext, hash them once the schema is up to date.
+	if err := s.hashUserAccessTokens(ctx); err != nil {
+		return errors.Wrap(err, "failed to hash access tokens")
+	}
+	return nil
+}
+
+// GetMigrationStatus returns the migrations pending for the database without applying them.
+func (s *Store) GetMigrationStatus(ctx context.Context) (*MigrationStatus, error) {
+	schemaVersion, err := s.GetCurrentSchemaVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current schema version")
+	}
+	migrationStatus := &MigrationStatus{
+		TargetSchemaVersion: schemaVersion,
+		Pending:             []*MigrationScript{},
+	}
+
+	migrationHistoryList, err := s.driver.FindMigrationHistoryList(ctx, &FindMigrationHistory{})
+	// A new database gets the latest schema, same as preMigrate.
+	if err != nil || len(migrationHistoryList) == 0 {
+		filePath := s.getMigrationBasePath() + LatestSchemaFileName
+		bytes, err := migrationFS.ReadFile(filePath)
+		if err != nil {
+			return nil, errors.Errorf("failed to read latest schema file: %s", err)
+		}
+		migrationStatus.Pending = append(migrationStatus.Pending, &MigrationScript{
+			Path:          filePath,
+			SchemaVersion: schemaVersion,
+			Statement:     string(bytes),
+		})
+		return migrationStatus, nil
+	}
+
+	migrationStatus.CurrentSchemaVersion = getLatestMigrationHistoryVersion(migrationHistoryList)
+	if version.IsVersionGreaterThan(schemaVersion, migrationStatus.CurrentSchemaVersion) {
+		migrationScripts, err := s.listMigrationScripts(migrationStatus.CurrentSchemaVersion, schemaVersion)
+		if err != nil {
+			return nil, err
+		}
+		migrationStatus.Pending = migrationScripts
+	}
+	return migrationStatus, nil
+}
+
+// MigrateDown reverts the migrations applied after targetVersion with their down scripts.
+// Nothing is reverted unless every migration file to revert has a down script.
+func (s *Store) MigrateDown(ctx context.Context, targetVersion string) error {
+	migrationHistoryList, err := s.driver.FindMigrationHistoryList(ctx, &FindMigrationHistory{})
+	if err != nil {
+		return errors.Wrap(err, "failed to find migration history")
+	}
+	if len(migrationHistoryList) == 0 {
+		return errors.Errorf("no migration history found")
+	}
+	latestMigrationHistoryVersion := getLatestMigrationHistoryVersion(migrationHistoryList)
+	if !version.IsVersionGreaterThan(latestMigrationHistoryVersion, targetVersion) {
+		return errors.Errorf("schema version %s is not newer than %s", latestMigrationHistoryVersion, targetVersion)
+	}
+
+	migrationScripts, err := s.listMigrationScripts(targetVersion, latestMigrationHistoryVersion)
+	if err != nil {
+		return err
+	}
+	for _, migrationScript := range migrationScripts {
+		if migrationScript.DownStatement == "" {
+			return errors.Errorf("no down script for %s", migrationScript.Path)
+		}
+	}
+
+	tx, err := s.driver.GetDB().Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to start transaction")
+	}
+	defer tx.Rollback()
+
+	slog.Info("start down migration", slog.String("currentSchemaVersion", latestMigrationHistoryVersion), slog.String("targetSchemaVersion", targetVersion))
+	for i := len(migrationScripts) - 1; i >= 0; i-- {
+		if err := s.execute(ctx, tx, migrationScripts[i].DownStatement); err != nil {
+			return errors.Wrapf(err, "down migrate error: %s", migrationScripts[i].Path)
+		}
+	}
+	deleteMigrationHistoryStmt := "DELETE FROM migration_history WHERE version = ?"
+	if s.profile.Driver == "postgres" {
+		deleteMigrationHistoryStmt = "DELETE FROM migration_history WHERE version = $1"
+	}
+	for _, migrationHistory := range migrationHistoryList {
+		if version.IsVersionGreaterThan(migrationHistory.Version, targetVersion) {
+			if err := s.execute(ctx, tx, deleteMigrationHistoryStmt, migrationHistory.Version); err != nil {
+				return errors.Wrap(err, "failed to delete migration history")
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	slog.Info("end down migrate")
+
+	if _, err = s.driver.UpsertMigrationHistory(ctx, &UpsertMigrationHistory{
+		Version: targetVersion,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to upsert migration history with version: %s", targetVersion)
+	}
+	if err := s.updateCurrentSchemaVersion(ctx, targetVersion); err != nil {
+		return errors.Wrap(err, "failed to update current schema version")
+	}
 	return nil
 }
 
@@ -217,12 +330,10 @@ func (s *Store) seed(ctx context.Context) error {
 func (s *Store) GetCurrentSchemaVersion() (string, error) {
 	currentVersion := version.GetCurrentVersion(s.profile.Mode)
 	minorVersion := version.GetMinorVersion(currentVersion)
-	filePaths, err := fs.Glob(migrationFS, fmt.Sprintf("%s%s/*.sql", s.getMigrationBasePath(), minorVersion))
+	filePaths, err := s.listMigrationFilePaths(minorVersion)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to read migration files")
+		return "", err
 	}
-
-	sort.Strings(filePaths)
 	if len(filePaths) == 0 {
 		return fmt.Sprintf("%s.0", minorVersion), nil
 	}
@@ -249,9 +360,66 @@ func (s *Store) getSchemaVersionOfMigrateScript(filePath string) (string, error)
 	return fmt.Sprintf("%s.%d", minorVersion, patchVersion+1), nil
 }
 
+// listMigrationFilePaths returns the sorted migration files of the minor version, down scripts excluded.
+func (s *Store) listMigrationFilePaths(minorVersion string) ([]string, error) {
+	filePaths, err := fs.Glob(migrationFS, fmt.Sprintf("%s%s/*.sql", s.getMigrationBasePath(), minorVersion))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read migration files")
+	}
+	filePaths = slices.DeleteFunc(filePaths, func(filePath string) bool {
+		return strings.HasSuffix(filePath, DownMigrateFileSuffix)
+	})
+	sort.Strings(filePaths)
+	return filePaths, nil
+}
+
+// listMigrationScripts returns the migration scripts with schema versions in (fromVersion, toVersion].
+func (s *Store) listMigrationScripts(fromVersion, toVersion string) ([]*MigrationScript, error) {
+	filePaths, err := s.listMigrationFilePaths("*")
+	if err != nil {
+		return nil, err
+	}
+
+	migrationScripts := []*MigrationScript{}
+	for _, filePath := range filePaths {
+		fileSchemaVersion, err := s.getSchemaVersionOfMigrateScript(filePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get schema version of migrate script")
+		}
+		if !version.IsVersionGreaterThan(fileSchemaVersion, fromVersion) || !version.IsVersionGreaterOrEqualThan(toVersion, fileSchemaVersion) {
+			continue
+		}
+		bytes, err := migrationFS.ReadFile(filePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read minor version migration file: %s", filePath)
+		}
+		migrationScript := &MigrationScript{
+			Path:          filePath,
+			SchemaVersion: fileSchemaVersion,
+			Statement:     string(bytes),
+		}
+		downBytes, err := migrationFS.ReadFile(strings.TrimSuffix(filePath, ".sql") + DownMigrateFileSuffix)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, errors.Wrapf(err, "failed to read down migration file of %s", filePath)
+		}
+		migrationScript.DownStatement = string(downBytes)
+		migrationScripts = append(migrationScripts, migrationScript)
+	}
+	return migrationScripts, nil
+}
+
+func getLatestMigrationHistoryVersion(migrationHistoryList []*MigrationHistory) string {
+	versions := []string{}
+	for _, migrationHistory := range migrationHistoryList {
+		versions = append(versions, migrationHistory.Version)
+	}
+	sort.Sort(version.SortVersion(versions))
+	return versions[len(versions)-1]
+}
+
 // execute runs a single SQL statement within a transaction.
-func (*Store) execute(ctx context.Context, tx *sql.Tx, stmt string) error {
-	if _, err := tx.ExecContext(ctx, stmt); err != nil {
+func (*Store) execute(ctx context.Context, tx *sql.Tx, stmt string, args ...any) error {
+	if _, err := tx.ExecContext(ctx, stmt, args...); err != nil {
 		return errors.Wrap(err, "failed to execute statement")
 	}
 	return nil
@@ -262,12 +430,7 @@ func (s *Store) normalizedMigrationHistoryList(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to find migration history")
 	}
-	versions := []string{}
-	for _, migrationHistory := range migrationHistoryList {
-		versions = append(versions, migrationHistory.Version)
-	}
-	sort.Sort(version.SortVersion(versions))
-	latestVersion := versions[len(versions)-1]
+	latestVersion := getLatestMigrationHistoryVersion(migrationHistoryList)
 	latestMinorVersion := version.GetMinorVersion(latestVersion)
 
 	// If the latest version is greater than 0.22, return.
@@ -277,11 +440,10 @@ func (s *Store) normalizedMigrationHistoryList(ctx context.Context) error {
 	}
 
 	schemaVersionMap := map[string]string{}
-	filePaths, err := fs.Glob(migrationFS, fmt.Sprintf("%s*/*.sql", s.getMigrationBasePath()))
+	filePaths, err := s.listMigrationFilePaths("*")
 	if err != nil {
-		return errors.Wrap(err, "failed to read migration files")
+		return err
 	}
-	sort.Strings(filePaths)
 	for _, filePath := range filePaths {
 		fileSchemaVersion, err := s.getSchemaVersionOfMigrateScript(filePath)
 		if err != nil {
@@ -26,6 +26,10 @@ type UpdateNotification struct {
 	IsRead *bool
 }
 
+type DeleteNotification struct {
+	ID int32
+}
+
 func (s *Store) CreateNotification(ctx context.Context, create *Notification) (*Notification, error) {
 	return s.driver.CreateNotification(ctx, create)
 }
@@ -37,3 +41,7 @@ func (s *Store) ListNotifications(ctx context.Context, find *FindNotification) (
 func (s *Store) UpdateNotification(ctx context.Context, update *UpdateNotification) (*Notification, error) {
 	return s.driver.UpdateNotification(ctx, update)
 }
+
+func (s *Store) DeleteNotification(ctx context.Context, delete *DeleteNotification) error {
+	return s.driver.DeleteNotification(ctx, delete)
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// Permission is a named capability checked before an action is performed.
+type Permission string
+
+const (
+	PermissionTicketView      Permission = "ticket.view"
+	PermissionTicketCreate    Permission = "ticket.create"
+	PermissionTicketEditOwn   Permission = "ticket.edit.own"
+	PermissionTicketEditAny   Permission = "ticket.edit.any"
+	PermissionTicketDeleteOwn Permission = "ticket.delete.own"
+	PermissionTicketDelete    Permission = "ticket.delete"
+	PermissionTicketAssign    Permission = "ticket.assign"
+
+	PermissionUserManage             Permission = "user.manage"
+	PermissionWorkspaceSettingManage Permission = "workspace.setting.manage"
+	PermissionWebhookManage          Permission = "webhook.manage"
+	PermissionIdentityProviderManage Permission = "identity_provider.manage"
+	PermissionRoleManage             Permission = "role.manage"
+	PermissionBackupManage           Permission = "backup.manage"
+	PermissionProjectManage          Permission = "project.manage"
+)
+
+var permissionDescriptions = map[Permission]string{
+	PermissionTicketView:             "View tickets.",
+	PermissionTicketCreate:           "Create tickets.",
+	PermissionTicketEditOwn:          "Edit tickets created by or assigned to the user.",
+	PermissionTicketEditAny:          "Edit any ticket.",
+	PermissionTicketDeleteOwn:        "Delete tickets created by the user.",
+	PermissionTicketDelete:           "Delete any ticket.",
+	PermissionTicketAssign:           "Assign tickets to other users.",
+	PermissionUserManage:             "Create, update and delete users.",
+	PermissionWorkspaceSettingManage: "Change workspace settings.",
+	PermissionWebhookManage:          "Create, update and delete webhooks.",
+	PermissionIdentityProviderManage: "Create, update and delete identity providers.",
+	PermissionRoleManage:             "Manage custom roles and role assignments.",
+	PermissionBackupManage:           "Create and list database backups.",
+	PermissionProjectManage:          "Create projects and manage any project and its members.",
+}
+
+// rolePermissions are the permissions granted by the built-in roles.
+var rolePermissions = map[Role][]Permission{
+	RoleHost: {
+		PermissionTicketView,
+		PermissionTicketCreate,
+		PermissionTicketEditOwn,
+		PermissionTicketEditAny,
+		PermissionTicketDeleteOwn,
+		PermissionTicketDelete,
+		PermissionTicketAssign,
+		PermissionUserManage,
+		PermissionWorkspaceSettingManage,
+		PermissionWebhookManage,
+		PermissionIdentityProviderManage,
+		PermissionRoleManage,
+		PermissionBackupManage,
+		PermissionProjectManage,
+	},
+	RoleAdmin: {
+		PermissionTicketView,
+		PermissionTicketCreate,
+		PermissionTicketEditOwn,
+		PermissionTicketEditAny,
+		PermissionTicketDeleteOwn,
+		PermissionTicketDelete,
+		PermissionTicketAssign,
+		PermissionUserManage,
+		PermissionWebhookManage,
+		PermissionProjectManage,
+	},
+	RoleUser: {
+		PermissionTicketView,
+		PermissionTicketCreate,
+		PermissionTicketEditOwn,
+		PermissionTicketDeleteOwn,
+		PermissionTicketAssign,
+		PermissionWebhookManage,
+	},
+}
+
+func (p Permission) String() string {
+	return string(p)
+}
+
+// Description returns a human readable description of the permission.
+func (p Permission) Description() string {
+	return permissionDescriptions[p]
+}
+
+// IsValid reports whether p is a known permission.
+func (p Permission) IsValid() bool {
+	_, ok := permissionDescriptions[p]
+	return ok
+}
+
+// ListPermissions returns all known permissions in a stable order.
+func ListPermissions() []Permission {
+	return slices.Clone(rolePermissions[RoleHost])
+}
+
+// ListRolePermissions returns the permissions granted by a built-in role.
+func ListRolePermissions(role Role) []Permission {
+	return slices.Clone(rolePermissions[role])
+}
+
+// ListUserPermissions returns the effective permissions of user, i.e. the permissions of its
+// built-in role together with the permissions of the custom roles assigned to it.
+func (s *Store) ListUserPermissions(ctx context.Context, user *User) ([]Permission, error) {
+	if user == nil {
+		return []Permission{}, nil
+	}
+	permissions := ListRolePermissions(user.Role)
+	customRoles, err := s.ListUserCustomRoles(ctx, user.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list custom roles")
+	}
+	for _, customRole := range customRoles {
+		for _, permission := range customRole.Permissions {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions, nil
+}
+
+// HasPermission reports whether user is granted permission.
+func (s *Store) HasPermission(ctx context.Context, user *User, permission Permission) (bool, error) {
+	if user == nil {
+		return false, nil
+	}
+	if slices.Contains(rolePermissions[user.Role], permission) {
+		return true, nil
+	}
+	permissions, err := s.ListUserPermissions(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(permissions, permission), nil
+}
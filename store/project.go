@@ -0,0 +1,282 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// ProjectKeyMatcher matches project keys, e.g. "API". Keys prefix the keys of the tickets in the project.
+var ProjectKeyMatcher = regexp.MustCompile("^[A-Z][A-Z0-9]{1,9}$")
+
+// ProjectMemberRole is the role of a user in a project.
+type ProjectMemberRole string
+
+const (
+	// ProjectMemberRoleOwner can change the project and manage its members.
+	ProjectMemberRoleOwner ProjectMemberRole = "OWNER"
+	// ProjectMemberRoleMember can see the tickets and memos of the project.
+	ProjectMemberRoleMember ProjectMemberRole = "MEMBER"
+)
+
+type Project struct {
+	ID int32
+
+	// Standard fields
+	RowStatus RowStatus
+	CreatedTs int64
+	UpdatedTs int64
+
+	// Domain specific fields
+	Key         string
+	Name        string
+	Description string
+	Payload     *storepb.ProjectPayload
+}
+
+type FindProject struct {
+	ID        *int32
+	Key       *string
+	RowStatus *RowStatus
+	// IDList filters the projects by ID when not nil.
+	IDList []int32
+}
+
+type UpdateProject struct {
+	ID          int32
+	UpdatedTs   *int64
+	RowStatus   *RowStatus
+	Name        *string
+	Description *string
+	Payload     *storepb.ProjectPayload
+}
+
+type DeleteProject struct {
+	ID int32
+}
+
+type ProjectMember struct {
+	ProjectID int32
+	UserID    int32
+	Role      ProjectMemberRole
+	CreatedTs int64
+}
+
+type FindProjectMember struct {
+	ProjectID *int32
+	UserID    *int32
+}
+
+type DeleteProjectMember struct {
+	ProjectID *int32
+	UserID    *int32
+}
+
+func (s *Store) CreateProject(ctx context.Context, create *Project) (*Project, error) {
+	if !ProjectKeyMatcher.MatchString(create.Key) {
+		return nil, errors.Errorf("invalid project key %q", create.Key)
+	}
+	if create.Payload == nil {
+		create.Payload = &storepb.ProjectPayload{}
+	}
+	if err := ValidateProjectPayload(create.Payload); err != nil {
+		return nil, err
+	}
+	return s.driver.CreateProject(ctx, create)
+}
+
+func (s *Store) ListProjects(ctx context.Context, find *FindProject) ([]*Project, error) {
+	if find.IDList != nil && len(find.IDList) == 0 {
+		return []*Project{}, nil
+	}
+	return s.driver.ListProjects(ctx, find)
+}
+
+func (s *Store) GetProject(ctx context.Context, find *FindProject) (*Project, error) {
+	list, err := s.ListProjects(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) UpdateProject(ctx context.Context, update *UpdateProject) (*Project, error) {
+	if update.Payload != nil {
+		if err := ValidateProjectPayload(update.Payload); err != nil {
+			return nil, err
+		}
+	}
+	return s.driver.UpdateProject(ctx, update)
+}
+
+// DeleteProject deletes the project and its memberships. Tickets and memos of the project are kept
+// and become workspace-wide.
+func (s *Store) DeleteProject(ctx context.Context, delete *DeleteProject) error {
+	if err := s.driver.DeleteProjectMember(ctx, &DeleteProjectMember{ProjectID: &delete.ID}); err != nil {
+		return errors.Wrap(err, "failed to delete project members")
+	}
+	return s.driver.DeleteProject(ctx, delete)
+}
+
+func (s *Store) UpsertProjectMember(ctx context.Context, upsert *ProjectMember) (*ProjectMember, error) {
+	if upsert.Role == "" {
+		upsert.Role = ProjectMemberRoleMember
+	}
+	if upsert.Role != ProjectMemberRoleOwner && upsert.Role != ProjectMemberRoleMember {
+		return nil, errors.Errorf("invalid project member role %q", upsert.Role)
+	}
+	return s.driver.UpsertProjectMember(ctx, upsert)
+}
+
+func (s *Store) ListProjectMembers(ctx context.Context, find *FindProjectMember) ([]*ProjectMember, error) {
+	return s.driver.ListProjectMembers(ctx, find)
+}
+
+func (s *Store) GetProjectMember(ctx context.Context, find *FindProjectMember) (*ProjectMember, error) {
+	list, err := s.ListProjectMembers(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) DeleteProjectMember(ctx context.Context, delete *DeleteProjectMember) error {
+	return s.driver.DeleteProjectMember(ctx, delete)
+}
+
+// ListUserProjectIDs returns the IDs of the projects the user is a member of.
+func (s *Store) ListUserProjectIDs(ctx context.Context, userID int32) ([]int32, error) {
+	members, err := s.ListProjectMembers(ctx, &FindProjectMember{UserID: &userID})
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := []int32{}
+	for _, member := range members {
+		projectIDs = append(projectIDs, member.ProjectID)
+	}
+	return projectIDs, nil
+}
+
+// ValidateProjectPayload checks that the ticket types and the workflow of a project are consistent.
+func ValidateProjectPayload(payload *storepb.ProjectPayload) error {
+	typeNames := []string{}
+	for _, ticketType := range payload.TicketTypes {
+		if ticketType.Name == "" {
+			return errors.New("ticket type name is required")
+		}
+		if slices.Contains(typeNames, ticketType.Name) {
+			return errors.Errorf("duplicate ticket type %q", ticketType.Name)
+		}
+		typeNames = append(typeNames, ticketType.Name)
+	}
+	if workflow := payload.Workflow; workflow != nil {
+		if len(workflow.Statuses) == 0 && len(workflow.Transitions) > 0 {
+			return errors.New("workflow transitions require statuses")
+		}
+		for _, status := range workflow.Statuses {
+			if status == "" {
+				return errors.New("workflow status is required")
+			}
+		}
+		for _, transition := range workflow.Transitions {
+			if !slices.Contains(workflow.Statuses, transition.From) || !slices.Contains(workflow.Statuses, transition.To) {
+				return errors.Errorf("workflow transition %s -> %s uses an unknown status", transition.From, transition.To)
+			}
+		}
+	}
+	return nil
+}
+
+// DefaultTicketType returns the type of new tickets in the project, or an empty string if any type is allowed.
+func (p *Project) DefaultTicketType() string {
+	if len(p.Payload.GetTicketTypes()) == 0 {
+		return ""
+	}
+	return p.Payload.TicketTypes[0].Name
+}
+
+// IsTicketTypeAllowed reports whether tickets of the project may have the type.
+func (p *Project) IsTicketTypeAllowed(ticketType string) bool {
+	if len(p.Payload.GetTicketTypes()) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(p.Payload.TicketTypes, func(t *storepb.ProjectPayload_TicketType) bool {
+		return t.Name == ticketType
+	})
+}
+
+// DefaultAssigneeID returns the user new tickets of the type are assigned to, if any.
+func (p *Project) DefaultAssigneeID(ticketType string) *int32 {
+	for _, t := range p.Payload.GetTicketTypes() {
+		if t.Name == ticketType && t.DefaultAssigneeId != 0 {
+			return &t.DefaultAssigneeId
+		}
+	}
+	if id := p.Payload.GetDefaultAssigneeId(); id != 0 {
+		return &id
+	}
+	return nil
+}
+
+// InitialTicketStatus returns the status of new tickets in the project, or an empty string to use the workspace default.
+func (p *Project) InitialTicketStatus() string {
+	statuses := p.Payload.GetWorkflow().GetStatuses()
+	if len(statuses) == 0 {
+		return ""
+	}
+	return statuses[0]
+}
+
+// IsTicketStatusAllowed reports whether tickets of the project may have the status.
+func (p *Project) IsTicketStatusAllowed(status string) bool {
+	statuses := p.Payload.GetWorkflow().GetStatuses()
+	return len(statuses) == 0 || slices.Contains(statuses, status)
+}
+
+// IsTicketTransitionAllowed reports whether the workflow allows changing the status of a ticket from one status to another.
+func (p *Project) IsTicketTransitionAllowed(from, to string) bool {
+	if from == to {
+		return true
+	}
+	if !p.IsTicketStatusAllowed(to) {
+		return false
+	}
+	transitions := p.Payload.GetWorkflow().GetTransitions()
+	if len(transitions) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(transitions, func(t *storepb.ProjectPayload_Transition) bool {
+		return t.From == from && t.To == to
+	})
+}
+
+// FormatTicketKey returns the key of a ticket in a project, e.g. "API-42".
+func FormatTicketKey(projectKey string, number int32) string {
+	return fmt.Sprintf("%s-%d", projectKey, number)
+}
+
+// ParseTicketKey splits a ticket key such as "API-42" into the project key and the ticket number.
+func ParseTicketKey(key string) (string, int32, bool) {
+	projectKey, numberStr, ok := strings.Cut(key, "-")
+	if !ok || !ProjectKeyMatcher.MatchString(projectKey) {
+		return "", 0, false
+	}
+	number, err := strconv.ParseInt(numberStr, 10, 32)
+	if err != nil || number <= 0 {
+		return "", 0, false
+	}
+	return projectKey, int32(number), true
+}
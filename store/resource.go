@@ -3,13 +3,10 @@ package store
 import (
 	"context"
 	"log/slog"
-	"os"
-	"path/filepath"
 
 	"github.com/pkg/errors"
 
 	"github.com/usememos/memos/internal/base"
-	"github.com/usememos/memos/plugin/storage/s3"
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
@@ -54,9 +51,14 @@ type FindResource struct {
 type UpdateResource struct {
 	ID        int32
 	UID       *string
+	CreatedTs *int64
 	UpdatedTs *int64
 	Filename  *string
 	MemoID    *int32
+	// StorageType moves the resource to another storage.
+	StorageType *storepb.ResourceStorageType
+	// Blob replaces the blob when StorageType is set, so it is cleared when the content leaves the database.
+	Blob      []byte
 	Reference *string
 	Payload   *storepb.ResourcePayload
 }
@@ -116,50 +118,24 @@ func (s *Store) DeleteResource(ctx context.Context, delete *DeleteResource) erro
 	if resource == nil {
 		return errors.New("resource not found")
 	}
+	if err := s.deleteResourceVariants(resource); err != nil {
+		slog.Warn("Failed to delete resource variants", slog.String("uid", resource.UID), slog.Any("err", err))
+	}
 
-	if resource.StorageType == storepb.ResourceStorageType_LOCAL {
-		if err := func() error {
-			p := filepath.FromSlash(resource.Reference)
-			if !filepath.IsAbs(p) {
-				p = filepath.Join(s.profile.Data, p)
-			}
-			err := os.Remove(p)
-			if err != nil {
-				return errors.Wrap(err, "failed to delete local file")
-			}
-			return nil
-		}(); err != nil {
-			return errors.Wrap(err, "failed to delete local file")
+	// The content of content addressed resources is shared, so only release it.
+	if hash := resource.Payload.GetContentHash(); hash != "" {
+		if err := s.driver.DeleteResource(ctx, delete); err != nil {
+			return err
 		}
-	} else if resource.StorageType == storepb.ResourceStorageType_S3 {
-		if err := func() error {
-			s3ObjectPayload := resource.Payload.GetS3Object()
-			if s3ObjectPayload == nil {
-				return errors.Errorf("No s3 object found")
-			}
-			workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
-			if err != nil {
-				return errors.Wrap(err, "failed to get workspace storage setting")
-			}
-			s3Config := s3ObjectPayload.S3Config
-			if s3Config == nil {
-				if workspaceStorageSetting.S3Config == nil {
-					return errors.Errorf("S3 config is not found")
-				}
-				s3Config = workspaceStorageSetting.S3Config
-			}
-
-			s3Client, err := s3.NewClient(ctx, s3Config)
-			if err != nil {
-				return errors.Wrap(err, "Failed to create s3 client")
-			}
-			if err := s3Client.DeleteObject(ctx, s3ObjectPayload.Key); err != nil {
-				return errors.Wrap(err, "Failed to delete s3 object")
-			}
-			return nil
-		}(); err != nil {
-			slog.Warn("Failed to delete s3 object", slog.Any("err", err))
+		return s.releaseResourceBlob(ctx, hash)
+	}
+
+	if err := s.deleteResourceContent(ctx, resource); err != nil {
+		if resource.StorageType == storepb.ResourceStorageType_LOCAL {
+			return errors.Wrap(err, "failed to delete local file")
 		}
+		// Remote storages may be unreachable, which must not keep the resource around.
+		slog.Warn("Failed to delete resource content", slog.String("uid", resource.UID), slog.Any("err", err))
 	}
 
 	return s.driver.DeleteResource(ctx, delete)
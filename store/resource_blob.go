@@ -0,0 +1,274 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"math"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// resourceBlobPathPrefix is the prefix of the keys of resource blobs in their storage.
+const resourceBlobPathPrefix = "blobs/sha256"
+
+// ResourceBlob is the content of content addressed resources, shared by the resources with the same content.
+type ResourceBlob struct {
+	// Hash is the hex encoded SHA-256 hash of the content.
+	Hash string
+
+	// Standard fields
+	CreatedTs int64
+	UpdatedTs int64
+
+	// Domain specific fields
+	Size        int64
+	StorageType storepb.ResourceStorageType
+	// Reference is the key of the content in its storage, e.g. the local file path or the S3 object key.
+	Reference string
+	// Blob is the content when it is stored in the database.
+	Blob []byte
+	// RefCount is the number of resources sharing the content.
+	RefCount int32
+	Payload  *storepb.ResourcePayload
+}
+
+type FindResourceBlob struct {
+	GetBlob bool
+	Hash    *string
+	// Orphaned finds the blobs no resource refers to.
+	Orphaned        bool
+	UpdatedTsBefore *int64
+}
+
+type UpdateResourceBlob struct {
+	Hash      string
+	UpdatedTs *int64
+	// StorageType moves the blob to another storage.
+	StorageType *storepb.ResourceStorageType
+	// Blob replaces the content when StorageType is set, so it is cleared when the content leaves the database.
+	Blob      []byte
+	Reference *string
+	Payload   *storepb.ResourcePayload
+	// RefCount sets the reference count.
+	RefCount *int32
+	// RefCountDelta is added to the reference count.
+	RefCountDelta int32
+}
+
+type DeleteResourceBlob struct {
+	Hash string
+}
+
+// HashResourceContent returns the hex encoded SHA-256 hash of the content.
+func HashResourceContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// ResourceBlobPath returns the key of the blob with the hash in its storage.
+func ResourceBlobPath(hash string) string {
+	return path.Join(resourceBlobPathPrefix, hash[:2], hash)
+}
+
+func (s *Store) ListResourceBlobs(ctx context.Context, find *FindResourceBlob) ([]*ResourceBlob, error) {
+	return s.driver.ListResourceBlobs(ctx, find)
+}
+
+func (s *Store) GetResourceBlob(ctx context.Context, find *FindResourceBlob) (*ResourceBlob, error) {
+	list, err := s.ListResourceBlobs(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// PutResourceBlob stores the content of the resource by its hash. Identical contents are stored once
+// and shared across resources and users. It references the stored content from the resource.
+func (s *Store) PutResourceBlob(ctx context.Context, resource *Resource, content io.ReadSeeker, storageSetting *storepb.WorkspaceStorageSetting) error {
+	// Hash the content first, and rewind it in case it has to be stored.
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, content)
+	if err != nil {
+		return errors.Wrap(err, "failed to read content")
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "failed to rewind content")
+	}
+	hash := hex.EncodeToString(hasher.Sum(nil))
+	blob, err := s.acquireResourceBlob(ctx, hash)
+	if err != nil {
+		return err
+	}
+	if blob == nil {
+		// The content is written outside of the lock, so uploads do not wait for each other.
+		create := &ResourceBlob{
+			Hash: hash,
+			Size: size,
+		}
+		if err := s.writeResourceBlob(ctx, create, resource.Type, content, storageSetting); err != nil {
+			return err
+		}
+		s.resourceBlobMutex.Lock()
+		blob, err = s.driver.UpsertResourceBlob(ctx, create)
+		s.resourceBlobMutex.Unlock()
+		if err != nil {
+			return errors.Wrap(err, "failed to create resource blob")
+		}
+	}
+
+	resource.Payload = &storepb.ResourcePayload{ContentHash: hash}
+	return setResourceContent(ctx, resource, &storedContent{
+		StorageType: blob.StorageType,
+		Reference:   blob.Reference,
+		S3Object:    blob.Payload.GetS3Object(),
+	})
+}
+
+// acquireResourceBlob adds a reference to the blob with the hash if it exists.
+func (s *Store) acquireResourceBlob(ctx context.Context, hash string) (*ResourceBlob, error) {
+	s.resourceBlobMutex.Lock()
+	defer s.resourceBlobMutex.Unlock()
+	blob, err := s.GetResourceBlob(ctx, &FindResourceBlob{Hash: &hash})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get resource blob")
+	}
+	if blob == nil {
+		return nil, nil
+	}
+	// Upserting an existing blob only increments its reference count.
+	blob, err = s.driver.UpsertResourceBlob(ctx, blob)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to acquire resource blob")
+	}
+	return blob, nil
+}
+
+// writeResourceBlob writes the content of the blob to the storage of the workspace.
+func (s *Store) writeResourceBlob(ctx context.Context, blob *ResourceBlob, contentType string, content io.Reader, storageSetting *storepb.WorkspaceStorageSetting) error {
+	stored, err := s.putContent(ctx, ResourceBlobPath(blob.Hash), contentType, content, blob.Size, storageSetting)
+	if err != nil {
+		return err
+	}
+	blob.StorageType = stored.StorageType
+	blob.Reference = stored.Reference
+	blob.Blob = stored.Blob
+	blob.Payload = stored.payload()
+	return nil
+}
+
+// ReadResourceBlob returns the content of the blob with the hash, and verifies it against the hash.
+func (s *Store) ReadResourceBlob(ctx context.Context, hash string) ([]byte, error) {
+	blob, err := s.GetResourceBlob(ctx, &FindResourceBlob{Hash: &hash, GetBlob: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get resource blob")
+	}
+	if blob == nil {
+		return nil, errors.Errorf("resource blob %s not found", hash)
+	}
+
+	contentStorage, err := s.contentStorage(ctx, blob.StorageType, blob.Payload)
+	if err != nil {
+		return nil, err
+	}
+	content, err := contentStorage.Get(ctx, contentObject(blob.StorageType, blob.Reference, blob.Blob, blob.Payload))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read resource blob %s", hash)
+	}
+	if HashResourceContent(content) != hash {
+		return nil, errors.Errorf("resource blob %s is corrupted", hash)
+	}
+	return content, nil
+}
+
+// releaseResourceBlob removes a reference to the blob with the hash. Blobs without references
+// are deleted by CollectResourceBlobGarbage.
+func (s *Store) releaseResourceBlob(ctx context.Context, hash string) error {
+	updatedTs := time.Now().Unix()
+	return s.driver.UpdateResourceBlob(ctx, &UpdateResourceBlob{
+		Hash:          hash,
+		UpdatedTs:     &updatedTs,
+		RefCountDelta: -1,
+	})
+}
+
+// CollectResourceBlobGarbage recounts the references of the blobs and deletes the blobs without
+// references that have not been used within the grace period. It returns the number of deleted blobs.
+func (s *Store) CollectResourceBlobGarbage(ctx context.Context, gracePeriod time.Duration) (int, error) {
+	// Hold the lock during the collection, so no blob is acquired between counting and deleting.
+	s.resourceBlobMutex.Lock()
+	defer s.resourceBlobMutex.Unlock()
+
+	before := time.Now().Add(-gracePeriod).Unix()
+	blobs, err := s.ListResourceBlobs(ctx, &FindResourceBlob{UpdatedTsBefore: &before})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list resource blobs")
+	}
+	if len(blobs) == 0 {
+		return 0, nil
+	}
+	refCounts, err := s.countResourceBlobReferences(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, blob := range blobs {
+		// Only blobs untouched within the grace period are recounted, since a resource created
+		// right after its blob was acquired may not be counted yet.
+		if refCount := refCounts[blob.Hash]; refCount != blob.RefCount {
+			if err := s.driver.UpdateResourceBlob(ctx, &UpdateResourceBlob{
+				Hash:     blob.Hash,
+				RefCount: &refCount,
+			}); err != nil {
+				return deleted, errors.Wrap(err, "failed to update resource blob")
+			}
+			blob.RefCount = refCount
+		}
+		if blob.RefCount > 0 {
+			continue
+		}
+		if err := s.driver.DeleteResourceBlob(ctx, &DeleteResourceBlob{Hash: blob.Hash}); err != nil {
+			return deleted, errors.Wrap(err, "failed to delete resource blob")
+		}
+		if err := s.deleteResourceBlobContent(ctx, blob); err != nil {
+			slog.Warn("failed to delete resource blob content", slog.String("hash", blob.Hash), slog.Any("err", err))
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// countResourceBlobReferences returns the number of resources referring to each blob.
+func (s *Store) countResourceBlobReferences(ctx context.Context) (map[string]int32, error) {
+	// List the resources at once, since paging would skip resources deleted in the meantime.
+	limit := math.MaxInt32
+	resources, err := s.ListResources(ctx, &FindResource{Limit: &limit})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list resources")
+	}
+	refCounts := map[string]int32{}
+	for _, resource := range resources {
+		if hash := resource.Payload.GetContentHash(); hash != "" {
+			refCounts[hash]++
+		}
+	}
+	return refCounts, nil
+}
+
+func (s *Store) deleteResourceBlobContent(ctx context.Context, blob *ResourceBlob) error {
+	contentStorage, err := s.contentStorage(ctx, blob.StorageType, blob.Payload)
+	if err != nil {
+		return err
+	}
+	return contentStorage.Delete(ctx, contentObject(blob.StorageType, blob.Reference, blob.Blob, blob.Payload))
+}
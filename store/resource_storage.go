@@ -0,0 +1,219 @@
+package store
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/plugin/storage"
+	"github.com/usememos/memos/plugin/storage/database"
+	"github.com/usememos/memos/plugin/storage/local"
+	"github.com/usememos/memos/plugin/storage/s3"
+	"github.com/usememos/memos/plugin/storage/webdav"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// ResourceStorageType returns the storage type of the resources stored with the workspace storage type.
+func ResourceStorageType(storageType storepb.WorkspaceStorageSetting_StorageType) storepb.ResourceStorageType {
+	switch storageType {
+	case storepb.WorkspaceStorageSetting_LOCAL:
+		return storepb.ResourceStorageType_LOCAL
+	case storepb.WorkspaceStorageSetting_S3:
+		return storepb.ResourceStorageType_S3
+	case storepb.WorkspaceStorageSetting_WEBDAV:
+		return storepb.ResourceStorageType_WEBDAV
+	default:
+		return storepb.ResourceStorageType_RESOURCE_STORAGE_TYPE_UNSPECIFIED
+	}
+}
+
+// newStorage returns the storage of the storage type, configured by the S3 or WebDAV config.
+func (s *Store) newStorage(ctx context.Context, storageType storepb.ResourceStorageType, s3Config *storepb.StorageS3Config, webDAVConfig *storepb.StorageWebDAVConfig) (storage.Storage, error) {
+	switch storageType {
+	case storepb.ResourceStorageType_RESOURCE_STORAGE_TYPE_UNSPECIFIED:
+		return database.New(), nil
+	case storepb.ResourceStorageType_LOCAL:
+		return local.New(s.profile.Data), nil
+	case storepb.ResourceStorageType_S3:
+		if s3Config == nil {
+			return nil, errors.New("S3 config is not found")
+		}
+		s3Client, err := s3.NewClient(ctx, s3Config)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create s3 client")
+		}
+		return s3Client, nil
+	case storepb.ResourceStorageType_WEBDAV:
+		if webDAVConfig == nil {
+			return nil, errors.New("WebDAV config is not found")
+		}
+		webDAVClient, err := webdav.NewClient(webDAVConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create webdav client")
+		}
+		return webDAVClient, nil
+	default:
+		return nil, errors.Errorf("unsupported storage type %s", storageType)
+	}
+}
+
+// contentStorage returns the storage holding a stored content. Contents in S3 are read with the S3 config
+// they were stored with, and the others with the configs of the workspace storage setting.
+func (s *Store) contentStorage(ctx context.Context, storageType storepb.ResourceStorageType, payload *storepb.ResourcePayload) (storage.Storage, error) {
+	workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	s3Config := workspaceStorageSetting.S3Config
+	if v := payload.GetS3Object().GetS3Config(); v != nil {
+		s3Config = v
+	}
+	return s.newStorage(ctx, storageType, s3Config, workspaceStorageSetting.WebdavConfig)
+}
+
+// contentObject returns the location of a stored content from the fields of its resource or resource blob.
+func contentObject(storageType storepb.ResourceStorageType, reference string, blob []byte, payload *storepb.ResourcePayload) *storage.Object {
+	switch storageType {
+	case storepb.ResourceStorageType_RESOURCE_STORAGE_TYPE_UNSPECIFIED:
+		return &storage.Object{Blob: blob}
+	case storepb.ResourceStorageType_S3:
+		return &storage.Object{Key: payload.GetS3Object().GetKey()}
+	default:
+		return &storage.Object{Key: reference}
+	}
+}
+
+// storedContent is where a content was stored, as kept in the fields of resources and resource blobs.
+type storedContent struct {
+	StorageType storepb.ResourceStorageType
+	Reference   string
+	Blob        []byte
+	// S3Object is the object of the contents stored in S3.
+	S3Object *storepb.ResourcePayload_S3Object
+}
+
+func (c *storedContent) payload() *storepb.ResourcePayload {
+	payload := &storepb.ResourcePayload{}
+	if c.S3Object != nil {
+		payload.Payload = &storepb.ResourcePayload_S3Object_{S3Object: c.S3Object}
+	}
+	return payload
+}
+
+// putContent streams the content of the size under the key to the storage of the workspace storage setting.
+func (s *Store) putContent(ctx context.Context, key, contentType string, content io.Reader, size int64, storageSetting *storepb.WorkspaceStorageSetting) (*storedContent, error) {
+	storageType := ResourceStorageType(storageSetting.StorageType)
+	contentStorage, err := s.newStorage(ctx, storageType, storageSetting.S3Config, storageSetting.WebdavConfig)
+	if err != nil {
+		return nil, err
+	}
+	object, err := contentStorage.Put(ctx, key, contentType, content, size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to store content in %s storage", storageSetting.StorageType)
+	}
+	stored := &storedContent{
+		StorageType: storageType,
+		Reference:   object.Key,
+		Blob:        object.Blob,
+	}
+	if storageType == storepb.ResourceStorageType_S3 {
+		stored.S3Object = &storepb.ResourcePayload_S3Object{
+			S3Config: storageSetting.S3Config,
+			Key:      object.Key,
+		}
+	}
+	return stored, nil
+}
+
+// setResourceContent refers the resource to the stored content. Resources in S3 refer to a presigned URL.
+func setResourceContent(ctx context.Context, resource *Resource, stored *storedContent) error {
+	resource.StorageType = stored.StorageType
+	resource.Reference = stored.Reference
+	resource.Blob = stored.Blob
+	if resource.Payload == nil {
+		resource.Payload = &storepb.ResourcePayload{}
+	}
+	resource.Payload.Payload = nil
+	if stored.S3Object == nil {
+		return nil
+	}
+
+	s3Object := proto.Clone(stored.S3Object).(*storepb.ResourcePayload_S3Object)
+	s3Client, err := s3.NewClient(ctx, s3Object.S3Config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create s3 client")
+	}
+	presignURL, err := s3Client.PresignGetObject(ctx, s3Object.Key)
+	if err != nil {
+		return errors.Wrap(err, "failed to presign via s3 client")
+	}
+	s3Object.LastPresignedTime = timestamppb.Now()
+	resource.Reference = presignURL
+	resource.Payload.Payload = &storepb.ResourcePayload_S3Object_{S3Object: s3Object}
+	return nil
+}
+
+// PutResourceContent streams the content of the resource under the key to the storage of the workspace
+// storage setting, and refers the resource to the stored content. The content is Size bytes long.
+func (s *Store) PutResourceContent(ctx context.Context, resource *Resource, key string, content io.Reader, storageSetting *storepb.WorkspaceStorageSetting) error {
+	stored, err := s.putContent(ctx, key, resource.Type, content, resource.Size, storageSetting)
+	if err != nil {
+		return err
+	}
+	return setResourceContent(ctx, resource, stored)
+}
+
+// ReadResourceContent returns the content of the resource from its storage. The blob of resources
+// stored in the database is only set when they are found with GetBlob.
+func (s *Store) ReadResourceContent(ctx context.Context, resource *Resource) ([]byte, error) {
+	if hash := resource.Payload.GetContentHash(); hash != "" {
+		return s.ReadResourceBlob(ctx, hash)
+	}
+	if resource.StorageType == storepb.ResourceStorageType_EXTERNAL {
+		return nil, errors.New("external resources have no stored content")
+	}
+	contentStorage, err := s.contentStorage(ctx, resource.StorageType, resource.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return contentStorage.Get(ctx, contentObject(resource.StorageType, resource.Reference, resource.Blob, resource.Payload))
+}
+
+// OpenResourceContent returns a seekable stream of the content of the resource from its storage.
+// Unlike ReadResourceContent, it does not verify the content of content addressed resources.
+func (s *Store) OpenResourceContent(ctx context.Context, resource *Resource) (io.ReadSeekCloser, error) {
+	storageType, reference, blob, payload := resource.StorageType, resource.Reference, resource.Blob, resource.Payload
+	if hash := resource.Payload.GetContentHash(); hash != "" {
+		resourceBlob, err := s.GetResourceBlob(ctx, &FindResourceBlob{Hash: &hash, GetBlob: true})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get resource blob")
+		}
+		if resourceBlob == nil {
+			return nil, errors.Errorf("resource blob %s not found", hash)
+		}
+		storageType, reference, blob, payload = resourceBlob.StorageType, resourceBlob.Reference, resourceBlob.Blob, resourceBlob.Payload
+	}
+	if storageType == storepb.ResourceStorageType_EXTERNAL {
+		return nil, errors.New("external resources have no stored content")
+	}
+	contentStorage, err := s.contentStorage(ctx, storageType, payload)
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewReader(ctx, contentStorage, contentObject(storageType, reference, blob, payload), resource.Size), nil
+}
+
+// deleteResourceContent deletes the content of a resource that is not content addressed.
+func (s *Store) deleteResourceContent(ctx context.Context, resource *Resource) error {
+	if resource.StorageType == storepb.ResourceStorageType_EXTERNAL {
+		return nil
+	}
+	contentStorage, err := s.contentStorage(ctx, resource.StorageType, resource.Payload)
+	if err != nil {
+		return err
+	}
+	return contentStorage.Delete(ctx, contentObject(resource.StorageType, resource.Reference, resource.Blob, resource.Payload))
+}
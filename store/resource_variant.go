@@ -0,0 +1,29 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// ResourceVariantFolder is the folder name where the variants of the resources are stored.
+const ResourceVariantFolder = ".resource_variants"
+
+// ResourceVariantPath returns the path of the file of the named variant of the resource.
+func (s *Store) ResourceVariantPath(resource *Resource, name string) string {
+	return filepath.Join(s.resourceVariantFolder(resource), name)
+}
+
+func (s *Store) resourceVariantFolder(resource *Resource) string {
+	return filepath.Join(s.profile.Data, ResourceVariantFolder, strconv.Itoa(int(resource.ID)))
+}
+
+// deleteResourceVariants deletes the variant files of the resource.
+func (s *Store) deleteResourceVariants(resource *Resource) error {
+	if err := os.RemoveAll(s.resourceVariantFolder(resource)); err != nil {
+		return errors.Wrap(err, "failed to delete resource variants")
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// sessionUsageInterval is how often the last seen time of a session is written.
+const sessionUsageInterval = time.Minute
+
+// IsSessionExpired reports whether the session has expired.
+func IsSessionExpired(session *storepb.SessionsUserSetting_Session, now time.Time) bool {
+	return session.ExpireTime != nil && !now.Before(session.ExpireTime.AsTime())
+}
+
+// GetUserSessions returns the unexpired sessions of the user.
+func (s *Store) GetUserSessions(ctx context.Context, userID int32) ([]*storepb.SessionsUserSetting_Session, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_SESSIONS,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return getUnexpiredSessions(userSetting, time.Now()), nil
+}
+
+// GetUserSession returns the unexpired session of the user with the id, or nil if there is none.
+func (s *Store) GetUserSession(ctx context.Context, userID int32, sessionID string) (*storepb.SessionsUserSetting_Session, error) {
+	sessions, err := s.GetUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	index := findSession(sessions, sessionID)
+	if index < 0 {
+		return nil, nil
+	}
+	return sessions[index], nil
+}
+
+// UpdateUserSessions calls update with copies of the unexpired sessions of the user and saves the sessions
+// it returns, unless update fails. The updates of the sessions of a user are serialized, so update sees
+// the changes of the previous updates.
+func (s *Store) UpdateUserSessions(ctx context.Context, userID int32, update func([]*storepb.SessionsUserSetting_Session) ([]*storepb.SessionsUserSetting_Session, error)) error {
+	return s.updateUserSetting(ctx, userID, storepb.UserSettingKey_SESSIONS, func(userSetting *storepb.UserSetting) error {
+		// Expired sessions are dropped at the same time.
+		sessions, err := update(getUnexpiredSessions(userSetting, time.Now()))
+		if err != nil {
+			return err
+		}
+		userSetting.Value = &storepb.UserSetting_Sessions{
+			Sessions: &storepb.SessionsUserSetting{
+				Sessions: sessions,
+			},
+		}
+		return nil
+	})
+}
+
+// UpsertUserSession saves the session of the user, replacing the one with the same id.
+func (s *Store) UpsertUserSession(ctx context.Context, userID int32, session *storepb.SessionsUserSetting_Session) error {
+	return s.UpdateUserSessions(ctx, userID, func(sessions []*storepb.SessionsUserSetting_Session) ([]*storepb.SessionsUserSetting_Session, error) {
+		if index := findSession(sessions, session.SessionId); index >= 0 {
+			sessions = slices.Delete(sessions, index, index+1)
+		}
+		return append(sessions, session), nil
+	})
+}
+
+// TouchUserSession records the last activity of the session. Writes are throttled.
+func (s *Store) TouchUserSession(ctx context.Context, userID int32, sessionID, ip, userAgent string) error {
+	// Check the throttle without the lock first, as sessions are touched by every request.
+	session, err := s.GetUserSession(ctx, userID, sessionID)
+	if err != nil || session == nil || !isSessionTouchDue(session, ip, userAgent, time.Now()) {
+		return err
+	}
+	return s.UpdateUserSessions(ctx, userID, func(sessions []*storepb.SessionsUserSetting_Session) ([]*storepb.SessionsUserSetting_Session, error) {
+		// The session may have been revoked or touched since it was read.
+		index := findSession(sessions, sessionID)
+		now := time.Now()
+		if index < 0 || !isSessionTouchDue(sessions[index], ip, userAgent, now) {
+			return sessions, nil
+		}
+		session := sessions[index]
+		session.LastSeenTime = timestamppb.New(now)
+		session.Ip = ip
+		if userAgent != "" {
+			session.UserAgent = userAgent
+		}
+		return sessions, nil
+	})
+}
+
+// RemoveUserSession removes the session of the user.
+func (s *Store) RemoveUserSession(ctx context.Context, userID int32, sessionID string) error {
+	return s.UpdateUserSessions(ctx, userID, func(sessions []*storepb.SessionsUserSetting_Session) ([]*storepb.SessionsUserSetting_Session, error) {
+		return slices.DeleteFunc(sessions, func(session *storepb.SessionsUserSetting_Session) bool {
+			return session.SessionId == sessionID
+		}), nil
+	})
+}
+
+// RemoveUserSessions removes all sessions of the user.
+func (s *Store) RemoveUserSessions(ctx context.Context, userID int32) error {
+	return s.UpdateUserSessions(ctx, userID, func([]*storepb.SessionsUserSetting_Session) ([]*storepb.SessionsUserSetting_Session, error) {
+		return []*storepb.SessionsUserSetting_Session{}, nil
+	})
+}
+
+func getUnexpiredSessions(userSetting *storepb.UserSetting, now time.Time) []*storepb.SessionsUserSetting_Session {
+	sessions := []*storepb.SessionsUserSetting_Session{}
+	for _, session := range userSetting.GetSessions().GetSessions() {
+		if !IsSessionExpired(session, now) {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+func findSession(sessions []*storepb.SessionsUserSetting_Session, sessionID string) int {
+	return slices.IndexFunc(sessions, func(session *storepb.SessionsUserSetting_Session) bool {
+		return session.SessionId == sessionID
+	})
+}
+
+// isSessionTouchDue reports whether the last activity of the session needs to be written.
+func isSessionTouchDue(session *storepb.SessionsUserSetting_Session, ip, userAgent string, now time.Time) bool {
+	return session.Ip != ip || session.UserAgent != userAgent || session.LastSeenTime == nil ||
+		now.Sub(session.LastSeenTime.AsTime()) >= sessionUsageInterval
+}
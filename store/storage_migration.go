@@ -0,0 +1,247 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// StartStorageMigration starts migrating the resources to the storage of the workspace storage setting.
+// A running migration is returned as is. Otherwise the migration starts over from the first resource,
+// skipping the resources already in the target storage, so the resources that failed before are retried.
+// RunStorageMigration processes the resources.
+func (s *Store) StartStorageMigration(ctx context.Context) (*storepb.WorkspaceStorageMigrationSetting, error) {
+	migration, err := s.GetWorkspaceStorageMigrationSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if migration.State == storepb.WorkspaceStorageMigrationSetting_RUNNING {
+		return migration, nil
+	}
+
+	workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	resources, err := s.listResourcesToMigrate(ctx, ResourceStorageType(workspaceStorageSetting.StorageType))
+	if err != nil {
+		return nil, err
+	}
+	migration = &storepb.WorkspaceStorageMigrationSetting{
+		State:             storepb.WorkspaceStorageMigrationSetting_RUNNING,
+		TargetStorageType: workspaceStorageSetting.StorageType,
+		Total:             int32(len(resources)),
+		StartTime:         timestamppb.Now(),
+	}
+	if err := s.upsertStorageMigration(ctx, migration); err != nil {
+		return nil, err
+	}
+	return migration, nil
+}
+
+// RunStorageMigration processes the resources of the running storage migration, resuming after the last
+// processed resource. It returns immediately if no migration is running, or if it is already being processed.
+// The progress is saved after each resource, so a migration interrupted by a restart resumes where it stopped.
+func (s *Store) RunStorageMigration(ctx context.Context) error {
+	if !s.storageMigrationMutex.TryLock() {
+		return nil
+	}
+	defer s.storageMigrationMutex.Unlock()
+
+	migration, err := s.GetWorkspaceStorageMigrationSetting(ctx)
+	if err != nil {
+		return err
+	}
+	if migration.State != storepb.WorkspaceStorageMigrationSetting_RUNNING {
+		return nil
+	}
+	workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	// Migrate with the configs of the workspace, to the storage the migration was started for.
+	target := proto.Clone(workspaceStorageSetting).(*storepb.WorkspaceStorageSetting)
+	target.StorageType = migration.TargetStorageType
+	resources, err := s.listResourcesToMigrate(ctx, ResourceStorageType(target.StorageType))
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources {
+		if resource.ID <= migration.LastResourceId {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.migrateResource(ctx, resource.ID, target); err != nil {
+			slog.Warn("Failed to migrate resource", slog.String("uid", resource.UID), slog.Any("err", err))
+			migration.Failed++
+			migration.LastError = fmt.Sprintf("resource %s: %v", resource.UID, err)
+		} else {
+			migration.Migrated++
+		}
+		migration.Processed++
+		migration.LastResourceId = resource.ID
+		if err := s.upsertStorageMigration(ctx, migration); err != nil {
+			return err
+		}
+	}
+
+	migration.State = storepb.WorkspaceStorageMigrationSetting_COMPLETED
+	migration.FinishTime = timestamppb.Now()
+	return s.upsertStorageMigration(ctx, migration)
+}
+
+func (s *Store) upsertStorageMigration(ctx context.Context, migration *storepb.WorkspaceStorageMigrationSetting) error {
+	if _, err := s.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_STORAGE_MIGRATION,
+		Value: &storepb.WorkspaceSetting_StorageMigrationSetting{
+			StorageMigrationSetting: proto.Clone(migration).(*storepb.WorkspaceStorageMigrationSetting),
+		},
+	}); err != nil {
+		return errors.Wrap(err, "failed to save storage migration")
+	}
+	return nil
+}
+
+// listResourcesToMigrate returns the resources not in the target storage, in ID order.
+func (s *Store) listResourcesToMigrate(ctx context.Context, target storepb.ResourceStorageType) ([]*Resource, error) {
+	limit := math.MaxInt32
+	resources, err := s.ListResources(ctx, &FindResource{Limit: &limit})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list resources")
+	}
+	resources = slices.DeleteFunc(resources, func(resource *Resource) bool {
+		return resource.StorageType == target || resource.StorageType == storepb.ResourceStorageType_EXTERNAL
+	})
+	slices.SortFunc(resources, func(a, b *Resource) int {
+		return int(a.ID - b.ID)
+	})
+	return resources, nil
+}
+
+// migrateResource moves the content of the resource to the target storage. The old content is deleted
+// once the resource refers to the new one.
+func (s *Store) migrateResource(ctx context.Context, id int32, target *storepb.WorkspaceStorageSetting) error {
+	resource, err := s.GetResource(ctx, &FindResource{ID: &id, GetBlob: true})
+	if err != nil {
+		return errors.Wrap(err, "failed to get resource")
+	}
+	// The resource was deleted, or moved, in the meantime.
+	if resource == nil || resource.StorageType == ResourceStorageType(target.StorageType) {
+		return nil
+	}
+	if hash := resource.Payload.GetContentHash(); hash != "" {
+		return s.migrateContentAddressedResource(ctx, resource, hash, target)
+	}
+
+	content, err := s.ReadResourceContent(ctx, resource)
+	if err != nil {
+		return err
+	}
+	source := *resource
+	resource.Payload = proto.Clone(resource.Payload).(*storepb.ResourcePayload)
+	resource.Size = int64(len(content))
+	if err := s.PutResourceContent(ctx, resource, storageMigrationKey(&source), bytes.NewReader(content), target); err != nil {
+		return err
+	}
+	if err := s.driver.UpdateResource(ctx, &UpdateResource{
+		ID:          resource.ID,
+		StorageType: &resource.StorageType,
+		Blob:        resource.Blob,
+		Reference:   &resource.Reference,
+		Payload:     resource.Payload,
+	}); err != nil {
+		return errors.Wrap(err, "failed to update resource")
+	}
+	if err := s.deleteResourceContent(ctx, &source); err != nil {
+		slog.Warn("Failed to delete migrated resource content", slog.String("uid", source.UID), slog.Any("err", err))
+	}
+	return nil
+}
+
+// migrateContentAddressedResource moves the shared blob of the resource to the target storage unless
+// another resource moved it already, and refers the resource to it.
+func (s *Store) migrateContentAddressedResource(ctx context.Context, resource *Resource, hash string, target *storepb.WorkspaceStorageSetting) error {
+	blob, err := s.GetResourceBlob(ctx, &FindResourceBlob{Hash: &hash})
+	if err != nil {
+		return errors.Wrap(err, "failed to get resource blob")
+	}
+	if blob == nil {
+		return errors.Errorf("resource blob %s not found", hash)
+	}
+	if blob.StorageType != ResourceStorageType(target.StorageType) {
+		content, err := s.ReadResourceBlob(ctx, hash)
+		if err != nil {
+			return err
+		}
+		stored, err := s.putContent(ctx, ResourceBlobPath(hash), resource.Type, bytes.NewReader(content), int64(len(content)), target)
+		if err != nil {
+			return err
+		}
+		if err := s.driver.UpdateResourceBlob(ctx, &UpdateResourceBlob{
+			Hash:        hash,
+			StorageType: &stored.StorageType,
+			Blob:        stored.Blob,
+			Reference:   &stored.Reference,
+			Payload:     stored.payload(),
+		}); err != nil {
+			return errors.Wrap(err, "failed to update resource blob")
+		}
+		if err := s.deleteResourceBlobContent(ctx, blob); err != nil {
+			slog.Warn("Failed to delete migrated resource blob content", slog.String("hash", hash), slog.Any("err", err))
+		}
+		blob.StorageType = stored.StorageType
+		blob.Reference = stored.Reference
+		blob.Payload = stored.payload()
+	}
+
+	if err := setResourceContent(ctx, resource, &storedContent{
+		StorageType: blob.StorageType,
+		Reference:   blob.Reference,
+		S3Object:    blob.Payload.GetS3Object(),
+	}); err != nil {
+		return err
+	}
+	if err := s.driver.UpdateResource(ctx, &UpdateResource{
+		ID:          resource.ID,
+		StorageType: &resource.StorageType,
+		Reference:   &resource.Reference,
+		Payload:     resource.Payload,
+	}); err != nil {
+		return errors.Wrap(err, "failed to update resource")
+	}
+	return nil
+}
+
+// storageMigrationKey returns the key of the resource in the target storage. It keeps the relative path
+// of the resource in its current storage, so the layout of the storages stays the same.
+func storageMigrationKey(resource *Resource) string {
+	key := ""
+	switch resource.StorageType {
+	case storepb.ResourceStorageType_LOCAL, storepb.ResourceStorageType_WEBDAV:
+		if !filepath.IsAbs(filepath.FromSlash(resource.Reference)) {
+			key = resource.Reference
+		}
+	case storepb.ResourceStorageType_S3:
+		key = resource.Payload.GetS3Object().GetKey()
+	default:
+	}
+	if key = path.Clean(key); key == "." || strings.HasPrefix(key, "..") || path.IsAbs(key) {
+		key = path.Join("assets", fmt.Sprintf("%s_%s", resource.UID, path.Base(filepath.ToSlash(resource.Filename))))
+	}
+	return key
+}
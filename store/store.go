@@ -1,6 +1,7 @@
 package store
 
 import (
+	"sync"
 	"time"
 
 	"github.com/usememos/memos/internal/profile"
@@ -19,6 +20,16 @@ type Store struct {
 	workspaceSettingCache *cache.Cache // cache for workspace settings
 	userCache             *cache.Cache // cache for users
 	userSettingCache      *cache.Cache // cache for user settings
+	analyticsCache        *cache.Cache // cache for the results of analytics
+
+	// resourceBlobMutex serializes acquiring and collecting resource blobs.
+	resourceBlobMutex sync.Mutex
+	// storageMigrationMutex is held while the storage migration runs.
+	storageMigrationMutex sync.Mutex
+	// userSettingMutexes serialize the updates of a user setting, by user setting cache key.
+	userSettingMutexes sync.Map
+	// sharedTicketViewsMutex serializes the updates of the ticket views shared with the workspace.
+	sharedTicketViewsMutex sync.Mutex
 }
 
 // New creates a new instance of Store.
@@ -38,6 +49,7 @@ func New(driver Driver, profile *profile.Profile) *Store {
 		workspaceSettingCache: cache.New(cacheConfig),
 		userCache:             cache.New(cacheConfig),
 		userSettingCache:      cache.New(cacheConfig),
+		analyticsCache:        cache.New(cacheConfig),
 	}
 
 	return store
@@ -52,6 +64,7 @@ func (s *Store) Close() error {
 	s.workspaceSettingCache.Close()
 	s.userCache.Close()
 	s.userSettingCache.Close()
+	s.analyticsCache.Close()
 
 	return s.driver.Close()
 }
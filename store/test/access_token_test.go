@@ -0,0 +1,166 @@
+package teststore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestUserAccessToken(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_ACCESS_TOKENS,
+		Value: &storepb.UserSetting_AccessTokens{
+			AccessTokens: &storepb.AccessTokensUserSetting{
+				AccessTokens: []*storepb.AccessTokensUserSetting_AccessToken{
+					// A token stored in plaintext before hashing was introduced.
+					{AccessToken: "legacy-token", Description: "legacy"},
+					{TokenHash: store.HashAccessToken("scoped-token"), Scopes: []string{string(store.AccessTokenScopeRead)}},
+					{TokenHash: store.HashAccessToken("expired-token"), ExpireTime: timestamppb.New(time.Now().Add(-time.Minute))},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	accessToken, err := ts.GetUserAccessToken(ctx, user.ID, "scoped-token")
+	require.NoError(t, err)
+	require.NotNil(t, accessToken)
+	require.True(t, store.HasAccessTokenScope(accessToken.Scopes, store.AccessTokenScopeRead))
+	require.False(t, store.HasAccessTokenScope(accessToken.Scopes, store.AccessTokenScopeMemosWrite))
+	accessToken, err = ts.GetUserAccessToken(ctx, user.ID, "expired-token")
+	require.NoError(t, err)
+	require.Nil(t, accessToken)
+	accessToken, err = ts.GetUserAccessToken(ctx, user.ID, "unknown-token")
+	require.NoError(t, err)
+	require.Nil(t, accessToken)
+
+	// Using a plaintext token replaces it with its hash and records the usage.
+	require.NoError(t, ts.TouchUserAccessToken(ctx, user.ID, "legacy-token", "10.0.0.1"))
+	accessToken, err = ts.GetUserAccessToken(ctx, user.ID, "legacy-token")
+	require.NoError(t, err)
+	require.NotNil(t, accessToken)
+	require.Empty(t, accessToken.AccessToken)
+	require.Equal(t, store.HashAccessToken("legacy-token"), accessToken.TokenHash)
+	require.Equal(t, "10.0.0.1", accessToken.LastUsedIp)
+	require.NotNil(t, accessToken.LastUsedTime)
+	require.True(t, store.HasAccessTokenScope(accessToken.Scopes, store.AccessTokenScopeWebhooks))
+
+	// Tokens can be removed by id or by the token itself.
+	require.NoError(t, ts.RemoveUserAccessToken(ctx, user.ID, store.AccessTokenID(accessToken)))
+	require.NoError(t, ts.RemoveUserAccessToken(ctx, user.ID, "scoped-token"))
+	accessTokens, err := ts.GetUserAccessTokens(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, accessTokens, 1)
+	ts.Close()
+}
+
+func TestHashUserAccessTokensOnMigrate(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_ACCESS_TOKENS,
+		Value: &storepb.UserSetting_AccessTokens{
+			AccessTokens: &storepb.AccessTokensUserSetting{
+				AccessTokens: []*storepb.AccessTokensUserSetting_AccessToken{
+					{AccessToken: "legacy-token", Description: "legacy"},
+					{TokenHash: store.HashAccessToken("hashed-token")},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Tokens stored in plaintext are hashed on start, whether they are used or not.
+	require.NoError(t, ts.Migrate(ctx))
+	accessTokens, err := ts.GetUserAccessTokens(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, accessTokens, 2)
+	for _, accessToken := range accessTokens {
+		require.Empty(t, accessToken.AccessToken)
+	}
+	require.Equal(t, store.HashAccessToken("legacy-token"), accessTokens[0].TokenHash)
+	require.Equal(t, "legacy", accessTokens[0].Description)
+	accessToken, err := ts.GetUserAccessToken(ctx, user.ID, "legacy-token")
+	require.NoError(t, err)
+	require.NotNil(t, accessToken)
+	ts.Close()
+}
+
+func TestConcurrentUserAccessTokenUpdates(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	const count = 20
+	for i := 0; i < count; i++ {
+		require.NoError(t, ts.AddUserAccessToken(ctx, user.ID, &storepb.AccessTokensUserSetting_AccessToken{
+			TokenHash: store.HashAccessToken(fmt.Sprintf("token-%d", i)),
+		}))
+	}
+
+	// Remove the even tokens and add new ones while all of them are being used from a new IP.
+	var wg sync.WaitGroup
+	errs := make(chan error, 3*count)
+	for i := 0; i < count; i++ {
+		token := fmt.Sprintf("token-%d", i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			errs <- ts.TouchUserAccessToken(ctx, user.ID, token, "10.0.0.2")
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- ts.AddUserAccessToken(ctx, user.ID, &storepb.AccessTokensUserSetting_AccessToken{
+				TokenHash: store.HashAccessToken("new-" + token),
+			})
+		}()
+		if i%2 == 0 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- ts.RemoveUserAccessToken(ctx, user.ID, token)
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	accessTokens, err := ts.GetUserAccessTokens(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, accessTokens, count+count/2)
+	for i := 0; i < count; i++ {
+		accessToken, err := ts.GetUserAccessToken(ctx, user.ID, fmt.Sprintf("token-%d", i))
+		require.NoError(t, err)
+		if i%2 == 0 {
+			require.Nil(t, accessToken, "removed token %d was restored", i)
+		} else {
+			require.NotNil(t, accessToken)
+			require.Equal(t, "10.0.0.2", accessToken.LastUsedIp)
+		}
+		accessToken, err = ts.GetUserAccessToken(ctx, user.ID, fmt.Sprintf("new-token-%d", i))
+		require.NoError(t, err)
+		require.NotNil(t, accessToken, "added token %d was lost", i)
+	}
+	ts.Close()
+}
@@ -0,0 +1,123 @@
+package teststore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+const day = int64(86400)
+
+func TestTicketMetrics(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	start := int64(1700000000)
+	createTicket := func(title string, createdTs int64, assigneeID *int32) *store.Ticket {
+		ticket, err := ts.CreateTicket(ctx, &store.Ticket{
+			Title:       title,
+			Description: "/m/" + title,
+			Status:      store.TicketStatusOpen,
+			Priority:    store.TicketPriorityMedium,
+			CreatorID:   user.ID,
+			AssigneeID:  assigneeID,
+			CreatedTs:   createdTs,
+			UpdatedTs:   createdTs,
+		})
+		require.NoError(t, err)
+		return ticket
+	}
+	setStatus := func(ticket *store.Ticket, status store.TicketStatus, ts64 int64) {
+		_, err := ts.UpdateTicket(ctx, &store.UpdateTicket{ID: ticket.ID, Status: &status, UpdatedTs: &ts64})
+		require.NoError(t, err)
+	}
+
+	// The first ticket is started and closed in the range, the second one is opened before the range and
+	// closed without being started, and the third one is still open.
+	first := createTicket("first", start+100, &user.ID)
+	setStatus(first, store.TicketStatusInProgress, start+1000)
+	setStatus(first, store.TicketStatusClosed, start+day+1000)
+	second := createTicket("second", start-1000, nil)
+	setStatus(second, store.TicketStatusClosed, start+2*day+50)
+	createTicket("third", start+2*day, nil)
+
+	transitions, err := ts.ListTicketStatusTransitions(ctx, &store.FindTicketStatusTransition{TicketID: &first.ID})
+	require.NoError(t, err)
+	require.Len(t, transitions, 3)
+	require.Equal(t, store.TicketStatus(""), transitions[0].FromStatus)
+	require.Equal(t, store.TicketStatusInProgress, transitions[2].FromStatus)
+	require.Equal(t, store.TicketStatusClosed, transitions[2].ToStatus)
+
+	find := &store.FindTicketMetrics{StartTs: start, EndTs: start + 3*day, PeriodSeconds: day}
+	metrics, err := ts.GetTicketMetrics(ctx, find)
+	require.NoError(t, err)
+	require.Equal(t, []*store.TicketPeriodCount{
+		{Period: 0, Opened: 1, Closed: 0},
+		{Period: 1, Opened: 0, Closed: 1},
+		{Period: 2, Opened: 1, Closed: 1},
+	}, metrics.Periods)
+	require.Equal(t, &store.DurationPercentiles{Count: 2, P50: day + 900, P85: 2*day + 1050, P95: 2*day + 1050}, metrics.LeadTime)
+	require.Equal(t, &store.DurationPercentiles{Count: 1, P50: day, P85: day, P95: day}, metrics.CycleTime)
+	require.Len(t, metrics.Throughput, 2)
+	for _, throughput := range metrics.Throughput {
+		require.Equal(t, int32(1), throughput.Closed)
+	}
+	require.Equal(t, []*store.TicketBacklogAgeCount{{Bucket: 1, Count: 1}}, metrics.BacklogAge)
+
+	flow, err := ts.GetTicketCumulativeFlow(ctx, find)
+	require.NoError(t, err)
+	require.Len(t, flow, 3)
+	require.Equal(t, map[store.TicketStatus]int32{store.TicketStatusOpen: 1, store.TicketStatusInProgress: 1}, flow[0].Counts)
+	require.Equal(t, map[store.TicketStatus]int32{store.TicketStatusOpen: 1, store.TicketStatusInProgress: 0, store.TicketStatusClosed: 1}, flow[1].Counts)
+	require.Equal(t, map[store.TicketStatus]int32{store.TicketStatusOpen: 1, store.TicketStatusInProgress: 0, store.TicketStatusClosed: 2}, flow[2].Counts)
+
+	// Tickets without a project are counted regardless of the visible projects.
+	metrics, err = ts.GetTicketMetrics(ctx, &store.FindTicketMetrics{VisibleProjectIDs: []int32{}, StartTs: start, EndTs: start + 3*day, PeriodSeconds: day})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), metrics.LeadTime.Count)
+
+	// The metrics of a project only count its tickets.
+	projectID := int32(1)
+	metrics, err = ts.GetTicketMetrics(ctx, &store.FindTicketMetrics{ProjectID: &projectID, StartTs: start, EndTs: start + 3*day, PeriodSeconds: day})
+	require.NoError(t, err)
+	require.Empty(t, metrics.Periods)
+	require.Equal(t, int32(0), metrics.LeadTime.Count)
+	ts.Close()
+}
+
+func TestMemoActivity(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	// 1700000000 is Tuesday 22:13:20 UTC.
+	start := int64(1700000000)
+	for i, visibility := range []store.Visibility{store.Public, store.Public, store.Private} {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("activity-%d", i),
+			CreatorID:  user.ID,
+			Content:    "activity",
+			Visibility: visibility,
+		})
+		require.NoError(t, err)
+		createdTs := start + int64(i)*60
+		require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, CreatedTs: &createdTs}))
+	}
+
+	memoFind := &store.FindMemo{VisibilityList: []store.Visibility{store.Public}, ExcludeContent: true}
+	list, err := ts.ListMemoActivity(ctx, &store.FindMemoActivity{MemoFind: memoFind, StartTs: start, EndTs: start + day})
+	require.NoError(t, err)
+	require.Equal(t, []*store.MemoActivityCount{{Weekday: 2, Hour: 22, Count: 2}}, list)
+
+	list, err = ts.ListMemoActivity(ctx, &store.FindMemoActivity{MemoFind: memoFind, StartTs: start, EndTs: start + day, UTCOffset: 2 * 3600})
+	require.NoError(t, err)
+	require.Equal(t, []*store.MemoActivityCount{{Weekday: 3, Hour: 0, Count: 2}}, list)
+	ts.Close()
+}
@@ -0,0 +1,299 @@
+package teststore
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/profile"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/archive"
+)
+
+func TestArchiveExportImport(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	archiveProfile := &profile.Profile{
+		Version: "test",
+		Driver:  getDriverFromEnv(),
+		Data:    t.TempDir(),
+	}
+	host, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	// Take an ID so that the records of the target instance are assigned different IDs.
+	_, err = ts.CreateUser(ctx, &store.User{Username: "placeholder", Role: store.RoleUser})
+	require.NoError(t, err)
+	user, err := ts.CreateUser(ctx, &store.User{Username: "user", Role: store.RoleUser, Nickname: "user"})
+	require.NoError(t, err)
+	createdTs := int64(1600000000)
+	_, err = ts.UpdateUser(ctx, &store.UpdateUser{ID: user.ID, CreatedTs: &createdTs})
+	require.NoError(t, err)
+
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "archived-memo",
+		CreatorID:  user.ID,
+		Content:    "hello #world",
+		Visibility: store.Public,
+		Payload:    &storepb.MemoPayload{Tags: []string{"world"}},
+	})
+	require.NoError(t, err)
+	comment, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "archived-comment",
+		CreatorID:  host.ID,
+		Content:    "comment on [[memos/archived-memo]]",
+		Visibility: store.Public,
+		Payload:    &storepb.MemoPayload{ContentRelations: []int32{memo.ID}},
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: comment.ID, RelatedMemoID: memo.ID, Type: store.MemoRelationComment})
+	require.NoError(t, err)
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: comment.ID, RelatedMemoID: memo.ID, Type: store.MemoRelationReference})
+	require.NoError(t, err)
+	_, err = ts.CreateResource(ctx, &store.Resource{
+		UID:       "archived-resource",
+		CreatorID: user.ID,
+		Filename:  "hello.txt",
+		Type:      "text/plain",
+		Size:      5,
+		Blob:      []byte("hello"),
+		MemoID:    &memo.ID,
+	})
+	require.NoError(t, err)
+	project, err := ts.CreateProject(ctx, &store.Project{Key: "OPS", Name: "Operations"})
+	require.NoError(t, err)
+	_, err = ts.UpsertProjectMember(ctx, &store.ProjectMember{ProjectID: project.ID, UserID: user.ID})
+	require.NoError(t, err)
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_TICKET_FIELDS,
+		Value: &storepb.WorkspaceSetting_TicketFieldsSetting{TicketFieldsSetting: &storepb.WorkspaceTicketFieldsSetting{
+			Fields: []*storepb.TicketField{
+				{Key: "points", Title: "Points", Type: storepb.TicketField_NUMBER},
+				{Key: "reviewer", Title: "Reviewer", Type: storepb.TicketField_USER},
+			},
+		}},
+	})
+	require.NoError(t, err)
+	// The deleted ticket leaves a gap in the numbers of the project.
+	deletedTicket, err := ts.CreateTicket(ctx, &store.Ticket{ProjectID: &project.ID, Title: "deleted ticket", CreatorID: host.ID, CreatedTs: createdTs, UpdatedTs: createdTs})
+	require.NoError(t, err)
+	ticket, err := ts.CreateTicket(ctx, &store.Ticket{
+		ProjectID:  &project.ID,
+		Title:      "archived ticket",
+		Status:     store.TicketStatusOpen,
+		Priority:   store.TicketPriorityLow,
+		CreatorID:  host.ID,
+		AssigneeID: &user.ID,
+		Tags:       []string{"archive"},
+		Fields:     map[string]any{"points": float64(3), "reviewer": user.ID},
+		CreatedTs:  createdTs,
+		UpdatedTs:  createdTs,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ts.DeleteTicket(ctx, &store.DeleteTicket{ID: deletedTicket.ID}))
+	_, err = ts.CreateNotification(ctx, &store.Notification{
+		InitiatorID: host.ID,
+		ReceiverID:  user.ID,
+		TicketURL:   fmt.Sprintf("/tickets/%d", ticket.ID),
+		CreatedTs:   createdTs,
+	})
+	require.NoError(t, err)
+
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_TICKET_VIEWS,
+		Value: &storepb.UserSetting_TicketViews{TicketViews: &storepb.TicketViewsUserSetting{
+			Views: []*storepb.TicketView{{Id: "mine", Title: "Mine", CreatorId: user.ID}},
+		}},
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_SHARED_TICKET_VIEWS,
+		Value: &storepb.WorkspaceSetting_SharedTicketViewsSetting{SharedTicketViewsSetting: &storepb.WorkspaceSharedTicketViewsSetting{
+			Views: []*storepb.TicketView{{Id: "shared", Title: "Shared", CreatorId: user.ID}},
+		}},
+	})
+	require.NoError(t, err)
+
+	triager, err := ts.CreateCustomRole(ctx, &store.CustomRole{Name: "triager", Permissions: []store.Permission{store.PermissionTicketEditAny}})
+	require.NoError(t, err)
+	require.NoError(t, ts.SetUserCustomRoles(ctx, user.ID, []int32{triager.ID}))
+
+	buf := &bytes.Buffer{}
+	manifest, err := archive.NewExporter(ts, archiveProfile).Export(ctx, buf)
+	require.NoError(t, err)
+	require.Equal(t, archive.FormatVersion, manifest.FormatVersion)
+	require.Equal(t, 3, manifest.Counts["users.jsonl"])
+	require.Equal(t, 2, manifest.Counts["memos.jsonl"])
+	ts.Close()
+
+	target := NewTestingStore(ctx, t)
+	defer target.Close()
+	// Shift the IDs of the target instance.
+	shift, err := target.CreateUser(ctx, &store.User{Username: "shift", Role: store.RoleUser})
+	require.NoError(t, err)
+	_, err = archive.NewImporter(target, archiveProfile).Import(ctx, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.ErrorContains(t, err, "not empty")
+	shiftMemo, err := target.CreateMemo(ctx, &store.Memo{UID: "shift", CreatorID: shift.ID, Visibility: store.Public})
+	require.NoError(t, err)
+	require.NoError(t, target.DeleteMemo(ctx, &store.DeleteMemo{ID: shiftMemo.ID}))
+	require.NoError(t, target.DeleteUser(ctx, &store.DeleteUser{ID: shift.ID}))
+
+	_, err = archive.NewImporter(target, archiveProfile).Import(ctx, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	importedUser, err := target.GetUser(ctx, &store.FindUser{Username: &user.Username})
+	require.NoError(t, err)
+	require.NotEqual(t, user.ID, importedUser.ID)
+	require.Equal(t, createdTs, importedUser.CreatedTs)
+	require.Equal(t, "user", importedUser.Nickname)
+
+	importedMemo, err := target.GetMemo(ctx, &store.FindMemo{UID: &memo.UID})
+	require.NoError(t, err)
+	require.Equal(t, importedUser.ID, importedMemo.CreatorID)
+	require.Equal(t, []string{"world"}, importedMemo.Payload.Tags)
+	relations, err := target.ListMemoRelations(ctx, &store.FindMemoRelation{RelatedMemoID: &importedMemo.ID})
+	require.NoError(t, err)
+	require.Len(t, relations, 2)
+	importedComment, err := target.GetMemo(ctx, &store.FindMemo{UID: &comment.UID})
+	require.NoError(t, err)
+	require.NotEqual(t, memo.ID, importedMemo.ID)
+	require.Equal(t, []int32{importedMemo.ID}, importedComment.Payload.ContentRelations)
+
+	resourceUID := "archived-resource"
+	importedResource, err := target.GetResource(ctx, &store.FindResource{UID: &resourceUID, GetBlob: true})
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), importedResource.Blob)
+	require.Equal(t, importedMemo.ID, *importedResource.MemoID)
+
+	tickets, err := target.ListTickets(ctx, &store.FindTicket{})
+	require.NoError(t, err)
+	require.Len(t, tickets, 1)
+	require.Equal(t, importedUser.ID, *tickets[0].AssigneeID)
+	projectKey := "OPS"
+	importedProject, err := target.GetProject(ctx, &store.FindProject{Key: &projectKey})
+	require.NoError(t, err)
+	require.Equal(t, importedProject.ID, *tickets[0].ProjectID)
+	require.Equal(t, int32(2), tickets[0].Number)
+	require.EqualValues(t, 3, tickets[0].Fields["points"])
+	require.EqualValues(t, importedUser.ID, tickets[0].Fields["reviewer"])
+	ticketFieldsSetting, err := target.GetWorkspaceTicketFieldsSetting(ctx)
+	require.NoError(t, err)
+	require.Len(t, ticketFieldsSetting.Fields, 2)
+	projectIDs, err := target.ListUserProjectIDs(ctx, importedUser.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int32{importedProject.ID}, projectIDs)
+	notifications, err := target.ListNotifications(ctx, &store.FindNotification{ReceiverID: &importedUser.ID})
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, fmt.Sprintf("/tickets/%d", tickets[0].ID), notifications[0].TicketURL)
+
+	ticketViews, err := target.GetUserSetting(ctx, &store.FindUserSetting{UserID: &importedUser.ID, Key: storepb.UserSettingKey_TICKET_VIEWS})
+	require.NoError(t, err)
+	require.Len(t, ticketViews.GetTicketViews().GetViews(), 1)
+	require.Equal(t, importedUser.ID, ticketViews.GetTicketViews().GetViews()[0].CreatorId)
+	sharedTicketViews, err := target.GetWorkspaceSharedTicketViewsSetting(ctx)
+	require.NoError(t, err)
+	require.Len(t, sharedTicketViews.Views, 1)
+	require.Equal(t, importedUser.ID, sharedTicketViews.Views[0].CreatorId)
+
+	customRoles, err := target.ListUserCustomRoles(ctx, importedUser.ID)
+	require.NoError(t, err)
+	require.Len(t, customRoles, 1)
+	require.Equal(t, "triager", customRoles[0].Name)
+}
+
+func TestArchiveImportRollback(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	defer ts.Close()
+	archiveProfile := &profile.Profile{
+		Version: "test",
+		Driver:  getDriverFromEnv(),
+		Data:    t.TempDir(),
+	}
+
+	// The archive holds a local resource whose reference escapes the data directory.
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	files := map[string]string{
+		"manifest.json":       `{"formatVersion":1}`,
+		"users.jsonl":         `{"id":1,"username":"alice","role":"HOST","rowStatus":"NORMAL"}`,
+		"user_settings.jsonl": `{"userId":1,"key":"LOCALE","value":"{\"locale\":\"fr\"}"}`,
+		"memos.jsonl":         `{"id":1,"uid":"archived-memo","creatorId":1,"content":"hello","visibility":"PUBLIC","rowStatus":"NORMAL"}`,
+		"resources.jsonl":     `{"id":1,"uid":"escape","creatorId":1,"filename":"escape.txt","storageType":"LOCAL","reference":"../escape.txt","hasBlob":true}`,
+		"resources/escape":    "escape",
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	_, err := archive.NewImporter(ts, archiveProfile).Import(ctx, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.ErrorContains(t, err, "outside of the data directory")
+	require.NoFileExists(t, filepath.Join(filepath.Dir(archiveProfile.Data), "escape.txt"))
+
+	// The records imported before the failure are removed, so the import can be retried.
+	users, err := ts.ListUsers(ctx, &store.FindUser{})
+	require.NoError(t, err)
+	require.Empty(t, users)
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{})
+	require.NoError(t, err)
+	require.Empty(t, memos)
+	userSettings, err := ts.GetDriver().ListUserSettings(ctx, &store.FindUserSetting{})
+	require.NoError(t, err)
+	require.Empty(t, userSettings)
+}
+
+func TestArchiveContentAddressedResources(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	archiveProfile := &profile.Profile{
+		Version: "test",
+		Driver:  getDriverFromEnv(),
+		Data:    t.TempDir(),
+	}
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	storageSetting := &storepb.WorkspaceStorageSetting{StorageType: storepb.WorkspaceStorageSetting_DATABASE, ContentAddressed: true}
+	content := []byte("shared content")
+	for _, uid := range []string{"first", "second"} {
+		resource := &store.Resource{UID: uid, CreatorID: user.ID, Filename: "shared.txt", Type: "text/plain", Size: int64(len(content))}
+		require.NoError(t, ts.PutResourceBlob(ctx, resource, bytes.NewReader(content), storageSetting))
+		_, err := ts.CreateResource(ctx, resource)
+		require.NoError(t, err)
+	}
+
+	buf := &bytes.Buffer{}
+	_, err = archive.NewExporter(ts, archiveProfile).Export(ctx, buf)
+	require.NoError(t, err)
+	ts.Close()
+
+	target := NewTestingStore(ctx, t)
+	defer target.Close()
+	_, err = archive.NewImporter(target, archiveProfile).Import(ctx, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	// The blob shared by the resources is stored once in the target.
+	hash := store.HashResourceContent(content)
+	blob, err := target.GetResourceBlob(ctx, &store.FindResourceBlob{Hash: &hash})
+	require.NoError(t, err)
+	require.NotNil(t, blob)
+	require.Equal(t, int32(2), blob.RefCount)
+	for _, uid := range []string{"first", "second"} {
+		resource, err := target.GetResource(ctx, &store.FindResource{UID: &uid})
+		require.NoError(t, err)
+		require.Equal(t, hash, resource.Payload.GetContentHash())
+	}
+	read, err := target.ReadResourceBlob(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, content, read)
+}
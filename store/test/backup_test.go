@@ -0,0 +1,97 @@
+package teststore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/backup"
+	"github.com/usememos/memos/store/db"
+)
+
+func TestBackupCreateAndRestore(t *testing.T) {
+	if getDriverFromEnv() != "sqlite" {
+		t.Skip("backups are only supported for sqlite")
+	}
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	backupProfile := &profile.Profile{
+		Mode:   "prod",
+		Driver: "sqlite",
+		Data:   t.TempDir(),
+	}
+	manager := backup.NewManager(ts, backupProfile)
+	manager.Keep = 1
+	// An older snapshot is removed by the rotation.
+	require.NoError(t, os.MkdirAll(manager.Dir(), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(manager.Dir(), "memos_20200101T000000Z.db"), []byte{}, 0600))
+
+	created, err := manager.Create(ctx)
+	require.NoError(t, err)
+	backups, err := manager.List()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	require.Equal(t, created.Name, backups[0].Name)
+
+	snapshot := filepath.Join(manager.Dir(), created.Name)
+	currentSchemaVersion, err := ts.GetCurrentSchemaVersion()
+	require.NoError(t, err)
+	schemaVersion, err := backup.ReadSchemaVersion(ctx, snapshot)
+	require.NoError(t, err)
+	require.Equal(t, currentSchemaVersion, schemaVersion)
+	ts.Close()
+
+	// A snapshot newer than the binary is refused.
+	backupProfile.DSN = filepath.Join(backupProfile.Data, "memos_prod.db")
+	_, err = backup.Restore(ctx, backupProfile, snapshot, "0.1.0")
+	require.ErrorContains(t, err, "newer than the current schema version")
+
+	// The write-ahead log of the replaced database is kept along with it.
+	require.NoError(t, os.WriteFile(backupProfile.DSN, []byte("previous"), 0600))
+	require.NoError(t, os.WriteFile(backupProfile.DSN+"-wal", []byte("previous wal"), 0600))
+	previousPath, err := backup.Restore(ctx, backupProfile, snapshot, currentSchemaVersion)
+	require.NoError(t, err)
+	previousWAL, err := os.ReadFile(previousPath + "-wal")
+	require.NoError(t, err)
+	require.Equal(t, "previous wal", string(previousWAL))
+	_, err = os.Stat(backupProfile.DSN + "-wal")
+	require.True(t, os.IsNotExist(err))
+	dbDriver, err := db.NewDBDriver(backupProfile)
+	require.NoError(t, err)
+	restored := store.New(dbDriver, backupProfile)
+	defer restored.Close()
+	restoredUser, err := restored.GetUser(ctx, &store.FindUser{ID: &user.ID})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, restoredUser.Username)
+}
+
+func TestBackupCreateWithinSameSecond(t *testing.T) {
+	if getDriverFromEnv() != "sqlite" {
+		t.Skip("backups are only supported for sqlite")
+	}
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	defer ts.Close()
+	manager := backup.NewManager(ts, &profile.Profile{
+		Mode:   "prod",
+		Driver: "sqlite",
+		Data:   t.TempDir(),
+	})
+
+	first, err := manager.Create(ctx)
+	require.NoError(t, err)
+	second, err := manager.Create(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, first.Name, second.Name)
+	backups, err := manager.List()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	require.Equal(t, second.Name, backups[0].Name)
+}
@@ -0,0 +1,97 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestCustomRoleStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	customRole, err := ts.CreateCustomRole(ctx, &store.CustomRole{
+		Name:        "triager",
+		Description: "Edits and assigns every ticket.",
+		Permissions: []store.Permission{store.PermissionTicketEditAny, store.PermissionTicketAssign},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "triager", customRole.Name)
+	customRoles, err := ts.ListCustomRoles(ctx, &store.FindCustomRole{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(customRoles))
+	require.Equal(t, customRole, customRoles[0])
+
+	newDescription := "Triages tickets."
+	updatedCustomRole, err := ts.UpdateCustomRole(ctx, &store.UpdateCustomRole{
+		ID:          customRole.ID,
+		Description: &newDescription,
+		Permissions: []store.Permission{store.PermissionTicketEditAny, store.PermissionTicketDelete},
+	})
+	require.NoError(t, err)
+	require.Equal(t, newDescription, updatedCustomRole.Description)
+	require.Equal(t, []store.Permission{store.PermissionTicketEditAny, store.PermissionTicketDelete}, updatedCustomRole.Permissions)
+
+	_, err = ts.UpdateCustomRole(ctx, &store.UpdateCustomRole{
+		ID:          customRole.ID,
+		Permissions: []store.Permission{"ticket.unknown"},
+	})
+	require.Error(t, err)
+
+	require.NoError(t, ts.DeleteCustomRole(ctx, &store.DeleteCustomRole{ID: customRole.ID}))
+	customRoles, err = ts.ListCustomRoles(ctx, &store.FindCustomRole{})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(customRoles))
+	ts.Close()
+}
+
+func TestUserPermissions(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := ts.CreateUser(ctx, &store.User{
+		Username: "member",
+		Role:     store.RoleUser,
+	})
+	require.NoError(t, err)
+
+	hasPermission, err := ts.HasPermission(ctx, user, store.PermissionTicketEditAny)
+	require.NoError(t, err)
+	require.False(t, hasPermission)
+	hasPermission, err = ts.HasPermission(ctx, user, store.PermissionTicketEditOwn)
+	require.NoError(t, err)
+	require.True(t, hasPermission)
+
+	triager, err := ts.CreateCustomRole(ctx, &store.CustomRole{
+		Name:        "triager",
+		Permissions: []store.Permission{store.PermissionTicketEditAny},
+	})
+	require.NoError(t, err)
+	janitor, err := ts.CreateCustomRole(ctx, &store.CustomRole{
+		Name:        "janitor",
+		Permissions: []store.Permission{store.PermissionTicketDelete},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ts.SetUserCustomRoles(ctx, user.ID, []int32{triager.ID, janitor.ID}))
+	customRoles, err := ts.ListUserCustomRoles(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(customRoles))
+	hasPermission, err = ts.HasPermission(ctx, user, store.PermissionTicketEditAny)
+	require.NoError(t, err)
+	require.True(t, hasPermission)
+
+	require.NoError(t, ts.SetUserCustomRoles(ctx, user.ID, []int32{janitor.ID}))
+	permissions, err := ts.ListUserPermissions(ctx, user)
+	require.NoError(t, err)
+	require.NotContains(t, permissions, store.PermissionTicketEditAny)
+	require.Contains(t, permissions, store.PermissionTicketDelete)
+
+	// Deleting a role removes its assignments.
+	require.NoError(t, ts.DeleteCustomRole(ctx, &store.DeleteCustomRole{ID: janitor.ID}))
+	customRoles, err = ts.ListUserCustomRoles(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(customRoles))
+	ts.Close()
+}
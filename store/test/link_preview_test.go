@@ -0,0 +1,74 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestLinkPreviewStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	url := "https://example.com/post"
+
+	linkPreview, err := ts.GetLinkPreview(ctx, &store.FindLinkPreview{URL: &url})
+	require.NoError(t, err)
+	require.Nil(t, linkPreview)
+
+	linkPreview, err = ts.UpsertLinkPreview(ctx, &store.LinkPreview{
+		URL:       url,
+		Error:     "failed to fetch",
+		UpdatedTs: 1700000000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, store.HashLinkPreviewURL(url), linkPreview.Hash)
+	require.Equal(t, "failed to fetch", linkPreview.Error)
+	require.Equal(t, int64(1700000000), linkPreview.UpdatedTs)
+
+	// Upserting the preview of the same URL replaces the cached one.
+	_, err = ts.UpsertLinkPreview(ctx, &store.LinkPreview{
+		URL:         url,
+		Title:       "Post",
+		Description: "A post",
+		Image:       "https://example.com/cover.png",
+	})
+	require.NoError(t, err)
+	linkPreview, err = ts.GetLinkPreview(ctx, &store.FindLinkPreview{URL: &url})
+	require.NoError(t, err)
+	require.Equal(t, "Post", linkPreview.Title)
+	require.Equal(t, "https://example.com/cover.png", linkPreview.Image)
+	require.Empty(t, linkPreview.Error)
+	require.Greater(t, linkPreview.UpdatedTs, int64(1700000000))
+
+	before := linkPreview.UpdatedTs
+	linkPreviews, err := ts.ListLinkPreviews(ctx, &store.FindLinkPreview{UpdatedTsBefore: &before})
+	require.NoError(t, err)
+	require.Empty(t, linkPreviews)
+	ts.Close()
+}
+
+func TestPruneLinkPreviews(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	expiredURL, freshURL := "https://example.com/expired", "https://example.com/fresh"
+	_, err := ts.UpsertLinkPreview(ctx, &store.LinkPreview{
+		URL:       expiredURL,
+		UpdatedTs: time.Now().Add(-store.LinkPreviewTTL - time.Minute).Unix(),
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertLinkPreview(ctx, &store.LinkPreview{URL: freshURL})
+	require.NoError(t, err)
+
+	require.NoError(t, ts.PruneLinkPreviews(ctx))
+	linkPreview, err := ts.GetLinkPreview(ctx, &store.FindLinkPreview{URL: &expiredURL})
+	require.NoError(t, err)
+	require.Nil(t, linkPreview)
+	linkPreview, err = ts.GetLinkPreview(ctx, &store.FindLinkPreview{URL: &freshURL})
+	require.NoError(t, err)
+	require.NotNil(t, linkPreview)
+	ts.Close()
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
@@ -60,3 +61,44 @@ func TestMemoRelationStore(t *testing.T) {
 	require.NoError(t, err)
 	ts.Close()
 }
+
+func TestMemoRelationContentRelations(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	relatedMemo, err := ts.CreateMemo(ctx, &store.Memo{UID: "related-memo", CreatorID: user.ID, Content: "related", Visibility: store.Public})
+	require.NoError(t, err)
+	otherMemo, err := ts.CreateMemo(ctx, &store.Memo{UID: "other-memo", CreatorID: user.ID, Content: "other", Visibility: store.Public})
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "main-memo",
+		CreatorID:  user.ID,
+		Content:    "[[memos/related-memo]]",
+		Visibility: store.Public,
+		Payload:    &storepb.MemoPayload{ContentRelations: []int32{relatedMemo.ID}},
+	})
+	require.NoError(t, err)
+	referenceType := store.MemoRelationReference
+	for _, relatedMemoID := range []int32{relatedMemo.ID, otherMemo.ID} {
+		_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: memo.ID, RelatedMemoID: relatedMemoID, Type: referenceType})
+		require.NoError(t, err)
+	}
+
+	// The content relations are kept in the payload.
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int32{relatedMemo.ID}, memo.Payload.ContentRelations)
+
+	// Deleting a content relation leaves the other reference relations alone.
+	require.NoError(t, ts.DeleteMemoRelation(ctx, &store.DeleteMemoRelation{
+		MemoID:        &memo.ID,
+		RelatedMemoID: &memo.Payload.ContentRelations[0],
+		Type:          &referenceType,
+	}))
+	relations, err := ts.ListMemoRelations(ctx, &store.FindMemoRelation{MemoID: &memo.ID, Type: &referenceType})
+	require.NoError(t, err)
+	require.Len(t, relations, 1)
+	require.Equal(t, otherMemo.ID, relations[0].RelatedMemoID)
+	ts.Close()
+}
@@ -96,6 +96,46 @@ func TestMemoListByTags(t *testing.T) {
 	ts.Close()
 }
 
+func TestMemoListByMentionsAndTicketReferences(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "test-mention-memo",
+		CreatorID:  user.ID,
+		Content:    "@test see #ticket-42",
+		Visibility: store.Public,
+		Payload: &storepb.MemoPayload{
+			Property: &storepb.MemoPayload_Property{
+				Mentions:         []int32{user.ID},
+				TicketReferences: []int32{42},
+			},
+		},
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateMemo(ctx, &store.Memo{
+		UID:        "test-other-memo",
+		CreatorID:  user.ID,
+		Content:    "test_content",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+
+	for _, filter := range []string{`mentions == "test"`, `references_ticket == 42`} {
+		memoList, err := ts.ListMemos(ctx, &store.FindMemo{Filter: &filter})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(memoList))
+		require.Equal(t, memo.ID, memoList[0].ID)
+	}
+	for _, filter := range []string{`mentions == "unknown"`, `references_ticket == 7`} {
+		memoList, err := ts.ListMemos(ctx, &store.FindMemo{Filter: &filter})
+		require.NoError(t, err)
+		require.Equal(t, 0, len(memoList))
+	}
+	ts.Close()
+}
+
 func TestDeleteMemoStore(t *testing.T) {
 	ctx := context.Background()
 	ts := NewTestingStore(ctx, t)
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
 )
 
 func TestGetCurrentSchemaVersion(t *testing.T) {
@@ -13,5 +15,69 @@ func TestGetCurrentSchemaVersion(t *testing.T) {
 
 	currentSchemaVersion, err := ts.GetCurrentSchemaVersion()
 	require.NoError(t, err)
-	require.Equal(t, "0.24.2", currentSchemaVersion)
+	require.Equal(t, "0.25.12", currentSchemaVersion)
+}
+
+func TestGetMigrationStatus(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+
+	migrationStatus, err := ts.GetMigrationStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, migrationStatus.TargetSchemaVersion, migrationStatus.CurrentSchemaVersion)
+	require.Empty(t, migrationStatus.Pending)
+}
+
+func TestVerifySchema(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+
+	drifts, err := ts.VerifySchema(ctx)
+	require.NoError(t, err)
+	require.Empty(t, drifts)
+
+	_, err = ts.GetDriver().GetDB().ExecContext(ctx, "ALTER TABLE webhook ADD COLUMN secret TEXT")
+	require.NoError(t, err)
+	drifts, err = ts.VerifySchema(ctx)
+	require.NoError(t, err)
+	require.Len(t, drifts, 1)
+	require.Equal(t, "webhook", drifts[0].Table)
+	require.Equal(t, "secret", drifts[0].Column)
+	require.False(t, drifts[0].Missing)
+}
+
+func TestMigrateDown(t *testing.T) {
+	if getDriverFromEnv() != "sqlite" {
+		t.Skip("down scripts are only tested against sqlite")
+	}
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	ticket, err := ts.CreateTicket(ctx, &store.Ticket{Title: "kept", CreatorID: user.ID})
+	require.NoError(t, err)
+
+	// Revert everything after the beads integration, 03__beads_integration.sql adds a UNIQUE column
+	// that SQLite can't add to an existing table, so it can't be applied again.
+	require.NoError(t, ts.MigrateDown(ctx, "0.25.4"))
+	migrationStatus, err := ts.GetMigrationStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "0.25.4", migrationStatus.CurrentSchemaVersion)
+	require.Len(t, migrationStatus.Pending, 8)
+	drifts, err := ts.VerifySchema(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, drifts)
+
+	// Migrating forward again restores the latest schema.
+	require.NoError(t, ts.Migrate(ctx))
+	drifts, err = ts.VerifySchema(ctx)
+	require.NoError(t, err)
+	require.Empty(t, drifts)
+	// Tables recreated by the down scripts keep their rows.
+	tickets, err := ts.ListTickets(ctx, &store.FindTicket{ID: &ticket.ID})
+	require.NoError(t, err)
+	require.Len(t, tickets, 1)
+	require.Equal(t, "kept", tickets[0].Title)
+
+	require.ErrorContains(t, ts.MigrateDown(ctx, "0.24.0"), "no down script")
 }
@@ -0,0 +1,178 @@
+package teststore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestProjectStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	_, err = ts.CreateProject(ctx, &store.Project{Key: "api", Name: "API"})
+	require.Error(t, err)
+	project, err := ts.CreateProject(ctx, &store.Project{
+		Key:  "API",
+		Name: "API",
+		Payload: &storepb.ProjectPayload{
+			TicketTypes: []*storepb.ProjectPayload_TicketType{{Name: "BUG", DefaultAssigneeId: user.ID}, {Name: "TASK"}},
+			Workflow: &storepb.ProjectPayload_Workflow{
+				Statuses:    []string{"TODO", "DOING", "DONE"},
+				Transitions: []*storepb.ProjectPayload_Transition{{From: "TODO", To: "DOING"}, {From: "DOING", To: "DONE"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, store.Normal, project.RowStatus)
+	require.Equal(t, "BUG", project.DefaultTicketType())
+	require.Equal(t, "TODO", project.InitialTicketStatus())
+	require.Equal(t, user.ID, *project.DefaultAssigneeID("BUG"))
+	require.Nil(t, project.DefaultAssigneeID("TASK"))
+	require.False(t, project.IsTicketTypeAllowed("EPIC"))
+	require.True(t, project.IsTicketTransitionAllowed("TODO", "DOING"))
+	require.False(t, project.IsTicketTransitionAllowed("TODO", "DONE"))
+
+	key := "API"
+	found, err := ts.GetProject(ctx, &store.FindProject{Key: &key})
+	require.NoError(t, err)
+	require.Equal(t, project.ID, found.ID)
+	require.Equal(t, 2, len(found.Payload.TicketTypes))
+
+	_, err = ts.UpdateProject(ctx, &store.UpdateProject{
+		ID: project.ID,
+		Payload: &storepb.ProjectPayload{
+			Workflow: &storepb.ProjectPayload_Workflow{
+				Statuses:    []string{"TODO"},
+				Transitions: []*storepb.ProjectPayload_Transition{{From: "TODO", To: "DONE"}},
+			},
+		},
+	})
+	require.Error(t, err)
+	name := "Public API"
+	updated, err := ts.UpdateProject(ctx, &store.UpdateProject{ID: project.ID, Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, name, updated.Name)
+
+	member, err := ts.UpsertProjectMember(ctx, &store.ProjectMember{ProjectID: project.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, store.ProjectMemberRoleMember, member.Role)
+	_, err = ts.UpsertProjectMember(ctx, &store.ProjectMember{ProjectID: project.ID, UserID: user.ID, Role: store.ProjectMemberRoleOwner})
+	require.NoError(t, err)
+	members, err := ts.ListProjectMembers(ctx, &store.FindProjectMember{ProjectID: &project.ID})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(members))
+	require.Equal(t, store.ProjectMemberRoleOwner, members[0].Role)
+	projectIDs, err := ts.ListUserProjectIDs(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int32{project.ID}, projectIDs)
+
+	require.NoError(t, ts.DeleteProject(ctx, &store.DeleteProject{ID: project.ID}))
+	projects, err := ts.ListProjects(ctx, &store.FindProject{})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(projects))
+	members, err = ts.ListProjectMembers(ctx, &store.FindProjectMember{ProjectID: &project.ID})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(members))
+	ts.Close()
+}
+
+func TestProjectTicketNumbers(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	apiProject, err := ts.CreateProject(ctx, &store.Project{Key: "API", Name: "API"})
+	require.NoError(t, err)
+	webProject, err := ts.CreateProject(ctx, &store.Project{Key: "WEB", Name: "Web"})
+	require.NoError(t, err)
+
+	createTicket := func(projectID *int32) *store.Ticket {
+		ticket, err := ts.CreateTicket(ctx, &store.Ticket{
+			Title:       "ticket",
+			Description: "/m/ticket",
+			Status:      store.TicketStatusOpen,
+			Priority:    store.TicketPriorityMedium,
+			CreatorID:   user.ID,
+			Type:        "TASK",
+			Tags:        []string{},
+			ProjectID:   projectID,
+		})
+		require.NoError(t, err)
+		return ticket
+	}
+	require.Equal(t, int32(1), createTicket(&apiProject.ID).Number)
+	require.Equal(t, int32(2), createTicket(&apiProject.ID).Number)
+	require.Equal(t, int32(1), createTicket(&webProject.ID).Number)
+	require.Equal(t, int32(0), createTicket(nil).Number)
+	last := createTicket(&apiProject.ID)
+	require.Equal(t, "API-3", store.FormatTicketKey(apiProject.Key, last.Number))
+
+	projectKey, number, ok := store.ParseTicketKey("API-3")
+	require.True(t, ok)
+	require.Equal(t, "API", projectKey)
+	found, err := ts.GetTicket(ctx, &store.FindTicket{ProjectID: &apiProject.ID, Number: &number})
+	require.NoError(t, err)
+	require.Equal(t, last.ID, found.ID)
+	_, _, ok = store.ParseTicketKey("API-0")
+	require.False(t, ok)
+
+	tickets, err := ts.ListTickets(ctx, &store.FindTicket{ProjectID: &apiProject.ID})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(tickets))
+	ts.Close()
+}
+
+func TestProjectMemoVisibility(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	project, err := ts.CreateProject(ctx, &store.Project{Key: "API", Name: "API"})
+	require.NoError(t, err)
+
+	projectMemo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "project-memo",
+		CreatorID:  user.ID,
+		Content:    "project memo",
+		Visibility: store.ProjectMembers,
+		ProjectID:  &project.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, project.ID, *projectMemo.ProjectID)
+	_, err = ts.CreateMemo(ctx, &store.Memo{
+		UID:        "public-memo",
+		CreatorID:  user.ID,
+		Content:    "public memo",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{ProjectID: &project.ID})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(memos))
+	require.Equal(t, store.ProjectMembers, memos[0].Visibility)
+
+	filter := fmt.Sprintf(`visibility == "PUBLIC" || (visibility == "PROJECT" && project_id in [%d])`, project.ID)
+	memos, err = ts.ListMemos(ctx, &store.FindMemo{Filter: &filter})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(memos))
+	filter = `visibility == "PUBLIC" || (visibility == "PROJECT" && project_id in [0])`
+	memos, err = ts.ListMemos(ctx, &store.FindMemo{Filter: &filter})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(memos))
+
+	removed := int32(0)
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: projectMemo.ID, ProjectID: &removed}))
+	memo, err := ts.GetMemo(ctx, &store.FindMemo{ID: &projectMemo.ID})
+	require.NoError(t, err)
+	require.Nil(t, memo.ProjectID)
+	ts.Close()
+}
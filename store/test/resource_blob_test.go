@@ -0,0 +1,112 @@
+package teststore
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestResourceBlobDeduplication(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	for _, storageType := range []storepb.WorkspaceStorageSetting_StorageType{storepb.WorkspaceStorageSetting_DATABASE, storepb.WorkspaceStorageSetting_LOCAL} {
+		storageSetting := &storepb.WorkspaceStorageSetting{StorageType: storageType, ContentAddressed: true}
+		content := []byte("screenshot " + storageType.String())
+		hash := store.HashResourceContent(content)
+
+		resources := []*store.Resource{}
+		for i := 0; i < 2; i++ {
+			resource := &store.Resource{
+				UID:       shortuuid.New(),
+				CreatorID: user.ID,
+				Filename:  "screenshot.png",
+				Blob:      content,
+				Type:      "image/png",
+				Size:      int64(len(content)),
+			}
+			require.NoError(t, ts.PutResourceBlob(ctx, resource, bytes.NewReader(resource.Blob), storageSetting))
+			require.Nil(t, resource.Blob)
+			require.Equal(t, hash, resource.Payload.GetContentHash())
+			resource, err = ts.CreateResource(ctx, resource)
+			require.NoError(t, err)
+			resources = append(resources, resource)
+		}
+		require.Equal(t, resources[0].Reference, resources[1].Reference)
+
+		// The content is stored once and referenced twice.
+		blob, err := ts.GetResourceBlob(ctx, &store.FindResourceBlob{Hash: &hash})
+		require.NoError(t, err)
+		require.Equal(t, int32(2), blob.RefCount)
+		read, err := ts.ReadResourceBlob(ctx, hash)
+		require.NoError(t, err)
+		require.Equal(t, content, read)
+
+		require.NoError(t, ts.DeleteResource(ctx, &store.DeleteResource{ID: resources[0].ID}))
+		blob, err = ts.GetResourceBlob(ctx, &store.FindResourceBlob{Hash: &hash})
+		require.NoError(t, err)
+		require.Equal(t, int32(1), blob.RefCount)
+
+		// Referenced blobs survive the collection.
+		deleted, err := ts.CollectResourceBlobGarbage(ctx, -time.Minute)
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+
+		require.NoError(t, ts.DeleteResource(ctx, &store.DeleteResource{ID: resources[1].ID}))
+		deleted, err = ts.CollectResourceBlobGarbage(ctx, -time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, 1, deleted)
+		blob, err = ts.GetResourceBlob(ctx, &store.FindResourceBlob{Hash: &hash})
+		require.NoError(t, err)
+		require.Nil(t, blob)
+	}
+	ts.Close()
+}
+
+func TestResourceBlobIntegrity(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	storageSetting := &storepb.WorkspaceStorageSetting{StorageType: storepb.WorkspaceStorageSetting_DATABASE, ContentAddressed: true}
+	resource := &store.Resource{
+		UID:       shortuuid.New(),
+		CreatorID: user.ID,
+		Filename:  "notes.txt",
+		Blob:      []byte("notes"),
+		Type:      "text/plain",
+		Size:      5,
+	}
+	require.NoError(t, ts.PutResourceBlob(ctx, resource, bytes.NewReader(resource.Blob), storageSetting))
+	hash := resource.Payload.GetContentHash()
+
+	// Recently used blobs are kept even though no resource refers to them yet.
+	deleted, err := ts.CollectResourceBlobGarbage(ctx, time.Hour)
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+
+	stmt := "UPDATE resource_blob SET blob = ? WHERE hash = ?"
+	if getDriverFromEnv() == "postgres" {
+		stmt = "UPDATE resource_blob SET blob = $1 WHERE hash = $2"
+	}
+	_, err = ts.GetDriver().GetDB().ExecContext(ctx, stmt, []byte("tampered"), hash)
+	require.NoError(t, err)
+	_, err = ts.ReadResourceBlob(ctx, hash)
+	require.ErrorContains(t, err, "corrupted")
+
+	// The blob left without resource, e.g. by a failed upload, is recounted and collected.
+	deleted, err = ts.CollectResourceBlobGarbage(ctx, -time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+	ts.Close()
+}
@@ -2,11 +2,14 @@ package teststore
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/lithammer/shortuuid/v4"
 	"github.com/stretchr/testify/require"
 
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
@@ -61,3 +64,62 @@ func TestResourceStore(t *testing.T) {
 	require.ErrorContains(t, err, "resource not found")
 	ts.Close()
 }
+
+func TestResourceExtractedTextSearch(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "attachment-memo",
+		CreatorID:  user.ID,
+		Content:    "see the attachment",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateResource(ctx, &store.Resource{
+		UID:       shortuuid.New(),
+		CreatorID: user.ID,
+		Filename:  "invoice.pdf",
+		Blob:      []byte("%PDF"),
+		Type:      "application/pdf",
+		Size:      4,
+		MemoID:    &memo.ID,
+		Payload:   &storepb.ResourcePayload{ExtractedText: "Invoice 42 for the quarterly report"},
+	})
+	require.NoError(t, err)
+
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{ContentSearch: []string{"quarterly"}})
+	require.NoError(t, err)
+	require.Len(t, memos, 1)
+	require.Equal(t, memo.ID, memos[0].ID)
+	memos, err = ts.ListMemos(ctx, &store.FindMemo{ContentSearch: []string{"attachment", "invoice"}})
+	require.NoError(t, err)
+	require.Len(t, memos, 1)
+	memos, err = ts.ListMemos(ctx, &store.FindMemo{ContentSearch: []string{"receipt"}})
+	require.NoError(t, err)
+	require.Len(t, memos, 0)
+	ts.Close()
+}
+
+func TestDeleteResourceVariants(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	resource, err := ts.CreateResource(ctx, &store.Resource{
+		UID:       shortuuid.New(),
+		CreatorID: 101,
+		Filename:  "photo.png",
+		Blob:      []byte("png"),
+		Type:      "image/png",
+		Size:      3,
+	})
+	require.NoError(t, err)
+	variantPath := ts.ResourceVariantPath(resource, "small")
+	require.NoError(t, os.MkdirAll(filepath.Dir(variantPath), os.ModePerm))
+	require.NoError(t, os.WriteFile(variantPath, []byte("webp"), 0644))
+
+	require.NoError(t, ts.DeleteResource(ctx, &store.DeleteResource{ID: resource.ID}))
+	_, err = os.Stat(variantPath)
+	require.True(t, os.IsNotExist(err))
+	ts.Close()
+}
@@ -0,0 +1,126 @@
+package teststore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestUserSession(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, ts.UpsertUserSession(ctx, user.ID, &storepb.SessionsUserSetting_Session{
+		SessionId:  "active",
+		CreateTime: timestamppb.New(now),
+		ExpireTime: timestamppb.New(now.Add(time.Hour)),
+	}))
+	require.NoError(t, ts.UpsertUserSession(ctx, user.ID, &storepb.SessionsUserSetting_Session{
+		SessionId:  "expired",
+		CreateTime: timestamppb.New(now.Add(-2 * time.Hour)),
+		ExpireTime: timestamppb.New(now.Add(-time.Hour)),
+	}))
+	sessions, err := ts.GetUserSessions(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	session, err := ts.GetUserSession(ctx, user.ID, "expired")
+	require.NoError(t, err)
+	require.Nil(t, session)
+
+	require.NoError(t, ts.TouchUserSession(ctx, user.ID, "active", "10.0.0.1", "Mozilla/5.0"))
+	session, err = ts.GetUserSession(ctx, user.ID, "active")
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	require.Equal(t, "10.0.0.1", session.Ip)
+	require.Equal(t, "Mozilla/5.0", session.UserAgent)
+	require.NotNil(t, session.LastSeenTime)
+
+	require.NoError(t, ts.RemoveUserSession(ctx, user.ID, "active"))
+	sessions, err = ts.GetUserSessions(ctx, user.ID)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+	ts.Close()
+}
+
+func TestArchivedUserSessions(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	require.NoError(t, ts.UpsertUserSession(ctx, user.ID, &storepb.SessionsUserSetting_Session{
+		SessionId:  "active",
+		CreateTime: timestamppb.Now(),
+		ExpireTime: timestamppb.New(time.Now().Add(time.Hour)),
+	}))
+	archived := store.Archived
+	_, err = ts.UpdateUser(ctx, &store.UpdateUser{ID: user.ID, RowStatus: &archived})
+	require.NoError(t, err)
+	sessions, err := ts.GetUserSessions(ctx, user.ID)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+	ts.Close()
+}
+
+func TestConcurrentUserSessionRevokeAndTouch(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	const count = 20
+	for i := 0; i < count; i++ {
+		require.NoError(t, ts.UpsertUserSession(ctx, user.ID, &storepb.SessionsUserSetting_Session{
+			SessionId:  fmt.Sprintf("session-%d", i),
+			CreateTime: timestamppb.Now(),
+			ExpireTime: timestamppb.New(time.Now().Add(time.Hour)),
+		}))
+	}
+
+	// Revoke the even sessions while all of them are being touched from a new IP.
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*count)
+	for i := 0; i < count; i++ {
+		sessionID := fmt.Sprintf("session-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- ts.TouchUserSession(ctx, user.ID, sessionID, "10.0.0.2", "Mozilla/5.0")
+		}()
+		if i%2 == 0 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- ts.RemoveUserSession(ctx, user.ID, sessionID)
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	sessions, err := ts.GetUserSessions(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, count/2)
+	for _, session := range sessions {
+		var i int
+		_, err := fmt.Sscanf(session.SessionId, "session-%d", &i)
+		require.NoError(t, err)
+		require.Equal(t, 1, i%2, "revoked session %s was restored", session.SessionId)
+		require.Equal(t, "10.0.0.2", session.Ip)
+	}
+	ts.Close()
+}
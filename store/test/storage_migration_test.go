@@ -0,0 +1,106 @@
+package teststore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestStorageMigration(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	setStorageType := func(storageType storepb.WorkspaceStorageSetting_StorageType) *storepb.WorkspaceStorageSetting {
+		storageSetting := &storepb.WorkspaceStorageSetting{StorageType: storageType}
+		_, err := ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+			Key:   storepb.WorkspaceSettingKey_STORAGE,
+			Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: storageSetting},
+		})
+		require.NoError(t, err)
+		return storageSetting
+	}
+	migrate := func() *storepb.WorkspaceStorageMigrationSetting {
+		_, err := ts.StartStorageMigration(ctx)
+		require.NoError(t, err)
+		require.NoError(t, ts.RunStorageMigration(ctx))
+		migration, err := ts.GetWorkspaceStorageMigrationSetting(ctx)
+		require.NoError(t, err)
+		return migration
+	}
+
+	storageSetting := setStorageType(storepb.WorkspaceStorageSetting_DATABASE)
+	contents := map[int32][]byte{}
+	for _, contentAddressed := range []bool{false, true} {
+		content := []byte(shortuuid.New())
+		resource := &store.Resource{
+			UID:       shortuuid.New(),
+			CreatorID: user.ID,
+			Filename:  "notes.txt",
+			Blob:      content,
+			Type:      "text/plain",
+			Size:      int64(len(content)),
+		}
+		if contentAddressed {
+			require.NoError(t, ts.PutResourceBlob(ctx, resource, bytes.NewReader(content), storageSetting))
+		}
+		resource, err = ts.CreateResource(ctx, resource)
+		require.NoError(t, err)
+		contents[resource.ID] = content
+	}
+	// External resources have no content to migrate.
+	_, err = ts.CreateResource(ctx, &store.Resource{
+		UID:         shortuuid.New(),
+		CreatorID:   user.ID,
+		Filename:    "link.png",
+		Type:        "image/png",
+		StorageType: storepb.ResourceStorageType_EXTERNAL,
+		Reference:   "https://example.com/link.png",
+	})
+	require.NoError(t, err)
+
+	for _, storageType := range []storepb.WorkspaceStorageSetting_StorageType{storepb.WorkspaceStorageSetting_LOCAL, storepb.WorkspaceStorageSetting_DATABASE} {
+		setStorageType(storageType)
+		migration := migrate()
+		require.Equal(t, storepb.WorkspaceStorageMigrationSetting_COMPLETED, migration.State)
+		require.Equal(t, storageType, migration.TargetStorageType)
+		require.Equal(t, int32(2), migration.Total)
+		require.Equal(t, int32(2), migration.Processed)
+		require.Equal(t, int32(2), migration.Migrated)
+		require.Zero(t, migration.Failed)
+
+		for id, content := range contents {
+			resource, err := ts.GetResource(ctx, &store.FindResource{ID: &id, GetBlob: true})
+			require.NoError(t, err)
+			require.Equal(t, store.ResourceStorageType(storageType), resource.StorageType)
+			read, err := ts.ReadResourceContent(ctx, resource)
+			require.NoError(t, err)
+			require.Equal(t, content, read)
+			reader, err := ts.OpenResourceContent(ctx, resource)
+			require.NoError(t, err)
+			_, err = reader.Seek(1, io.SeekStart)
+			require.NoError(t, err)
+			read, err = io.ReadAll(reader)
+			require.NoError(t, err)
+			require.NoError(t, reader.Close())
+			require.Equal(t, content[1:], read)
+			if storageType == storepb.WorkspaceStorageSetting_LOCAL || resource.Payload.GetContentHash() != "" {
+				require.Nil(t, resource.Blob)
+			}
+		}
+
+		// Migrating again has nothing left to do.
+		migration = migrate()
+		require.Zero(t, migration.Total)
+		require.Zero(t, migration.Processed)
+	}
+	ts.Close()
+}
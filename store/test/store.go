@@ -45,13 +45,20 @@ func resetTestingDB(ctx context.Context, profile *profile.Profile, dbDriver stor
 		DROP TABLE IF EXISTS memo_organizer;
 		DROP TABLE IF EXISTS memo_relation;
 		DROP TABLE IF EXISTS resource;
+		DROP TABLE IF EXISTS resource_blob;
+		DROP TABLE IF EXISTS link_preview;
+		DROP TABLE IF EXISTS ticket_status_history;
 		DROP TABLE IF EXISTS tag;
 		DROP TABLE IF EXISTS activity;
 		DROP TABLE IF EXISTS storage;
 		DROP TABLE IF EXISTS idp;
 		DROP TABLE IF EXISTS inbox;
 		DROP TABLE IF EXISTS webhook;
-		DROP TABLE IF EXISTS reaction;`)
+		DROP TABLE IF EXISTS reaction;
+		DROP TABLE IF EXISTS custom_role;
+		DROP TABLE IF EXISTS user_custom_role;
+		DROP TABLE IF EXISTS project;
+		DROP TABLE IF EXISTS project_member;`)
 		if err != nil {
 			slog.Error("failed to reset testing db", slog.String("error", err.Error()))
 			panic(err)
@@ -66,13 +73,20 @@ func resetTestingDB(ctx context.Context, profile *profile.Profile, dbDriver stor
 		DROP TABLE IF EXISTS memo_organizer CASCADE;
 		DROP TABLE IF EXISTS memo_relation CASCADE;
 		DROP TABLE IF EXISTS resource CASCADE;
+		DROP TABLE IF EXISTS resource_blob CASCADE;
+		DROP TABLE IF EXISTS link_preview CASCADE;
+		DROP TABLE IF EXISTS ticket_status_history CASCADE;
 		DROP TABLE IF EXISTS tag CASCADE;
 		DROP TABLE IF EXISTS activity CASCADE;
 		DROP TABLE IF EXISTS storage CASCADE;
 		DROP TABLE IF EXISTS idp CASCADE;
 		DROP TABLE IF EXISTS inbox CASCADE;
 		DROP TABLE IF EXISTS webhook CASCADE;
-		DROP TABLE IF EXISTS reaction CASCADE;`)
+		DROP TABLE IF EXISTS reaction CASCADE;
+		DROP TABLE IF EXISTS custom_role CASCADE;
+		DROP TABLE IF EXISTS user_custom_role CASCADE;
+		DROP TABLE IF EXISTS project CASCADE;
+		DROP TABLE IF EXISTS project_member CASCADE;`)
 		if err != nil {
 			slog.Error("failed to reset testing db", slog.String("error", err.Error()))
 			panic(err)
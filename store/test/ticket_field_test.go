@@ -0,0 +1,88 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestTicketFields(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	ticket, err := ts.CreateTicket(ctx, &store.Ticket{
+		Title:       "Fields",
+		Description: "/m/fields",
+		Status:      store.TicketStatusOpen,
+		Priority:    store.TicketPriorityMedium,
+		Type:        "STORY",
+		Tags:        []string{},
+		CreatorID:   user.ID,
+		CreatedTs:   1600000000,
+		UpdatedTs:   1600000000,
+		Fields:      map[string]any{"component": "api", "story_points": float64(3)},
+	})
+	require.NoError(t, err)
+	ticket, err = ts.GetTicket(ctx, &store.FindTicket{ID: &ticket.ID})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"component": "api", "story_points": float64(3)}, ticket.Fields)
+
+	ticket, err = ts.UpdateTicket(ctx, &store.UpdateTicket{ID: ticket.ID, Fields: map[string]any{"component": "web"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"component": "web"}, ticket.Fields)
+
+	// The values are kept when the fields are not updated.
+	title := "Renamed"
+	ticket, err = ts.UpdateTicket(ctx, &store.UpdateTicket{ID: ticket.ID, Title: &title})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"component": "web"}, ticket.Fields)
+	ts.Close()
+}
+
+func TestNormalizeTicketFields(t *testing.T) {
+	definitions := []*storepb.TicketField{
+		{Key: "component", Title: "Component", Type: storepb.TicketField_ENUM, Options: []string{"api", "web"}, Required: true},
+		{Key: "story_points", Title: "Story points", Type: storepb.TicketField_NUMBER, TicketTypes: []string{"STORY"}},
+		{Key: "customer", Title: "Customer", Type: storepb.TicketField_TEXT},
+		{Key: "owner", Title: "Owner", Type: storepb.TicketField_USER},
+		{Key: "due", Title: "Due", Type: storepb.TicketField_DATE},
+	}
+	for _, field := range definitions {
+		require.NoError(t, store.ValidateTicketField(field))
+	}
+
+	fields, err := store.NormalizeTicketFields(definitions, "STORY", map[string]any{
+		"component":    "api",
+		"story_points": float64(5),
+		"customer":     "",
+		"owner":        float64(1),
+		"due":          "2024-05-01",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"component": "api", "story_points": float64(5), "owner": int32(1), "due": "2024-05-01"}, fields)
+
+	for _, invalid := range []map[string]any{
+		// The required field is missing.
+		{"customer": "ACME"},
+		{"component": "mobile"},
+		{"component": "api", "owner": 1.5},
+		{"component": "api", "due": "05/01/2024"},
+		{"component": "api", "unknown": "value"},
+	} {
+		_, err := store.NormalizeTicketFields(definitions, "STORY", invalid)
+		require.Error(t, err, invalid)
+	}
+	// The story points do not apply to bugs.
+	_, err = store.NormalizeTicketFields(definitions, "BUG", map[string]any{"component": "api", "story_points": float64(1)})
+	require.Error(t, err)
+
+	require.Error(t, store.ValidateTicketField(&storepb.TicketField{Key: "Bad Key", Title: "Bad", Type: storepb.TicketField_TEXT}))
+	require.Error(t, store.ValidateTicketField(&storepb.TicketField{Key: "empty_enum", Title: "Empty", Type: storepb.TicketField_ENUM}))
+	require.Error(t, store.ValidateTicketField(&storepb.TicketField{Key: "text", Title: "Text", Type: storepb.TicketField_TEXT, Options: []string{"a"}}))
+}
@@ -172,3 +172,63 @@ func TestTicketForeignKeyConstraints(t *testing.T) {
 
 	ts.Close()
 }
+
+func TestBatchTickets(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	ids := []int32{}
+	for _, title := range []string{"First", "Second", "Third"} {
+		ticket, err := ts.CreateTicket(ctx, &store.Ticket{
+			Title:       title,
+			Description: "/m/batch",
+			Status:      store.TicketStatusOpen,
+			Priority:    store.TicketPriorityMedium,
+			Type:        "TASK",
+			Tags:        []string{},
+			CreatorID:   user.ID,
+			CreatedTs:   1600000000,
+			UpdatedTs:   1600000000,
+		})
+		require.NoError(t, err)
+		ids = append(ids, ticket.ID)
+	}
+
+	closed, high := store.TicketStatusClosed, store.TicketPriorityHigh
+	updatedTs := int64(1600000100)
+	tickets, err := ts.BatchUpdateTickets(ctx, []*store.UpdateTicket{
+		{ID: ids[1], Status: &closed, UpdatedTs: &updatedTs},
+		{ID: ids[0], Priority: &high, UpdatedTs: &updatedTs},
+	})
+	require.NoError(t, err)
+	require.Len(t, tickets, 2)
+	require.Equal(t, ids[1], tickets[0].ID)
+	require.Equal(t, store.TicketStatusClosed, tickets[0].Status)
+	require.Equal(t, store.TicketPriorityHigh, tickets[1].Priority)
+	require.Equal(t, updatedTs, tickets[1].UpdatedTs)
+
+	transitions, err := ts.ListTicketStatusTransitions(ctx, &store.FindTicketStatusTransition{TicketID: &ids[1]})
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	require.Equal(t, store.TicketStatusOpen, transitions[1].FromStatus)
+	require.Equal(t, store.TicketStatusClosed, transitions[1].ToStatus)
+
+	// The batch fails as a whole when one of the tickets does not exist.
+	_, err = ts.BatchUpdateTickets(ctx, []*store.UpdateTicket{{ID: ids[2], Priority: &high}, {ID: 99999, Priority: &high}})
+	require.Error(t, err)
+	ticket, err := ts.GetTicket(ctx, &store.FindTicket{ID: &ids[2]})
+	require.NoError(t, err)
+	require.Equal(t, store.TicketPriorityMedium, ticket.Priority)
+
+	require.NoError(t, ts.BatchDeleteTickets(ctx, ids[:2]))
+	list, err := ts.ListTickets(ctx, &store.FindTicket{IDList: ids})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, ids[2], list[0].ID)
+	transitions, err = ts.ListTicketStatusTransitions(ctx, &store.FindTicketStatusTransition{TicketID: &ids[1]})
+	require.NoError(t, err)
+	require.Empty(t, transitions)
+	ts.Close()
+}
@@ -0,0 +1,97 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestUserTOTPStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	userTOTP, err := ts.GetUserTOTP(ctx, user.ID)
+	require.NoError(t, err)
+	require.False(t, userTOTP.Enabled)
+
+	err = ts.UpsertUserTOTP(ctx, user.ID, &storepb.TOTPUserSetting{Secret: "JBSWY3DPEHPK3PXP", Enabled: true, LastUsedStep: 42})
+	require.NoError(t, err)
+	userTOTP, err = ts.GetUserTOTP(ctx, user.ID)
+	require.NoError(t, err)
+	require.True(t, userTOTP.Enabled)
+	require.Equal(t, "JBSWY3DPEHPK3PXP", userTOTP.Secret)
+	require.Equal(t, int64(42), userTOTP.LastUsedStep)
+	ts.Close()
+}
+
+func TestUserRecoveryCodes(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	err = ts.SetUserRecoveryCodes(ctx, user.ID, []string{"abcde-fghjk", "mnpqr-stuvw"})
+	require.NoError(t, err)
+	hashes, err := ts.GetUserRecoveryCodeHashes(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, hashes, 2)
+	require.NotContains(t, hashes, "abcde-fghjk")
+
+	// Codes are matched ignoring case and dashes, and can only be used once.
+	used, err := ts.UseUserRecoveryCode(ctx, user.ID, "ABCDEFGHJK")
+	require.NoError(t, err)
+	require.True(t, used)
+	used, err = ts.UseUserRecoveryCode(ctx, user.ID, "abcde-fghjk")
+	require.NoError(t, err)
+	require.False(t, used)
+	hashes, err = ts.GetUserRecoveryCodeHashes(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, hashes, 1)
+	ts.Close()
+}
+
+func TestTwoFactorEnrollmentRequired(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	host, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	user, err := ts.CreateUser(ctx, &store.User{
+		Username: "member",
+		Role:     store.RoleUser,
+		Email:    "member@test.com",
+	})
+	require.NoError(t, err)
+
+	required, err := ts.IsTwoFactorEnrollmentRequired(ctx, host)
+	require.NoError(t, err)
+	require.False(t, required)
+
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_GENERAL,
+		Value: &storepb.WorkspaceSetting_GeneralSetting{
+			GeneralSetting: &storepb.WorkspaceGeneralSetting{
+				RequireTwoFactorForAdmins: true,
+			},
+		},
+	})
+	require.NoError(t, err)
+	required, err = ts.IsTwoFactorEnrollmentRequired(ctx, host)
+	require.NoError(t, err)
+	require.True(t, required)
+	required, err = ts.IsTwoFactorEnrollmentRequired(ctx, user)
+	require.NoError(t, err)
+	require.False(t, required)
+
+	err = ts.UpsertUserTOTP(ctx, host.ID, &storepb.TOTPUserSetting{Secret: "JBSWY3DPEHPK3PXP", Enabled: true})
+	require.NoError(t, err)
+	required, err = ts.IsTwoFactorEnrollmentRequired(ctx, host)
+	require.NoError(t, err)
+	require.False(t, required)
+	ts.Close()
+}
@@ -29,3 +29,63 @@ func TestWorkspaceSettingV1Store(t *testing.T) {
 	require.Equal(t, workspaceSetting, setting)
 	ts.Close()
 }
+
+func TestWorkspaceRateLimitSetting(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	_, err := ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_RATE_LIMIT,
+		Value: &storepb.WorkspaceSetting_RateLimitSetting{
+			RateLimitSetting: &storepb.WorkspaceRateLimitSetting{
+				RequestsPerMinute: 120,
+			},
+		},
+	})
+	require.NoError(t, err)
+	setting, err := ts.GetWorkspaceRateLimitSetting(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(120), setting.RequestsPerMinute)
+	// Unset limits fall back to the defaults.
+	require.Positive(t, setting.Burst)
+	require.Positive(t, setting.AuthRequestsPerMinute)
+	require.Positive(t, setting.MaxFailedSignInAttempts)
+	require.Positive(t, setting.LockoutDurationSeconds)
+	ts.Close()
+}
+
+func TestWorkspaceSharedTicketViewsSetting(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	setting, err := ts.GetWorkspaceSharedTicketViewsSetting(ctx)
+	require.NoError(t, err)
+	require.Empty(t, setting.Views)
+
+	view := &storepb.TicketView{
+		Id:          "board",
+		Title:       "Board",
+		Filter:      `status != "CLOSED"`,
+		GroupBy:     storepb.TicketView_STATUS,
+		ColumnOrder: []string{"OPEN", "IN_PROGRESS"},
+		WipLimits:   map[string]int32{"IN_PROGRESS": 3},
+		SwimlaneBy:  storepb.TicketView_ASSIGNEE,
+	}
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_SHARED_TICKET_VIEWS,
+		Value: &storepb.WorkspaceSetting_SharedTicketViewsSetting{
+			SharedTicketViewsSetting: &storepb.WorkspaceSharedTicketViewsSetting{Views: []*storepb.TicketView{view}},
+		},
+	})
+	require.NoError(t, err)
+	setting, err = ts.GetWorkspaceSharedTicketViewsSetting(ctx)
+	require.NoError(t, err)
+	require.Len(t, setting.Views, 1)
+	require.Equal(t, int32(3), setting.Views[0].WipLimits["IN_PROGRESS"])
+	require.Equal(t, storepb.TicketView_ASSIGNEE, setting.Views[0].SwimlaneBy)
+
+	// The returned setting is a copy, so editing it does not change the cached one.
+	setting.Views = nil
+	setting, err = ts.GetWorkspaceSharedTicketViewsSetting(ctx)
+	require.NoError(t, err)
+	require.Len(t, setting.Views, 1)
+	ts.Close()
+}
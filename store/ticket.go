@@ -2,7 +2,9 @@ package store
 
 import (
 	"context"
-	"errors"
+	"time"
+
+	"github.com/pkg/errors"
 )
 
 type TicketStatus string
@@ -33,13 +35,23 @@ type Ticket struct {
 	UpdatedTs   int64
 	Type        string
 	Tags        []string
+	// ProjectID is the project of the ticket, nil for workspace-wide tickets.
+	ProjectID *int32
+	// Number is the sequence number of the ticket in its project, assigned on creation unless it is set.
+	Number int32
+	// Fields are the values of the custom fields by field key, see NormalizeTicketFields.
+	Fields map[string]any
 }
 
 type FindTicket struct {
-	ID          *int32
+	ID *int32
+	// IDList filters the tickets by ID when not nil.
+	IDList      []int32
 	CreatorID   *int32
 	Type        *string
 	Description *string
+	ProjectID   *int32
+	Number      *int32
 }
 
 type UpdateTicket struct {
@@ -52,12 +64,28 @@ type UpdateTicket struct {
 	UpdatedTs   *int64
 	Type        *string
 	Tags        []string
+	// Fields replaces the values of the custom fields when it is not nil.
+	Fields map[string]any
 }
 
 type DeleteTicket struct {
 	ID int32
 }
 
+// TicketStatusTransition is a change of the status of a ticket, recorded for the ticket metrics.
+type TicketStatusTransition struct {
+	ID       int32
+	TicketID int32
+	// FromStatus is empty for the status a ticket is created with.
+	FromStatus TicketStatus
+	ToStatus   TicketStatus
+	CreatedTs  int64
+}
+
+type FindTicketStatusTransition struct {
+	TicketID *int32
+}
+
 func (t *Ticket) Validate() error {
 	if t.Title == "" {
 		return errors.New("title is required")
@@ -83,10 +111,24 @@ type TicketStore interface {
 }
 
 func (s *Store) CreateTicket(ctx context.Context, ticket *Ticket) (*Ticket, error) {
-	return s.driver.CreateTicket(ctx, ticket)
+	ticket, err := s.driver.CreateTicket(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.driver.CreateTicketStatusTransition(ctx, &TicketStatusTransition{
+		TicketID:  ticket.ID,
+		ToStatus:  ticket.Status,
+		CreatedTs: ticket.CreatedTs,
+	}); err != nil {
+		return nil, err
+	}
+	return ticket, nil
 }
 
 func (s *Store) ListTickets(ctx context.Context, find *FindTicket) ([]*Ticket, error) {
+	if find.IDList != nil && len(find.IDList) == 0 {
+		return []*Ticket{}, nil
+	}
 	return s.driver.ListTickets(ctx, find)
 }
 
@@ -95,9 +137,105 @@ func (s *Store) GetTicket(ctx context.Context, find *FindTicket) (*Ticket, error
 }
 
 func (s *Store) UpdateTicket(ctx context.Context, update *UpdateTicket) (*Ticket, error) {
-	return s.driver.UpdateTicket(ctx, update)
+	var previousStatus TicketStatus
+	if update.Status != nil {
+		previous, err := s.driver.GetTicket(ctx, &FindTicket{ID: &update.ID})
+		if err != nil {
+			return nil, err
+		}
+		if previous != nil {
+			previousStatus = previous.Status
+		}
+	}
+	ticket, err := s.driver.UpdateTicket(ctx, update)
+	if err != nil {
+		return nil, err
+	}
+	if update.Status != nil && previousStatus != "" && previousStatus != ticket.Status {
+		createdTs := time.Now().Unix()
+		if update.UpdatedTs != nil {
+			createdTs = *update.UpdatedTs
+		}
+		if _, err := s.driver.CreateTicketStatusTransition(ctx, &TicketStatusTransition{
+			TicketID:   ticket.ID,
+			FromStatus: previousStatus,
+			ToStatus:   ticket.Status,
+			CreatedTs:  createdTs,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return ticket, nil
 }
 
 func (s *Store) DeleteTicket(ctx context.Context, delete *DeleteTicket) error {
 	return s.driver.DeleteTicket(ctx, delete)
 }
+
+// BatchUpdateTickets applies the updates in a single transaction, recording the changes of status like
+// UpdateTicket, and returns the updated tickets in the order of the updates.
+func (s *Store) BatchUpdateTickets(ctx context.Context, updates []*UpdateTicket) ([]*Ticket, error) {
+	ids := make([]int32, 0, len(updates))
+	for _, update := range updates {
+		ids = append(ids, update.ID)
+	}
+	previous, err := s.listTicketsByID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := []*TicketStatusTransition{}
+	for _, update := range updates {
+		ticket, ok := previous[update.ID]
+		if !ok {
+			return nil, errors.Errorf("ticket %d not found", update.ID)
+		}
+		if update.Status == nil || *update.Status == ticket.Status {
+			continue
+		}
+		createdTs := time.Now().Unix()
+		if update.UpdatedTs != nil {
+			createdTs = *update.UpdatedTs
+		}
+		transitions = append(transitions, &TicketStatusTransition{
+			TicketID:   ticket.ID,
+			FromStatus: ticket.Status,
+			ToStatus:   *update.Status,
+			CreatedTs:  createdTs,
+		})
+	}
+	if err := s.driver.UpdateTickets(ctx, updates, transitions); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.listTicketsByID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	tickets := make([]*Ticket, 0, len(updates))
+	for _, id := range ids {
+		tickets = append(tickets, updated[id])
+	}
+	return tickets, nil
+}
+
+// BatchDeleteTickets deletes the tickets in a single transaction.
+func (s *Store) BatchDeleteTickets(ctx context.Context, ids []int32) error {
+	return s.driver.DeleteTickets(ctx, ids)
+}
+
+func (s *Store) listTicketsByID(ctx context.Context, ids []int32) (map[int32]*Ticket, error) {
+	list, err := s.ListTickets(ctx, &FindTicket{IDList: ids})
+	if err != nil {
+		return nil, err
+	}
+	tickets := map[int32]*Ticket{}
+	for _, ticket := range list {
+		tickets[ticket.ID] = ticket
+	}
+	return tickets, nil
+}
+
+func (s *Store) ListTicketStatusTransitions(ctx context.Context, find *FindTicketStatusTransition) ([]*TicketStatusTransition, error) {
+	return s.driver.ListTicketStatusTransitions(ctx, find)
+}
@@ -0,0 +1,137 @@
+package store
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// TicketFieldDateLayout is the layout of the values of the DATE fields.
+const TicketFieldDateLayout = "2006-01-02"
+
+var ticketFieldKeyRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// ValidateTicketField validates the definition of a custom field.
+func ValidateTicketField(field *storepb.TicketField) error {
+	if !ticketFieldKeyRegexp.MatchString(field.Key) {
+		return errors.Errorf("invalid key %q, it must start with a lowercase letter followed by lowercase letters, digits or underscores", field.Key)
+	}
+	if field.Title == "" {
+		return errors.New("title is required")
+	}
+	if _, ok := storepb.TicketField_Type_name[int32(field.Type)]; !ok || field.Type == storepb.TicketField_TYPE_UNSPECIFIED {
+		return errors.Errorf("invalid type %v", field.Type)
+	}
+	if field.Type == storepb.TicketField_ENUM {
+		if len(field.Options) == 0 {
+			return errors.New("an enum field requires options")
+		}
+		for i, option := range field.Options {
+			if option == "" {
+				return errors.New("options cannot be empty")
+			}
+			if slices.Contains(field.Options[:i], option) {
+				return errors.Errorf("duplicate option %q", option)
+			}
+		}
+	} else if len(field.Options) > 0 {
+		return errors.New("only an enum field can have options")
+	}
+	return nil
+}
+
+// TicketFieldAppliesTo returns whether the field applies to the tickets of the type.
+func TicketFieldAppliesTo(field *storepb.TicketField, ticketType string) bool {
+	return len(field.TicketTypes) == 0 || slices.Contains(field.TicketTypes, ticketType)
+}
+
+// NormalizeTicketFields validates the values of the custom fields of a ticket of the type against the
+// definitions, and returns them normalized: strings for the TEXT, ENUM and DATE fields, float64 for the
+// NUMBER fields and int32 user ids for the USER fields. Null and empty values are removed.
+func NormalizeTicketFields(definitions []*storepb.TicketField, ticketType string, fields map[string]any) (map[string]any, error) {
+	normalized := map[string]any{}
+	for key, value := range fields {
+		index := slices.IndexFunc(definitions, func(field *storepb.TicketField) bool {
+			return field.Key == key
+		})
+		if index < 0 || !TicketFieldAppliesTo(definitions[index], ticketType) {
+			return nil, errors.Errorf("field %q is not defined for ticket type %q", key, ticketType)
+		}
+		if value == nil || value == "" {
+			continue
+		}
+		normalizedValue, err := normalizeTicketFieldValue(definitions[index], value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value of field %q", key)
+		}
+		normalized[key] = normalizedValue
+	}
+	for _, field := range definitions {
+		if _, ok := normalized[field.Key]; !ok && field.Required && TicketFieldAppliesTo(field, ticketType) {
+			return nil, errors.Errorf("field %q is required", field.Key)
+		}
+	}
+	return normalized, nil
+}
+
+func normalizeTicketFieldValue(field *storepb.TicketField, value any) (any, error) {
+	switch field.Type {
+	case storepb.TicketField_TEXT:
+		text, ok := value.(string)
+		if !ok {
+			return nil, errors.New("expected a string")
+		}
+		return text, nil
+	case storepb.TicketField_NUMBER:
+		number, ok := toFloat64(value)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, errors.New("expected a number")
+		}
+		return number, nil
+	case storepb.TicketField_ENUM:
+		option, ok := value.(string)
+		if !ok || !slices.Contains(field.Options, option) {
+			return nil, errors.Errorf("expected one of %v", field.Options)
+		}
+		return option, nil
+	case storepb.TicketField_USER:
+		number, ok := toFloat64(value)
+		if !ok || number <= 0 || number > math.MaxInt32 || number != math.Trunc(number) {
+			return nil, errors.New("expected a user id")
+		}
+		return int32(number), nil
+	case storepb.TicketField_DATE:
+		date, ok := value.(string)
+		if !ok {
+			return nil, errors.New("expected a date")
+		}
+		if _, err := time.Parse(TicketFieldDateLayout, date); err != nil {
+			return nil, errors.Errorf("expected a date in the %s format", TicketFieldDateLayout)
+		}
+		return date, nil
+	default:
+		return nil, errors.Errorf("unsupported field type %v", field.Type)
+	}
+}
+
+func toFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
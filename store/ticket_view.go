@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// UpdateUserTicketViews calls update with copies of the ticket views of the user and saves the views it
+// returns, unless update fails. The updates of the views of a user are serialized, so update sees the
+// changes of the previous updates.
+func (s *Store) UpdateUserTicketViews(ctx context.Context, userID int32, update func([]*storepb.TicketView) ([]*storepb.TicketView, error)) error {
+	return s.updateUserSetting(ctx, userID, storepb.UserSettingKey_TICKET_VIEWS, func(userSetting *storepb.UserSetting) error {
+		views, err := update(userSetting.GetTicketViews().GetViews())
+		if err != nil {
+			return err
+		}
+		userSetting.Value = &storepb.UserSetting_TicketViews{
+			TicketViews: &storepb.TicketViewsUserSetting{Views: views},
+		}
+		return nil
+	})
+}
+
+// UpdateWorkspaceSharedTicketViews calls update with copies of the ticket views shared with the workspace
+// and saves the views it returns, unless update fails. The updates are serialized like the ones of the
+// views of a user.
+func (s *Store) UpdateWorkspaceSharedTicketViews(ctx context.Context, update func([]*storepb.TicketView) ([]*storepb.TicketView, error)) error {
+	s.sharedTicketViewsMutex.Lock()
+	defer s.sharedTicketViewsMutex.Unlock()
+
+	workspaceSharedTicketViewsSetting, err := s.GetWorkspaceSharedTicketViewsSetting(ctx)
+	if err != nil {
+		return err
+	}
+	views, err := update(workspaceSharedTicketViewsSetting.Views)
+	if err != nil {
+		return err
+	}
+	updated := &storepb.WorkspaceSharedTicketViewsSetting{Views: views}
+	if proto.Equal(workspaceSharedTicketViewsSetting, updated) {
+		return nil
+	}
+	_, err = s.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key:   storepb.WorkspaceSettingKey_SHARED_TICKET_VIEWS,
+		Value: &storepb.WorkspaceSetting_SharedTicketViewsSetting{SharedTicketViewsSetting: updated},
+	})
+	return err
+}
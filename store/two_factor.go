@@ -0,0 +1,145 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// GetUserTOTP returns the TOTP setting of the user, or an empty setting if there is none.
+func (s *Store) GetUserTOTP(ctx context.Context, userID int32) (*storepb.TOTPUserSetting, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_TOTP,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userSetting == nil || userSetting.GetTotp() == nil {
+		return &storepb.TOTPUserSetting{}, nil
+	}
+	return userSetting.GetTotp(), nil
+}
+
+// UpsertUserTOTP saves the TOTP setting of the user.
+func (s *Store) UpsertUserTOTP(ctx context.Context, userID int32, totp *storepb.TOTPUserSetting) error {
+	_, err := s.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSettingKey_TOTP,
+		Value:  &storepb.UserSetting_Totp{Totp: totp},
+	})
+	return err
+}
+
+// UpdateUserTOTP calls update with a copy of the TOTP setting of the user, which is empty if there is none,
+// and saves it. The updates of the TOTP setting of a user are serialized, so that a code can't be used twice
+// by concurrent requests.
+func (s *Store) UpdateUserTOTP(ctx context.Context, userID int32, update func(*storepb.TOTPUserSetting) error) error {
+	return s.updateUserSetting(ctx, userID, storepb.UserSettingKey_TOTP, func(userSetting *storepb.UserSetting) error {
+		totp := userSetting.GetTotp()
+		if totp == nil {
+			totp = &storepb.TOTPUserSetting{}
+		}
+		if err := update(totp); err != nil {
+			return err
+		}
+		// Don't save an empty setting for a user without one.
+		if userSetting.Value != nil || !proto.Equal(totp, &storepb.TOTPUserSetting{}) {
+			userSetting.Value = &storepb.UserSetting_Totp{Totp: totp}
+		}
+		return nil
+	})
+}
+
+// GetUserRecoveryCodeHashes returns the hashes of the unused recovery codes of the user.
+func (s *Store) GetUserRecoveryCodeHashes(ctx context.Context, userID int32) ([]string, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_RECOVERY_CODES,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userSetting == nil {
+		return []string{}, nil
+	}
+	return userSetting.GetRecoveryCodes().GetCodeHashes(), nil
+}
+
+// SetUserRecoveryCodes replaces the recovery codes of the user, only their hashes are stored.
+func (s *Store) SetUserRecoveryCodes(ctx context.Context, userID int32, codes []string) error {
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+	return s.upsertUserRecoveryCodeHashes(ctx, userID, hashes)
+}
+
+// UseUserRecoveryCode consumes the recovery code of the user and reports whether it was valid.
+func (s *Store) UseUserRecoveryCode(ctx context.Context, userID int32, code string) (bool, error) {
+	hashes, err := s.GetUserRecoveryCodeHashes(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	hash := HashRecoveryCode(code)
+	index := slices.IndexFunc(hashes, func(h string) bool {
+		return subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1
+	})
+	if index < 0 {
+		return false, nil
+	}
+	if err := s.upsertUserRecoveryCodeHashes(ctx, userID, slices.Delete(slices.Clone(hashes), index, index+1)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *Store) upsertUserRecoveryCodeHashes(ctx context.Context, userID int32, hashes []string) error {
+	_, err := s.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSettingKey_RECOVERY_CODES,
+		Value: &storepb.UserSetting_RecoveryCodes{
+			RecoveryCodes: &storepb.RecoveryCodesUserSetting{CodeHashes: hashes},
+		},
+	})
+	return err
+}
+
+// HashRecoveryCode returns the hash of the recovery code, ignoring case, spaces and dashes.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsTwoFactorRequired reports whether the workspace requires the user to use two-factor authentication.
+func (s *Store) IsTwoFactorRequired(ctx context.Context, user *User) (bool, error) {
+	if user.Role != RoleHost && user.Role != RoleAdmin {
+		return false, nil
+	}
+	workspaceGeneralSetting, err := s.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return false, err
+	}
+	return workspaceGeneralSetting.RequireTwoFactorForAdmins, nil
+}
+
+// IsTwoFactorEnrollmentRequired reports whether the user must enable two-factor authentication before using the workspace.
+func (s *Store) IsTwoFactorEnrollmentRequired(ctx context.Context, user *User) (bool, error) {
+	required, err := s.IsTwoFactorRequired(ctx, user)
+	if err != nil || !required {
+		return false, err
+	}
+	totp, err := s.GetUserTOTP(ctx, user.ID)
+	if err != nil {
+		return false, err
+	}
+	return !totp.Enabled, nil
+}
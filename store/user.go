@@ -2,6 +2,8 @@ package store
 
 import (
 	"context"
+
+	"github.com/pkg/errors"
 )
 
 // Role is the type of a role.
@@ -28,6 +30,19 @@ func (e Role) String() string {
 	return "USER"
 }
 
+// Rank returns the privilege level of the role, higher for the roles granting more permissions.
+func (e Role) Rank() int {
+	switch e {
+	case RoleHost:
+		return 3
+	case RoleAdmin:
+		return 2
+	case RoleUser:
+		return 1
+	}
+	return 0
+}
+
 const (
 	SystemBotID int32 = 0
 )
@@ -63,6 +78,7 @@ type User struct {
 type UpdateUser struct {
 	ID int32
 
+	CreatedTs    *int64
 	UpdatedTs    *int64
 	RowStatus    *RowStatus
 	Username     *string
@@ -108,6 +124,12 @@ func (s *Store) UpdateUser(ctx context.Context, update *UpdateUser) (*User, erro
 	}
 
 	s.userCache.Set(ctx, string(user.ID), user)
+	// Archived users are signed out of all their sessions.
+	if update.RowStatus != nil && *update.RowStatus == Archived {
+		if err := s.RemoveUserSessions(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
 	return user, nil
 }
 
@@ -149,7 +171,11 @@ func (s *Store) GetUser(ctx context.Context, find *FindUser) (*User, error) {
 	return user, nil
 }
 
+// DeleteUser deletes the user together with their custom role assignments.
 func (s *Store) DeleteUser(ctx context.Context, delete *DeleteUser) error {
+	if err := s.driver.DeleteUserCustomRole(ctx, &DeleteUserCustomRole{UserID: &delete.ID}); err != nil {
+		return errors.Wrap(err, "failed to delete role assignments")
+	}
 	err := s.driver.DeleteUser(ctx, delete)
 	if err != nil {
 		return err
@@ -2,9 +2,12 @@ package store
 
 import (
 	"context"
+	"slices"
+	"sync"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
@@ -20,6 +23,10 @@ type FindUserSetting struct {
 	Key    storepb.UserSettingKey
 }
 
+type DeleteUserSetting struct {
+	UserID int32
+}
+
 func (s *Store) UpsertUserSetting(ctx context.Context, upsert *storepb.UserSetting) (*storepb.UserSetting, error) {
 	userSettingRaw, err := convertUserSettingToRaw(upsert)
 	if err != nil {
@@ -88,6 +95,46 @@ func (s *Store) GetUserSetting(ctx context.Context, find *FindUserSetting) (*sto
 	return userSetting, nil
 }
 
+// DeleteUserSettings deletes all the settings of the user.
+func (s *Store) DeleteUserSettings(ctx context.Context, delete *DeleteUserSetting) error {
+	if err := s.driver.DeleteUserSettings(ctx, delete); err != nil {
+		return err
+	}
+	for key := range storepb.UserSettingKey_name {
+		s.userSettingCache.Delete(ctx, getUserSettingCacheKey(delete.UserID, storepb.UserSettingKey(key).String()))
+	}
+	return nil
+}
+
+// updateUserSetting calls update with a copy of the setting of the user with the key, whose value is nil
+// if there is none, and saves the setting unless it is unchanged. The updates of a setting of a user are
+// serialized, so that concurrent read-modify-writes, such as revoking a session while it is being used,
+// are not lost.
+func (s *Store) updateUserSetting(ctx context.Context, userID int32, key storepb.UserSettingKey, update func(*storepb.UserSetting) error) error {
+	value, _ := s.userSettingMutexes.LoadOrStore(getUserSettingCacheKey(userID, key.String()), &sync.Mutex{})
+	mutex := value.(*sync.Mutex)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{UserID: &userID, Key: key})
+	if err != nil {
+		return err
+	}
+	updated := &storepb.UserSetting{UserId: userID, Key: key}
+	if userSetting != nil {
+		// The setting may be shared with the cache, so update a copy.
+		updated = proto.Clone(userSetting).(*storepb.UserSetting)
+	}
+	if err := update(updated); err != nil {
+		return err
+	}
+	if (userSetting == nil && updated.Value == nil) || (userSetting != nil && proto.Equal(userSetting, updated)) {
+		return nil
+	}
+	_, err = s.UpsertUserSetting(ctx, updated)
+	return err
+}
+
 // GetUserAccessTokens returns the access tokens of the user.
 func (s *Store) GetUserAccessTokens(ctx context.Context, userID int32) ([]*storepb.AccessTokensUserSetting_AccessToken, error) {
 	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
@@ -106,30 +153,14 @@ func (s *Store) GetUserAccessTokens(ctx context.Context, userID int32) ([]*store
 }
 
 // RemoveUserAccessToken remove the access token of the user.
+// The token can be given by its id or by the token itself.
 func (s *Store) RemoveUserAccessToken(ctx context.Context, userID int32, token string) error {
-	oldAccessTokens, err := s.GetUserAccessTokens(ctx, userID)
-	if err != nil {
-		return err
-	}
-
-	newAccessTokens := make([]*storepb.AccessTokensUserSetting_AccessToken, 0, len(oldAccessTokens))
-	for _, t := range oldAccessTokens {
-		if token != t.AccessToken {
-			newAccessTokens = append(newAccessTokens, t)
-		}
-	}
-
-	_, err = s.UpsertUserSetting(ctx, &storepb.UserSetting{
-		UserId: userID,
-		Key:    storepb.UserSettingKey_ACCESS_TOKENS,
-		Value: &storepb.UserSetting_AccessTokens{
-			AccessTokens: &storepb.AccessTokensUserSetting{
-				AccessTokens: newAccessTokens,
-			},
-		},
+	hash := HashAccessToken(token)
+	return s.updateUserAccessTokens(ctx, userID, func(accessTokens []*storepb.AccessTokensUserSetting_AccessToken) []*storepb.AccessTokensUserSetting_AccessToken {
+		return slices.DeleteFunc(accessTokens, func(t *storepb.AccessTokensUserSetting_AccessToken) bool {
+			return token == AccessTokenID(t) || hash == AccessTokenHash(t)
+		})
 	})
-
-	return err
 }
 
 func convertUserSettingFromRaw(raw *UserSetting) (*storepb.UserSetting, error) {
@@ -151,6 +182,30 @@ func convertUserSettingFromRaw(raw *UserSetting) (*storepb.UserSetting, error) {
 			return nil, err
 		}
 		userSetting.Value = &storepb.UserSetting_Shortcuts{Shortcuts: shortcutsUserSetting}
+	case storepb.UserSettingKey_TOTP:
+		totpUserSetting := &storepb.TOTPUserSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(raw.Value), totpUserSetting); err != nil {
+			return nil, err
+		}
+		userSetting.Value = &storepb.UserSetting_Totp{Totp: totpUserSetting}
+	case storepb.UserSettingKey_RECOVERY_CODES:
+		recoveryCodesUserSetting := &storepb.RecoveryCodesUserSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(raw.Value), recoveryCodesUserSetting); err != nil {
+			return nil, err
+		}
+		userSetting.Value = &storepb.UserSetting_RecoveryCodes{RecoveryCodes: recoveryCodesUserSetting}
+	case storepb.UserSettingKey_SESSIONS:
+		sessionsUserSetting := &storepb.SessionsUserSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(raw.Value), sessionsUserSetting); err != nil {
+			return nil, err
+		}
+		userSetting.Value = &storepb.UserSetting_Sessions{Sessions: sessionsUserSetting}
+	case storepb.UserSettingKey_TICKET_VIEWS:
+		ticketViewsUserSetting := &storepb.TicketViewsUserSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(raw.Value), ticketViewsUserSetting); err != nil {
+			return nil, err
+		}
+		userSetting.Value = &storepb.UserSetting_TicketViews{TicketViews: ticketViewsUserSetting}
 	case storepb.UserSettingKey_LOCALE:
 		userSetting.Value = &storepb.UserSetting_Locale{Locale: raw.Value}
 	case storepb.UserSettingKey_APPEARANCE:
@@ -184,6 +239,30 @@ func convertUserSettingToRaw(userSetting *storepb.UserSetting) (*UserSetting, er
 			return nil, err
 		}
 		raw.Value = string(value)
+	case storepb.UserSettingKey_TOTP:
+		value, err := protojson.Marshal(userSetting.GetTotp())
+		if err != nil {
+			return nil, err
+		}
+		raw.Value = string(value)
+	case storepb.UserSettingKey_RECOVERY_CODES:
+		value, err := protojson.Marshal(userSetting.GetRecoveryCodes())
+		if err != nil {
+			return nil, err
+		}
+		raw.Value = string(value)
+	case storepb.UserSettingKey_SESSIONS:
+		value, err := protojson.Marshal(userSetting.GetSessions())
+		if err != nil {
+			return nil, err
+		}
+		raw.Value = string(value)
+	case storepb.UserSettingKey_TICKET_VIEWS:
+		value, err := protojson.Marshal(userSetting.GetTicketViews())
+		if err != nil {
+			return nil, err
+		}
+		raw.Value = string(value)
 	case storepb.UserSettingKey_LOCALE:
 		raw.Value = userSetting.GetLocale()
 	case storepb.UserSettingKey_APPEARANCE:
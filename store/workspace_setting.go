@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
@@ -37,6 +38,14 @@ func (s *Store) UpsertWorkspaceSetting(ctx context.Context, upsert *storepb.Work
 		valueBytes, err = protojson.Marshal(upsert.GetStorageSetting())
 	} else if upsert.Key == storepb.WorkspaceSettingKey_MEMO_RELATED {
 		valueBytes, err = protojson.Marshal(upsert.GetMemoRelatedSetting())
+	} else if upsert.Key == storepb.WorkspaceSettingKey_RATE_LIMIT {
+		valueBytes, err = protojson.Marshal(upsert.GetRateLimitSetting())
+	} else if upsert.Key == storepb.WorkspaceSettingKey_STORAGE_MIGRATION {
+		valueBytes, err = protojson.Marshal(upsert.GetStorageMigrationSetting())
+	} else if upsert.Key == storepb.WorkspaceSettingKey_SHARED_TICKET_VIEWS {
+		valueBytes, err = protojson.Marshal(upsert.GetSharedTicketViewsSetting())
+	} else if upsert.Key == storepb.WorkspaceSettingKey_TICKET_FIELDS {
+		valueBytes, err = protojson.Marshal(upsert.GetTicketFieldsSetting())
 	} else {
 		return nil, errors.Errorf("unsupported workspace setting key: %v", upsert.Key)
 	}
@@ -208,6 +217,101 @@ func (s *Store) GetWorkspaceStorageSetting(ctx context.Context) (*storepb.Worksp
 	return workspaceStorageSetting, nil
 }
 
+const (
+	defaultRequestsPerMinute       = 600
+	defaultRequestBurst            = 100
+	defaultAuthRequestsPerMinute   = 10
+	defaultMaxFailedSignInAttempts = 5
+	defaultLockoutDurationSeconds  = 60
+)
+
+func (s *Store) GetWorkspaceRateLimitSetting(ctx context.Context) (*storepb.WorkspaceRateLimitSetting, error) {
+	workspaceSetting, err := s.GetWorkspaceSetting(ctx, &FindWorkspaceSetting{
+		Name: storepb.WorkspaceSettingKey_RATE_LIMIT.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace rate limit setting")
+	}
+
+	workspaceRateLimitSetting := &storepb.WorkspaceRateLimitSetting{}
+	if workspaceSetting != nil {
+		// The setting is read on every request, so apply the defaults to a copy of the cached one.
+		workspaceRateLimitSetting = proto.Clone(workspaceSetting.GetRateLimitSetting()).(*storepb.WorkspaceRateLimitSetting)
+	}
+	if workspaceRateLimitSetting.RequestsPerMinute <= 0 {
+		workspaceRateLimitSetting.RequestsPerMinute = defaultRequestsPerMinute
+	}
+	if workspaceRateLimitSetting.Burst <= 0 {
+		workspaceRateLimitSetting.Burst = defaultRequestBurst
+	}
+	if workspaceRateLimitSetting.AuthRequestsPerMinute <= 0 {
+		workspaceRateLimitSetting.AuthRequestsPerMinute = defaultAuthRequestsPerMinute
+	}
+	if workspaceRateLimitSetting.MaxFailedSignInAttempts <= 0 {
+		workspaceRateLimitSetting.MaxFailedSignInAttempts = defaultMaxFailedSignInAttempts
+	}
+	if workspaceRateLimitSetting.LockoutDurationSeconds <= 0 {
+		workspaceRateLimitSetting.LockoutDurationSeconds = defaultLockoutDurationSeconds
+	}
+	s.workspaceSettingCache.Set(ctx, storepb.WorkspaceSettingKey_RATE_LIMIT.String(), &storepb.WorkspaceSetting{
+		Key:   storepb.WorkspaceSettingKey_RATE_LIMIT,
+		Value: &storepb.WorkspaceSetting_RateLimitSetting{RateLimitSetting: workspaceRateLimitSetting},
+	})
+	return workspaceRateLimitSetting, nil
+}
+
+// GetWorkspaceStorageMigrationSetting returns the state of the last storage migration. It is empty if
+// no migration has been started.
+func (s *Store) GetWorkspaceStorageMigrationSetting(ctx context.Context) (*storepb.WorkspaceStorageMigrationSetting, error) {
+	workspaceSetting, err := s.GetWorkspaceSetting(ctx, &FindWorkspaceSetting{
+		Name: storepb.WorkspaceSettingKey_STORAGE_MIGRATION.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace storage migration setting")
+	}
+
+	workspaceStorageMigrationSetting := &storepb.WorkspaceStorageMigrationSetting{}
+	if workspaceSetting != nil {
+		// The migration updates its state while others read it, so return a copy of the cached one.
+		workspaceStorageMigrationSetting = proto.Clone(workspaceSetting.GetStorageMigrationSetting()).(*storepb.WorkspaceStorageMigrationSetting)
+	}
+	return workspaceStorageMigrationSetting, nil
+}
+
+// GetWorkspaceSharedTicketViewsSetting returns the ticket views shared with the workspace.
+func (s *Store) GetWorkspaceSharedTicketViewsSetting(ctx context.Context) (*storepb.WorkspaceSharedTicketViewsSetting, error) {
+	workspaceSetting, err := s.GetWorkspaceSetting(ctx, &FindWorkspaceSetting{
+		Name: storepb.WorkspaceSettingKey_SHARED_TICKET_VIEWS.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace shared ticket views setting")
+	}
+
+	workspaceSharedTicketViewsSetting := &storepb.WorkspaceSharedTicketViewsSetting{}
+	if workspaceSetting != nil {
+		// The views are edited in place by the callers, so return a copy of the cached one.
+		workspaceSharedTicketViewsSetting = proto.Clone(workspaceSetting.GetSharedTicketViewsSetting()).(*storepb.WorkspaceSharedTicketViewsSetting)
+	}
+	return workspaceSharedTicketViewsSetting, nil
+}
+
+// GetWorkspaceTicketFieldsSetting returns the definitions of the custom fields of the tickets.
+func (s *Store) GetWorkspaceTicketFieldsSetting(ctx context.Context) (*storepb.WorkspaceTicketFieldsSetting, error) {
+	workspaceSetting, err := s.GetWorkspaceSetting(ctx, &FindWorkspaceSetting{
+		Name: storepb.WorkspaceSettingKey_TICKET_FIELDS.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace ticket fields setting")
+	}
+
+	workspaceTicketFieldsSetting := &storepb.WorkspaceTicketFieldsSetting{}
+	if workspaceSetting != nil {
+		// The definitions are edited in place by the callers, so return a copy of the cached one.
+		workspaceTicketFieldsSetting = proto.Clone(workspaceSetting.GetTicketFieldsSetting()).(*storepb.WorkspaceTicketFieldsSetting)
+	}
+	return workspaceTicketFieldsSetting, nil
+}
+
 func convertWorkspaceSettingFromRaw(workspaceSettingRaw *WorkspaceSetting) (*storepb.WorkspaceSetting, error) {
 	workspaceSetting := &storepb.WorkspaceSetting{
 		Key: storepb.WorkspaceSettingKey(storepb.WorkspaceSettingKey_value[workspaceSettingRaw.Name]),
@@ -237,6 +341,30 @@ func convertWorkspaceSettingFromRaw(workspaceSettingRaw *WorkspaceSetting) (*sto
 			return nil, err
 		}
 		workspaceSetting.Value = &storepb.WorkspaceSetting_MemoRelatedSetting{MemoRelatedSetting: memoRelatedSetting}
+	case storepb.WorkspaceSettingKey_RATE_LIMIT.String():
+		rateLimitSetting := &storepb.WorkspaceRateLimitSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(workspaceSettingRaw.Value), rateLimitSetting); err != nil {
+			return nil, err
+		}
+		workspaceSetting.Value = &storepb.WorkspaceSetting_RateLimitSetting{RateLimitSetting: rateLimitSetting}
+	case storepb.WorkspaceSettingKey_STORAGE_MIGRATION.String():
+		storageMigrationSetting := &storepb.WorkspaceStorageMigrationSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(workspaceSettingRaw.Value), storageMigrationSetting); err != nil {
+			return nil, err
+		}
+		workspaceSetting.Value = &storepb.WorkspaceSetting_StorageMigrationSetting{StorageMigrationSetting: storageMigrationSetting}
+	case storepb.WorkspaceSettingKey_SHARED_TICKET_VIEWS.String():
+		sharedTicketViewsSetting := &storepb.WorkspaceSharedTicketViewsSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(workspaceSettingRaw.Value), sharedTicketViewsSetting); err != nil {
+			return nil, err
+		}
+		workspaceSetting.Value = &storepb.WorkspaceSetting_SharedTicketViewsSetting{SharedTicketViewsSetting: sharedTicketViewsSetting}
+	case storepb.WorkspaceSettingKey_TICKET_FIELDS.String():
+		ticketFieldsSetting := &storepb.WorkspaceTicketFieldsSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(workspaceSettingRaw.Value), ticketFieldsSetting); err != nil {
+			return nil, err
+		}
+		workspaceSetting.Value = &storepb.WorkspaceSetting_TicketFieldsSetting{TicketFieldsSetting: ticketFieldsSetting}
 	default:
 		// Skip unsupported workspace setting key.
 		return nil, nil
@@ -1,6 +1,6 @@
 import { Button, Input, Textarea } from "@mui/joy";
 import { useEffect, useState } from "react";
-import { useParams, useNavigate } from "react-router-dom";
+import { Link, useParams, useNavigate } from "react-router-dom";
 import { useTranslate } from "@/utils/i18n";
 import axios from "axios";
 import { toast } from "react-hot-toast";
@@ -17,6 +17,14 @@ interface Ticket {
     updatedTs: number;
     type: string;
     tags: string[];
+    taskCount: number;
+    completedTaskCount: number;
+}
+
+interface Backlink {
+    name: string;
+    uid: string;
+    snippet: string;
 }
 
 const TicketDetail = () => {
@@ -25,6 +33,7 @@ const TicketDetail = () => {
     const t = useTranslate();
     const [ticket, setTicket] = useState<Ticket | null>(null);
     const [loading, setLoading] = useState(true);
+    const [backlinks, setBacklinks] = useState<Backlink[]>([]);
 
     useEffect(() => {
         const fetchTicket = async () => {
@@ -45,6 +54,17 @@ const TicketDetail = () => {
         }
     }, [id, navigate]);
 
+    useEffect(() => {
+        if (!ticket) {
+            return;
+        }
+        // The memos referencing the ticket, e.g. with #ticket-{id} or a link to the ticket.
+        axios
+            .get<{ memos: Backlink[] }>(`/api/v1/tickets/${ticket.id}/backlinks`)
+            .then(({ data }) => setBacklinks(data.memos || []))
+            .catch((error) => console.error("Failed to fetch ticket backlinks", error));
+    }, [ticket?.id]);
+
     if (loading) {
         return <div className="w-full h-full flex justify-center items-center">Loading...</div>;
     }
@@ -79,6 +99,14 @@ const TicketDetail = () => {
                             <p className="text-sm text-gray-500">Assignee</p>
                             <p className="font-semibold">{ticket.assigneeId || "Unassigned"}</p>
                         </div>
+                        {ticket.taskCount > 0 && (
+                            <div>
+                                <p className="text-sm text-gray-500">Tasks</p>
+                                <p className="font-semibold">
+                                    {ticket.completedTaskCount}/{ticket.taskCount}
+                                </p>
+                            </div>
+                        )}
                     </div>
 
                     <div className="mt-6 w-full">
@@ -87,6 +115,21 @@ const TicketDetail = () => {
                             {ticket.description}
                         </div>
                     </div>
+
+                    {backlinks.length > 0 && (
+                        <div className="mt-6 w-full">
+                            <p className="text-sm text-gray-500 mb-2">Referenced in</p>
+                            <ul className="flex flex-col gap-1">
+                                {backlinks.map((backlink) => (
+                                    <li key={backlink.name}>
+                                        <Link className="text-blue-600 dark:text-blue-400 hover:underline" to={`/m/${backlink.uid}`}>
+                                            {backlink.snippet || backlink.uid}
+                                        </Link>
+                                    </li>
+                                ))}
+                            </ul>
+                        </div>
+                    )}
                 </div>
             </div>
         </section>